@@ -0,0 +1,32 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/pkg/sysinfo"
+)
+
+// collectJSON decodes the options object, collects and encodes the report
+func collectJSON(options string) (string, error) {
+	var opts sysinfo.Options
+	if strings.TrimSpace(options) != "" {
+		decoder := json.NewDecoder(strings.NewReader(options))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&opts); err != nil {
+			return "", fmt.Errorf("invalid options: %w", err)
+		}
+	}
+	// JSON escapes NUL, so the report cannot be cut short as a C string
+	data, err := sysinfo.CollectJSON(opts)
+	return string(data), err
+}
+
+// modulesJSON encodes the module names
+func modulesJSON() (string, error) {
+	data, err := json.Marshal(sysinfo.Modules())
+	return string(data), err
+}
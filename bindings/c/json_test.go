@@ -0,0 +1,42 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCollectJSON(t *testing.T) {
+	out, err := collectJSON(`{"modules": ["memory"], "redact": true}`)
+	if err != nil {
+		t.Fatalf("collectJSON failed: %v", err)
+	}
+	var report map[string]any
+	if err := json.Unmarshal([]byte(out), &report); err != nil || report["memory"] == nil || report["cpu"] != nil {
+		t.Errorf("collectJSON() = %s, %v; expected a report with only memory", out, err)
+	}
+
+	tests := []struct {
+		options   string
+		expectErr string
+	}{
+		{`{"modules": ["floppy"]}`, "floppy"},
+		{`{"module": ["cpu"]}`, "invalid options"},
+		{`["cpu"]`, "invalid options"},
+	}
+	for _, tt := range tests {
+		if _, err := collectJSON(tt.options); err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+			t.Errorf("collectJSON(%s) error = %v, expected %q", tt.options, err, tt.expectErr)
+		}
+	}
+}
+
+func TestModulesJSON(t *testing.T) {
+	out, err := modulesJSON()
+	var modules []string
+	if err != nil || json.Unmarshal([]byte(out), &modules) != nil || len(modules) == 0 {
+		t.Errorf("modulesJSON() = %s, %v; expected a JSON array of names", out, err)
+	}
+}
@@ -0,0 +1,86 @@
+// Command c builds the C bindings of the collector, a shared library Python, Node and other
+// languages can load to collect reports in-process instead of running sysinfo and parsing
+// its output:
+//
+//	go build -buildmode=c-shared -o libsysinfo.so ./bindings/c
+//
+// The build also writes libsysinfo.h declaring the functions below. Strings returned are
+// allocated with malloc and must be released with sysinfo_free. A NULL result means the
+// call failed, and sysinfo_last_error says why
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+var (
+	lastErrorMu sync.Mutex
+	lastError   string
+)
+
+// setLastError records the error of the failed call, or clears it
+func setLastError(err error) {
+	lastErrorMu.Lock()
+	defer lastErrorMu.Unlock()
+	lastError = ""
+	if err != nil {
+		lastError = err.Error()
+	}
+}
+
+// result hands a string to C, recording err and returning NULL on failure
+func result(s string, err error) *C.char {
+	setLastError(err)
+	if err != nil {
+		return nil
+	}
+	return C.CString(s)
+}
+
+// sysinfo_collect_json collects a report and returns it as JSON. options is a JSON object
+// such as {"modules": ["cpu", "memory"], "redact": true, "utc": true}; NULL or "" collects
+// the default modules
+//
+//export sysinfo_collect_json
+func sysinfo_collect_json(options *C.char) *C.char {
+	var opts string
+	if options != nil {
+		opts = C.GoString(options)
+	}
+	return result(collectJSON(opts))
+}
+
+// sysinfo_modules_json returns the module names the modules option accepts, as a JSON array
+//
+//export sysinfo_modules_json
+func sysinfo_modules_json() *C.char {
+	return result(modulesJSON())
+}
+
+// sysinfo_last_error returns the message of the last failed call, or NULL when the last call
+// succeeded. Calls from every thread share it, so read it right after the failure
+//
+//export sysinfo_last_error
+func sysinfo_last_error() *C.char {
+	lastErrorMu.Lock()
+	defer lastErrorMu.Unlock()
+	if lastError == "" {
+		return nil
+	}
+	return C.CString(lastError)
+}
+
+// sysinfo_free releases a string returned by the other functions; NULL is ignored
+//
+//export sysinfo_free
+func sysinfo_free(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
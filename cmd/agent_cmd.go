@@ -0,0 +1,906 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/agent"
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/hotplug"
+	"github.com/mayvqt/sysinfo/internal/netwatch"
+	"github.com/mayvqt/sysinfo/internal/output"
+	"github.com/mayvqt/sysinfo/internal/privdrop"
+	"github.com/mayvqt/sysinfo/internal/scheduler"
+	"github.com/mayvqt/sysinfo/internal/systemd"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// agentMemoryLimit is the agent's soft heap limit, leaving room for the runtime within a 30MB RSS
+const agentMemoryLimit = 20 << 20
+
+var (
+	agentListen   string
+	agentInterval time.Duration
+	agentDBPath   string
+	agentHost     string
+	agentUser     string
+	agentGroup    string
+)
+
+// agentCmd runs a long-lived HTTP agent serving reports and live metrics
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Serve system reports and live metrics over HTTP",
+	Long: `Runs SysInfo as a long-lived HTTP agent.
+
+Open http://<listen>/ in a browser for the built-in dashboard: live CPU,
+memory and network graphs, SMART health badges, SMART history charts and
+alert history from the history database (see 'sysinfo smart analyze').
+
+Endpoints:
+  GET /                            Web dashboard
+  GET /api/report                  Full report as JSON
+  GET /api/events                  Server-sent event stream of live metrics
+  GET /api/events?modules=cpu,memory
+                                   Stream only the listed modules
+  GET /api/smart                   Current SMART health per drive
+  GET /api/history[?device=&period=7d]
+                                   Recorded devices, or one device's readings
+  GET /api/alerts[?period=7d]      Recorded SMART issues, newest first
+  GET /healthz                     Liveness: 503 once a scheduled task is stuck
+  GET /readyz                      Readiness: 503 also when the history database
+                                   is not writable or the alert webhook is
+                                   unreachable
+
+Live modules are cpu, memory, network, gpu and battery. Each sample is sent
+as an event named after its module, every --interval. Network events include
+per-interface throughput measured between samples.
+
+API tokens configured under agent.tokens in the config file restrict each
+consumer to its own modules. Present the token as "Authorization: Bearer
+<token>" or ?token=<token> (open the dashboard as /?token=<token>).
+
+Periodic tasks configured under agent.schedule in the config file run on
+cron expressions while the agent is up:
+  collect         Collect a report and write it to the file/stdout outputs
+  push            Collect a report and send it to the webhook outputs
+  smart_analyze   Analyze SMART data, record history and send alerts
+  disk_usage      Record partition usage and check the agent.disk_growth rules
+  gpu_memory      Record per-process GPU memory and alert on processes leaking it
+  prune           Delete history older than the task's retention (default 90d)
+
+With agent.hotplug.enabled, disks and USB devices being attached or detached
+are logged and recorded in the history database (udev/kernel uevents on
+Linux, WMI polling on Windows); agent.hotplug.alerts sends removals to the
+SMART webhook so a drive dropping off the controller is noticed.
+
+With agent.network.enabled, network links going up or down and addresses
+being added or removed are logged and recorded the same way, as the
+operating system announces them (rtnetlink on Linux, IP Helper notifications
+on Windows, the routing socket on macOS). agent.network.alerts sends an
+alert when an interface goes down agent.network.flap_count times (default 3)
+within agent.network.flap_window (default 10m).
+
+The health endpoints need no token and report the agent's own state as JSON:
+the last successful collection and last failure of each module, each
+scheduled task's last run and whether it has missed two runs in a row, and,
+for /readyz, the dependency checks. Point Kubernetes liveness and readiness
+probes (or a Docker HEALTHCHECK) at them to restart a wedged agent.
+
+Under systemd (Type=notify) the agent reports readiness once listening and,
+with WatchdogSec= set, sends watchdog pings carrying its last collection and
+last alert as the unit's status line.
+
+Started as root with --user (or agent.user), the agent binds its address and
+then re-executes itself as that user, keeping only the capabilities listed
+under agent.capabilities (default CAP_SYS_RAWIO for SMART). Linux only.
+
+Examples:
+  sysinfo agent                            # Listen on 127.0.0.1:8090
+  sysinfo agent --listen :8090 -i 1s       # All interfaces, 1s updates
+  curl -N localhost:8090/api/events?modules=cpu`,
+	RunE: runAgent,
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+
+	agentCmd.Flags().StringVarP(&agentListen, "listen", "l", "127.0.0.1:8090", "Address to listen on")
+	agentCmd.Flags().DurationVarP(&agentInterval, "interval", "i", 2*time.Second, "Live metric sampling interval")
+	agentCmd.Flags().StringVar(&agentHost, "host", "", "Host whose SMART history the dashboard shows (default: this machine's hostname)")
+	agentCmd.Flags().StringVar(&agentDBPath, "db", "", "SMART history database for dashboard charts (default: same as 'smart' commands)")
+	agentCmd.Flags().StringVar(&agentUser, "user", "", "Drop root privileges to this user after binding (Linux)")
+	agentCmd.Flags().StringVar(&agentGroup, "group", "", "Group to run as with --user (default: the user's primary group)")
+	agentCmd.Flags().StringVar(&simulateSpec, "simulate", "", "Inject synthetic SMART data into smart_analyze, e.g. smart-failure=/dev/sda,temp=85 (for rehearsing alerts)")
+	agentCmd.Flags().MarkHidden("simulate")
+}
+
+func runAgent(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serveAgent(ctx, nil)
+}
+
+// serveAgent runs the agent until ctx is cancelled; ready, when set, is called once it is listening
+// It is shared by the agent command and the Windows service
+func serveAgent(ctx context.Context, ready func()) error {
+	if agentInterval <= 0 {
+		return fmt.Errorf("invalid interval: %s", agentInterval)
+	}
+	if err := loadSimulation(); err != nil {
+		return err
+	}
+
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	agentConfig := config.NewConfig()
+	agentConfig.UTC = cfg.UTC
+	agentConfig.TimestampFormat = cfg.TimestampFormat
+	agentConfig.MergeWithFileConfig(fileConfig)
+	if err := utils.ValidateTimestampFormat(agentConfig.TimestampFormat); err != nil {
+		return err
+	}
+	if err := analyzer.ValidHealthWeights(agentConfig.HealthWeights); err != nil {
+		return fmt.Errorf("invalid health configuration: %w", err)
+	}
+
+	dropOptions := agentDropOptions(fileConfig)
+	if dropOptions.User != "" && !privdrop.IsChild() {
+		return runAgentPrivileged(dropOptions)
+	}
+
+	server := agent.New(agentConfig, agentInterval)
+	if err := server.SetTokens(fileConfig.Agent.Tokens); err != nil {
+		return fmt.Errorf("invalid agent configuration: %w", err)
+	}
+	if err := server.SetBuffers(fileConfig.Agent.Buffers); err != nil {
+		return fmt.Errorf("invalid agent configuration: %w", err)
+	}
+
+	// The dashboard still serves live data when the history database is unavailable,
+	// though the agent is then not ready
+	health := server.Health()
+	db, err := openAgentHistory(fileConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: history disabled: %v\n", err)
+		openErr := fmt.Errorf("history disabled: %w", err)
+		health.AddCheck("database", func(context.Context) error { return openErr })
+	} else {
+		defer db.Close()
+		if agentHost != "" {
+			db.SetHost(agentHost)
+		}
+		recordBoot(db)
+		server.SetHistory(db)
+		health.AddCheck("database", db.Writable)
+	}
+	if webhookURL := fileConfig.SMART.WebhookURL; webhookURL != "" {
+		health.AddCheck("alerts", func(ctx context.Context) error {
+			return analyzer.CheckWebhook(ctx, webhookURL)
+		})
+	}
+
+	status := &agentStatus{listen: agentListen, health: health}
+	schedule, err := buildAgentSchedule(fileConfig.Agent.Schedule, agentConfig, fileConfig, db, status)
+	if err != nil {
+		return fmt.Errorf("invalid agent schedule: %w", err)
+	}
+
+	var flaps *netwatch.FlapDetector
+	if fileConfig.Agent.Network.Enabled {
+		if flaps, err = networkFlapDetector(fileConfig); err != nil {
+			return fmt.Errorf("invalid agent network configuration: %w", err)
+		}
+	}
+
+	// Keep the heap small over week-long runs unless GOMEMLIMIT is set explicitly
+	if os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(agentMemoryLimit)
+	}
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	listener, err := agentListener()
+	if err != nil {
+		return err
+	}
+
+	// Report readiness and keep the systemd watchdog fed (no-ops outside systemd), then tell the caller
+	server.SetReadyFunc(func() {
+		if _, err := systemd.Notify("READY=1\n" + systemd.Status(status.String())); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if ready != nil {
+			ready()
+		}
+	})
+	go func() {
+		if err := systemd.Watchdog(ctx, status.String); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: systemd watchdog stopped: %v\n", err)
+		}
+	}()
+
+	// Wait for in-flight tasks on shutdown so none is cut off mid-write to the history database
+	scheduleDone := make(chan struct{})
+	go func() {
+		defer close(scheduleDone)
+		if schedule != nil {
+			schedule.Run(ctx)
+		}
+	}()
+
+	hotplugDone := make(chan struct{})
+	go func() {
+		defer close(hotplugDone)
+		if fileConfig.Agent.Hotplug.Enabled {
+			watchDevices(ctx, fileConfig, db)
+		}
+	}()
+
+	networkDone := make(chan struct{})
+	go func() {
+		defer close(networkDone)
+		if fileConfig.Agent.Network.Enabled {
+			watchNetworkEvents(ctx, fileConfig, db, flaps)
+		}
+	}()
+
+	fmt.Fprintf(os.Stderr, "SysInfo agent listening on http://%s (Ctrl+C to stop)\n", agentListen)
+	err = server.Serve(ctx, listener)
+	stop()
+	_, _ = systemd.Notify("STOPPING=1")
+	<-scheduleDone
+	<-hotplugDone
+	<-networkDone
+	return err
+}
+
+// watchDevices logs disk and USB hot-swap events, records them in the history database
+// and, with agent.hotplug.alerts, sends removals as alerts until ctx is cancelled
+func watchDevices(ctx context.Context, fileConfig *config.FileConfig, db *analyzer.HistoryDB) {
+	var alertMgr *analyzer.AlertManager
+	if fileConfig.Agent.Hotplug.Alerts {
+		alertMgr = createAlertManager(fileConfig, db)
+	}
+
+	events := make(chan hotplug.Event, 16)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- hotplug.Watch(ctx, events)
+	}()
+
+	for {
+		select {
+		case err := <-watchErr:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: hot-swap events disabled: %v\n", err)
+			}
+			return
+		case event := <-events:
+			fmt.Fprintf(os.Stderr, "%s: %s\n", historyTime(event.Time), describeDeviceEvent(analyzer.DeviceEvent(event)))
+			if db != nil {
+				if err := db.RecordDeviceEvent(analyzer.DeviceEvent(event)); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to record device event: %v\n", err)
+				}
+			}
+			if alertMgr != nil {
+				if err := alertMgr.Send(deviceEventAlert(event)); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// describeDeviceEvent renders a recorded event, e.g. "disk /dev/sdc removed (ST8000NM000A, serial ZA1B2C3D)"
+// or "network eth0 link down"
+func describeDeviceEvent(event analyzer.DeviceEvent) string {
+	if event.Kind == netwatch.Kind {
+		return describeNetworkEvent(networkEvent(event))
+	}
+
+	verb := "added"
+	if event.Action == hotplug.ActionRemove {
+		verb = "removed"
+	}
+	text := fmt.Sprintf("%s %s %s", event.Kind, event.Device, verb)
+
+	var details []string
+	if event.Description != "" {
+		details = append(details, event.Description)
+	}
+	if event.Serial != "" {
+		details = append(details, "serial "+event.Serial)
+	}
+	if len(details) > 0 {
+		text += " (" + strings.Join(details, ", ") + ")"
+	}
+	return text
+}
+
+// deviceEventAlert rates a disk dropping off as critical, a USB device leaving as a warning
+// and additions as informational
+func deviceEventAlert(event hotplug.Event) analyzer.Alert {
+	level := analyzer.AlertInfo
+	title := "Device Added: %s"
+	if event.Action == hotplug.ActionRemove {
+		level = analyzer.AlertWarning
+		if event.Kind == hotplug.KindDisk {
+			level = analyzer.AlertCritical
+		}
+		title = "Device Removed: %s"
+	}
+
+	return analyzer.Alert{
+		Level:       level,
+		Device:      event.Device,
+		Title:       fmt.Sprintf(title, event.Device),
+		Description: describeDeviceEvent(analyzer.DeviceEvent(event)),
+		Timestamp:   event.Time,
+		Data: map[string]interface{}{
+			"action":      event.Action,
+			"kind":        event.Kind,
+			"description": event.Description,
+			"serial":      event.Serial,
+		},
+	}
+}
+
+// Defaults for agent.network flap detection
+const (
+	defaultFlapCount  = 3
+	defaultFlapWindow = "10m"
+)
+
+// networkFlapDetector builds the flap detector from agent.network
+func networkFlapDetector(fileConfig *config.FileConfig) (*netwatch.FlapDetector, error) {
+	count := fileConfig.Agent.Network.FlapCount
+	if count == 0 {
+		count = defaultFlapCount
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("invalid flap_count %d", count)
+	}
+	window := fileConfig.Agent.Network.FlapWindow
+	if window == "" {
+		window = defaultFlapWindow
+	}
+	duration, err := utils.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid flap_window %q", window)
+	}
+	return netwatch.NewFlapDetector(count, duration), nil
+}
+
+// watchNetworkEvents logs link and address changes, records them in the history database
+// and, with agent.network.alerts, sends flapping interfaces as alerts until ctx is cancelled
+func watchNetworkEvents(ctx context.Context, fileConfig *config.FileConfig, db *analyzer.HistoryDB, flaps *netwatch.FlapDetector) {
+	var alertMgr *analyzer.AlertManager
+	if fileConfig.Agent.Network.Alerts {
+		alertMgr = createAlertManager(fileConfig, db)
+	}
+
+	events := make(chan netwatch.Event, 16)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- netwatch.Watch(ctx, events)
+	}()
+
+	for {
+		select {
+		case err := <-watchErr:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: network events disabled: %v\n", err)
+			}
+			return
+		case event := <-events:
+			recorded := analyzer.DeviceEvent{
+				Time:        event.Time,
+				Action:      event.Action,
+				Kind:        netwatch.Kind,
+				Device:      event.Interface,
+				Description: event.Address,
+			}
+			fmt.Fprintf(os.Stderr, "%s: %s\n", historyTime(event.Time), describeDeviceEvent(recorded))
+			if db != nil {
+				if err := db.RecordDeviceEvent(recorded); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to record network event: %v\n", err)
+				}
+			}
+			downs, flapping := flaps.Observe(event)
+			if flapping && alertMgr != nil {
+				if err := alertMgr.Send(flappingAlert(event, downs, fileConfig.Agent.Network.FlapWindow)); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// networkEvent reads a recorded network event back
+func networkEvent(event analyzer.DeviceEvent) netwatch.Event {
+	return netwatch.Event{Time: event.Time, Interface: event.Device, Action: event.Action, Address: event.Description}
+}
+
+// describeNetworkEvent renders a network event, e.g. "network eth0 address 192.0.2.10/24 added"
+func describeNetworkEvent(event netwatch.Event) string {
+	switch event.Action {
+	case netwatch.LinkUp:
+		return fmt.Sprintf("network %s link up", event.Interface)
+	case netwatch.LinkDown:
+		return fmt.Sprintf("network %s link down", event.Interface)
+	case netwatch.AddrAdd:
+		return fmt.Sprintf("network %s address %s added", event.Interface, event.Address)
+	case netwatch.AddrRemove:
+		return fmt.Sprintf("network %s address %s removed", event.Interface, event.Address)
+	default:
+		return fmt.Sprintf("network %s %s", event.Interface, event.Action)
+	}
+}
+
+// flappingAlert warns about an interface whose link keeps dropping
+func flappingAlert(event netwatch.Event, downs int, window string) analyzer.Alert {
+	if window == "" {
+		window = defaultFlapWindow
+	}
+	return analyzer.Alert{
+		Level:       analyzer.AlertWarning,
+		Device:      event.Interface,
+		Title:       fmt.Sprintf("Interface Flapping: %s", event.Interface),
+		Description: fmt.Sprintf("%s went down %d times in %s", event.Interface, downs, window),
+		Timestamp:   event.Time,
+		Data: map[string]interface{}{
+			"interface": event.Interface,
+			"downs":     downs,
+			"window":    window,
+		},
+	}
+}
+
+// defaultPruneRetention is how much history a prune task keeps unless it sets a retention
+const defaultPruneRetention = "90d"
+
+// buildAgentSchedule creates the scheduler for the configured periodic tasks, or nil when there are none
+// db may be nil when the history database is unavailable; tasks that need it are then rejected
+func buildAgentSchedule(entries []config.ScheduledTask, agentConfig *config.Config, fileConfig *config.FileConfig, db *analyzer.HistoryDB, status *agentStatus) (*scheduler.Scheduler, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	tasks := make([]scheduler.Task, 0, len(entries))
+	for i, entry := range entries {
+		schedule, err := scheduler.Parse(entry.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("task %d (%s): %w", i+1, entry.Task, err)
+		}
+		run, err := agentTask(entry, agentConfig, fileConfig, db, status)
+		if err != nil {
+			return nil, fmt.Errorf("task %d (%s): %w", i+1, entry.Task, err)
+		}
+		tasks = append(tasks, scheduler.Task{Name: entry.Task, Schedule: schedule, Run: status.track(entry.Task, schedule, run)})
+	}
+
+	return scheduler.New(tasks...), nil
+}
+
+// agentTask returns the function that runs one scheduled task
+func agentTask(entry config.ScheduledTask, agentConfig *config.Config, fileConfig *config.FileConfig, db *analyzer.HistoryDB, status *agentStatus) (func(context.Context) error, error) {
+	switch entry.Task {
+	case "collect":
+		return reportTask(agentConfig, false, status)
+	case "push":
+		return reportTask(agentConfig, true, status)
+	case "smart_analyze":
+		if db == nil {
+			return nil, errors.New("requires the history database")
+		}
+		smartAnalyzer := createAnalyzer(fileConfig)
+		var alertMgr *analyzer.AlertManager
+		if fileConfig.SMART.WebhookURL != "" {
+			alertMgr = createAlertManager(fileConfig, db)
+		}
+		// Polling every few minutes would keep archival disks from ever spinning down
+		skipStandby := fileConfig.SMART.SkipStandby
+		return func(context.Context) error {
+			drives, standby, err := pollSMART(skipStandby)
+			status.moduleCollected("smart", err)
+			if err != nil {
+				return err
+			}
+			status.collected(time.Now())
+			recordSkips(db, standby)
+			for i := range drives {
+				smart := &drives[i]
+				analyzeAndRecord(db, smartAnalyzer, alertMgr, smart)
+				if alertMgr != nil {
+					if at, ok := alertMgr.GetLastAlertTime(smart.Device); ok {
+						status.alerted(smart.Device, at)
+					}
+				}
+			}
+			return nil
+		}, nil
+	case "disk_usage":
+		if db == nil {
+			return nil, errors.New("requires the history database")
+		}
+		rules, err := diskGrowthRules(fileConfig.Agent.DiskGrowth)
+		if err != nil {
+			return nil, err
+		}
+		var alertMgr *analyzer.AlertManager
+		if len(rules) > 0 && fileConfig.SMART.WebhookURL != "" {
+			alertMgr = createAlertManager(fileConfig, db)
+		}
+		return diskUsageTask(db, rules, alertMgr, status), nil
+	case "gpu_memory":
+		if db == nil {
+			return nil, errors.New("requires the history database")
+		}
+		rule, err := vramLeakRule(fileConfig.Agent.VRAMLeak)
+		if err != nil {
+			return nil, err
+		}
+		var alertMgr *analyzer.AlertManager
+		if fileConfig.SMART.WebhookURL != "" {
+			alertMgr = createAlertManager(fileConfig, db)
+		}
+		return gpuMemoryTask(db, rule, alertMgr, status), nil
+	case "prune":
+		if db == nil {
+			return nil, errors.New("requires the history database")
+		}
+		retention := entry.Retention
+		if retention == "" {
+			retention = defaultPruneRetention
+		}
+		olderThan, err := utils.ParseDuration(retention)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention: %w", err)
+		}
+		return func(context.Context) error {
+			return db.CleanOldRecords(olderThan)
+		}, nil
+	case "":
+		return nil, errors.New("missing task name")
+	default:
+		return nil, errors.New("unknown task (expected collect, push, smart_analyze, disk_usage, gpu_memory or prune)")
+	}
+}
+
+// diskGrowthRules parses agent.disk_growth
+func diskGrowthRules(entries []config.DiskGrowthRule) ([]analyzer.GrowthRule, error) {
+	rules := make([]analyzer.GrowthRule, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Increase <= 0 || entry.Increase > 100 {
+			return nil, fmt.Errorf("disk_growth rule %d: invalid increase %g (expected percentage points above 0)", i+1, entry.Increase)
+		}
+		window, err := utils.ParseDuration(entry.Window)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("disk_growth rule %d: invalid window %q", i+1, entry.Window)
+		}
+		level := analyzer.AlertLevel(strings.ToUpper(entry.Level))
+		if level == "" {
+			level = analyzer.AlertWarning
+		}
+		if !analyzer.ValidAlertLevel(level) {
+			return nil, fmt.Errorf("disk_growth rule %d: invalid level %q", i+1, entry.Level)
+		}
+		if _, err := path.Match(entry.Mount, ""); err != nil {
+			return nil, fmt.Errorf("disk_growth rule %d: invalid mount pattern %q", i+1, entry.Mount)
+		}
+		rules = append(rules, analyzer.GrowthRule{Mount: entry.Mount, Increase: entry.Increase, Window: window, Level: level})
+	}
+	return rules, nil
+}
+
+// diskUsageTask records the usage of every partition and checks it against the growth rules.
+// A partition matching a rule is reported once per rule window, however long it keeps growing
+func diskUsageTask(db *analyzer.HistoryDB, rules []analyzer.GrowthRule, alertMgr *analyzer.AlertManager, status *agentStatus) func(context.Context) error {
+	lastAlerts := make(map[string]time.Time) // rule index and mount point -> last alert
+	return func(context.Context) error {
+		data, err := collector.CollectDisk(false)
+		status.moduleCollected("disk", err)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		status.collected(now)
+
+		for _, growth := range checkDiskGrowth(db, rules, data.Partitions, now) {
+			key := fmt.Sprintf("%d %s", slices.Index(rules, growth.Rule), growth.Partition.MountPoint)
+			if last, ok := lastAlerts[key]; ok && now.Sub(last) < growth.Rule.Window {
+				continue
+			}
+			lastAlerts[key] = now
+
+			alert := analyzer.GrowthAlert(growth, now)
+			fmt.Fprintf(os.Stderr, "%s: %s\n", historyTime(now), alert.Description)
+			if alertMgr != nil {
+				if err := alertMgr.Send(alert); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				} else {
+					status.alerted(growth.Partition.MountPoint, now)
+				}
+			}
+		}
+		return db.RecordDiskUsage(data.Partitions, now)
+	}
+}
+
+// checkDiskGrowth returns the partitions whose usage rose faster than a rule allows,
+// measured against the usage recorded before now
+func checkDiskGrowth(db *analyzer.HistoryDB, rules []analyzer.GrowthRule, partitions []types.PartitionInfo, now time.Time) []*analyzer.DiskGrowth {
+	var growths []*analyzer.DiskGrowth
+	for _, partition := range partitions {
+		for _, rule := range rules {
+			if !rule.Matches(partition.MountPoint) {
+				continue
+			}
+			samples, err := db.GetDiskUsage(partition.MountPoint, now.Add(-rule.Window))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to read disk usage history: %v\n", err)
+				return growths
+			}
+			if growth := analyzer.CheckGrowth(rule, partition, samples, now); growth != nil {
+				growths = append(growths, growth)
+			}
+		}
+	}
+	return growths
+}
+
+// vramLeakRule parses agent.vram_leak, filling in the defaults
+func vramLeakRule(check config.VRAMLeakCheck) (analyzer.LeakRule, error) {
+	rule := analyzer.LeakRule{Window: time.Hour, Samples: 6, MinIncrease: 512 << 20}
+	if check.Window != "" {
+		window, err := utils.ParseDuration(check.Window)
+		if err != nil || window <= 0 {
+			return rule, fmt.Errorf("vram_leak: invalid window %q", check.Window)
+		}
+		rule.Window = window
+	}
+	if check.Samples < 0 || check.Samples == 1 {
+		return rule, fmt.Errorf("vram_leak: invalid samples %d (expected 2 or more)", check.Samples)
+	} else if check.Samples > 0 {
+		rule.Samples = check.Samples
+	}
+	if check.MinIncreaseMB < 0 {
+		return rule, fmt.Errorf("vram_leak: invalid min_increase_mb %d", check.MinIncreaseMB)
+	} else if check.MinIncreaseMB > 0 {
+		rule.MinIncrease = uint64(check.MinIncreaseMB) << 20
+	}
+	return rule, nil
+}
+
+// gpuMemoryTask records the GPU memory of every process and checks it for leaks. A process
+// is reported once per rule window, however long it keeps leaking
+func gpuMemoryTask(db *analyzer.HistoryDB, rule analyzer.LeakRule, alertMgr *analyzer.AlertManager, status *agentStatus) func(context.Context) error {
+	lastAlerts := make(map[string]time.Time) // GPU, PID and process -> last alert
+	return func(context.Context) error {
+		data, err := collector.CollectGPU()
+		status.moduleCollected("gpu", err)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		status.collected(now)
+
+		for _, leak := range checkVRAMLeaks(db, rule, data.GPUs, now) {
+			key := fmt.Sprintf("%s %d %s", analyzer.GPUKey(leak.GPU), leak.Process.PID, leak.Process.Name)
+			if last, ok := lastAlerts[key]; ok && now.Sub(last) < rule.Window {
+				continue
+			}
+			lastAlerts[key] = now
+
+			alert := analyzer.VRAMLeakAlert(leak, now)
+			fmt.Fprintf(os.Stderr, "%s: %s\n", historyTime(now), alert.Description)
+			if alertMgr != nil {
+				if err := alertMgr.Send(alert); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				} else {
+					status.alerted(alert.Device, now)
+				}
+			}
+		}
+		return db.RecordGPUMemory(data.GPUs, now)
+	}
+}
+
+// checkVRAMLeaks returns the processes whose GPU memory kept growing, measured against the
+// readings recorded before now
+func checkVRAMLeaks(db *analyzer.HistoryDB, rule analyzer.LeakRule, gpus []types.GPUInfo, now time.Time) []*analyzer.VRAMLeak {
+	var leaks []*analyzer.VRAMLeak
+	for _, gpu := range gpus {
+		for _, process := range gpu.Processes {
+			samples, err := db.GetGPUMemory(analyzer.GPUKey(gpu), process.PID, now.Add(-rule.Window))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to read GPU memory history: %v\n", err)
+				return leaks
+			}
+			if leak := analyzer.CheckVRAMLeak(rule, gpu, process, samples, now); leak != nil {
+				leaks = append(leaks, leak)
+			}
+		}
+	}
+	return leaks
+}
+
+// reportTask collects a report and writes it to the webhook, scrutiny and homeassistant outputs, or to all other outputs
+// Sinks are built once so delta webhooks keep their acknowledged baseline between runs
+func reportTask(agentConfig *config.Config, push bool, status *agentStatus) (func(context.Context) error, error) {
+	all, err := output.Build(agentConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var sinks []output.Sink
+	for _, sink := range all {
+		if output.IsPush(sink) == push {
+			sinks = append(sinks, sink)
+		}
+	}
+	if len(sinks) == 0 {
+		if push {
+			return nil, errors.New("no webhook, scrutiny or homeassistant outputs configured")
+		}
+		return nil, errors.New("no file or stdout outputs configured")
+	}
+
+	return func(context.Context) error {
+		info, err := collector.Collect(agentConfig)
+		status.reportCollected(agentConfig, info, err)
+		if err != nil {
+			return fmt.Errorf("failed to collect system information: %w", err)
+		}
+		status.collected(time.Now())
+		if agentConfig.UTC {
+			collector.UseUTC(info)
+		}
+		info.TimestampFormat = agentConfig.TimestampFormat
+		if agentConfig.Redact {
+			collector.Redact(info)
+		}
+		return output.WriteAll(sinks, info, agentConfig.Verbose)
+	}, nil
+}
+
+// agentDropOptions returns the identity to serve as, flags taking precedence over the config file
+func agentDropOptions(fileConfig *config.FileConfig) privdrop.Options {
+	opts := privdrop.Options{
+		User:         fileConfig.Agent.User,
+		Group:        fileConfig.Agent.Group,
+		Capabilities: fileConfig.Agent.Capabilities,
+	}
+	if agentUser != "" {
+		opts.User = agentUser
+	}
+	if agentGroup != "" {
+		opts.Group = agentGroup
+	}
+	return opts
+}
+
+// runAgentPrivileged binds the listen address as root and hands it to an unprivileged copy of the agent
+func runAgentPrivileged(opts privdrop.Options) error {
+	listener, err := net.Listen("tcp", agentListen)
+	if err != nil {
+		return err
+	}
+	file, err := listener.(*net.TCPListener).File()
+	listener.Close()
+	if err != nil {
+		return fmt.Errorf("failed to pass listener: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Dropping privileges to user %s\n", opts.User)
+	return privdrop.Run(opts, file)
+}
+
+// agentListener binds the listen address, or adopts the one bound by the privileged parent
+func agentListener() (net.Listener, error) {
+	if !privdrop.IsChild() {
+		return net.Listen("tcp", agentListen)
+	}
+	file := privdrop.InheritedFile(0, "listener")
+	defer file.Close()
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adopt inherited listener: %w", err)
+	}
+	return listener, nil
+}
+
+// openAgentHistory opens the SMART history database shared with the smart commands
+func openAgentHistory(fileConfig *config.FileConfig) (*analyzer.HistoryDB, error) {
+	dbPath, err := resolveSMARTDBPath(agentDBPath, fileConfig)
+	if err != nil {
+		return nil, err
+	}
+	return openHistoryDB(dbPath)
+}
+
+// agentStatus tracks recent agent activity for the systemd status line and, when health is
+// set, the health probes
+type agentStatus struct {
+	mu             sync.Mutex
+	listen         string
+	lastCollection time.Time
+	lastAlert      time.Time
+	alertDevice    string
+
+	health *agent.Health
+}
+
+// track wraps a scheduled task's run so the health probes notice when it stops finishing
+func (s *agentStatus) track(name string, schedule scheduler.Schedule, run func(context.Context) error) func(context.Context) error {
+	if s.health == nil {
+		return run
+	}
+	return s.health.Track(name, schedule, run)
+}
+
+// reportCollected records the outcome of a scheduled report collection per module
+func (s *agentStatus) reportCollected(cfg *config.Config, info *types.SystemInfo, err error) {
+	if s.health != nil {
+		s.health.RecordCollection(cfg, info, err)
+	}
+}
+
+// moduleCollected records the outcome of collecting a single module
+func (s *agentStatus) moduleCollected(module string, err error) {
+	if s.health != nil {
+		s.health.RecordModule(module, err)
+	}
+}
+
+// collected records a completed scheduled collection
+func (s *agentStatus) collected(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCollection = at
+}
+
+// alerted records the most recent alert sent for a device
+func (s *agentStatus) alerted(device string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if at.After(s.lastAlert) {
+		s.lastAlert = at
+		s.alertDevice = device
+	}
+}
+
+// String summarizes the status, e.g. "Listening on :8090, last collection 2025-01-15 14:05, last alert none"
+func (s *agentStatus) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collection := "never"
+	if !s.lastCollection.IsZero() {
+		collection = historyTime(s.lastCollection)
+	}
+	alert := "none"
+	if !s.lastAlert.IsZero() {
+		alert = fmt.Sprintf("%s at %s", s.alertDevice, historyTime(s.lastAlert))
+	}
+	return fmt.Sprintf("Listening on %s, last collection %s, last alert %s", s.listen, collection, alert)
+}
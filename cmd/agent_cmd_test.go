@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/hotplug"
+	"github.com/mayvqt/sysinfo/internal/netwatch"
+)
+
+func TestAgentCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "agent" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'agent' command to be registered")
+	}
+
+	for _, name := range []string{"listen", "interval", "db"} {
+		if agentCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected agent flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestRunAgentInvalidInterval(t *testing.T) {
+	oldInterval := agentInterval
+	defer func() { agentInterval = oldInterval }()
+
+	agentInterval = 0
+	if err := runAgent(agentCmd, nil); err == nil {
+		t.Error("Expected error for zero interval")
+	}
+}
+
+func TestBuildAgentSchedule(t *testing.T) {
+	webhookConfig := config.NewConfig()
+	webhookConfig.Outputs = []config.OutputConfig{{Type: "webhook", URL: "http://localhost:9/hook"}}
+
+	tests := []struct {
+		name      string
+		entries   []config.ScheduledTask
+		cfg       *config.Config
+		expectErr string
+	}{
+		{"no tasks", nil, config.NewConfig(), ""},
+		{"collect to stdout", []config.ScheduledTask{{Task: "collect", Cron: "*/5 * * * *"}}, config.NewConfig(), ""},
+		{"push to webhook", []config.ScheduledTask{{Task: "push", Cron: "@hourly"}}, webhookConfig, ""},
+		{"push without webhook", []config.ScheduledTask{{Task: "push", Cron: "@hourly"}}, config.NewConfig(), "no webhook, scrutiny or homeassistant outputs"},
+		{"collect with only webhooks", []config.ScheduledTask{{Task: "collect", Cron: "@hourly"}}, webhookConfig, "no file or stdout outputs"},
+		{"analyze without database", []config.ScheduledTask{{Task: "smart_analyze", Cron: "@daily"}}, config.NewConfig(), "requires the history database"},
+		{"prune without database", []config.ScheduledTask{{Task: "prune", Cron: "@weekly"}}, config.NewConfig(), "requires the history database"},
+		{"disk usage without database", []config.ScheduledTask{{Task: "disk_usage", Cron: "*/5 * * * *"}}, config.NewConfig(), "requires the history database"},
+		{"invalid cron", []config.ScheduledTask{{Task: "collect", Cron: "every day"}}, config.NewConfig(), "task 1 (collect)"},
+		{"unknown task", []config.ScheduledTask{{Task: "reboot", Cron: "@daily"}}, config.NewConfig(), "unknown task"},
+		{"missing task", []config.ScheduledTask{{Cron: "@daily"}}, config.NewConfig(), "missing task name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := buildAgentSchedule(tt.entries, tt.cfg, &config.FileConfig{}, nil, &agentStatus{})
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Fatalf("buildAgentSchedule returned error: %v", err)
+				}
+				if (schedule == nil) != (len(tt.entries) == 0) {
+					t.Errorf("schedule = %v, expected one only when tasks are configured", schedule)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+				t.Errorf("error = %v, expected it to contain %q", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestAgentStatus(t *testing.T) {
+	oldUTC := cfg.UTC
+	defer func() { cfg.UTC = oldUTC }()
+	cfg.UTC = true
+
+	status := &agentStatus{listen: "127.0.0.1:8090"}
+	if got, expected := status.String(), "Listening on 127.0.0.1:8090, last collection never, last alert none"; got != expected {
+		t.Errorf("String = %q, expected %q", got, expected)
+	}
+
+	status.collected(time.Date(2025, 1, 15, 14, 5, 0, 0, time.UTC))
+	status.alerted("/dev/sdb", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC))
+	// An older alert for another device does not replace the latest one
+	status.alerted("/dev/sda", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+
+	expected := "Listening on 127.0.0.1:8090, last collection 2025-01-15 14:05, last alert /dev/sdb at 2025-01-15 13:00"
+	if got := status.String(); got != expected {
+		t.Errorf("String = %q, expected %q", got, expected)
+	}
+}
+
+func TestAgentDropOptions(t *testing.T) {
+	oldUser, oldGroup := agentUser, agentGroup
+	defer func() { agentUser, agentGroup = oldUser, oldGroup }()
+
+	fileConfig := &config.FileConfig{}
+	fileConfig.Agent.User = "sysinfo"
+	fileConfig.Agent.Group = "sysinfo"
+	fileConfig.Agent.Capabilities = []string{"CAP_SYS_RAWIO", "CAP_DAC_READ_SEARCH"}
+
+	agentUser, agentGroup = "", ""
+	opts := agentDropOptions(fileConfig)
+	if opts.User != "sysinfo" || opts.Group != "sysinfo" || len(opts.Capabilities) != 2 {
+		t.Errorf("options = %+v, expected the config file values", opts)
+	}
+
+	agentUser, agentGroup = "nobody", "disk"
+	opts = agentDropOptions(fileConfig)
+	if opts.User != "nobody" || opts.Group != "disk" {
+		t.Errorf("options = %+v, expected flags to take precedence", opts)
+	}
+}
+
+func TestDeviceEventAlert(t *testing.T) {
+	removed := hotplug.Event{
+		Time:        time.Date(2025, 3, 1, 3, 12, 0, 0, time.UTC),
+		Action:      hotplug.ActionRemove,
+		Kind:        hotplug.KindDisk,
+		Device:      "/dev/sdc",
+		Description: "ST8000NM000A",
+		Serial:      "ZA1B2C3D",
+	}
+
+	if got := describeDeviceEvent(analyzer.DeviceEvent(removed)); got != "disk /dev/sdc removed (ST8000NM000A, serial ZA1B2C3D)" {
+		t.Errorf("describeDeviceEvent() = %q", got)
+	}
+	alert := deviceEventAlert(removed)
+	if alert.Level != analyzer.AlertCritical || alert.Title != "Device Removed: /dev/sdc" || !alert.Timestamp.Equal(removed.Time) {
+		t.Errorf("alert = %+v", alert)
+	}
+
+	usb := hotplug.Event{Action: hotplug.ActionRemove, Kind: hotplug.KindUSB, Device: "1-2"}
+	if alert := deviceEventAlert(usb); alert.Level != analyzer.AlertWarning {
+		t.Errorf("USB removal level = %s, expected WARNING", alert.Level)
+	}
+	usb.Action = hotplug.ActionAdd
+	if alert := deviceEventAlert(usb); alert.Level != analyzer.AlertInfo || describeDeviceEvent(analyzer.DeviceEvent(usb)) != "usb 1-2 added" {
+		t.Errorf("USB addition = %+v", alert)
+	}
+}
+
+func TestNetworkEvents(t *testing.T) {
+	recorded := analyzer.DeviceEvent{Action: netwatch.AddrRemove, Kind: netwatch.Kind, Device: "eth0", Description: "192.0.2.10/24"}
+	if got := describeDeviceEvent(recorded); got != "network eth0 address 192.0.2.10/24 removed" {
+		t.Errorf("describeDeviceEvent() = %q", got)
+	}
+
+	fileConfig := &config.FileConfig{}
+	flaps, err := networkFlapDetector(fileConfig)
+	if err != nil {
+		t.Fatalf("networkFlapDetector() with defaults: %v", err)
+	}
+	start := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	var downs int
+	var flapping bool
+	for i := 0; i < defaultFlapCount; i++ {
+		downs, flapping = flaps.Observe(netwatch.Event{Time: start.Add(time.Duration(i) * time.Minute), Interface: "eth0", Action: netwatch.LinkDown})
+	}
+	if !flapping {
+		t.Fatalf("%d downs within the default window not reported as flapping", downs)
+	}
+	alert := flappingAlert(netwatch.Event{Time: start, Interface: "eth0"}, downs, "")
+	if alert.Level != analyzer.AlertWarning || alert.Description != "eth0 went down 3 times in 10m" {
+		t.Errorf("alert = %+v", alert)
+	}
+
+	fileConfig.Agent.Network.FlapWindow = "soon"
+	if _, err := networkFlapDetector(fileConfig); err == nil {
+		t.Error("expected an invalid flap_window to be rejected")
+	}
+}
+
+func TestDiskGrowthRules(t *testing.T) {
+	rules, err := diskGrowthRules([]config.DiskGrowthRule{
+		{Mount: "/var/*", Increase: 5, Window: "1h"},
+		{Increase: 20, Window: "1d", Level: "critical"},
+	})
+	if err != nil {
+		t.Fatalf("diskGrowthRules returned error: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Window != time.Hour || rules[0].Level != analyzer.AlertWarning {
+		t.Errorf("rules = %+v, expected the first over 1h at WARNING", rules)
+	}
+	if rules[1].Window != 24*time.Hour || rules[1].Level != analyzer.AlertCritical || !rules[1].Matches("/home") {
+		t.Errorf("second rule = %+v, expected every partition over 1d at CRITICAL", rules[1])
+	}
+
+	invalid := []struct {
+		rule      config.DiskGrowthRule
+		expectErr string
+	}{
+		{config.DiskGrowthRule{Window: "1h"}, "invalid increase"},
+		{config.DiskGrowthRule{Increase: 150, Window: "1h"}, "invalid increase"},
+		{config.DiskGrowthRule{Increase: 5}, "invalid window"},
+		{config.DiskGrowthRule{Increase: 5, Window: "soon"}, "invalid window"},
+		{config.DiskGrowthRule{Increase: 5, Window: "1h", Level: "urgent"}, "invalid level"},
+		{config.DiskGrowthRule{Mount: "/var/[", Increase: 5, Window: "1h"}, "invalid mount pattern"},
+	}
+	for _, tt := range invalid {
+		if _, err := diskGrowthRules([]config.DiskGrowthRule{tt.rule}); err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+			t.Errorf("diskGrowthRules(%+v) error = %v, expected %q", tt.rule, err, tt.expectErr)
+		}
+	}
+}
+
+func TestVRAMLeakRule(t *testing.T) {
+	rule, err := vramLeakRule(config.VRAMLeakCheck{})
+	if err != nil {
+		t.Fatalf("vramLeakRule returned error: %v", err)
+	}
+	if rule.Window != time.Hour || rule.Samples != 6 || rule.MinIncrease != 512<<20 {
+		t.Errorf("default rule = %+v, expected 6 readings over 1h rising more than 512 MiB", rule)
+	}
+	rule, err = vramLeakRule(config.VRAMLeakCheck{Window: "6h", Samples: 12, MinIncreaseMB: 2048})
+	if err != nil || rule.Window != 6*time.Hour || rule.Samples != 12 || rule.MinIncrease != 2048<<20 {
+		t.Errorf("rule = %+v, %v, expected 12 readings over 6h rising more than 2 GiB", rule, err)
+	}
+
+	invalid := []struct {
+		check     config.VRAMLeakCheck
+		expectErr string
+	}{
+		{config.VRAMLeakCheck{Window: "soon"}, "invalid window"},
+		{config.VRAMLeakCheck{Samples: 1}, "invalid samples"},
+		{config.VRAMLeakCheck{MinIncreaseMB: -1}, "invalid min_increase_mb"},
+	}
+	for _, tt := range invalid {
+		if _, err := vramLeakRule(tt.check); err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+			t.Errorf("vramLeakRule(%+v) error = %v, expected %q", tt.check, err, tt.expectErr)
+		}
+	}
+}
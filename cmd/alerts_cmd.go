@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/alertserver"
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertsListen   string
+	alertsDBPath   string
+	alertsForward  string
+	alertsMinLevel string
+	alertsDedupe   string
+)
+
+// alertsCmd groups the fleet alert commands
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Fleet alert aggregation",
+	Long:  `Commands for collecting the alerts of many sysinfo instances in one place.`,
+}
+
+// alertsServerCmd receives alert webhooks from a fleet
+var alertsServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Receive, deduplicate and forward alert webhooks from many hosts",
+	Long: `Runs a minimal fleet alert aggregator. Point each host's smart.webhook_url at
+http://<server>:9102/api/alerts and the server:
+  - stores every alert in a central sqlite database (the history database format)
+  - folds repeats from the same host and device within the dedupe window into
+    one alert, unless the repeat escalates its level
+  - forwards each new alert to a single downstream webhook (--forward)
+
+Alerts name the host that raised them; alerts from older senders are named by
+an X-SysInfo-Host header or, failing that, their address. GET /api/alerts
+lists the stored alerts (?period=, default 7d).
+
+Settings can also be given in the alerts.server section of the config file;
+set alerts.server.token there to require senders to present it, as a bearer
+token or ?token= in their webhook URL.
+
+Examples:
+  sysinfo alerts server --forward https://hooks.slack.com/services/...
+  sysinfo alerts server --listen 127.0.0.1:9102 --dedupe 6h --min-level CRITICAL`,
+	Args: cobra.NoArgs,
+	RunE: runAlertsServer,
+}
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.AddCommand(alertsServerCmd)
+
+	alertsServerCmd.Flags().StringVarP(&alertsListen, "listen", "l", "", "Address to listen on (default: :9102)")
+	alertsServerCmd.Flags().StringVar(&alertsDBPath, "db", "", "Database alerts are stored in (default: same as 'smart' commands)")
+	alertsServerCmd.Flags().StringVar(&alertsForward, "forward", "", "Downstream webhook new alerts are forwarded to")
+	alertsServerCmd.Flags().StringVar(&alertsMinLevel, "min-level", "", "Lowest level forwarded: INFO, WARNING or CRITICAL (default: WARNING)")
+	alertsServerCmd.Flags().StringVar(&alertsDedupe, "dedupe", "", "Window over which repeats from a host and device are folded (default: 1h)")
+}
+
+// alertsServerSettings are the resolved alert server settings
+type alertsServerSettings struct {
+	listen   string
+	forward  string
+	minLevel analyzer.AlertLevel
+	dedupe   time.Duration
+	token    string
+}
+
+// resolveAlertsServer merges the flags over the alerts.server config section
+func resolveAlertsServer(fileConfig *config.FileConfig) (alertsServerSettings, error) {
+	section := fileConfig.Alerts.Server
+	settings := alertsServerSettings{
+		listen:   firstNonEmpty(alertsListen, section.Listen, ":9102"),
+		forward:  firstNonEmpty(alertsForward, section.ForwardURL),
+		minLevel: analyzer.AlertLevel(strings.ToUpper(firstNonEmpty(alertsMinLevel, section.MinLevel, string(analyzer.AlertWarning)))),
+		token:    section.Token,
+	}
+	if !analyzer.ValidAlertLevel(settings.minLevel) {
+		return settings, fmt.Errorf("invalid min level %q (expected INFO, WARNING or CRITICAL)", settings.minLevel)
+	}
+
+	dedupe := firstNonEmpty(alertsDedupe, section.Dedupe, "1h")
+	window, err := utils.ParseDuration(dedupe)
+	if err != nil {
+		return settings, fmt.Errorf("invalid dedupe window %q: %w", dedupe, err)
+	}
+	settings.dedupe = window
+	return settings, nil
+}
+
+func runAlertsServer(cmd *cobra.Command, args []string) error {
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+	settings, err := resolveAlertsServer(fileConfig)
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := resolveSMARTDBPath(firstNonEmpty(alertsDBPath, fileConfig.Alerts.Server.DBPath), fileConfig)
+	if err != nil {
+		return err
+	}
+	db, err := openHistoryDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	server := alertserver.New(db, settings.dedupe)
+	server.SetToken(settings.token)
+	if settings.forward != "" {
+		// The manager filters by level only; the server has already folded repeats
+		server.SetForward(analyzer.NewAlertManager(analyzer.AlertConfig{
+			Enabled:    true,
+			WebhookURL: settings.forward,
+			MinLevel:   settings.minLevel,
+		}))
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: no --forward webhook configured, alerts are only stored\n")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Receiving alerts on %s (storing in %s)\n", settings.listen, dbPath)
+	return server.ListenAndServe(ctx, settings.listen)
+}
+
+// firstNonEmpty returns the first value that is set
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+func TestAlertsServerCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range alertsCmd.Commands() {
+		if cmd.Name() == "server" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'alerts server' command to be registered")
+	}
+
+	for _, name := range []string{"listen", "db", "forward", "min-level", "dedupe"} {
+		if alertsServerCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected alerts server flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestResolveAlertsServer(t *testing.T) {
+	defer func() {
+		alertsListen, alertsMinLevel, alertsDedupe = "", "", ""
+	}()
+
+	fileConfig := &config.FileConfig{}
+	settings, err := resolveAlertsServer(fileConfig)
+	if err != nil {
+		t.Fatalf("resolveAlertsServer failed: %v", err)
+	}
+	if settings.listen != ":9102" || settings.minLevel != analyzer.AlertWarning || settings.dedupe != time.Hour {
+		t.Errorf("defaults = %+v", settings)
+	}
+
+	// Flags win over the config file
+	fileConfig.Alerts.Server.Listen = ":9000"
+	fileConfig.Alerts.Server.Dedupe = "6h"
+	fileConfig.Alerts.Server.Token = "secret"
+	alertsListen = "127.0.0.1:9102"
+	alertsMinLevel = "critical"
+	settings, err = resolveAlertsServer(fileConfig)
+	if err != nil {
+		t.Fatalf("resolveAlertsServer failed: %v", err)
+	}
+	if settings.listen != "127.0.0.1:9102" || settings.minLevel != analyzer.AlertCritical ||
+		settings.dedupe != 6*time.Hour || settings.token != "secret" {
+		t.Errorf("merged settings = %+v", settings)
+	}
+
+	alertsMinLevel = "loud"
+	if _, err := resolveAlertsServer(fileConfig); err == nil {
+		t.Error("expected an unknown level to be rejected")
+	}
+	alertsMinLevel = ""
+	alertsDedupe = "a while"
+	if _, err := resolveAlertsServer(fileConfig); err == nil {
+		t.Error("expected an invalid dedupe window to be rejected")
+	}
+}
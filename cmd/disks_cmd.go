@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	disksFormat  string
+	disksOutput  string
+	disksNoSMART bool
+)
+
+// disksCmd prints a storage-only inventory
+var disksCmd = &cobra.Command{
+	Use:   "disks",
+	Short: "Show physical disks, partitions, SMART summary and IO stats",
+	Long: `Collects storage information only, without running the full system
+collector. Prints physical disks, mounted partitions, a one-line SMART
+summary per drive and cumulative IO statistics.
+
+Examples:
+  sysinfo disks                  # Storage inventory with SMART summary
+  sysinfo disks --no-smart       # Skip SMART (no elevated privileges needed)
+  sysinfo disks -f json -o disks.json`,
+	RunE: runDisks,
+}
+
+func init() {
+	rootCmd.AddCommand(disksCmd)
+
+	disksCmd.Flags().StringVarP(&disksFormat, "format", "f", "pretty", "Output format: json, text, pretty")
+	disksCmd.Flags().StringVarP(&disksOutput, "output", "o", "", "Output file path (default: stdout)")
+	disksCmd.Flags().BoolVar(&disksNoSMART, "no-smart", false, "Skip SMART data collection")
+}
+
+func runDisks(cmd *cobra.Command, args []string) error {
+	data, err := collector.CollectDisk(!disksNoSMART)
+	if err != nil {
+		return fmt.Errorf("failed to collect disk information: %w", err)
+	}
+
+	output, err := formatter.FormatDisks(data, disksFormat)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if disksOutput != "" {
+		if err := os.WriteFile(disksOutput, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Output written to: %s\n", disksOutput)
+		return nil
+	}
+
+	fmt.Print(output)
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDisksCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "disks" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'disks' command to be registered")
+	}
+
+	for _, name := range []string{"format", "output", "no-smart"} {
+		if disksCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected disks flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestRunDisks(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "disks.json")
+
+	disksFormat = "json"
+	disksOutput = outputFile
+	disksNoSMART = true
+	defer func() {
+		disksFormat = "pretty"
+		disksOutput = ""
+		disksNoSMART = false
+	}()
+
+	if err := runDisks(disksCmd, []string{}); err != nil {
+		t.Fatalf("runDisks failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(data), "partitions") {
+		t.Error("Disks JSON output missing partitions")
+	}
+	if strings.Contains(string(data), "\"hostname\"") {
+		t.Error("Disks output should not contain system information")
+	}
+}
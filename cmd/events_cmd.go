@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/netwatch"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsPeriod string
+	eventsDBPath string
+	eventsHost   string
+	eventsFormat string
+)
+
+// Timeline categories
+const (
+	timelineBoot    = "boot"
+	timelineDevice  = "device"
+	timelineNetwork = "network"
+	timelineHealth  = "health"
+	timelineIssue   = "issue"
+	timelineAlert   = "alert"
+)
+
+// eventsCmd prints the merged event timeline of a host
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the event timeline recorded in the history database",
+	Long: `Lists what happened on a host as one chronological timeline, merged from
+the history database shared with the smart commands and the agent:
+  boot      The host booted (noted by smart analyze and the agent)
+  device    A disk or USB device was attached or detached (agent.hotplug)
+  network   A link went up or down or an address changed (agent.network)
+  health    A drive's health status changed between SMART analyses
+  issue     A SMART issue appeared or cleared, such as a temperature
+            crossing its threshold
+  alert     An alert was delivered to the webhook
+
+Examples:
+  sysinfo events                   # Last 7 days
+  sysinfo events --period 24h      # Last day
+  sysinfo events --host web01      # Timeline recorded for another host
+  sysinfo events -f json`,
+	RunE: runEvents,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	eventsCmd.Flags().StringVar(&eventsPeriod, "period", "7d", "Time period (e.g., 1h, 24h, 7d, 30d)")
+	eventsCmd.Flags().StringVar(&eventsDBPath, "db", "", "History database (default: same as 'smart' commands)")
+	eventsCmd.Flags().StringVar(&eventsHost, "host", "", "Host whose timeline to show (default: this machine's hostname)")
+	eventsCmd.Flags().StringVarP(&eventsFormat, "format", "f", "text", "Output format: text, json")
+}
+
+// timelineEntry is one event of the timeline
+type timelineEntry struct {
+	Time     time.Time `json:"time"`
+	Category string    `json:"category"`
+	Level    string    `json:"level,omitempty"` // Severity of issues and alerts
+	Device   string    `json:"device,omitempty"`
+	Summary  string    `json:"summary"`
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	if eventsFormat != "text" && eventsFormat != "json" {
+		return fmt.Errorf("unsupported format: %s", eventsFormat)
+	}
+	period, err := utils.ParseDuration(eventsPeriod)
+	if err != nil {
+		return fmt.Errorf("invalid period format: %w", err)
+	}
+
+	fileConfig, _ := config.LoadConfigFile(configFile)
+	dbPath, err := resolveSMARTDBPath(eventsDBPath, fileConfig)
+	if err != nil {
+		return err
+	}
+	db, err := openHistoryDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if eventsHost != "" {
+		db.SetHost(eventsHost)
+	}
+
+	timeline, err := buildTimeline(db, time.Now().Add(-period))
+	if err != nil {
+		return fmt.Errorf("failed to read event timeline: %w", err)
+	}
+
+	if eventsFormat == "json" {
+		if timeline == nil {
+			timeline = []timelineEntry{}
+		}
+		data, err := json.MarshalIndent(timeline, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(timeline) == 0 {
+		fmt.Printf("No events recorded for host %s in the last %s.\n", db.Host(), eventsPeriod)
+		return nil
+	}
+	fmt.Printf("Event Timeline for %s (Last %s)\n", db.Host(), eventsPeriod)
+	fmt.Println(repeatString("=", 70))
+	for _, entry := range timeline {
+		fmt.Printf("%s  %-8s %s\n", historyTime(entry.Time), entry.Category, entry.Summary)
+	}
+	return nil
+}
+
+// buildTimeline merges everything recorded for the database's host since the given time, oldest first
+func buildTimeline(db *analyzer.HistoryDB, since time.Time) ([]timelineEntry, error) {
+	var timeline []timelineEntry
+
+	boots, err := db.GetBoots(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, bootTime := range boots {
+		timeline = append(timeline, timelineEntry{Time: bootTime, Category: timelineBoot, Summary: "System booted"})
+	}
+
+	events, err := db.GetDeviceEvents(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		category := timelineDevice
+		if event.Kind == netwatch.Kind {
+			category = timelineNetwork
+		}
+		timeline = append(timeline, timelineEntry{
+			Time:     event.Time,
+			Category: category,
+			Device:   event.Device,
+			Summary:  describeDeviceEvent(event),
+		})
+	}
+
+	transitions, err := db.GetHealthTransitions(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, transition := range transitions {
+		timeline = append(timeline, timelineEntry{
+			Time:     transition.Time,
+			Category: timelineHealth,
+			Device:   transition.Device,
+			Summary:  fmt.Sprintf("%s health changed from %s to %s", transition.Device, transition.From, transition.To),
+		})
+	}
+
+	changes, err := db.GetIssueChanges(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		summary := fmt.Sprintf("%s %s: %s", change.Device, change.Code, change.Description)
+		if change.Cleared {
+			summary = fmt.Sprintf("%s %s cleared", change.Device, change.Code)
+		}
+		timeline = append(timeline, timelineEntry{
+			Time:     change.Time,
+			Category: timelineIssue,
+			Level:    string(change.Severity),
+			Device:   change.Device,
+			Summary:  summary,
+		})
+	}
+
+	alerts, err := db.GetSentAlerts(since)
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range alerts {
+		timeline = append(timeline, timelineEntry{
+			Time:     alert.Time,
+			Category: timelineAlert,
+			Level:    string(alert.Level),
+			Device:   alert.Device,
+			Summary:  fmt.Sprintf("%s alert sent: %s", alert.Level, alert.Title),
+		})
+	}
+
+	// Each source is already in order; a stable sort keeps, e.g., an issue ahead of the alert it raised
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Time.Before(timeline[j].Time)
+	})
+	return timeline, nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestEventsCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "events" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'events' command to be registered")
+	}
+
+	for _, name := range []string{"period", "db", "host", "format"} {
+		if eventsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected events flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestRunEventsRejectsBadInput(t *testing.T) {
+	defer func() {
+		eventsFormat = "text"
+		eventsPeriod = "7d"
+	}()
+
+	eventsFormat = "yaml"
+	if err := runEvents(eventsCmd, nil); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+
+	eventsFormat = "text"
+	eventsPeriod = "a while"
+	if err := runEvents(eventsCmd, nil); err == nil {
+		t.Error("expected an invalid period to be rejected")
+	}
+}
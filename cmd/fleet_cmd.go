@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var fleetFormat string
+
+// fleetCmd groups the commands working on many hosts' reports
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Compare system reports across a fleet",
+	Long:  `Commands for comparing the reports of many hosts.`,
+}
+
+// fleetAnalyzeCmd flags hosts that differ from their peers
+var fleetAnalyzeCmd = &cobra.Command{
+	Use:   "analyze <report.json>...",
+	Short: "Flag outlier hosts in a set of JSON reports",
+	Long: `Reads JSON reports of many hosts (sysinfo -f json, or ndjson with several
+reports per file) and flags the hosts that stand out from their peers:
+  - temperature: the hottest CPU sensor, drive or GPU is more than 10°C (or
+    three median absolute deviations, if wider) above the fleet median
+  - firmware: a drive or CPU runs older firmware or microcode than others of
+    the same model
+  - memory: less memory than most hosts with the same CPU
+  - integrity: a file hashed by --integrity differs from the copy most hosts
+    have
+
+Hosts are named by hostname, or by file when a report has none; with several
+reports of a host, the newest is used. Temperature, memory and integrity need
+at least three hosts to compare.
+
+Examples:
+  sysinfo fleet analyze reports/*.json
+  sysinfo fleet analyze -f json reports/*.json | jq '.anomalies[].host'`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runFleetAnalyze,
+}
+
+func init() {
+	rootCmd.AddCommand(fleetCmd)
+	fleetCmd.AddCommand(fleetAnalyzeCmd)
+
+	fleetAnalyzeCmd.Flags().StringVarP(&fleetFormat, "format", "f", "text", "Output format: json, text")
+}
+
+func runFleetAnalyze(cmd *cobra.Command, args []string) error {
+	if fleetFormat != "json" && fleetFormat != "text" {
+		return fmt.Errorf("unknown format: %s", fleetFormat)
+	}
+
+	hosts, err := loadFleetReports(args)
+	if err != nil {
+		return err
+	}
+	analysis := analyzer.AnalyzeFleet(hosts)
+
+	if fleetFormat == "json" {
+		data, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	displayFleetAnalysis(os.Stdout, analysis)
+	return nil
+}
+
+// loadFleetReports reads the reports in the given files, expanding glob patterns the shell
+// left alone (as on Windows), and keeps the newest report of each host
+func loadFleetReports(patterns []string) ([]analyzer.FleetHost, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil || len(matches) == 0 {
+			matches = []string{pattern}
+		}
+		paths = append(paths, matches...)
+	}
+
+	newest := map[string]*types.SystemInfo{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			info := &types.SystemInfo{}
+			if err := decoder.Decode(info); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+			}
+			name := filepath.Base(path)
+			if info.System != nil && info.System.Hostname != "" {
+				name = info.System.Hostname
+			}
+			if previous, ok := newest[name]; !ok || info.Timestamp.After(previous.Timestamp) {
+				newest[name] = info
+			}
+		}
+	}
+	if len(newest) == 0 {
+		return nil, fmt.Errorf("no reports found in %s", strings.Join(patterns, ", "))
+	}
+
+	hosts := make([]analyzer.FleetHost, 0, len(newest))
+	for name, info := range newest {
+		hosts = append(hosts, analyzer.FleetHost{Name: name, Info: info})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+	return hosts, nil
+}
+
+// displayFleetAnalysis prints the anomalies grouped by host
+func displayFleetAnalysis(w io.Writer, analysis *analyzer.FleetAnalysis) {
+	hostColor := color.New(color.Bold)
+	kindColor := color.New(color.FgYellow)
+
+	fmt.Fprintf(w, "Fleet: %d hosts, %d anomalies\n", analysis.Hosts, len(analysis.Anomalies))
+	host := ""
+	for _, anomaly := range analysis.Anomalies {
+		if anomaly.Host != host {
+			host = anomaly.Host
+			fmt.Fprintf(w, "\n%s\n", hostColor.Sprint(host))
+		}
+		fmt.Fprintf(w, "  %s %s: %s (%s)\n", kindColor.Sprintf("%-11s", anomaly.Kind), anomaly.Subject, anomaly.Value, anomaly.Expected)
+	}
+	if len(analysis.Anomalies) == 0 {
+		fmt.Fprintln(w, color.New(color.FgGreen, color.Bold).Sprint("✓ No host stands out from its peers"))
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+)
+
+func TestLoadFleetReports(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write report: %v", err)
+		}
+	}
+	write("web-1.json", `{"timestamp": "2024-03-05T14:30:00Z", "system": {"hostname": "web-1"}}`)
+	// ndjson history of one host, with unix timestamps: the newest report is kept
+	write("web-2.ndjson", `{"timestamp":1709649000,"system":{"hostname":"web-2"},"memory":{"total_bytes":1}}`+"\n"+
+		`{"timestamp":1709652600,"system":{"hostname":"web-2"},"memory":{"total_bytes":2}}`+"\n")
+	write("anonymous.json", `{"cpu": {"model_name": "Xeon"}}`)
+
+	hosts, err := loadFleetReports([]string{filepath.Join(dir, "*")})
+	if err != nil {
+		t.Fatalf("loadFleetReports failed: %v", err)
+	}
+	if len(hosts) != 3 || hosts[0].Name != "anonymous.json" || hosts[1].Name != "web-1" || hosts[2].Name != "web-2" {
+		t.Fatalf("hosts = %+v, expected anonymous.json, web-1 and web-2", hosts)
+	}
+	if hosts[2].Info.Memory.Total != 2 {
+		t.Errorf("web-2 memory = %d, expected the newest report's 2", hosts[2].Info.Memory.Total)
+	}
+
+	write("broken.json", `{"system": `)
+	if _, err := loadFleetReports([]string{filepath.Join(dir, "broken.json")}); err == nil {
+		t.Error("loadFleetReports(broken) succeeded, expected an error")
+	}
+	if _, err := loadFleetReports([]string{filepath.Join(dir, "missing-*.json")}); err == nil {
+		t.Error("loadFleetReports(no matches) succeeded, expected an error")
+	}
+}
+
+func TestDisplayFleetAnalysis(t *testing.T) {
+	var buf bytes.Buffer
+	displayFleetAnalysis(&buf, &analyzer.FleetAnalysis{Hosts: 4, Anomalies: []analyzer.FleetAnomaly{
+		{Host: "node-1", Kind: analyzer.AnomalyMemory, Subject: "memory (AMD EPYC 7313)", Value: "31.40 GB", Expected: "63.00 GB on 3 of 4"},
+	}})
+	output := buf.String()
+	for _, want := range []string{"Fleet: 4 hosts, 1 anomalies", "node-1", "memory (AMD EPYC 7313): 31.40 GB (63.00 GB on 3 of 4)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q in:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	motdTargetUpdateMOTD = "update-motd"
+	motdTargetProfile    = "profile"
+
+	motdUpdateMOTDDir    = "/etc/update-motd.d"
+	motdProfileDir       = "/etc/profile.d"
+	motdUpdateMOTDScript = "90-sysinfo"
+	motdProfileScript    = "sysinfo-motd.sh"
+	motdSystemCache      = "/var/cache/sysinfo/motd"
+)
+
+var (
+	motdTarget string
+	motdDir    string
+	motdMaxAge time.Duration
+	motdCache  string
+	motdANSI   bool
+)
+
+// motdCmd groups login banner commands
+var motdCmd = &cobra.Command{
+	Use:   "motd",
+	Short: "Show system health in login banners",
+	Long: `Integrates the summary overview into login banners so every SSH
+session starts with current health at a glance.
+
+Examples:
+  sudo sysinfo motd install                  # update-motd.d if present, else profile.d
+  sudo sysinfo motd install --target profile # Force a profile.d entry
+  sysinfo motd show --max-age 10m            # Print the cached summary
+  sudo sysinfo motd uninstall`,
+}
+
+// motdInstallCmd writes the login script
+var motdInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install an update-motd script or profile.d entry",
+	RunE:  runMOTDInstall,
+}
+
+// motdUninstallCmd removes the login script
+var motdUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the installed login script",
+	RunE:  runMOTDUninstall,
+}
+
+// motdShowCmd prints the summary, reusing a cached copy while it is fresh
+var motdShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the summary using a cache (called by the login script)",
+	RunE:  runMOTDShow,
+}
+
+func init() {
+	rootCmd.AddCommand(motdCmd)
+
+	motdCmd.AddCommand(motdInstallCmd)
+	motdCmd.AddCommand(motdUninstallCmd)
+	motdCmd.AddCommand(motdShowCmd)
+
+	motdCmd.PersistentFlags().StringVar(&motdTarget, "target", "", "Login hook: update-motd or profile (default: update-motd if /etc/update-motd.d exists)")
+	motdCmd.PersistentFlags().StringVar(&motdDir, "dir", "", "Override the directory the script is written to")
+	motdCmd.PersistentFlags().DurationVar(&motdMaxAge, "max-age", 5*time.Minute, "Reuse the cached summary while it is younger than this")
+
+	motdShowCmd.Flags().StringVar(&motdCache, "cache", "", "Cache file path (default: user cache directory)")
+	motdShowCmd.Flags().BoolVar(&motdANSI, "ansi", false, "Emit ANSI colors")
+}
+
+func runMOTDInstall(cmd *cobra.Command, args []string) error {
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("motd install is not supported on Windows")
+	}
+
+	target, path, err := resolveMOTDScript()
+	if err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate sysinfo executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	if err := os.WriteFile(path, []byte(renderMOTDScript(target, exe, motdMaxAge)), 0755); err != nil {
+		return fmt.Errorf("failed to write login script: %w", err)
+	}
+
+	fmt.Printf("Installed %s login script: %s\n", target, path)
+	return nil
+}
+
+func runMOTDUninstall(cmd *cobra.Command, args []string) error {
+	_, path, err := resolveMOTDScript()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			fmt.Printf("No login script installed at %s\n", path)
+			return nil
+		}
+		return fmt.Errorf("failed to remove login script: %w", err)
+	}
+
+	fmt.Printf("Removed login script: %s\n", path)
+	return nil
+}
+
+func runMOTDShow(cmd *cobra.Command, args []string) error {
+	cachePath := motdCache
+	if cachePath == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return fmt.Errorf("failed to determine cache directory: %w", err)
+		}
+		cachePath = filepath.Join(cacheDir, "sysinfo", "motd")
+	}
+
+	if cached, ok := readCachedMOTD(cachePath, motdMaxAge, time.Now()); ok {
+		fmt.Print(cached)
+		return nil
+	}
+
+	info, err := collectSummary()
+	if err != nil {
+		return err
+	}
+	output := formatter.FormatSummary(info, motdANSI)
+
+	// Caching is best-effort; a read-only cache must not break logins
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+		_ = os.WriteFile(cachePath, []byte(output), 0644)
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+// resolveMOTDScript picks the login hook and returns the script path for it
+func resolveMOTDScript() (string, string, error) {
+	target := motdTarget
+	if target == "" {
+		target = motdTargetProfile
+		if info, err := os.Stat(motdUpdateMOTDDir); err == nil && info.IsDir() {
+			target = motdTargetUpdateMOTD
+		}
+	}
+
+	switch target {
+	case motdTargetUpdateMOTD:
+		dir := motdDir
+		if dir == "" {
+			dir = motdUpdateMOTDDir
+		}
+		return target, filepath.Join(dir, motdUpdateMOTDScript), nil
+	case motdTargetProfile:
+		dir := motdDir
+		if dir == "" {
+			dir = motdProfileDir
+		}
+		return target, filepath.Join(dir, motdProfileScript), nil
+	default:
+		return "", "", fmt.Errorf("unknown motd target: %s (use update-motd or profile)", target)
+	}
+}
+
+// renderMOTDScript returns the shell script for a login hook
+func renderMOTDScript(target, exe string, maxAge time.Duration) string {
+	if target == motdTargetUpdateMOTD {
+		// update-motd runs as root, so a single system-wide cache is shared by all users
+		return fmt.Sprintf(`#!/bin/sh
+# Installed by 'sysinfo motd install'. Remove with 'sysinfo motd uninstall'.
+[ -x %[1]q ] || exit 0
+exec %[1]q motd show --ansi --max-age %[2]s --cache %[3]s
+`, exe, maxAge, motdSystemCache)
+	}
+
+	// profile.d is sourced by every login shell; only print for interactive shells
+	return fmt.Sprintf(`# Installed by 'sysinfo motd install'. Remove with 'sysinfo motd uninstall'.
+case $- in
+    *i*) [ -x %[1]q ] && %[1]q motd show --ansi --max-age %[2]s ;;
+esac
+`, exe, maxAge)
+}
+
+// readCachedMOTD returns the cached summary if it was written within maxAge
+func readCachedMOTD(path string, maxAge time.Duration, now time.Time) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || now.Sub(info.ModTime()) > maxAge {
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return "", false
+	}
+	return string(data), true
+}
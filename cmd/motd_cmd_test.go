@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMOTDCommandsRegistered(t *testing.T) {
+	subcommands := make(map[string]bool)
+	for _, cmd := range motdCmd.Commands() {
+		subcommands[cmd.Name()] = true
+	}
+
+	for _, name := range []string{"install", "uninstall", "show"} {
+		if !subcommands[name] {
+			t.Errorf("Expected '%s' motd subcommand to be registered", name)
+		}
+	}
+}
+
+func TestRenderMOTDScript(t *testing.T) {
+	tests := []struct {
+		target   string
+		contains []string
+	}{
+		{motdTargetUpdateMOTD, []string{"#!/bin/sh", `exec "/usr/local/bin/sysinfo" motd show --ansi --max-age 5m0s --cache /var/cache/sysinfo/motd`}},
+		{motdTargetProfile, []string{"*i*)", `"/usr/local/bin/sysinfo" motd show --ansi --max-age 5m0s`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			script := renderMOTDScript(tt.target, "/usr/local/bin/sysinfo", 5*time.Minute)
+			for _, want := range tt.contains {
+				if !strings.Contains(script, want) {
+					t.Errorf("renderMOTDScript(%q) missing %q in:\n%s", tt.target, want, script)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveMOTDScript(t *testing.T) {
+	defer func() {
+		motdTarget = ""
+		motdDir = ""
+	}()
+
+	motdDir = "/tmp/hooks"
+
+	motdTarget = motdTargetUpdateMOTD
+	if _, path, err := resolveMOTDScript(); err != nil || path != filepath.Join("/tmp/hooks", motdUpdateMOTDScript) {
+		t.Errorf("resolveMOTDScript(update-motd) = %v, %v", path, err)
+	}
+
+	motdTarget = motdTargetProfile
+	if _, path, err := resolveMOTDScript(); err != nil || path != filepath.Join("/tmp/hooks", motdProfileScript) {
+		t.Errorf("resolveMOTDScript(profile) = %v, %v", path, err)
+	}
+
+	motdTarget = "invalid"
+	if _, _, err := resolveMOTDScript(); err == nil {
+		t.Error("Expected error for unknown target, got nil")
+	}
+}
+
+func TestMOTDInstallAndUninstall(t *testing.T) {
+	if os.PathSeparator == '\\' {
+		t.Skip("motd install is not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	motdTarget = motdTargetProfile
+	motdDir = dir
+	defer func() {
+		motdTarget = ""
+		motdDir = ""
+	}()
+
+	if err := runMOTDInstall(motdInstallCmd, []string{}); err != nil {
+		t.Fatalf("runMOTDInstall failed: %v", err)
+	}
+
+	path := filepath.Join(dir, motdProfileScript)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read installed script: %v", err)
+	}
+	if !strings.Contains(string(data), "motd show") {
+		t.Error("Installed script does not call motd show")
+	}
+
+	if err := runMOTDUninstall(motdUninstallCmd, []string{}); err != nil {
+		t.Fatalf("runMOTDUninstall failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Login script was not removed")
+	}
+}
+
+func TestReadCachedMOTD(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "motd")
+	if err := os.WriteFile(path, []byte("Host   test\n"), 0644); err != nil {
+		t.Fatalf("Failed to write cache: %v", err)
+	}
+	now := time.Now()
+
+	if cached, ok := readCachedMOTD(path, 5*time.Minute, now); !ok || cached != "Host   test\n" {
+		t.Errorf("readCachedMOTD(fresh) = %q, %v, expected cached summary", cached, ok)
+	}
+	if _, ok := readCachedMOTD(path, 5*time.Minute, now.Add(10*time.Minute)); ok {
+		t.Error("readCachedMOTD(stale) should miss")
+	}
+	if _, ok := readCachedMOTD(filepath.Join(t.TempDir(), "missing"), 5*time.Minute, now); ok {
+		t.Error("readCachedMOTD(missing) should miss")
+	}
+}
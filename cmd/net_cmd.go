@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	netFormat   string
+	netOutput   string
+	netWatch    bool
+	netInterval time.Duration
+)
+
+// netCmd prints network interfaces only, optionally refreshing with live rates
+var netCmd = &cobra.Command{
+	Use:   "net",
+	Short: "Show network interfaces with optional live throughput",
+	Long: `Collects network information only: interfaces, addresses, traffic
+totals, errors/drops and connection count.
+
+With --watch the report refreshes every --interval and shows per-interface
+send/receive throughput measured between samples. Press Ctrl+C to stop.
+With -f json or ndjson each sample is printed as one compact JSON line
+(NDJSON) instead of redrawing the screen, so the stream can be piped to a
+log shipper.
+
+Examples:
+  sysinfo net                    # One-shot network report
+  sysinfo net --watch            # Live throughput, refreshed every 2s
+  sysinfo net --watch -i 500ms   # Faster refresh
+  sysinfo net --watch -f json    # One JSON line per sample`,
+	RunE: runNet,
+}
+
+func init() {
+	rootCmd.AddCommand(netCmd)
+
+	netCmd.Flags().StringVarP(&netFormat, "format", "f", "pretty", "Output format: json, ndjson, text, pretty")
+	netCmd.Flags().StringVarP(&netOutput, "output", "o", "", "Output file path (default: stdout, ignored with --watch)")
+	netCmd.Flags().BoolVarP(&netWatch, "watch", "w", false, "Refresh continuously and show live throughput")
+	netCmd.Flags().DurationVarP(&netInterval, "interval", "i", 2*time.Second, "Refresh interval for --watch")
+}
+
+func runNet(cmd *cobra.Command, args []string) error {
+	if netWatch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return watchNetwork(ctx)
+	}
+
+	data, err := collector.CollectNetwork()
+	if err != nil {
+		return fmt.Errorf("failed to collect network information: %w", err)
+	}
+
+	output, err := formatter.FormatNetwork(data, netFormat)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if netOutput != "" {
+		if err := os.WriteFile(netOutput, []byte(output), 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Output written to: %s\n", netOutput)
+		return nil
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+// watchNetwork samples interface counters every netInterval until ctx is cancelled
+func watchNetwork(ctx context.Context) error {
+	if netInterval <= 0 {
+		return fmt.Errorf("invalid interval: %s", netInterval)
+	}
+
+	ticker := time.NewTicker(netInterval)
+	defer ticker.Stop()
+
+	var previous *types.NetworkData
+	lastSample := time.Now()
+
+	for {
+		data, err := collector.CollectNetwork()
+		if err != nil {
+			return fmt.Errorf("failed to collect network information: %w", err)
+		}
+		now := time.Now()
+		collector.CalculateNetworkRates(previous, data, now.Sub(lastSample))
+		previous, lastSample = data, now
+
+		// Samples are streamed as NDJSON, so each one is a single event for log shippers
+		format := netFormat
+		if format == "json" {
+			format = "ndjson"
+		}
+		output, err := formatter.FormatNetwork(data, format)
+		if err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+
+		if format == "ndjson" {
+			fmt.Print(output)
+		} else {
+			// Clear the screen and redraw from the top-left corner
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Network throughput every %s - %s (Ctrl+C to stop)\n\n", netInterval, now.Format("15:04:05"))
+			fmt.Print(output)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "net" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'net' command to be registered")
+	}
+
+	for _, name := range []string{"format", "output", "watch", "interval"} {
+		if netCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected net flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestRunNet(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "net.txt")
+
+	netFormat = "text"
+	netOutput = outputFile
+	defer func() {
+		netFormat = "pretty"
+		netOutput = ""
+	}()
+
+	if err := runNet(netCmd, []string{}); err != nil {
+		t.Fatalf("runNet failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(data), "NETWORK INTERFACES") {
+		t.Error("Net output missing network header")
+	}
+}
+
+func TestWatchNetworkStopsOnCancel(t *testing.T) {
+	netFormat = "json"
+	netInterval = 10 * time.Millisecond
+	defer func() {
+		netFormat = "pretty"
+		netInterval = 2 * time.Second
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := watchNetwork(ctx); err != nil {
+		t.Errorf("watchNetwork returned error: %v", err)
+	}
+}
+
+func TestWatchNetworkInvalidInterval(t *testing.T) {
+	netInterval = 0
+	defer func() { netInterval = 2 * time.Second }()
+
+	if err := watchNetwork(context.Background()); err == nil {
+		t.Error("Expected error for zero interval, got nil")
+	}
+}
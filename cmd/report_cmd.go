@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/output"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFormat  string
+	reportOutput  string
+	reportVerbose bool
+)
+
+// reportCmd writes everything collected as one shareable document
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write a shareable report of everything collected",
+	Long: `Collects every module and writes a single report, by default a
+self-contained HTML page: styled tables with usage bars for CPU, memory,
+filesystems, GPUs and batteries, SMART health with collapsible attribute
+tables and trend charts from the history database, and the full text
+report at the end. The page has no external assets, so it can be mailed
+or attached to a ticket as one file.
+
+The report goes to --output or stdout only; outputs configured in the
+config file are not used.
+
+Examples:
+  sysinfo report -o report.html              # HTML report
+  sudo sysinfo report -o report.html         # Include SMART data
+  sysinfo report --format json -o report.json`,
+	RunE: runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVarP(&reportFormat, "format", "f", "html", "Output format: html, json, text, pretty")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Output file path (default: stdout)")
+	reportCmd.Flags().BoolVarP(&reportVerbose, "verbose", "v", false, "Verbose output")
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	reportConfig := config.NewConfig()
+	reportConfig.Verbose = reportVerbose
+	reportConfig.UTC = cfg.UTC
+	reportConfig.TimestampFormat = cfg.TimestampFormat
+	reportConfig.NTPServer = cfg.NTPServer
+	reportConfig.MergeWithFileConfig(fileConfig)
+	// The flags decide the format and destination, not the config file
+	reportConfig.Format = reportFormat
+	reportConfig.OutputFile = reportOutput
+	reportConfig.Outputs = nil
+
+	if err := utils.ValidateTimestampFormat(reportConfig.TimestampFormat); err != nil {
+		return err
+	}
+	sinks, err := output.Build(reportConfig)
+	if err != nil {
+		return fmt.Errorf("invalid output configuration: %w", err)
+	}
+
+	if reportConfig.Verbose {
+		fmt.Fprintf(os.Stderr, "Collecting system information...\n")
+	}
+	info, err := collector.Collect(reportConfig)
+	if err != nil {
+		return fmt.Errorf("failed to collect system information: %w", err)
+	}
+
+	if info.Disk != nil && len(info.Disk.SMARTData) > 0 {
+		if err := attachSMARTHistory(info.Disk.SMARTData, fileConfig); err != nil && reportConfig.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: SMART history unavailable: %v\n", err)
+		}
+	}
+	if reportConfig.UTC {
+		collector.UseUTC(info)
+	}
+	info.TimestampFormat = reportConfig.TimestampFormat
+	if reportConfig.Redact {
+		collector.Redact(info)
+	}
+
+	return output.WriteAll(sinks, info, reportConfig.Verbose)
+}
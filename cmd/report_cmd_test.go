@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReportCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "report" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'report' command to be registered")
+	}
+
+	if flag := reportCmd.Flags().Lookup("format"); flag == nil || flag.DefValue != "html" {
+		t.Error("Expected report --format to default to html")
+	}
+	if reportCmd.Flags().Lookup("output") == nil {
+		t.Error("Expected report flag --output to be defined")
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.html")
+
+	reportOutput = outputFile
+	defer func() {
+		reportOutput = ""
+	}()
+
+	if err := runReport(reportCmd, []string{}); err != nil {
+		t.Fatalf("runReport failed: %v", err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<!DOCTYPE html>") || !strings.Contains(string(data), "<h2>System</h2>") {
+		t.Errorf("report is not the HTML page:\n%.200s", data)
+	}
+}
@@ -3,10 +3,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/mayvqt/sysinfo/internal/analyzer"
 	"github.com/mayvqt/sysinfo/internal/collector"
 	"github.com/mayvqt/sysinfo/internal/config"
 	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/mayvqt/sysinfo/internal/output"
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -20,18 +26,34 @@ var rootCmd = &cobra.Command{
 that collects and displays detailed information about your computer including
 CPU, memory, disk, network, processes, and SMART data.`,
 	RunE: runSysInfo,
+	// Apply the external command policy and size units before any subcommand runs a collector
+	PersistentPreRunE: applyGlobalSettings,
 }
 
 func init() {
 	cfg = config.NewConfig()
 
 	// Configuration file
+	rootCmd.PersistentFlags().BoolVar(&cfg.UTC, "utc", false, "Report times in UTC instead of local time")
+	rootCmd.PersistentFlags().StringVar(&cfg.TimestampFormat, "timestamp-format", "", "Timestamp format: rfc3339, unix, none (default: RFC 3339 in JSON, readable local time in text)")
+	rootCmd.PersistentFlags().StringVar(&cfg.ByteUnits, "units", "", "Size units: binary (KiB, MiB, 1024-based) or decimal (KB, MB, 1000-based) (default: 1024-based, labelled KB, MB)")
+	rootCmd.PersistentFlags().StringVar(&cfg.HostRoot, "host-root", "", "Read the host's files from this directory instead of /, e.g. the host's / mounted into a container (Linux)")
+	rootCmd.PersistentFlags().StringVar(&cfg.SysfsRoot, "sysfs-root", "", "Read sysfs from this directory instead of /sys, or <host-root>/sys with --host-root (Linux)")
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (default: searches for .sysinforc, ~/.config/sysinfo/config.yaml)")
 
 	// Output options
-	rootCmd.Flags().StringVarP(&cfg.Format, "format", "f", "pretty", "Output format: json, text, pretty")
+	rootCmd.Flags().StringVarP(&cfg.Format, "format", "f", "pretty", "Output format: json, ndjson, text, pretty, html, csv, prometheus, influx, template, xml, msgpack, dot, parquet, sqlite")
+	rootCmd.Flags().StringVar(&cfg.Query, "query", "", "Print only the values at a jq-style path, e.g. '.disk.smart_data[].temperature_celsius' (replaces --format)")
+	rootCmd.Flags().StringSliceVar(&cfg.Fields, "fields", nil, "Keep only these fields in any format, e.g. system.hostname,cpu.model_name,memory.used_percent")
+	rootCmd.Flags().BoolVar(&cfg.Redact, "redact", false, "Mask serial numbers, MAC and IP addresses, the hostname, Wi-Fi network names and UUIDs, for sharing the report publicly")
+	rootCmd.Flags().BoolVar(&cfg.Compact, "compact", false, "Minified JSON with the json format, for piping and smaller log lines")
+	rootCmd.Flags().StringVar(&cfg.InfluxPrefix, "influx-prefix", "", "Measurement name prefix for the influx format (default: sysinfo_)")
+	rootCmd.Flags().StringVar(&cfg.TemplateFile, "template-file", "", "Go text/template file rendered by the template format")
+	rootCmd.Flags().StringVar(&cfg.Section, "section", "", "Section emitted by the csv format: disk, process, network, smart, sensors, pci, usb, displays, audio, drivers, packages, integrity (default: all)")
 	rootCmd.Flags().StringVarP(&cfg.OutputFile, "output", "o", "", "Output file path (default: stdout)")
 	rootCmd.Flags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.Flags().BoolVar(&cfg.Stable, "stable", false, "Deterministic output: sorted lists and a fixed timestamp, for diffing and checksums")
+	rootCmd.Flags().StringVar(&cfg.Timestamp, "timestamp", "", "Report timestamp to use instead of the collection time (RFC 3339)")
 
 	// Full dump mode
 	rootCmd.Flags().BoolVar(&cfg.FullDumpToFile, "full-dump", false, "Collect ALL system information and save to sysinfo_dump.json")
@@ -47,12 +69,136 @@ func init() {
 	rootCmd.Flags().BoolVar(&cfg.Modules.SMART, "smart", false, "Collect SMART disk data (may require elevated privileges)")
 	rootCmd.Flags().BoolVar(&cfg.Modules.GPU, "gpu", false, "Collect GPU information")
 	rootCmd.Flags().BoolVar(&cfg.Modules.Battery, "battery", false, "Collect battery information")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Security, "security", false, "Collect OS security and compliance posture")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Accelerator, "accelerator", false, "Collect non-GPU accelerators (NPUs, TPUs, Gaudi, FPGAs)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Thermal, "thermal", false, "Collect thermal zones, trip points and cooling policy")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Baseboard, "baseboard", false, "Collect motherboard, BIOS and chassis details (DMI/SMBIOS)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.PCI, "pci", false, "Collect PCI devices with IDs, class and bound driver")
+	rootCmd.Flags().BoolVar(&cfg.Modules.USB, "usb", false, "Collect connected USB devices with IDs, serial, speed and port")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Displays, "displays", false, "Collect connected monitors with resolution, refresh rate and EDID model")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Audio, "audio", false, "Collect sound cards with their codecs, drivers and output and input devices")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Bluetooth, "bluetooth", false, "Collect Bluetooth adapters with address and firmware, and paired devices with battery level")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Drivers, "drivers", false, "Collect loaded kernel modules, kexts or drivers with their versions")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Services, "services", false, "Collect running and failed service counts and the failed services (systemd, Windows services, launchd)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Firewall, "firewall", false, "Collect whether a firewall is active with its rule and zone summary (ufw, firewalld, nftables/iptables, pf, Windows Firewall)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Users, "users", false, "Collect logged-in users' sessions with terminal, login time and remote host, and local account counts")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Packages, "packages", false, "Collect installed packages with versions: dpkg, rpm, pacman, Homebrew, MSI and programs (not included in --all)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Sensors, "sensors", false, "Collect hardware monitoring temperature sensors (hwmon, SMC, OpenHardwareMonitor)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.Integrity, "integrity", false, "Hash critical system binaries and configuration files with SHA-256 (not included in --all)")
+	rootCmd.Flags().StringSliceVar(&cfg.IntegrityPaths, "integrity-path", nil, "Files or glob patterns hashed by --integrity, e.g. /usr/local/bin/* (default: critical system binaries and configs)")
+	rootCmd.Flags().BoolVar(&cfg.Modules.TimeSync, "timesync", false, "Measure clock offset against an NTP server (not included in --all)")
+	rootCmd.PersistentFlags().StringVar(&cfg.NTPServer, "ntp-server", "", "NTP server for --timesync and smart analyze --correct-clock (default: pool.ntp.org)")
+
+	// Process capture options
+	rootCmd.Flags().BoolVar(&cfg.ProcessCmdline, "process-cmdline", false, "Capture command lines of top processes (secrets are redacted)")
+	rootCmd.Flags().StringSliceVar(&cfg.ProcessEnv, "process-env", nil, "Environment variables to capture from top processes, e.g. JAVA_OPTS,PATH (secrets are redacted)")
+	rootCmd.Flags().IntVar(&cfg.ProcessNameWidth, "process-name-width", 0, "Characters of process names shown in pretty output (default 30)")
 }
 
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// ExitError carries a specific process exit code for commands used in scripts
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// applyGlobalSettings configures how collectors run external tools from the config file's
+// commands section, where they read the host's files, and the units sizes and width process
+// names are written in
+func applyGlobalSettings(cmd *cobra.Command, args []string) error {
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	units := firstNonEmpty(cfg.ByteUnits, fileConfig.Units)
+	if err := utils.ValidateByteUnits(units); err != nil {
+		return err
+	}
+	utils.SetByteUnits(units)
+
+	nameWidth := cfg.ProcessNameWidth
+	if nameWidth == 0 {
+		nameWidth = fileConfig.Process.NameWidth
+	}
+	if err := formatter.ValidateProcessNameWidth(nameWidth); err != nil {
+		return err
+	}
+	formatter.SetProcessNameWidth(nameWidth)
+
+	if err := collector.SetHostPaths(firstNonEmpty(cfg.HostRoot, fileConfig.HostRoot), firstNonEmpty(cfg.SysfsRoot, fileConfig.SysfsRoot)); err != nil {
+		return err
+	}
+
+	policy := fileConfig.Commands
+	if err := sandbox.Configure(sandbox.Policy{
+		Allow:      policy.Allow,
+		Restricted: policy.Restricted,
+		Paths:      policy.Paths,
+		Landlock:   policy.Landlock,
+		AuditLog:   policy.AuditLog,
+	}); err != nil {
+		return fmt.Errorf("invalid commands configuration: %w", err)
+	}
+	return nil
+}
+
+// selectSection validates --section and, when no module was picked, collects only what the section needs
+func selectSection(cfg *config.Config) error {
+	if cfg.Format != "csv" {
+		return fmt.Errorf("--section only applies to the csv format")
+	}
+	if err := formatter.ValidateCSVSection(cfg.Section); err != nil {
+		return err
+	}
+
+	m := &cfg.Modules
+	if m.System || m.CPU || m.Memory || m.Disk || m.Network || m.Process || m.SMART || m.GPU || m.Battery ||
+		m.Security || m.Accelerator || m.Thermal || m.Sensors || m.Baseboard || m.PCI || m.USB || m.Displays || m.Audio || m.Bluetooth || m.Drivers || m.Services || m.Firewall || m.Users || m.Packages || m.Integrity || m.TimeSync {
+		return nil
+	}
+	switch cfg.Section {
+	case "disk":
+		m.Disk = true
+	case "process":
+		m.Process = true
+	case "network":
+		m.Network = true
+	case "smart":
+		m.SMART = true
+	case "sensors":
+		m.Sensors = true
+	case "pci":
+		m.PCI = true
+	case "usb":
+		m.USB = true
+	case "displays":
+		m.Displays = true
+	case "audio":
+		m.Audio = true
+	case "drivers":
+		m.Drivers = true
+	case "users":
+		m.Users = true
+	case "packages":
+		m.Packages = true
+	case "integrity":
+		m.Integrity = true
+	}
+	return nil
+}
+
 func runSysInfo(cmd *cobra.Command, args []string) error {
 	// Load configuration file if it exists
 	fileConfig, err := config.LoadConfigFile(configFile)
@@ -68,12 +214,54 @@ func runSysInfo(cmd *cobra.Command, args []string) error {
 		return runFullDump()
 	}
 
+	if cfg.Section != "" {
+		if err := selectSection(cfg); err != nil {
+			return err
+		}
+	}
+
 	// If any specific module is selected, disable --all
 	if cfg.Modules.System || cfg.Modules.CPU || cfg.Modules.Memory ||
-		cfg.Modules.Disk || cfg.Modules.Network || cfg.Modules.Process || cfg.Modules.SMART || cfg.Modules.GPU || cfg.Modules.Battery {
+		cfg.Modules.Disk || cfg.Modules.Network || cfg.Modules.Process || cfg.Modules.SMART || cfg.Modules.GPU || cfg.Modules.Battery ||
+		cfg.Modules.Security || cfg.Modules.Accelerator || cfg.Modules.Thermal || cfg.Modules.Sensors || cfg.Modules.Baseboard ||
+		cfg.Modules.PCI || cfg.Modules.USB || cfg.Modules.Displays || cfg.Modules.Audio || cfg.Modules.Bluetooth || cfg.Modules.Drivers || cfg.Modules.Services || cfg.Modules.Firewall || cfg.Modules.Users || cfg.Modules.Packages || cfg.Modules.Integrity || cfg.Modules.TimeSync {
 		cfg.Modules.All = false
 	}
 
+	// Resolve output sinks before collecting so configuration errors fail fast
+	sinks, err := output.Build(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid output configuration: %w", err)
+	}
+	defer output.Close(sinks)
+
+	if err := utils.ValidateTimestampFormat(cfg.TimestampFormat); err != nil {
+		return err
+	}
+	if err := analyzer.ValidHealthWeights(cfg.HealthWeights); err != nil {
+		return fmt.Errorf("invalid health configuration: %w", err)
+	}
+	fields, err := collector.ParseFields(cfg.Fields)
+	if err != nil {
+		return err
+	}
+	if cfg.Query != "" {
+		if _, err := formatter.ParseQuery(cfg.Query); err != nil {
+			return err
+		}
+	} else if cfg.Format == "template" {
+		if _, err := formatter.ParseTemplate(cfg.TemplateFile); err != nil {
+			return err
+		}
+	}
+
+	var timestamp time.Time
+	if cfg.Timestamp != "" {
+		if timestamp, err = time.Parse(time.RFC3339, cfg.Timestamp); err != nil {
+			return fmt.Errorf("invalid timestamp %q: expected RFC 3339, e.g. 2024-01-02T15:04:05Z", cfg.Timestamp)
+		}
+	}
+
 	if cfg.Verbose {
 		fmt.Fprintf(os.Stderr, "Collecting system information...\n")
 	}
@@ -84,33 +272,119 @@ func runSysInfo(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to collect system information: %w", err)
 	}
 
+	if cfg.NoiseHistory && info.Thermal != nil && info.Thermal.Noise != nil {
+		// A missing database only costs the trend, not the report
+		if err := trackNoise(info.Thermal.Noise, fileConfig); err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: noise history unavailable: %v\n", err)
+		}
+	}
+
+	// Embedded history would make stable reports differ between runs
+	if !cfg.Stable && info.Disk != nil && len(info.Disk.SMARTData) > 0 {
+		if err := attachSMARTHistory(info.Disk.SMARTData, fileConfig); err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: SMART history unavailable: %v\n", err)
+		}
+	}
+
+	if cfg.Stable {
+		if timestamp.IsZero() {
+			timestamp = collector.StableTimestamp
+		}
+		collector.Stabilize(info, timestamp)
+	} else if !timestamp.IsZero() {
+		info.Timestamp = timestamp
+	}
+	if cfg.UTC {
+		collector.UseUTC(info)
+	}
+	info.TimestampFormat = cfg.TimestampFormat
+	if cfg.Redact {
+		collector.Redact(info)
+	}
+	collector.SelectFields(info, fields)
+
 	if cfg.Verbose {
 		fmt.Fprintf(os.Stderr, "Formatting output...\n")
 	}
 
-	// Format output
-	output, err := formatter.Format(info, cfg)
+	// Format and deliver to every configured sink
+	if err := output.WriteAll(sinks, info, cfg.Verbose); err != nil {
+		return err
+	}
+
+	// Check if we should pause (when double-clicked, not running from terminal);
+	// a query is read by scripts, which would capture the prompt
+	if cfg.Query == "" {
+		waitForEnter()
+	}
+
+	return nil
+}
+
+// historyWindow is how far back trends embedded in a report look
+const historyWindow = 30 * 24 * time.Hour
+
+// trackNoise records the noise estimate in the history database and adds the trend to the report
+func trackNoise(noise *types.NoiseEstimate, fileConfig *config.FileConfig) error {
+	dbPath, err := resolveSMARTDBPath("", fileConfig)
 	if err != nil {
-		return fmt.Errorf("failed to format output: %w", err)
+		return err
+	}
+	db, err := openHistoryDB(dbPath)
+	if err != nil {
+		return err
 	}
+	defer db.Close()
 
-	// Write output
-	if cfg.OutputFile != "" {
-		if cfg.Verbose {
-			fmt.Fprintf(os.Stderr, "Writing to file: %s\n", cfg.OutputFile)
-		}
-		err = os.WriteFile(cfg.OutputFile, []byte(output), 0644)
-		if err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
-		}
-		fmt.Fprintf(os.Stderr, "Output written to: %s\n", cfg.OutputFile)
-	} else {
-		fmt.Print(output)
+	if err := db.RecordNoise(noise.TotalDBA); err != nil {
+		return fmt.Errorf("failed to record noise estimate: %w", err)
 	}
+	trend, err := db.GetNoiseTrend(time.Now().Add(-historyWindow))
+	if err != nil {
+		return fmt.Errorf("failed to read noise history: %w", err)
+	}
+	noise.Trend = trend.Trend
+	noise.TrendDBPerDay = trend.DBPerDay
+	return nil
+}
 
-	// Check if we should pause (when double-clicked, not running from terminal)
-	waitForEnter()
+// attachSMARTHistory embeds each device's recent temperature and wear readings in the report
+// Nothing is attached, and no database is created, when no history has been recorded
+func attachSMARTHistory(devices []types.SMARTInfo, fileConfig *config.FileConfig) error {
+	dbPath, err := resolveSMARTDBPath("", fileConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil
+	}
+	db, err := openHistoryDB(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	since := time.Now().Add(-historyWindow)
+	for i := range devices {
+		records, err := db.GetHistory(devices[i].Device, since, 1000)
+		if err != nil {
+			return fmt.Errorf("failed to read history for %s: %w", devices[i].Device, err)
+		}
+		if len(records) == 0 {
+			continue
+		}
 
+		// GetHistory returns the newest record first
+		history := &types.SMARTHistory{Since: since, Points: make([]types.SMARTHistoryPoint, 0, len(records))}
+		for j := len(records) - 1; j >= 0; j-- {
+			history.Points = append(history.Points, types.SMARTHistoryPoint{
+				Timestamp:   records[j].Timestamp,
+				Temperature: records[j].Temperature,
+				PercentUsed: records[j].PercentUsed,
+			})
+		}
+		devices[i].History = history
+	}
 	return nil
 }
 
@@ -130,6 +404,11 @@ func runFullDump() error {
 		return fmt.Errorf("failed to collect system information: %w", err)
 	}
 
+	if cfg.Redact {
+		fmt.Fprintf(os.Stderr, "✓ Masking identifiers...\n")
+		collector.Redact(info)
+	}
+
 	fmt.Fprintf(os.Stderr, "✓ Formatting data to JSON...\n")
 	output, err := formatter.Format(info, dumpConfig)
 	if err != nil {
@@ -146,19 +425,13 @@ func runFullDump() error {
 	}
 
 	fileInfo, _ := os.Stat(filename)
-	sizeKB := float64(fileInfo.Size()) / 1024.0
-	sizeMB := sizeKB / 1024.0
 
 	fmt.Fprintf(os.Stderr, "\n")
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════════\n")
 	fmt.Fprintf(os.Stderr, "  FULL SYSTEM DUMP COMPLETE\n")
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════════\n")
 	fmt.Fprintf(os.Stderr, "  File: %s\n", filename)
-	if sizeMB >= 1.0 {
-		fmt.Fprintf(os.Stderr, "  Size: %.2f MB\n", sizeMB)
-	} else {
-		fmt.Fprintf(os.Stderr, "  Size: %.2f KB\n", sizeKB)
-	}
+	fmt.Fprintf(os.Stderr, "  Size: %s\n", utils.FormatBytes(uint64(fileInfo.Size())))
 	fmt.Fprintf(os.Stderr, "\n")
 	fmt.Fprintf(os.Stderr, "  Includes:\n")
 	fmt.Fprintf(os.Stderr, "    • System information\n")
@@ -169,6 +442,20 @@ func runFullDump() error {
 	fmt.Fprintf(os.Stderr, "    • Process information\n")
 	fmt.Fprintf(os.Stderr, "    • Comprehensive SMART data with health assessment\n")
 	fmt.Fprintf(os.Stderr, "    • GPU information\n")
+	fmt.Fprintf(os.Stderr, "    • NPU, TPU and FPGA accelerators\n")
+	fmt.Fprintf(os.Stderr, "    • Thermal zones and trip points\n")
+	fmt.Fprintf(os.Stderr, "    • Hardware monitoring temperature sensors\n")
+	fmt.Fprintf(os.Stderr, "    • Motherboard, BIOS and chassis\n")
+	fmt.Fprintf(os.Stderr, "    • PCI devices and their drivers\n")
+	fmt.Fprintf(os.Stderr, "    • Connected USB devices\n")
+	fmt.Fprintf(os.Stderr, "    • Connected displays\n")
+	fmt.Fprintf(os.Stderr, "    • Sound cards and audio devices\n")
+	fmt.Fprintf(os.Stderr, "    • Bluetooth adapters and paired devices\n")
+	fmt.Fprintf(os.Stderr, "    • Loaded kernel modules and drivers\n")
+	fmt.Fprintf(os.Stderr, "    • Service states and failed services\n")
+	fmt.Fprintf(os.Stderr, "    • Firewall state and rule summary\n")
+	fmt.Fprintf(os.Stderr, "    • Logged-in users and local accounts\n")
+	fmt.Fprintf(os.Stderr, "    • Security and compliance posture\n")
 	fmt.Fprintf(os.Stderr, "═══════════════════════════════════════════════════════════════\n")
 	fmt.Fprintf(os.Stderr, "\n")
 
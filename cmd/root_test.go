@@ -248,6 +248,82 @@ func TestRunSysInfoWithInvalidFormat(t *testing.T) {
 	}
 }
 
+func TestRunSysInfoStable(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputFile := filepath.Join(tmpDir, "output.json")
+
+	testCfg := config.NewConfig()
+	testCfg.Format = "json"
+	testCfg.OutputFile = outputFile
+	testCfg.Modules.System = true
+	testCfg.Stable = true
+	cfg = testCfg
+
+	if err := runSysInfo(&cobra.Command{}, []string{}); err != nil {
+		t.Fatalf("runSysInfo with --stable failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), `"timestamp": "1970-01-01T00:00:00Z"`) {
+		t.Errorf("Stable output should use the fixed timestamp, got: %s", content)
+	}
+
+	// An explicit timestamp overrides the fixed one
+	testCfg.Timestamp = "2024-05-01T12:00:00Z"
+	if err := runSysInfo(&cobra.Command{}, []string{}); err != nil {
+		t.Fatalf("runSysInfo with --timestamp failed: %v", err)
+	}
+	content, _ = os.ReadFile(outputFile)
+	if !strings.Contains(string(content), `"timestamp": "2024-05-01T12:00:00Z"`) {
+		t.Errorf("Output should use the --timestamp value, got: %s", content)
+	}
+}
+
+func TestRunSysInfoWithInvalidTimestamp(t *testing.T) {
+	testCfg := config.NewConfig()
+	testCfg.Format = "json"
+	testCfg.OutputFile = filepath.Join(t.TempDir(), "output.json")
+	testCfg.Timestamp = "yesterday"
+	cfg = testCfg
+
+	err := runSysInfo(&cobra.Command{}, []string{})
+	if err == nil || !strings.Contains(err.Error(), "invalid timestamp") {
+		t.Errorf("Expected invalid timestamp error, got %v", err)
+	}
+}
+
+func TestRunSysInfoUTCUnixTimestamp(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+
+	testCfg := config.NewConfig()
+	testCfg.Format = "json"
+	testCfg.OutputFile = outputFile
+	testCfg.Modules.System = true
+	testCfg.Timestamp = "2024-05-01T14:00:00+02:00"
+	testCfg.TimestampFormat = "rfc3339"
+	testCfg.UTC = true
+	cfg = testCfg
+
+	if err := runSysInfo(&cobra.Command{}, []string{}); err != nil {
+		t.Fatalf("runSysInfo failed: %v", err)
+	}
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), `"timestamp": "2024-05-01T12:00:00Z"`) {
+		t.Errorf("Output should contain the UTC timestamp, got: %s", content)
+	}
+
+	testCfg.TimestampFormat = "iso"
+	if err := runSysInfo(&cobra.Command{}, []string{}); err == nil || !strings.Contains(err.Error(), "invalid timestamp format") {
+		t.Errorf("Expected invalid timestamp format error, got %v", err)
+	}
+}
+
 func TestRunSysInfoWithInvalidOutputPath(t *testing.T) {
 	// Try to write to a directory that doesn't exist
 	invalidPath := "/this/path/does/not/exist/output.json"
@@ -476,3 +552,29 @@ func TestSMARTDataCollection(t *testing.T) {
 		t.Log("Note: SMART data may not be available on this system")
 	}
 }
+
+func TestSelectSection(t *testing.T) {
+	c := &config.Config{Format: "csv", Section: "process"}
+	if err := selectSection(c); err != nil {
+		t.Fatalf("selectSection() error = %v", err)
+	}
+	if !c.Modules.Process || c.Modules.Disk {
+		t.Errorf("Modules = %+v; want only process", c.Modules)
+	}
+
+	// An explicit module selection is left alone
+	c = &config.Config{Format: "csv", Section: "smart", Modules: config.ModuleConfig{Disk: true}}
+	if err := selectSection(c); err != nil {
+		t.Fatalf("selectSection() error = %v", err)
+	}
+	if c.Modules.SMART {
+		t.Error("SMART should not be added to an explicit selection")
+	}
+
+	if err := selectSection(&config.Config{Format: "json", Section: "disk"}); err == nil {
+		t.Error("expected error for --section without csv")
+	}
+	if err := selectSection(&config.Config{Format: "csv", Section: "gpu"}); err == nil {
+		t.Error("expected error for unknown section")
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/rpc"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var rpcVerbose bool
+
+// rpcCmd serves collection as JSON-RPC over stdin and stdout
+var rpcCmd = &cobra.Command{
+	Use:     "rpc",
+	Aliases: []string{"mcp"},
+	Short:   "Serve collection as JSON-RPC 2.0 over stdin/stdout (MCP compatible)",
+	Long: `Runs SysInfo as a long-lived JSON-RPC 2.0 server on stdin and stdout, so
+orchestration tools and AI agents can query modules without starting a new
+process for each question. Each request and response is one line of JSON;
+batches are supported. Logs go to stderr.
+
+Methods:
+  collect    Collect a report. Params (all optional):
+               modules   Modules to collect, e.g. ["cpu","memory"], or ["all"]
+                         (default: the modules enabled in the config file)
+               max_age   Return the last report of the same modules when it
+                         was collected within this duration, e.g. "30s"
+               redact    Mask serial numbers, addresses and the hostname
+  modules    List the module names
+  ping       Check the server is responding
+
+The server also speaks the Model Context Protocol (MCP) over stdio:
+initialize, tools/list and tools/call expose collect as a tool with the same
+parameters, so it can be registered with an MCP client directly.
+
+Examples:
+  echo '{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["cpu"]}}' | sysinfo rpc
+
+  MCP client configuration:
+    {"mcpServers": {"sysinfo": {"command": "sysinfo", "args": ["mcp"]}}}`,
+	Args: cobra.NoArgs,
+	RunE: runRPC,
+}
+
+func init() {
+	rootCmd.AddCommand(rpcCmd)
+
+	rpcCmd.Flags().BoolVarP(&rpcVerbose, "verbose", "v", false, "Log collection errors to stderr")
+}
+
+func runRPC(cmd *cobra.Command, args []string) error {
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config file: %w", err)
+	}
+
+	rpcConfig := config.NewConfig()
+	rpcConfig.Verbose = rpcVerbose
+	rpcConfig.UTC = cfg.UTC
+	rpcConfig.TimestampFormat = cfg.TimestampFormat
+	rpcConfig.NTPServer = cfg.NTPServer
+	rpcConfig.MergeWithFileConfig(fileConfig)
+	if err := utils.ValidateTimestampFormat(rpcConfig.TimestampFormat); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rpc.New(rpcConfig).Serve(ctx, os.Stdin, os.Stdout)
+}
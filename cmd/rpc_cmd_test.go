@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestRPCCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "rpc" && cmd.HasAlias("mcp") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'rpc' command to be registered with the 'mcp' alias")
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd prints the JSON Schema of the JSON report
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the JSON report",
+	Long: `Prints a JSON Schema (draft 2020-12) describing the report written by
+--format json, generated from sysinfo's own types, so downstream consumers can
+validate snapshots.
+
+Fields that are always written are required; fields that are left out when
+empty are optional. Unknown fields are rejected, so validate against the schema
+of the sysinfo version that wrote the reports.
+
+Examples:
+  sysinfo schema > sysinfo.schema.json
+  sysinfo -f json | check-jsonschema --schemafile sysinfo.schema.json -`,
+	Args: cobra.NoArgs,
+	RunE: runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	output, err := formatter.FormatSchema()
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
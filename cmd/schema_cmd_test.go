@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestSchemaCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "schema" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'schema' command to be registered")
+	}
+	if err := schemaCmd.Args(schemaCmd, []string{"extra"}); err == nil {
+		t.Error("Expected schema to reject arguments")
+	}
+}
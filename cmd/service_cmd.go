@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	serviceName        = "sysinfo"
+	serviceDisplayName = "SysInfo Agent"
+	serviceDescription = "Serves system reports, live metrics and SMART history over HTTP (sysinfo agent)."
+)
+
+// serviceCmd manages running the agent as a native Windows service
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Run the agent as a Windows service",
+	Long: `Registers and runs 'sysinfo agent' as a native Windows service managed by the
+Service Control Manager, without external wrappers such as NSSM.
+
+The agent flags given to 'install' (--listen, --interval, --db, --host and the
+global --config) are stored in the service's command line. Errors are written
+to the Application event log under the source "sysinfo".
+
+Run the install, uninstall, start and stop subcommands from an elevated prompt.
+On Linux, run 'sysinfo agent' under systemd instead (see README).
+
+Examples:
+  sysinfo service install --listen :8090 --config C:\ProgramData\sysinfo\config.yaml
+  sysinfo service start
+  sysinfo service stop
+  sysinfo service uninstall`,
+}
+
+var serviceInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Register the agent as an automatic-start service",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return installService(serviceRunArgs())
+	},
+}
+
+var serviceUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the service registration",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return uninstallService()
+	},
+}
+
+var serviceStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the installed service",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return startService()
+	},
+}
+
+var serviceStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running service",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return stopService()
+	},
+}
+
+// serviceRunCmd is the entry point the Service Control Manager starts
+// Run from a console it serves in the foreground, for debugging the service configuration
+var serviceRunCmd = &cobra.Command{
+	Use:    "run",
+	Short:  "Run as the service (invoked by the Service Control Manager)",
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runService()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serviceCmd)
+	serviceCmd.AddCommand(serviceInstallCmd, serviceUninstallCmd, serviceStartCmd, serviceStopCmd, serviceRunCmd)
+
+	// The service runs the agent, so it takes the agent's flags
+	for _, cmd := range []*cobra.Command{serviceInstallCmd, serviceRunCmd} {
+		cmd.Flags().StringVarP(&agentListen, "listen", "l", "127.0.0.1:8090", "Address to listen on")
+		cmd.Flags().DurationVarP(&agentInterval, "interval", "i", 2*time.Second, "Live metric sampling interval")
+		cmd.Flags().StringVar(&agentHost, "host", "", "Host whose SMART history the dashboard shows (default: this machine's hostname)")
+		cmd.Flags().StringVar(&agentDBPath, "db", "", "SMART history database for dashboard charts (default: same as 'smart' commands)")
+	}
+}
+
+// serviceRunArgs is the command line the service is registered with
+// Paths are made absolute because services start in the system directory
+func serviceRunArgs() []string {
+	args := []string{"service", "run", "--listen", agentListen, "--interval", agentInterval.String()}
+	if agentHost != "" {
+		args = append(args, "--host", agentHost)
+	}
+	if agentDBPath != "" {
+		args = append(args, "--db", absPath(agentDBPath))
+	}
+	if configFile != "" {
+		args = append(args, "--config", absPath(configFile))
+	}
+	return args
+}
+
+// absPath returns path as absolute, or unchanged if that fails
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// errServiceUnsupported is returned by the service commands outside Windows
+var errServiceUnsupported = errors.New("services are only supported on Windows; run 'sysinfo agent' under systemd instead")
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceCommandRegistered(t *testing.T) {
+	for _, name := range []string{"install", "uninstall", "start", "stop", "run"} {
+		found := false
+		for _, cmd := range serviceCmd.Commands() {
+			if cmd.Name() == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected 'service %s' command to be registered", name)
+		}
+	}
+}
+
+func TestServiceRunArgs(t *testing.T) {
+	oldListen, oldInterval, oldHost, oldDB, oldConfig := agentListen, agentInterval, agentHost, agentDBPath, configFile
+	defer func() {
+		agentListen, agentInterval, agentHost, agentDBPath, configFile = oldListen, oldInterval, oldHost, oldDB, oldConfig
+	}()
+
+	agentListen, agentInterval, agentHost, agentDBPath, configFile = ":8090", 5*time.Second, "", "", ""
+	expected := []string{"service", "run", "--listen", ":8090", "--interval", "5s"}
+	if got := serviceRunArgs(); !slices.Equal(got, expected) {
+		t.Errorf("serviceRunArgs = %v, expected %v", got, expected)
+	}
+
+	// Relative paths would resolve against the service's working directory, so they are made absolute
+	agentHost, agentDBPath, configFile = "web01", "smart.db", "config.yaml"
+	args := serviceRunArgs()
+	for _, flag := range []string{"--db", "--config"} {
+		i := slices.Index(args, flag)
+		if i < 0 || i+1 >= len(args) {
+			t.Fatalf("serviceRunArgs = %v, expected %s", args, flag)
+		}
+		if !filepath.IsAbs(args[i+1]) {
+			t.Errorf("%s = %q, expected an absolute path", flag, args[i+1])
+		}
+	}
+	if !strings.Contains(strings.Join(args, " "), "--host web01") {
+		t.Errorf("serviceRunArgs = %v, expected --host web01", args)
+	}
+}
+
+func TestServiceUnsupported(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("services are supported on Windows")
+	}
+	if err := runService(); err != errServiceUnsupported {
+		t.Errorf("runService = %v, expected %v", err, errServiceUnsupported)
+	}
+}
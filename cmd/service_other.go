@@ -0,0 +1,9 @@
+//go:build !windows
+
+package cmd
+
+func installService(args []string) error { return errServiceUnsupported }
+func uninstallService() error            { return errServiceUnsupported }
+func startService() error                { return errServiceUnsupported }
+func stopService() error                 { return errServiceUnsupported }
+func runService() error                  { return errServiceUnsupported }
@@ -0,0 +1,219 @@
+//go:build windows
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/debug"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// serviceStopTimeout bounds how long stop waits for the service to report Stopped
+const serviceStopTimeout = 30 * time.Second
+
+// agentService adapts the agent to the Service Control Manager
+type agentService struct {
+	log debug.Log
+}
+
+// Execute runs the agent and reports its state to the SCM until a stop or shutdown request
+func (s *agentService) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	const accepts = svc.AcceptStop | svc.AcceptShutdown
+	changes <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Report Running only once the listener is bound, so a failed start is visible in the SCM
+	ready := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- serveAgent(ctx, func() { close(ready) })
+	}()
+
+	for {
+		select {
+		case <-ready:
+			ready = nil
+			changes <- svc.Status{State: svc.Running, Accepts: accepts}
+			_ = s.log.Info(1, fmt.Sprintf("SysInfo agent listening on http://%s", agentListen))
+		case err := <-done:
+			if err != nil {
+				_ = s.log.Error(1, fmt.Sprintf("SysInfo agent stopped: %v", err))
+				return true, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				if err := <-done; err != nil {
+					_ = s.log.Error(1, fmt.Sprintf("SysInfo agent stopped: %v", err))
+					return true, 1
+				}
+				return false, 0
+			}
+		}
+	}
+}
+
+// runService serves under the SCM, or in the foreground when started from a console
+func runService() error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return fmt.Errorf("failed to detect service environment: %w", err)
+	}
+	if !isService {
+		return debug.Run(serviceName, &agentService{log: debug.New(serviceName)})
+	}
+
+	log, err := eventlog.Open(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer log.Close()
+
+	// Output written to stderr by the agent is lost under the SCM, so failures go to the event log
+	if err := svc.Run(serviceName, &agentService{log: log}); err != nil {
+		_ = log.Error(1, fmt.Sprintf("service failed: %v", err))
+		return err
+	}
+	return nil
+}
+
+// installService registers the service and its event log source
+func installService(args []string) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager (run as Administrator): %w", err)
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(serviceName); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s is already installed", serviceName)
+	}
+
+	s, err := m.CreateService(serviceName, exePath, mgr.Config{
+		DisplayName: serviceDisplayName,
+		Description: serviceDescription,
+		StartType:   mgr.StartAutomatic,
+	}, args...)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	defer s.Close()
+
+	// Restart after crashes: 5s, then 30s, then every minute
+	if err := s.SetRecoveryActions([]mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: time.Minute},
+	}, uint32((24 * time.Hour).Seconds())); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to set recovery actions: %v\n", err)
+	}
+
+	if err := eventlog.InstallAsEventCreate(serviceName, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		_ = s.Delete()
+		return fmt.Errorf("failed to register event log source: %w", err)
+	}
+
+	fmt.Printf("Installed service %s (%s)\n", serviceName, serviceDisplayName)
+	fmt.Println("Start it with 'sysinfo service start' or from services.msc")
+	return nil
+}
+
+// uninstallService removes the service and its event log source
+func uninstallService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager (run as Administrator): %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if err := eventlog.Remove(serviceName); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove event log source: %v\n", err)
+	}
+
+	fmt.Printf("Removed service %s\n", serviceName)
+	return nil
+}
+
+// startService asks the SCM to start the service
+func startService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager (run as Administrator): %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
+	}
+	fmt.Printf("Started service %s\n", serviceName)
+	return nil
+}
+
+// stopService asks the SCM to stop the service and waits for it to stop
+func stopService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager (run as Administrator): %w", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	status, err := s.Control(svc.Stop)
+	if err != nil {
+		return fmt.Errorf("failed to stop service: %w", err)
+	}
+
+	deadline := time.Now().Add(serviceStopTimeout)
+	for status.State != svc.Stopped {
+		if time.Now().After(deadline) {
+			return errors.New("timed out waiting for the service to stop")
+		}
+		time.Sleep(300 * time.Millisecond)
+		if status, err = s.Query(); err != nil {
+			return fmt.Errorf("failed to query service status: %w", err)
+		}
+	}
+
+	fmt.Printf("Stopped service %s\n", serviceName)
+	return nil
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// simulatedDevice names the drive synthesized when a simulation targets no real drive
+const simulatedDevice = "/dev/simulated0"
+
+// simulateSpec is the hidden --simulate developer flag of smart analyze and agent
+var simulateSpec string
+
+// smartSimulation is the parsed --simulate spec; nil outside a simulation
+var smartSimulation *simulation
+
+// simulation injects synthetic hardware failure states into polled SMART data, so alert
+// routing, cooldowns and webhook formatting can be rehearsed without a failing drive
+type simulation struct {
+	failures    []string // Devices reported as failing
+	temperature int      // Celsius reported by every drive, when set
+	wear        float64  // SSD percent used reported by every drive, when set
+}
+
+// parseSimulation parses a --simulate spec such as "smart-failure=/dev/sda,temp=85";
+// an empty spec is no simulation
+func parseSimulation(spec string) (*simulation, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	sim := &simulation{}
+	for _, part := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid simulation %q (expected key=value)", part)
+		}
+		switch key {
+		case "smart-failure":
+			sim.failures = append(sim.failures, value)
+		case "temp":
+			temperature, err := strconv.Atoi(value)
+			if err != nil || temperature <= 0 {
+				return nil, fmt.Errorf("invalid simulated temperature %q", value)
+			}
+			sim.temperature = temperature
+		case "wear":
+			wear, err := strconv.ParseFloat(value, 64)
+			if err != nil || wear <= 0 || wear > 100 {
+				return nil, fmt.Errorf("invalid simulated wear %q (expected a percentage)", value)
+			}
+			sim.wear = wear
+		default:
+			return nil, fmt.Errorf("unknown simulation %q (expected smart-failure, temp or wear)", key)
+		}
+	}
+	return sim, nil
+}
+
+// loadSimulation parses --simulate and warns that what follows is synthetic
+func loadSimulation() error {
+	sim, err := parseSimulation(simulateSpec)
+	if err != nil {
+		return err
+	}
+	smartSimulation = sim
+	if sim != nil {
+		fmt.Fprintf(os.Stderr, "SIMULATION: injecting synthetic SMART data (%s); history is not recorded\n", simulateSpec)
+	}
+	return nil
+}
+
+// apply overlays the simulated states on drives. A failure naming a drive that was not
+// polled, or a temperature or wear with no drives at all, adds a synthetic drive, so a
+// staging host without SMART access can still rehearse
+func (s *simulation) apply(drives []types.SMARTInfo) []types.SMARTInfo {
+	for _, device := range s.failures {
+		found := false
+		for i := range drives {
+			if drives[i].Device == device {
+				found = true
+			}
+		}
+		if !found {
+			drives = append(drives, simulatedDrive(device))
+		}
+	}
+	if len(drives) == 0 && (s.temperature > 0 || s.wear > 0) {
+		drives = append(drives, simulatedDrive(simulatedDevice))
+	}
+
+	for i := range drives {
+		smart := &drives[i]
+		if s.temperature > 0 {
+			smart.Temperature = s.temperature
+		}
+		if s.wear > 0 {
+			smart.HealthAssessment = simulatedHealth(smart.HealthAssessment)
+			smart.HealthAssessment.PercentUsed = s.wear
+		}
+		for _, device := range s.failures {
+			if smart.Device == device {
+				simulateFailure(smart)
+			}
+		}
+	}
+	return drives
+}
+
+func simulatedDrive(device string) types.SMARTInfo {
+	return types.SMARTInfo{
+		Device:      device,
+		DeviceModel: "SysInfo Simulated Drive",
+		Serial:      "SIMULATED",
+		Healthy:     true,
+	}
+}
+
+// simulatedHealth copies the drive's health assessment, so the collected one is not modified
+func simulatedHealth(health *types.SMARTHealthStatus) *types.SMARTHealthStatus {
+	if health == nil {
+		return &types.SMARTHealthStatus{Passed: true, OverallAssessment: "PASS"}
+	}
+	copied := *health
+	return &copied
+}
+
+// simulateFailure makes a drive look as a dying one does: a failed self-assessment with
+// reallocated and pending sectors
+func simulateFailure(smart *types.SMARTInfo) {
+	smart.Healthy = false
+	smart.HealthAssessment = simulatedHealth(smart.HealthAssessment)
+	smart.HealthAssessment.Passed = false
+	smart.HealthAssessment.OverallAssessment = "FAIL"
+
+	attributes := append([]types.SMARTAttribute(nil), smart.DetailedAttribs...)
+	for _, injected := range []types.SMARTAttribute{
+		{ID: 5, Name: "Reallocated_Sector_Ct", Type: "Pre-fail", Value: 5, Worst: 5, Threshold: 10, WhenFailed: "FAILING_NOW", RawValue: 2048, RawString: "2048"},
+		{ID: 197, Name: "Current_Pending_Sector", Type: "Old_age", Value: 100, Worst: 100, WhenFailed: "-", RawValue: 64, RawString: "64"},
+	} {
+		replaced := false
+		for i := range attributes {
+			if attributes[i].ID == injected.ID {
+				attributes[i] = injected
+				replaced = true
+			}
+		}
+		if !replaced {
+			attributes = append(attributes, injected)
+		}
+	}
+	smart.DetailedAttribs = attributes
+}
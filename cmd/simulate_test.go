@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestParseSimulation(t *testing.T) {
+	sim, err := parseSimulation("smart-failure=/dev/sda, temp=85,wear=97.5")
+	if err != nil {
+		t.Fatalf("parseSimulation failed: %v", err)
+	}
+	if len(sim.failures) != 1 || sim.failures[0] != "/dev/sda" || sim.temperature != 85 || sim.wear != 97.5 {
+		t.Errorf("simulation = %+v", sim)
+	}
+
+	if sim, err := parseSimulation(""); sim != nil || err != nil {
+		t.Errorf("empty spec = %v, %v, expected no simulation", sim, err)
+	}
+	for _, spec := range []string{"temp", "temp=hot", "temp=-5", "wear=150", "fan=0", "smart-failure="} {
+		if _, err := parseSimulation(spec); err == nil {
+			t.Errorf("parseSimulation(%q) succeeded, expected an error", spec)
+		}
+	}
+}
+
+func TestSimulationApply(t *testing.T) {
+	sim, _ := parseSimulation("smart-failure=/dev/sda,smart-failure=/dev/sdz,temp=85")
+	collected := []types.SMARTInfo{
+		{Device: "/dev/sda", Healthy: true, Temperature: 35, HealthAssessment: &types.SMARTHealthStatus{Passed: true},
+			DetailedAttribs: []types.SMARTAttribute{{ID: 5, Name: "Reallocated_Sector_Ct"}, {ID: 9, Name: "Power_On_Hours", RawValue: 1000}}},
+		{Device: "/dev/sdb", Healthy: true, Temperature: 30},
+	}
+	drives := sim.apply(collected)
+
+	if len(drives) != 3 || drives[2].Device != "/dev/sdz" || drives[2].Serial != "SIMULATED" {
+		t.Fatalf("drives = %+v, expected /dev/sdz synthesized", drives)
+	}
+	if !collected[0].HealthAssessment.Passed {
+		t.Error("the collected health assessment was modified")
+	}
+
+	smartAnalyzer := analyzer.NewSMARTAnalyzer()
+	for _, smart := range drives {
+		if smart.Temperature != 85 {
+			t.Errorf("%s temperature = %d, expected 85", smart.Device, smart.Temperature)
+		}
+		failed := false
+		for _, issue := range smartAnalyzer.Analyze(&smart).Issues {
+			failed = failed || issue.Code == "SMART_STATUS_FAILED"
+		}
+		if failed != (smart.Device != "/dev/sdb") {
+			t.Errorf("%s SMART status failed = %v", smart.Device, failed)
+		}
+	}
+	if len(drives[0].DetailedAttribs) != 3 || drives[0].DetailedAttribs[0].RawValue != 2048 {
+		t.Errorf("attributes = %+v, expected reallocated sectors replaced and pending sectors added", drives[0].DetailedAttribs)
+	}
+
+	// Without drives, a wear level still has one to report on
+	sim, _ = parseSimulation("wear=96")
+	drives = sim.apply(nil)
+	if len(drives) != 1 || drives[0].Device != simulatedDevice || drives[0].HealthAssessment.PercentUsed != 96 {
+		t.Errorf("drives = %+v, expected one simulated drive", drives)
+	}
+}
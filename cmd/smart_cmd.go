@@ -4,18 +4,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/mayvqt/sysinfo/internal/analyzer"
 	"github.com/mayvqt/sysinfo/internal/collector"
 	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/formatter"
 	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var (
-	smartPeriod string
-	smartDBPath string
+	smartPeriod       string
+	smartDBPath       string
+	smartCorrectClock bool
+	smartSkipStandby  bool
+	smartHost         string
+	smartLocateOff    bool
+	smartExportScan   bool
+	smartHistoryFmt   string
+	smartHistoryOut   string
 )
 
 // smartCmd represents the smart command
@@ -29,7 +39,11 @@ Examples:
   sysinfo smart analyze              # Analyze all drives with failure prediction
   sysinfo smart history              # Show 7-day trend history
   sysinfo smart history --period 30d # Show 30-day trends
-  sysinfo smart check                # Quick health check all drives`,
+  sysinfo smart history --host web01 # Show history recorded for another host
+  sysinfo smart check                # Quick health check all drives
+  sysinfo smart capabilities /dev/sda # Show which SMART features a drive supports
+  sysinfo smart locate /dev/sdd      # Blink the LED of the backplane slot holding a drive
+  sysinfo smart export /dev/sda      # Print a drive as smartctl -a -j would`,
 }
 
 // smartAnalyzeCmd performs deep SMART analysis
@@ -43,7 +57,10 @@ var smartAnalyzeCmd = &cobra.Command{
   - Temperature monitoring
   - Detailed recommendations
 
-Results are automatically stored in the history database.`,
+Results are automatically stored in the history database. With
+--correct-clock (or timesync.correct_history in the config file) the clock
+is first checked against an NTP server and records are stored at the
+corrected time.`,
 	RunE: runSmartAnalyze,
 }
 
@@ -55,7 +72,15 @@ var smartHistoryCmd = &cobra.Command{
   - Recent health records
   - Temperature trends (increasing/stable/decreasing)
   - Health status trends
-  - SSD wear rates and estimated end-of-life dates`,
+  - SSD wear rates and estimated end-of-life dates
+
+With --export csv, every record of the period is written as CSV instead, one
+row per reading with its time, temperature, health status and wear, for
+offline analysis in a spreadsheet.
+
+Examples:
+  sysinfo smart history --period 30d
+  sysinfo smart history --period 90d --export csv --output history.csv`,
 	RunE: runSmartHistory,
 }
 
@@ -68,6 +93,60 @@ Useful for scripts and monitoring systems.`,
 	RunE: runSmartCheck,
 }
 
+// smartCapabilitiesCmd reports which SMART features a device supports
+var smartCapabilitiesCmd = &cobra.Command{
+	Use:   "capabilities <device>",
+	Short: "Show which SMART features a drive supports",
+	Long: `Probes a drive and reports which SMART features it supports:
+  - Self-tests (short/extended, conveyance, selective)
+  - Error logging
+  - SCT (SMART Command Transport) and SCT error recovery control
+  - Sanitize
+  - TRIM
+
+Useful for understanding why certain analyzer features produce no data
+for a given drive.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSmartCapabilities,
+}
+
+// smartLocateCmd turns on the identification LED of a drive's enclosure slot
+var smartLocateCmd = &cobra.Command{
+	Use:   "locate <device>",
+	Short: "Blink the enclosure slot LED of a drive",
+	Long: `Finds the backplane slot holding a drive through SCSI enclosure services
+and turns on the slot's identification LED, so the right drive can be found
+and pulled. Use --off to turn the LED off again.
+
+Linux reads slots from the kernel ses driver (/sys/class/enclosure) and falls
+back to sg_ses from sg3-utils when the LED cannot be set through sysfs.
+Windows uses the Storage Management enclosure information.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSmartLocate,
+}
+
+// smartExportCmd prints SMART data in smartctl's JSON schema
+var smartExportCmd = &cobra.Command{
+	Use:   "export [device]",
+	Short: "Print SMART data as smartctl JSON",
+	Long: `Prints SMART data in the JSON schema of smartctl -a -j, so tools built
+around smartctl output (Scrutiny collectors, scripts using jq) can read it.
+This includes data smartctl cannot see itself, such as drives read through
+WMI on Windows.
+
+With a device, one smartctl document is printed; without one, a JSON array
+of documents for every drive. --scan prints the device list in the schema of
+smartctl --scan -j. The smartctl.exit_status bits are set as smartctl would
+set them for a failing drive, threshold crossings and log errors.
+
+Examples:
+  sysinfo smart export /dev/sda      # Same shape as smartctl -a -j /dev/sda
+  sysinfo smart export               # Every drive, as a JSON array
+  sysinfo smart export --scan        # Same shape as smartctl --scan -j`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSmartExport,
+}
+
 func init() {
 	// Add smart command to root
 	rootCmd.AddCommand(smartCmd)
@@ -76,22 +155,37 @@ func init() {
 	smartCmd.AddCommand(smartAnalyzeCmd)
 	smartCmd.AddCommand(smartHistoryCmd)
 	smartCmd.AddCommand(smartCheckCmd)
+	smartCmd.AddCommand(smartCapabilitiesCmd)
+	smartCmd.AddCommand(smartLocateCmd)
+	smartCmd.AddCommand(smartExportCmd)
 
 	// Shared flags for all smart subcommands
 	smartCmd.PersistentFlags().StringVar(&smartDBPath, "db", "", "Custom database path (default: smart.db next to binary)")
 	smartCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Verbose output")
+	smartCmd.PersistentFlags().StringVar(&smartHost, "host", "", "Host name to record and read history under (default: this machine's hostname)")
 
 	// History-specific flags
 	smartHistoryCmd.Flags().StringVar(&smartPeriod, "period", "7d", "Time period (e.g., 1h, 24h, 7d, 30d)")
+	smartHistoryCmd.Flags().StringVar(&smartHistoryFmt, "export", "", "Export the period's records instead of showing trends: csv, parquet")
+	smartHistoryCmd.Flags().StringVarP(&smartHistoryOut, "output", "o", "", "Write the export to a file instead of stdout")
 
 	// Analyze-specific flags
 	smartAnalyzeCmd.Flags().BoolVar(&cfg.SMARTAlerts, "alerts", false, "Send webhook alerts for critical issues")
+	smartAnalyzeCmd.Flags().BoolVar(&smartCorrectClock, "correct-clock", false, "Measure clock offset against NTP and record corrected times")
+	smartLocateCmd.Flags().BoolVar(&smartLocateOff, "off", false, "Turn the slot LED off")
+	smartExportCmd.Flags().BoolVar(&smartExportScan, "scan", false, "List devices as smartctl --scan -j does")
+	smartAnalyzeCmd.Flags().BoolVar(&smartSkipStandby, "skip-standby", false, "Do not wake drives in standby; record the skipped poll in history instead")
+	smartAnalyzeCmd.Flags().StringVar(&simulateSpec, "simulate", "", "Inject synthetic SMART data, e.g. smart-failure=/dev/sda,temp=85 (for rehearsing alerts)")
+	smartAnalyzeCmd.Flags().MarkHidden("simulate")
 }
 
 func runSmartAnalyze(cmd *cobra.Command, args []string) error {
 	if cfg.Verbose {
 		fmt.Fprintf(os.Stderr, "Initializing SMART analysis...\n")
 	}
+	if err := loadSimulation(); err != nil {
+		return err
+	}
 
 	// Setup database
 	db, fileConfig, err := initSMARTDatabase()
@@ -100,54 +194,80 @@ func runSmartAnalyze(cmd *cobra.Command, args []string) error {
 	}
 	defer db.Close()
 
+	if smartCorrectClock || (fileConfig != nil && fileConfig.TimeSync.CorrectHistory) {
+		applyClockCorrection(db, fileConfig)
+	}
+	recordBoot(db)
+
 	// Setup analyzer
 	smartAnalyzer := createAnalyzer(fileConfig)
 
 	// Setup alerts if enabled
 	var alertMgr *analyzer.AlertManager
 	if cfg.SMARTAlerts {
-		alertMgr = createAlertManager(fileConfig)
+		alertMgr = createAlertManager(fileConfig, db)
 	}
 
 	// Collect SMART data
-	diskData, err := collectSMARTData()
+	skipStandby := smartSkipStandby || (fileConfig != nil && fileConfig.SMART.SkipStandby)
+	drives, standby, err := pollSMART(skipStandby)
 	if err != nil {
 		return err
 	}
 
-	if len(diskData.SMARTData) == 0 {
-		fmt.Fprintf(os.Stderr, "No SMART data available. Try running with elevated privileges (sudo).\n")
+	for _, device := range standby {
+		fmt.Fprintf(os.Stderr, "Skipped %s: drive is in standby\n", device)
+	}
+	recordSkips(db, standby)
+
+	if len(drives) == 0 {
+		if len(standby) == 0 {
+			fmt.Fprintf(os.Stderr, "No SMART data available. Try running with elevated privileges (sudo).\n")
+		}
 		return nil
 	}
 
 	// Analyze each drive
-	for _, smart := range diskData.SMARTData {
+	for _, smart := range drives {
 		if cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Analyzing %s...\n", smart.Device)
 		}
 
-		result := smartAnalyzer.Analyze(&smart)
+		result := analyzeAndRecord(db, smartAnalyzer, alertMgr, &smart)
 
-		// Store to history
-		if err := db.RecordAnalysis(&smart, result); err != nil {
+		// Display results
+		displayAnalysisResult(result)
+	}
+
+	return nil
+}
+
+// analyzeAndRecord analyzes one drive, stores the result to history and sends alerts when enabled
+func analyzeAndRecord(db *analyzer.HistoryDB, smartAnalyzer *analyzer.SMARTAnalyzer, alertMgr *analyzer.AlertManager, smart *types.SMARTInfo) *analyzer.AnalysisResult {
+	result := smartAnalyzer.Analyze(smart)
+
+	// Store to history; simulated readings would skew the real drives' trends
+	if smartSimulation == nil {
+		if err := db.RecordAnalysis(smart, result); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to record history for %s: %v\n", smart.Device, err)
 		}
+	}
 
-		// Send alerts
-		if alertMgr != nil {
-			if err := alertMgr.CheckAndAlert(result); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to send alert for %s: %v\n", smart.Device, err)
-			}
+	// Send alerts
+	if alertMgr != nil {
+		if err := alertMgr.CheckAndAlert(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to send alert for %s: %v\n", smart.Device, err)
 		}
-
-		// Display results
-		displayAnalysisResult(result)
 	}
 
-	return nil
+	return result
 }
 
 func runSmartHistory(cmd *cobra.Command, args []string) error {
+	if smartHistoryFmt != "" && smartHistoryFmt != "csv" && smartHistoryFmt != "parquet" {
+		return fmt.Errorf("unknown export format: %s (expected csv or parquet)", smartHistoryFmt)
+	}
+
 	// Setup database
 	db, _, err := initSMARTDatabase()
 	if err != nil {
@@ -156,13 +276,17 @@ func runSmartHistory(cmd *cobra.Command, args []string) error {
 	defer db.Close()
 
 	// Parse time period
-	period, err := parseDuration(smartPeriod)
+	period, err := utils.ParseDuration(smartPeriod)
 	if err != nil {
 		return fmt.Errorf("invalid period format: %w", err)
 	}
 
 	since := time.Now().Add(-period)
 
+	if smartHistoryFmt != "" {
+		return exportSmartHistory(db, since)
+	}
+
 	// Get all devices
 	devices, err := db.GetDevices()
 	if err != nil {
@@ -170,13 +294,17 @@ func runSmartHistory(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(devices) == 0 {
-		fmt.Println("No historical SMART data available.")
+		fmt.Printf("No historical SMART data available for host %s.\n", db.Host())
+		if hosts, err := db.GetHosts(); err == nil && len(hosts) > 0 {
+			fmt.Printf("\nHosts with history: %s (select one with --host)\n", strings.Join(hosts, ", "))
+			return nil
+		}
 		fmt.Println("\nRun 'sysinfo smart analyze' to start collecting data.")
 		return nil
 	}
 
 	// Display header
-	fmt.Printf("SMART History (Last %s)\n", smartPeriod)
+	fmt.Printf("SMART History for %s (Last %s)\n", db.Host(), smartPeriod)
 	fmt.Println(repeatString("=", 70))
 
 	// Display history for each device
@@ -187,6 +315,15 @@ func runSmartHistory(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Hot-swap and network events recorded by the agent
+	if events, err := db.GetDeviceEvents(since); err == nil && len(events) > 0 {
+		fmt.Printf("\nDevice Events\n")
+		fmt.Println(repeatString("-", 70))
+		for _, event := range events {
+			fmt.Printf("  %s  %s\n", historyTime(event.Time), describeDeviceEvent(event))
+		}
+	}
+
 	return nil
 }
 
@@ -247,14 +384,121 @@ func runSmartCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSmartCapabilities(cmd *cobra.Command, args []string) error {
+	device := args[0]
+
+	if cfg.Verbose {
+		fmt.Fprintf(os.Stderr, "Probing SMART capabilities of %s...\n", device)
+	}
+
+	caps, err := collector.CollectSMARTCapabilities(device)
+	if err != nil {
+		return fmt.Errorf("failed to probe capabilities: %w", err)
+	}
+
+	displayCapabilities(caps)
+	return nil
+}
+
+func runSmartLocate(cmd *cobra.Command, args []string) error {
+	slot, err := collector.LocateDrive(args[0], !smartLocateOff)
+	if err != nil {
+		return err
+	}
+
+	state := "on"
+	if smartLocateOff {
+		state = "off"
+	}
+	fmt.Printf("%s: locate LED %s at %s\n", slot.Device, state, slot)
+	return nil
+}
+
+func runSmartExport(cmd *cobra.Command, args []string) error {
+	if smartExportScan && len(args) > 0 {
+		return fmt.Errorf("--scan does not take a device")
+	}
+
+	diskData, err := collectSMARTData()
+	if err != nil {
+		return err
+	}
+
+	var output string
+	switch {
+	case smartExportScan:
+		output, err = formatter.FormatSmartctlScan(diskData.SMARTData)
+	case len(args) == 1:
+		smart := findSMARTDevice(diskData.SMARTData, args[0])
+		if smart == nil {
+			return fmt.Errorf("no SMART data for %s", args[0])
+		}
+		output, err = formatter.FormatSmartctl(*smart)
+	default:
+		output, err = formatter.FormatSmartctlList(diskData.SMARTData)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(output)
+	return nil
+}
+
+// findSMARTDevice looks a drive up by device path, ignoring case for Windows drive names
+func findSMARTDevice(drives []types.SMARTInfo, device string) *types.SMARTInfo {
+	for i := range drives {
+		if strings.EqualFold(drives[i].Device, device) {
+			return &drives[i]
+		}
+	}
+	return nil
+}
+
 // Helper functions
 
 func initSMARTDatabase() (*analyzer.HistoryDB, *config.FileConfig, error) {
 	// Load config file
 	fileConfig, _ := config.LoadConfigFile(configFile)
 
-	// Determine database path
-	dbPath := smartDBPath
+	dbPath, err := resolveSMARTDBPath(smartDBPath, fileConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := openHistoryDB(dbPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if smartHost != "" {
+		db.SetHost(smartHost)
+	}
+
+	return db, fileConfig, nil
+}
+
+// applyClockCorrection measures the clock offset so history is recorded at corrected times
+// Recording continues uncorrected when the time server cannot be reached
+func applyClockCorrection(db *analyzer.HistoryDB, fileConfig *config.FileConfig) {
+	server := cfg.NTPServer
+	if server == "" && fileConfig != nil {
+		server = fileConfig.TimeSync.Server
+	}
+
+	offset, err := collector.MeasureClockOffset(server, 5*time.Second)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: clock correction disabled: %v\n", err)
+		return
+	}
+	if cfg.Verbose {
+		fmt.Fprintf(os.Stderr, "Clock offset: %+.1f ms vs %s\n", offset.OffsetMS, offset.Server)
+	}
+	db.SetClockOffset(time.Duration(offset.OffsetMS * float64(time.Millisecond)))
+}
+
+// resolveSMARTDBPath picks the history database from the --db flag, the config file, or the default
+func resolveSMARTDBPath(flagPath string, fileConfig *config.FileConfig) (string, error) {
+	dbPath := flagPath
 	if dbPath == "" && fileConfig != nil {
 		dbPath = fileConfig.SMART.DBPath
 	}
@@ -262,41 +506,55 @@ func initSMARTDatabase() (*analyzer.HistoryDB, *config.FileConfig, error) {
 		// Default to placing database next to the binary (for multi-OS support)
 		exePath, err := os.Executable()
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get executable path: %w", err)
+			return "", fmt.Errorf("failed to get executable path: %w", err)
 		}
 		exeDir := filepath.Dir(exePath)
 		dbPath = filepath.Join(exeDir, "smart.db")
 	}
+	return dbPath, nil
+}
 
+// openHistoryDB opens the SMART history database, creating its directory if needed
+func openHistoryDB(dbPath string) (*analyzer.HistoryDB, error) {
 	// Ensure directory exists
 	dbDir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		return nil, nil, fmt.Errorf("failed to create database directory: %w", err)
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
 	// Open database
 	db, err := analyzer.NewHistoryDB(dbPath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open SMART history database: %w", err)
+		return nil, fmt.Errorf("failed to open SMART history database: %w", err)
 	}
 
-	return db, fileConfig, nil
+	return db, nil
 }
 
 func createAnalyzer(fileConfig *config.FileConfig) *analyzer.SMARTAnalyzer {
-	if fileConfig != nil && fileConfig.SMART.AlertThresholds.TemperatureCritical > 0 {
-		return analyzer.NewSMARTAnalyzerWithConfig(analyzer.AnalyzerConfig{
-			TempWarning:      fileConfig.SMART.AlertThresholds.TemperatureWarning,
-			TempCritical:     fileConfig.SMART.AlertThresholds.TemperatureCritical,
-			WearWarning:      80.0,
-			WearCritical:     90.0,
-			EnablePredictive: true,
+	analyzerConfig := analyzer.DefaultAnalyzerConfig()
+	if fileConfig == nil {
+		return analyzer.NewSMARTAnalyzerWithConfig(analyzerConfig)
+	}
+
+	if fileConfig.SMART.AlertThresholds.TemperatureCritical > 0 {
+		analyzerConfig.TempWarning = fileConfig.SMART.AlertThresholds.TemperatureWarning
+		analyzerConfig.TempCritical = fileConfig.SMART.AlertThresholds.TemperatureCritical
+	}
+
+	for _, rating := range fileConfig.SMART.Endurance {
+		analyzerConfig.EnduranceRatings = append(analyzerConfig.EnduranceRatings, analyzer.EnduranceRating{
+			Model: rating.Model,
+			TBW:   rating.TBW,
 		})
 	}
-	return analyzer.NewSMARTAnalyzer()
+
+	return analyzer.NewSMARTAnalyzerWithConfig(analyzerConfig)
 }
 
-func createAlertManager(fileConfig *config.FileConfig) *analyzer.AlertManager {
+// createAlertManager sends alerts to the configured webhook; db, when set, records
+// the alerts delivered for the event timeline
+func createAlertManager(fileConfig *config.FileConfig, db *analyzer.HistoryDB) *analyzer.AlertManager {
 	webhookURL := ""
 	if fileConfig != nil {
 		webhookURL = fileConfig.SMART.WebhookURL
@@ -307,13 +565,35 @@ func createAlertManager(fileConfig *config.FileConfig) *analyzer.AlertManager {
 		fmt.Fprintf(os.Stderr, "Add 'webhook_url' to smart section in config file\n")
 	}
 
-	return analyzer.NewAlertManager(analyzer.AlertConfig{
+	alertConfig := analyzer.AlertConfig{
 		Enabled:        true,
 		WebhookURL:     webhookURL,
 		WebhookTimeout: 30,
 		MinLevel:       analyzer.AlertWarning,
 		Cooldown:       60,
-	})
+	}
+	// Alerts name the host the history is kept under, which --host may override
+	if db != nil {
+		alertConfig.Host = db.Host()
+	}
+	alertMgr := analyzer.NewAlertManager(alertConfig)
+	if smartSimulation != nil {
+		alertMgr.SetSimulated()
+	} else if db != nil {
+		alertMgr.SetHistory(db)
+	}
+	return alertMgr
+}
+
+// recordBoot notes when the host booted so reboots show in the event timeline
+func recordBoot(db *analyzer.HistoryDB) {
+	bootTime, err := collector.BootTime()
+	if err == nil {
+		err = db.RecordBoot(bootTime)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to record boot time: %v\n", err)
+	}
 }
 
 func collectSMARTData() (*types.DiskData, error) {
@@ -329,6 +609,74 @@ func collectSMARTData() (*types.DiskData, error) {
 	return diskData, nil
 }
 
+// pollSMART reads SMART data for analysis; with skipStandby, drives that are spun down are
+// returned separately instead of being woken. Under --simulate the synthetic states are applied
+func pollSMART(skipStandby bool) ([]types.SMARTInfo, []string, error) {
+	var drives []types.SMARTInfo
+	var standby []string
+	if !skipStandby {
+		diskData, err := collectSMARTData()
+		if err != nil {
+			return nil, nil, err
+		}
+		drives = diskData.SMARTData
+	} else {
+		if cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Collecting SMART data (skipping drives in standby)...\n")
+		}
+		drives, standby = collector.CollectSMARTSkipStandby()
+	}
+
+	if smartSimulation != nil {
+		drives = smartSimulation.apply(drives)
+	}
+	return drives, standby, nil
+}
+
+// recordSkips records skipped polls so history shows "skipped: standby" rather than a gap
+func recordSkips(db *analyzer.HistoryDB, standby []string) {
+	if smartSimulation != nil {
+		return
+	}
+	for _, device := range standby {
+		if err := db.RecordSkip(device, analyzer.SkipReasonStandby); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to record skipped poll for %s: %v\n", device, err)
+		}
+	}
+}
+
+// exportSmartHistory writes every record since the given time in the --export format
+func exportSmartHistory(db *analyzer.HistoryDB, since time.Time) error {
+	records, err := db.ExportHistory(since)
+	if err != nil {
+		return fmt.Errorf("failed to get history: %w", err)
+	}
+
+	loc := time.Local
+	if cfg.UTC {
+		loc = time.UTC
+	}
+	var output string
+	if smartHistoryFmt == "parquet" {
+		output, err = formatter.FormatSMARTHistoryParquet(records)
+	} else {
+		output, err = formatter.FormatSMARTHistoryCSV(records, loc)
+	}
+	if err != nil {
+		return err
+	}
+
+	if smartHistoryOut == "" {
+		fmt.Print(output)
+		return nil
+	}
+	if err := os.WriteFile(smartHistoryOut, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d records to: %s\n", len(records), smartHistoryOut)
+	return nil
+}
+
 func displayDeviceHistory(db *analyzer.HistoryDB, device string, since time.Time) error {
 	fmt.Printf("\nDevice: %s\n", device)
 	fmt.Println(repeatString("-", 70))
@@ -339,6 +687,14 @@ func displayDeviceHistory(db *analyzer.HistoryDB, device string, since time.Time
 		return fmt.Errorf("failed to get history: %w", err)
 	}
 
+	skips, err := db.GetSkipSummary(device, since)
+	if err != nil {
+		return fmt.Errorf("failed to get skipped polls: %w", err)
+	}
+	if skips != nil {
+		fmt.Printf("  Skipped Polls: %d (%s), last %s\n", skips.Count, skips.Reason, historyTime(skips.Last))
+	}
+
 	if len(history) == 0 {
 		fmt.Println("  No records in this period")
 		return nil
@@ -354,7 +710,7 @@ func displayDeviceHistory(db *analyzer.HistoryDB, device string, since time.Time
 	for i := 0; i < maxRecords; i++ {
 		record := history[i]
 		fmt.Printf("    %s | Health: %-8s | Temp: %3d°C | Issues: %d (Critical: %d)\n",
-			record.Timestamp.Format("2006-01-02 15:04"),
+			historyTime(record.Timestamp),
 			record.HealthStatus,
 			record.Temperature,
 			record.IssueCount,
@@ -384,10 +740,24 @@ func displayDeviceHistory(db *analyzer.HistoryDB, device string, since time.Time
 	return nil
 }
 
+// historyTime formats a recorded time in local time, or UTC with --utc
+func historyTime(t time.Time) string {
+	if cfg.UTC {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	return utils.FormatTimestamp(t, cfg.TimestampFormat, "2006-01-02 15:04")
+}
+
 func displayAnalysisResult(result *analyzer.AnalysisResult) {
 	fmt.Printf("\n%s\n", result.Device)
 	fmt.Println(repeatString("=", 70))
 
+	if result.Location != "" {
+		fmt.Printf("Location: %s\n", result.Location)
+	}
+
 	// Overall health
 	healthSymbol := getHealthSymbol(result.OverallHealth)
 	fmt.Printf("Overall Health: %s %s\n", healthSymbol, result.OverallHealth)
@@ -398,6 +768,9 @@ func displayAnalysisResult(result *analyzer.AnalysisResult) {
 	} else if result.FailureProbability > 20 {
 		fmt.Printf("Failure Risk: %.1f%%\n", result.FailureProbability)
 	}
+	if cfg.Verbose && len(result.Explanations) > 0 {
+		displayExplanations(result)
+	}
 
 	// SSD wear analysis
 	if result.SSDWearAnalysis != nil {
@@ -417,6 +790,46 @@ func displayAnalysisResult(result *analyzer.AnalysisResult) {
 	fmt.Println()
 }
 
+func displayCapabilities(caps *types.SMARTCapabilities) {
+	fmt.Printf("\n%s", caps.Device)
+	if caps.Protocol != "" {
+		fmt.Printf(" (%s)", caps.Protocol)
+	}
+	fmt.Println()
+	fmt.Println(repeatString("=", 70))
+
+	features := []struct {
+		name      string
+		supported bool
+		impact    string
+	}{
+		{"SMART supported", caps.SMARTSupported, "no SMART attributes or health status"},
+		{"SMART enabled", caps.SMARTEnabled, "enable with 'smartctl -s on'"},
+		{"Self-tests", caps.SelfTest, "self-test log will be empty"},
+		{"Conveyance self-test", caps.ConveyanceSelfTest, ""},
+		{"Selective self-test", caps.SelectiveSelfTest, ""},
+		{"Error logging", caps.ErrorLogging, "error log will be empty"},
+		{"SCT", caps.SCT, "no SCT temperature history"},
+		{"SCT error recovery control", caps.SCTErrorRecovery, ""},
+		{"Sanitize", caps.Sanitize, ""},
+		{"TRIM", caps.TRIM, ""},
+	}
+
+	for _, f := range features {
+		symbol := "✓"
+		if !f.supported {
+			symbol = "✗"
+		}
+		fmt.Printf("  %s %-28s", symbol, f.name)
+		if !f.supported && f.impact != "" {
+			fmt.Printf(" (%s)", f.impact)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+}
+
 func getHealthSymbol(health analyzer.HealthStatus) string {
 	switch health {
 	case analyzer.HealthGood:
@@ -440,6 +853,26 @@ func displaySSDWear(wear *analyzer.SSDWearInfo) {
 		years := float64(days) / 365.0
 		fmt.Printf("  Estimated Remaining: %d days (%.1f years)\n", days, years)
 	}
+	if wear.HostBytesWritten > 0 {
+		fmt.Printf("  Host Writes: %s\n", utils.FormatBytes(wear.HostBytesWritten))
+	}
+	if wear.DailyBytesWritten > 0 {
+		fmt.Printf("  Daily Writes: %s/day", utils.FormatBytes(uint64(wear.DailyBytesWritten)))
+		if wear.DriveWritesPerDay > 0 {
+			fmt.Printf(" (%.3f DWPD)", wear.DriveWritesPerDay)
+		}
+		fmt.Println()
+	}
+	if wear.WriteAmplification > 0 {
+		fmt.Printf("  Write Amplification: %.2fx\n", wear.WriteAmplification)
+	}
+	if wear.RatedTBW > 0 {
+		fmt.Printf("  Rated Endurance: %.0f TBW (%.1f%% consumed)\n", wear.RatedTBW, wear.TBWConsumedPercent)
+		if wear.EnduranceRemaining > 0 {
+			years := wear.EnduranceRemaining.Hours() / 24 / 365
+			fmt.Printf("  Warranty Endurance Left: ~%.1f years at current write rate\n", years)
+		}
+	}
 }
 
 func displayIssues(issues []analyzer.Issue) {
@@ -458,6 +891,14 @@ func displayIssues(issues []analyzer.Issue) {
 	}
 }
 
+// displayExplanations breaks the failure probability down into what contributed to it
+func displayExplanations(result *analyzer.AnalysisResult) {
+	fmt.Printf("\nFailure Probability Breakdown (%.1f%%):\n", result.FailureProbability)
+	for _, explanation := range result.Explanations {
+		fmt.Printf("  %+6.1f  %s\n", explanation.Points, explanation.Reason)
+	}
+}
+
 func displayRecommendations(recommendations []string) {
 	fmt.Println("\nRecommendations:")
 	for _, rec := range recommendations {
@@ -465,36 +906,6 @@ func displayRecommendations(recommendations []string) {
 	}
 }
 
-func parseDuration(s string) (time.Duration, error) {
-	if len(s) < 2 {
-		return 0, fmt.Errorf("invalid duration format")
-	}
-
-	unit := s[len(s)-1]
-	value := s[:len(s)-1]
-
-	var multiplier time.Duration
-	switch unit {
-	case 'h':
-		multiplier = time.Hour
-	case 'd':
-		multiplier = 24 * time.Hour
-	case 'w':
-		multiplier = 7 * 24 * time.Hour
-	case 'm':
-		multiplier = 30 * 24 * time.Hour
-	default:
-		return time.ParseDuration(s)
-	}
-
-	var num int
-	if _, err := fmt.Sscanf(value, "%d", &num); err != nil {
-		return 0, fmt.Errorf("invalid number in duration: %w", err)
-	}
-
-	return time.Duration(num) * multiplier, nil
-}
-
 func repeatString(s string, n int) string {
 	result := ""
 	for i := 0; i < n; i++ {
@@ -20,13 +20,13 @@ func TestSmartCommands_Help(t *testing.T) {
 	}
 
 	// Test subcommands are registered
-	if len(smartCmd.Commands()) != 3 {
-		t.Errorf("Expected 3 subcommands, got %d", len(smartCmd.Commands()))
+	if len(smartCmd.Commands()) != 6 {
+		t.Errorf("Expected 6 subcommands, got %d", len(smartCmd.Commands()))
 	}
 
 	subcommands := make(map[string]bool)
 	for _, cmd := range smartCmd.Commands() {
-		subcommands[cmd.Use] = true
+		subcommands[cmd.Name()] = true
 	}
 
 	if !subcommands["analyze"] {
@@ -38,46 +38,11 @@ func TestSmartCommands_Help(t *testing.T) {
 	if !subcommands["check"] {
 		t.Error("Expected 'check' subcommand to be registered")
 	}
-}
-
-func TestParseDuration(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-		wantErr  bool
-	}{
-		{"1h", "1h0m0s", false},
-		{"24h", "24h0m0s", false},
-		{"1d", "24h0m0s", false},
-		{"7d", "168h0m0s", false},
-		{"30d", "720h0m0s", false},
-		{"1w", "168h0m0s", false},
-		{"1m", "720h0m0s", false},
-		{"invalid", "", true},
-		{"", "", true},
-		{"x", "", true},
+	if !subcommands["capabilities"] {
+		t.Error("Expected 'capabilities' subcommand to be registered")
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			duration, err := parseDuration(tt.input)
-
-			if tt.wantErr {
-				if err == nil {
-					t.Errorf("Expected error for input %q, got nil", tt.input)
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("Unexpected error for input %q: %v", tt.input, err)
-				return
-			}
-
-			if duration.String() != tt.expected {
-				t.Errorf("For input %q: expected %s, got %s", tt.input, tt.expected, duration.String())
-			}
-		})
+	if !subcommands["locate"] {
+		t.Error("Expected 'locate' subcommand to be registered")
 	}
 }
 
@@ -203,14 +168,14 @@ func TestCreateAnalyzer(t *testing.T) {
 
 func TestCreateAlertManager(t *testing.T) {
 	// Test with nil config
-	manager := createAlertManager(nil)
+	manager := createAlertManager(nil, nil)
 	if manager == nil {
 		t.Error("Expected alert manager to be created with nil config")
 	}
 
 	// Test with config but no webhook URL
 	cfg := &config.FileConfig{}
-	manager = createAlertManager(cfg)
+	manager = createAlertManager(cfg, nil)
 	if manager == nil {
 		t.Error("Expected alert manager to be created with config but no webhook")
 	}
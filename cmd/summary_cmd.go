@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	summaryANSI  bool
+	summaryPlain bool
+)
+
+// summaryCmd prints a compact single-screen overview
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Show a compact one-screen system overview",
+	Long: `Prints a compact overview designed for MOTD/login banners: host and
+uptime, the composite health score, CPU load, memory usage, the fullest filesystem, the worst SMART
+status, the hottest GPU and battery charge.
+
+Colors are used when writing to a terminal. Use --ansi to force colors
+(e.g. when the output is cached for a login banner) or --plain to disable them.`,
+	RunE: runSummary,
+}
+
+func init() {
+	rootCmd.AddCommand(summaryCmd)
+
+	summaryCmd.Flags().BoolVar(&summaryANSI, "ansi", false, "Always emit ANSI colors")
+	summaryCmd.Flags().BoolVar(&summaryPlain, "plain", false, "Never emit ANSI colors")
+	summaryCmd.MarkFlagsMutuallyExclusive("ansi", "plain")
+}
+
+func runSummary(cmd *cobra.Command, args []string) error {
+	info, err := collectSummary()
+	if err != nil {
+		return err
+	}
+
+	ansi := isTerminal()
+	if summaryANSI {
+		ansi = true
+	}
+	if summaryPlain {
+		ansi = false
+	}
+
+	fmt.Print(formatter.FormatSummary(info, ansi))
+	return nil
+}
+
+// collectSummary gathers only the modules shown in the summary (processes are skipped)
+func collectSummary() (*types.SystemInfo, error) {
+	summaryConfig := config.NewConfig()
+	summaryConfig.Modules = config.ModuleConfig{
+		System:  true,
+		CPU:     true,
+		Memory:  true,
+		Disk:    true,
+		SMART:   true,
+		GPU:     true,
+		Battery: true,
+	}
+	summaryConfig.Verbose = cfg.Verbose
+
+	// Score health with the configured weights, like the full report
+	fileConfig, err := config.LoadConfigFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	if err := analyzer.ValidHealthWeights(fileConfig.Health.Weights); err != nil {
+		return nil, fmt.Errorf("invalid health configuration: %w", err)
+	}
+	summaryConfig.HealthWeights = fileConfig.Health.Weights
+
+	info, err := collector.Collect(summaryConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect system information: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestSummaryCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "summary" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected 'summary' command to be registered")
+	}
+
+	for _, name := range []string{"ansi", "plain"} {
+		if summaryCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected summary flag --%s to be defined", name)
+		}
+	}
+}
+
+func TestCollectSummary(t *testing.T) {
+	info, err := collectSummary()
+	if err != nil {
+		t.Fatalf("collectSummary failed: %v", err)
+	}
+
+	if info.System == nil {
+		t.Error("Expected system information in summary")
+	}
+	if info.Processes != nil {
+		t.Error("Summary should not collect process information")
+	}
+}
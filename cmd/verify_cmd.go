@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/requirements"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes for verify-requirements
+const (
+	verifyExitFailed  = 1 // One or more requirements not met
+	verifyExitInvalid = 2 // Manifest could not be loaded or data could not be collected
+)
+
+var (
+	verifyFormat     string
+	verifyReportFile string
+)
+
+// verifyCmd checks the machine against a requirements manifest
+var verifyCmd = &cobra.Command{
+	Use:   "verify-requirements <reqs.yaml>",
+	Short: "Check this machine against a hardware requirements manifest",
+	Long: `Collects system information and checks it against a declarative
+requirements file, printing pass/fail for each requirement.
+
+Exit codes:
+  0  all requirements met
+  1  one or more requirements not met
+  2  the requirements file is invalid or data could not be collected
+
+Example reqs.yaml:
+  name: Render Node
+  min_memory: 32GB
+  min_cores: 8
+  min_threads: 16
+  min_gpu_vram: 8GB
+  min_free_disk: 100GB
+  disk_path: /var/lib/render
+  os: [linux]
+  platforms: [ubuntu, debian]
+  min_os_version: "20.04"
+  max_os_version: "25.04"
+
+Provisioning checks validate a freshly built machine, e.g. at the end of a
+Packer or Terraform provisioner, with --report saving the results as JSON for
+the build's artifacts:
+  memory: 64GB                # Installed memory, not a minimum
+  disks:
+    - mount: /
+      fs_type: ext4
+      min_size: 40GB
+    - mount: /var/lib/docker
+      fs_type: xfs
+  drivers: [nvidia]           # Bound to a GPU or accelerator
+  no_smart_warnings: true     # Needs root; drives without SMART pass
+
+  sysinfo verify-requirements image.yaml --report /tmp/validation.json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runVerifyRequirements,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVarP(&verifyFormat, "format", "f", "text", "Output format: json, text")
+	verifyCmd.Flags().StringVar(&verifyReportFile, "report", "", "Also write the results as JSON to this file")
+}
+
+func runVerifyRequirements(cmd *cobra.Command, args []string) error {
+	manifest, err := requirements.LoadManifest(args[0])
+	if err != nil {
+		return &ExitError{Code: verifyExitInvalid, Err: err}
+	}
+
+	verifyConfig := config.NewConfig()
+	verifyConfig.Modules = config.ModuleConfig{
+		System: true,
+		CPU:    true,
+		Memory: true,
+		Disk:   true,
+		GPU:    true,
+		// Slower modules only when the manifest checks them
+		SMART:       manifest.NoSMARTWarnings,
+		Accelerator: len(manifest.Drivers) > 0,
+	}
+	verifyConfig.Verbose = cfg.Verbose
+
+	info, err := collector.Collect(verifyConfig)
+	if err != nil {
+		return &ExitError{Code: verifyExitInvalid, Err: fmt.Errorf("failed to collect system information: %w", err)}
+	}
+
+	report := requirements.Check(manifest, info)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return &ExitError{Code: verifyExitInvalid, Err: fmt.Errorf("failed to marshal JSON: %w", err)}
+	}
+
+	// The file is written before failing, so a build that stops here keeps the evidence
+	if verifyReportFile != "" {
+		if err := os.WriteFile(verifyReportFile, append(data, '\n'), 0644); err != nil {
+			return &ExitError{Code: verifyExitInvalid, Err: fmt.Errorf("failed to write report: %w", err)}
+		}
+	}
+
+	switch verifyFormat {
+	case "json":
+		fmt.Println(string(data))
+	case "text":
+		displayRequirementsReport(os.Stdout, report)
+	default:
+		return &ExitError{Code: verifyExitInvalid, Err: fmt.Errorf("unknown format: %s", verifyFormat)}
+	}
+
+	if !report.Passed {
+		return &ExitError{Code: verifyExitFailed, Err: fmt.Errorf("%d of %d requirements not met", countFailed(report), len(report.Results))}
+	}
+	return nil
+}
+
+// displayRequirementsReport prints one pass/fail line per requirement
+func displayRequirementsReport(w io.Writer, report *requirements.Report) {
+	passColor := color.New(color.FgGreen, color.Bold)
+	failColor := color.New(color.FgRed, color.Bold)
+
+	if report.Name != "" {
+		fmt.Fprintf(w, "Requirements: %s\n\n", report.Name)
+	}
+
+	for _, result := range report.Results {
+		status := passColor.Sprint("PASS")
+		if !result.Passed {
+			status = failColor.Sprint("FAIL")
+		}
+		fmt.Fprintf(w, "  %s  %-24s expected %-20s actual %s\n", status, result.Requirement, result.Expected, result.Actual)
+	}
+
+	fmt.Fprintln(w)
+	if report.Passed {
+		fmt.Fprintln(w, passColor.Sprint("✓ All requirements met"))
+	} else {
+		fmt.Fprintln(w, failColor.Sprintf("✗ %d requirement(s) not met", countFailed(report)))
+	}
+}
+
+// countFailed returns the number of failed checks in a report
+func countFailed(report *requirements.Report) int {
+	failed := 0
+	for _, result := range report.Results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/requirements"
+)
+
+func TestVerifyRequirementsExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		return path
+	}
+
+	verifyFormat = "json"
+	defer func() { verifyFormat = "text" }()
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{"met", write("met.yaml", "min_cores: 1\nmin_memory: 1MB\n"), 0},
+		{"not met", write("unmet.yaml", "min_memory: 1024TB\n"), verifyExitFailed},
+		{"invalid manifest", write("invalid.yaml", "min_memory: plenty\n"), verifyExitInvalid},
+		{"missing manifest", filepath.Join(dir, "missing.yaml"), verifyExitInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runVerifyRequirements(verifyCmd, []string{tt.path})
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("runVerifyRequirements() error = %v, expected nil", err)
+				}
+				return
+			}
+
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("runVerifyRequirements() error = %v, expected ExitError", err)
+			}
+			if exitErr.Code != tt.wantCode {
+				t.Errorf("exit code = %d, expected %d", exitErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDisplayRequirementsReport(t *testing.T) {
+	report := &requirements.Report{
+		Name: "Build Agent",
+		Results: []requirements.Result{
+			{Requirement: "Memory", Expected: ">= 8GB", Actual: "16.00 GB", Passed: true},
+			{Requirement: "CPU cores", Expected: ">= 16", Actual: "8", Passed: false},
+		},
+	}
+
+	var buf bytes.Buffer
+	displayRequirementsReport(&buf, report)
+	output := buf.String()
+
+	for _, want := range []string{"Build Agent", "PASS", "FAIL", "CPU cores", "1 requirement(s) not met"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("displayRequirementsReport() missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// LiveModules are the modules that can be streamed from /api/events
+// Slow or static modules (disk, SMART, processes, system) are only in /api/report
+var LiveModules = []string{"cpu", "memory", "network", "gpu", "battery"}
+
+// sampler collects one live module
+type sampler func() (any, error)
+
+// Server serves full reports and live metric streams over HTTP
+type Server struct {
+	cfg      *config.Config
+	interval time.Duration
+	mux      *http.ServeMux
+
+	// history backs the dashboard's history and alert endpoints when set
+	history *analyzer.HistoryDB
+
+	// grants restrict API access per token; empty means open access
+	grants []*grant
+
+	// onReady is called once the listener is bound and requests can be accepted
+	onReady func()
+
+	// health backs the /healthz and /readyz probes
+	health *Health
+
+	// newSamplers builds a fresh sampler set per stream so rate state is not shared between clients
+	newSamplers func() map[string]sampler
+	// historyLimit caps the rows a history or alerts request loads; eventBufferLimit the
+	// encoding buffer a stream keeps between samples
+	historyLimit     int
+	eventBufferLimit int
+
+	collect      func(*config.Config) (*types.SystemInfo, error)
+	collectSMART func() []types.SMARTInfo
+}
+
+// Defaults for AgentBuffers
+const (
+	defaultHistoryLimit     = 1000
+	defaultEventBufferLimit = 64 << 10
+)
+
+// New creates an agent server that samples live modules every interval
+func New(cfg *config.Config, interval time.Duration) *Server {
+	s := &Server{
+		cfg:              cfg,
+		interval:         interval,
+		mux:              http.NewServeMux(),
+		health:           NewHealth(),
+		newSamplers:      defaultSamplers,
+		historyLimit:     defaultHistoryLimit,
+		eventBufferLimit: defaultEventBufferLimit,
+		collect:          collector.Collect,
+		collectSMART:     collector.CollectSMART,
+	}
+	// Unauthenticated so orchestrators can probe without a token; they expose no report data
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.mux.HandleFunc("GET /{$}", s.handleDashboard)
+	s.mux.HandleFunc("GET /api/report", s.authorize(s.handleReport))
+	s.mux.HandleFunc("GET /api/events", s.authorize(s.handleEvents))
+	s.mux.HandleFunc("GET /api/smart", s.requireModule("smart", s.handleSMART))
+	s.mux.HandleFunc("GET /api/history", s.requireModule("smart", s.handleHistory))
+	s.mux.HandleFunc("GET /api/alerts", s.requireModule("smart", s.handleAlerts))
+	return s
+}
+
+// SetBuffers caps the history rows a request loads and the buffer each event stream keeps,
+// leaving the default for limits that are zero
+func (s *Server) SetBuffers(buffers config.AgentBuffers) error {
+	if buffers.HistoryRecords < 0 || buffers.EventBytes < 0 {
+		return fmt.Errorf("agent buffers must not be negative")
+	}
+	if buffers.HistoryRecords > 0 {
+		s.historyLimit = buffers.HistoryRecords
+	}
+	if buffers.EventBytes > 0 {
+		s.eventBufferLimit = buffers.EventBytes
+	}
+	return nil
+}
+
+// Handler returns the HTTP handler for the agent
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Health returns the tracker behind the health probes, for recording scheduled work and
+// registering dependency checks
+func (s *Server) Health() *Health {
+	return s.health
+}
+
+// SetReadyFunc registers a callback run once the agent is listening, e.g. to notify an init system
+func (s *Server) SetReadyFunc(fn func()) {
+	s.onReady = fn
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	// Bind before reporting readiness so address errors surface first
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve accepts connections on an already bound listener until ctx is cancelled,
+// e.g. one bound by a privileged parent process before dropping privileges
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Close idle keep-alive connections so abandoned clients do not hold buffers for days
+		IdleTimeout:    2 * time.Minute,
+		MaxHeaderBytes: 64 << 10,
+		BaseContext:    func(net.Listener) context.Context { return ctx },
+	}
+
+	if s.onReady != nil {
+		s.onReady()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// handleReport returns a full collection as JSON, limited to the modules the token allows
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	g := grantFrom(r)
+	reportConfig := s.reportConfig(g)
+	info, err := s.collect(reportConfig)
+	s.health.RecordCollection(reportConfig, info, err)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to collect system information: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if reportConfig.UTC {
+		collector.UseUTC(info)
+	}
+	info.TimestampFormat = reportConfig.TimestampFormat
+	var redactor *collector.Redactor
+	if reportConfig.Redact {
+		hostname := ""
+		if info.System != nil {
+			hostname = info.System.Hostname
+		}
+		redactor = collector.NewRedactor(hostname)
+	}
+	protect(info, redactor, g)
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(info)
+}
+
+// handleEvents streams live module samples as server-sent events
+// Each sample is sent as "event: <module>" with the module's JSON as data
+// ?modules=cpu,memory limits the stream to the listed modules
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	requested := r.URL.Query().Get("modules")
+	modules, err := parseModules(requested)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Explicitly requested modules must all be allowed, the default stream only includes allowed ones
+	g := grantFrom(r)
+	var allowed []string
+	for _, module := range modules {
+		if g.modules.Includes(module) {
+			allowed = append(allowed, module)
+		} else if requested != "" {
+			http.Error(w, fmt.Sprintf("token does not grant access to %s", module), http.StatusForbidden)
+			return
+		}
+	}
+	if len(allowed) == 0 {
+		http.Error(w, "token does not grant access to any live module", http.StatusForbidden)
+		return
+	}
+	modules = allowed
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Ask browsers to reconnect after one interval if the connection drops
+	fmt.Fprintf(w, "retry: %d\n\n", s.interval.Milliseconds())
+	flusher.Flush()
+
+	samplers := s.newSamplers()
+	// One buffer is reused for every event on this stream
+	var buf bytes.Buffer
+	// One redactor too, so a value keeps its placeholder from sample to sample
+	var redactor *collector.Redactor
+	if s.cfg.Redact {
+		hostname, _ := os.Hostname()
+		redactor = collector.NewRedactor(hostname)
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, module := range modules {
+			data, err := samplers[module]()
+			if err != nil {
+				if s.cfg.Verbose {
+					fmt.Fprintf(os.Stderr, "Error sampling %s: %v\n", module, err)
+				}
+				continue
+			}
+			protect(data, redactor, g)
+			if err := writeEvent(w, &buf, module, data); err != nil {
+				return
+			}
+			// A rare large sample must not pin its buffer for the life of the stream
+			if buf.Cap() > s.eventBufferLimit {
+				buf = bytes.Buffer{}
+			}
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeEvent writes one server-sent event, encoding data into buf
+func writeEvent(w http.ResponseWriter, buf *bytes.Buffer, event string, data any) error {
+	buf.Reset()
+	fmt.Fprintf(buf, "event: %s\ndata: ", event)
+	// Encode terminates the JSON with a newline, the second one ends the event
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// parseModules validates a comma-separated module list, defaulting to every live module
+func parseModules(value string) ([]string, error) {
+	if value == "" {
+		return LiveModules, nil
+	}
+
+	var modules []string
+	for _, module := range strings.Split(value, ",") {
+		module = strings.ToLower(strings.TrimSpace(module))
+		if module == "" {
+			continue
+		}
+		if !isLiveModule(module) {
+			return nil, fmt.Errorf("unknown live module: %s (available: %s)", module, strings.Join(LiveModules, ", "))
+		}
+		modules = append(modules, module)
+	}
+	if len(modules) == 0 {
+		return LiveModules, nil
+	}
+	return modules, nil
+}
+
+func isLiveModule(module string) bool {
+	for _, live := range LiveModules {
+		if live == module {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSamplers wraps the collectors for each live module
+// The network sampler keeps the previous sample to report per-interface throughput
+func defaultSamplers() map[string]sampler {
+	var previous *types.NetworkData
+	var lastSample time.Time
+
+	return map[string]sampler{
+		"cpu":     func() (any, error) { return collector.CollectCPU() },
+		"memory":  func() (any, error) { return collector.CollectMemory() },
+		"gpu":     func() (any, error) { return collector.CollectGPU() },
+		"battery": func() (any, error) { return collector.CollectBattery() },
+		"network": func() (any, error) {
+			data, err := collector.CollectNetwork()
+			if err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			collector.CalculateNetworkRates(previous, data, now.Sub(lastSample))
+			previous, lastSample = data, now
+			return data, nil
+		},
+	}
+}
@@ -0,0 +1,360 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// fakeSamplers returns a fixed payload per module
+func fakeSamplers() map[string]sampler {
+	samplers := make(map[string]sampler)
+	for _, module := range LiveModules {
+		samplers[module] = func() (any, error) {
+			return map[string]string{"module": module}, nil
+		}
+	}
+	return samplers
+}
+
+func newTestServer() *Server {
+	s := New(config.NewConfig(), 10*time.Millisecond)
+	s.newSamplers = fakeSamplers
+	return s
+}
+
+// readEvents reads SSE event names until count events have been seen
+func readEvents(t *testing.T, url string, count int) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, expected text/event-stream", got)
+	}
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && len(events) < count {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			events = append(events, name)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok && !strings.Contains(data, `"module"`) {
+			t.Errorf("unexpected event data: %s", data)
+		}
+	}
+	return events
+}
+
+func TestEventsStreamsAllLiveModules(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	events := readEvents(t, server.URL+"/api/events", len(LiveModules))
+	if strings.Join(events, ",") != strings.Join(LiveModules, ",") {
+		t.Errorf("events = %v, expected %v", events, LiveModules)
+	}
+}
+
+func TestEventsModuleFilter(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	// Two ticks of a single module
+	events := readEvents(t, server.URL+"/api/events?modules=memory", 2)
+	for _, event := range events {
+		if event != "memory" {
+			t.Errorf("event = %q, expected only memory", event)
+		}
+	}
+	if len(events) != 2 {
+		t.Errorf("received %d events, expected 2", len(events))
+	}
+}
+
+func TestEventsUnknownModule(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/events?modules=smart")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, expected %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestParseModules(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", strings.Join(LiveModules, ","), false},
+		{"cpu", "cpu", false},
+		{"CPU, network", "cpu,network", false},
+		{"cpu,,memory", "cpu,memory", false},
+		{"disk", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseModules(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseModules(%q) error = %v, expected error %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && strings.Join(got, ",") != tt.want {
+			t.Errorf("parseModules(%q) = %v, expected %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/api/events", "text/plain", nil)
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, expected %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestReportTimestampOptions(t *testing.T) {
+	s := newTestServer()
+	s.cfg.UTC = true
+	s.cfg.TimestampFormat = "rfc3339"
+	collected := time.Date(2026, 6, 1, 11, 12, 0, 0, time.FixedZone("CEST", 2*60*60))
+	s.collect = func(*config.Config) (*types.SystemInfo, error) {
+		return &types.SystemInfo{Timestamp: collected}, nil
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/report")
+	if err != nil {
+		t.Fatalf("GET /api/report failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `"timestamp": "2026-06-01T09:12:00Z"`) {
+		t.Errorf("report = %s, expected the timestamp in UTC as RFC 3339", body)
+	}
+}
+
+func TestReportRedacted(t *testing.T) {
+	s := newTestServer()
+	s.cfg.Redact = true
+	s.collect = func(*config.Config) (*types.SystemInfo, error) {
+		return &types.SystemInfo{
+			System: &types.SystemData{Hostname: "db-1.example.com"},
+			Disk:   &types.DiskData{PhysicalDisks: []types.PhysicalDisk{{Name: "sda", SerialNumber: "S3Z9NB0K123456"}}},
+		}, nil
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/report")
+	if err != nil {
+		t.Fatalf("GET /api/report failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, leaked := range []string{"db-1", "S3Z9NB0K"} {
+		if strings.Contains(string(body), leaked) {
+			t.Errorf("report contains %q with redact set", leaked)
+		}
+	}
+	if !strings.Contains(string(body), `"redacted": true`) {
+		t.Errorf("report = %s, expected it marked redacted", body)
+	}
+}
+
+func TestEventsRedacted(t *testing.T) {
+	s := newTestServer()
+	s.cfg.Redact = true
+	s.newSamplers = func() map[string]sampler {
+		return map[string]sampler{"network": func() (any, error) {
+			return &types.NetworkData{Interfaces: []types.NetworkInterface{
+				{Name: "eth0", HardwareAddr: "00:1a:7d:da:71:13", Addresses: []string{"192.168.1.20/24"}},
+			}}, nil
+		}}
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events?modules=network", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/events failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Two samples, so the placeholders are seen to stay the same across the stream
+	var samples []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && len(samples) < 2 {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			samples = append(samples, data)
+		}
+	}
+	if len(samples) != 2 {
+		t.Fatalf("received %d samples, expected 2", len(samples))
+	}
+	for _, sample := range samples {
+		for _, leaked := range []string{"00:1a:7d", "192.168.1.20"} {
+			if strings.Contains(sample, leaked) {
+				t.Errorf("sample %s contains %q with redact set", sample, leaked)
+			}
+		}
+		if !strings.Contains(sample, "mac-1") || !strings.Contains(sample, "ip-1") {
+			t.Errorf("sample = %s, expected the first MAC and IP placeholders", sample)
+		}
+	}
+}
+
+func TestListenAndServeReportsReady(t *testing.T) {
+	s := newTestServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ready := make(chan struct{})
+	s.SetReadyFunc(func() { close(ready) })
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+
+	select {
+	case <-ready:
+	case err := <-done:
+		t.Fatalf("ListenAndServe returned before ready: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ready callback was not called")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("ListenAndServe returned error: %v", err)
+	}
+}
+
+func TestListenAndServeBindError(t *testing.T) {
+	s := newTestServer()
+	s.SetReadyFunc(func() { t.Error("ready callback called for an unusable address") })
+
+	if err := s.ListenAndServe(context.Background(), "127.0.0.1:-1"); err == nil {
+		t.Error("expected error for invalid address")
+	}
+}
+
+// discardStream is a flushable response writer that drops events, calling onEvent after each
+type discardStream struct {
+	header  http.Header
+	events  int
+	onEvent func(events int)
+}
+
+func (d *discardStream) Header() http.Header { return d.header }
+func (d *discardStream) WriteHeader(int)     {}
+func (d *discardStream) Flush()              {}
+func (d *discardStream) Write(p []byte) (int, error) {
+	if bytes.HasPrefix(p, []byte("event: ")) {
+		d.events++
+		d.onEvent(d.events)
+	}
+	return len(p), nil
+}
+
+func TestEventStreamSteadyHeap(t *testing.T) {
+	s := newTestServer()
+	s.interval = time.Millisecond
+	calls := 0
+	s.newSamplers = func() map[string]sampler {
+		samplers := make(map[string]sampler)
+		for _, module := range LiveModules {
+			samplers[module] = func() (any, error) {
+				calls++
+				// One sample of a few megabytes, as a host with many GPU processes might produce
+				if calls == 1000 {
+					return map[string]string{"module": module, "padding": strings.Repeat("x", 4<<20)}, nil
+				}
+				return map[string]string{"module": module}, nil
+			}
+		}
+		return samplers
+	}
+
+	heapAlloc := func() uint64 {
+		var stats runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&stats)
+		return stats.HeapAlloc
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var warm, steady uint64
+	w := &discardStream{header: make(http.Header), onEvent: func(events int) {
+		switch events {
+		case 500:
+			warm = heapAlloc()
+		case 5000:
+			steady = heapAlloc()
+			cancel()
+		}
+	}}
+	req := httptest.NewRequest(http.MethodGet, "/api/events", nil).WithContext(ctx)
+	s.handleEvents(w, req)
+
+	if steady == 0 {
+		t.Fatalf("stream ended after %d events", w.events)
+	}
+	// The stream holds no more than its capped buffer once the large sample is sent
+	if steady > warm && steady-warm > 1<<20 {
+		t.Errorf("heap grew from %d to %d bytes over the stream, expected it to stay steady", warm, steady)
+	}
+}
+
+func TestSetBuffers(t *testing.T) {
+	s := newTestServer()
+	if err := s.SetBuffers(config.AgentBuffers{HistoryRecords: 100}); err != nil {
+		t.Fatalf("SetBuffers() error = %v", err)
+	}
+	if s.historyLimit != 100 || s.eventBufferLimit != defaultEventBufferLimit {
+		t.Errorf("limits = %d, %d; expected 100 and the default", s.historyLimit, s.eventBufferLimit)
+	}
+	if err := s.SetBuffers(config.AgentBuffers{EventBytes: -1}); err == nil {
+		t.Error("SetBuffers() accepted a negative limit")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/history?device=sda&limit=100000", nil)
+	if _, limit, err := historyWindow(req, s.historyLimit); err != nil || limit != 100 {
+		t.Errorf("historyWindow() limit = %d, %v; expected it held to 100", limit, err)
+	}
+}
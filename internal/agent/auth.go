@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+// grant is what the token presented with a request allows
+type grant struct {
+	name    string
+	token   string
+	modules config.ModuleConfig
+	serials bool
+}
+
+// openGrant applies when no tokens are configured
+var openGrant = &grant{name: "anonymous", modules: config.ModuleConfig{All: true}, serials: true}
+
+type grantKey struct{}
+
+// SetTokens requires one of the given tokens on every API request
+// Each token only sees its own modules, and serial numbers only when allowed
+func (s *Server) SetTokens(tokens []config.AgentToken) error {
+	grants := make([]*grant, 0, len(tokens))
+	for i, token := range tokens {
+		g := &grant{name: token.Name, token: token.Token, serials: token.Serials}
+		if g.name == "" {
+			g.name = fmt.Sprintf("token %d", i+1)
+		}
+		if token.Token == "" {
+			return fmt.Errorf("agent token %s: token is empty", g.name)
+		}
+		if len(token.Modules) == 0 {
+			return fmt.Errorf("agent token %s: no modules listed", g.name)
+		}
+		for _, module := range token.Modules {
+			if err := g.modules.Enable(strings.ToLower(strings.TrimSpace(module))); err != nil {
+				return fmt.Errorf("agent token %s: %w", g.name, err)
+			}
+		}
+		grants = append(grants, g)
+	}
+	s.grants = grants
+	return nil
+}
+
+// authorize resolves the request's token before calling next
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		g := openGrant
+		if len(s.grants) > 0 {
+			g = s.lookupGrant(requestToken(r))
+			if g == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="sysinfo"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), grantKey{}, g)))
+	}
+}
+
+// requireModule rejects requests whose token does not include module
+func (s *Server) requireModule(module string, next http.HandlerFunc) http.HandlerFunc {
+	return s.authorize(func(w http.ResponseWriter, r *http.Request) {
+		if !grantFrom(r).modules.Includes(module) {
+			http.Error(w, fmt.Sprintf("token does not grant access to %s", module), http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// lookupGrant finds the grant for a token, comparing in constant time
+func (s *Server) lookupGrant(token string) *grant {
+	if token == "" {
+		return nil
+	}
+	var found *grant
+	for _, g := range s.grants {
+		if subtle.ConstantTimeCompare([]byte(g.token), []byte(token)) == 1 {
+			found = g
+		}
+	}
+	return found
+}
+
+// requestToken reads "Authorization: Bearer <token>", falling back to ?token=
+// Browsers cannot set headers on EventSource connections, hence the query parameter
+func requestToken(r *http.Request) string {
+	if value, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(value)
+	}
+	return r.URL.Query().Get("token")
+}
+
+// grantFrom returns the grant attached by authorize
+func grantFrom(r *http.Request) *grant {
+	if g, ok := r.Context().Value(grantKey{}).(*grant); ok {
+		return g
+	}
+	return openGrant
+}
+
+// reportConfig limits the agent's configured modules to those the grant allows
+func (s *Server) reportConfig(g *grant) *config.Config {
+	reportCfg := *s.cfg
+	if g.modules.All {
+		return &reportCfg
+	}
+
+	reportCfg.Modules = config.ModuleConfig{}
+	for _, module := range config.ModuleNames {
+		if s.cfg.ShouldCollect(module) && g.modules.Includes(module) {
+			_ = reportCfg.Modules.Enable(module)
+		}
+	}
+	return &reportCfg
+}
+
+// protect masks a report or one live module's sample the same way for every endpoint:
+// identifiers when the agent redacts, then serial numbers and UUIDs for tokens without serial
+// access. redactor is nil when the agent does not redact
+func protect(data any, redactor *collector.Redactor, g *grant) {
+	if redactor != nil {
+		redactor.Redact(data)
+	}
+	if !g.serials {
+		stripSerials(data)
+	}
+}
+
+// stripSerials removes serial numbers and UUIDs from a report or one live module's sample,
+// for tokens without serial access
+func stripSerials(data any) {
+	collector.StripSerials(data)
+}
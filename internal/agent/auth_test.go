@@ -0,0 +1,205 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+var testTokens = []config.AgentToken{
+	{Name: "monitoring", Token: "mon-secret", Modules: []string{"cpu", "memory", "network"}},
+	{Name: "inventory", Token: "inv-secret", Modules: []string{"all"}, Serials: true},
+}
+
+func newTokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	s := newTestServer()
+	s.collectSMART = func() []types.SMARTInfo { return nil }
+	if err := s.SetTokens(testTokens); err != nil {
+		t.Fatalf("SetTokens() error = %v", err)
+	}
+	return httptest.NewServer(s.Handler())
+}
+
+func statusWithToken(t *testing.T, url, token string) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestSetTokensValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []config.AgentToken
+		wantErr string
+	}{
+		{"Valid", testTokens, ""},
+		{"Empty token", []config.AgentToken{{Name: "x", Modules: []string{"cpu"}}}, "token is empty"},
+		{"No modules", []config.AgentToken{{Name: "x", Token: "t"}}, "no modules"},
+		{"Unknown module", []config.AgentToken{{Name: "x", Token: "t", Modules: []string{"cpu", "secrets"}}}, "unknown module"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestServer().SetTokens(tt.tokens)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("SetTokens() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SetTokens() error = %v, expected to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenAccess(t *testing.T) {
+	server := newTokenServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{"Missing token", "/api/smart", "", http.StatusUnauthorized},
+		{"Wrong token", "/api/smart", "nope", http.StatusUnauthorized},
+		{"Module not granted", "/api/smart", "mon-secret", http.StatusForbidden},
+		{"Module granted", "/api/smart", "inv-secret", http.StatusOK},
+		{"Live module not granted", "/api/events?modules=gpu", "mon-secret", http.StatusForbidden},
+		{"Dashboard is public", "/", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusWithToken(t, server.URL+tt.path, tt.token); got != tt.want {
+				t.Errorf("GET %s status = %d, expected %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenLimitsDefaultStream(t *testing.T) {
+	server := newTokenServer(t)
+	defer server.Close()
+
+	// The query-string token is what the dashboard's EventSource uses
+	events := readEvents(t, server.URL+"/api/events?token=mon-secret", 3)
+	if strings.Join(events, ",") != "cpu,memory,network" {
+		t.Errorf("events = %v, expected only the granted cpu, memory and network", events)
+	}
+}
+
+func TestReportConfig(t *testing.T) {
+	s := newTestServer()
+	if err := s.SetTokens(testTokens); err != nil {
+		t.Fatalf("SetTokens() error = %v", err)
+	}
+
+	monitoring := s.reportConfig(s.lookupGrant("mon-secret"))
+	for _, module := range config.ModuleNames {
+		want := module == "cpu" || module == "memory" || module == "network"
+		if got := monitoring.ShouldCollect(module); got != want {
+			t.Errorf("monitoring ShouldCollect(%q) = %v, expected %v", module, got, want)
+		}
+	}
+
+	inventory := s.reportConfig(s.lookupGrant("inv-secret"))
+	if !inventory.ShouldCollect("process") {
+		t.Error("inventory token should collect processes")
+	}
+}
+
+func TestStripSerials(t *testing.T) {
+	info := &types.SystemInfo{
+		Memory: &types.MemoryData{Modules: []types.MemoryModule{{Locator: "DIMM0", SerialNumber: "MEM123"}}},
+		Disk: &types.DiskData{
+			PhysicalDisks: []types.PhysicalDisk{{Name: "sda", SerialNumber: "DISK123", Slot: &types.EnclosureSlot{Device: "sda", Serial: "DISK123", Slot: 4}}},
+			SMARTData:     []types.SMARTInfo{{Device: "/dev/sda", Serial: "DISK123"}},
+		},
+		GPU: &types.GPUData{GPUs: []types.GPUInfo{{
+			Name:       "GPU",
+			UUID:       "GPU-123",
+			Partitions: []types.GPUPartition{{Kind: "mig", Profile: "3g.40gb", UUID: "MIG-123"}},
+		}}},
+		Battery: &types.BatteryData{Batteries: []types.BatteryInfo{{Name: "BAT0", SerialNumber: "BAT123"}}},
+	}
+
+	stripSerials(info)
+
+	if info.Memory.Modules[0].SerialNumber != "" || info.Disk.PhysicalDisks[0].SerialNumber != "" || info.Disk.PhysicalDisks[0].Slot.Serial != "" ||
+		info.Disk.SMARTData[0].Serial != "" || info.GPU.GPUs[0].UUID != "" || info.GPU.GPUs[0].Partitions[0].UUID != "" || info.Battery.Batteries[0].SerialNumber != "" {
+		t.Errorf("serials not stripped: %+v", info)
+	}
+	if info.Memory.Modules[0].Locator != "DIMM0" || info.Disk.PhysicalDisks[0].Name != "sda" || info.Disk.PhysicalDisks[0].Slot.Slot != 4 {
+		t.Error("stripSerials removed non-serial fields")
+	}
+
+	// Sections that were not collected are left alone
+	stripSerials(&types.SystemInfo{})
+}
+
+func TestStripBaseboardSerials(t *testing.T) {
+	info := &types.SystemInfo{Baseboard: &types.BoardData{
+		SystemVendor: "Dell Inc.",
+		SystemSerial: "7XK2PQ3",
+		UUID:         "4c4c4544-0058-4b10-8032-b7c04f505133",
+		Product:      "0H3YY2",
+		Serial:       ".7XK2PQ3.CNFCW0099F00T1.",
+	}}
+
+	stripSerials(info)
+
+	if board := info.Baseboard; board.SystemSerial != "" || board.UUID != "" || board.Serial != "" {
+		t.Errorf("baseboard serials not stripped: %+v", board)
+	}
+	if board := info.Baseboard; board.SystemVendor != "Dell Inc." || board.Product != "0H3YY2" {
+		t.Error("stripSerials removed non-serial baseboard fields")
+	}
+}
+
+func TestStripUSBSerials(t *testing.T) {
+	info := &types.SystemInfo{USB: &types.USBData{Devices: []types.USBDevice{
+		{Address: "1-2", VendorID: "0781", ProductID: "5581", Product: "Ultra", Serial: "4C530001071205117433"},
+	}}}
+
+	stripSerials(info)
+
+	if device := info.USB.Devices[0]; device.Serial != "" || device.Address != "1-2" || device.Product != "Ultra" {
+		t.Errorf("USB device = %+v, expected only the serial stripped", device)
+	}
+
+	// On Windows the address is the PnP device ID, which ends in the serial
+	info.USB.Devices[0].Address = `USB\VID_0781&PID_5581\4C530001071205117433`
+	stripSerials(info)
+	if address := info.USB.Devices[0].Address; strings.Contains(address, "4C5300") {
+		t.Errorf("Address = %s, expected the serial stripped", address)
+	}
+}
+
+func TestStripDisplaySerials(t *testing.T) {
+	info := &types.SystemInfo{Displays: &types.DisplayData{Displays: []types.DisplayInfo{
+		{Name: "DP-1", Manufacturer: "Dell", Model: "DELL U2720Q", Serial: "F8KFX13", Year: 2021},
+	}}}
+
+	stripSerials(info)
+
+	if display := info.Displays.Displays[0]; display.Serial != "" || display.Model != "DELL U2720Q" || display.Year != 2021 {
+		t.Errorf("display = %+v, expected only the EDID serial stripped", display)
+	}
+}
@@ -0,0 +1,163 @@
+package agent
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+//go:embed dashboard/index.html
+var dashboardFS embed.FS
+
+// defaultHistoryPeriod is used when ?period is not given
+const defaultHistoryPeriod = "7d"
+
+// SMARTStatus is the per-drive health badge shown on the dashboard
+type SMARTStatus struct {
+	Device             string                `json:"device"`
+	Model              string                `json:"model,omitempty"`
+	Health             analyzer.HealthStatus `json:"health"`
+	Temperature        int                   `json:"temperature_celsius,omitempty"`
+	FailureProbability float64               `json:"failure_probability"`
+	PredictedFailure   bool                  `json:"predicted_failure"`
+	RemainingLife      *float64              `json:"remaining_life,omitempty"`
+	Issues             int                   `json:"issues"`
+}
+
+// SetHistory enables the history and alert endpoints backed by the SMART history database
+func (s *Server) SetHistory(db *analyzer.HistoryDB) {
+	s.history = db
+}
+
+// handleDashboard serves the embedded single-page dashboard
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	page, err := dashboardFS.ReadFile("dashboard/index.html")
+	if err != nil {
+		http.Error(w, "dashboard unavailable", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+// handleSMART analyzes current SMART data for each drive
+func (s *Server) handleSMART(w http.ResponseWriter, r *http.Request) {
+	smartAnalyzer := analyzer.NewSMARTAnalyzer()
+
+	statuses := []SMARTStatus{}
+	for _, smart := range s.collectSMART() {
+		result := smartAnalyzer.Analyze(&smart)
+		status := SMARTStatus{
+			Device:             smart.Device,
+			Model:              smart.DeviceModel,
+			Health:             result.OverallHealth,
+			Temperature:        smart.Temperature,
+			FailureProbability: result.FailureProbability,
+			PredictedFailure:   result.PredictedFailure,
+			Issues:             len(result.Issues),
+		}
+		if result.SSDWearAnalysis != nil {
+			remaining := result.SSDWearAnalysis.RemainingLife
+			status.RemainingLife = &remaining
+		}
+		statuses = append(statuses, status)
+	}
+
+	writeJSON(w, statuses)
+}
+
+// handleHistory lists recorded devices, or returns one device's readings with ?device=
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if s.history == nil {
+		http.Error(w, "history database not configured", http.StatusNotFound)
+		return
+	}
+
+	device := r.URL.Query().Get("device")
+	if device == "" {
+		devices, err := s.history.GetDevices()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read history: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if devices == nil {
+			devices = []string{}
+		}
+		writeJSON(w, devices)
+		return
+	}
+
+	since, limit, err := historyWindow(r, s.historyLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records, err := s.history.GetHistory(device, since, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if records == nil {
+		records = []analyzer.SMARTHistoryRecord{}
+	}
+	writeJSON(w, records)
+}
+
+// handleAlerts returns recorded SMART issues across all devices, newest first
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	if s.history == nil {
+		http.Error(w, "history database not configured", http.StatusNotFound)
+		return
+	}
+
+	since, limit, err := historyWindow(r, s.historyLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	issues, err := s.history.GetRecentIssues(since, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read alerts: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if issues == nil {
+		issues = []analyzer.IssueRecord{}
+	}
+	writeJSON(w, issues)
+}
+
+// historyWindow reads ?period= (default 7d) and ?limit= (default 500), holding the limit to
+// maxLimit so one request cannot load the whole database into memory
+func historyWindow(r *http.Request, maxLimit int) (time.Time, int, error) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = defaultHistoryPeriod
+	}
+	duration, err := utils.ParseDuration(period)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid period: %s", period)
+	}
+
+	limit := 500
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return time.Time{}, 0, fmt.Errorf("invalid limit: %s", value)
+		}
+	}
+	limit = min(limit, maxLimit)
+
+	return time.Now().Add(-duration), limit, nil
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
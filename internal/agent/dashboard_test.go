@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s returned invalid JSON: %v", url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestDashboardServed(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatalf("GET / failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("Content-Type = %q, expected text/html", resp.Header.Get("Content-Type"))
+	}
+	for _, endpoint := range []string{"api/events", "api/smart", "api/history", "api/alerts"} {
+		if !strings.Contains(string(body), endpoint) {
+			t.Errorf("dashboard does not reference %s", endpoint)
+		}
+	}
+
+	// Unknown paths are not the dashboard
+	resp, err = http.Get(server.URL + "/missing")
+	if err != nil {
+		t.Fatalf("GET /missing failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /missing status = %d, expected %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestSMARTEndpoint(t *testing.T) {
+	s := newTestServer()
+	s.collectSMART = func() []types.SMARTInfo {
+		return []types.SMARTInfo{{Device: "/dev/sda", DeviceModel: "Test Disk", Healthy: true, Temperature: 35}}
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	var statuses []SMARTStatus
+	if status := getJSON(t, server.URL+"/api/smart", &statuses); status != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", status)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("received %d drives, expected 1", len(statuses))
+	}
+	if statuses[0].Device != "/dev/sda" || statuses[0].Model != "Test Disk" || statuses[0].Temperature != 35 {
+		t.Errorf("unexpected status: %+v", statuses[0])
+	}
+	if statuses[0].Health == "" {
+		t.Error("expected a health status")
+	}
+}
+
+func TestHistoryEndpoints(t *testing.T) {
+	db, err := analyzer.NewHistoryDB(filepath.Join(t.TempDir(), "smart.db"))
+	if err != nil {
+		t.Fatalf("failed to open history database: %v", err)
+	}
+	defer db.Close()
+
+	smart := &types.SMARTInfo{Device: "/dev/sda", Temperature: 40, DetailedAttribs: []types.SMARTAttribute{}}
+	result := &analyzer.AnalysisResult{
+		Device:        "/dev/sda",
+		OverallHealth: analyzer.HealthWarning,
+		Issues: []analyzer.Issue{
+			{Severity: analyzer.SeverityWarning, Code: "HIGH_TEMP_WARNING", Description: "Temperature warning"},
+		},
+	}
+	if err := db.RecordAnalysis(smart, result); err != nil {
+		t.Fatalf("failed to record analysis: %v", err)
+	}
+
+	s := newTestServer()
+	s.SetHistory(db)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	var devices []string
+	if status := getJSON(t, server.URL+"/api/history", &devices); status != http.StatusOK {
+		t.Fatalf("devices status = %d, expected 200", status)
+	}
+	if len(devices) != 1 || devices[0] != "/dev/sda" {
+		t.Errorf("devices = %v, expected [/dev/sda]", devices)
+	}
+
+	var records []analyzer.SMARTHistoryRecord
+	if status := getJSON(t, server.URL+"/api/history?device=/dev/sda&period=24h", &records); status != http.StatusOK {
+		t.Fatalf("history status = %d, expected 200", status)
+	}
+	if len(records) != 1 || records[0].Temperature != 40 {
+		t.Errorf("records = %+v, expected one reading at 40°C", records)
+	}
+
+	var alerts []analyzer.IssueRecord
+	if status := getJSON(t, server.URL+"/api/alerts", &alerts); status != http.StatusOK {
+		t.Fatalf("alerts status = %d, expected 200", status)
+	}
+	if len(alerts) != 1 || alerts[0].Code != "HIGH_TEMP_WARNING" {
+		t.Errorf("alerts = %+v, expected one HIGH_TEMP_WARNING", alerts)
+	}
+
+	if status := getJSON(t, server.URL+"/api/alerts?period=bogus", &alerts); status != http.StatusBadRequest {
+		t.Errorf("invalid period status = %d, expected 400", status)
+	}
+}
+
+func TestHistoryEndpointsWithoutDatabase(t *testing.T) {
+	server := httptest.NewServer(newTestServer().Handler())
+	defer server.Close()
+
+	for _, path := range []string{"/api/history", "/api/alerts"} {
+		var v any
+		if status := getJSON(t, server.URL+path, &v); status != http.StatusNotFound {
+			t.Errorf("GET %s status = %d, expected 404", path, status)
+		}
+	}
+}
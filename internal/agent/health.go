@@ -0,0 +1,261 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/scheduler"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Health probe statuses
+const (
+	healthOK        = "ok"
+	healthUnhealthy = "unhealthy"
+)
+
+// taskGrace is how long past its second missed due time a task may still finish before the
+// agent counts as wedged, covering slow runs such as polling SMART on many drives
+const taskGrace = 5 * time.Minute
+
+// checkTimeout bounds each readiness check, so probes are answered before orchestrators give up
+const checkTimeout = 3 * time.Second
+
+// errNotCollected is recorded for a requested module the report came back without
+var errNotCollected = errors.New("no data collected")
+
+// Health tracks the agent's own state for the /healthz and /readyz probes: when each module
+// was last collected, whether the scheduled tasks keep running, and checks of what the agent
+// depends on, such as the history database and the alert webhook
+type Health struct {
+	mu      sync.Mutex
+	started time.Time
+	modules map[string]*ModuleHealth
+	tasks   []*taskState
+	checks  map[string]func(context.Context) error
+
+	now func() time.Time
+}
+
+// ModuleHealth is the outcome of a module's recent collections
+type ModuleHealth struct {
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+	Error       string     `json:"error,omitempty"` // Why the last failed collection failed
+}
+
+// TaskHealth is the state of one scheduled task
+type TaskHealth struct {
+	Name    string     `json:"name"`
+	LastRun *time.Time `json:"last_run,omitempty"` // When the last run finished, successfully or not
+	Error   string     `json:"error,omitempty"`    // Error of the last run
+	Overdue bool       `json:"overdue"`            // Missed two runs in a row, so it is stuck
+}
+
+// CheckResult is the outcome of one readiness check
+type CheckResult struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// HealthReport is the body of /healthz and /readyz
+type HealthReport struct {
+	Status  string                  `json:"status"`
+	Started time.Time               `json:"started"`
+	Modules map[string]ModuleHealth `json:"modules,omitempty"`
+	Tasks   []TaskHealth            `json:"tasks,omitempty"`
+	Checks  map[string]CheckResult  `json:"checks,omitempty"` // Only checked for readiness
+}
+
+type taskState struct {
+	name     string
+	schedule scheduler.Schedule
+	lastRun  time.Time
+	err      string
+}
+
+// NewHealth creates a tracker for an agent starting now
+func NewHealth() *Health {
+	return &Health{
+		started: time.Now(),
+		modules: map[string]*ModuleHealth{},
+		checks:  map[string]func(context.Context) error{},
+		now:     time.Now,
+	}
+}
+
+// Track wraps a scheduled task's run so liveness notices when it stops finishing
+func (h *Health) Track(name string, schedule scheduler.Schedule, run func(context.Context) error) func(context.Context) error {
+	task := &taskState{name: name, schedule: schedule}
+	h.mu.Lock()
+	h.tasks = append(h.tasks, task)
+	h.mu.Unlock()
+
+	return func(ctx context.Context) error {
+		err := run(ctx)
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		task.lastRun = h.now()
+		task.err = ""
+		if err != nil {
+			task.err = err.Error()
+		}
+		return err
+	}
+}
+
+// RecordCollection records the outcome of a report collected with cfg for each module it
+// requested; err is Collect's error, failing every module
+func (h *Health) RecordCollection(cfg *config.Config, info *types.SystemInfo, err error) {
+	for _, module := range config.ModuleNames {
+		if !cfg.ShouldCollect(module) {
+			continue
+		}
+		switch {
+		case err != nil:
+			h.RecordModule(module, err)
+		case collector.Collected(info, module):
+			h.RecordModule(module, nil)
+		default:
+			h.RecordModule(module, skipReason(info, module))
+		}
+	}
+}
+
+// skipReason returns why the report left a module out
+func skipReason(info *types.SystemInfo, module string) error {
+	for _, e := range info.Errors {
+		if e.Module == module {
+			return errors.New(e.Error)
+		}
+	}
+	return errNotCollected
+}
+
+// RecordModule records one collection of a module, successful when err is nil
+func (h *Health) RecordModule(module string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.modules[module]
+	if m == nil {
+		m = &ModuleHealth{}
+		h.modules[module] = m
+	}
+	now := h.now()
+	if err != nil {
+		m.LastFailure, m.Error = &now, err.Error()
+	} else {
+		m.LastSuccess = &now
+	}
+}
+
+// AddCheck registers a dependency readiness depends on, such as the history database being writable
+func (h *Health) AddCheck(name string, check func(context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Liveness reports the tracked state, unhealthy when a scheduled task is stuck. It checks no
+// dependencies, so an unreachable webhook does not get a working agent restarted
+func (h *Health) Liveness() HealthReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	report := HealthReport{Status: healthOK, Started: h.started, Modules: map[string]ModuleHealth{}}
+	for module, m := range h.modules {
+		report.Modules[module] = *m
+	}
+	for _, task := range h.tasks {
+		t := TaskHealth{Name: task.name, Error: task.err, Overdue: task.overdue(h.started, now)}
+		if !task.lastRun.IsZero() {
+			lastRun := task.lastRun
+			t.LastRun = &lastRun
+		}
+		if t.Overdue {
+			report.Status = healthUnhealthy
+		}
+		report.Tasks = append(report.Tasks, t)
+	}
+	return report
+}
+
+// overdue reports whether the task has missed two due times in a row, counted from its last
+// run or, before the first, from when the agent started
+func (t *taskState) overdue(started, now time.Time) bool {
+	since := t.lastRun
+	if since.IsZero() {
+		since = started
+	}
+	due := t.schedule.Next(since)
+	if due.IsZero() {
+		return false
+	}
+	due = t.schedule.Next(due)
+	return !due.IsZero() && now.After(due.Add(taskGrace))
+}
+
+// Readiness adds the dependency checks, run concurrently, to the liveness report; it is
+// unhealthy when the agent is not live or a check fails
+func (h *Health) Readiness(ctx context.Context) HealthReport {
+	report := h.Liveness()
+
+	h.mu.Lock()
+	checks := make(map[string]func(context.Context) error, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	report.Checks = map[string]CheckResult{}
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := CheckResult{OK: true}
+			if err := check(ctx); err != nil {
+				result = CheckResult{Error: err.Error()}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if !result.OK {
+				report.Status = healthUnhealthy
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// handleHealthz answers liveness probes: 200 while the agent is live, 503 once it is wedged
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, s.health.Liveness())
+}
+
+// handleReadyz answers readiness probes: 200 while the agent and its dependencies work, else 503
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, s.health.Readiness(r.Context()))
+}
+
+// writeHealth writes a probe's report with the status code orchestrators act on
+func writeHealth(w http.ResponseWriter, report HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != healthOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/scheduler"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestHealthTasks(t *testing.T) {
+	h := NewHealth()
+	start := h.started
+	now := start
+	h.now = func() time.Time { return now }
+
+	run := h.Track("collect", scheduler.EverySchedule{Interval: 10 * time.Minute}, func(context.Context) error {
+		return errors.New("output failed")
+	})
+
+	// One missed run is not yet stuck
+	now = start.Add(15 * time.Minute)
+	if report := h.Liveness(); report.Status != healthOK || report.Tasks[0].Overdue {
+		t.Errorf("after one missed run: %+v, expected ok", report)
+	}
+
+	// A failing run still shows the task is not stuck
+	if err := run(context.Background()); err == nil {
+		t.Fatal("Track() should pass the run's error on")
+	}
+	now = start.Add(30 * time.Minute)
+	report := h.Liveness()
+	if report.Status != healthOK || report.Tasks[0].LastRun == nil || report.Tasks[0].Error != "output failed" {
+		t.Errorf("after a failed run: %+v, expected ok with the error", report.Tasks[0])
+	}
+
+	// Two missed runs past the grace period mean the task is wedged
+	now = start.Add(15*time.Minute + 20*time.Minute + taskGrace + time.Second)
+	if report := h.Liveness(); report.Status != healthUnhealthy || !report.Tasks[0].Overdue {
+		t.Errorf("after two missed runs: %+v, expected unhealthy", report)
+	}
+}
+
+func TestHealthModules(t *testing.T) {
+	h := NewHealth()
+	cfg := &config.Config{Modules: config.ModuleConfig{CPU: true, Memory: true, Battery: true}}
+	info := &types.SystemInfo{
+		CPU:    &types.CPUData{},
+		Errors: []types.CollectionError{{Module: "battery", Error: "Server Core has no battery class driver"}},
+	}
+
+	h.RecordCollection(cfg, info, nil)
+	modules := h.Liveness().Modules
+	if len(modules) != 3 {
+		t.Fatalf("recorded %d modules, expected the 3 requested: %+v", len(modules), modules)
+	}
+	if modules["cpu"].LastSuccess == nil || modules["cpu"].LastFailure != nil {
+		t.Errorf("cpu = %+v, expected a success", modules["cpu"])
+	}
+	if modules["memory"].LastSuccess != nil || modules["memory"].Error != errNotCollected.Error() {
+		t.Errorf("memory = %+v, expected a failure", modules["memory"])
+	}
+	if modules["battery"].Error != "Server Core has no battery class driver" {
+		t.Errorf("battery error = %q, expected the skip reason", modules["battery"].Error)
+	}
+
+	// A later success keeps the earlier failure on record
+	info.Memory = &types.MemoryData{}
+	h.RecordCollection(cfg, info, nil)
+	if m := h.Liveness().Modules["memory"]; m.LastSuccess == nil || m.LastFailure == nil {
+		t.Errorf("memory = %+v, expected both a success and a failure", m)
+	}
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	s := newTestServer()
+	if err := s.SetTokens([]config.AgentToken{{Token: "secret", Modules: []string{"cpu"}}}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	var report HealthReport
+	if status := getJSON(t, server.URL+"/healthz", &report); status != http.StatusOK {
+		t.Fatalf("/healthz status = %d, expected 200 without a token", status)
+	}
+	if report.Status != healthOK {
+		t.Errorf("/healthz status = %q, expected ok", report.Status)
+	}
+
+	s.Health().AddCheck("database", func(context.Context) error { return nil })
+	if status := getJSON(t, server.URL+"/readyz", &report); status != http.StatusOK {
+		t.Fatalf("/readyz status = %d, expected 200", status)
+	}
+	if !report.Checks["database"].OK {
+		t.Errorf("/readyz checks = %+v, expected database ok", report.Checks)
+	}
+
+	// A failing dependency makes the agent unready but leaves it live
+	s.Health().AddCheck("alerts", func(context.Context) error { return errors.New("webhook unreachable") })
+	if status := getJSON(t, server.URL+"/readyz", &report); status != http.StatusServiceUnavailable {
+		t.Errorf("/readyz status = %d, expected 503", status)
+	}
+	if status := getJSON(t, server.URL+"/healthz", &report); status != http.StatusOK {
+		t.Errorf("/healthz status = %d, expected 200", status)
+	}
+}
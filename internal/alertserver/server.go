@@ -0,0 +1,207 @@
+// Package alertserver aggregates the alert webhooks of a fleet of sysinfo instances: alerts
+// are stored centrally, repeats from the same host and device are folded together, and what
+// is new is forwarded to a single downstream webhook
+package alertserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// maxAlertSize bounds an alert request body
+const maxAlertSize = 1 << 20
+
+// defaultPeriod is how far back GET /api/alerts looks without ?period=
+const defaultPeriod = "7d"
+
+// HostHeader names the sending host for alerts that do not carry one
+const HostHeader = "X-SysInfo-Host"
+
+// Server receives, stores and forwards fleet alerts
+type Server struct {
+	db     *analyzer.HistoryDB
+	mux    *http.ServeMux
+	window time.Duration // Repeats within the window are duplicates
+	token  string        // Shared secret senders present; empty means open access
+
+	// forward delivers new alerts downstream; nil only stores them
+	forward *analyzer.AlertManager
+
+	now func() time.Time
+}
+
+// New creates an alert server storing alerts in db and deduplicating them over window
+func New(db *analyzer.HistoryDB, window time.Duration) *Server {
+	s := &Server{
+		db:     db,
+		mux:    http.NewServeMux(),
+		window: window,
+		now:    time.Now,
+	}
+	s.mux.HandleFunc("POST /api/alerts", s.authorize(s.handleReceive))
+	s.mux.HandleFunc("GET /api/alerts", s.authorize(s.handleList))
+	return s
+}
+
+// SetForward forwards new alerts through am, whose minimum level filters them
+func (s *Server) SetForward(am *analyzer.AlertManager) {
+	s.forward = am
+}
+
+// SetToken requires senders and readers to present token
+func (s *Server) SetToken(token string) {
+	s.token = token
+}
+
+// Handler returns the HTTP handler for the server
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    64 << 10,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// receipt tells the sender what became of its alert
+type receipt struct {
+	Host      string `json:"host"`
+	Duplicate bool   `json:"duplicate"`
+	Forwarded bool   `json:"forwarded"`
+}
+
+// handleReceive accepts one alert as sent by a sysinfo alert webhook
+func (s *Server) handleReceive(w http.ResponseWriter, r *http.Request) {
+	var alert analyzer.Alert
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAlertSize)).Decode(&alert); err != nil {
+		http.Error(w, fmt.Sprintf("invalid alert: %v", err), http.StatusBadRequest)
+		return
+	}
+	if !analyzer.ValidAlertLevel(alert.Level) {
+		http.Error(w, fmt.Sprintf("invalid alert level %q", alert.Level), http.StatusBadRequest)
+		return
+	}
+	alert.Host = alertHost(alert, r)
+
+	isNew, err := s.db.RecordReceivedAlert(alert, s.now(), s.window)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to store alert: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	result := receipt{Host: alert.Host, Duplicate: !isNew}
+	if isNew && s.forward != nil {
+		// The alert is stored either way; a downstream outage is logged rather than bounced
+		// back, as senders do not retry
+		if err := s.forward.Send(alert); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to forward alert from %s: %v\n", alert.Host, err)
+		} else {
+			result.Forwarded = true
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// handleList returns the stored alerts last seen within ?period= (default 7d), newest first
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = defaultPeriod
+	}
+	duration, err := utils.ParseDuration(period)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid period: %s", period), http.StatusBadRequest)
+		return
+	}
+
+	alerts, err := s.db.GetReceivedAlerts(s.now().Add(-duration))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read alerts: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if alerts == nil {
+		alerts = []analyzer.ReceivedAlert{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(alerts)
+}
+
+// authorize rejects requests without the shared token when one is set
+// The token is read from "Authorization: Bearer <token>" or ?token=, as alert webhooks
+// are configured with a bare URL
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			token := r.URL.Query().Get("token")
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				token = bearer
+			}
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="sysinfo"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// alertHost names the machine an alert came from: the host in the alert, the X-SysInfo-Host
+// header for senders that do not set one, or else the address it was sent from
+func alertHost(alert analyzer.Alert, r *http.Request) string {
+	if alert.Host != "" {
+		return alert.Host
+	}
+	if host := strings.TrimSpace(r.Header.Get(HostHeader)); host != "" {
+		return host
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
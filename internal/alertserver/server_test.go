@@ -0,0 +1,222 @@
+package alertserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	db, err := analyzer.NewHistoryDB(filepath.Join(t.TempDir(), "fleet.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return New(db, time.Hour)
+}
+
+func postAlert(t *testing.T, url string, alert analyzer.Alert, header http.Header) receipt {
+	t.Helper()
+	body, _ := json.Marshal(alert)
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d, expected 202", resp.StatusCode)
+	}
+	var result receipt
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("invalid receipt: %v", err)
+	}
+	return result
+}
+
+func TestAlertServerReceiveAndForward(t *testing.T) {
+	var mu sync.Mutex
+	var forwarded []analyzer.Alert
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert analyzer.Alert
+		json.NewDecoder(r.Body).Decode(&alert)
+		mu.Lock()
+		forwarded = append(forwarded, alert)
+		mu.Unlock()
+	}))
+	defer downstream.Close()
+
+	s := newTestServer(t)
+	s.SetForward(analyzer.NewAlertManager(analyzer.AlertConfig{Enabled: true, WebhookURL: downstream.URL}))
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	alert := analyzer.Alert{Level: analyzer.AlertCritical, Host: "nas", Device: "/dev/sda", Title: "Predicted Disk Failure: /dev/sda"}
+	if got := postAlert(t, server.URL+"/api/alerts", alert, nil); got.Duplicate || !got.Forwarded {
+		t.Errorf("first alert receipt = %+v, expected forwarded", got)
+	}
+	if got := postAlert(t, server.URL+"/api/alerts", alert, nil); !got.Duplicate || got.Forwarded {
+		t.Errorf("repeat receipt = %+v, expected a duplicate", got)
+	}
+
+	// Senders from before alerts carried a host are named by header
+	alert.Host = ""
+	if got := postAlert(t, server.URL+"/api/alerts", alert, http.Header{HostHeader: {"web"}}); got.Host != "web" || !got.Forwarded {
+		t.Errorf("header receipt = %+v, expected host web forwarded", got)
+	}
+
+	mu.Lock()
+	if len(forwarded) != 2 || forwarded[0].Host != "nas" || forwarded[1].Host != "web" {
+		t.Errorf("forwarded = %+v, expected one alert each from nas and web", forwarded)
+	}
+	mu.Unlock()
+
+	resp, err := http.Get(server.URL + "/api/alerts?period=1d")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var stored []analyzer.ReceivedAlert
+	if err := json.NewDecoder(resp.Body).Decode(&stored); err != nil {
+		t.Fatalf("invalid list: %v", err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("stored %d alerts, expected 2: %+v", len(stored), stored)
+	}
+	counts := map[string]int{}
+	for _, a := range stored {
+		counts[a.Host] = a.Count
+	}
+	if counts["nas"] != 2 || counts["web"] != 1 {
+		t.Errorf("counts = %v, expected nas 2 and web 1", counts)
+	}
+}
+
+func TestAlertServerConcurrentDuplicates(t *testing.T) {
+	var mu sync.Mutex
+	forwarded := 0
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		forwarded++
+		mu.Unlock()
+	}))
+	defer downstream.Close()
+
+	s := newTestServer(t)
+	s.SetForward(analyzer.NewAlertManager(analyzer.AlertConfig{Enabled: true, WebhookURL: downstream.URL}))
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	// Retries and several aggregators' senders deliver the same alert at once
+	const senders = 20
+	alert := analyzer.Alert{Level: analyzer.AlertWarning, Host: "nas", Device: "/dev/sdb", Title: "High Temperature: /dev/sdb"}
+	body, _ := json.Marshal(alert)
+	receipts := make([]receipt, senders)
+	var wg sync.WaitGroup
+	for i := range receipts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := http.Post(server.URL+"/api/alerts", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusAccepted {
+				t.Errorf("status = %d, expected 202", resp.StatusCode)
+			}
+			json.NewDecoder(resp.Body).Decode(&receipts[i])
+		}(i)
+	}
+	wg.Wait()
+
+	fresh := 0
+	for _, got := range receipts {
+		if !got.Duplicate {
+			fresh++
+		}
+	}
+	if fresh != 1 {
+		t.Errorf("%d of %d receipts were not duplicates, expected 1", fresh, senders)
+	}
+	mu.Lock()
+	if forwarded != 1 {
+		t.Errorf("forwarded %d alerts, expected 1", forwarded)
+	}
+	mu.Unlock()
+
+	stored, err := s.db.GetReceivedAlerts(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetReceivedAlerts failed: %v", err)
+	}
+	if len(stored) != 1 || stored[0].Count != senders {
+		t.Errorf("stored %+v, expected one alert with count %d", stored, senders)
+	}
+}
+
+func TestAlertServerRejects(t *testing.T) {
+	// Requests are rejected before the database is touched
+	s := New(nil, time.Hour)
+	s.SetToken("secret")
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		auth   string
+		status int
+	}{
+		{"no token", http.MethodPost, "/api/alerts", `{"level":"WARNING"}`, "", http.StatusUnauthorized},
+		{"wrong token", http.MethodGet, "/api/alerts", "", "Bearer nope", http.StatusUnauthorized},
+		{"bad level", http.MethodPost, "/api/alerts?token=secret", `{"level":"LOUD"}`, "", http.StatusBadRequest},
+		{"bad json", http.MethodPost, "/api/alerts", `{`, "Bearer secret", http.StatusBadRequest},
+		{"bad period", http.MethodGet, "/api/alerts?period=soon", "", "Bearer secret", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, expected %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestAlertHost(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/alerts", nil)
+	req.RemoteAddr = "192.0.2.7:41234"
+	if got := alertHost(analyzer.Alert{}, req); got != "192.0.2.7" {
+		t.Errorf("alertHost without host = %q, expected the remote address", got)
+	}
+	req.Header.Set(HostHeader, "db1")
+	if got := alertHost(analyzer.Alert{}, req); got != "db1" {
+		t.Errorf("alertHost with header = %q, expected db1", got)
+	}
+	if got := alertHost(analyzer.Alert{Host: "nas"}, req); got != "nas" {
+		t.Errorf("alertHost with host = %q, expected nas", got)
+	}
+}
@@ -2,9 +2,13 @@ package analyzer
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -20,10 +24,13 @@ const (
 // Alert represents a disk health alert
 type Alert struct {
 	Level       AlertLevel             `json:"level"`
+	Host        string                 `json:"host,omitempty"` // Machine that raised the alert, for fleet aggregation
 	Device      string                 `json:"device"`
+	Location    string                 `json:"location,omitempty"` // Enclosure slot, so the drive can be found
 	Title       string                 `json:"title"`
 	Description string                 `json:"description"`
 	Timestamp   time.Time              `json:"timestamp"`
+	Simulated   bool                   `json:"simulated,omitempty"` // Raised from injected test data, not real hardware
 	Data        map[string]interface{} `json:"data,omitempty"`
 }
 
@@ -31,6 +38,7 @@ type Alert struct {
 type AlertConfig struct {
 	Enabled        bool       `json:"enabled"`
 	WebhookURL     string     `json:"webhook_url,omitempty"`
+	Host           string     `json:"host,omitempty"`  // Stamped on outgoing alerts (default: the hostname)
 	WebhookTimeout int        `json:"webhook_timeout"` // seconds
 	MinLevel       AlertLevel `json:"min_level"`
 	Cooldown       int        `json:"cooldown"` // minutes between alerts for same device
@@ -41,6 +49,8 @@ type AlertManager struct {
 	config     AlertConfig
 	lastAlerts map[string]time.Time // device -> last alert time
 	client     *http.Client
+	history    *HistoryDB // Records delivered alerts when set
+	simulated  bool       // Marks outgoing alerts as rehearsals
 }
 
 // NewAlertManager creates a new alert manager
@@ -54,6 +64,9 @@ func NewAlertManager(config AlertConfig) *AlertManager {
 	if config.MinLevel == "" {
 		config.MinLevel = AlertWarning
 	}
+	if config.Host == "" {
+		config.Host = localHost()
+	}
 
 	return &AlertManager{
 		config:     config,
@@ -64,6 +77,17 @@ func NewAlertManager(config AlertConfig) *AlertManager {
 	}
 }
 
+// SetHistory records delivered alerts in db for the event timeline
+func (am *AlertManager) SetHistory(db *HistoryDB) {
+	am.history = db
+}
+
+// SetSimulated marks outgoing alerts as simulated, so receivers can tell a rehearsal from
+// a real failure
+func (am *AlertManager) SetSimulated() {
+	am.simulated = true
+}
+
 // CheckAndAlert analyzes a SMART result and sends alerts if necessary
 func (am *AlertManager) CheckAndAlert(result *AnalysisResult) error {
 	if !am.config.Enabled {
@@ -80,6 +104,21 @@ func (am *AlertManager) CheckAndAlert(result *AnalysisResult) error {
 	return nil
 }
 
+// Send delivers an alert raised outside SMART analysis, such as a device disappearing
+// The minimum level applies, the per-device cooldown does not
+func (am *AlertManager) Send(alert Alert) error {
+	if !am.config.Enabled || !am.shouldSendAlert(alert.Level) {
+		return nil
+	}
+	if alert.Timestamp.IsZero() {
+		alert.Timestamp = time.Now()
+	}
+	if err := am.sendAlert(alert); err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	return nil
+}
+
 // generateAlerts creates alerts based on analysis results
 func (am *AlertManager) generateAlerts(result *AnalysisResult) []Alert {
 	var alerts []Alert
@@ -190,6 +229,10 @@ func (am *AlertManager) generateAlerts(result *AnalysisResult) []Alert {
 		}
 	}
 
+	for i := range alerts {
+		alerts[i].Location = result.Location
+	}
+
 	// Update last alert time if we're sending any alerts
 	if len(alerts) > 0 {
 		am.lastAlerts[result.Device] = time.Now()
@@ -200,25 +243,41 @@ func (am *AlertManager) generateAlerts(result *AnalysisResult) []Alert {
 
 // shouldSendAlert checks if an alert level should be sent
 func (am *AlertManager) shouldSendAlert(level AlertLevel) bool {
-	minLevel := am.config.MinLevel
+	return alertLevels[level] >= alertLevels[am.config.MinLevel]
+}
 
-	// Define level hierarchy
-	levels := map[AlertLevel]int{
-		AlertInfo:     1,
-		AlertWarning:  2,
-		AlertCritical: 3,
-	}
+// alertLevels ranks the alert levels by severity; unknown levels rank 0
+var alertLevels = map[AlertLevel]int{
+	AlertInfo:     1,
+	AlertWarning:  2,
+	AlertCritical: 3,
+}
 
-	return levels[level] >= levels[minLevel]
+// ValidAlertLevel reports whether level is one of the known alert levels
+func ValidAlertLevel(level AlertLevel) bool {
+	return alertLevels[level] > 0
 }
 
 // sendAlert sends an alert via configured channels
 func (am *AlertManager) sendAlert(alert Alert) error {
+	// Forwarded alerts keep the host that raised them
+	if alert.Host == "" {
+		alert.Host = am.config.Host
+	}
+	if am.simulated && !alert.Simulated {
+		alert.Simulated = true
+		alert.Title = "[SIMULATED] " + alert.Title
+	}
+
 	// Send to webhook if configured
 	if am.config.WebhookURL != "" {
 		if err := am.sendWebhook(alert); err != nil {
 			return err
 		}
+		// The alert went out; failing to record it is not a delivery failure
+		if am.history != nil {
+			_ = am.history.RecordAlert(alert)
+		}
 	}
 
 	// Could add other notification methods here (email, Slack, PagerDuty, etc.)
@@ -246,6 +305,28 @@ func (am *AlertManager) sendWebhook(alert Alert) error {
 	return nil
 }
 
+// CheckWebhook checks that the webhook's host accepts connections, without posting anything
+// to it. Errors name only the host, as webhook URLs often carry a secret in their path
+func CheckWebhook(ctx context.Context, webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Hostname() == "" {
+		return errors.New("invalid webhook URL")
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return fmt.Errorf("webhook unreachable: %w", err)
+	}
+	return conn.Close()
+}
+
 // ClearCooldown clears the cooldown for a specific device
 func (am *AlertManager) ClearCooldown(device string) {
 	delete(am.lastAlerts, device)
@@ -1,9 +1,12 @@
 package analyzer
 
 import (
+	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -96,6 +99,7 @@ func TestAlertManager_CheckAndAlert_Critical(t *testing.T) {
 		OverallHealth:      HealthCritical,
 		FailureProbability: 85.5,
 		PredictedFailure:   true,
+		Location:           "enclosure 0:0:8:0, slot 3",
 		Issues: []Issue{
 			{
 				Severity:    SeverityCritical,
@@ -124,6 +128,10 @@ func TestAlertManager_CheckAndAlert_Critical(t *testing.T) {
 	if receivedAlert.Level != AlertCritical {
 		t.Errorf("Expected level CRITICAL, got %s", receivedAlert.Level)
 	}
+
+	if receivedAlert.Location != result.Location {
+		t.Errorf("Expected location %q, got %q", result.Location, receivedAlert.Location)
+	}
 }
 
 func TestAlertManager_CheckAndAlert_Temperature(t *testing.T) {
@@ -419,3 +427,65 @@ func TestAlertManager_HealthyDrive(t *testing.T) {
 		t.Error("Expected no alerts for healthy drive")
 	}
 }
+
+func TestAlertManager_Simulated(t *testing.T) {
+	var received []Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		json.NewDecoder(r.Body).Decode(&alert)
+		received = append(received, alert)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	manager := NewAlertManager(AlertConfig{Enabled: true, WebhookURL: server.URL, MinLevel: AlertWarning})
+	manager.SetSimulated()
+
+	if err := manager.Send(Alert{Level: AlertCritical, Device: "/dev/sda", Title: "Drive failing"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	// Forwarded alerts that were already simulated are not marked twice
+	if err := manager.Send(Alert{Level: AlertCritical, Device: "/dev/sdb", Title: "[SIMULATED] Drive failing", Simulated: true}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("received %d alerts, expected 2", len(received))
+	}
+	for _, alert := range received {
+		if !alert.Simulated || alert.Title != "[SIMULATED] Drive failing" {
+			t.Errorf("alert = %+v, expected it marked simulated once", alert)
+		}
+	}
+}
+
+func TestCheckWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("CheckWebhook sent a %s request, expected only a connection", r.Method)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if err := CheckWebhook(ctx, server.URL+"/hooks/secret-token"); err != nil {
+		t.Errorf("CheckWebhook() = %v, expected reachable", err)
+	}
+
+	// A port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := "http://" + listener.Addr().String() + "/hooks/secret-token"
+	listener.Close()
+	err = CheckWebhook(ctx, closed)
+	if err == nil {
+		t.Fatal("CheckWebhook() = nil for a closed port")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("CheckWebhook() error leaks the URL path: %v", err)
+	}
+
+	if err := CheckWebhook(ctx, "not a url"); err == nil {
+		t.Error("CheckWebhook() = nil for an invalid URL")
+	}
+}
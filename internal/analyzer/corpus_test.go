@@ -0,0 +1,75 @@
+package analyzer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/collector"
+)
+
+// The corpus in testdata/smartctl holds anonymized `smartctl -a -j` captures
+// from drives that went on to fail, plus healthy drives of the same kinds.
+// Each capture runs through the same parser the collector uses so the
+// classification is checked end to end.
+func TestSMARTAnalyzer_Corpus(t *testing.T) {
+	tests := []struct {
+		file          string
+		health        analyzer.HealthStatus
+		predicted     bool
+		minProb       float64
+		maxProb       float64
+		expectedCodes []string
+	}{
+		{"hdd_healthy_wd_red.json", analyzer.HealthGood, false, 0, 0, nil},
+		{"hdd_degraded_toshiba_reallocated.json", analyzer.HealthWarning, false, 1, 20, []string{"REALLOCATED_SECTORS"}},
+		{"hdd_failing_hgst_pending.json", analyzer.HealthCritical, false, 20, 49, []string{"PENDING_SECTORS"}},
+		{"hdd_failed_seagate_reallocated.json", analyzer.HealthCritical, true, 90, 100, []string{"SMART_STATUS_FAILED", "ATTRIBUTE_FAILING", "REALLOCATED_SECTORS", "PENDING_SECTORS", "UNCORRECTABLE_SECTORS"}},
+		{"ssd_healthy_samsung_860.json", analyzer.HealthGood, false, 0, 0, nil},
+		{"ssd_worn_crucial_mx500.json", analyzer.HealthCritical, false, 30, 49, nil},
+		{"nvme_healthy_wd_sn750.json", analyzer.HealthGood, false, 0, 0, nil},
+		{"nvme_failed_intel_660p.json", analyzer.HealthCritical, true, 50, 100, []string{"SMART_STATUS_FAILED"}},
+	}
+
+	smartAnalyzer := analyzer.NewSMARTAnalyzer()
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "smartctl", tt.file))
+			if err != nil {
+				t.Fatalf("Failed to read capture: %v", err)
+			}
+
+			smart, err := collector.ParseSmartctlJSON("/dev/test", data)
+			if err != nil {
+				t.Fatalf("ParseSmartctlJSON failed: %v", err)
+			}
+
+			result := smartAnalyzer.Analyze(smart)
+
+			if result.OverallHealth != tt.health {
+				t.Errorf("OverallHealth = %s, expected %s (issues: %+v)", result.OverallHealth, tt.health, result.Issues)
+			}
+			if result.PredictedFailure != tt.predicted {
+				t.Errorf("PredictedFailure = %v, expected %v", result.PredictedFailure, tt.predicted)
+			}
+			if result.FailureProbability < tt.minProb || result.FailureProbability > tt.maxProb {
+				t.Errorf("FailureProbability = %.1f, expected between %.1f and %.1f", result.FailureProbability, tt.minProb, tt.maxProb)
+			}
+			if tt.health == analyzer.HealthGood && len(result.Issues) > 0 {
+				t.Errorf("Expected no issues for a healthy drive, got %+v", result.Issues)
+			}
+
+			codes := make(map[string]bool)
+			for _, issue := range result.Issues {
+				codes[issue.Code] = true
+			}
+			for _, code := range tt.expectedCodes {
+				if !codes[code] {
+					t.Errorf("Expected issue %s, got %+v", code, result.Issues)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DeviceEvent is a disk or USB device appearing or disappearing, or a network
+// interface changing, while the agent runs
+type DeviceEvent struct {
+	Time        time.Time `json:"time"`
+	Action      string    `json:"action"`                // add or remove; link_up, link_down, addr_add or addr_remove for network
+	Kind        string    `json:"kind"`                  // disk, usb or network
+	Device      string    `json:"device"`                // The interface name for network
+	Description string    `json:"description,omitempty"` // Model or product name, when known; the address for network
+	Serial      string    `json:"serial,omitempty"`
+}
+
+// RecordDeviceEvent stores a hot-swap event at the time it was observed
+func (h *HistoryDB) RecordDeviceEvent(event DeviceEvent) error {
+	at := event.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if h.clockOffset != nil {
+		at = at.Add(*h.clockOffset)
+	}
+
+	_, err := h.db.Exec(`INSERT INTO device_events (host, timestamp, action, kind, device, description, serial) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		h.host, at.UTC().Format("2006-01-02 15:04:05"), event.Action, event.Kind, event.Device, event.Description, event.Serial)
+	return err
+}
+
+// GetDeviceEvents returns the hot-swap events recorded since the given time, oldest first
+func (h *HistoryDB) GetDeviceEvents(since time.Time) ([]DeviceEvent, error) {
+	rows, err := h.db.Query(`
+		SELECT timestamp, action, kind, device, description, serial
+		FROM device_events
+		WHERE host = ? AND timestamp >= ?
+		ORDER BY timestamp ASC, id ASC`, h.host, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []DeviceEvent
+	for rows.Next() {
+		var timestampStr string
+		var description, serial sql.NullString
+		var event DeviceEvent
+		if err := rows.Scan(&timestampStr, &event.Action, &event.Kind, &event.Device, &description, &serial); err != nil {
+			continue
+		}
+		if event.Time, err = parseTimestamp(timestampStr); err != nil {
+			continue
+		}
+		event.Description = description.String
+		event.Serial = serial.String
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryDB_DeviceEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	removed := time.Now().Add(-time.Minute).Truncate(time.Second)
+	events := []DeviceEvent{
+		{Time: removed.Add(-time.Hour), Action: "add", Kind: "usb", Device: "1-2", Description: "SanDisk Ultra"},
+		{Time: removed, Action: "remove", Kind: "disk", Device: "/dev/sdc", Description: "ST8000NM000A", Serial: "ZA1B2C3D"},
+	}
+	for _, event := range events {
+		if err := db.RecordDeviceEvent(event); err != nil {
+			t.Fatalf("RecordDeviceEvent failed: %v", err)
+		}
+	}
+
+	got, err := db.GetDeviceEvents(removed.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("GetDeviceEvents failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d events, expected only the removal: %+v", len(got), got)
+	}
+	if got[0].Device != "/dev/sdc" || got[0].Action != "remove" || got[0].Serial != "ZA1B2C3D" || !got[0].Time.Equal(removed) {
+		t.Errorf("event = %+v", got[0])
+	}
+
+	got, err = db.GetDeviceEvents(removed.Add(-2 * time.Hour))
+	if err != nil || len(got) != 2 || got[0].Kind != "usb" {
+		t.Errorf("GetDeviceEvents() = %+v, %v, expected both events oldest first", got, err)
+	}
+}
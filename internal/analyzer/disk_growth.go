@@ -0,0 +1,170 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// DiskUsageSample is a partition's usage at one point in time
+type DiskUsageSample struct {
+	Time        time.Time `json:"time"`
+	MountPoint  string    `json:"mount_point"`
+	Device      string    `json:"device"`
+	UsedPercent float64   `json:"used_percent"`
+	UsedBytes   uint64    `json:"used_bytes"`
+	TotalBytes  uint64    `json:"total_bytes"`
+}
+
+// RecordDiskUsage stores the usage of each partition at the given time
+func (h *HistoryDB) RecordDiskUsage(partitions []types.PartitionInfo, at time.Time) error {
+	if h.clockOffset != nil {
+		at = at.Add(*h.clockOffset)
+	}
+	timestamp := at.UTC().Format("2006-01-02 15:04:05")
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, p := range partitions {
+		if p.Total == 0 {
+			continue
+		}
+		if _, err := tx.Exec(`INSERT INTO disk_usage (host, timestamp, mount_point, device, used_percent, used_bytes, total_bytes) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			h.host, timestamp, p.MountPoint, p.Device, p.UsedPercent, p.Used, p.Total); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetDiskUsage returns the usage recorded for a mount point since the given time, oldest first
+func (h *HistoryDB) GetDiskUsage(mountPoint string, since time.Time) ([]DiskUsageSample, error) {
+	rows, err := h.db.Query(`
+		SELECT timestamp, mount_point, device, used_percent, used_bytes, total_bytes
+		FROM disk_usage
+		WHERE host = ? AND mount_point = ? AND timestamp >= ?
+		ORDER BY timestamp ASC, id ASC`, h.host, mountPoint, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []DiskUsageSample
+	for rows.Next() {
+		var timestampStr string
+		var sample DiskUsageSample
+		if err := rows.Scan(&timestampStr, &sample.MountPoint, &sample.Device, &sample.UsedPercent, &sample.UsedBytes, &sample.TotalBytes); err != nil {
+			continue
+		}
+		if sample.Time, err = parseTimestamp(timestampStr); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
+// GrowthRule is a rate-of-change condition on partition usage: it fires when the used
+// percentage of a matching partition rises by more than Increase points within Window,
+// e.g. runaway log growth long before an absolute threshold would
+type GrowthRule struct {
+	Mount    string        // Mount point or glob pattern, e.g. /var/*; empty matches every partition
+	Increase float64       // Rise in used percentage points that fires the rule
+	Window   time.Duration // How far back the rise is measured
+	Level    AlertLevel
+}
+
+// Matches reports whether the rule applies to a mount point
+func (r GrowthRule) Matches(mountPoint string) bool {
+	if r.Mount == "" {
+		return true
+	}
+	matched, err := path.Match(r.Mount, mountPoint)
+	return err == nil && matched
+}
+
+// DiskGrowth is a partition whose usage rose faster than a rule allows
+type DiskGrowth struct {
+	Rule        GrowthRule
+	Partition   types.PartitionInfo
+	From        DiskUsageSample // The lowest usage within the window
+	Increase    float64         // Percentage points risen since From
+	TimeToFull  time.Duration   // At the current rate; 0 when not rising
+	MeasuredFor time.Duration   // Time between From and now
+}
+
+// CheckGrowth compares a partition's current usage with the lowest usage recorded within
+// the rule's window, so a burst after a cleanup counts from the cleaned-up level
+func CheckGrowth(rule GrowthRule, partition types.PartitionInfo, samples []DiskUsageSample, now time.Time) *DiskGrowth {
+	since := now.Add(-rule.Window)
+	var from *DiskUsageSample
+	for i := range samples {
+		sample := &samples[i]
+		if sample.Time.Before(since) || sample.Time.After(now) {
+			continue
+		}
+		if from == nil || sample.UsedPercent < from.UsedPercent {
+			from = sample
+		}
+	}
+	if from == nil {
+		return nil
+	}
+
+	increase := partition.UsedPercent - from.UsedPercent
+	if increase <= rule.Increase {
+		return nil
+	}
+	growth := &DiskGrowth{
+		Rule:        rule,
+		Partition:   partition,
+		From:        *from,
+		Increase:    increase,
+		MeasuredFor: now.Sub(from.Time),
+	}
+	if growth.MeasuredFor > 0 && partition.UsedPercent < 100 {
+		perSecond := increase / growth.MeasuredFor.Seconds()
+		growth.TimeToFull = time.Duration((100 - partition.UsedPercent) / perSecond * float64(time.Second))
+	}
+	return growth
+}
+
+// GrowthAlert describes a partition filling faster than a rule allows
+func GrowthAlert(growth *DiskGrowth, at time.Time) Alert {
+	p := growth.Partition
+	description := fmt.Sprintf("%s usage rose %.1f points in %s, from %.1f%% to %.1f%%",
+		p.MountPoint, growth.Increase, growth.MeasuredFor.Round(time.Minute), growth.From.UsedPercent, p.UsedPercent)
+	data := map[string]interface{}{
+		"mount_point":   p.MountPoint,
+		"used_percent":  p.UsedPercent,
+		"previous":      growth.From.UsedPercent,
+		"previous_time": growth.From.Time,
+		"increase":      growth.Increase,
+		"rule_increase": growth.Rule.Increase,
+		"rule_window":   growth.Rule.Window.String(),
+		"free_bytes":    p.Free,
+	}
+	if growth.TimeToFull > 0 {
+		description += fmt.Sprintf("; full in about %s at this rate", growth.TimeToFull.Round(time.Minute))
+		data["time_to_full"] = growth.TimeToFull.Round(time.Minute).String()
+	}
+
+	level := growth.Rule.Level
+	if level == "" {
+		level = AlertWarning
+	}
+	return Alert{
+		Level:       level,
+		Device:      p.Device,
+		Title:       fmt.Sprintf("Disk Filling Fast: %s", p.MountPoint),
+		Description: description,
+		Timestamp:   at,
+		Data:        data,
+	}
+}
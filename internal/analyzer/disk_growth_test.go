@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestGrowthRuleMatches(t *testing.T) {
+	tests := []struct {
+		mount    string
+		point    string
+		expected bool
+	}{
+		{"", "/", true},
+		{"/var", "/var", true},
+		{"/var", "/var/log", false},
+		{"/var/*", "/var/log", true},
+		{"/var/*", "/var", false},
+		{"C:*", `C:\`, true},
+	}
+	for _, tt := range tests {
+		if matched := (GrowthRule{Mount: tt.mount}).Matches(tt.point); matched != tt.expected {
+			t.Errorf("GrowthRule{Mount: %q}.Matches(%q) = %v, expected %v", tt.mount, tt.point, matched, tt.expected)
+		}
+	}
+}
+
+func TestCheckGrowth(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	rule := GrowthRule{Increase: 5, Window: time.Hour}
+	partition := types.PartitionInfo{Device: "/dev/sda2", MountPoint: "/var", UsedPercent: 62}
+	samples := []DiskUsageSample{
+		{Time: now.Add(-2 * time.Hour), UsedPercent: 40}, // Outside the window
+		{Time: now.Add(-50 * time.Minute), UsedPercent: 58},
+		{Time: now.Add(-30 * time.Minute), UsedPercent: 55}, // After a cleanup
+		{Time: now.Add(-10 * time.Minute), UsedPercent: 60},
+	}
+
+	growth := CheckGrowth(rule, partition, samples, now)
+	if growth == nil {
+		t.Fatal("CheckGrowth() = nil, expected a 7 point rise from the cleaned-up level")
+	}
+	if growth.Increase != 7 || growth.From.UsedPercent != 55 || growth.MeasuredFor != 30*time.Minute {
+		t.Errorf("growth = %+v, expected 7 points over 30m", growth)
+	}
+	// 38 points left at 14 points an hour
+	if growth.TimeToFull.Round(time.Minute) != 163*time.Minute {
+		t.Errorf("TimeToFull = %s, expected about 2h43m", growth.TimeToFull)
+	}
+
+	// A rise of exactly the limit, or no samples in the window, does not fire
+	if growth := CheckGrowth(GrowthRule{Increase: 7, Window: time.Hour}, partition, samples, now); growth != nil {
+		t.Errorf("CheckGrowth() at the limit = %+v, expected nil", growth)
+	}
+	if growth := CheckGrowth(GrowthRule{Increase: 5, Window: 5 * time.Minute}, partition, samples, now); growth != nil {
+		t.Errorf("CheckGrowth() without samples in the window = %+v, expected nil", growth)
+	}
+}
+
+func TestGrowthAlert(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	growth := &DiskGrowth{
+		Rule:        GrowthRule{Increase: 5, Window: time.Hour},
+		Partition:   types.PartitionInfo{Device: "/dev/sda2", MountPoint: "/var", UsedPercent: 62},
+		From:        DiskUsageSample{Time: now.Add(-30 * time.Minute), UsedPercent: 55},
+		Increase:    7,
+		MeasuredFor: 30 * time.Minute,
+		TimeToFull:  163 * time.Minute,
+	}
+	alert := GrowthAlert(growth, now)
+	if alert.Level != AlertWarning || alert.Device != "/dev/sda2" || alert.Title != "Disk Filling Fast: /var" {
+		t.Errorf("alert = %+v, expected a WARNING for /var on /dev/sda2", alert)
+	}
+	expected := "/var usage rose 7.0 points in 30m0s, from 55.0% to 62.0%; full in about 2h43m0s at this rate"
+	if alert.Description != expected {
+		t.Errorf("Description = %q, expected %q", alert.Description, expected)
+	}
+	if alert.Data["time_to_full"] != "2h43m0s" || alert.Data["rule_window"] != "1h0m0s" {
+		t.Errorf("Data = %v", alert.Data)
+	}
+
+	growth.Rule.Level = AlertCritical
+	growth.TimeToFull = 0
+	alert = GrowthAlert(growth, now)
+	if alert.Level != AlertCritical || strings.Contains(alert.Description, "full in") {
+		t.Errorf("alert = %+v, expected CRITICAL without a time to full", alert)
+	}
+}
+
+func TestHistoryDB_DiskUsage(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now().Truncate(time.Second)
+	partitions := []types.PartitionInfo{
+		{Device: "/dev/sda2", MountPoint: "/var", Total: 100 << 30, Used: 55 << 30, UsedPercent: 55},
+		{Device: "proc", MountPoint: "/proc"}, // No capacity, not recorded
+	}
+	if err := db.RecordDiskUsage(partitions, now.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("RecordDiskUsage failed: %v", err)
+	}
+	partitions[0].UsedPercent = 60
+	if err := db.RecordDiskUsage(partitions, now.Add(-30*time.Minute)); err != nil {
+		t.Fatalf("RecordDiskUsage failed: %v", err)
+	}
+
+	samples, err := db.GetDiskUsage("/var", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetDiskUsage failed: %v", err)
+	}
+	if len(samples) != 1 || samples[0].UsedPercent != 60 || samples[0].Device != "/dev/sda2" || !samples[0].Time.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("samples = %+v, expected the reading within the hour", samples)
+	}
+	if samples, err := db.GetDiskUsage("/proc", now.Add(-3*time.Hour)); err != nil || len(samples) != 0 {
+		t.Errorf("GetDiskUsage(/proc) = %+v, %v, expected none", samples, err)
+	}
+
+	if err := db.CleanOldRecords(time.Hour); err != nil {
+		t.Fatalf("CleanOldRecords failed: %v", err)
+	}
+	if samples, err := db.GetDiskUsage("/var", now.Add(-3*time.Hour)); err != nil || len(samples) != 1 {
+		t.Errorf("GetDiskUsage() after pruning = %+v, %v, expected the recent reading", samples, err)
+	}
+}
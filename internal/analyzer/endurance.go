@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// EnduranceRating is a drive's warranted endurance in terabytes written
+type EnduranceRating struct {
+	Model string  // Case-insensitive substring matched against the drive model
+	TBW   float64 // Rated terabytes written (10^12 bytes)
+}
+
+// defaultEnduranceRatings contains manufacturer TBW warranty ratings for common drives
+// More specific models must come before less specific ones
+var defaultEnduranceRatings = []EnduranceRating{
+	{Model: "Samsung SSD 870 EVO 4TB", TBW: 2400},
+	{Model: "Samsung SSD 870 EVO 2TB", TBW: 1200},
+	{Model: "Samsung SSD 870 EVO 1TB", TBW: 600},
+	{Model: "Samsung SSD 870 EVO 500GB", TBW: 300},
+	{Model: "Samsung SSD 860 EVO 1TB", TBW: 600},
+	{Model: "Samsung SSD 860 EVO 500GB", TBW: 300},
+	{Model: "Samsung SSD 970 EVO Plus 2TB", TBW: 1200},
+	{Model: "Samsung SSD 970 EVO Plus 1TB", TBW: 600},
+	{Model: "Samsung SSD 970 EVO Plus 500GB", TBW: 300},
+	{Model: "Samsung SSD 980 PRO 2TB", TBW: 1200},
+	{Model: "Samsung SSD 980 PRO 1TB", TBW: 600},
+	{Model: "CT2000MX500", TBW: 700},
+	{Model: "CT1000MX500", TBW: 360},
+	{Model: "CT500MX500", TBW: 180},
+	{Model: "WDS100T3X0C", TBW: 600}, // WD Black SN750 1TB
+	{Model: "WDS500G3X0C", TBW: 300}, // WD Black SN750 500GB
+	{Model: "INTEL SSDPEKNW010T8", TBW: 200},
+	{Model: "INTEL SSDPEKNW512G8", TBW: 150},
+}
+
+// estimateEndurance compares host writes against the drive's rated TBW
+func estimateEndurance(smart *types.SMARTInfo, wear *SSDWearInfo, ratings []EnduranceRating) {
+	if wear.HostBytesWritten == 0 {
+		return
+	}
+
+	rating, ok := lookupEnduranceRating(smart.DeviceModel, ratings)
+	if !ok {
+		return
+	}
+
+	ratedBytes := rating.TBW * 1e12
+	wear.RatedTBW = rating.TBW
+	wear.TBWConsumedPercent = float64(wear.HostBytesWritten) / ratedBytes * 100
+
+	// Remaining warranted writes at the drive's average daily write rate
+	remainingBytes := ratedBytes - float64(wear.HostBytesWritten)
+	if remainingBytes > 0 && wear.DailyBytesWritten > 0 {
+		days := remainingBytes / wear.DailyBytesWritten
+		wear.EnduranceRemaining = time.Duration(days*24) * time.Hour
+	}
+}
+
+// lookupEnduranceRating finds a TBW rating for a model, preferring user-configured ratings
+func lookupEnduranceRating(model string, ratings []EnduranceRating) (EnduranceRating, bool) {
+	if model == "" {
+		return EnduranceRating{}, false
+	}
+
+	model = strings.ToLower(model)
+	for _, list := range [][]EnduranceRating{ratings, defaultEnduranceRatings} {
+		for _, rating := range list {
+			if rating.TBW > 0 && rating.Model != "" && strings.Contains(model, strings.ToLower(rating.Model)) {
+				return rating, true
+			}
+		}
+	}
+
+	return EnduranceRating{}, false
+}
@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestLookupEnduranceRating(t *testing.T) {
+	custom := []EnduranceRating{{Model: "Samsung SSD 870 EVO 1TB", TBW: 1000}}
+
+	tests := []struct {
+		name     string
+		model    string
+		ratings  []EnduranceRating
+		expected float64
+		found    bool
+	}{
+		{"built-in", "Samsung SSD 870 EVO 1TB", nil, 600, true},
+		{"case-insensitive", "samsung ssd 870 evo 1tb", nil, 600, true},
+		{"custom overrides built-in", "Samsung SSD 870 EVO 1TB", custom, 1000, true},
+		{"crucial part number", "CT1000MX500SSD1", nil, 360, true},
+		{"unknown model", "Generic SSD", nil, 0, false},
+		{"empty model", "", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating, ok := lookupEnduranceRating(tt.model, tt.ratings)
+			if ok != tt.found {
+				t.Fatalf("found = %v, expected %v", ok, tt.found)
+			}
+			if rating.TBW != tt.expected {
+				t.Errorf("TBW = %.0f, expected %.0f", rating.TBW, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEstimateEndurance(t *testing.T) {
+	smart := &types.SMARTInfo{DeviceModel: "Samsung SSD 870 EVO 1TB"}
+	wear := &SSDWearInfo{
+		HostBytesWritten:  228e12, // 38% of 600 TBW
+		DailyBytesWritten: 325e9,  // ~3.1 years for the remaining 372 TB
+	}
+
+	estimateEndurance(smart, wear, nil)
+
+	if wear.RatedTBW != 600 {
+		t.Errorf("RatedTBW = %.0f, expected 600", wear.RatedTBW)
+	}
+	if math.Abs(wear.TBWConsumedPercent-38) > 0.01 {
+		t.Errorf("TBWConsumedPercent = %.2f, expected 38", wear.TBWConsumedPercent)
+	}
+	years := wear.EnduranceRemaining.Hours() / 24 / 365
+	if math.Abs(years-3.1) > 0.1 {
+		t.Errorf("EnduranceRemaining = %.2f years, expected ~3.1", years)
+	}
+}
+
+func TestEstimateEndurance_NoData(t *testing.T) {
+	// No host writes
+	wear := &SSDWearInfo{}
+	estimateEndurance(&types.SMARTInfo{DeviceModel: "Samsung SSD 870 EVO 1TB"}, wear, nil)
+	if wear.RatedTBW != 0 {
+		t.Error("Expected no rating without host writes")
+	}
+
+	// Rating exceeded - no remaining time
+	wear = &SSDWearInfo{HostBytesWritten: 700e12, DailyBytesWritten: 1e9}
+	estimateEndurance(&types.SMARTInfo{DeviceModel: "Samsung SSD 870 EVO 1TB"}, wear, nil)
+	if wear.TBWConsumedPercent <= 100 {
+		t.Errorf("TBWConsumedPercent = %.1f, expected > 100", wear.TBWConsumedPercent)
+	}
+	if wear.EnduranceRemaining != 0 {
+		t.Error("Expected no remaining endurance once rating is exceeded")
+	}
+}
@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ReceivedAlert is an alert received from another sysinfo instance by the alert aggregator,
+// with the repeats folded into it while it was deduplicated
+type ReceivedAlert struct {
+	ID          int64                  `json:"id"`
+	Host        string                 `json:"host"`
+	Level       AlertLevel             `json:"level"`
+	Device      string                 `json:"device"`
+	Location    string                 `json:"location,omitempty"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	FirstSeen   time.Time              `json:"first_seen"`
+	LastSeen    time.Time              `json:"last_seen"`
+	Count       int                    `json:"count"`
+}
+
+// RecordReceivedAlert stores an alert received from the fleet at the given time
+// An alert for a host and device that already alerted within window, at the same or a
+// higher level, is a duplicate: it only bumps that alert's count and reports false.
+// Otherwise the alert is stored as new and true is returned, so it can be forwarded
+func (h *HistoryDB) RecordReceivedAlert(alert Alert, at time.Time, window time.Duration) (isNew bool, err error) {
+	seen := at.UTC().Format("2006-01-02 15:04:05")
+
+	// The lookup and the write are one immediate transaction: concurrent deliveries of the
+	// same alert wait for the write lock rather than each finding no match and storing it
+	ctx := context.Background()
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "PRAGMA busy_timeout = 5000"); err != nil {
+		return false, err
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return false, err
+	}
+	defer func() {
+		if err == nil {
+			_, err = conn.ExecContext(ctx, "COMMIT")
+		}
+		if err != nil {
+			_, _ = conn.ExecContext(ctx, "ROLLBACK")
+			isNew = false
+		}
+	}()
+
+	var id int64
+	var level AlertLevel
+	err = conn.QueryRowContext(ctx, `
+		SELECT id, level FROM fleet_alerts
+		WHERE host = ? AND device = ? AND last_seen >= ?
+		ORDER BY last_seen DESC, id DESC
+		LIMIT 1`, alert.Host, alert.Device, at.Add(-window).UTC().Format("2006-01-02 15:04:05")).Scan(&id, &level)
+	switch {
+	case err == nil && alertLevels[alert.Level] <= alertLevels[level]:
+		_, err = conn.ExecContext(ctx, `UPDATE fleet_alerts SET last_seen = ?, count = count + 1 WHERE id = ?`, seen, id)
+		return false, err
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return false, err
+	}
+
+	// An escalation within the window is new, so it reaches the downstream channel
+	var data sql.NullString
+	if len(alert.Data) > 0 {
+		encoded, err := json.Marshal(alert.Data)
+		if err != nil {
+			return false, err
+		}
+		data = sql.NullString{String: string(encoded), Valid: true}
+	}
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO fleet_alerts (host, device, level, title, description, location, data, first_seen, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		alert.Host, alert.Device, alert.Level, alert.Title, alert.Description, alert.Location, data, seen, seen)
+	return err == nil, err
+}
+
+// GetReceivedAlerts returns the fleet alerts last seen since the given time, newest first
+func (h *HistoryDB) GetReceivedAlerts(since time.Time) ([]ReceivedAlert, error) {
+	rows, err := h.db.Query(`
+		SELECT id, host, device, level, title, description, location, data, first_seen, last_seen, count
+		FROM fleet_alerts
+		WHERE last_seen >= ?
+		ORDER BY last_seen DESC, id DESC`, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []ReceivedAlert
+	for rows.Next() {
+		var a ReceivedAlert
+		var title, description, location, data sql.NullString
+		var firstSeen, lastSeen string
+		if err := rows.Scan(&a.ID, &a.Host, &a.Device, &a.Level, &title, &description, &location, &data,
+			&firstSeen, &lastSeen, &a.Count); err != nil {
+			return nil, err
+		}
+		a.Title, a.Description, a.Location = title.String, description.String, location.String
+		if data.Valid {
+			_ = json.Unmarshal([]byte(data.String), &a.Data)
+		}
+		a.FirstSeen, _ = parseTimestamp(firstSeen)
+		a.LastSeen, _ = parseTimestamp(lastSeen)
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryDB_RecordReceivedAlert(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	warning := Alert{Level: AlertWarning, Host: "nas", Device: "/dev/sda", Title: "Disk Health Warning: /dev/sda",
+		Data: map[string]interface{}{"issue_count": 1}}
+	steps := []struct {
+		alert  Alert
+		offset time.Duration
+		isNew  bool
+	}{
+		{warning, 0, true},
+		{warning, 10 * time.Minute, false}, // Repeat within the window
+		{Alert{Level: AlertWarning, Host: "web", Device: "/dev/sda"}, 11 * time.Minute, true},  // Same device, other host
+		{Alert{Level: AlertCritical, Host: "nas", Device: "/dev/sda"}, 12 * time.Minute, true}, // Escalation
+		{warning, 13 * time.Minute, false}, // Below the escalated level
+		{warning, 2 * time.Hour, true},     // Window has passed
+	}
+	for i, step := range steps {
+		isNew, err := db.RecordReceivedAlert(step.alert, start.Add(step.offset), 30*time.Minute)
+		if err != nil {
+			t.Fatalf("step %d: RecordReceivedAlert failed: %v", i, err)
+		}
+		if isNew != step.isNew {
+			t.Errorf("step %d: new = %v, expected %v", i, isNew, step.isNew)
+		}
+	}
+
+	alerts, err := db.GetReceivedAlerts(start.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("GetReceivedAlerts failed: %v", err)
+	}
+	if len(alerts) != 4 {
+		t.Fatalf("got %d alerts, expected 4: %+v", len(alerts), alerts)
+	}
+	first := alerts[3]
+	if first.Host != "nas" || first.Count != 2 || first.Data["issue_count"] != float64(1) ||
+		!first.LastSeen.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("first alert = %+v, expected nas seen twice with its data", first)
+	}
+	if escalated := alerts[1]; escalated.Level != AlertCritical || escalated.Count != 2 {
+		t.Errorf("escalated alert = %+v, expected CRITICAL absorbing the later warning", escalated)
+	}
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CheckGPUDrivers compares the userspace driver, the loaded kernel module and
+// the installed CUDA runtime of each GPU. These drift apart after a driver or
+// kernel update until the machine is rebooted or the module is rebuilt.
+func CheckGPUDrivers(gpus []types.GPUInfo) []types.GPUDriverIssue {
+	issues := make([]types.GPUDriverIssue, 0)
+
+	for _, gpu := range gpus {
+		lowerErr := strings.ToLower(gpu.DriverError)
+		switch {
+		case strings.Contains(lowerErr, "version mismatch"):
+			issues = append(issues, types.GPUDriverIssue{
+				GPUIndex:       gpu.Index,
+				Severity:       string(SeverityCritical),
+				Code:           "DRIVER_LIBRARY_MISMATCH",
+				Description:    fmt.Sprintf("Userspace driver libraries do not match the loaded kernel module (%s)", moduleVersionLabel(gpu.KernelModuleVersion)),
+				Recommendation: "Reboot to load the kernel module that matches the installed driver, or reinstall the driver version of the loaded module",
+			})
+		case strings.Contains(lowerErr, "couldn't communicate"), strings.Contains(lowerErr, "no devices were found"):
+			issues = append(issues, types.GPUDriverIssue{
+				GPUIndex:       gpu.Index,
+				Severity:       string(SeverityCritical),
+				Code:           "DRIVER_NOT_LOADED",
+				Description:    fmt.Sprintf("Driver tools cannot reach the GPU: %s", gpu.DriverError),
+				Recommendation: "Rebuild the kernel module for the running kernel (e.g. dkms autoinstall) and check that it loads",
+			})
+		case gpu.DriverError != "":
+			issues = append(issues, types.GPUDriverIssue{
+				GPUIndex:       gpu.Index,
+				Severity:       string(SeverityWarning),
+				Code:           "DRIVER_QUERY_FAILED",
+				Description:    fmt.Sprintf("Driver tools failed to query the GPU: %s", gpu.DriverError),
+				Recommendation: "Check the driver installation and the kernel log for errors",
+			})
+		}
+
+		if gpu.DriverVersion != "" && gpu.KernelModuleVersion != "" && gpu.DriverVersion != gpu.KernelModuleVersion {
+			issues = append(issues, types.GPUDriverIssue{
+				GPUIndex:       gpu.Index,
+				Severity:       string(SeverityCritical),
+				Code:           "DRIVER_MODULE_MISMATCH",
+				Description:    fmt.Sprintf("Driver %s is installed but kernel module %s is loaded", gpu.DriverVersion, gpu.KernelModuleVersion),
+				Recommendation: fmt.Sprintf("Reboot so kernel module %s is loaded", gpu.DriverVersion),
+			})
+		}
+
+		if gpu.CUDAVersion != "" && gpu.CUDARuntimeVersion != "" && compareVersions(gpu.CUDARuntimeVersion, gpu.CUDAVersion, 2) > 0 {
+			issues = append(issues, types.GPUDriverIssue{
+				GPUIndex:       gpu.Index,
+				Severity:       string(SeverityWarning),
+				Code:           "CUDA_RUNTIME_UNSUPPORTED",
+				Description:    fmt.Sprintf("CUDA runtime %s is newer than CUDA %s supported by driver %s", gpu.CUDARuntimeVersion, gpu.CUDAVersion, gpu.DriverVersion),
+				Recommendation: fmt.Sprintf("Upgrade the driver to one that supports CUDA %s, or install a CUDA toolkit no newer than %s", majorMinor(gpu.CUDARuntimeVersion), gpu.CUDAVersion),
+			})
+		}
+	}
+
+	return issues
+}
+
+// moduleVersionLabel describes the loaded kernel module version
+func moduleVersionLabel(version string) string {
+	if version == "" {
+		return "loaded module version unknown"
+	}
+	return "loaded module " + version
+}
+
+// compareVersions compares the first parts components of two dotted
+// versions, returning -1, 0 or 1
+func compareVersions(a, b string, parts int) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < parts; i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// majorMinor trims a version such as 12.2.140 to 12.2
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestCheckGPUDrivers(t *testing.T) {
+	tests := []struct {
+		name         string
+		gpu          types.GPUInfo
+		expectedCode string
+		severity     Severity
+	}{
+		{
+			name: "Consistent stack",
+			gpu:  types.GPUInfo{DriverVersion: "535.129.03", KernelModuleVersion: "535.129.03", CUDAVersion: "12.2", CUDARuntimeVersion: "12.2.140"},
+		},
+		{
+			name:         "NVML library mismatch after upgrade",
+			gpu:          types.GPUInfo{KernelModuleVersion: "535.104.05", DriverError: "Failed to initialize NVML: Driver/library version mismatch"},
+			expectedCode: "DRIVER_LIBRARY_MISMATCH",
+			severity:     SeverityCritical,
+		},
+		{
+			name:         "Module not built for new kernel",
+			gpu:          types.GPUInfo{DriverError: "NVIDIA-SMI has failed because it couldn't communicate with the NVIDIA driver."},
+			expectedCode: "DRIVER_NOT_LOADED",
+			severity:     SeverityCritical,
+		},
+		{
+			name:         "Other query failure",
+			gpu:          types.GPUInfo{DriverError: "Unable to determine the device handle for GPU 0000:41:00.0: Unknown Error"},
+			expectedCode: "DRIVER_QUERY_FAILED",
+			severity:     SeverityWarning,
+		},
+		{
+			name:         "Module version differs from driver",
+			gpu:          types.GPUInfo{DriverVersion: "550.54.14", KernelModuleVersion: "535.129.03"},
+			expectedCode: "DRIVER_MODULE_MISMATCH",
+			severity:     SeverityCritical,
+		},
+		{
+			name:         "Runtime newer than driver supports",
+			gpu:          types.GPUInfo{DriverVersion: "525.147.05", CUDAVersion: "12.0", CUDARuntimeVersion: "12.4.99"},
+			expectedCode: "CUDA_RUNTIME_UNSUPPORTED",
+			severity:     SeverityWarning,
+		},
+		{
+			name: "Older runtime is fine",
+			gpu:  types.GPUInfo{DriverVersion: "550.54.14", CUDAVersion: "12.4", CUDARuntimeVersion: "11.8.89"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckGPUDrivers([]types.GPUInfo{tt.gpu})
+
+			if tt.expectedCode == "" {
+				if len(issues) != 0 {
+					t.Errorf("Expected no issues, got %+v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %+v", issues)
+			}
+			if issues[0].Code != tt.expectedCode {
+				t.Errorf("Code = %s, expected %s", issues[0].Code, tt.expectedCode)
+			}
+			if issues[0].Severity != string(tt.severity) {
+				t.Errorf("Severity = %s, expected %s", issues[0].Severity, tt.severity)
+			}
+			if issues[0].Recommendation == "" {
+				t.Error("Expected a recommendation")
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"12.2.140", "12.2", 0},
+		{"12.4", "12.2", 1},
+		{"11.8", "12.0", -1},
+		{"12.10", "12.9", 1},
+	}
+
+	for _, tt := range tests {
+		if result := compareVersions(tt.a, tt.b, 2); result != tt.expected {
+			t.Errorf("compareVersions(%s, %s) = %d, expected %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package analyzer
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/mayvqt/sysinfo/internal/types"
@@ -12,22 +14,40 @@ import (
 // HistoryDB manages SMART data history
 type HistoryDB struct {
 	db *sql.DB
+
+	// clockOffset corrects the local clock when recording, set by SetClockOffset
+	clockOffset *time.Duration
+
+	// host namespaces records so one database can hold several machines' history
+	host string
 }
 
 // SMARTHistoryRecord represents a historical SMART reading
 type SMARTHistoryRecord struct {
-	ID                 int64
-	Device             string
-	Timestamp          time.Time
-	Temperature        int
-	PowerOnHours       int64
-	HealthStatus       HealthStatus
-	FailureProbability float64
-	RemainingLife      float64
-	PercentUsed        float64
-	IssueCount         int
-	CriticalIssues     int
-	WarningIssues      int
+	ID                 int64        `json:"id"`
+	Host               string       `json:"host"`
+	Device             string       `json:"device"`
+	Timestamp          time.Time    `json:"timestamp"`
+	Temperature        int          `json:"temperature"`
+	PowerOnHours       int64        `json:"power_on_hours"`
+	HealthStatus       HealthStatus `json:"health_status"`
+	FailureProbability float64      `json:"failure_probability"`
+	RemainingLife      float64      `json:"remaining_life"`
+	PercentUsed        float64      `json:"percent_used"`
+	IssueCount         int          `json:"issue_count"`
+	CriticalIssues     int          `json:"critical_issues"`
+	WarningIssues      int          `json:"warning_issues"`
+	ClockOffsetMS      *float64     `json:"clock_offset_ms,omitempty"` // Correction applied to Timestamp, if any
+}
+
+// IssueRecord is a stored SMART issue with the device and time it was recorded
+type IssueRecord struct {
+	Host        string    `json:"host"`
+	Device      string    `json:"device"`
+	Timestamp   time.Time `json:"timestamp"`
+	Severity    Severity  `json:"severity"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
 }
 
 // TrendData represents trend analysis over a time period
@@ -52,7 +72,7 @@ func NewHistoryDB(dbPath string) (*HistoryDB, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	h := &HistoryDB{db: db}
+	h := &HistoryDB{db: db, host: localHost()}
 	if err := h.initSchema(); err != nil {
 		db.Close()
 		return nil, err
@@ -74,6 +94,7 @@ func (h *HistoryDB) initSchema() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS smart_history (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
 		device TEXT NOT NULL,
 		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
 		temperature INTEGER,
@@ -94,6 +115,7 @@ func (h *HistoryDB) initSchema() error {
 	CREATE TABLE IF NOT EXISTS smart_attributes (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		history_id INTEGER NOT NULL,
+		host TEXT NOT NULL DEFAULT '',
 		attribute_id INTEGER,
 		attribute_name TEXT,
 		value INTEGER,
@@ -109,6 +131,7 @@ func (h *HistoryDB) initSchema() error {
 	CREATE TABLE IF NOT EXISTS smart_issues (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		history_id INTEGER NOT NULL,
+		host TEXT NOT NULL DEFAULT '',
 		severity TEXT,
 		code TEXT,
 		description TEXT,
@@ -117,12 +140,197 @@ func (h *HistoryDB) initSchema() error {
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_history_issues ON smart_issues(history_id);
+
+	CREATE TABLE IF NOT EXISTS noise_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		total_dba REAL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_noise_host_timestamp ON noise_history(host, timestamp);
+
+	CREATE TABLE IF NOT EXISTS smart_skips (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		device TEXT NOT NULL,
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		reason TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_skips_host_device_timestamp ON smart_skips(host, device, timestamp);
+
+	CREATE TABLE IF NOT EXISTS device_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		action TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		device TEXT NOT NULL,
+		description TEXT,
+		serial TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_device_events_host_timestamp ON device_events(host, timestamp);
+
+	CREATE TABLE IF NOT EXISTS disk_usage (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		mount_point TEXT NOT NULL,
+		device TEXT,
+		used_percent REAL,
+		used_bytes INTEGER,
+		total_bytes INTEGER
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_disk_usage_host_mount_timestamp ON disk_usage(host, mount_point, timestamp);
+
+	CREATE TABLE IF NOT EXISTS gpu_memory (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		gpu TEXT NOT NULL,
+		pid INTEGER NOT NULL,
+		process TEXT,
+		used_bytes INTEGER
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_gpu_memory_host_gpu_pid_timestamp ON gpu_memory(host, gpu, pid, timestamp);
+
+	CREATE TABLE IF NOT EXISTS boots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		boot_time DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_boots_host_boot_time ON boots(host, boot_time);
+
+	CREATE TABLE IF NOT EXISTS sent_alerts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL DEFAULT '',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+		level TEXT NOT NULL,
+		device TEXT,
+		title TEXT,
+		description TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_sent_alerts_host_timestamp ON sent_alerts(host, timestamp);
+
+	CREATE TABLE IF NOT EXISTS fleet_alerts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		host TEXT NOT NULL,
+		device TEXT NOT NULL DEFAULT '',
+		level TEXT NOT NULL,
+		title TEXT,
+		description TEXT,
+		location TEXT,
+		data TEXT,
+		first_seen DATETIME NOT NULL,
+		last_seen DATETIME NOT NULL,
+		count INTEGER NOT NULL DEFAULT 1
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_fleet_alerts_host_device_last_seen ON fleet_alerts(host, device, last_seen);
 	`
 
-	_, err := h.db.Exec(schema)
+	if _, err := h.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// Columns added after the first release
+	if _, err := h.addColumn("smart_history", "clock_offset_ms", "REAL"); err != nil {
+		return err
+	}
+
+	// Records from before hosts were tracked were taken on this machine
+	for _, table := range []string{"smart_history", "smart_attributes", "smart_issues"} {
+		added, err := h.addColumn(table, "host", "TEXT NOT NULL DEFAULT ''")
+		if err != nil {
+			return err
+		}
+		if added {
+			if _, err := h.db.Exec(fmt.Sprintf("UPDATE %s SET host = ? WHERE host = ''", table), h.host); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := h.db.Exec("CREATE INDEX IF NOT EXISTS idx_host_device_timestamp ON smart_history(host, device, timestamp)")
 	return err
 }
 
+// addColumn adds a column to an existing table unless it is already there, reporting whether it was added
+func (h *HistoryDB) addColumn(table, column, definition string) (bool, error) {
+	rows, err := h.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+
+	exists := false
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return false, err
+		}
+		if name == column {
+			exists = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || exists {
+		return false, err
+	}
+
+	_, err = h.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err == nil, err
+}
+
+// localHost identifies this machine in the history database
+func localHost() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "localhost"
+	}
+	return host
+}
+
+// SetHost records and reads history under another host name
+// Used when importing or viewing history collected on other machines
+func (h *HistoryDB) SetHost(host string) {
+	h.host = host
+}
+
+// Writable checks the database still accepts writes, such as after its disk filled up or was
+// remounted read-only, by taking the write lock and releasing it without writing
+func (h *HistoryDB) Writable(ctx context.Context) error {
+	conn, err := h.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("database unavailable: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("database not writable: %w", err)
+	}
+	_, err = conn.ExecContext(ctx, "ROLLBACK")
+	return err
+}
+
+// Host returns the host name records are read and written under
+func (h *HistoryDB) Host() string {
+	return h.host
+}
+
+// SetClockOffset stores new records at the local time corrected by offset (measured against NTP),
+// so trends from hosts with wrong clocks line up with the rest of the fleet
+func (h *HistoryDB) SetClockOffset(offset time.Duration) {
+	h.clockOffset = &offset
+}
+
 // RecordAnalysis stores a SMART analysis result
 func (h *HistoryDB) RecordAnalysis(smart *types.SMARTInfo, result *AnalysisResult) error {
 	tx, err := h.db.Begin()
@@ -153,14 +361,25 @@ func (h *HistoryDB) RecordAnalysis(smart *types.SMARTInfo, result *AnalysisResul
 		percentUsed = result.SSDWearAnalysis.PercentUsed
 	}
 
+	// Without a clock correction the database records its own CURRENT_TIMESTAMP
+	var timestamp sql.NullString
+	var clockOffsetMS sql.NullFloat64
+	if h.clockOffset != nil {
+		timestamp = sql.NullString{String: time.Now().Add(*h.clockOffset).UTC().Format("2006-01-02 15:04:05"), Valid: true}
+		clockOffsetMS = sql.NullFloat64{Float64: float64(*h.clockOffset) / float64(time.Millisecond), Valid: true}
+	}
+
 	// Insert main record
 	res, err := tx.Exec(`
 		INSERT INTO smart_history (
-			device, temperature, power_on_hours, health_status,
+			host, device, timestamp, clock_offset_ms, temperature, power_on_hours, health_status,
 			failure_probability, remaining_life, percent_used,
 			issue_count, critical_issues, warning_issues
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		) VALUES (?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		h.host,
 		smart.Device,
+		timestamp,
+		clockOffsetMS,
 		smart.Temperature,
 		smart.PowerOnHours,
 		result.OverallHealth,
@@ -184,10 +403,11 @@ func (h *HistoryDB) RecordAnalysis(smart *types.SMARTInfo, result *AnalysisResul
 	for _, attr := range smart.DetailedAttribs {
 		_, err := tx.Exec(`
 			INSERT INTO smart_attributes (
-				history_id, attribute_id, attribute_name,
+				history_id, host, attribute_id, attribute_name,
 				value, worst, threshold, raw_value, when_failed
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			historyID,
+			h.host,
 			attr.ID,
 			attr.Name,
 			attr.Value,
@@ -205,9 +425,10 @@ func (h *HistoryDB) RecordAnalysis(smart *types.SMARTInfo, result *AnalysisResul
 	for _, issue := range result.Issues {
 		_, err := tx.Exec(`
 			INSERT INTO smart_issues (
-				history_id, severity, code, description, attribute_id
-			) VALUES (?, ?, ?, ?, ?)`,
+				history_id, host, severity, code, description, attribute_id
+			) VALUES (?, ?, ?, ?, ?, ?)`,
 			historyID,
+			h.host,
 			issue.Severity,
 			issue.Code,
 			issue.Description,
@@ -221,42 +442,101 @@ func (h *HistoryDB) RecordAnalysis(smart *types.SMARTInfo, result *AnalysisResul
 	return tx.Commit()
 }
 
-// GetHistory retrieves historical records for a device
+// GetHistory retrieves historical records for a device on the current host
 func (h *HistoryDB) GetHistory(device string, since time.Time, limit int) ([]SMARTHistoryRecord, error) {
 	query := `
-		SELECT id, device, timestamp, temperature, power_on_hours,
+		SELECT id, host, device, timestamp, temperature, power_on_hours,
 		       health_status, failure_probability, remaining_life,
-		       percent_used, issue_count, critical_issues, warning_issues
+		       percent_used, issue_count, critical_issues, warning_issues,
+		       clock_offset_ms
 		FROM smart_history
-		WHERE device = ? AND timestamp >= datetime(?)
+		WHERE host = ? AND device = ? AND timestamp >= datetime(?)
 		ORDER BY timestamp DESC
 		LIMIT ?`
 
-	rows, err := h.db.Query(query, device, since.Format("2006-01-02 15:04:05"), limit)
+	rows, err := h.db.Query(query, h.host, device, since.UTC().Format("2006-01-02 15:04:05"), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanHistoryRecords(rows)
+}
+
+// ExportHistory retrieves every record of the current host's devices since the given time,
+// by device and oldest first, for exporting in full
+func (h *HistoryDB) ExportHistory(since time.Time) ([]SMARTHistoryRecord, error) {
+	query := `
+		SELECT id, host, device, timestamp, temperature, power_on_hours,
+		       health_status, failure_probability, remaining_life,
+		       percent_used, issue_count, critical_issues, warning_issues,
+		       clock_offset_ms
+		FROM smart_history
+		WHERE host = ? AND timestamp >= datetime(?)
+		ORDER BY device, timestamp`
+
+	rows, err := h.db.Query(query, h.host, since.UTC().Format("2006-01-02 15:04:05"))
 	if err != nil {
 		return nil, err
 	}
+	return scanHistoryRecords(rows)
+}
+
+func scanHistoryRecords(rows *sql.Rows) ([]SMARTHistoryRecord, error) {
 	defer rows.Close()
 
 	var records []SMARTHistoryRecord
 	for rows.Next() {
 		var r SMARTHistoryRecord
 		var timestamp string
+		var clockOffset sql.NullFloat64
 		err := rows.Scan(
-			&r.ID, &r.Device, &timestamp, &r.Temperature, &r.PowerOnHours,
+			&r.ID, &r.Host, &r.Device, &timestamp, &r.Temperature, &r.PowerOnHours,
 			&r.HealthStatus, &r.FailureProbability, &r.RemainingLife,
 			&r.PercentUsed, &r.IssueCount, &r.CriticalIssues, &r.WarningIssues,
+			&clockOffset,
 		)
 		if err != nil {
 			return nil, err
 		}
-		r.Timestamp, _ = time.Parse("2006-01-02 15:04:05", timestamp)
+		r.Timestamp, _ = parseTimestamp(timestamp)
+		if clockOffset.Valid {
+			r.ClockOffsetMS = &clockOffset.Float64
+		}
 		records = append(records, r)
 	}
 
 	return records, rows.Err()
 }
 
+// GetRecentIssues retrieves stored issues across the current host's devices, newest first
+func (h *HistoryDB) GetRecentIssues(since time.Time, limit int) ([]IssueRecord, error) {
+	query := `
+		SELECT h.host, h.device, h.timestamp, i.severity, i.code, i.description
+		FROM smart_issues i
+		JOIN smart_history h ON h.id = i.history_id
+		WHERE h.host = ? AND h.timestamp >= datetime(?)
+		ORDER BY h.timestamp DESC, i.id
+		LIMIT ?`
+
+	rows, err := h.db.Query(query, h.host, since.UTC().Format("2006-01-02 15:04:05"), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var issues []IssueRecord
+	for rows.Next() {
+		var r IssueRecord
+		var timestamp string
+		if err := rows.Scan(&r.Host, &r.Device, &timestamp, &r.Severity, &r.Code, &r.Description); err != nil {
+			return nil, err
+		}
+		r.Timestamp, _ = parseTimestamp(timestamp)
+		issues = append(issues, r)
+	}
+
+	return issues, rows.Err()
+}
+
 // GetTrend analyzes trends for a device over a time period
 func (h *HistoryDB) GetTrend(device string, since time.Time) (*TrendData, error) {
 	// Get aggregate stats
@@ -269,13 +549,13 @@ func (h *HistoryDB) GetTrend(device string, since time.Time) (*TrendData, error)
 			MIN(temperature) as min_temp,
 			COUNT(*) as record_count
 		FROM smart_history
-		WHERE device = ? AND timestamp >= ?`
+		WHERE host = ? AND device = ? AND timestamp >= ?`
 
 	var trend TrendData
 	trend.Device = device
 
 	var startTime, endTime string
-	err := h.db.QueryRow(query, device, since).Scan(
+	err := h.db.QueryRow(query, h.host, device, since).Scan(
 		&startTime, &endTime, &trend.AvgTemperature,
 		&trend.MaxTemperature, &trend.MinTemperature,
 		&trend.RecordCount,
@@ -284,8 +564,8 @@ func (h *HistoryDB) GetTrend(device string, since time.Time) (*TrendData, error)
 		return nil, err
 	}
 
-	trend.StartTime, _ = time.Parse("2006-01-02 15:04:05", startTime)
-	trend.EndTime, _ = time.Parse("2006-01-02 15:04:05", endTime)
+	trend.StartTime, _ = parseTimestamp(startTime)
+	trend.EndTime, _ = parseTimestamp(endTime)
 
 	// Analyze temperature trend
 	tempTrend, err := h.calculateTrend(device, since, "temperature")
@@ -321,10 +601,10 @@ func (h *HistoryDB) calculateTrend(device string, since time.Time, column string
 	query := fmt.Sprintf(`
 		SELECT %s, timestamp
 		FROM smart_history
-		WHERE device = ? AND timestamp >= ?
+		WHERE host = ? AND device = ? AND timestamp >= ?
 		ORDER BY timestamp ASC`, column)
 
-	rows, err := h.db.Query(query, device, since)
+	rows, err := h.db.Query(query, h.host, device, since)
 	if err != nil {
 		return "", err
 	}
@@ -359,10 +639,10 @@ func (h *HistoryDB) analyzeHealthTrend(device string, since time.Time) (string,
 	query := `
 		SELECT critical_issues, warning_issues, failure_probability
 		FROM smart_history
-		WHERE device = ? AND timestamp >= ?
+		WHERE host = ? AND device = ? AND timestamp >= ?
 		ORDER BY timestamp ASC`
 
-	rows, err := h.db.Query(query, device, since)
+	rows, err := h.db.Query(query, h.host, device, since)
 	if err != nil {
 		return "", err
 	}
@@ -398,10 +678,10 @@ func (h *HistoryDB) calculateWearRate(device string, since time.Time) (float64,
 	query := `
 		SELECT percent_used, timestamp
 		FROM smart_history
-		WHERE device = ? AND timestamp >= ? AND percent_used > 0
+		WHERE host = ? AND device = ? AND timestamp >= ? AND percent_used > 0
 		ORDER BY timestamp ASC`
 
-	rows, err := h.db.Query(query, device, since)
+	rows, err := h.db.Query(query, h.host, device, since)
 	if err != nil {
 		return 0, err
 	}
@@ -418,7 +698,7 @@ func (h *HistoryDB) calculateWearRate(device string, since time.Time) (float64,
 			continue
 		}
 
-		t, _ := time.Parse("2006-01-02 15:04:05", timestamp)
+		t, _ := parseTimestamp(timestamp)
 		if count == 0 {
 			firstUsed = used
 			firstTime = t
@@ -466,13 +746,71 @@ func calculateLinearTrend(values []float64) float64 {
 // CleanOldRecords removes records older than the specified duration
 func (h *HistoryDB) CleanOldRecords(olderThan time.Duration) error {
 	cutoff := time.Now().Add(-olderThan)
-	_, err := h.db.Exec("DELETE FROM smart_history WHERE timestamp < ?", cutoff)
+	if _, err := h.db.Exec("DELETE FROM smart_history WHERE timestamp < ?", cutoff); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM noise_history WHERE timestamp < ?", cutoff); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM smart_skips WHERE timestamp < ?", cutoff); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM device_events WHERE timestamp < ?", cutoff); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM disk_usage WHERE timestamp < ?", cutoff.UTC().Format("2006-01-02 15:04:05")); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM gpu_memory WHERE timestamp < ?", cutoff.UTC().Format("2006-01-02 15:04:05")); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM boots WHERE boot_time < ?", cutoff); err != nil {
+		return err
+	}
+	if _, err := h.db.Exec("DELETE FROM sent_alerts WHERE timestamp < ?", cutoff); err != nil {
+		return err
+	}
+	_, err := h.db.Exec("DELETE FROM fleet_alerts WHERE last_seen < ?", cutoff.UTC().Format("2006-01-02 15:04:05"))
 	return err
 }
 
-// GetDevices returns all devices with recorded history
+// parseTimestamp parses a stored timestamp
+// The sqlite driver returns DATETIME columns as RFC 3339, older rows may use SQLite's own layout
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04:05", value)
+}
+
+// GetHosts returns every host with recorded history
+func (h *HistoryDB) GetHosts() ([]string, error) {
+	rows, err := h.db.Query("SELECT DISTINCT host FROM smart_history ORDER BY host")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hosts []string
+	for rows.Next() {
+		var host string
+		if err := rows.Scan(&host); err != nil {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts, rows.Err()
+}
+
+// GetDevices returns all devices with recorded history for the current host
 func (h *HistoryDB) GetDevices() ([]string, error) {
-	rows, err := h.db.Query("SELECT DISTINCT device FROM smart_history ORDER BY device")
+	// Drives that were only ever skipped (e.g. always in standby) are still listed
+	rows, err := h.db.Query(`
+		SELECT device FROM smart_history WHERE host = ?
+		UNION
+		SELECT device FROM smart_skips WHERE host = ?
+		ORDER BY device`, h.host, h.host)
 	if err != nil {
 		return nil, err
 	}
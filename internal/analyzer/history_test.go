@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -132,6 +133,49 @@ func TestHistoryDB_RecordAnalysisWithIssues(t *testing.T) {
 	}
 }
 
+func TestHistoryDB_GetRecentIssues(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, device := range []string{"/dev/sda", "/dev/sdb"} {
+		smart := &types.SMARTInfo{Device: device, DetailedAttribs: []types.SMARTAttribute{}}
+		result := &AnalysisResult{
+			Device:        device,
+			OverallHealth: HealthWarning,
+			Issues: []Issue{
+				{Severity: SeverityWarning, Code: "HIGH_TEMP_WARNING", Description: "Temperature warning"},
+			},
+		}
+		if err := db.RecordAnalysis(smart, result); err != nil {
+			t.Fatalf("Failed to record analysis: %v", err)
+		}
+	}
+
+	issues, err := db.GetRecentIssues(time.Unix(0, 0), 10)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	for _, issue := range issues {
+		if issue.Code != "HIGH_TEMP_WARNING" || issue.Severity != SeverityWarning {
+			t.Errorf("Unexpected issue: %+v", issue)
+		}
+		if issue.Device == "" || issue.Timestamp.IsZero() {
+			t.Errorf("Issue missing device or timestamp: %+v", issue)
+		}
+	}
+
+	limited, err := db.GetRecentIssues(time.Unix(0, 0), 1)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("Expected limit of 1 issue, got %d", len(limited))
+	}
+}
+
 func TestHistoryDB_GetHistory(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -176,6 +220,41 @@ func TestHistoryDB_GetHistory(t *testing.T) {
 	}
 }
 
+func TestHistoryDB_ExportHistory(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		for _, device := range []string{"/dev/sdb", "/dev/sda"} {
+			smart := &types.SMARTInfo{Device: device, Temperature: 40 + i, DetailedAttribs: []types.SMARTAttribute{}}
+			result := &AnalysisResult{Device: device, OverallHealth: HealthGood, Issues: []Issue{}}
+			if err := db.RecordAnalysis(smart, result); err != nil {
+				t.Fatalf("Failed to record analysis: %v", err)
+			}
+		}
+	}
+
+	records, err := db.ExportHistory(time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("Failed to export history: %v", err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("Expected 6 records, got %d", len(records))
+	}
+	for i, record := range records {
+		wantDevice := "/dev/sda"
+		if i >= 3 {
+			wantDevice = "/dev/sdb"
+		}
+		if record.Device != wantDevice {
+			t.Errorf("record %d: device = %s, want %s", i, record.Device, wantDevice)
+		}
+		if i > 0 && record.Device == records[i-1].Device && record.Timestamp.Before(records[i-1].Timestamp) {
+			t.Error("Export not ordered oldest first")
+		}
+	}
+}
+
 func TestHistoryDB_GetTrend(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -402,3 +481,156 @@ func TestCalculateLinearTrend(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryDB_ClockOffset(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	smart := &types.SMARTInfo{Device: "/dev/sda", Temperature: 40}
+	result := &AnalysisResult{Device: "/dev/sda", OverallHealth: HealthGood}
+
+	// Uncorrected record
+	if err := db.RecordAnalysis(smart, result); err != nil {
+		t.Fatalf("Failed to record analysis: %v", err)
+	}
+
+	// A clock two hours behind is corrected forward
+	db.SetClockOffset(2 * time.Hour)
+	if err := db.RecordAnalysis(smart, result); err != nil {
+		t.Fatalf("Failed to record corrected analysis: %v", err)
+	}
+
+	history, err := db.GetHistory("/dev/sda", time.Now().Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("Expected 1 corrected record ahead of the local clock, got %d", len(history))
+	}
+	if history[0].ClockOffsetMS == nil || *history[0].ClockOffsetMS != 7200000 {
+		t.Errorf("ClockOffsetMS = %v, expected 7200000", history[0].ClockOffsetMS)
+	}
+
+	all, err := db.GetHistory("/dev/sda", time.Unix(0, 0), 10)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(all) != 2 || all[1].ClockOffsetMS != nil {
+		t.Errorf("Uncorrected record should have no clock offset: %+v", all)
+	}
+}
+
+func TestHistoryDB_MigratesOldSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	db, err := NewHistoryDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	// Recreate the table as it was before clock_offset_ms existed
+	if _, err := db.db.Exec(`DROP TABLE smart_history; CREATE TABLE smart_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, device TEXT NOT NULL,
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP, temperature INTEGER, power_on_hours INTEGER,
+		health_status TEXT, failure_probability REAL, remaining_life REAL, percent_used REAL,
+		issue_count INTEGER, critical_issues INTEGER, warning_issues INTEGER, raw_data TEXT)`); err != nil {
+		t.Fatalf("Failed to create old schema: %v", err)
+	}
+	if _, err := db.db.Exec("INSERT INTO smart_history (device, temperature, power_on_hours, health_status, failure_probability, remaining_life, percent_used, issue_count, critical_issues, warning_issues) VALUES ('/dev/sdb', 35, 100, 'GOOD', 0, 0, 0, 0, 0, 0)"); err != nil {
+		t.Fatalf("Failed to insert old record: %v", err)
+	}
+	db.Close()
+
+	db, err = NewHistoryDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+
+	smart := &types.SMARTInfo{Device: "/dev/sda"}
+	if err := db.RecordAnalysis(smart, &AnalysisResult{Device: "/dev/sda", OverallHealth: HealthGood}); err != nil {
+		t.Errorf("RecordAnalysis after migration failed: %v", err)
+	}
+	if history, err := db.GetHistory("/dev/sda", time.Unix(0, 0), 10); err != nil || len(history) != 1 || history[0].Host != db.Host() {
+		t.Errorf("GetHistory after migration = %+v, %v; expected one record for the local host", history, err)
+	}
+	// Existing records are assigned to the local host
+	if history, err := db.GetHistory("/dev/sdb", time.Unix(0, 0), 10); err != nil || len(history) != 1 {
+		t.Errorf("GetHistory for a pre-migration record = %+v, %v; expected one record", history, err)
+	}
+}
+
+func TestHistoryDB_Hosts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if db.Host() == "" {
+		t.Fatal("Host should default to the local host name")
+	}
+
+	result := &AnalysisResult{Device: "/dev/sda", OverallHealth: HealthWarning, Issues: []Issue{
+		{Severity: SeverityWarning, Code: "TEMP_HIGH", Description: "Temperature high"},
+	}}
+
+	db.SetHost("web01")
+	if err := db.RecordAnalysis(&types.SMARTInfo{Device: "/dev/sda", Temperature: 40}, result); err != nil {
+		t.Fatalf("Failed to record analysis: %v", err)
+	}
+	db.SetHost("web02")
+	if err := db.RecordAnalysis(&types.SMARTInfo{Device: "/dev/sda", Temperature: 55}, result); err != nil {
+		t.Fatalf("Failed to record analysis: %v", err)
+	}
+
+	hosts, err := db.GetHosts()
+	if err != nil {
+		t.Fatalf("Failed to get hosts: %v", err)
+	}
+	if len(hosts) != 2 || hosts[0] != "web01" || hosts[1] != "web02" {
+		t.Errorf("GetHosts() = %v, expected [web01 web02]", hosts)
+	}
+
+	// The same device name on different hosts does not collide
+	history, err := db.GetHistory("/dev/sda", time.Unix(0, 0), 10)
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	if len(history) != 1 || history[0].Temperature != 55 || history[0].Host != "web02" {
+		t.Errorf("GetHistory for web02 = %+v, expected the single web02 record", history)
+	}
+
+	db.SetHost("web01")
+	issues, err := db.GetRecentIssues(time.Unix(0, 0), 10)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Host != "web01" {
+		t.Errorf("GetRecentIssues for web01 = %+v, expected one web01 issue", issues)
+	}
+
+	db.SetHost("unknown")
+	devices, err := db.GetDevices()
+	if err != nil {
+		t.Fatalf("Failed to get devices: %v", err)
+	}
+	if len(devices) != 0 {
+		t.Errorf("GetDevices for an unknown host = %v, expected none", devices)
+	}
+}
+
+func TestHistoryDB_Writable(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := db.Writable(ctx); err != nil {
+		t.Fatalf("Writable() = %v, expected a fresh database to be writable", err)
+	}
+	// The probe leaves no transaction open behind it
+	if err := db.RecordAnalysis(&types.SMARTInfo{Device: "/dev/sda"}, &AnalysisResult{Device: "/dev/sda", OverallHealth: HealthGood}); err != nil {
+		t.Fatalf("RecordAnalysis after Writable() failed: %v", err)
+	}
+
+	db.Close()
+	if err := db.Writable(ctx); err == nil {
+		t.Error("Writable() = nil for a closed database")
+	}
+}
@@ -0,0 +1,258 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Host health components, as named in HealthWeights
+const (
+	HealthSMART       = "smart"
+	HealthDisk        = "disk"
+	HealthMemory      = "memory"
+	HealthTemperature = "temperature"
+	HealthServices    = "services"
+)
+
+// DefaultHealthWeights weigh failing hardware and services above resource pressure
+var DefaultHealthWeights = map[string]float64{
+	HealthSMART:       3,
+	HealthServices:    2,
+	HealthTemperature: 2,
+	HealthDisk:        2,
+	HealthMemory:      1,
+}
+
+// healthWorstMargin caps the composite this far above the worst component, so one critical
+// problem is not averaged away by healthy components
+const healthWorstMargin = 50.0
+
+// Usage levels at which the disk and memory scores start to drop, reaching 0 when full
+const (
+	diskUsageHealthy   = 80.0
+	memoryUsageHealthy = 80.0
+)
+
+// GPU temperatures used when the GPU does not report its own slowdown and shutdown points
+const (
+	gpuTempHigh     = 85.0
+	gpuTempCritical = 100.0
+)
+
+// ValidHealthWeights checks configured weights: known components and no negative weights
+func ValidHealthWeights(weights map[string]float64) error {
+	for name, weight := range weights {
+		if _, ok := DefaultHealthWeights[name]; !ok {
+			return fmt.Errorf("unknown health component %q (expected smart, disk, memory, temperature or services)", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("health weight for %s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// ScoreHostHealth combines what was collected into a 0-100 score: the weighted mean of the
+// components that have data, capped at healthWorstMargin above the worst of them. weights
+// override DefaultHealthWeights per component; a weight of 0 leaves the component out.
+// Returns nil when nothing scorable was collected
+func ScoreHostHealth(info *types.SystemInfo, weights map[string]float64) *types.HostHealth {
+	var components []types.HealthComponent
+	add := func(name string, score float64, detail string) {
+		weight, ok := weights[name]
+		if !ok {
+			weight = DefaultHealthWeights[name]
+		}
+		if weight > 0 {
+			components = append(components, types.HealthComponent{Name: name, Score: roundScore(score), Weight: weight, Detail: detail})
+		}
+	}
+
+	if info.Disk != nil && len(info.Disk.SMARTData) > 0 {
+		score, detail := smartHealthScore(info.Disk.SMARTData)
+		add(HealthSMART, score, detail)
+	}
+	if info.Disk != nil {
+		if score, detail, ok := diskHealthScore(info.Disk.Partitions); ok {
+			add(HealthDisk, score, detail)
+		}
+	}
+	if info.Memory != nil && info.Memory.Total > 0 {
+		add(HealthMemory, usageScore(info.Memory.UsedPercent, memoryUsageHealthy),
+			fmt.Sprintf("%.0f%% used", info.Memory.UsedPercent))
+	}
+	if score, detail, ok := temperatureHealthScore(info); ok {
+		add(HealthTemperature, score, detail)
+	}
+	if info.System != nil {
+		failed := info.System.FailedServices
+		detail := "none failed"
+		if len(failed) > 0 {
+			detail = fmt.Sprintf("%d failed: %s", len(failed), strings.Join(failed, ", "))
+		}
+		add(HealthServices, math.Max(0, 100-25*float64(len(failed))), detail)
+	}
+
+	if len(components) == 0 {
+		return nil
+	}
+
+	var total, weightSum float64
+	worst := 100.0
+	for _, c := range components {
+		total += c.Score * c.Weight
+		weightSum += c.Weight
+		worst = math.Min(worst, c.Score)
+	}
+	score := math.Min(total/weightSum, worst+healthWorstMargin)
+	return &types.HostHealth{Score: roundScore(score), Components: components}
+}
+
+// smartHealthScore scores the worst drive: 100 less its predicted failure probability, held
+// down by the analyzer's verdict and 0 for a drive failing its own self-assessment
+func smartHealthScore(drives []types.SMARTInfo) (float64, string) {
+	smartAnalyzer := NewSMARTAnalyzer()
+	worst, detail := 100.0, fmt.Sprintf("%d drives healthy", len(drives))
+	for i := range drives {
+		smart := &drives[i]
+		result := smartAnalyzer.Analyze(smart)
+		score := 100 - result.FailureProbability
+		switch result.OverallHealth {
+		case HealthFailing:
+			score = 0
+		case HealthCritical:
+			score = math.Min(score, 25)
+		case HealthWarning:
+			score = math.Min(score, 70)
+		}
+		verdict := string(result.OverallHealth)
+		if !smart.Healthy {
+			score, verdict = 0, "failed self-assessment"
+		}
+		if score < worst {
+			worst, detail = score, fmt.Sprintf("%s %s", smart.Device, verdict)
+		}
+	}
+	return worst, detail
+}
+
+// diskHealthScore scores the fullest filesystem, ignoring loop devices and read-only images
+func diskHealthScore(partitions []types.PartitionInfo) (float64, string, bool) {
+	var fullest *types.PartitionInfo
+	for i := range partitions {
+		p := &partitions[i]
+		if p.Total == 0 || strings.HasPrefix(p.Device, "/dev/loop") || p.FSType == "squashfs" {
+			continue
+		}
+		if fullest == nil || p.UsedPercent > fullest.UsedPercent {
+			fullest = p
+		}
+	}
+	if fullest == nil {
+		return 0, "", false
+	}
+	return usageScore(fullest.UsedPercent, diskUsageHealthy), fmt.Sprintf("%s %.0f%% full", fullest.MountPoint, fullest.UsedPercent), true
+}
+
+// temperatureHealthScore scores the hottest sensor relative to its thresholds. Thermal zones
+// count when they have trip points; without the thermal module, drive and GPU readings are used
+func temperatureHealthScore(info *types.SystemInfo) (float64, string, bool) {
+	type reading struct {
+		name           string
+		celsius        float64
+		high, critical float64
+	}
+	var readings []reading
+
+	if info.Thermal != nil {
+		// Drive and GPU readings are already among the sensors, with their thresholds
+		for _, sensor := range info.Thermal.Sensors {
+			high, critical := tripThresholds(sensor.TripPoints)
+			if critical > 0 {
+				readings = append(readings, reading{sensor.Name, sensor.Temperature, high, critical})
+			}
+		}
+	} else {
+		defaults := DefaultAnalyzerConfig()
+		if info.Disk != nil {
+			for _, smart := range info.Disk.SMARTData {
+				if smart.Temperature > 0 {
+					readings = append(readings, reading{smart.Device, float64(smart.Temperature), float64(defaults.TempWarning), float64(defaults.TempCritical)})
+				}
+			}
+		}
+		if info.GPU != nil {
+			for _, gpu := range info.GPU.GPUs {
+				if gpu.Temperature <= 0 {
+					continue
+				}
+				high, critical := gpuTempHigh, gpuTempCritical
+				if gpu.TemperatureSlowdown > 0 {
+					high = float64(gpu.TemperatureSlowdown)
+				}
+				if gpu.TemperatureShutdown > 0 {
+					critical = float64(gpu.TemperatureShutdown)
+				}
+				readings = append(readings, reading{fmt.Sprintf("GPU %d", gpu.Index), float64(gpu.Temperature), high, critical})
+			}
+		}
+	}
+	if len(readings) == 0 {
+		return 0, "", false
+	}
+
+	// The detail names the lowest scoring sensor, the hottest among equals, and the first by
+	// name among those, so it is stable between runs
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].name < readings[j].name })
+	worst, hottest, detail := 101.0, 0.0, ""
+	for _, r := range readings {
+		score := 100.0
+		if r.critical > r.high && r.celsius > r.high {
+			score = math.Max(0, 100*(r.critical-r.celsius)/(r.critical-r.high))
+		} else if r.celsius >= r.critical {
+			score = 0
+		}
+		if score < worst || (score == worst && r.celsius > hottest) {
+			worst, hottest, detail = score, r.celsius, fmt.Sprintf("%s at %.0f°C", r.name, r.celsius)
+		}
+	}
+	return worst, detail, true
+}
+
+// tripThresholds returns the first temperature where a sensor's platform intervenes (passive
+// cooling, slowdown, high) and the one where it shuts down or goes critical; high defaults to
+// 15°C below critical
+func tripThresholds(trips []types.TripPoint) (high, critical float64) {
+	for _, trip := range trips {
+		switch trip.Type {
+		case "critical", "shutdown":
+			if critical == 0 || trip.Temperature < critical {
+				critical = trip.Temperature
+			}
+		case "passive", "hot", "high", "slowdown":
+			if high == 0 || trip.Temperature < high {
+				high = trip.Temperature
+			}
+		}
+	}
+	if critical > 0 && (high == 0 || high >= critical) {
+		high = critical - 15
+	}
+	return high, critical
+}
+
+// usageScore is 100 up to healthy percent used, falling linearly to 0 when full
+func usageScore(usedPercent, healthy float64) float64 {
+	if usedPercent <= healthy {
+		return 100
+	}
+	return math.Max(0, 100*(100-usedPercent)/(100-healthy))
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
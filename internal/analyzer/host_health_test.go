@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func healthyHost() *types.SystemInfo {
+	return &types.SystemInfo{
+		System: &types.SystemData{Hostname: "web-1"},
+		Memory: &types.MemoryData{Total: 16 << 30, UsedPercent: 40},
+		Disk: &types.DiskData{
+			Partitions: []types.PartitionInfo{
+				{Device: "/dev/sda1", MountPoint: "/", FSType: "ext4", Total: 100 << 30, UsedPercent: 50},
+				{Device: "/dev/loop0", MountPoint: "/snap/core", FSType: "squashfs", Total: 1 << 20, UsedPercent: 100},
+			},
+			SMARTData: []types.SMARTInfo{{Device: "/dev/sda", Healthy: true, Temperature: 35}},
+		},
+	}
+}
+
+func healthComponent(health *types.HostHealth, name string) (types.HealthComponent, bool) {
+	for _, c := range health.Components {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return types.HealthComponent{}, false
+}
+
+func TestScoreHostHealth(t *testing.T) {
+	health := ScoreHostHealth(healthyHost(), nil)
+	if health == nil || health.Score != 100 || len(health.Components) != 5 {
+		t.Fatalf("ScoreHostHealth(healthy) = %+v, expected 100 from five components", health)
+	}
+
+	// A full disk alone drags the weighted mean down
+	info := healthyHost()
+	info.Disk.Partitions[0].UsedPercent = 90
+	health = ScoreHostHealth(info, nil)
+	disk, _ := healthComponent(health, HealthDisk)
+	if disk.Score != 50 || disk.Detail != "/ 90% full" {
+		t.Errorf("disk component = %+v, expected 50 for / 90%% full", disk)
+	}
+	if health.Score != 90 {
+		t.Errorf("score = %v, expected 90", health.Score)
+	}
+
+	// A failed drive is not averaged away by the healthy components
+	info = healthyHost()
+	info.Disk.SMARTData[0].Healthy = false
+	health = ScoreHostHealth(info, nil)
+	if smart, _ := healthComponent(health, HealthSMART); smart.Score != 0 || !strings.Contains(smart.Detail, "/dev/sda") {
+		t.Errorf("SMART component = %+v, expected 0 naming /dev/sda", smart)
+	}
+	if health.Score != healthWorstMargin {
+		t.Errorf("score = %v, expected the worst component plus %v", health.Score, healthWorstMargin)
+	}
+
+	info = healthyHost()
+	info.System.FailedServices = []string{"nginx.service", "cron.service"}
+	health = ScoreHostHealth(info, nil)
+	if services, _ := healthComponent(health, HealthServices); services.Score != 50 || services.Detail != "2 failed: nginx.service, cron.service" {
+		t.Errorf("services component = %+v", services)
+	}
+
+	if health := ScoreHostHealth(&types.SystemInfo{}, nil); health != nil {
+		t.Errorf("ScoreHostHealth(empty) = %+v, expected nil", health)
+	}
+}
+
+func TestScoreHostHealth_Weights(t *testing.T) {
+	info := healthyHost()
+	info.Memory.UsedPercent = 90
+
+	health := ScoreHostHealth(info, map[string]float64{HealthMemory: 0})
+	if _, ok := healthComponent(health, HealthMemory); ok || health.Score != 100 {
+		t.Errorf("ScoreHostHealth(memory 0) = %+v, expected memory left out", health)
+	}
+
+	// Memory at 50 weighs 1 of 10 by default, and half the total when weighted 9
+	if health := ScoreHostHealth(info, nil); health.Score != 95 {
+		t.Errorf("default weights score = %v, expected 95", health.Score)
+	}
+	if health := ScoreHostHealth(info, map[string]float64{HealthMemory: 9}); health.Score != 75 {
+		t.Errorf("memory weight 9 score = %v, expected 75", health.Score)
+	}
+}
+
+func TestScoreHostHealth_Temperature(t *testing.T) {
+	// Without thermal zones, drives are scored against the analyzer thresholds
+	info := healthyHost()
+	info.Disk.SMARTData = append(info.Disk.SMARTData, types.SMARTInfo{Device: "/dev/sdb", Healthy: true, Temperature: 65})
+	temperature, _ := healthComponent(ScoreHostHealth(info, nil), HealthTemperature)
+	if temperature.Score != 50 || temperature.Detail != "/dev/sdb at 65°C" {
+		t.Errorf("temperature component = %+v, expected 50 for /dev/sdb at 65°C", temperature)
+	}
+
+	// Thermal zones use their own trip points; zones without any are not scored
+	info.Thermal = &types.ThermalData{Sensors: []types.ThermalSensor{
+		{Name: "x86_pkg_temp", Temperature: 90, TripPoints: []types.TripPoint{{Type: "passive", Temperature: 80}, {Type: "critical", Temperature: 100}}},
+		{Name: "acpitz", Temperature: 120},
+	}}
+	temperature, _ = healthComponent(ScoreHostHealth(info, nil), HealthTemperature)
+	if temperature.Score != 50 || temperature.Detail != "x86_pkg_temp at 90°C" {
+		t.Errorf("temperature component = %+v, expected 50 for x86_pkg_temp at 90°C", temperature)
+	}
+}
+
+func TestValidHealthWeights(t *testing.T) {
+	if err := ValidHealthWeights(map[string]float64{HealthSMART: 5, HealthMemory: 0}); err != nil {
+		t.Errorf("ValidHealthWeights() = %v", err)
+	}
+	for _, weights := range []map[string]float64{{"fans": 1}, {HealthDisk: -1}} {
+		if err := ValidHealthWeights(weights); err == nil {
+			t.Errorf("ValidHealthWeights(%v) succeeded, expected an error", weights)
+		}
+	}
+}
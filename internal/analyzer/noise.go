@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"database/sql"
+	"time"
+)
+
+// noiseTrendThreshold is the change over the window, in dB, before a machine counts as louder or quieter
+const noiseTrendThreshold = 1.0
+
+// NoiseTrend summarizes how a machine's estimated noise level changed over time
+type NoiseTrend struct {
+	Trend       string  // "increasing", "stable", "decreasing"
+	DBPerDay    float64 // Least-squares slope of the total estimate
+	RecordCount int
+}
+
+// RecordNoise stores the estimated noise level of this host
+func (h *HistoryDB) RecordNoise(totalDBA float64) error {
+	// Without a clock correction the database records its own CURRENT_TIMESTAMP
+	var timestamp sql.NullString
+	if h.clockOffset != nil {
+		timestamp = sql.NullString{String: time.Now().Add(*h.clockOffset).UTC().Format("2006-01-02 15:04:05"), Valid: true}
+	}
+
+	_, err := h.db.Exec(`INSERT INTO noise_history (host, timestamp, total_dba) VALUES (?, COALESCE(?, CURRENT_TIMESTAMP), ?)`,
+		h.host, timestamp, totalDBA)
+	return err
+}
+
+// GetNoiseTrend fits a line through this host's noise estimates since the given time.
+// Fans spinning faster for the same load is an early sign of dust, failing
+// bearings or dried-out thermal paste
+func (h *HistoryDB) GetNoiseTrend(since time.Time) (*NoiseTrend, error) {
+	rows, err := h.db.Query(`
+		SELECT total_dba, timestamp
+		FROM noise_history
+		WHERE host = ? AND timestamp >= ?
+		ORDER BY timestamp ASC`, h.host, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days, values []float64
+	var first time.Time
+	for rows.Next() {
+		var value float64
+		var timestamp string
+		if err := rows.Scan(&value, &timestamp); err != nil {
+			continue
+		}
+		t, err := parseTimestamp(timestamp)
+		if err != nil {
+			continue
+		}
+		if len(days) == 0 {
+			first = t
+		}
+		days = append(days, t.Sub(first).Hours()/24)
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trend := &NoiseTrend{Trend: "stable", RecordCount: len(values)}
+	if len(values) < 3 {
+		return trend, nil
+	}
+
+	trend.DBPerDay = leastSquaresSlope(days, values)
+	change := trend.DBPerDay * days[len(days)-1]
+	if change > noiseTrendThreshold {
+		trend.Trend = "increasing"
+	} else if change < -noiseTrendThreshold {
+		trend.Trend = "decreasing"
+	}
+	return trend, nil
+}
+
+// leastSquaresSlope returns the slope of y over x; unlike calculateLinearTrend the samples need not be evenly spaced
+func leastSquaresSlope(x, y []float64) float64 {
+	n := float64(len(x))
+	var sumX, sumY, sumXY, sumX2 float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+		sumXY += x[i] * y[i]
+		sumX2 += x[i] * x[i]
+	}
+
+	denominator := n*sumX2 - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLeastSquaresSlope(t *testing.T) {
+	// Uneven spacing: 1 dB per day regardless of sample gaps
+	slope := leastSquaresSlope([]float64{0, 1, 5, 6}, []float64{30, 31, 35, 36})
+	if math.Abs(slope-1) > 1e-9 {
+		t.Errorf("leastSquaresSlope() = %v, expected 1", slope)
+	}
+	if slope := leastSquaresSlope([]float64{2, 2}, []float64{30, 40}); slope != 0 {
+		t.Errorf("leastSquaresSlope() with identical x = %v, expected 0", slope)
+	}
+}
+
+func TestHistoryDB_NoiseTrend(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	start := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	for day, dba := range []float64{31.0, 31.2, 31.9, 32.5, 33.4} {
+		timestamp := start.Add(time.Duration(day*7*24) * time.Hour).Format("2006-01-02 15:04:05")
+		if _, err := db.db.Exec("INSERT INTO noise_history (host, timestamp, total_dba) VALUES (?, ?, ?)", db.Host(), timestamp, dba); err != nil {
+			t.Fatalf("Failed to insert noise record: %v", err)
+		}
+	}
+	// Another machine's history is not part of this host's trend
+	if _, err := db.db.Exec("INSERT INTO noise_history (host, total_dba) VALUES ('other', 60)"); err != nil {
+		t.Fatalf("Failed to insert noise record: %v", err)
+	}
+
+	trend, err := db.GetNoiseTrend(time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("GetNoiseTrend failed: %v", err)
+	}
+	if trend.RecordCount != 5 || trend.Trend != "increasing" || trend.DBPerDay <= 0 {
+		t.Errorf("GetNoiseTrend() = %+v, expected 5 increasing records", trend)
+	}
+
+	if err := db.RecordNoise(33.5); err != nil {
+		t.Fatalf("RecordNoise failed: %v", err)
+	}
+	trend, err = db.GetNoiseTrend(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetNoiseTrend failed: %v", err)
+	}
+	if trend.RecordCount != 1 || trend.Trend != "stable" {
+		t.Errorf("GetNoiseTrend() over the last hour = %+v, expected 1 stable record", trend)
+	}
+}
@@ -0,0 +1,379 @@
+package analyzer
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// Fleet anomaly kinds
+const (
+	AnomalyTemperature = "temperature"
+	AnomalyFirmware    = "firmware"
+	AnomalyMemory      = "memory"
+	AnomalyIntegrity   = "integrity"
+)
+
+const (
+	// fleetMinPeers is the fewest hosts a temperature or memory size is compared across
+	fleetMinPeers = 3
+	// fleetTempMargin is how far above the fleet median a temperature must be to stand out,
+	// unless the fleet's own spread is wider
+	fleetTempMargin = 10.0
+	// fleetMemoryTolerance leaves room for memory reserved by firmware and the kernel, which
+	// varies a little between otherwise identical machines
+	fleetMemoryTolerance = 0.95
+)
+
+// FleetHost is one host's report in a fleet analysis
+type FleetHost struct {
+	Name string // Hostname, or where the report came from
+	Info *types.SystemInfo
+}
+
+// FleetAnomaly is one way a host differs from its peers
+type FleetAnomaly struct {
+	Host     string `json:"host"`
+	Kind     string `json:"kind"`     // temperature, firmware, memory, integrity
+	Subject  string `json:"subject"`  // What differs, e.g. "drive /dev/sda (Samsung SSD 870 EVO 1TB)"
+	Value    string `json:"value"`    // This host's value
+	Expected string `json:"expected"` // What its peers have
+	Peers    int    `json:"peers"`    // Hosts or drives the value is compared with
+}
+
+// FleetAnalysis is the anomaly summary of a set of host reports
+type FleetAnalysis struct {
+	Hosts     int            `json:"hosts"`
+	Anomalies []FleetAnomaly `json:"anomalies"`
+}
+
+// AnalyzeFleet compares host reports and flags outliers: temperatures far above the fleet
+// median, drive firmware and CPU microcode older than peers of the same model, less memory
+// than most hosts with the same CPU, and files whose checksum differs from most hosts'
+func AnalyzeFleet(hosts []FleetHost) *FleetAnalysis {
+	analysis := &FleetAnalysis{Hosts: len(hosts), Anomalies: []FleetAnomaly{}}
+	analysis.Anomalies = append(analysis.Anomalies, temperatureOutliers(hosts)...)
+	analysis.Anomalies = append(analysis.Anomalies, firmwareOutliers(hosts)...)
+	analysis.Anomalies = append(analysis.Anomalies, memoryOutliers(hosts)...)
+	analysis.Anomalies = append(analysis.Anomalies, integrityOutliers(hosts)...)
+
+	sort.SliceStable(analysis.Anomalies, func(i, j int) bool {
+		a, b := analysis.Anomalies[i], analysis.Anomalies[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Subject < b.Subject
+	})
+	return analysis
+}
+
+// temperatureOutliers compares each host's hottest CPU sensor, drive and GPU with the rest of
+// the fleet's, flagging those above the median by more than the margin or three median
+// absolute deviations, whichever is wider
+func temperatureOutliers(hosts []FleetHost) []FleetAnomaly {
+	type reading struct {
+		host    string
+		subject string
+		celsius float64
+	}
+	readings := map[string][]reading{}
+	for _, host := range hosts {
+		info := host.Info
+		if info.Thermal != nil {
+			hottest := reading{host: host.Name}
+			for _, sensor := range info.Thermal.Sensors {
+				if sensor.Component == "CPU" && sensor.Temperature > hottest.celsius {
+					hottest.subject, hottest.celsius = "CPU "+sensor.Name, sensor.Temperature
+				}
+			}
+			if hottest.celsius > 0 {
+				readings["CPU"] = append(readings["CPU"], hottest)
+			}
+		}
+		if info.Disk != nil {
+			hottest := reading{host: host.Name}
+			for _, smart := range info.Disk.SMARTData {
+				if float64(smart.Temperature) > hottest.celsius {
+					hottest.subject, hottest.celsius = "drive "+smart.Device, float64(smart.Temperature)
+				}
+			}
+			if hottest.celsius > 0 {
+				readings["drive"] = append(readings["drive"], hottest)
+			}
+		}
+		if info.GPU != nil {
+			hottest := reading{host: host.Name}
+			for _, gpu := range info.GPU.GPUs {
+				if float64(gpu.Temperature) > hottest.celsius {
+					hottest.subject, hottest.celsius = fmt.Sprintf("GPU %d (%s)", gpu.Index, gpu.Name), float64(gpu.Temperature)
+				}
+			}
+			if hottest.celsius > 0 {
+				readings["GPU"] = append(readings["GPU"], hottest)
+			}
+		}
+	}
+
+	var anomalies []FleetAnomaly
+	for _, component := range []string{"CPU", "drive", "GPU"} {
+		group := readings[component]
+		if len(group) < fleetMinPeers {
+			continue
+		}
+		values := make([]float64, len(group))
+		for i, r := range group {
+			values[i] = r.celsius
+		}
+		median := medianOf(values)
+		deviations := make([]float64, len(values))
+		for i, v := range values {
+			deviations[i] = math.Abs(v - median)
+		}
+		limit := median + math.Max(fleetTempMargin, 3*medianOf(deviations))
+		for _, r := range group {
+			if r.celsius > limit {
+				anomalies = append(anomalies, FleetAnomaly{
+					Host:     r.host,
+					Kind:     AnomalyTemperature,
+					Subject:  r.subject,
+					Value:    fmt.Sprintf("%.0f°C", r.celsius),
+					Expected: fmt.Sprintf("fleet median %.0f°C", median),
+					Peers:    len(group) - 1,
+				})
+			}
+		}
+	}
+	return anomalies
+}
+
+// firmwareOutliers flags drives and CPUs running older firmware than others of the same model
+func firmwareOutliers(hosts []FleetHost) []FleetAnomaly {
+	type install struct {
+		host    string
+		subject string
+		version string
+	}
+	groups := map[string][]install{}
+	var models []string
+	add := func(kind, model string, i install) {
+		if model == "" || i.version == "" {
+			return
+		}
+		key := kind + "/" + model
+		if _, ok := groups[key]; !ok {
+			models = append(models, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	for _, host := range hosts {
+		if cpu := host.Info.CPU; cpu != nil {
+			add("cpu", cpu.ModelName, install{host.Name, "CPU microcode (" + cpu.ModelName + ")", cpu.Microcode})
+		}
+		if disk := host.Info.Disk; disk != nil {
+			for _, smart := range disk.SMARTData {
+				add("drive", smart.DeviceModel, install{host.Name, fmt.Sprintf("drive %s (%s)", smart.Device, smart.DeviceModel), smart.FirmwareVersion})
+			}
+		}
+	}
+
+	var anomalies []FleetAnomaly
+	for _, model := range models {
+		group := groups[model]
+		newest, onNewest := "", 0
+		for _, i := range group {
+			if newest == "" || compareFirmware(i.version, newest) > 0 {
+				newest = i.version
+			}
+		}
+		for _, i := range group {
+			if compareFirmware(i.version, newest) == 0 {
+				onNewest++
+			}
+		}
+		for _, i := range group {
+			if compareFirmware(i.version, newest) < 0 {
+				anomalies = append(anomalies, FleetAnomaly{
+					Host:     i.host,
+					Kind:     AnomalyFirmware,
+					Subject:  i.subject,
+					Value:    i.version,
+					Expected: fmt.Sprintf("%s on %d of %d", newest, onNewest, len(group)),
+					Peers:    len(group) - 1,
+				})
+			}
+		}
+	}
+	return anomalies
+}
+
+// memoryOutliers flags hosts with less memory than the most common size among hosts with the
+// same CPU, taken as that model's spec
+func memoryOutliers(hosts []FleetHost) []FleetAnomaly {
+	groups := map[string][]FleetHost{}
+	var models []string
+	for _, host := range hosts {
+		if host.Info.CPU == nil || host.Info.CPU.ModelName == "" || host.Info.Memory == nil || host.Info.Memory.Total == 0 {
+			continue
+		}
+		model := host.Info.CPU.ModelName
+		if _, ok := groups[model]; !ok {
+			models = append(models, model)
+		}
+		groups[model] = append(groups[model], host)
+	}
+
+	var anomalies []FleetAnomaly
+	for _, model := range models {
+		group := groups[model]
+		if len(group) < fleetMinPeers {
+			continue
+		}
+		// Sizes are counted to the GiB, as usable memory differs by a few megabytes
+		counts := map[uint64]int{}
+		var spec uint64
+		for _, host := range group {
+			gib := roundGiB(host.Info.Memory.Total)
+			counts[gib]++
+			if counts[gib] > counts[spec] || (counts[gib] == counts[spec] && gib > spec) {
+				spec = gib
+			}
+		}
+		for _, host := range group {
+			total := host.Info.Memory.Total
+			if float64(total) < fleetMemoryTolerance*float64(spec<<30) {
+				anomalies = append(anomalies, FleetAnomaly{
+					Host:     host.Name,
+					Kind:     AnomalyMemory,
+					Subject:  "memory (" + model + ")",
+					Value:    utils.FormatBytes(total),
+					Expected: fmt.Sprintf("%s on %d of %d", utils.FormatBytes(spec<<30), counts[spec], len(group)),
+					Peers:    len(group) - 1,
+				})
+			}
+		}
+	}
+	return anomalies
+}
+
+// integrityOutliers flags hosts whose copy of a file hashes differently from the copy most
+// hosts reporting it have, taken as the known-good one. A file is only compared when at
+// least fleetMinPeers hosts hashed it and more than half of them agree, as hosts on
+// different OS releases legitimately have different binaries
+func integrityOutliers(hosts []FleetHost) []FleetAnomaly {
+	type hashed struct {
+		host   string
+		sha256 string
+	}
+	groups := map[string][]hashed{}
+	var paths []string
+	for _, host := range hosts {
+		if host.Info.Integrity == nil {
+			continue
+		}
+		for _, file := range host.Info.Integrity.Files {
+			if file.SHA256 == "" {
+				continue
+			}
+			if _, ok := groups[file.Path]; !ok {
+				paths = append(paths, file.Path)
+			}
+			groups[file.Path] = append(groups[file.Path], hashed{host.Name, file.SHA256})
+		}
+	}
+
+	var anomalies []FleetAnomaly
+	for _, path := range paths {
+		group := groups[path]
+		if len(group) < fleetMinPeers {
+			continue
+		}
+		counts := map[string]int{}
+		known := ""
+		for _, c := range group {
+			counts[c.sha256]++
+			if counts[c.sha256] > counts[known] {
+				known = c.sha256
+			}
+		}
+		if counts[known]*2 <= len(group) {
+			continue
+		}
+		for _, c := range group {
+			if c.sha256 != known {
+				anomalies = append(anomalies, FleetAnomaly{
+					Host:     c.host,
+					Kind:     AnomalyIntegrity,
+					Subject:  "file " + path,
+					Value:    shortChecksum(c.sha256),
+					Expected: fmt.Sprintf("%s on %d of %d", shortChecksum(known), counts[known], len(group)),
+					Peers:    len(group) - 1,
+				})
+			}
+		}
+	}
+	return anomalies
+}
+
+// shortChecksum abbreviates a SHA-256 the way git does commits, long enough to tell copies apart
+func shortChecksum(sha256 string) string {
+	if len(sha256) > 12 {
+		return sha256[:12]
+	}
+	return sha256
+}
+
+func roundGiB(bytes uint64) uint64 {
+	return uint64(math.Round(float64(bytes) / (1 << 30)))
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// compareFirmware orders firmware versions, returning -1, 0 or 1. Drive firmware mixes
+// letters and digits (2B2QEXM7, SVQ02B6Q), so runs of digits compare as numbers and
+// anything else alphabetically, ignoring case
+func compareFirmware(a, b string) int {
+	a, b = strings.ToUpper(strings.TrimSpace(a)), strings.ToUpper(strings.TrimSpace(b))
+	for a != "" && b != "" {
+		chunkA, chunkB := firmwareChunk(a), firmwareChunk(b)
+		a, b = a[len(chunkA):], b[len(chunkB):]
+		if isDigit(chunkA[0]) && isDigit(chunkB[0]) {
+			chunkA, chunkB = strings.TrimLeft(chunkA, "0"), strings.TrimLeft(chunkB, "0")
+			if len(chunkA) != len(chunkB) {
+				return cmp.Compare(len(chunkA), len(chunkB))
+			}
+		}
+		if c := strings.Compare(chunkA, chunkB); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// firmwareChunk returns the leading run of digits or non-digits of s
+func firmwareChunk(s string) string {
+	end := 1
+	for end < len(s) && isDigit(s[end]) == isDigit(s[0]) {
+		end++
+	}
+	return s[:end]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func fleetHost(name string, memoryGiB float64, driveTemp int, firmware string) FleetHost {
+	return FleetHost{Name: name, Info: &types.SystemInfo{
+		CPU:    &types.CPUData{ModelName: "AMD EPYC 7313", Microcode: "0xa0011d1"},
+		Memory: &types.MemoryData{Total: uint64(memoryGiB * (1 << 30))},
+		Disk: &types.DiskData{SMARTData: []types.SMARTInfo{
+			{Device: "/dev/sda", DeviceModel: "Samsung SSD 870 EVO 1TB", FirmwareVersion: firmware, Temperature: driveTemp},
+		}},
+	}}
+}
+
+func TestAnalyzeFleet(t *testing.T) {
+	var hosts []FleetHost
+	for i := 0; i < 5; i++ {
+		hosts = append(hosts, fleetHost(fmt.Sprintf("node-%d", i), 62.8, 34+i, "SVT02B6Q"))
+	}
+	hosts[1] = fleetHost("node-1", 31.4, 58, "SVT01B6Q")
+	hosts[3].Info.CPU.Microcode = "0xa0011ce"
+
+	analysis := AnalyzeFleet(hosts)
+	if analysis.Hosts != 5 {
+		t.Errorf("Hosts = %d, expected 5", analysis.Hosts)
+	}
+	expected := []FleetAnomaly{
+		{Host: "node-1", Kind: AnomalyFirmware, Subject: "drive /dev/sda (Samsung SSD 870 EVO 1TB)", Value: "SVT01B6Q", Expected: "SVT02B6Q on 4 of 5", Peers: 4},
+		{Host: "node-1", Kind: AnomalyMemory, Subject: "memory (AMD EPYC 7313)", Value: "31.40 GB", Expected: "63.00 GB on 4 of 5", Peers: 4},
+		{Host: "node-1", Kind: AnomalyTemperature, Subject: "drive /dev/sda", Value: "58°C", Expected: "fleet median 37°C", Peers: 4},
+		{Host: "node-3", Kind: AnomalyFirmware, Subject: "CPU microcode (AMD EPYC 7313)", Value: "0xa0011ce", Expected: "0xa0011d1 on 4 of 5", Peers: 4},
+	}
+	if len(analysis.Anomalies) != len(expected) {
+		t.Fatalf("Anomalies = %+v, expected %d", analysis.Anomalies, len(expected))
+	}
+	for i, anomaly := range analysis.Anomalies {
+		if anomaly != expected[i] {
+			t.Errorf("anomaly %d = %+v, expected %+v", i, anomaly, expected[i])
+		}
+	}
+
+	// Two hosts are too few to say which one is off
+	if analysis := AnalyzeFleet(hosts[:2]); len(analysis.Anomalies) != 1 || analysis.Anomalies[0].Kind != AnomalyFirmware {
+		t.Errorf("AnalyzeFleet(2 hosts) = %+v, expected only the firmware difference", analysis.Anomalies)
+	}
+}
+
+func TestIntegrityOutliers(t *testing.T) {
+	const good, patched = "9d1b3f2ac71e1e04c9e6b7a1b5b26d3bc0e7a0f1d1c2b3a4f5e6d7c8b9a0f1e2", "0c8e3a1f6b2d4e5f7a9b1c3d5e7f9a1b3c5d7e9f1a3b5c7d9e1f3a5b7c9d1e3f"
+	var hosts []FleetHost
+	for i := 0; i < 4; i++ {
+		hosts = append(hosts, FleetHost{Name: fmt.Sprintf("node-%d", i), Info: &types.SystemInfo{Integrity: &types.IntegrityData{Files: []types.FileChecksum{
+			{Path: "/usr/bin/sudo", SHA256: good},
+			{Path: "/etc/ld.so.preload", Error: "not found"},
+		}}}})
+	}
+	hosts[2].Info.Integrity.Files[0].SHA256 = patched
+	// Unreadable copies are not compared
+	hosts[3].Info.Integrity.Files[0] = types.FileChecksum{Path: "/usr/bin/sudo", Error: "permission denied"}
+
+	expected := []FleetAnomaly{
+		{Host: "node-2", Kind: AnomalyIntegrity, Subject: "file /usr/bin/sudo", Value: "0c8e3a1f6b2d", Expected: "9d1b3f2ac71e on 2 of 3", Peers: 2},
+	}
+	anomalies := integrityOutliers(hosts)
+	if len(anomalies) != len(expected) || anomalies[0] != expected[0] {
+		t.Errorf("integrityOutliers() = %+v, expected %+v", anomalies, expected)
+	}
+
+	// Without a majority there is no known-good copy to compare with
+	hosts[1].Info.Integrity.Files[0].SHA256 = patched
+	hosts[3].Info.Integrity.Files[0].SHA256 = "5f2e"
+	if anomalies := integrityOutliers(hosts); len(anomalies) != 0 {
+		t.Errorf("integrityOutliers(split fleet) = %+v, expected none", anomalies)
+	}
+}
+
+func TestCompareFirmware(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2B2QEXM7", "2B6QEXM7", -1},
+		{"1.10", "1.9", 1},
+		{"0xa0011d1", "0xa0011ce", 1},
+		{"svt02b6q", "SVT02B6Q", 0},
+		{"004", "4", 0},
+		{"1.2", "1.2.1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareFirmware(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareFirmware(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// Recommendation components, as reported in Recommendation.Component
+const (
+	RecommendMemory      = "memory"
+	RecommendFileHandles = "file_handles"
+	RecommendZombies     = "zombies"
+	RecommendServices    = "services"
+	RecommendSMART       = "smart"
+	RecommendGPU         = "gpu"
+)
+
+// Levels at which host resources call for action
+const (
+	memoryWarningPercent      = 90.0
+	memoryCriticalPercent     = 95.0
+	fileHandleWarningPercent  = 90.0
+	fileHandleCriticalPercent = 98.0
+	// A process below this share of memory is not named as the one to restart
+	memoryHogPercent = 10.0
+	// Zombies a parent has left unreaped before it is named
+	zombieWarningCount = 10
+)
+
+// Recommend turns the problems found in a report into actions, naming the process, service or
+// device to act on, most severe first. Returns nil when nothing needs doing
+func Recommend(info *types.SystemInfo) []types.Recommendation {
+	var recommendations []types.Recommendation
+	recommendations = append(recommendations, memoryRecommendations(info)...)
+	recommendations = append(recommendations, fileHandleRecommendations(info.Processes)...)
+	recommendations = append(recommendations, zombieRecommendations(info.Processes)...)
+	recommendations = append(recommendations, serviceRecommendations(info)...)
+	if info.Disk != nil {
+		recommendations = append(recommendations, smartRecommendations(info.Disk.SMARTData)...)
+	}
+	if info.GPU != nil {
+		for _, issue := range info.GPU.DriverIssues {
+			recommendations = append(recommendations, types.Recommendation{
+				Severity:  issue.Severity,
+				Component: RecommendGPU,
+				Subject:   fmt.Sprintf("GPU %d", issue.GPUIndex),
+				Action:    issue.Recommendation,
+			})
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Severity == string(SeverityCritical) && recommendations[j].Severity != string(SeverityCritical)
+	})
+	return recommendations
+}
+
+// memoryRecommendations names the process holding the most memory when memory runs out
+func memoryRecommendations(info *types.SystemInfo) []types.Recommendation {
+	if info.Memory == nil || info.Memory.Total == 0 || info.Memory.UsedPercent < memoryWarningPercent {
+		return nil
+	}
+	rec := types.Recommendation{
+		Severity:  usageSeverity(info.Memory.UsedPercent, memoryCriticalPercent),
+		Component: RecommendMemory,
+	}
+
+	switch {
+	case info.Processes == nil || len(info.Processes.TopByMemory) == 0:
+		rec.Action = fmt.Sprintf("Memory is %.0f%% used: collect processes (--process) to find what holds it, or add memory", info.Memory.UsedPercent)
+	case float64(info.Processes.TopByMemory[0].MemoryPercent) < memoryHogPercent:
+		rec.Action = fmt.Sprintf("Memory is %.0f%% used across many processes: stop the ones that are not needed, or add memory", info.Memory.UsedPercent)
+	default:
+		proc := info.Processes.TopByMemory[0]
+		rec.Subject = processSubject(proc)
+		rec.Action = fmt.Sprintf("Memory is %.0f%% used: restart %s, which holds %s (%.0f%% of memory), or stop it if it is not needed",
+			info.Memory.UsedPercent, processTarget(proc), utils.FormatBytes(proc.MemoryMB<<20), proc.MemoryPercent)
+	}
+	return []types.Recommendation{rec}
+}
+
+// fileHandleRecommendations flags the system running out of file handles, naming the process
+// holding the most, and processes close to their own descriptor limit
+func fileHandleRecommendations(processes *types.ProcessData) []types.Recommendation {
+	if processes == nil {
+		return nil
+	}
+	var recommendations []types.Recommendation
+
+	if handles := processes.FileHandles; handles != nil && handles.Max > 0 {
+		used := 100 * float64(handles.Open) / float64(handles.Max)
+		if used >= fileHandleWarningPercent {
+			rec := types.Recommendation{
+				Severity:  usageSeverity(used, fileHandleCriticalPercent),
+				Component: RecommendFileHandles,
+				Action:    fmt.Sprintf("%d of %d system file handles are open: raise the system limit", handles.Open, handles.Max),
+			}
+			if len(processes.TopByOpenFiles) > 0 {
+				proc := processes.TopByOpenFiles[0]
+				rec.Subject = processSubject(proc)
+				rec.Action = fmt.Sprintf("%d of %d system file handles are open: restart %s, which has %d open, or raise the system limit",
+					handles.Open, handles.Max, processTarget(proc), proc.OpenFiles)
+			}
+			recommendations = append(recommendations, rec)
+		}
+	}
+
+	for _, proc := range processes.TopByOpenFiles {
+		if proc.OpenFilesLimit == 0 {
+			continue
+		}
+		used := 100 * float64(proc.OpenFiles) / float64(proc.OpenFilesLimit)
+		if used < fileHandleWarningPercent {
+			continue
+		}
+		limitHint := "its limit"
+		if proc.Service != "" {
+			limitHint = "LimitNOFILE= in its unit"
+		}
+		recommendations = append(recommendations, types.Recommendation{
+			Severity:  usageSeverity(used, fileHandleCriticalPercent),
+			Component: RecommendFileHandles,
+			Subject:   processSubject(proc),
+			Action: fmt.Sprintf("%s has %d of its %d file descriptors open: restart it if it is leaking them, or raise %s",
+				processTarget(proc), proc.OpenFiles, proc.OpenFilesLimit, limitHint),
+		})
+	}
+	return recommendations
+}
+
+// zombieRecommendations names the parents that leave exited children unreaped, which hold on
+// to their PIDs until the parent reaps them or exits
+func zombieRecommendations(processes *types.ProcessData) []types.Recommendation {
+	if processes == nil {
+		return nil
+	}
+	var recommendations []types.Recommendation
+	for _, parent := range processes.ZombieParents {
+		if parent.Zombies < zombieWarningCount {
+			continue
+		}
+		name := parent.Name
+		if name == "" {
+			name = "process"
+		}
+		action := fmt.Sprintf("Restart %s (PID %d), which has not reaped %d exited child processes (zombies)", name, parent.PID, parent.Zombies)
+		if parent.PID == 1 {
+			// An init system reaps its children; a container's entrypoint often does not
+			action = fmt.Sprintf("%s (PID 1) has not reaped %d exited child processes (zombies): in a container, run it under an init that reaps them, e.g. docker run --init",
+				name, parent.Zombies)
+		}
+		recommendations = append(recommendations, types.Recommendation{
+			Severity:  string(SeverityWarning),
+			Component: RecommendZombies,
+			Subject:   parent.Name,
+			Action:    action,
+		})
+	}
+	return recommendations
+}
+
+// serviceRecommendations asks for each failed service to be looked into and restarted, with the
+// service manager's commands where the services module was collected
+func serviceRecommendations(info *types.SystemInfo) []types.Recommendation {
+	var recommendations []types.Recommendation
+	add := func(name, action string) {
+		recommendations = append(recommendations, types.Recommendation{
+			Severity:  string(SeverityWarning),
+			Component: RecommendServices,
+			Subject:   name,
+			Action:    action,
+		})
+	}
+
+	if info.Services != nil {
+		for _, svc := range info.Services.FailedServices {
+			reason := ""
+			if svc.Reason != "" {
+				reason = " (" + svc.Reason + ")"
+			}
+			switch info.Services.Manager {
+			case "systemd":
+				add(svc.Name, fmt.Sprintf("Check why %s failed%s with journalctl -u %s, then restart it: systemctl restart %s", svc.Name, reason, svc.Name, svc.Name))
+			case "scm":
+				add(svc.Name, fmt.Sprintf("Check the System event log for why %s stopped%s, then start it: Start-Service %s", svc.Name, reason, svc.Name))
+			default:
+				add(svc.Name, fmt.Sprintf("Check why %s failed%s, then restart it", svc.Name, reason))
+			}
+		}
+		return recommendations
+	}
+
+	if info.System != nil {
+		for _, name := range info.System.FailedServices {
+			add(name, fmt.Sprintf("Check why %s failed, then restart it", name))
+		}
+	}
+	return recommendations
+}
+
+// smartRecommendations repeats the SMART analyzer's recommendations for drives that are not healthy
+func smartRecommendations(drives []types.SMARTInfo) []types.Recommendation {
+	var recommendations []types.Recommendation
+	smartAnalyzer := NewSMARTAnalyzer()
+	for i := range drives {
+		smart := &drives[i]
+		result := smartAnalyzer.Analyze(smart)
+		severity := SeverityWarning
+		switch {
+		case !smart.Healthy, result.OverallHealth == HealthCritical, result.OverallHealth == HealthFailing:
+			severity = SeverityCritical
+		case result.OverallHealth != HealthWarning:
+			continue
+		}
+
+		actions := result.Recommendations
+		if result.OverallHealth == HealthGood {
+			actions = nil // "continue monitoring"
+		}
+		if !smart.Healthy && len(actions) == 0 {
+			actions = []string{"Drive fails its own SMART self-assessment - back up its data and replace it"}
+		}
+		for _, action := range actions {
+			recommendations = append(recommendations, types.Recommendation{
+				Severity:  string(severity),
+				Component: RecommendSMART,
+				Subject:   smart.Device,
+				Action:    action,
+			})
+		}
+	}
+	return recommendations
+}
+
+// usageSeverity is CRITICAL from critical percent used and WARNING below it
+func usageSeverity(usedPercent, critical float64) string {
+	if usedPercent >= critical {
+		return string(SeverityCritical)
+	}
+	return string(SeverityWarning)
+}
+
+// processSubject is the service a process runs in, or its name
+func processSubject(proc types.ProcessInfo) string {
+	if proc.Service != "" {
+		return proc.Service
+	}
+	return proc.Name
+}
+
+// processTarget names a process for an action, with its service when it runs in one
+func processTarget(proc types.ProcessInfo) string {
+	if proc.Service != "" {
+		return fmt.Sprintf("%s (%s, PID %d)", proc.Service, proc.Name, proc.PID)
+	}
+	return fmt.Sprintf("%s (PID %d)", proc.Name, proc.PID)
+}
@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestRecommendHealthyHost(t *testing.T) {
+	if recs := Recommend(healthyHost()); recs != nil {
+		t.Errorf("Recommend(healthy) = %+v, expected nil", recs)
+	}
+}
+
+func TestRecommendMemory(t *testing.T) {
+	info := healthyHost()
+	info.Memory.UsedPercent = 96
+	info.Processes = &types.ProcessData{TopByMemory: []types.ProcessInfo{
+		{PID: 812, Name: "java", MemoryMB: 12 << 10, MemoryPercent: 75, Service: "tomcat.service"},
+	}}
+	recs := Recommend(info)
+	if len(recs) != 1 {
+		t.Fatalf("Recommend() = %+v, expected one recommendation", recs)
+	}
+	rec := recs[0]
+	if rec.Severity != "CRITICAL" || rec.Component != RecommendMemory || rec.Subject != "tomcat.service" {
+		t.Errorf("recommendation = %+v, expected a CRITICAL memory one for tomcat.service", rec)
+	}
+	if !strings.Contains(rec.Action, "restart tomcat.service (java, PID 812), which holds 12.00 GB (75% of memory)") {
+		t.Errorf("action = %q", rec.Action)
+	}
+
+	// No single process to blame
+	info.Memory.UsedPercent = 91
+	info.Processes.TopByMemory[0].MemoryPercent = 4
+	recs = Recommend(info)
+	if len(recs) != 1 || recs[0].Severity != "WARNING" || recs[0].Subject != "" || !strings.Contains(recs[0].Action, "across many processes") {
+		t.Errorf("Recommend() = %+v, expected a WARNING naming no process", recs)
+	}
+}
+
+func TestRecommendFileHandles(t *testing.T) {
+	info := healthyHost()
+	info.Processes = &types.ProcessData{
+		FileHandles: &types.FileHandleUsage{Open: 95000, Max: 100000},
+		TopByOpenFiles: []types.ProcessInfo{
+			{PID: 4242, Name: "nginx", OpenFiles: 1020, OpenFilesLimit: 1024, Service: "nginx.service"},
+			{PID: 77, Name: "postgres", OpenFiles: 600, OpenFilesLimit: 1024},
+			{PID: 90, Name: "sshd", OpenFiles: 10},
+		},
+	}
+	recs := Recommend(info)
+	if len(recs) != 2 {
+		t.Fatalf("Recommend() = %+v, expected the system and nginx limits", recs)
+	}
+	// The process at its limit is more severe than the system, so comes first
+	if recs[0].Severity != "CRITICAL" || recs[0].Subject != "nginx.service" ||
+		recs[0].Action != "nginx.service (nginx, PID 4242) has 1020 of its 1024 file descriptors open: restart it if it is leaking them, or raise LimitNOFILE= in its unit" {
+		t.Errorf("process recommendation = %+v", recs[0])
+	}
+	if recs[1].Severity != "WARNING" || recs[1].Component != RecommendFileHandles ||
+		recs[1].Action != "95000 of 100000 system file handles are open: restart nginx.service (nginx, PID 4242), which has 1020 open, or raise the system limit" {
+		t.Errorf("system recommendation = %+v", recs[1])
+	}
+}
+
+func TestRecommendZombies(t *testing.T) {
+	info := healthyHost()
+	info.Processes = &types.ProcessData{
+		Zombies: 45,
+		ZombieParents: []types.ZombieParent{
+			{PID: 1, Name: "node", Zombies: 30},
+			{PID: 2301, Name: "php-fpm", Zombies: 12},
+			{PID: 900, Name: "bash", Zombies: 3},
+		},
+	}
+	recs := Recommend(info)
+	if len(recs) != 2 {
+		t.Fatalf("Recommend() = %+v, expected node and php-fpm", recs)
+	}
+	if recs[0].Subject != "node" || !strings.Contains(recs[0].Action, "docker run --init") {
+		t.Errorf("PID 1 recommendation = %+v, expected an init to reap its children", recs[0])
+	}
+	if recs[1].Action != "Restart php-fpm (PID 2301), which has not reaped 12 exited child processes (zombies)" {
+		t.Errorf("recommendation = %+v", recs[1])
+	}
+}
+
+func TestRecommendServices(t *testing.T) {
+	info := healthyHost()
+	info.System.FailedServices = []string{"nginx.service"}
+	recs := Recommend(info)
+	if len(recs) != 1 || recs[0].Action != "Check why nginx.service failed, then restart it" {
+		t.Errorf("Recommend() = %+v, expected nginx.service from the system module", recs)
+	}
+
+	// The services module has the reason and the service manager
+	info.Services = &types.ServiceData{Manager: "systemd", FailedServices: []types.ServiceStatus{
+		{Name: "nginx.service", Reason: "exit-code 1"},
+	}}
+	recs = Recommend(info)
+	if len(recs) != 1 || recs[0].Subject != "nginx.service" ||
+		recs[0].Action != "Check why nginx.service failed (exit-code 1) with journalctl -u nginx.service, then restart it: systemctl restart nginx.service" {
+		t.Errorf("Recommend() = %+v", recs)
+	}
+
+	info.Services = &types.ServiceData{Manager: "scm", FailedServices: []types.ServiceStatus{{Name: "Spooler"}}}
+	recs = Recommend(info)
+	if len(recs) != 1 || recs[0].Action != "Check the System event log for why Spooler stopped, then start it: Start-Service Spooler" {
+		t.Errorf("Recommend() = %+v", recs)
+	}
+}
+
+func TestRecommendSMARTAndGPU(t *testing.T) {
+	info := healthyHost()
+	info.Disk.SMARTData = append(info.Disk.SMARTData, types.SMARTInfo{Device: "/dev/sdb", Healthy: false})
+	info.GPU = &types.GPUData{DriverIssues: []types.GPUDriverIssue{
+		{GPUIndex: 0, Severity: "WARNING", Code: "DRIVER_QUERY_FAILED", Recommendation: "Check the driver installation and the kernel log for errors"},
+	}}
+	info.Memory.UsedPercent = 92
+
+	recs := Recommend(info)
+	if len(recs) < 3 {
+		t.Fatalf("Recommend() = %+v, expected memory, SMART and GPU recommendations", recs)
+	}
+	// Critical first, then warnings in the order they were found
+	if recs[0].Component != RecommendSMART || recs[0].Severity != "CRITICAL" || recs[0].Subject != "/dev/sdb" {
+		t.Errorf("first recommendation = %+v, expected the failing drive", recs[0])
+	}
+	last := recs[len(recs)-1]
+	if last.Component != RecommendGPU || last.Subject != "GPU 0" || last.Action != "Check the driver installation and the kernel log for errors" {
+		t.Errorf("last recommendation = %+v, expected the GPU driver issue", last)
+	}
+	for _, rec := range recs {
+		if rec.Subject == "/dev/sda" {
+			t.Errorf("healthy drive /dev/sda got a recommendation: %+v", rec)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SkipReasonStandby records a poll that left a spun-down drive asleep
+const SkipReasonStandby = "standby"
+
+// SkipSummary describes the polls skipped for one device in a period
+type SkipSummary struct {
+	Count  int       `json:"count"`
+	Last   time.Time `json:"last"`
+	Reason string    `json:"reason"` // Reason of the most recent skip
+}
+
+// RecordSkip records that a device was deliberately not polled, so the gap in its history is explained
+func (h *HistoryDB) RecordSkip(device, reason string) error {
+	// Without a clock correction the database records its own CURRENT_TIMESTAMP
+	var timestamp sql.NullString
+	if h.clockOffset != nil {
+		timestamp = sql.NullString{String: time.Now().Add(*h.clockOffset).UTC().Format("2006-01-02 15:04:05"), Valid: true}
+	}
+
+	_, err := h.db.Exec(`INSERT INTO smart_skips (host, device, timestamp, reason) VALUES (?, ?, COALESCE(?, CURRENT_TIMESTAMP), ?)`,
+		h.host, device, timestamp, reason)
+	return err
+}
+
+// GetSkipSummary counts the skipped polls of a device since the given time
+// It returns nil when the device was never skipped in the period
+func (h *HistoryDB) GetSkipSummary(device string, since time.Time) (*SkipSummary, error) {
+	rows, err := h.db.Query(`
+		SELECT timestamp, reason
+		FROM smart_skips
+		WHERE host = ? AND device = ? AND timestamp >= ?
+		ORDER BY timestamp ASC`, h.host, device, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summary *SkipSummary
+	for rows.Next() {
+		var timestampStr string
+		var reason sql.NullString
+		if err := rows.Scan(&timestampStr, &reason); err != nil {
+			continue
+		}
+		timestamp, err := parseTimestamp(timestampStr)
+		if err != nil {
+			continue
+		}
+		if summary == nil {
+			summary = &SkipSummary{}
+		}
+		summary.Count++
+		summary.Last = timestamp
+		summary.Reason = reason.String
+	}
+
+	return summary, rows.Err()
+}
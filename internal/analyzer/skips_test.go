@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHistoryDB_SkipSummary(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for i := 0; i < 3; i++ {
+		if err := db.RecordSkip("/dev/sdc", SkipReasonStandby); err != nil {
+			t.Fatalf("RecordSkip failed: %v", err)
+		}
+	}
+
+	summary, err := db.GetSkipSummary("/dev/sdc", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetSkipSummary failed: %v", err)
+	}
+	if summary == nil || summary.Count != 3 || summary.Reason != SkipReasonStandby || summary.Last.IsZero() {
+		t.Errorf("summary = %+v, expected 3 standby skips", summary)
+	}
+
+	// A device that is never polled still shows up in the device list
+	devices, err := db.GetDevices()
+	if err != nil {
+		t.Fatalf("GetDevices failed: %v", err)
+	}
+	if !slices.Contains(devices, "/dev/sdc") {
+		t.Errorf("devices = %v, expected /dev/sdc", devices)
+	}
+
+	if summary, err := db.GetSkipSummary("/dev/sda", time.Now().Add(-time.Hour)); err != nil || summary != nil {
+		t.Errorf("GetSkipSummary(/dev/sda) = %+v, %v, expected nil", summary, err)
+	}
+}
@@ -24,19 +24,25 @@ type AnalyzerConfig struct {
 
 	// Enable predictive analysis
 	EnablePredictive bool
+
+	// User-configured TBW ratings, checked before the built-in defaults
+	EnduranceRatings []EnduranceRating
+}
+
+// DefaultAnalyzerConfig returns the default SMART analysis thresholds
+func DefaultAnalyzerConfig() AnalyzerConfig {
+	return AnalyzerConfig{
+		TempWarning:      60,
+		TempCritical:     70,
+		WearWarning:      80.0,
+		WearCritical:     90.0,
+		EnablePredictive: true,
+	}
 }
 
 // NewSMARTAnalyzer creates a new SMART analyzer with default config
 func NewSMARTAnalyzer() *SMARTAnalyzer {
-	return &SMARTAnalyzer{
-		config: AnalyzerConfig{
-			TempWarning:      60,
-			TempCritical:     70,
-			WearWarning:      80.0,
-			WearCritical:     90.0,
-			EnablePredictive: true,
-		},
-	}
+	return &SMARTAnalyzer{config: DefaultAnalyzerConfig()}
 }
 
 // NewSMARTAnalyzerWithConfig creates a new SMART analyzer with custom config
@@ -52,8 +58,16 @@ type AnalysisResult struct {
 	FailureProbability float64 // 0-100%
 	TimeToFailure      *time.Duration
 	Issues             []Issue
+	Explanations       []Explanation // What FailureProbability is made of, in the order it was scored
 	Recommendations    []string
 	SSDWearAnalysis    *SSDWearInfo
+	Location           string // Enclosure slot holding the drive, empty when unknown
+}
+
+// Explanation is one contribution to a drive's failure probability
+type Explanation struct {
+	Reason string  // The issue, attribute or measurement that was scored
+	Points float64 // Percentage points added; negative for the cap at 100%
 }
 
 // HealthStatus represents the health status of a drive
@@ -93,6 +107,18 @@ type SSDWearInfo struct {
 	EstimatedLifespan time.Duration
 	RemainingLife     float64 // 0-100%
 	WearStatus        HealthStatus
+
+	// Write volume estimates (zero when the drive doesn't report the counters)
+	HostBytesWritten   uint64  // Total bytes written by the host
+	NANDBytesWritten   uint64  // Total bytes written to flash media
+	WriteAmplification float64 // NAND writes / host writes
+	DailyBytesWritten  float64 // Average host bytes written per power-on day
+	DriveWritesPerDay  float64 // DailyBytesWritten / capacity (DWPD)
+
+	// Warranty endurance (zero when no TBW rating is known for the model)
+	RatedTBW           float64       // Rated terabytes written
+	TBWConsumedPercent float64       // Host writes as a percentage of RatedTBW
+	EnduranceRemaining time.Duration // Time until RatedTBW is reached at the current write rate
 }
 
 // Analyze performs comprehensive SMART analysis
@@ -109,6 +135,9 @@ func (a *SMARTAnalyzer) Analyze(smart *types.SMARTInfo) *AnalysisResult {
 		Issues:          []Issue{},
 		Recommendations: []string{},
 	}
+	if smart.Slot != nil {
+		result.Location = smart.Slot.String()
+	}
 
 	// Check temperature
 	a.analyzeTemperature(smart, result)
@@ -163,6 +192,20 @@ func (a *SMARTAnalyzer) analyzeTemperature(smart *types.SMARTInfo, result *Analy
 
 // analyzeAttributes checks SMART attributes for failures
 func (a *SMARTAnalyzer) analyzeAttributes(smart *types.SMARTInfo, result *AnalysisResult) {
+	// The drive's own overall self-assessment
+	if smart.HealthAssessment != nil && !smart.HealthAssessment.Passed {
+		value := "FAILED"
+		if smart.HealthAssessment.CriticalWarning != "" {
+			value = fmt.Sprintf("FAILED (critical warning: %s)", smart.HealthAssessment.CriticalWarning)
+		}
+		result.Issues = append(result.Issues, Issue{
+			Severity:    SeverityCritical,
+			Code:        "SMART_STATUS_FAILED",
+			Description: "Drive reports a failed SMART overall-health self-assessment",
+			Value:       value,
+		})
+	}
+
 	for _, attr := range smart.DetailedAttribs {
 		// Check if attribute has failed
 		switch attr.WhenFailed {
@@ -279,6 +322,10 @@ func (a *SMARTAnalyzer) analyzeSSDWear(smart *types.SMARTInfo) *SSDWearInfo {
 		wear.EstimatedLifespan = time.Duration(remainingHours) * time.Hour
 	}
 
+	// Estimate write amplification and daily write volume
+	estimateWriteVolume(smart, wear)
+	estimateEndurance(smart, wear, a.config.EnduranceRatings)
+
 	// Determine wear status
 	if wear.PercentUsed >= a.config.WearCritical {
 		wear.WearStatus = HealthCritical
@@ -292,44 +339,43 @@ func (a *SMARTAnalyzer) analyzeSSDWear(smart *types.SMARTInfo) *SSDWearInfo {
 // predictiveAnalysis performs predictive failure analysis
 func (a *SMARTAnalyzer) predictiveAnalysis(smart *types.SMARTInfo, result *AnalysisResult) {
 	failureScore := 0.0
+	score := func(points float64, reason string) {
+		failureScore += points
+		result.Explanations = append(result.Explanations, Explanation{Reason: reason, Points: points})
+	}
 
-	// Count critical issues
-	criticalCount := 0
-	warningCount := 0
+	// Calculate failure probability based on issues
 	for _, issue := range result.Issues {
 		switch issue.Severity {
 		case SeverityCritical:
-			criticalCount++
+			score(30.0, fmt.Sprintf("Critical issue %s: %s", issue.Code, issue.Description))
 		case SeverityWarning:
-			warningCount++
+			score(10.0, fmt.Sprintf("Warning issue %s: %s", issue.Code, issue.Description))
 		}
 	}
 
-	// Calculate failure probability based on issues
-	failureScore += float64(criticalCount) * 30.0
-	failureScore += float64(warningCount) * 10.0
-
 	// Check SSD wear if available
-	if result.SSDWearAnalysis != nil {
-		if result.SSDWearAnalysis.PercentUsed >= 95 {
-			failureScore += 40.0
-		} else if result.SSDWearAnalysis.PercentUsed >= 90 {
-			failureScore += 25.0
-		} else if result.SSDWearAnalysis.PercentUsed >= 80 {
-			failureScore += 15.0
+	if wear := result.SSDWearAnalysis; wear != nil {
+		reason := fmt.Sprintf("SSD wear at %.0f%% used", wear.PercentUsed)
+		if wear.PercentUsed >= 95 {
+			score(40.0, reason+" (95% or more)")
+		} else if wear.PercentUsed >= 90 {
+			score(25.0, reason+" (90% or more)")
+		} else if wear.PercentUsed >= 80 {
+			score(15.0, reason+" (80% or more)")
 		}
 	}
 
 	// Check for high reallocated sectors
 	for _, attr := range smart.DetailedAttribs {
 		if attr.ID == 5 && attr.RawValue > 50 {
-			failureScore += 20.0
+			score(20.0, fmt.Sprintf("Attribute 5 (%s) raw value %d is above 50", attr.Name, attr.RawValue))
 		}
 	}
 
 	// Cap at 100%
 	if failureScore > 100 {
-		failureScore = 100
+		score(100-failureScore, "Capped at 100%")
 	}
 
 	result.FailureProbability = failureScore
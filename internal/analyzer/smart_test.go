@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -225,6 +226,51 @@ func TestSMARTAnalyzer_PredictiveAnalysis(t *testing.T) {
 	}
 }
 
+func TestSMARTAnalyzer_Explanations(t *testing.T) {
+	analyzer := NewSMARTAnalyzer()
+
+	// One warning-level temperature and 60 reallocated sectors
+	result := analyzer.Analyze(&types.SMARTInfo{
+		Device:      "/dev/sda",
+		Temperature: 65,
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 5, Name: "Reallocated_Sector_Ct", Value: 100, RawValue: 60, Threshold: 36, Type: "Pre-fail"},
+		},
+	})
+
+	total := 0.0
+	var reasons []string
+	for _, explanation := range result.Explanations {
+		total += explanation.Points
+		reasons = append(reasons, explanation.Reason)
+	}
+	if total != result.FailureProbability {
+		t.Errorf("explanations add up to %.1f, FailureProbability is %.1f", total, result.FailureProbability)
+	}
+	joined := strings.Join(reasons, "\n")
+	for _, expected := range []string{"HIGH_TEMP_WARNING", "REALLOCATED_SECTORS", "raw value 60 is above 50"} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("explanations %q do not mention %s", joined, expected)
+		}
+	}
+
+	// Past 100% the cap is accounted for too
+	result = analyzer.Analyze(&types.SMARTInfo{
+		Device:           "/dev/sdb",
+		Temperature:      75,
+		HealthAssessment: &types.SMARTHealthStatus{Passed: false},
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 5, Name: "Reallocated_Sector_Ct", RawValue: 500},
+			{ID: 197, Name: "Current_Pending_Sector", RawValue: 10},
+			{ID: 198, Name: "Offline_Uncorrectable", RawValue: 10},
+		},
+	})
+	last := result.Explanations[len(result.Explanations)-1]
+	if result.FailureProbability != 100 || last.Points >= 0 || last.Reason != "Capped at 100%" {
+		t.Errorf("FailureProbability = %.1f, last explanation = %+v, expected the cap", result.FailureProbability, last)
+	}
+}
+
 func TestSMARTAnalyzer_FailingAttribute(t *testing.T) {
 	analyzer := NewSMARTAnalyzer()
 
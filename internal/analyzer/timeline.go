@@ -0,0 +1,244 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+)
+
+// bootTolerance absorbs the jitter in boot times derived from uptime, so one boot is recorded once
+const bootTolerance = time.Minute
+
+// HealthTransition is a drive's health status changing between two recorded analyses
+type HealthTransition struct {
+	Time   time.Time    `json:"time"`
+	Device string       `json:"device"`
+	From   HealthStatus `json:"from"`
+	To     HealthStatus `json:"to"`
+}
+
+// IssueChange is a SMART issue appearing in, or clearing from, consecutive analyses of a
+// drive, e.g. the temperature crossing its warning threshold
+type IssueChange struct {
+	Time        time.Time `json:"time"`
+	Device      string    `json:"device"`
+	Severity    Severity  `json:"severity"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	Cleared     bool      `json:"cleared,omitempty"`
+}
+
+// SentAlert is an alert that was delivered to the webhook
+type SentAlert struct {
+	Time        time.Time  `json:"time"`
+	Level       AlertLevel `json:"level"`
+	Device      string     `json:"device"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+}
+
+// RecordBoot stores the time the host booted, unless that boot is already recorded
+func (h *HistoryDB) RecordBoot(bootTime time.Time) error {
+	if h.clockOffset != nil {
+		bootTime = bootTime.Add(*h.clockOffset)
+	}
+
+	var count int
+	err := h.db.QueryRow(`SELECT COUNT(*) FROM boots WHERE host = ? AND boot_time BETWEEN ? AND ?`,
+		h.host,
+		bootTime.Add(-bootTolerance).UTC().Format("2006-01-02 15:04:05"),
+		bootTime.Add(bootTolerance).UTC().Format("2006-01-02 15:04:05")).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	_, err = h.db.Exec(`INSERT INTO boots (host, boot_time) VALUES (?, ?)`, h.host, bootTime.UTC().Format("2006-01-02 15:04:05"))
+	return err
+}
+
+// GetBoots returns the boot times recorded since the given time, oldest first
+func (h *HistoryDB) GetBoots(since time.Time) ([]time.Time, error) {
+	rows, err := h.db.Query(`SELECT boot_time FROM boots WHERE host = ? AND boot_time >= ? ORDER BY boot_time ASC`,
+		h.host, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var boots []time.Time
+	for rows.Next() {
+		var timestamp string
+		if err := rows.Scan(&timestamp); err != nil {
+			return nil, err
+		}
+		bootTime, _ := parseTimestamp(timestamp)
+		boots = append(boots, bootTime)
+	}
+	return boots, rows.Err()
+}
+
+// RecordAlert stores a delivered alert at its timestamp
+func (h *HistoryDB) RecordAlert(alert Alert) error {
+	at := alert.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if h.clockOffset != nil {
+		at = at.Add(*h.clockOffset)
+	}
+
+	_, err := h.db.Exec(`INSERT INTO sent_alerts (host, timestamp, level, device, title, description) VALUES (?, ?, ?, ?, ?, ?)`,
+		h.host, at.UTC().Format("2006-01-02 15:04:05"), alert.Level, alert.Device, alert.Title, alert.Description)
+	return err
+}
+
+// GetSentAlerts returns the alerts delivered since the given time, oldest first
+func (h *HistoryDB) GetSentAlerts(since time.Time) ([]SentAlert, error) {
+	rows, err := h.db.Query(`
+		SELECT timestamp, level, device, title, description
+		FROM sent_alerts
+		WHERE host = ? AND timestamp >= ?
+		ORDER BY timestamp ASC, id ASC`, h.host, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []SentAlert
+	for rows.Next() {
+		var a SentAlert
+		var timestamp string
+		if err := rows.Scan(&timestamp, &a.Level, &a.Device, &a.Title, &a.Description); err != nil {
+			return nil, err
+		}
+		a.Time, _ = parseTimestamp(timestamp)
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// GetHealthTransitions returns the health status changes between consecutive analyses
+// of each drive that happened since the given time, oldest first. A drive's first
+// analysis is not a transition
+func (h *HistoryDB) GetHealthTransitions(since time.Time) ([]HealthTransition, error) {
+	// Earlier records are read too, so a change right at the start of the period is seen
+	rows, err := h.db.Query(`
+		SELECT device, timestamp, health_status
+		FROM smart_history
+		WHERE host = ?
+		ORDER BY timestamp ASC, id ASC`, h.host)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	last := make(map[string]HealthStatus)
+	var transitions []HealthTransition
+	for rows.Next() {
+		var device, timestamp string
+		var status HealthStatus
+		if err := rows.Scan(&device, &timestamp, &status); err != nil {
+			return nil, err
+		}
+		at, _ := parseTimestamp(timestamp)
+
+		previous, seen := last[device]
+		last[device] = status
+		if seen && previous != status && !at.Before(since) {
+			transitions = append(transitions, HealthTransition{Time: at, Device: device, From: previous, To: status})
+		}
+	}
+	return transitions, rows.Err()
+}
+
+// GetIssueChanges returns the SMART issues that appeared or cleared between consecutive
+// analyses of each drive since the given time, oldest first. Issues found by a drive's
+// first analysis count as appearing
+func (h *HistoryDB) GetIssueChanges(since time.Time) ([]IssueChange, error) {
+	rows, err := h.db.Query(`
+		SELECT h.id, h.device, h.timestamp, i.severity, i.code, i.description, i.attribute_id
+		FROM smart_history h
+		LEFT JOIN smart_issues i ON i.history_id = h.id
+		WHERE h.host = ?
+		ORDER BY h.timestamp ASC, h.id ASC, i.id ASC`, h.host)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// One analysis with its issues, keyed by code and attribute so per-attribute issues are told apart
+	type analysis struct {
+		id     int64
+		device string
+		time   time.Time
+		issues map[string]IssueChange
+		order  []string
+	}
+
+	var analyses []*analysis
+	for rows.Next() {
+		var id int64
+		var device, timestamp string
+		var severity, code, description *string
+		var attributeID *int64
+		if err := rows.Scan(&id, &device, &timestamp, &severity, &code, &description, &attributeID); err != nil {
+			return nil, err
+		}
+		if len(analyses) == 0 || analyses[len(analyses)-1].id != id {
+			at, _ := parseTimestamp(timestamp)
+			analyses = append(analyses, &analysis{id: id, device: device, time: at, issues: make(map[string]IssueChange)})
+		}
+		if code == nil {
+			continue
+		}
+
+		current := analyses[len(analyses)-1]
+		key := *code
+		if attributeID != nil {
+			key = fmt.Sprintf("%s/%d", *code, *attributeID)
+		}
+		issue := IssueChange{Time: current.time, Device: device, Code: *code}
+		if severity != nil {
+			issue.Severity = Severity(*severity)
+		}
+		if description != nil {
+			issue.Description = *description
+		}
+		if _, dup := current.issues[key]; !dup {
+			current.order = append(current.order, key)
+		}
+		current.issues[key] = issue
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	last := make(map[string]*analysis)
+	var changes []IssueChange
+	for _, current := range analyses {
+		previous := last[current.device]
+		last[current.device] = current
+		if current.time.Before(since) {
+			continue
+		}
+
+		if previous != nil {
+			for _, key := range previous.order {
+				if _, ok := current.issues[key]; !ok {
+					cleared := previous.issues[key]
+					cleared.Time = current.time
+					cleared.Cleared = true
+					changes = append(changes, cleared)
+				}
+			}
+		}
+		for _, key := range current.order {
+			if previous != nil {
+				if _, ok := previous.issues[key]; ok {
+					continue
+				}
+			}
+			changes = append(changes, current.issues[key])
+		}
+	}
+	return changes, nil
+}
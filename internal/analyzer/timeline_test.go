@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestHistoryDB_Timeline(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	since := time.Now().Add(-time.Hour)
+	smart := &types.SMARTInfo{Device: "/dev/sda", Temperature: 45}
+	hot := Issue{Severity: SeverityWarning, Code: "HIGH_TEMP_WARNING", Description: "Temperature 58°C exceeds warning threshold"}
+	pending := Issue{Severity: SeverityCritical, Code: "PENDING_SECTORS", Description: "8 sectors pending reallocation", AttributeID: 197}
+	analyses := []*AnalysisResult{
+		{Device: "/dev/sda", OverallHealth: HealthGood},
+		{Device: "/dev/sda", OverallHealth: HealthWarning, Issues: []Issue{hot}},
+		{Device: "/dev/sda", OverallHealth: HealthWarning, Issues: []Issue{hot}},
+		{Device: "/dev/sda", OverallHealth: HealthCritical, Issues: []Issue{pending}},
+	}
+	for _, result := range analyses {
+		if err := db.RecordAnalysis(smart, result); err != nil {
+			t.Fatalf("RecordAnalysis failed: %v", err)
+		}
+	}
+
+	transitions, err := db.GetHealthTransitions(since)
+	if err != nil {
+		t.Fatalf("GetHealthTransitions failed: %v", err)
+	}
+	if len(transitions) != 2 || transitions[0].From != HealthGood || transitions[0].To != HealthWarning ||
+		transitions[1].From != HealthWarning || transitions[1].To != HealthCritical {
+		t.Errorf("transitions = %+v, expected GOOD to WARNING then WARNING to CRITICAL", transitions)
+	}
+
+	changes, err := db.GetIssueChanges(since)
+	if err != nil {
+		t.Fatalf("GetIssueChanges failed: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("got %d issue changes, expected 3: %+v", len(changes), changes)
+	}
+	if changes[0].Code != "HIGH_TEMP_WARNING" || changes[0].Cleared {
+		t.Errorf("first change = %+v, expected the temperature warning to appear", changes[0])
+	}
+	if changes[1].Code != "HIGH_TEMP_WARNING" || !changes[1].Cleared {
+		t.Errorf("second change = %+v, expected the temperature warning to clear", changes[1])
+	}
+	if changes[2].Code != "PENDING_SECTORS" || changes[2].Severity != SeverityCritical {
+		t.Errorf("third change = %+v, expected pending sectors to appear", changes[2])
+	}
+
+	// Nothing happened after now
+	if changes, err := db.GetIssueChanges(time.Now().Add(time.Hour)); err != nil || len(changes) != 0 {
+		t.Errorf("GetIssueChanges() in the future = %+v, %v", changes, err)
+	}
+}
+
+func TestHistoryDB_Boots(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	booted := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	// Boot times derived from uptime drift by a second between reads
+	for _, bootTime := range []time.Time{booted, booted.Add(time.Second), booted.Add(-time.Second)} {
+		if err := db.RecordBoot(bootTime); err != nil {
+			t.Fatalf("RecordBoot failed: %v", err)
+		}
+	}
+	if err := db.RecordBoot(booted.Add(90 * time.Minute)); err != nil {
+		t.Fatalf("RecordBoot failed: %v", err)
+	}
+
+	boots, err := db.GetBoots(booted.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("GetBoots failed: %v", err)
+	}
+	if len(boots) != 2 || !boots[0].Equal(booted) {
+		t.Errorf("boots = %v, expected two boots starting at %v", boots, booted)
+	}
+}
+
+func TestHistoryDB_SentAlerts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	sent := time.Now().Add(-time.Minute).Truncate(time.Second)
+	alert := Alert{Level: AlertCritical, Device: "/dev/sdc", Title: "Device Removed: /dev/sdc", Timestamp: sent}
+	if err := db.RecordAlert(alert); err != nil {
+		t.Fatalf("RecordAlert failed: %v", err)
+	}
+
+	alerts, err := db.GetSentAlerts(sent.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("GetSentAlerts failed: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Level != AlertCritical || alerts[0].Title != alert.Title || !alerts[0].Time.Equal(sent) {
+		t.Errorf("alerts = %+v", alerts)
+	}
+}
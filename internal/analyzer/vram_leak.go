@@ -0,0 +1,166 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// GPUMemorySample is the GPU memory a process held at one point in time
+type GPUMemorySample struct {
+	Time      time.Time `json:"time"`
+	GPU       string    `json:"gpu"`
+	PID       int       `json:"pid"`
+	Process   string    `json:"process"`
+	UsedBytes uint64    `json:"used_bytes"`
+}
+
+// GPUKey identifies a GPU across collections: its UUID, or its index when the driver reports none
+func GPUKey(gpu types.GPUInfo) string {
+	if gpu.UUID != "" {
+		return gpu.UUID
+	}
+	return fmt.Sprintf("gpu%d", gpu.Index)
+}
+
+// RecordGPUMemory stores the GPU memory each process holds at the given time
+func (h *HistoryDB) RecordGPUMemory(gpus []types.GPUInfo, at time.Time) error {
+	if h.clockOffset != nil {
+		at = at.Add(*h.clockOffset)
+	}
+	timestamp := at.UTC().Format("2006-01-02 15:04:05")
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, gpu := range gpus {
+		for _, p := range gpu.Processes {
+			if _, err := tx.Exec(`INSERT INTO gpu_memory (host, timestamp, gpu, pid, process, used_bytes) VALUES (?, ?, ?, ?, ?, ?)`,
+				h.host, timestamp, GPUKey(gpu), p.PID, p.Name, p.MemoryUsed); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// GetGPUMemory returns the GPU memory recorded for a process on a GPU since the given time,
+// oldest first
+func (h *HistoryDB) GetGPUMemory(gpu string, pid int, since time.Time) ([]GPUMemorySample, error) {
+	rows, err := h.db.Query(`
+		SELECT timestamp, gpu, pid, process, used_bytes
+		FROM gpu_memory
+		WHERE host = ? AND gpu = ? AND pid = ? AND timestamp >= ?
+		ORDER BY timestamp ASC, id ASC`, h.host, gpu, pid, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []GPUMemorySample
+	for rows.Next() {
+		var timestampStr string
+		var sample GPUMemorySample
+		if err := rows.Scan(&timestampStr, &sample.GPU, &sample.PID, &sample.Process, &sample.UsedBytes); err != nil {
+			continue
+		}
+		if sample.Time, err = parseTimestamp(timestampStr); err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, rows.Err()
+}
+
+// LeakRule is the condition for a GPU memory leak: a process whose GPU memory never went
+// down over at least Samples readings within Window, and grew by more than MinIncrease.
+// Inference servers that leak VRAM climb steadily until allocations fail, while healthy
+// ones settle once their caches are warm or free memory between batches
+type LeakRule struct {
+	Window      time.Duration
+	Samples     int    // Readings, counting the current one, the growth must span
+	MinIncrease uint64 // Bytes
+}
+
+// VRAMLeak is a process whose GPU memory keeps growing
+type VRAMLeak struct {
+	Rule        LeakRule
+	GPU         types.GPUInfo
+	Process     types.GPUProcess
+	From        GPUMemorySample // The first reading of the run
+	Samples     int             // Readings in the run, counting the current one
+	Increase    uint64          // Bytes gained since From
+	MeasuredFor time.Duration   // Time between From and now
+}
+
+// CheckVRAMLeak looks for a leak in a process's current GPU memory and the readings recorded
+// for it within the rule's window. Readings of an earlier process that had the same PID are
+// ignored, and any drop restarts the run, so only growth up to now counts
+func CheckVRAMLeak(rule LeakRule, gpu types.GPUInfo, process types.GPUProcess, samples []GPUMemorySample, now time.Time) *VRAMLeak {
+	since := now.Add(-rule.Window)
+	var run []GPUMemorySample
+	for _, sample := range samples {
+		if sample.Time.Before(since) || !sample.Time.Before(now) || sample.Process != process.Name {
+			continue
+		}
+		if len(run) > 0 && sample.UsedBytes < run[len(run)-1].UsedBytes {
+			run = run[:0]
+		}
+		run = append(run, sample)
+	}
+	if len(run) == 0 || process.MemoryUsed < run[len(run)-1].UsedBytes {
+		return nil
+	}
+	if len(run)+1 < rule.Samples || process.MemoryUsed-run[0].UsedBytes <= rule.MinIncrease {
+		return nil
+	}
+	return &VRAMLeak{
+		Rule:        rule,
+		GPU:         gpu,
+		Process:     process,
+		From:        run[0],
+		Samples:     len(run) + 1,
+		Increase:    process.MemoryUsed - run[0].UsedBytes,
+		MeasuredFor: now.Sub(run[0].Time),
+	}
+}
+
+// VRAMLeakAlert describes a process that appears to leak GPU memory
+func VRAMLeakAlert(leak *VRAMLeak, at time.Time) Alert {
+	p := leak.Process
+	gpu := fmt.Sprintf("GPU %d", leak.GPU.Index)
+	if leak.GPU.Name != "" {
+		gpu += " (" + leak.GPU.Name + ")"
+	}
+	const mib = 1024 * 1024
+	description := fmt.Sprintf("%s (PID %d) on %s grew its GPU memory over %d readings in %s without releasing any, from %d MiB to %d MiB",
+		p.Name, p.PID, gpu, leak.Samples, leak.MeasuredFor.Round(time.Minute), leak.From.UsedBytes/mib, p.MemoryUsed/mib)
+	data := map[string]interface{}{
+		"gpu_index":     leak.GPU.Index,
+		"gpu_uuid":      leak.GPU.UUID,
+		"pid":           p.PID,
+		"process":       p.Name,
+		"used_bytes":    p.MemoryUsed,
+		"previous":      leak.From.UsedBytes,
+		"previous_time": leak.From.Time,
+		"increase":      leak.Increase,
+		"samples":       leak.Samples,
+	}
+	if leak.GPU.MemoryTotal > 0 {
+		description += fmt.Sprintf(" of %d MiB", leak.GPU.MemoryTotal/mib)
+		data["gpu_memory_total_bytes"] = leak.GPU.MemoryTotal
+	}
+
+	return Alert{
+		Level:       AlertWarning,
+		Device:      GPUKey(leak.GPU),
+		Title:       fmt.Sprintf("GPU Memory Leak: %s (PID %d)", p.Name, p.PID),
+		Description: description,
+		Timestamp:   at,
+		Data:        data,
+	}
+}
@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const mib = 1024 * 1024
+
+func TestCheckVRAMLeak(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	rule := LeakRule{Window: time.Hour, Samples: 4, MinIncrease: 512 * mib}
+	gpu := types.GPUInfo{Index: 0, Name: "NVIDIA A100-SXM4-80GB", UUID: "GPU-5d6f2c1a", MemoryTotal: 81920 * mib}
+	process := types.GPUProcess{PID: 48213, Name: "python3", MemoryUsed: 12000 * mib}
+	sample := func(ago time.Duration, name string, used uint64) GPUMemorySample {
+		return GPUMemorySample{Time: now.Add(-ago), GPU: gpu.UUID, PID: process.PID, Process: name, UsedBytes: used * mib}
+	}
+	samples := []GPUMemorySample{
+		sample(2*time.Hour, "python3", 2000),     // Outside the window
+		sample(50*time.Minute, "trainer", 30000), // An earlier process with the same PID
+		sample(40*time.Minute, "python3", 11800), // Before the process freed memory
+		sample(30*time.Minute, "python3", 10000),
+		sample(20*time.Minute, "python3", 10000),
+		sample(10*time.Minute, "python3", 11000),
+	}
+
+	leak := CheckVRAMLeak(rule, gpu, process, samples, now)
+	if leak == nil {
+		t.Fatal("CheckVRAMLeak() = nil, expected a leak over the last 4 readings")
+	}
+	if leak.Samples != 4 || leak.Increase != 2000*mib || leak.From.UsedBytes != 10000*mib || leak.MeasuredFor != 30*time.Minute {
+		t.Errorf("leak = %+v, expected 2000 MiB over 4 readings in 30m", leak)
+	}
+
+	// Too few readings since the drop, too little growth, or a drop now do not fire
+	if leak := CheckVRAMLeak(LeakRule{Window: time.Hour, Samples: 5, MinIncrease: 512 * mib}, gpu, process, samples, now); leak != nil {
+		t.Errorf("CheckVRAMLeak() with 5 readings required = %+v, expected nil", leak)
+	}
+	if leak := CheckVRAMLeak(LeakRule{Window: time.Hour, Samples: 4, MinIncrease: 2000 * mib}, gpu, process, samples, now); leak != nil {
+		t.Errorf("CheckVRAMLeak() at the minimum increase = %+v, expected nil", leak)
+	}
+	process.MemoryUsed = 10500 * mib
+	if leak := CheckVRAMLeak(rule, gpu, process, samples, now); leak != nil {
+		t.Errorf("CheckVRAMLeak() after a drop = %+v, expected nil", leak)
+	}
+}
+
+func TestVRAMLeakAlert(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	leak := &VRAMLeak{
+		GPU:         types.GPUInfo{Index: 1, Name: "NVIDIA L4", UUID: "GPU-7e8f9a0b", MemoryTotal: 23034 * mib},
+		Process:     types.GPUProcess{PID: 48213, Name: "python3", MemoryUsed: 12000 * mib},
+		From:        GPUMemorySample{Time: now.Add(-30 * time.Minute), UsedBytes: 10000 * mib},
+		Samples:     4,
+		Increase:    2000 * mib,
+		MeasuredFor: 30 * time.Minute,
+	}
+	alert := VRAMLeakAlert(leak, now)
+	if alert.Level != AlertWarning || alert.Device != "GPU-7e8f9a0b" || alert.Title != "GPU Memory Leak: python3 (PID 48213)" {
+		t.Errorf("alert = %+v, expected a WARNING naming python3 on GPU-7e8f9a0b", alert)
+	}
+	expected := "python3 (PID 48213) on GPU 1 (NVIDIA L4) grew its GPU memory over 4 readings in 30m0s without releasing any, from 10000 MiB to 12000 MiB of 23034 MiB"
+	if alert.Description != expected {
+		t.Errorf("Description = %q, expected %q", alert.Description, expected)
+	}
+	if alert.Data["pid"] != 48213 || alert.Data["increase"] != uint64(2000*mib) {
+		t.Errorf("Data = %v", alert.Data)
+	}
+
+	leak.GPU = types.GPUInfo{Index: 0}
+	if alert := VRAMLeakAlert(leak, now); alert.Device != "gpu0" || strings.Contains(alert.Description, " of ") {
+		t.Errorf("alert = %+v, expected gpu0 without the GPU's total memory", alert)
+	}
+}
+
+func TestHistoryDB_GPUMemory(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now().Truncate(time.Second)
+	gpus := []types.GPUInfo{
+		{Index: 0, UUID: "GPU-5d6f2c1a", Processes: []types.GPUProcess{{PID: 48213, Name: "python3", MemoryUsed: 10000 * mib}}},
+		{Index: 1}, // No processes, nothing recorded
+	}
+	if err := db.RecordGPUMemory(gpus, now.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("RecordGPUMemory failed: %v", err)
+	}
+	gpus[0].Processes[0].MemoryUsed = 11000 * mib
+	if err := db.RecordGPUMemory(gpus, now.Add(-30*time.Minute)); err != nil {
+		t.Fatalf("RecordGPUMemory failed: %v", err)
+	}
+
+	samples, err := db.GetGPUMemory("GPU-5d6f2c1a", 48213, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GetGPUMemory failed: %v", err)
+	}
+	if len(samples) != 1 || samples[0].UsedBytes != 11000*mib || samples[0].Process != "python3" || !samples[0].Time.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("samples = %+v, expected the reading within the hour", samples)
+	}
+	if samples, err := db.GetGPUMemory("GPU-5d6f2c1a", 1, now.Add(-3*time.Hour)); err != nil || len(samples) != 0 {
+		t.Errorf("GetGPUMemory(pid 1) = %+v, %v, expected none", samples, err)
+	}
+
+	if err := db.CleanOldRecords(time.Hour); err != nil {
+		t.Fatalf("CleanOldRecords failed: %v", err)
+	}
+	if samples, err := db.GetGPUMemory("GPU-5d6f2c1a", 48213, now.Add(-3*time.Hour)); err != nil || len(samples) != 1 {
+		t.Errorf("GetGPUMemory() after pruning = %+v, %v, expected the recent reading", samples, err)
+	}
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"strconv"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	bytesPerLBA      = 512
+	bytesPer32MiB    = 32 * 1024 * 1024
+	bytesPerGiB      = 1024 * 1024 * 1024
+	bytesPerNVMeUnit = 512 * 1000 // NVMe data units are thousands of 512-byte blocks
+)
+
+// hostWriteAttributes maps vendor attribute names reporting host writes to their unit size in bytes
+var hostWriteAttributes = map[string]uint64{
+	"Total_LBAs_Written":  bytesPerLBA,
+	"Host_Writes_32MiB":   bytesPer32MiB,
+	"Host_Writes_GiB":     bytesPerGiB,
+	"Lifetime_Writes_GiB": bytesPerGiB,
+}
+
+// nandWriteAttributes maps vendor attribute names reporting NAND (media) writes to their unit size in bytes
+var nandWriteAttributes = map[string]uint64{
+	"NAND_Writes_1GiB":    bytesPerGiB,
+	"NAND_Writes_32MiB":   bytesPer32MiB,
+	"NAND_GB_Written_TLC": bytesPerGiB,
+	"NAND_GB_Written_SLC": bytesPerGiB,
+}
+
+// estimateWriteVolume fills in host/NAND write totals, write amplification and daily write volume
+func estimateWriteVolume(smart *types.SMARTInfo, wear *SSDWearInfo) {
+	var hostProgramPages, ftlProgramPages uint64
+
+	for _, attr := range smart.DetailedAttribs {
+		if unit, ok := hostWriteAttributes[attr.Name]; ok && wear.HostBytesWritten == 0 {
+			wear.HostBytesWritten = attr.RawValue * unit
+		}
+		if unit, ok := nandWriteAttributes[attr.Name]; ok {
+			wear.NANDBytesWritten += attr.RawValue * unit
+		}
+
+		// Crucial/Micron report page counts for host and FTL (garbage collection) writes
+		switch attr.Name {
+		case "Host_Program_Page_Count":
+			hostProgramPages = attr.RawValue
+		case "FTL_Program_Page_Count":
+			ftlProgramPages = attr.RawValue
+		}
+	}
+
+	// NVMe drives report host writes in data units via the health log
+	if wear.HostBytesWritten == 0 && smart.Attributes != nil {
+		if units, err := strconv.ParseUint(smart.Attributes["Data_Units_Written"], 10, 64); err == nil {
+			wear.HostBytesWritten = units * bytesPerNVMeUnit
+		}
+	}
+
+	switch {
+	case hostProgramPages > 0:
+		wear.WriteAmplification = float64(hostProgramPages+ftlProgramPages) / float64(hostProgramPages)
+	case wear.HostBytesWritten > 0 && wear.NANDBytesWritten > 0:
+		wear.WriteAmplification = float64(wear.NANDBytesWritten) / float64(wear.HostBytesWritten)
+	}
+
+	// Daily write volume and drive writes per day (DWPD)
+	if wear.HostBytesWritten > 0 && smart.PowerOnHours >= 24 {
+		days := float64(smart.PowerOnHours) / 24.0
+		wear.DailyBytesWritten = float64(wear.HostBytesWritten) / days
+		if smart.Capacity > 0 {
+			wear.DriveWritesPerDay = wear.DailyBytesWritten / float64(smart.Capacity)
+		}
+	}
+}
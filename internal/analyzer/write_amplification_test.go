@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestEstimateWriteVolume_IntelCounters(t *testing.T) {
+	smart := &types.SMARTInfo{
+		Device:       "/dev/sda",
+		Capacity:     480 * bytesPerGiB,
+		PowerOnHours: 240, // 10 days
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 241, Name: "Host_Writes_32MiB", RawValue: 3200}, // 100 GiB
+			{ID: 249, Name: "NAND_Writes_1GiB", RawValue: 150},
+		},
+	}
+
+	wear := &SSDWearInfo{}
+	estimateWriteVolume(smart, wear)
+
+	if wear.HostBytesWritten != 100*bytesPerGiB {
+		t.Errorf("HostBytesWritten = %d, expected %d", wear.HostBytesWritten, uint64(100*bytesPerGiB))
+	}
+	if wear.NANDBytesWritten != 150*bytesPerGiB {
+		t.Errorf("NANDBytesWritten = %d, expected %d", wear.NANDBytesWritten, uint64(150*bytesPerGiB))
+	}
+	if math.Abs(wear.WriteAmplification-1.5) > 0.001 {
+		t.Errorf("WriteAmplification = %.3f, expected 1.5", wear.WriteAmplification)
+	}
+	if math.Abs(wear.DailyBytesWritten-10*bytesPerGiB) > 1 {
+		t.Errorf("DailyBytesWritten = %.0f, expected %d", wear.DailyBytesWritten, 10*bytesPerGiB)
+	}
+	if math.Abs(wear.DriveWritesPerDay-10.0/480.0) > 0.0001 {
+		t.Errorf("DriveWritesPerDay = %.5f, expected %.5f", wear.DriveWritesPerDay, 10.0/480.0)
+	}
+}
+
+func TestEstimateWriteVolume_CrucialPageCounts(t *testing.T) {
+	smart := &types.SMARTInfo{
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 246, Name: "Total_LBAs_Written", RawValue: 2048},
+			{ID: 247, Name: "Host_Program_Page_Count", RawValue: 1000},
+			{ID: 248, Name: "FTL_Program_Page_Count", RawValue: 500},
+		},
+	}
+
+	wear := &SSDWearInfo{}
+	estimateWriteVolume(smart, wear)
+
+	if wear.HostBytesWritten != 2048*bytesPerLBA {
+		t.Errorf("HostBytesWritten = %d, expected %d", wear.HostBytesWritten, 2048*bytesPerLBA)
+	}
+	if math.Abs(wear.WriteAmplification-1.5) > 0.001 {
+		t.Errorf("WriteAmplification = %.3f, expected 1.5", wear.WriteAmplification)
+	}
+	if wear.DailyBytesWritten != 0 {
+		t.Error("DailyBytesWritten should be zero without power-on hours")
+	}
+}
+
+func TestEstimateWriteVolume_NVMeDataUnits(t *testing.T) {
+	smart := &types.SMARTInfo{
+		Capacity:     1000 * 1000 * 1000 * 1000,
+		PowerOnHours: 48,
+		Attributes: map[string]string{
+			"Data_Units_Written": "4000000", // 2.048 TB
+		},
+	}
+
+	wear := &SSDWearInfo{}
+	estimateWriteVolume(smart, wear)
+
+	if wear.HostBytesWritten != 4000000*bytesPerNVMeUnit {
+		t.Errorf("HostBytesWritten = %d, expected %d", wear.HostBytesWritten, 4000000*bytesPerNVMeUnit)
+	}
+	if wear.WriteAmplification != 0 {
+		t.Error("WriteAmplification should be unknown without NAND counters")
+	}
+	if math.Abs(wear.DriveWritesPerDay-1.024) > 0.0001 {
+		t.Errorf("DriveWritesPerDay = %.4f, expected 1.024", wear.DriveWritesPerDay)
+	}
+}
+
+func TestSMARTAnalyzer_AnalyzeSSDWear_IncludesWriteVolume(t *testing.T) {
+	analyzer := NewSMARTAnalyzer()
+
+	smart := &types.SMARTInfo{
+		Device:       "/dev/sda",
+		RotationRate: 0,
+		PowerOnHours: 1000,
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 241, Name: "Total_LBAs_Written", RawValue: 1 << 30},
+		},
+	}
+
+	result := analyzer.Analyze(smart)
+	if result.SSDWearAnalysis == nil {
+		t.Fatal("Expected SSD wear analysis")
+	}
+	if result.SSDWearAnalysis.HostBytesWritten != (1<<30)*bytesPerLBA {
+		t.Errorf("HostBytesWritten = %d", result.SSDWearAnalysis.HostBytesWritten)
+	}
+}
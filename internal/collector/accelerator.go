@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// acceleratorID identifies a known accelerator by bus, vendor and device ID
+type acceleratorID struct {
+	Bus      string
+	VendorID string
+	DeviceID string // empty matches every device of the vendor
+}
+
+// acceleratorModel describes a known accelerator
+type acceleratorModel struct {
+	Type   string
+	Name   string
+	Vendor string
+
+	// Userspace is set for USB devices driven by a userspace runtime over
+	// libusb; they re-enumerate with this ID once the runtime has booted them
+	Userspace string
+}
+
+// knownAccelerators maps PCI and USB IDs (lowercase hex) to accelerator models
+var knownAccelerators = map[acceleratorID]acceleratorModel{
+	// Intel NPUs (intel_vpu driver)
+	{"pci", "8086", "7d1d"}: {Type: "NPU", Name: "Intel Meteor Lake NPU", Vendor: "Intel"},
+	{"pci", "8086", "ad1d"}: {Type: "NPU", Name: "Intel Arrow Lake NPU", Vendor: "Intel"},
+	{"pci", "8086", "643e"}: {Type: "NPU", Name: "Intel Lunar Lake NPU", Vendor: "Intel"},
+	{"pci", "8086", "b03e"}: {Type: "NPU", Name: "Intel Panther Lake NPU", Vendor: "Intel"},
+
+	// AMD XDNA NPUs (amdxdna driver)
+	{"pci", "1022", "1502"}: {Type: "NPU", Name: "AMD Ryzen AI NPU (XDNA)", Vendor: "AMD"},
+	{"pci", "1022", "17f0"}: {Type: "NPU", Name: "AMD Ryzen AI NPU (XDNA 2)", Vendor: "AMD"},
+
+	// Google Coral Edge TPU (apex driver on PCIe, libedgetpu on USB)
+	{"pci", "1ac1", "089a"}: {Type: "TPU", Name: "Coral Edge TPU", Vendor: "Google"},
+	{"usb", "1a6e", "089a"}: {Type: "TPU", Name: "Coral USB Accelerator", Vendor: "Google"},
+	{"usb", "18d1", "9302"}: {Type: "TPU", Name: "Coral USB Accelerator", Vendor: "Google", Userspace: "libedgetpu"},
+
+	// Intel Movidius VPUs (OpenVINO over libusb)
+	{"usb", "03e7", "2150"}: {Type: "VPU", Name: "Intel Movidius Myriad 2", Vendor: "Intel"},
+	{"usb", "03e7", "2485"}: {Type: "VPU", Name: "Intel Movidius Myriad X", Vendor: "Intel"},
+	{"usb", "03e7", "f63b"}: {Type: "VPU", Name: "Intel Movidius Myriad X", Vendor: "Intel", Userspace: "openvino"},
+
+	// Habana Labs (habanalabs driver)
+	{"pci", "1da3", "0001"}: {Type: "AI Accelerator", Name: "Habana Goya", Vendor: "Habana"},
+	{"pci", "1da3", "1000"}: {Type: "AI Accelerator", Name: "Habana Gaudi", Vendor: "Habana"},
+	{"pci", "1da3", "1020"}: {Type: "AI Accelerator", Name: "Habana Gaudi2", Vendor: "Habana"},
+	{"pci", "1da3", "1060"}: {Type: "AI Accelerator", Name: "Intel Gaudi 3", Vendor: "Habana"},
+	{"pci", "1da3", ""}:     {Type: "AI Accelerator", Name: "Habana accelerator", Vendor: "Habana"},
+
+	// Qualcomm Cloud AI (qaic driver)
+	{"pci", "17cb", "a100"}: {Type: "AI Accelerator", Name: "Qualcomm Cloud AI 100", Vendor: "Qualcomm"},
+
+	// FPGAs
+	{"pci", "10ee", ""}:     {Type: "FPGA", Name: "Xilinx FPGA", Vendor: "Xilinx"},
+	{"pci", "1172", ""}:     {Type: "FPGA", Name: "Altera FPGA", Vendor: "Intel"},
+	{"pci", "8086", "09c4"}: {Type: "FPGA", Name: "Intel PAC with Arria 10 GX", Vendor: "Intel"},
+	{"pci", "8086", "0b2b"}: {Type: "FPGA", Name: "Intel PAC D5005", Vendor: "Intel"},
+	{"pci", "8086", "0b30"}: {Type: "FPGA", Name: "Intel FPGA PAC N3000", Vendor: "Intel"},
+}
+
+// pciClassProcessingAccelerator is the PCI base class for processing accelerators
+const pciClassProcessingAccelerator = "12"
+
+// CollectAccelerators gathers NPUs, TPUs, AI accelerators and FPGAs
+func CollectAccelerators() (*types.AcceleratorData, error) {
+	accelerators := collectAcceleratorsPlatform()
+	if len(accelerators) == 0 {
+		return nil, fmt.Errorf("no accelerators found")
+	}
+
+	sort.SliceStable(accelerators, func(i, j int) bool {
+		if accelerators[i].Bus != accelerators[j].Bus {
+			return accelerators[i].Bus < accelerators[j].Bus
+		}
+		return accelerators[i].Address < accelerators[j].Address
+	})
+
+	return &types.AcceleratorData{Accelerators: accelerators}, nil
+}
+
+// classifyAccelerator identifies an accelerator from its bus, vendor and
+// device IDs and, for PCI, its class code (e.g. 0x120000). IDs may carry a
+// 0x prefix and any case.
+func classifyAccelerator(bus, vendorID, deviceID, class string) (types.AcceleratorInfo, bool) {
+	vendorID = normalizeHexID(vendorID)
+	deviceID = normalizeHexID(deviceID)
+
+	model, ok := knownAccelerators[acceleratorID{bus, vendorID, deviceID}]
+	if !ok {
+		model, ok = knownAccelerators[acceleratorID{bus, vendorID, ""}]
+	}
+	if !ok && bus == "pci" && strings.HasPrefix(normalizeHexID(class), pciClassProcessingAccelerator) {
+		model, ok = acceleratorModel{Type: "Accelerator", Name: fmt.Sprintf("Processing accelerator %s:%s", vendorID, deviceID)}, true
+	}
+	if !ok {
+		return types.AcceleratorInfo{}, false
+	}
+
+	info := types.AcceleratorInfo{
+		Type:     model.Type,
+		Name:     model.Name,
+		Vendor:   model.Vendor,
+		Bus:      bus,
+		VendorID: vendorID,
+		DeviceID: deviceID,
+	}
+	if model.Userspace != "" {
+		info.Driver = model.Userspace
+		info.DriverLoaded = true
+	}
+	return info, true
+}
+
+// normalizeHexID lowercases an ID and strips a 0x prefix and whitespace
+func normalizeHexID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	return strings.TrimPrefix(id, "0x")
+}
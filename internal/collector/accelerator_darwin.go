@@ -0,0 +1,11 @@
+//go:build darwin
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectAcceleratorsPlatform reports no accelerators on macOS; the Apple
+// Neural Engine is part of the SoC and has no PCI or USB identity
+func collectAcceleratorsPlatform() []types.AcceleratorInfo {
+	return nil
+}
@@ -0,0 +1,103 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	pciDevicesPath = "/sys/bus/pci/devices"
+	usbDevicesPath = "/sys/bus/usb/devices"
+)
+
+// collectAcceleratorsPlatform scans the PCI and USB buses in sysfs
+func collectAcceleratorsPlatform() []types.AcceleratorInfo {
+	accelerators := scanPCIAccelerators(hostPath(pciDevicesPath))
+	return append(accelerators, scanUSBAccelerators(hostPath(usbDevicesPath))...)
+}
+
+// scanPCIAccelerators reads vendor, device and class IDs of every PCI device
+func scanPCIAccelerators(root string) []types.AcceleratorInfo {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var accelerators []types.AcceleratorInfo
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		vendor, err := readSysFile(filepath.Join(dir, "vendor"))
+		if err != nil {
+			continue
+		}
+		device, _ := readSysFile(filepath.Join(dir, "device"))
+		class, _ := readSysFile(filepath.Join(dir, "class"))
+
+		info, ok := classifyAccelerator("pci", vendor, device, class)
+		if !ok {
+			continue
+		}
+		info.Address = entry.Name()
+		info.Driver, info.DriverLoaded = boundDriver(dir)
+		accelerators = append(accelerators, info)
+	}
+	return accelerators
+}
+
+// scanUSBAccelerators reads idVendor and idProduct of every USB device.
+// Interface entries (e.g. 1-2:1.0) carry no IDs and are skipped
+func scanUSBAccelerators(root string) []types.AcceleratorInfo {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var accelerators []types.AcceleratorInfo
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		vendor, err := readSysFile(filepath.Join(dir, "idVendor"))
+		if err != nil {
+			continue
+		}
+		product, _ := readSysFile(filepath.Join(dir, "idProduct"))
+
+		info, ok := classifyAccelerator("usb", vendor, product, "")
+		if !ok {
+			continue
+		}
+		info.Address = entry.Name()
+		if !info.DriverLoaded {
+			info.Driver, info.DriverLoaded = usbInterfaceDriver(root, entry.Name())
+		}
+		accelerators = append(accelerators, info)
+	}
+	return accelerators
+}
+
+// boundDriver returns the name of the driver bound to a sysfs device
+func boundDriver(dir string) (string, bool) {
+	target, err := os.Readlink(filepath.Join(dir, "driver"))
+	if err != nil {
+		return "", false
+	}
+	return filepath.Base(target), true
+}
+
+// usbInterfaceDriver returns the first driver bound to an interface of a USB
+// device; usbfs means a userspace runtime has claimed it through libusb
+func usbInterfaceDriver(root, device string) (string, bool) {
+	interfaces, err := filepath.Glob(filepath.Join(root, device+":*"))
+	if err != nil {
+		return "", false
+	}
+	for _, iface := range interfaces {
+		if driver, ok := boundDriver(iface); ok {
+			return driver, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanAccelerators(t *testing.T) {
+	dir := t.TempDir()
+	writeDevice := func(root, name string, files map[string]string, driver string) {
+		devDir := filepath.Join(dir, root, name)
+		if err := os.MkdirAll(devDir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(devDir, file), []byte(content+"\n"), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", file, err)
+			}
+		}
+		if driver != "" {
+			if err := os.Symlink(filepath.Join("drivers", driver), filepath.Join(devDir, "driver")); err != nil {
+				t.Fatalf("Failed to link driver: %v", err)
+			}
+		}
+	}
+
+	writeDevice("pci", "0000:00:0b.0", map[string]string{"vendor": "0x8086", "device": "0x7d1d", "class": "0x120000"}, "intel_vpu")
+	writeDevice("pci", "0000:01:00.0", map[string]string{"vendor": "0x10ee", "device": "0x903f", "class": "0x058000"}, "")
+	writeDevice("pci", "0000:00:02.0", map[string]string{"vendor": "0x8086", "device": "0x7d55", "class": "0x030000"}, "i915")
+	writeDevice("usb", "2-1", map[string]string{"idVendor": "1a6e", "idProduct": "089a"}, "usb")
+	writeDevice("usb", "2-1:1.0", map[string]string{}, "usbfs")
+
+	pci := scanPCIAccelerators(filepath.Join(dir, "pci"))
+	if len(pci) != 2 {
+		t.Fatalf("scanPCIAccelerators() found %d devices, expected 2: %+v", len(pci), pci)
+	}
+	byAddress := map[string]int{}
+	for i, acc := range pci {
+		byAddress[acc.Address] = i
+	}
+	npu := pci[byAddress["0000:00:0b.0"]]
+	if npu.Type != "NPU" || npu.Driver != "intel_vpu" || !npu.DriverLoaded {
+		t.Errorf("NPU = %+v, expected intel_vpu driver loaded", npu)
+	}
+	fpga := pci[byAddress["0000:01:00.0"]]
+	if fpga.Type != "FPGA" || fpga.DriverLoaded {
+		t.Errorf("FPGA = %+v, expected no driver", fpga)
+	}
+
+	usb := scanUSBAccelerators(filepath.Join(dir, "usb"))
+	if len(usb) != 1 {
+		t.Fatalf("scanUSBAccelerators() found %d devices, expected 1: %+v", len(usb), usb)
+	}
+	if usb[0].Name != "Coral USB Accelerator" || usb[0].Address != "2-1" || usb[0].Driver != "usbfs" {
+		t.Errorf("USB accelerator = %+v, expected Coral on 2-1 claimed by usbfs", usb[0])
+	}
+
+	if got := scanPCIAccelerators(filepath.Join(dir, "missing")); got != nil {
+		t.Errorf("scanPCIAccelerators() on missing dir = %+v, expected nil", got)
+	}
+}
@@ -0,0 +1,49 @@
+package collector
+
+import "testing"
+
+func TestClassifyAccelerator(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bus          string
+		vendorID     string
+		deviceID     string
+		class        string
+		expectOK     bool
+		expectType   string
+		expectName   string
+		expectDriver string
+	}{
+		{"intel npu", "pci", "0x8086", "0x7d1d", "0x120000", true, "NPU", "Intel Meteor Lake NPU", ""},
+		{"amd xdna", "pci", "0x1022", "0x1502", "0x118000", true, "NPU", "AMD Ryzen AI NPU (XDNA)", ""},
+		{"coral pcie", "pci", "1AC1", "089A", "0x0880", true, "TPU", "Coral Edge TPU", ""},
+		{"coral usb before boot", "usb", "1a6e", "089a", "", true, "TPU", "Coral USB Accelerator", ""},
+		{"coral usb after boot", "usb", "18d1", "9302", "", true, "TPU", "Coral USB Accelerator", "libedgetpu"},
+		{"unknown gaudi", "pci", "0x1da3", "0xffff", "0x120000", true, "AI Accelerator", "Habana accelerator", ""},
+		{"xilinx fpga", "pci", "0x10ee", "0x5000", "0x058000", true, "FPGA", "Xilinx FPGA", ""},
+		{"unknown class 12", "pci", "0x1e52", "0x0100", "0x120000", true, "Accelerator", "Processing accelerator 1e52:0100", ""},
+		{"intel gpu", "pci", "0x8086", "0x7d55", "0x030000", false, "", "", ""},
+		{"class 12 on usb", "usb", "1e52", "0100", "0x120000", false, "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, ok := classifyAccelerator(tc.bus, tc.vendorID, tc.deviceID, tc.class)
+			if ok != tc.expectOK {
+				t.Fatalf("classifyAccelerator() ok = %v, expected %v", ok, tc.expectOK)
+			}
+			if !ok {
+				return
+			}
+			if info.Type != tc.expectType || info.Name != tc.expectName {
+				t.Errorf("classifyAccelerator() = %s %q, expected %s %q", info.Type, info.Name, tc.expectType, tc.expectName)
+			}
+			if info.Driver != tc.expectDriver || info.DriverLoaded != (tc.expectDriver != "") {
+				t.Errorf("Driver = %q (loaded %v), expected %q", info.Driver, info.DriverLoaded, tc.expectDriver)
+			}
+			if info.VendorID != normalizeHexID(tc.vendorID) || info.Bus != tc.bus {
+				t.Errorf("IDs not normalized: %+v", info)
+			}
+		})
+	}
+}
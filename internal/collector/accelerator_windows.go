@@ -0,0 +1,66 @@
+//go:build windows
+
+package collector
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Win32_PnPEntity represents a Plug and Play device from WMI
+type Win32_PnPEntity struct {
+	Name                   string
+	PNPDeviceID            string
+	Service                string
+	ConfigManagerErrorCode uint32
+}
+
+var (
+	pnpPCIIDRe = regexp.MustCompile(`(?i)^PCI\\VEN_([0-9A-F]{4})&DEV_([0-9A-F]{4})`)
+	pnpUSBIDRe = regexp.MustCompile(`(?i)^USB\\VID_([0-9A-F]{4})&PID_([0-9A-F]{4})\\`)
+)
+
+// collectAcceleratorsPlatform matches PCI and USB PnP devices against known accelerators
+func collectAcceleratorsPlatform() []types.AcceleratorInfo {
+	var entities []Win32_PnPEntity
+	query := "SELECT Name, PNPDeviceID, Service, ConfigManagerErrorCode FROM Win32_PnPEntity WHERE PNPDeviceID LIKE 'PCI\\\\%' OR PNPDeviceID LIKE 'USB\\\\VID%'"
+	if err := wmi.Query(query, &entities); err != nil {
+		return nil
+	}
+
+	var accelerators []types.AcceleratorInfo
+	for _, entity := range entities {
+		info, ok := classifyPnPAccelerator(entity.PNPDeviceID)
+		if !ok {
+			continue
+		}
+		// Error code 0 means the device is started; 28 means no driver is installed
+		if entity.Service != "" && entity.ConfigManagerErrorCode == 0 {
+			info.Driver = entity.Service
+			info.DriverLoaded = true
+		}
+		accelerators = append(accelerators, info)
+	}
+	return accelerators
+}
+
+// classifyPnPAccelerator extracts bus and IDs from a PnP device ID such as
+// PCI\VEN_8086&DEV_7D1D&SUBSYS_... or USB\VID_18D1&PID_9302\...
+// PnP device IDs carry no class code, so only known models are matched
+func classifyPnPAccelerator(pnpID string) (types.AcceleratorInfo, bool) {
+	var info types.AcceleratorInfo
+	var ok bool
+	if m := pnpPCIIDRe.FindStringSubmatch(pnpID); m != nil {
+		info, ok = classifyAccelerator("pci", m[1], m[2], "")
+	} else if m := pnpUSBIDRe.FindStringSubmatch(pnpID); m != nil {
+		info, ok = classifyAccelerator("usb", m[1], m[2], "")
+	}
+	if !ok {
+		return info, false
+	}
+	info.Address = strings.TrimSpace(pnpID)
+	return info, true
+}
@@ -0,0 +1,16 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectAudio gathers the sound cards with their codecs, drivers and output and input devices
+func CollectAudio() (*types.AudioData, error) {
+	devices := collectAudioPlatform()
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no audio devices found")
+	}
+	return &types.AudioData{Devices: devices}, nil
+}
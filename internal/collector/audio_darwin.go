@@ -0,0 +1,66 @@
+//go:build darwin
+
+package collector
+
+import (
+	"cmp"
+	"encoding/json"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// spAudioDevice is a Core Audio device in the SPAudioDataType report; the counts are channels
+type spAudioDevice struct {
+	Name          string `json:"_name"`
+	Manufacturer  string `json:"coreaudio_device_manufacturer"`
+	Transport     string `json:"coreaudio_device_transport"` // e.g. coreaudio_device_type_usb
+	Outputs       int    `json:"coreaudio_device_output"`
+	Inputs        int    `json:"coreaudio_device_input"`
+	OutputSource  string `json:"coreaudio_output_source"` // e.g. Internal Speakers, or Headphones while plugged in
+	InputSource   string `json:"coreaudio_input_source"`
+	DefaultOutput string `json:"coreaudio_default_audio_output_device"` // spaudio_yes
+	DefaultInput  string `json:"coreaudio_default_audio_input_device"`
+}
+
+func collectAudioPlatform() []types.AudioDevice {
+	out, err := sandbox.Command("system_profiler", "SPAudioDataType", "-json").Output()
+	if err != nil {
+		return nil
+	}
+	return parseSPAudio(out)
+}
+
+// parseSPAudio reads the Core Audio devices of a system_profiler SPAudioDataType report.
+// macOS has no sound card view: each device is listed with its current output or input source
+func parseSPAudio(output []byte) []types.AudioDevice {
+	var report struct {
+		Items []struct {
+			Devices []spAudioDevice `json:"_items"`
+		} `json:"SPAudioDataType"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil
+	}
+
+	var devices []types.AudioDevice
+	for _, group := range report.Items {
+		for _, d := range group.Devices {
+			device := types.AudioDevice{
+				Name:         d.Name,
+				Manufacturer: d.Manufacturer,
+				Bus:          strings.TrimPrefix(d.Transport, "coreaudio_device_type_"),
+				Default:      d.DefaultOutput == "spaudio_yes" || d.DefaultInput == "spaudio_yes",
+			}
+			if d.Outputs > 0 {
+				device.Playback = []string{cmp.Or(d.OutputSource, d.Name)}
+			}
+			if d.Inputs > 0 {
+				device.Capture = []string{cmp.Or(d.InputSource, d.Name)}
+			}
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
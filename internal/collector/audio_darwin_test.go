@@ -0,0 +1,56 @@
+//go:build darwin
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const spAudioOutput = `{"SPAudioDataType": [{
+  "_name": "coreaudio_device",
+  "_items": [
+    {
+      "_name": "MacBook Pro Microphone",
+      "coreaudio_default_audio_input_device": "spaudio_yes",
+      "coreaudio_device_input": 1,
+      "coreaudio_device_manufacturer": "Apple Inc.",
+      "coreaudio_device_srate": 48000,
+      "coreaudio_device_transport": "coreaudio_device_type_builtin",
+      "coreaudio_input_source": "MacBook Pro Microphone"
+    },
+    {
+      "_name": "MacBook Pro Speakers",
+      "coreaudio_device_manufacturer": "Apple Inc.",
+      "coreaudio_device_output": 2,
+      "coreaudio_device_srate": 48000,
+      "coreaudio_device_transport": "coreaudio_device_type_builtin",
+      "coreaudio_output_source": "MacBook Pro Speakers"
+    },
+    {
+      "_name": "Scarlett 2i2 USB",
+      "coreaudio_default_audio_output_device": "spaudio_yes",
+      "coreaudio_device_input": 2,
+      "coreaudio_device_manufacturer": "Focusrite",
+      "coreaudio_device_output": 2,
+      "coreaudio_device_transport": "coreaudio_device_type_usb"
+    }
+  ]
+}]}`
+
+func TestParseSPAudio(t *testing.T) {
+	devices := parseSPAudio([]byte(spAudioOutput))
+	expected := []types.AudioDevice{
+		{Name: "MacBook Pro Microphone", Manufacturer: "Apple Inc.", Bus: "builtin", Capture: []string{"MacBook Pro Microphone"}, Default: true},
+		{Name: "MacBook Pro Speakers", Manufacturer: "Apple Inc.", Bus: "builtin", Playback: []string{"MacBook Pro Speakers"}},
+		{Name: "Scarlett 2i2 USB", Manufacturer: "Focusrite", Bus: "usb", Playback: []string{"Scarlett 2i2 USB"}, Capture: []string{"Scarlett 2i2 USB"}, Default: true},
+	}
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("parseSPAudio() =\n%+v\nexpected\n%+v", devices, expected)
+	}
+	if devices := parseSPAudio([]byte("not json")); devices != nil {
+		t.Errorf("parseSPAudio(invalid) = %+v, expected nil", devices)
+	}
+}
@@ -0,0 +1,133 @@
+//go:build linux
+
+package collector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	asoundPath     = "/proc/asound"
+	soundClassPath = "/sys/class/sound"
+)
+
+// asoundCardRe matches a card's first line in /proc/asound/cards, e.g.
+// " 0 [PCH            ]: HDA-Intel - HDA Intel PCH"
+var asoundCardRe = regexp.MustCompile(`^\s*(\d+)\s+\[(\S+)\s*\]:\s*(.*?)\s+-\s+(.*)$`)
+
+// alsaPCM is a card's PCM devices, named as in /proc/asound/pcm
+type alsaPCM struct {
+	playback, capture []string
+}
+
+// collectAudioPlatform lists the ALSA sound cards from procfs, with the driver and bus of the
+// device behind each from sysfs
+func collectAudioPlatform() []types.AudioDevice {
+	return scanALSACards(hostPath(asoundPath), hostPath(soundClassPath))
+}
+
+// scanALSACards reads the cards listed in asound/cards, their PCM devices from asound/pcm and
+// their HD Audio codecs from asound/cardN/codec#M. The driver is the one bound to the card's
+// device in sysfs, or ALSA's name for it where sysfs is missing
+func scanALSACards(asound, sysfs string) []types.AudioDevice {
+	f, err := os.Open(filepath.Join(asound, "cards"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var pcms map[int]alsaPCM
+	if data, err := os.ReadFile(filepath.Join(asound, "pcm")); err == nil {
+		pcms = parseALSAPCM(string(data))
+	}
+
+	var devices []types.AudioDevice
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := asoundCardRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		index, _ := strconv.Atoi(m[1])
+		card := "card" + m[1]
+		device := types.AudioDevice{
+			Name:     strings.TrimSpace(m[4]),
+			ID:       m[2],
+			Codecs:   alsaCodecs(filepath.Join(asound, card)),
+			Driver:   strings.TrimSpace(m[3]),
+			Playback: pcms[index].playback,
+			Capture:  pcms[index].capture,
+		}
+		dir := filepath.Join(sysfs, card, "device")
+		if driver, ok := boundDriver(dir); ok {
+			device.Driver = driver
+		}
+		if subsystem, err := os.Readlink(filepath.Join(dir, "subsystem")); err == nil {
+			device.Bus = filepath.Base(subsystem)
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// parseALSAPCM reads /proc/asound/pcm, one line per PCM device with its card and device
+// number, ID, name and stream counts, e.g.
+// "00-03: HDMI 0 : HDMI 0 : playback 1"
+func parseALSAPCM(data string) map[int]alsaPCM {
+	pcms := map[int]alsaPCM{}
+	for _, line := range strings.Split(data, "\n") {
+		address, rest, found := strings.Cut(line, ": ")
+		cardNum, _, ok := strings.Cut(address, "-")
+		if !found || !ok {
+			continue
+		}
+		card, err := strconv.Atoi(cardNum)
+		if err != nil {
+			continue
+		}
+		fields := strings.Split(rest, " : ")
+		if len(fields) < 3 {
+			continue
+		}
+		name := strings.TrimSpace(fields[1])
+		pcm := pcms[card]
+		for _, stream := range fields[2:] {
+			switch {
+			case strings.HasPrefix(strings.TrimSpace(stream), "playback"):
+				pcm.playback = append(pcm.playback, name)
+			case strings.HasPrefix(strings.TrimSpace(stream), "capture"):
+				pcm.capture = append(pcm.capture, name)
+			}
+		}
+		pcms[card] = pcm
+	}
+	return pcms
+}
+
+// alsaCodecs names the HD Audio codecs on a card from the first line of each codec#N file,
+// e.g. "Codec: Realtek ALC257"
+func alsaCodecs(cardDir string) []string {
+	files, err := filepath.Glob(filepath.Join(cardDir, "codec#*"))
+	if err != nil {
+		return nil
+	}
+	var codecs []string
+	for _, file := range files {
+		data, err := readSysFile(file)
+		if err != nil {
+			continue
+		}
+		first, _, _ := strings.Cut(data, "\n")
+		if codec, ok := strings.CutPrefix(first, "Codec: "); ok && strings.TrimSpace(codec) != "" {
+			codecs = append(codecs, strings.TrimSpace(codec))
+		}
+	}
+	return codecs
+}
@@ -0,0 +1,81 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const asoundCards = ` 0 [PCH            ]: HDA-Intel - HDA Intel PCH
+                      HDA Intel PCH at 0xf1230000 irq 145
+ 1 [Headset        ]: USB-Audio - Jabra Evolve2 65
+                      GN Audio A/S Jabra Evolve2 65 at usb-0000:00:14.0-2, full speed
+`
+
+const asoundPCM = `00-00: ALC257 Analog : ALC257 Analog : playback 1 : capture 1
+00-03: HDMI 0 : HDMI 0 : playback 1
+00-07: HDMI 1 : HDMI 1 : playback 1
+01-00: USB Audio : USB Audio : playback 1 : capture 1
+`
+
+func TestScanALSACards(t *testing.T) {
+	root := t.TempDir()
+	asound, sysfs := filepath.Join(root, "asound"), filepath.Join(root, "sound")
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(asound, "cards"), asoundCards)
+	write(filepath.Join(asound, "pcm"), asoundPCM)
+	write(filepath.Join(asound, "card0", "codec#0"), "Codec: Realtek ALC257\nAddress: 0\n")
+	write(filepath.Join(asound, "card0", "codec#2"), "Codec: Intel Kabylake HDMI\nAddress: 2\n")
+	// Card 0's device is a PCI function with a bound driver; card 1 has no sysfs entry
+	device := filepath.Join(sysfs, "card0", "device")
+	if err := os.MkdirAll(device, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for link, target := range map[string]string{"driver": "../../bus/pci/drivers/snd_hda_intel", "subsystem": "../../bus/pci"} {
+		if err := os.Symlink(target, filepath.Join(device, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	devices := scanALSACards(asound, sysfs)
+	expected := []types.AudioDevice{
+		{
+			Name: "HDA Intel PCH", ID: "PCH", Codecs: []string{"Realtek ALC257", "Intel Kabylake HDMI"},
+			Driver: "snd_hda_intel", Bus: "pci",
+			Playback: []string{"ALC257 Analog", "HDMI 0", "HDMI 1"}, Capture: []string{"ALC257 Analog"},
+		},
+		{
+			Name: "Jabra Evolve2 65", ID: "Headset", Driver: "USB-Audio",
+			Playback: []string{"USB Audio"}, Capture: []string{"USB Audio"},
+		},
+	}
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("scanALSACards() =\n%+v\nexpected\n%+v", devices, expected)
+	}
+
+	if devices := scanALSACards(filepath.Join(root, "missing"), sysfs); devices != nil {
+		t.Errorf("scanALSACards() without procfs = %+v, expected nil", devices)
+	}
+}
+
+func TestParseALSAPCM(t *testing.T) {
+	pcms := parseALSAPCM(asoundPCM + "garbage line\n02-00: Loopback PCM : Loopback PCM : capture 8\n")
+	if len(pcms) != 3 {
+		t.Fatalf("parseALSAPCM() = %+v, expected 3 cards", pcms)
+	}
+	if pcm := pcms[2]; pcm.playback != nil || !reflect.DeepEqual(pcm.capture, []string{"Loopback PCM"}) {
+		t.Errorf("card 2 = %+v, expected a capture-only device", pcm)
+	}
+}
@@ -0,0 +1,119 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// pnpAudioEntity is a sound device (class MEDIA) or one of its outputs and inputs (class
+// AudioEndpoint) as Win32_PnPEntity reports it
+type pnpAudioEntity struct {
+	Name         string // Endpoints are named after their device, e.g. Speakers (Realtek(R) Audio)
+	Manufacturer string
+	PNPDeviceID  string // e.g. HDAUDIO\FUNC_01&VEN_10EC&DEV_0257..., or SWD\MMDEVAPI\{0.0.0.00000000}.{...} for endpoints
+	PNPClass     string
+	Service      string
+	Status       string
+}
+
+// pnpAudioBuses names the enumerators of sound devices' PnP IDs
+var pnpAudioBuses = map[string]string{
+	"HDAUDIO":     "hdaudio",
+	"USB":         "usb",
+	"PCI":         "pci",
+	"BTHENUM":     "bluetooth",
+	"BTHHFENUM":   "bluetooth",
+	"BTHLEDEVICE": "bluetooth",
+	"INTELAUDIO":  "intelaudio",
+}
+
+// collectAudioPlatform lists the sound devices and their audio endpoints. Win32_SoundDevice
+// gives the same devices without their driver or endpoints, so Win32_PnPEntity is used;
+// its PNPClass needs Windows 10
+func collectAudioPlatform() []types.AudioDevice {
+	var entities []pnpAudioEntity
+	query := "SELECT Name, Manufacturer, PNPDeviceID, PNPClass, Service, Status FROM Win32_PnPEntity WHERE PNPClass = 'MEDIA' OR PNPClass = 'AudioEndpoint'"
+	if err := wmi.Query(query, &entities); err != nil {
+		return nil
+	}
+	return pnpAudioDevices(entities)
+}
+
+// pnpAudioDevices builds the sound devices and attaches each endpoint to the device it is
+// named after. The endpoint's ID tells outputs ({0.0.0.) from inputs ({0.0.1.). Endpoints of
+// devices outside the MEDIA class, such as Bluetooth headsets, are listed under their own device
+func pnpAudioDevices(entities []pnpAudioEntity) []types.AudioDevice {
+	var devices []types.AudioDevice
+	byName := map[string]int{}
+	for _, entity := range entities {
+		if !strings.EqualFold(entity.PNPClass, "MEDIA") {
+			continue
+		}
+		device := types.AudioDevice{
+			Name:         strings.TrimSpace(entity.Name),
+			ID:           strings.TrimSpace(entity.PNPDeviceID),
+			Manufacturer: strings.TrimSpace(entity.Manufacturer),
+			Driver:       entity.Service,
+		}
+		// Generic names Windows gives vendors it has no INF for
+		if strings.HasPrefix(device.Manufacturer, "(") {
+			device.Manufacturer = ""
+		}
+		enumerator, _, _ := strings.Cut(device.ID, `\`)
+		device.Bus = pnpAudioBuses[strings.ToUpper(enumerator)]
+		if entity.Status != "" && entity.Status != "OK" {
+			device.Status = entity.Status
+		}
+		byName[device.Name] = len(devices)
+		devices = append(devices, device)
+	}
+
+	for _, entity := range entities {
+		if !strings.EqualFold(entity.PNPClass, "AudioEndpoint") {
+			continue
+		}
+		endpoint, deviceName := splitEndpointName(strings.TrimSpace(entity.Name))
+		i, ok := byName[deviceName]
+		if !ok {
+			i = len(devices)
+			byName[deviceName] = i
+			devices = append(devices, types.AudioDevice{Name: deviceName})
+		}
+		if strings.Contains(entity.PNPDeviceID, "{0.0.1.") {
+			devices[i].Capture = append(devices[i].Capture, endpoint)
+		} else {
+			devices[i].Playback = append(devices[i].Playback, endpoint)
+		}
+	}
+	return devices
+}
+
+// splitEndpointName splits an endpoint name such as "Speakers (Realtek(R) Audio)" into the
+// endpoint and its device, minding parentheses within the device name
+func splitEndpointName(name string) (endpoint, device string) {
+	if !strings.HasSuffix(name, ")") {
+		return name, name
+	}
+	depth := 0
+	for i := len(name) - 1; i >= 0; i-- {
+		switch name[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				endpoint = strings.TrimSpace(name[:i])
+				device = name[i+1 : len(name)-1]
+				if endpoint == "" {
+					return name, name
+				}
+				return endpoint, device
+			}
+		}
+	}
+	return name, name
+}
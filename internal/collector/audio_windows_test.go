@@ -0,0 +1,48 @@
+//go:build windows
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestPnPAudioDevices(t *testing.T) {
+	devices := pnpAudioDevices([]pnpAudioEntity{
+		{Name: "Realtek(R) Audio", Manufacturer: "Realtek", PNPClass: "MEDIA", Service: "IntcAzAudAddService", Status: "OK",
+			PNPDeviceID: `HDAUDIO\FUNC_01&VEN_10EC&DEV_0257&SUBSYS_17AA2292&REV_1000\4&2B1C2F&0&0001`},
+		{Name: "USB Audio Device", Manufacturer: "(Generic USB Audio)", PNPClass: "MEDIA", Service: "usbaudio", Status: "Error",
+			PNPDeviceID: `USB\VID_0D8C&PID_0014&MI_00\7&1A2B3C&0&0000`},
+		{Name: "Speakers (Realtek(R) Audio)", PNPClass: "AudioEndpoint", PNPDeviceID: `SWD\MMDEVAPI\{0.0.0.00000000}.{B1A2}`},
+		{Name: "Microphone Array (Realtek(R) Audio)", PNPClass: "AudioEndpoint", PNPDeviceID: `SWD\MMDEVAPI\{0.0.1.00000000}.{C3D4}`},
+		{Name: "Headphones (WH-1000XM4)", PNPClass: "AudioEndpoint", PNPDeviceID: `SWD\MMDEVAPI\{0.0.0.00000000}.{E5F6}`},
+	})
+	expected := []types.AudioDevice{
+		{
+			Name: "Realtek(R) Audio", ID: `HDAUDIO\FUNC_01&VEN_10EC&DEV_0257&SUBSYS_17AA2292&REV_1000\4&2B1C2F&0&0001`,
+			Manufacturer: "Realtek", Driver: "IntcAzAudAddService", Bus: "hdaudio",
+			Playback: []string{"Speakers"}, Capture: []string{"Microphone Array"},
+		},
+		{Name: "USB Audio Device", ID: `USB\VID_0D8C&PID_0014&MI_00\7&1A2B3C&0&0000`, Driver: "usbaudio", Bus: "usb", Status: "Error"},
+		{Name: "WH-1000XM4", Playback: []string{"Headphones"}},
+	}
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("pnpAudioDevices() =\n%+v\nexpected\n%+v", devices, expected)
+	}
+}
+
+func TestSplitEndpointName(t *testing.T) {
+	tests := []struct{ name, endpoint, device string }{
+		{"Speakers (Realtek(R) Audio)", "Speakers", "Realtek(R) Audio"},
+		{"Digital Audio (S/PDIF) (High Definition Audio Device)", "Digital Audio (S/PDIF)", "High Definition Audio Device"},
+		{"Speakers", "Speakers", "Speakers"},
+		{"(Unbalanced", "(Unbalanced", "(Unbalanced"},
+	}
+	for _, tt := range tests {
+		if endpoint, device := splitEndpointName(tt.name); endpoint != tt.endpoint || device != tt.device {
+			t.Errorf("splitEndpointName(%q) = %q, %q; expected %q, %q", tt.name, endpoint, device, tt.endpoint, tt.device)
+		}
+	}
+}
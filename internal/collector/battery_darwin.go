@@ -4,11 +4,11 @@ package collector
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 )
 
@@ -21,7 +21,7 @@ func CollectBattery() (*types.BatteryData, error) {
 	}
 
 	// Use pmset to get battery information
-	pmsetOutput, err := exec.Command("pmset", "-g", "batt").Output()
+	pmsetOutput, err := sandbox.Command("pmset", "-g", "batt").Output()
 	if err != nil {
 		return data, nil // No battery information available
 	}
@@ -83,7 +83,7 @@ func CollectBattery() (*types.BatteryData, error) {
 	}
 
 	// Use ioreg to get detailed battery information
-	ioregOutput, err := exec.Command("ioreg", "-r", "-c", "AppleSmartBattery").Output()
+	ioregOutput, err := sandbox.Command("ioreg", "-r", "-c", "AppleSmartBattery").Output()
 	if err == nil {
 		ioregStr := string(ioregOutput)
 
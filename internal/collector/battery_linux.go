@@ -18,19 +18,22 @@ const (
 
 // CollectBattery collects battery information on Linux
 func CollectBattery() (*types.BatteryData, error) {
+	return collectBattery(hostfs)
+}
+
+// collectBattery reads the batteries and AC adapters below /sys/class/power_supply
+func collectBattery(fsys fsReader) (*types.BatteryData, error) {
 	data := &types.BatteryData{
 		Present:   false,
 		Batteries: []types.BatteryInfo{},
 		OnBattery: false,
 	}
 
-	// Check if power_supply directory exists
-	if _, err := os.Stat(powerSupplyPath); os.IsNotExist(err) {
+	// Read all power supply devices
+	entries, err := fsys.ReadDir(powerSupplyPath)
+	if os.IsNotExist(err) {
 		return data, nil // No battery information available
 	}
-
-	// Read all power supply devices
-	entries, err := os.ReadDir(powerSupplyPath)
 	if err != nil {
 		return data, fmt.Errorf("failed to read power supply directory: %w", err)
 	}
@@ -44,7 +47,7 @@ func CollectBattery() (*types.BatteryData, error) {
 		}
 
 		devicePath := filepath.Join(powerSupplyPath, entry.Name())
-		deviceType, err := readSysFile(filepath.Join(devicePath, "type"))
+		deviceType, err := readString(fsys, filepath.Join(devicePath, "type"))
 		if err != nil {
 			continue
 		}
@@ -53,7 +56,7 @@ func CollectBattery() (*types.BatteryData, error) {
 
 		// Check if AC adapter
 		if deviceType == "Mains" {
-			online, err := readSysFile(filepath.Join(devicePath, "online"))
+			online, err := readString(fsys, filepath.Join(devicePath, "online"))
 			if err == nil && strings.TrimSpace(online) == "1" {
 				acOnline = true
 			}
@@ -65,7 +68,7 @@ func CollectBattery() (*types.BatteryData, error) {
 			continue
 		}
 
-		battery, err := readBatteryInfo(devicePath, entry.Name())
+		battery, err := readBatteryInfo(fsys, devicePath, entry.Name())
 		if err != nil {
 			continue
 		}
@@ -84,7 +87,7 @@ func CollectBattery() (*types.BatteryData, error) {
 }
 
 // readBatteryInfo reads battery information from a specific battery device
-func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
+func readBatteryInfo(fsys fsReader, devicePath, name string) (types.BatteryInfo, error) {
 	battery := types.BatteryInfo{
 		Name:          name,
 		TimeToEmpty:   -1,
@@ -93,34 +96,34 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 	}
 
 	// Read status
-	if status, err := readSysFile(filepath.Join(devicePath, "status")); err == nil {
+	if status, err := readString(fsys, filepath.Join(devicePath, "status")); err == nil {
 		battery.State = strings.TrimSpace(status)
 		battery.IsCharging = battery.State == "Charging"
 		battery.IsDischarging = battery.State == "Discharging"
 	}
 
 	// Read manufacturer/vendor
-	if vendor, err := readSysFile(filepath.Join(devicePath, "manufacturer")); err == nil {
+	if vendor, err := readString(fsys, filepath.Join(devicePath, "manufacturer")); err == nil {
 		battery.Vendor = strings.TrimSpace(vendor)
 	}
 
 	// Read model
-	if model, err := readSysFile(filepath.Join(devicePath, "model_name")); err == nil {
+	if model, err := readString(fsys, filepath.Join(devicePath, "model_name")); err == nil {
 		battery.Model = strings.TrimSpace(model)
 	}
 
 	// Read serial number
-	if serial, err := readSysFile(filepath.Join(devicePath, "serial_number")); err == nil {
+	if serial, err := readString(fsys, filepath.Join(devicePath, "serial_number")); err == nil {
 		battery.SerialNumber = strings.TrimSpace(serial)
 	}
 
 	// Read technology
-	if tech, err := readSysFile(filepath.Join(devicePath, "technology")); err == nil {
+	if tech, err := readString(fsys, filepath.Join(devicePath, "technology")); err == nil {
 		battery.Technology = strings.TrimSpace(tech)
 	}
 
 	// Read cycle count
-	if cycleStr, err := readSysFile(filepath.Join(devicePath, "cycle_count")); err == nil {
+	if cycleStr, err := readString(fsys, filepath.Join(devicePath, "cycle_count")); err == nil {
 		if cycle, err := strconv.ParseUint(strings.TrimSpace(cycleStr), 10, 64); err == nil {
 			battery.CycleCount = cycle
 		}
@@ -128,15 +131,15 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 
 	// Read capacity information (in µWh - microwatt-hours)
 	// Convert to mWh (milliwatt-hours) by dividing by 1000
-	if capacityStr, err := readSysFile(filepath.Join(devicePath, "energy_full_design")); err == nil {
+	if capacityStr, err := readString(fsys, filepath.Join(devicePath, "energy_full_design")); err == nil {
 		if capacity, err := strconv.ParseUint(strings.TrimSpace(capacityStr), 10, 64); err == nil {
 			battery.Capacity = capacity / 1000 // Convert µWh to mWh
 		}
-	} else if capacityStr, err := readSysFile(filepath.Join(devicePath, "charge_full_design")); err == nil {
+	} else if capacityStr, err := readString(fsys, filepath.Join(devicePath, "charge_full_design")); err == nil {
 		// Some batteries report in µAh (microampere-hours)
 		if capacity, err := strconv.ParseUint(strings.TrimSpace(capacityStr), 10, 64); err == nil {
 			// Read voltage to convert to energy
-			if voltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_now")); err == nil {
+			if voltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_now")); err == nil {
 				if voltage, err := strconv.ParseUint(strings.TrimSpace(voltageStr), 10, 64); err == nil {
 					// energy (µWh) = charge (µAh) * voltage (µV) / 1000000
 					// then convert to mWh
@@ -147,14 +150,14 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 	}
 
 	// Read full capacity
-	if fullStr, err := readSysFile(filepath.Join(devicePath, "energy_full")); err == nil {
+	if fullStr, err := readString(fsys, filepath.Join(devicePath, "energy_full")); err == nil {
 		if full, err := strconv.ParseUint(strings.TrimSpace(fullStr), 10, 64); err == nil {
 			battery.CapacityFull = full / 1000
 			battery.EnergyFull = full / 1000
 		}
-	} else if fullStr, err := readSysFile(filepath.Join(devicePath, "charge_full")); err == nil {
+	} else if fullStr, err := readString(fsys, filepath.Join(devicePath, "charge_full")); err == nil {
 		if full, err := strconv.ParseUint(strings.TrimSpace(fullStr), 10, 64); err == nil {
-			if voltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_now")); err == nil {
+			if voltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_now")); err == nil {
 				if voltage, err := strconv.ParseUint(strings.TrimSpace(voltageStr), 10, 64); err == nil {
 					battery.CapacityFull = (full * voltage) / 1000000 / 1000
 					battery.EnergyFull = battery.CapacityFull
@@ -164,14 +167,14 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 	}
 
 	// Read current capacity
-	if nowStr, err := readSysFile(filepath.Join(devicePath, "energy_now")); err == nil {
+	if nowStr, err := readString(fsys, filepath.Join(devicePath, "energy_now")); err == nil {
 		if now, err := strconv.ParseUint(strings.TrimSpace(nowStr), 10, 64); err == nil {
 			battery.CapacityNow = now / 1000
 			battery.EnergyNow = now / 1000
 		}
-	} else if nowStr, err := readSysFile(filepath.Join(devicePath, "charge_now")); err == nil {
+	} else if nowStr, err := readString(fsys, filepath.Join(devicePath, "charge_now")); err == nil {
 		if now, err := strconv.ParseUint(strings.TrimSpace(nowStr), 10, 64); err == nil {
-			if voltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_now")); err == nil {
+			if voltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_now")); err == nil {
 				if voltage, err := strconv.ParseUint(strings.TrimSpace(voltageStr), 10, 64); err == nil {
 					battery.CapacityNow = (now * voltage) / 1000000 / 1000
 					battery.EnergyNow = battery.CapacityNow
@@ -197,14 +200,14 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 	}
 
 	// Read power consumption/charge rate
-	if powerStr, err := readSysFile(filepath.Join(devicePath, "power_now")); err == nil {
+	if powerStr, err := readString(fsys, filepath.Join(devicePath, "power_now")); err == nil {
 		if power, err := strconv.ParseUint(strings.TrimSpace(powerStr), 10, 64); err == nil {
 			battery.PowerNow = power / 1000 // Convert µW to mW
 		}
-	} else if currentStr, err := readSysFile(filepath.Join(devicePath, "current_now")); err == nil {
+	} else if currentStr, err := readString(fsys, filepath.Join(devicePath, "current_now")); err == nil {
 		// Calculate power from current and voltage
 		if current, err := strconv.ParseInt(strings.TrimSpace(currentStr), 10, 64); err == nil {
-			if voltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_now")); err == nil {
+			if voltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_now")); err == nil {
 				if voltage, err := strconv.ParseUint(strings.TrimSpace(voltageStr), 10, 64); err == nil {
 					// power (µW) = abs(current (µA)) * voltage (µV) / 1000000
 					absCurrent := current
@@ -219,14 +222,14 @@ func readBatteryInfo(devicePath, name string) (types.BatteryInfo, error) {
 	}
 
 	// Read voltage
-	if voltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_now")); err == nil {
+	if voltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_now")); err == nil {
 		if voltage, err := strconv.ParseUint(strings.TrimSpace(voltageStr), 10, 64); err == nil {
 			battery.Voltage = float64(voltage) / 1000000.0 // Convert µV to V
 		}
 	}
 
 	// Read minimum voltage
-	if minVoltageStr, err := readSysFile(filepath.Join(devicePath, "voltage_min_design")); err == nil {
+	if minVoltageStr, err := readString(fsys, filepath.Join(devicePath, "voltage_min_design")); err == nil {
 		if minVoltage, err := strconv.ParseUint(strings.TrimSpace(minVoltageStr), 10, 64); err == nil {
 			battery.VoltageMin = float64(minVoltage) / 1000000.0
 		}
@@ -0,0 +1,44 @@
+//go:build linux
+
+package collector
+
+import "testing"
+
+func TestCollectBatterySysfs(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/power_supply/AC/type":                          "Mains",
+		"class/power_supply/AC/online":                        "0",
+		"class/power_supply/BAT0/type":                        "Battery",
+		"class/power_supply/BAT0/status":                      "Discharging",
+		"class/power_supply/BAT0/model_name":                  "5B10W13930",
+		"class/power_supply/BAT0/energy_full_design":          "50000000",
+		"class/power_supply/BAT0/energy_full":                 "45000000",
+		"class/power_supply/BAT0/energy_now":                  "22500000",
+		"class/power_supply/BAT0/power_now":                   "9000000",
+		"class/power_supply/ucsi-source-psy-USBC000:001/type": "USB",
+	})
+
+	data, err := collectBattery(hostReader{sysfs: root})
+	if err != nil {
+		t.Fatalf("collectBattery() = %v", err)
+	}
+	if !data.Present || !data.OnBattery || len(data.Batteries) != 1 {
+		t.Fatalf("collectBattery() = %+v, expected one battery with AC offline", data)
+	}
+
+	battery := data.Batteries[0]
+	if battery.Name != "BAT0" || battery.Model != "5B10W13930" || !battery.IsDischarging {
+		t.Errorf("battery = %+v, expected discharging BAT0", battery)
+	}
+	if battery.ChargeLevel != 50 || battery.Health != 90 || battery.TimeToEmpty != 150 {
+		t.Errorf("charge %v%%, health %v%%, %d minutes left; expected 50%%, 90%% and 150",
+			battery.ChargeLevel, battery.Health, battery.TimeToEmpty)
+	}
+
+	// Containers and VMs often have no power supplies at all
+	data, err = collectBattery(hostReader{sysfs: t.TempDir()})
+	if err != nil || data.Present {
+		t.Errorf("collectBattery() without power_supply = %+v, %v; expected no battery and no error", data, err)
+	}
+}
@@ -0,0 +1,17 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectBluetooth gathers the Bluetooth adapters with their address and firmware, and the
+// paired devices with whether they are connected and their battery level
+func CollectBluetooth() (*types.BluetoothData, error) {
+	adapters, devices := collectBluetoothPlatform()
+	if len(adapters) == 0 {
+		return nil, fmt.Errorf("no Bluetooth adapters found")
+	}
+	return &types.BluetoothData{Adapters: adapters, Devices: devices}, nil
+}
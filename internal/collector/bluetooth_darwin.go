@@ -0,0 +1,107 @@
+//go:build darwin
+
+package collector
+
+import (
+	"cmp"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// spBluetoothController is the controller in the SPBluetoothDataType report (macOS 12 and later)
+type spBluetoothController struct {
+	Address   string `json:"controller_address"`
+	Chipset   string `json:"controller_chipset"`         // e.g. BCM_4387
+	Firmware  string `json:"controller_firmwareVersion"` // e.g. v102 c4550
+	State     string `json:"controller_state"`           // attrib_on or attrib_off
+	Transport string `json:"controller_transport"`       // e.g. PCIe, UART, USB
+	VendorID  string `json:"controller_vendorID"`        // e.g. 0x004C (Apple)
+}
+
+// spBluetoothDevice is a paired device; battery levels are percentages such as "85%", with
+// separate levels for each earbud and the case of AirPods
+type spBluetoothDevice struct {
+	Address      string `json:"device_address"`
+	MinorType    string `json:"device_minorType"` // e.g. Headphones, Keyboard
+	BatteryMain  string `json:"device_batteryLevelMain"`
+	BatteryLeft  string `json:"device_batteryLevelLeft"`
+	BatteryRight string `json:"device_batteryLevelRight"`
+}
+
+func collectBluetoothPlatform() ([]types.BluetoothAdapter, []types.BluetoothDevice) {
+	out, err := sandbox.Command("system_profiler", "SPBluetoothDataType", "-json").Output()
+	if err != nil {
+		return nil, nil
+	}
+	return parseSPBluetooth(out)
+}
+
+// parseSPBluetooth reads the controller and the paired devices of a system_profiler
+// SPBluetoothDataType report, which lists them as connected and not connected, each
+// device as an object keyed by its name
+func parseSPBluetooth(output []byte) ([]types.BluetoothAdapter, []types.BluetoothDevice) {
+	var report struct {
+		Items []struct {
+			Controller   *spBluetoothController         `json:"controller_properties"`
+			Connected    []map[string]spBluetoothDevice `json:"device_connected"`
+			NotConnected []map[string]spBluetoothDevice `json:"device_not_connected"`
+		} `json:"SPBluetoothDataType"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, nil
+	}
+
+	var adapters []types.BluetoothAdapter
+	var devices []types.BluetoothDevice
+	for _, item := range report.Items {
+		if c := item.Controller; c != nil {
+			manufacturer := ""
+			if _, name, found := strings.Cut(c.VendorID, "("); found {
+				manufacturer = strings.TrimSuffix(name, ")")
+			}
+			adapters = append(adapters, types.BluetoothAdapter{
+				Name:         cmp.Or(c.Chipset, "Bluetooth"),
+				Address:      c.Address,
+				Manufacturer: manufacturer,
+				Firmware:     c.Firmware,
+				Bus:          strings.ToLower(c.Transport),
+				Powered:      c.State == "attrib_on",
+			})
+		}
+		for _, group := range []struct {
+			devices   []map[string]spBluetoothDevice
+			connected bool
+		}{{item.Connected, true}, {item.NotConnected, false}} {
+			for _, named := range group.devices {
+				for name, d := range named {
+					devices = append(devices, types.BluetoothDevice{
+						Name:           name,
+						Address:        d.Address,
+						Type:           d.MinorType,
+						Connected:      group.connected,
+						BatteryPercent: spBatteryPercent(d),
+					})
+				}
+			}
+		}
+	}
+	return adapters, devices
+}
+
+// spBatteryPercent returns a device's battery level, or for earbuds the lowest one's
+func spBatteryPercent(d spBluetoothDevice) int {
+	if level, err := strconv.Atoi(strings.TrimSuffix(d.BatteryMain, "%")); err == nil {
+		return level
+	}
+	lowest := 0
+	for _, part := range []string{d.BatteryLeft, d.BatteryRight} {
+		if level, err := strconv.Atoi(strings.TrimSuffix(part, "%")); err == nil && (lowest == 0 || level < lowest) {
+			lowest = level
+		}
+	}
+	return lowest
+}
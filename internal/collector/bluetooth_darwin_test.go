@@ -0,0 +1,59 @@
+//go:build darwin
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const spBluetoothOutput = `{"SPBluetoothDataType": [{
+  "controller_properties": {
+    "controller_address": "F8:4D:89:12:34:56",
+    "controller_chipset": "BCM_4387",
+    "controller_discoverable": "attrib_off",
+    "controller_firmwareVersion": "v102 c4550",
+    "controller_productID": "0x4387",
+    "controller_state": "attrib_on",
+    "controller_transport": "PCIe",
+    "controller_vendorID": "0x004C (Apple)"
+  },
+  "device_connected": [
+    {"AirPods Pro": {
+      "device_address": "AC:90:85:11:22:33",
+      "device_batteryLevelCase": "40%",
+      "device_batteryLevelLeft": "100%",
+      "device_batteryLevelRight": "95%",
+      "device_minorType": "Headphones"
+    }}
+  ],
+  "device_not_connected": [
+    {"Magic Keyboard": {
+      "device_address": "70:F9:4A:44:55:66",
+      "device_batteryLevelMain": "81%",
+      "device_minorType": "Keyboard"
+    }}
+  ]
+}]}`
+
+func TestParseSPBluetooth(t *testing.T) {
+	adapters, devices := parseSPBluetooth([]byte(spBluetoothOutput))
+	expectedAdapters := []types.BluetoothAdapter{
+		{Name: "BCM_4387", Address: "F8:4D:89:12:34:56", Manufacturer: "Apple", Firmware: "v102 c4550", Bus: "pcie", Powered: true},
+	}
+	expectedDevices := []types.BluetoothDevice{
+		{Name: "AirPods Pro", Address: "AC:90:85:11:22:33", Type: "Headphones", Connected: true, BatteryPercent: 95},
+		{Name: "Magic Keyboard", Address: "70:F9:4A:44:55:66", Type: "Keyboard", BatteryPercent: 81},
+	}
+	if !reflect.DeepEqual(adapters, expectedAdapters) {
+		t.Errorf("adapters = %+v, expected %+v", adapters, expectedAdapters)
+	}
+	if !reflect.DeepEqual(devices, expectedDevices) {
+		t.Errorf("devices = %+v, expected %+v", devices, expectedDevices)
+	}
+	if adapters, devices := parseSPBluetooth([]byte("not json")); adapters != nil || devices != nil {
+		t.Error("parseSPBluetooth(invalid) should return nothing")
+	}
+}
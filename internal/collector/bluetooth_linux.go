@@ -0,0 +1,228 @@
+//go:build linux
+
+package collector
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	bluetoothClassPath   = "/sys/class/bluetooth"
+	bluetoothStoragePath = "/var/lib/bluetooth"
+)
+
+var (
+	// hciconfigVersionRe matches the HCI and LMP version lines of hciconfig -a, e.g.
+	// "HCI Version: 5.2 (0xb)  Revision: 0x100"
+	hciconfigVersionRe = regexp.MustCompile(`^(HCI|LMP) Version:\s*(\S*)\s*\(0x[0-9a-f]+\)\s+(?:Revision|Subversion):\s*(0x[0-9a-f]+)`)
+	// batteryPercentageRe matches bluetoothctl's "Battery Percentage: 0x46 (70)"
+	batteryPercentageRe = regexp.MustCompile(`\((\d+)\)`)
+)
+
+// collectBluetoothPlatform lists the adapters from sysfs, with their address, version and
+// firmware from hciconfig where installed. Paired devices come from bluetoothctl, which
+// knows which are connected and their battery, or else from BlueZ's pairing storage, which
+// only root can read
+func collectBluetoothPlatform() ([]types.BluetoothAdapter, []types.BluetoothDevice) {
+	adapters := scanHCIAdapters(hostPath(bluetoothClassPath))
+	if len(adapters) == 0 {
+		return nil, nil
+	}
+	if readingHost() {
+		// The Bluetooth daemon reachable from here is not the host's
+		return adapters, storedBluetoothDevices(hostPath(bluetoothStoragePath))
+	}
+
+	if out, err := sandbox.Command("hciconfig", "-a").Output(); err == nil {
+		adapters = mergeHciconfig(adapters, string(out))
+	} else if len(adapters) == 1 {
+		// bluetoothctl only shows the default controller, without naming it
+		if out, err := sandbox.Command("bluetoothctl", "--timeout", "5", "show").Output(); err == nil {
+			address, fields := parseBluetoothctl(string(out))
+			adapters[0].Address, adapters[0].Powered = address, fields["Powered"] == "yes"
+		}
+	}
+
+	devices, ok := bluetoothctlDevices()
+	if !ok {
+		devices = storedBluetoothDevices(hostPath(bluetoothStoragePath))
+	}
+	return adapters, devices
+}
+
+// scanHCIAdapters lists the controllers below /sys/class/bluetooth, e.g. hci0, with the
+// driver and bus of the device behind each
+func scanHCIAdapters(root string) []types.BluetoothAdapter {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+	var adapters []types.BluetoothAdapter
+	for _, entry := range entries {
+		// Connections are listed as hci0:1 next to their controller
+		if !strings.HasPrefix(entry.Name(), "hci") || strings.Contains(entry.Name(), ":") {
+			continue
+		}
+		adapter := types.BluetoothAdapter{Name: entry.Name()}
+		dir := filepath.Join(root, entry.Name(), "device")
+		adapter.Driver, _ = boundDriver(dir)
+		if subsystem, err := os.Readlink(filepath.Join(dir, "subsystem")); err == nil {
+			adapter.Bus = filepath.Base(subsystem)
+		}
+		adapters = append(adapters, adapter)
+	}
+	return adapters
+}
+
+// mergeHciconfig sets the adapters' address, state, versions and manufacturer from the
+// output of hciconfig -a, one indented block per controller
+func mergeHciconfig(adapters []types.BluetoothAdapter, output string) []types.BluetoothAdapter {
+	var current *types.BluetoothAdapter
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			name, _, _ := strings.Cut(line, ":")
+			current = nil
+			for i := range adapters {
+				if adapters[i].Name == name {
+					current = &adapters[i]
+				}
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		fields := strings.Fields(trimmed)
+		switch {
+		case strings.HasPrefix(trimmed, "BD Address:") && len(fields) > 2:
+			current.Address = fields[2]
+		case len(fields) > 0 && (fields[0] == "UP" || fields[0] == "DOWN"):
+			current.Powered = fields[0] == "UP"
+		case strings.HasPrefix(trimmed, "Manufacturer:"):
+			manufacturer := strings.TrimSpace(strings.TrimPrefix(trimmed, "Manufacturer:"))
+			// Drop the company ID, e.g. "Intel Corp. (2)"
+			if i := strings.LastIndex(manufacturer, " ("); i > 0 {
+				manufacturer = manufacturer[:i]
+			}
+			current.Manufacturer = manufacturer
+		default:
+			if m := hciconfigVersionRe.FindStringSubmatch(trimmed); m != nil {
+				if m[1] == "HCI" {
+					current.Version = m[2]
+				} else {
+					current.Firmware = m[3]
+				}
+			}
+		}
+	}
+	return adapters
+}
+
+// bluetoothctlDevices lists the paired devices of the default controller with their state;
+// ok is false where bluetoothctl or the Bluetooth daemon is unavailable. Older versions
+// ignore the Paired filter, so each device's own Paired field decides
+func bluetoothctlDevices() (devices []types.BluetoothDevice, ok bool) {
+	out, err := sandbox.Command("bluetoothctl", "--timeout", "5", "devices", "Paired").Output()
+	if err != nil {
+		return nil, false
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "Device" {
+			continue
+		}
+		info, err := sandbox.Command("bluetoothctl", "--timeout", "5", "info", fields[1]).Output()
+		if err != nil {
+			continue
+		}
+		if device, paired := parseBluetoothctlDevice(string(info)); paired {
+			devices = append(devices, device)
+		}
+	}
+	return devices, true
+}
+
+// parseBluetoothctl reads the output of bluetoothctl show and info: a "Controller <address>"
+// or "Device <address>" line followed by indented "Key: value" lines. Repeated keys such
+// as UUID keep their first value
+func parseBluetoothctl(output string) (address string, fields map[string]string) {
+	fields = map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			if header := strings.Fields(line); len(header) > 1 && (header[0] == "Controller" || header[0] == "Device") {
+				address = header[1]
+			}
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if _, seen := fields[key]; found && !seen {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return address, fields
+}
+
+// parseBluetoothctlDevice reads a device from bluetoothctl info
+func parseBluetoothctlDevice(output string) (device types.BluetoothDevice, paired bool) {
+	address, fields := parseBluetoothctl(output)
+	device = types.BluetoothDevice{
+		Name:      fields["Alias"],
+		Address:   address,
+		Type:      fields["Icon"],
+		Connected: fields["Connected"] == "yes",
+	}
+	if device.Name == "" {
+		device.Name = cmp.Or(fields["Name"], address)
+	}
+	if m := batteryPercentageRe.FindStringSubmatch(fields["Battery Percentage"]); m != nil {
+		device.BatteryPercent, _ = strconv.Atoi(m[1])
+	}
+	return device, fields["Paired"] == "yes"
+}
+
+// storedBluetoothDevices reads the devices BlueZ keeps pairing keys for, in
+// <storage>/<adapter>/<device>/info, whose [General] section has the device's name
+func storedBluetoothDevices(storage string) []types.BluetoothDevice {
+	infos, err := filepath.Glob(filepath.Join(storage, "*", "*", "info"))
+	if err != nil {
+		return nil
+	}
+	var devices []types.BluetoothDevice
+	for _, info := range infos {
+		data, err := os.ReadFile(info)
+		if err != nil {
+			continue
+		}
+		address := filepath.Base(filepath.Dir(info))
+		device := types.BluetoothDevice{Name: address, Address: address}
+		section := ""
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "[") {
+				section = strings.Trim(line, "[]")
+				continue
+			}
+			if key, value, found := strings.Cut(line, "="); found && section == "General" && key == "Name" && value != "" {
+				device.Name = value
+			}
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
@@ -0,0 +1,116 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const hciconfigOutput = `hci0:	Type: Primary  Bus: USB
+	BD Address: 00:1A:7D:DA:71:13  ACL MTU: 1021:4  SCO MTU: 96:6
+	UP RUNNING PSCAN ISCAN 
+	RX bytes:18841 acl:0 sco:0 events:1416 errors:0
+	TX bytes:56432 acl:0 sco:0 commands:1367 errors:0
+	Features: 0xbf 0xfe 0x0f 0xfe 0xdb 0xff 0x7b 0x87
+	Name: 'workstation'
+	Class: 0x6c010c
+	HCI Version: 5.2 (0xb)  Revision: 0x100
+	LMP Version: 5.2 (0xb)  Subversion: 0x1e0b
+	Manufacturer: Intel Corp. (2)
+
+hci1:	Type: Primary  Bus: UART
+	BD Address: 00:00:00:00:00:00  ACL MTU: 0:0  SCO MTU: 0:0
+	DOWN 
+`
+
+const bluetoothctlInfo = `Device 38:18:4C:1A:2B:3C (public)
+	Name: WH-1000XM4
+	Alias: Headphones
+	Class: 0x00240404
+	Icon: audio-headset
+	Paired: yes
+	Bonded: yes
+	Trusted: yes
+	Blocked: no
+	Connected: yes
+	UUID: Vendor specific           (00000000-deca-fade-deca-deafdecacaff)
+	UUID: Headset                   (00001108-0000-1000-8000-00805f9b34fb)
+	Battery Percentage: 0x46 (70)
+`
+
+func TestScanHCIAdapters(t *testing.T) {
+	root := t.TempDir()
+	device := filepath.Join(root, "hci0", "device")
+	if err := os.MkdirAll(device, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for link, target := range map[string]string{"driver": "../../bus/usb/drivers/btusb", "subsystem": "../../bus/usb"} {
+		if err := os.Symlink(target, filepath.Join(device, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Connections are listed next to their controller
+	if err := os.MkdirAll(filepath.Join(root, "hci0:256"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	adapters := scanHCIAdapters(root)
+	expected := []types.BluetoothAdapter{{Name: "hci0", Driver: "btusb", Bus: "usb"}}
+	if !reflect.DeepEqual(adapters, expected) {
+		t.Errorf("scanHCIAdapters() = %+v, expected %+v", adapters, expected)
+	}
+}
+
+func TestMergeHciconfig(t *testing.T) {
+	adapters := mergeHciconfig([]types.BluetoothAdapter{{Name: "hci0", Driver: "btusb", Bus: "usb"}, {Name: "hci1"}}, hciconfigOutput)
+	expected := []types.BluetoothAdapter{
+		{Name: "hci0", Address: "00:1A:7D:DA:71:13", Manufacturer: "Intel Corp.", Version: "5.2", Firmware: "0x1e0b", Driver: "btusb", Bus: "usb", Powered: true},
+		{Name: "hci1", Address: "00:00:00:00:00:00"},
+	}
+	if !reflect.DeepEqual(adapters, expected) {
+		t.Errorf("mergeHciconfig() =\n%+v\nexpected\n%+v", adapters, expected)
+	}
+}
+
+func TestParseBluetoothctlDevice(t *testing.T) {
+	device, paired := parseBluetoothctlDevice(bluetoothctlInfo)
+	expected := types.BluetoothDevice{Name: "Headphones", Address: "38:18:4C:1A:2B:3C", Type: "audio-headset", Connected: true, BatteryPercent: 70}
+	if !paired || device != expected {
+		t.Errorf("parseBluetoothctlDevice() = %+v, %v; expected %+v, true", device, paired, expected)
+	}
+
+	// Devices seen in a scan but never paired are listed by older bluetoothctl versions
+	if _, paired := parseBluetoothctlDevice("Device 11:22:33:44:55:66 (random)\n\tName: Beacon\n\tPaired: no\n\tConnected: no\n"); paired {
+		t.Error("parseBluetoothctlDevice() reported an unpaired device as paired")
+	}
+}
+
+func TestStoredBluetoothDevices(t *testing.T) {
+	storage := t.TempDir()
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	adapter := filepath.Join(storage, "00:1A:7D:DA:71:13")
+	write(filepath.Join(adapter, "settings"), "[General]\nDiscoverable=false\n")
+	write(filepath.Join(adapter, "38:18:4C:1A:2B:3C", "info"), "[General]\nName=WH-1000XM4\nClass=0x240404\n\n[LinkKey]\nKey=0123456789ABCDEF\n")
+	write(filepath.Join(adapter, "C8:2A:DD:00:11:22", "info"), "[LinkKey]\nName=not this\n")
+
+	devices := storedBluetoothDevices(storage)
+	expected := []types.BluetoothDevice{
+		{Name: "WH-1000XM4", Address: "38:18:4C:1A:2B:3C"},
+		{Name: "C8:2A:DD:00:11:22", Address: "C8:2A:DD:00:11:22"},
+	}
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("storedBluetoothDevices() = %+v, expected %+v", devices, expected)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows/registry"
+)
+
+// pnpBluetoothEntity is a device of the Bluetooth class: a radio, a paired device, or one
+// of the service nodes Windows creates below a device
+type pnpBluetoothEntity struct {
+	Name                   string
+	Manufacturer           string
+	DeviceID               string // e.g. USB\VID_8087&PID_0026\5&..., or BTHENUM\DEV_001A7DDA7113\7&... for a paired device
+	Service                string
+	ConfigManagerErrorCode uint32 // 45 for paired devices that are not connected
+}
+
+// pnpBluetoothBuses names the enumerators of Bluetooth radios' PnP IDs
+var pnpBluetoothBuses = map[string]string{
+	"USB":  "usb",
+	"PCI":  "pci",
+	"ACPI": "acpi",
+}
+
+// bthportKeysPath holds a subkey per adapter address with the link keys of its paired devices
+const bthportKeysPath = `SYSTEM\CurrentControlSet\Services\BTHPORT\Parameters\Keys`
+
+// collectBluetoothPlatform lists the radios and paired devices of the Bluetooth PnP class.
+// Windows keeps a device node for each paired device, BTHENUM\DEV_<address> for classic
+// and BTHLE\DEV_<address> for Low Energy devices, which is only started while the device
+// is connected. Battery levels are not available through WMI
+func collectBluetoothPlatform() ([]types.BluetoothAdapter, []types.BluetoothDevice) {
+	var entities []pnpBluetoothEntity
+	query := "SELECT Name, Manufacturer, DeviceID, Service, ConfigManagerErrorCode FROM Win32_PnPEntity WHERE PNPClass = 'Bluetooth'"
+	if err := wmi.Query(query, &entities); err != nil {
+		return nil, nil
+	}
+	adapters, devices := pnpBluetooth(entities)
+
+	// The adapter's own address is only known from its pairing keys, so with one of each
+	if key, err := registry.OpenKey(registry.LOCAL_MACHINE, bthportKeysPath, registry.ENUMERATE_SUB_KEYS); err == nil {
+		defer key.Close()
+		if addresses, err := key.ReadSubKeyNames(-1); err == nil && len(addresses) == 1 && len(adapters) == 1 {
+			adapters[0].Address = bluetoothAddress(addresses[0])
+		}
+	}
+	return adapters, devices
+}
+
+// pnpBluetooth splits the Bluetooth class into radios, by the bus they are enumerated on,
+// and paired devices, by their BTHENUM\DEV_ or BTHLE\DEV_ ID; service nodes are left out
+func pnpBluetooth(entities []pnpBluetoothEntity) ([]types.BluetoothAdapter, []types.BluetoothDevice) {
+	var adapters []types.BluetoothAdapter
+	var devices []types.BluetoothDevice
+	for _, entity := range entities {
+		id := strings.ToUpper(entity.DeviceID)
+		enumerator, rest, _ := strings.Cut(id, `\`)
+		if bus, ok := pnpBluetoothBuses[enumerator]; ok {
+			adapter := types.BluetoothAdapter{
+				Name:         strings.TrimSpace(entity.Name),
+				Manufacturer: strings.TrimSpace(entity.Manufacturer),
+				Driver:       entity.Service,
+				Bus:          bus,
+				Powered:      entity.ConfigManagerErrorCode == 0,
+			}
+			// Generic names Windows gives vendors it has no INF for
+			if strings.HasPrefix(adapter.Manufacturer, "(") {
+				adapter.Manufacturer = ""
+			}
+			adapters = append(adapters, adapter)
+			continue
+		}
+		if enumerator != "BTHENUM" && enumerator != "BTHLE" {
+			continue
+		}
+		address, ok := strings.CutPrefix(rest, "DEV_")
+		if !ok {
+			continue
+		}
+		address, _, _ = strings.Cut(address, `\`)
+		devices = append(devices, types.BluetoothDevice{
+			Name:      strings.TrimSpace(entity.Name),
+			Address:   bluetoothAddress(address),
+			Connected: entity.ConfigManagerErrorCode == 0,
+		})
+	}
+	return adapters, devices
+}
+
+// bluetoothAddress writes a 12 digit address as Windows stores it, e.g. 001a7dda7113, the
+// usual way: 00:1A:7D:DA:71:13
+func bluetoothAddress(hex string) string {
+	if len(hex) != 12 {
+		return hex
+	}
+	hex = strings.ToUpper(hex)
+	parts := make([]string, 6)
+	for i := range parts {
+		parts[i] = hex[2*i : 2*i+2]
+	}
+	return strings.Join(parts, ":")
+}
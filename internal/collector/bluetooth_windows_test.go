@@ -0,0 +1,42 @@
+//go:build windows
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestPnPBluetooth(t *testing.T) {
+	adapters, devices := pnpBluetooth([]pnpBluetoothEntity{
+		{Name: "Intel(R) Wireless Bluetooth(R)", Manufacturer: "Intel Corporation", DeviceID: `USB\VID_8087&PID_0026\5&2F1A3B&0&10`, Service: "BTHUSB"},
+		{Name: "Microsoft Bluetooth Enumerator", Manufacturer: "Microsoft", DeviceID: `BTH\MS_BTHBRB\7&1C2D3E&0&1`, Service: "BthEnum"},
+		{Name: "WH-1000XM4", Manufacturer: "Microsoft", DeviceID: `BTHENUM\DEV_38184C1A2B3C\7&1C2D3E&0&BLUETOOTHDEVICE_38184C1A2B3C`, Service: "BthEnum"},
+		{Name: "WH-1000XM4 Avrcp Transport", DeviceID: `BTHENUM\{0000110E-0000-1000-8000-00805F9B34FB}_LOCALMFG&0002\8&2A&0&38184C1A2B3C_C00000000`},
+		{Name: "MX Master 3", DeviceID: `BTHLE\DEV_C82ADD001122\7&3D4E5F&0&C82ADD001122`, ConfigManagerErrorCode: 45},
+	})
+	expectedAdapters := []types.BluetoothAdapter{
+		{Name: "Intel(R) Wireless Bluetooth(R)", Manufacturer: "Intel Corporation", Driver: "BTHUSB", Bus: "usb", Powered: true},
+	}
+	expectedDevices := []types.BluetoothDevice{
+		{Name: "WH-1000XM4", Address: "38:18:4C:1A:2B:3C", Connected: true},
+		{Name: "MX Master 3", Address: "C8:2A:DD:00:11:22"},
+	}
+	if !reflect.DeepEqual(adapters, expectedAdapters) {
+		t.Errorf("adapters = %+v, expected %+v", adapters, expectedAdapters)
+	}
+	if !reflect.DeepEqual(devices, expectedDevices) {
+		t.Errorf("devices = %+v, expected %+v", devices, expectedDevices)
+	}
+}
+
+func TestBluetoothAddress(t *testing.T) {
+	if address := bluetoothAddress("001a7dda7113"); address != "00:1A:7D:DA:71:13" {
+		t.Errorf("bluetoothAddress() = %q, expected 00:1A:7D:DA:71:13", address)
+	}
+	if address := bluetoothAddress("short"); address != "short" {
+		t.Errorf("bluetoothAddress(short) = %q, expected it unchanged", address)
+	}
+}
@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smbiosChassisTypes names the SMBIOS enclosure type codes, which Linux sysfs and
+// Win32_SystemEnclosure both report as numbers
+var smbiosChassisTypes = map[int]string{
+	1: "Other", 2: "Unknown", 3: "Desktop", 4: "Low Profile Desktop", 5: "Pizza Box",
+	6: "Mini Tower", 7: "Tower", 8: "Portable", 9: "Laptop", 10: "Notebook",
+	11: "Hand Held", 12: "Docking Station", 13: "All in One", 14: "Sub Notebook",
+	15: "Space-saving", 16: "Lunch Box", 17: "Main Server Chassis", 18: "Expansion Chassis",
+	19: "Sub Chassis", 20: "Bus Expansion Chassis", 21: "Peripheral Chassis", 22: "RAID Chassis",
+	23: "Rack Mount Chassis", 24: "Sealed-case PC", 25: "Multi-system Chassis", 26: "Compact PCI",
+	27: "Advanced TCA", 28: "Blade", 29: "Blade Enclosure", 30: "Tablet", 31: "Convertible",
+	32: "Detachable", 33: "IoT Gateway", 34: "Embedded PC", 35: "Mini PC", 36: "Stick PC",
+}
+
+// dmiPlaceholders are values firmware ships in place of a real one
+var dmiPlaceholders = map[string]bool{
+	"to be filled by o.e.m.": true, "default string": true, "not specified": true,
+	"not applicable": true, "not available": true, "n/a": true, "none": true, "invalid": true,
+	"system manufacturer": true, "system product name": true, "system version": true,
+	"system serial number": true, "base board serial number": true, "0123456789": true,
+	"00000000-0000-0000-0000-000000000000": true, "ffffffff-ffff-ffff-ffff-ffffffffffff": true,
+	"03000200-0400-0500-0006-000700080009": true, // Shipped by many AMI boards
+}
+
+// CollectBaseboard gathers the system, motherboard, firmware and chassis identity
+func CollectBaseboard() (*types.BoardData, error) {
+	data := collectBaseboardPlatform()
+	if data == nil || *data == (types.BoardData{}) {
+		return nil, fmt.Errorf("no DMI/SMBIOS information found")
+	}
+	return data, nil
+}
+
+// dmiValue trims a DMI string, returning "" for the placeholders firmware leaves unset
+func dmiValue(value string) string {
+	value = strings.TrimSpace(value)
+	if dmiPlaceholders[strings.ToLower(value)] {
+		return ""
+	}
+	return value
+}
+
+// chassisTypeName names an SMBIOS chassis type code; the top bit flags a chassis lock
+func chassisTypeName(code int) string {
+	if name, ok := smbiosChassisTypes[code&0x7f]; ok {
+		return name
+	}
+	return ""
+}
+
+// biosDate converts the firmware's release date, MM/DD/YYYY as SMBIOS stores it (MM/DD/YY
+// before SMBIOS 2.3), to YYYY-MM-DD. Anything else is kept as reported
+func biosDate(date string) string {
+	date = strings.TrimSpace(date)
+	var month, day, year int
+	if n, err := fmt.Sscanf(date, "%d/%d/%d", &month, &day, &year); err != nil || n != 3 {
+		return date
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return date
+	}
+	if year < 100 {
+		year += 1900
+		if year < 1980 {
+			year += 100
+		}
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
@@ -0,0 +1,53 @@
+//go:build darwin
+
+package collector
+
+import (
+	"encoding/json"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// spHardwareReport is the part of `system_profiler SPHardwareDataType -json` naming the Mac.
+// Macs have no separate baseboard or SMBIOS, so the model stands for both
+type spHardwareReport struct {
+	Hardware []struct {
+		MachineName    string `json:"machine_name"`
+		MachineModel   string `json:"machine_model"`
+		ModelNumber    string `json:"model_number"`
+		SerialNumber   string `json:"serial_number"`
+		PlatformUUID   string `json:"platform_UUID"`
+		BootROMVersion string `json:"boot_rom_version"`
+	} `json:"SPHardwareDataType"`
+}
+
+func collectBaseboardPlatform() *types.BoardData {
+	out, err := sandbox.Command("system_profiler", "SPHardwareDataType", "-json").Output()
+	if err != nil {
+		return nil
+	}
+	return parseSPHardware(out)
+}
+
+// parseSPHardware maps the hardware overview onto the board report; the boot ROM version
+// is the firmware (iBoot on Apple silicon) version
+func parseSPHardware(output []byte) *types.BoardData {
+	var report spHardwareReport
+	if err := json.Unmarshal(output, &report); err != nil || len(report.Hardware) == 0 {
+		return nil
+	}
+	hw := report.Hardware[0]
+	return &types.BoardData{
+		SystemVendor:  "Apple Inc.",
+		SystemProduct: dmiValue(hw.MachineName),
+		SystemVersion: dmiValue(hw.MachineModel),
+		SystemSerial:  dmiValue(hw.SerialNumber),
+		UUID:          dmiValue(hw.PlatformUUID),
+		Vendor:        "Apple Inc.",
+		Product:       dmiValue(hw.MachineModel),
+		Version:       dmiValue(hw.ModelNumber),
+		BIOSVendor:    "Apple Inc.",
+		BIOSVersion:   dmiValue(hw.BootROMVersion),
+	}
+}
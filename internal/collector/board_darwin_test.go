@@ -0,0 +1,39 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+func TestParseSPHardware(t *testing.T) {
+	output := []byte(`{
+  "SPHardwareDataType" : [
+    {
+      "_name" : "hardware_overview",
+      "boot_rom_version" : "10151.121.1",
+      "chip_type" : "Apple M2 Pro",
+      "machine_model" : "Mac14,10",
+      "machine_name" : "MacBook Pro",
+      "model_number" : "MPHE3LL/A",
+      "platform_UUID" : "6A2C3F0E-1B7D-5E44-9F3A-2C8D1E0B7A55",
+      "serial_number" : "C02XK1ABCDEF"
+    }
+  ]
+}`)
+	data := parseSPHardware(output)
+	if data == nil {
+		t.Fatal("parseSPHardware() = nil")
+	}
+	if data.SystemProduct != "MacBook Pro" || data.Product != "Mac14,10" || data.Version != "MPHE3LL/A" {
+		t.Errorf("model = %q/%q/%q", data.SystemProduct, data.Product, data.Version)
+	}
+	if data.SystemSerial != "C02XK1ABCDEF" || data.UUID != "6A2C3F0E-1B7D-5E44-9F3A-2C8D1E0B7A55" {
+		t.Errorf("identity = %q/%q", data.SystemSerial, data.UUID)
+	}
+	if data.BIOSVersion != "10151.121.1" {
+		t.Errorf("BIOSVersion = %q", data.BIOSVersion)
+	}
+
+	if parseSPHardware([]byte(`{"SPHardwareDataType": []}`)) != nil {
+		t.Error("parseSPHardware() with no hardware should be nil")
+	}
+}
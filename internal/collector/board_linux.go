@@ -0,0 +1,100 @@
+//go:build linux
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const dmiClassPath = "/sys/class/dmi/id"
+
+// collectBaseboardPlatform reads the DMI tables the kernel exports in sysfs, then asks
+// dmidecode for what sysfs lacks: serials and the UUID are root-only there, and some
+// kernels and ARM boards export no DMI attributes at all
+func collectBaseboardPlatform() *types.BoardData {
+	data := readDMISysfs(hostfs, dmiClassPath)
+	if boardIncomplete(data) {
+		if out, err := sandbox.Command("dmidecode", "-t", "0,1,2,3", "-q").Output(); err == nil {
+			parseDmidecodeBoard(string(out), data)
+		}
+	}
+	return data
+}
+
+// readDMISysfs reads the DMI attributes below dir; unreadable ones are left empty
+func readDMISysfs(fsys fsReader, dir string) *types.BoardData {
+	read := func(name string) string {
+		value, err := readString(fsys, dir+"/"+name)
+		if err != nil {
+			return ""
+		}
+		return dmiValue(value)
+	}
+	data := &types.BoardData{
+		SystemVendor:  read("sys_vendor"),
+		SystemProduct: read("product_name"),
+		SystemVersion: read("product_version"),
+		SystemSerial:  read("product_serial"),
+		UUID:          read("product_uuid"),
+		Vendor:        read("board_vendor"),
+		Product:       read("board_name"),
+		Version:       read("board_version"),
+		Serial:        read("board_serial"),
+		BIOSVendor:    read("bios_vendor"),
+		BIOSVersion:   read("bios_version"),
+		BIOSDate:      biosDate(read("bios_date")),
+	}
+	if code, err := strconv.Atoi(read("chassis_type")); err == nil {
+		data.ChassisType = chassisTypeName(code)
+	}
+	return data
+}
+
+// boardIncomplete reports whether dmidecode could add anything sysfs left out
+func boardIncomplete(data *types.BoardData) bool {
+	return data.SystemVendor == "" || data.SystemSerial == "" || data.UUID == "" || data.Serial == ""
+}
+
+// parseDmidecodeBoard fills fields still empty from `dmidecode -q` output, whose sections
+// start with an unindented title followed by tab-indented "Key: Value" lines
+func parseDmidecodeBoard(output string, data *types.BoardData) {
+	fields := map[string]map[string]*string{
+		"BIOS Information": {
+			"Vendor": &data.BIOSVendor, "Version": &data.BIOSVersion, "Release Date": &data.BIOSDate,
+		},
+		"System Information": {
+			"Manufacturer": &data.SystemVendor, "Product Name": &data.SystemProduct, "Version": &data.SystemVersion,
+			"Serial Number": &data.SystemSerial, "UUID": &data.UUID,
+		},
+		"Base Board Information": {
+			"Manufacturer": &data.Vendor, "Product Name": &data.Product, "Version": &data.Version,
+			"Serial Number": &data.Serial,
+		},
+		"Chassis Information": {"Type": &data.ChassisType},
+	}
+
+	var section map[string]*string
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "\t") {
+			section = fields[strings.TrimSpace(line)]
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found || section == nil {
+			continue
+		}
+		if field, ok := section[key]; ok && *field == "" {
+			*field = dmiValue(value)
+			if field == &data.BIOSDate {
+				*field = biosDate(*field)
+			}
+		}
+	}
+}
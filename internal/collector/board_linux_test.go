@@ -0,0 +1,79 @@
+//go:build linux
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestReadDMISysfs(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/dmi/id/sys_vendor":      "LENOVO",
+		"class/dmi/id/product_name":    "20XW0026US",
+		"class/dmi/id/product_version": "ThinkPad X1 Carbon Gen 9",
+		"class/dmi/id/board_vendor":    "LENOVO",
+		"class/dmi/id/board_name":      "20XW0026US",
+		"class/dmi/id/board_version":   "Default string",
+		"class/dmi/id/bios_vendor":     "LENOVO",
+		"class/dmi/id/bios_version":    "N32ET75W (1.51 )",
+		"class/dmi/id/bios_date":       "11/25/2021",
+		"class/dmi/id/chassis_type":    "10",
+	})
+
+	data := readDMISysfs(hostReader{sysfs: root}, dmiClassPath)
+	if data.SystemVendor != "LENOVO" || data.SystemProduct != "20XW0026US" || data.SystemVersion != "ThinkPad X1 Carbon Gen 9" {
+		t.Errorf("system = %+v", data)
+	}
+	if data.Version != "" {
+		t.Errorf("Version = %q, expected the placeholder dropped", data.Version)
+	}
+	if data.BIOSVersion != "N32ET75W (1.51 )" || data.BIOSDate != "2021-11-25" || data.ChassisType != "Notebook" {
+		t.Errorf("firmware and chassis = %q %q %q", data.BIOSVersion, data.BIOSDate, data.ChassisType)
+	}
+	// Serials and the UUID are root-only and absent here
+	if !boardIncomplete(data) {
+		t.Error("boardIncomplete() = false without serials")
+	}
+}
+
+func TestParseDmidecodeBoard(t *testing.T) {
+	output := "BIOS Information\n" +
+		"\tVendor: Dell Inc.\n" +
+		"\tVersion: 2.19.1\n" +
+		"\tRelease Date: 06/13/2023\n" +
+		"\n" +
+		"System Information\n" +
+		"\tManufacturer: Dell Inc.\n" +
+		"\tProduct Name: PowerEdge R740\n" +
+		"\tSerial Number: 7XK2Q93\n" +
+		"\tUUID: 4c4c4544-0058-4b10-8032-b7c04f513933\n" +
+		"\n" +
+		"Base Board Information\n" +
+		"\tManufacturer: Dell Inc.\n" +
+		"\tProduct Name: 06WXJT\n" +
+		"\tVersion: A01\n" +
+		"\tSerial Number: .7XK2Q93.CNCMS0097L00E4.\n" +
+		"\n" +
+		"Chassis Information\n" +
+		"\tManufacturer: Dell Inc.\n" +
+		"\tType: Rack Mount Chassis\n" +
+		"\tSerial Number: 7XK2Q93\n"
+
+	// Values sysfs already read are kept
+	data := &types.BoardData{SystemVendor: "Dell Inc.", BIOSVersion: "2.19.1"}
+	parseDmidecodeBoard(output, data)
+
+	want := types.BoardData{
+		SystemVendor: "Dell Inc.", SystemProduct: "PowerEdge R740", SystemSerial: "7XK2Q93",
+		UUID:   "4c4c4544-0058-4b10-8032-b7c04f513933",
+		Vendor: "Dell Inc.", Product: "06WXJT", Version: "A01", Serial: ".7XK2Q93.CNCMS0097L00E4.",
+		BIOSVendor: "Dell Inc.", BIOSVersion: "2.19.1", BIOSDate: "2023-06-13",
+		ChassisType: "Rack Mount Chassis",
+	}
+	if *data != want {
+		t.Errorf("parseDmidecodeBoard() = %+v\nexpected %+v", *data, want)
+	}
+}
@@ -0,0 +1,44 @@
+package collector
+
+import "testing"
+
+func TestBIOSDate(t *testing.T) {
+	tests := map[string]string{
+		"11/25/2021":  "2021-11-25",
+		"6/3/2019":    "2019-06-03",
+		"04/02/98":    "1998-04-02",
+		"01/15/05":    "2005-01-15",
+		"2023-05-12":  "2023-05-12", // Already ISO
+		"13/45/2020":  "13/45/2020",
+		" 07/01/2024": "2024-07-01",
+	}
+	for input, want := range tests {
+		if got := biosDate(input); got != want {
+			t.Errorf("biosDate(%q) = %q, expected %q", input, got, want)
+		}
+	}
+}
+
+func TestDMIValue(t *testing.T) {
+	for _, placeholder := range []string{"To Be Filled By O.E.M.", "Default string", " Not Specified ", "System Serial Number", "03000200-0400-0500-0006-000700080009"} {
+		if got := dmiValue(placeholder); got != "" {
+			t.Errorf("dmiValue(%q) = %q, expected the placeholder dropped", placeholder, got)
+		}
+	}
+	if got := dmiValue(" ASUSTeK COMPUTER INC.\n"); got != "ASUSTeK COMPUTER INC." {
+		t.Errorf("dmiValue() = %q", got)
+	}
+}
+
+func TestChassisTypeName(t *testing.T) {
+	if got := chassisTypeName(23); got != "Rack Mount Chassis" {
+		t.Errorf("chassisTypeName(23) = %q", got)
+	}
+	// The top bit flags a lock on the chassis
+	if got := chassisTypeName(0x80 | 3); got != "Desktop" {
+		t.Errorf("chassisTypeName(0x83) = %q", got)
+	}
+	if got := chassisTypeName(99); got != "" {
+		t.Errorf("chassisTypeName(99) = %q, expected empty", got)
+	}
+}
@@ -0,0 +1,86 @@
+//go:build windows
+
+package collector
+
+import (
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Win32_BaseBoard is the motherboard, from the SMBIOS type 2 structure
+type Win32_BaseBoard struct {
+	Manufacturer string
+	Product      string
+	Version      string
+	SerialNumber string
+}
+
+// Win32_BIOS is the system firmware, from the SMBIOS type 0 structure
+type Win32_BIOS struct {
+	Manufacturer      string
+	SMBIOSBIOSVersion string
+	ReleaseDate       time.Time
+}
+
+// Win32_ComputerSystemProduct is the system as a whole, from the SMBIOS type 1 structure
+type Win32_ComputerSystemProduct struct {
+	Vendor            string
+	Name              string
+	Version           string
+	IdentifyingNumber string
+	UUID              string
+}
+
+// Win32_SystemEnclosure is the chassis, from the SMBIOS type 3 structure
+type Win32_SystemEnclosure struct {
+	ChassisTypes []uint16
+}
+
+// collectBaseboardPlatform reads the SMBIOS tables Windows publishes through WMI; a class
+// that fails to query leaves its fields empty
+func collectBaseboardPlatform() *types.BoardData {
+	var boards []Win32_BaseBoard
+	_ = wmi.Query("SELECT Manufacturer, Product, Version, SerialNumber FROM Win32_BaseBoard", &boards)
+	var bios []Win32_BIOS
+	_ = wmi.Query("SELECT Manufacturer, SMBIOSBIOSVersion, ReleaseDate FROM Win32_BIOS", &bios)
+	var products []Win32_ComputerSystemProduct
+	_ = wmi.Query("SELECT Vendor, Name, Version, IdentifyingNumber, UUID FROM Win32_ComputerSystemProduct", &products)
+	var enclosures []Win32_SystemEnclosure
+	_ = wmi.Query("SELECT ChassisTypes FROM Win32_SystemEnclosure", &enclosures)
+	return buildBoardData(boards, bios, products, enclosures)
+}
+
+// buildBoardData combines the first instance of each class into the board report
+func buildBoardData(boards []Win32_BaseBoard, bios []Win32_BIOS, products []Win32_ComputerSystemProduct, enclosures []Win32_SystemEnclosure) *types.BoardData {
+	data := &types.BoardData{}
+	if len(products) > 0 {
+		p := products[0]
+		data.SystemVendor = dmiValue(p.Vendor)
+		data.SystemProduct = dmiValue(p.Name)
+		data.SystemVersion = dmiValue(p.Version)
+		data.SystemSerial = dmiValue(p.IdentifyingNumber)
+		data.UUID = dmiValue(p.UUID)
+	}
+	if len(boards) > 0 {
+		b := boards[0]
+		data.Vendor = dmiValue(b.Manufacturer)
+		data.Product = dmiValue(b.Product)
+		data.Version = dmiValue(b.Version)
+		data.Serial = dmiValue(b.SerialNumber)
+	}
+	if len(bios) > 0 {
+		b := bios[0]
+		data.BIOSVendor = dmiValue(b.Manufacturer)
+		data.BIOSVersion = dmiValue(b.SMBIOSBIOSVersion)
+		if !b.ReleaseDate.IsZero() {
+			data.BIOSDate = b.ReleaseDate.Format("2006-01-02")
+		}
+	}
+	// Unknown (2) says no more than leaving the type out
+	if len(enclosures) > 0 && len(enclosures[0].ChassisTypes) > 0 && enclosures[0].ChassisTypes[0] != 2 {
+		data.ChassisType = chassisTypeName(int(enclosures[0].ChassisTypes[0]))
+	}
+	return data
+}
@@ -0,0 +1,37 @@
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestBuildBoardData(t *testing.T) {
+	data := buildBoardData(
+		[]Win32_BaseBoard{{Manufacturer: "ASUSTeK COMPUTER INC.", Product: "PRIME X570-PRO", Version: "Rev X.0x", SerialNumber: "Default string"}},
+		[]Win32_BIOS{{Manufacturer: "American Megatrends Inc.", SMBIOSBIOSVersion: "4021", ReleaseDate: time.Date(2022, 8, 9, 0, 0, 0, 0, time.UTC)}},
+		[]Win32_ComputerSystemProduct{{Vendor: "System manufacturer", Name: "System Product Name", IdentifyingNumber: "MXQ1234567", UUID: "4C4C4544-0042-3510-8052-B7C04F4B3232"}},
+		[]Win32_SystemEnclosure{{ChassisTypes: []uint16{3}}},
+	)
+	expected := types.BoardData{
+		SystemSerial: "MXQ1234567",
+		UUID:         "4C4C4544-0042-3510-8052-B7C04F4B3232",
+		Vendor:       "ASUSTeK COMPUTER INC.",
+		Product:      "PRIME X570-PRO",
+		Version:      "Rev X.0x",
+		BIOSVendor:   "American Megatrends Inc.",
+		BIOSVersion:  "4021",
+		BIOSDate:     "2022-08-09",
+		ChassisType:  "Desktop",
+	}
+	if *data != expected {
+		t.Errorf("buildBoardData() = %+v, expected %+v", *data, expected)
+	}
+
+	if empty := buildBoardData(nil, nil, nil, []Win32_SystemEnclosure{{ChassisTypes: []uint16{2}}}); *empty != (types.BoardData{}) {
+		t.Errorf("buildBoardData() with nothing known = %+v, expected empty", *empty)
+	}
+}
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+// deviceScanTTL bounds how long a device scan is reused before rescanning
+// Long-running modes otherwise spawn a scan on every collection; new drives appear within one TTL
+const deviceScanTTL = 5 * time.Minute
+
+// scanCache reuses the result of an expensive device scan
+type scanCache struct {
+	mu      sync.Mutex
+	devices []string
+	expires time.Time
+}
+
+// smartDeviceScan caches the SMART device list between collections
+var smartDeviceScan scanCache
+
+// get returns the cached devices, running scan when the cache is empty or expired
+func (c *scanCache) get(scan func() []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.devices == nil || now.After(c.expires) {
+		c.devices = scan()
+		c.expires = now.Add(deviceScanTTL)
+	}
+
+	devices := make([]string, len(c.devices))
+	copy(devices, c.devices)
+	return devices
+}
@@ -0,0 +1,32 @@
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScanCacheReusesResult(t *testing.T) {
+	var cache scanCache
+	scans := 0
+	scan := func() []string {
+		scans++
+		return []string{"/dev/sda", "/dev/nvme0"}
+	}
+
+	first := cache.get(scan)
+	first[0] = "modified"
+	second := cache.get(scan)
+
+	if scans != 1 {
+		t.Errorf("scans = %d, expected 1", scans)
+	}
+	if second[0] != "/dev/sda" {
+		t.Errorf("second[0] = %s, expected /dev/sda (callers must not share the cached slice)", second[0])
+	}
+
+	cache.expires = time.Now().Add(-time.Second)
+	cache.get(scan)
+	if scans != 2 {
+		t.Errorf("scans after expiry = %d, expected 2", scans)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/mayvqt/sysinfo/internal/analyzer"
 	"github.com/mayvqt/sysinfo/internal/config"
 	"github.com/mayvqt/sysinfo/internal/types"
 )
@@ -17,16 +18,40 @@ func Collect(cfg *config.Config) (*types.SystemInfo, error) {
 
 	var err error
 
+	// Minimal Windows installations lack the subsystems some modules read
+	unavailable := unavailableModules(collectInstallationTypePlatform())
+	shouldCollect := func(module string) bool {
+		if !cfg.ShouldCollect(module) {
+			return false
+		}
+		if reason, ok := unavailable[module]; ok {
+			if cfg.Verbose {
+				fmt.Fprintf(os.Stderr, "Skipping %s: %s\n", module, reason)
+			}
+			info.Errors = append(info.Errors, types.CollectionError{Module: module, Error: reason})
+			return false
+		}
+		return true
+	}
+
 	// Collect system information
-	if cfg.ShouldCollect("system") {
+	if shouldCollect("system") {
 		info.System, err = CollectSystem()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting system info: %v\n", err)
 		}
 	}
 
+	// Collect motherboard, firmware and chassis information
+	if shouldCollect("baseboard") {
+		info.Baseboard, err = CollectBaseboard()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting baseboard info: %v\n", err)
+		}
+	}
+
 	// Collect CPU information
-	if cfg.ShouldCollect("cpu") {
+	if shouldCollect("cpu") {
 		info.CPU, err = CollectCPU()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting CPU info: %v\n", err)
@@ -34,7 +59,7 @@ func Collect(cfg *config.Config) (*types.SystemInfo, error) {
 	}
 
 	// Collect memory information
-	if cfg.ShouldCollect("memory") {
+	if shouldCollect("memory") {
 		info.Memory, err = CollectMemory()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting memory info: %v\n", err)
@@ -43,7 +68,7 @@ func Collect(cfg *config.Config) (*types.SystemInfo, error) {
 
 	// Collect disk information
 	// Note: If SMART is requested, we need to collect disk data to include SMART info
-	if cfg.ShouldCollect("disk") || cfg.ShouldCollect("smart") {
+	if shouldCollect("disk") || shouldCollect("smart") {
 		info.Disk, err = CollectDisk(cfg.ShouldCollect("smart"))
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting disk info: %v\n", err)
@@ -51,7 +76,7 @@ func Collect(cfg *config.Config) (*types.SystemInfo, error) {
 	}
 
 	// Collect network information
-	if cfg.ShouldCollect("network") {
+	if shouldCollect("network") {
 		info.Network, err = CollectNetwork()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting network info: %v\n", err)
@@ -59,28 +84,232 @@ func Collect(cfg *config.Config) (*types.SystemInfo, error) {
 	}
 
 	// Collect process information
-	if cfg.ShouldCollect("process") {
-		info.Processes, err = CollectProcesses()
+	if shouldCollect("process") {
+		info.Processes, err = CollectProcesses(ProcessOptions{Cmdline: cfg.ProcessCmdline, Env: cfg.ProcessEnv})
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting process info: %v\n", err)
 		}
 	}
 
 	// Collect GPU information
-	if cfg.ShouldCollect("gpu") {
+	if shouldCollect("gpu") {
 		info.GPU, err = CollectGPU()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting GPU info: %v\n", err)
 		}
+		if info.GPU != nil {
+			info.GPU.DriverIssues = analyzer.CheckGPUDrivers(info.GPU.GPUs)
+		}
 	}
 
 	// Collect battery information
-	if cfg.ShouldCollect("battery") {
+	if shouldCollect("battery") {
 		info.Battery, err = CollectBattery()
 		if err != nil && cfg.Verbose {
 			fmt.Fprintf(os.Stderr, "Error collecting battery info: %v\n", err)
 		}
 	}
 
+	// Collect security posture
+	if shouldCollect("security") {
+		info.Security, err = CollectSecurity()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting security info: %v\n", err)
+		}
+	}
+
+	// Collect non-GPU accelerators
+	if shouldCollect("accelerator") {
+		info.Accelerators, err = CollectAccelerators()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting accelerator info: %v\n", err)
+		}
+	}
+
+	// Collect PCI devices
+	if shouldCollect("pci") {
+		info.PCI, err = CollectPCI()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting PCI devices: %v\n", err)
+		}
+	}
+
+	// Collect connected USB devices
+	if shouldCollect("usb") {
+		info.USB, err = CollectUSB()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting USB devices: %v\n", err)
+		}
+	}
+
+	// Collect connected displays
+	if shouldCollect("displays") {
+		info.Displays, err = CollectDisplays()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting displays: %v\n", err)
+		}
+	}
+
+	// Collect sound cards
+	if shouldCollect("audio") {
+		info.Audio, err = CollectAudio()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting audio devices: %v\n", err)
+		}
+	}
+
+	// Collect Bluetooth adapters and paired devices
+	if shouldCollect("bluetooth") {
+		info.Bluetooth, err = CollectBluetooth()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting Bluetooth devices: %v\n", err)
+		}
+	}
+
+	// Collect loaded kernel modules and drivers
+	if shouldCollect("drivers") {
+		info.Drivers, err = CollectDrivers()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting drivers: %v\n", err)
+		}
+	}
+
+	// Collect service states and the failed services
+	if shouldCollect("services") {
+		info.Services, err = CollectServices()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting services: %v\n", err)
+		}
+	}
+
+	// Collect the firewall state and summarize its rules
+	if shouldCollect("firewall") {
+		info.Firewall, err = CollectFirewall()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting firewall: %v\n", err)
+		}
+	}
+
+	// Collect logged-in users' sessions and count local accounts
+	if shouldCollect("users") {
+		info.Users, err = CollectUsers()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting users: %v\n", err)
+		}
+	}
+
+	// Collect thermal zones and tie GPU and disk temperatures to their thresholds
+	if shouldCollect("thermal") {
+		info.Thermal, err = CollectThermal()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting thermal info: %v\n", err)
+		}
+		info.Thermal = addDeviceTemperatures(info.Thermal, info.GPU, info.Disk)
+		if info.Thermal != nil {
+			info.Thermal.Noise = estimateNoise(info.Thermal.Fans, cfg.FanModels)
+		}
+	}
+
+	// Collect hardware monitoring temperature sensors
+	if shouldCollect("sensors") {
+		info.Sensors, err = CollectSensors()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting sensors: %v\n", err)
+		}
+	}
+
+	// Inventory installed software
+	if shouldCollect("packages") {
+		info.Packages, err = CollectPackages()
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting packages: %v\n", err)
+		}
+	}
+
+	// Hash critical binaries and configuration files for drift and tamper detection
+	if shouldCollect("integrity") {
+		info.Integrity, err = CollectIntegrity(cfg.IntegrityPaths)
+		if err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Error collecting file checksums: %v\n", err)
+		}
+	}
+
+	// Measure clock offset so consumers can correct this host's timestamps
+	if shouldCollect("timesync") {
+		offset, err := MeasureClockOffset(cfg.NTPServer, 5*time.Second)
+		if err != nil {
+			if cfg.Verbose {
+				fmt.Fprintf(os.Stderr, "Error measuring clock offset: %v\n", err)
+			}
+		} else {
+			info.Meta = &types.ReportMeta{ClockOffset: offset}
+		}
+	}
+
+	// Rank the host by what was collected
+	info.Health = analyzer.ScoreHostHealth(info, cfg.HealthWeights)
+	info.Recommendations = analyzer.Recommend(info)
+
 	return info, nil
 }
+
+// Collected reports whether the report holds data for the module, i.e. whether collecting
+// it succeeded; modules that were not requested are never collected
+func Collected(info *types.SystemInfo, module string) bool {
+	switch module {
+	case "system":
+		return info.System != nil
+	case "baseboard":
+		return info.Baseboard != nil
+	case "cpu":
+		return info.CPU != nil
+	case "memory":
+		return info.Memory != nil
+	case "disk":
+		return info.Disk != nil
+	case "smart":
+		return info.Disk != nil && len(info.Disk.SMARTData) > 0
+	case "network":
+		return info.Network != nil
+	case "process":
+		return info.Processes != nil
+	case "gpu":
+		return info.GPU != nil
+	case "battery":
+		return info.Battery != nil
+	case "security":
+		return info.Security != nil
+	case "accelerator":
+		return info.Accelerators != nil
+	case "pci":
+		return info.PCI != nil
+	case "usb":
+		return info.USB != nil
+	case "displays":
+		return info.Displays != nil
+	case "audio":
+		return info.Audio != nil
+	case "bluetooth":
+		return info.Bluetooth != nil
+	case "drivers":
+		return info.Drivers != nil
+	case "services":
+		return info.Services != nil
+	case "firewall":
+		return info.Firewall != nil
+	case "users":
+		return info.Users != nil
+	case "packages":
+		return info.Packages != nil
+	case "thermal":
+		return info.Thermal != nil
+	case "sensors":
+		return info.Sensors != nil
+	case "integrity":
+		return info.Integrity != nil
+	case "timesync":
+		return info.Meta != nil && info.Meta.ClockOffset != nil
+	default:
+		return false
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
 )
 
 // TestCollect is an integration test that verifies Collect can run without panicking
@@ -114,6 +115,23 @@ func TestCollectAllModulesIndividually(t *testing.T) {
 	}
 }
 
+func TestCollected(t *testing.T) {
+	info := &types.SystemInfo{
+		CPU:  &types.CPUData{},
+		Disk: &types.DiskData{},
+	}
+	for module, expected := range map[string]bool{"cpu": true, "disk": true, "smart": false, "memory": false, "timesync": false, "unknown": false} {
+		if got := Collected(info, module); got != expected {
+			t.Errorf("Collected(%q) = %v, expected %v", module, got, expected)
+		}
+	}
+
+	info.Disk.SMARTData = []types.SMARTInfo{{Device: "/dev/sda"}}
+	if !Collected(info, "smart") {
+		t.Error("Collected(smart) = false with SMART data present")
+	}
+}
+
 func BenchmarkCollect(b *testing.B) {
 	cfg := config.NewConfig()
 
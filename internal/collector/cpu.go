@@ -2,6 +2,8 @@ package collector
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/mayvqt/sysinfo/internal/types"
@@ -9,6 +11,12 @@ import (
 	"github.com/shirou/gopsutil/v3/load"
 )
 
+// Core classes of hybrid CPUs
+const (
+	coreClassPerformance = "performance"
+	coreClassEfficiency  = "efficiency"
+)
+
 // CollectCPU gathers CPU information
 func CollectCPU() (*types.CPUData, error) {
 	cpuInfo, err := cpu.Info()
@@ -51,6 +59,10 @@ func CollectCPU() (*types.CPUData, error) {
 		Microcode:   cpuInfo[0].Microcode,
 	}
 
+	fillTopologyIDsPlatform(cpuInfo)
+	applyCPUTopology(data, cpuInfo, logicalCPUs)
+	applyCoreClasses(data, coreClassesPlatform(logicalCPUs))
+
 	// Get load average (Unix-like systems)
 	loadAvg, err := load.Avg()
 	if err == nil {
@@ -63,3 +75,146 @@ func CollectCPU() (*types.CPUData, error) {
 
 	return data, nil
 }
+
+// applyCPUTopology works out sockets, cores per socket and threads per core from cpu.Info,
+// which lists every logical CPU with its socket and core IDs on Linux, and one entry per
+// socket with its core count on Windows and macOS
+func applyCPUTopology(data *types.CPUData, infos []cpu.InfoStat, logicalCPUs int) {
+	if len(infos) == 0 {
+		return
+	}
+	perLogicalCPU := len(infos) > 1
+	for _, info := range infos {
+		if info.Cores > 1 {
+			perLogicalCPU = false
+		}
+	}
+
+	type packageKey struct {
+		socket int32
+		model  string
+	}
+	packages := map[packageKey]*types.CPUPackage{}
+	addPackage := func(socket int32, info cpu.InfoStat) *types.CPUPackage {
+		key := packageKey{socket, info.ModelName}
+		if p, ok := packages[key]; ok {
+			return p
+		}
+		p := &types.CPUPackage{Socket: socket, ModelName: info.ModelName, Stepping: info.Stepping, Microcode: info.Microcode}
+		packages[key] = p
+		return p
+	}
+
+	var totalCores, threadsPerCore int32
+	if perLogicalCPU {
+		socketIDs := map[string]int32{}
+		threads := map[string]int32{} // Logical CPUs per socket/core
+		for _, info := range infos {
+			socket, ok := socketIDs[info.PhysicalID]
+			if !ok {
+				if id, err := strconv.Atoi(info.PhysicalID); err == nil {
+					socket = int32(id)
+				} else {
+					socket = int32(len(socketIDs))
+				}
+				socketIDs[info.PhysicalID] = socket
+			}
+			core := info.CoreID
+			if core == "" {
+				core = "cpu" + strconv.Itoa(int(info.CPU)) // No IDs: count each logical CPU as a core
+			}
+			p := addPackage(socket, info)
+			coreKey := info.PhysicalID + "/" + core
+			if threads[coreKey] == 0 {
+				p.Cores++
+				totalCores++
+			}
+			threads[coreKey]++
+			p.Threads++
+			threadsPerCore = max(threadsPerCore, threads[coreKey])
+		}
+	} else {
+		for i, info := range infos {
+			p := addPackage(int32(i), info)
+			p.Cores += max(info.Cores, 1)
+			totalCores += max(info.Cores, 1)
+		}
+		threadsPerCore = max(int32(logicalCPUs)/totalCores, 1)
+		for _, p := range packages {
+			p.Threads = p.Cores * threadsPerCore
+		}
+	}
+
+	sockets := map[int32]bool{}
+	for key, p := range packages {
+		sockets[key.socket] = true
+		data.Packages = append(data.Packages, *p)
+	}
+	sort.Slice(data.Packages, func(i, j int) bool {
+		a, b := data.Packages[i], data.Packages[j]
+		if a.Socket != b.Socket {
+			return a.Socket < b.Socket
+		}
+		return a.ModelName < b.ModelName
+	})
+	data.Sockets = int32(len(sockets))
+	data.CoresPerSocket = totalCores / data.Sockets
+	data.ThreadsPerCore = threadsPerCore
+}
+
+// rankCoreClasses classifies logical CPUs by a rank where higher is faster, such as Linux
+// cpu_capacity or the Windows efficiency class: CPUs of the lowest rank are efficiency cores,
+// the rest performance cores. Returns nil when every CPU ranks the same
+func rankCoreClasses(ranks map[int]int) map[int]string {
+	lowest, highest := 0, 0
+	first := true
+	for _, rank := range ranks {
+		if first || rank < lowest {
+			lowest = rank
+		}
+		if first || rank > highest {
+			highest = rank
+		}
+		first = false
+	}
+	if lowest == highest {
+		return nil
+	}
+	classes := make(map[int]string, len(ranks))
+	for cpu, rank := range ranks {
+		classes[cpu] = coreClassPerformance
+		if rank == lowest {
+			classes[cpu] = coreClassEfficiency
+		}
+	}
+	return classes
+}
+
+// applyCoreClasses groups the logical CPUs by core class and averages their usage. Nothing is
+// set unless both classes are present, so CPUs with one kind of core are left as they were
+func applyCoreClasses(data *types.CPUData, classes map[int]string) {
+	for _, class := range []string{coreClassPerformance, coreClassEfficiency} {
+		group := types.CPUCoreClass{Class: class}
+		var total float64
+		var measured int
+		for cpu, c := range classes {
+			if c != class {
+				continue
+			}
+			group.CPUs = append(group.CPUs, cpu)
+			if cpu < len(data.Usage) {
+				total += data.Usage[cpu]
+				measured++
+			}
+		}
+		if len(group.CPUs) == 0 {
+			data.CoreClasses = nil
+			return
+		}
+		sort.Ints(group.CPUs)
+		if measured > 0 {
+			group.Usage = total / float64(measured)
+		}
+		data.CoreClasses = append(data.CoreClasses, group)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build darwin
+
+package collector
+
+import (
+	"github.com/shirou/gopsutil/v3/cpu"
+	"golang.org/x/sys/unix"
+)
+
+// fillTopologyIDsPlatform does nothing; cpu.Info lists sockets with their core counts
+func fillTopologyIDsPlatform(infos []cpu.InfoStat) {}
+
+// coreClassesPlatform classifies logical CPUs on Apple Silicon, which reports its performance
+// levels through sysctl: perflevel0 is the performance cluster, perflevel1 the efficiency one
+func coreClassesPlatform(logicalCPUs int) map[int]string {
+	levels, err := unix.SysctlUint32("hw.nperflevels")
+	if err != nil || levels < 2 {
+		return nil
+	}
+	performance, err := unix.SysctlUint32("hw.perflevel0.logicalcpu")
+	if err != nil {
+		return nil
+	}
+	efficiency, err := unix.SysctlUint32("hw.perflevel1.logicalcpu")
+	if err != nil {
+		return nil
+	}
+	return perfLevelClasses(int(performance), int(efficiency))
+}
+
+// perfLevelClasses numbers the clusters as macOS does, efficiency cores first
+func perfLevelClasses(performance, efficiency int) map[int]string {
+	classes := make(map[int]string, performance+efficiency)
+	for cpu := 0; cpu < efficiency; cpu++ {
+		classes[cpu] = coreClassEfficiency
+	}
+	for cpu := efficiency; cpu < efficiency+performance; cpu++ {
+		classes[cpu] = coreClassPerformance
+	}
+	return classes
+}
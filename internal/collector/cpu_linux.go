@@ -0,0 +1,84 @@
+//go:build linux
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// fillTopologyIDsPlatform reads socket and core IDs from sysfs
+func fillTopologyIDsPlatform(infos []cpu.InfoStat) {
+	fillTopologyIDs(hostfs, infos)
+}
+
+// fillTopologyIDs sets each logical CPU's socket and core IDs from its sysfs topology, which
+// covers what /proc/cpuinfo leaves out, such as physical IDs on ARM
+func fillTopologyIDs(fsys fsReader, infos []cpu.InfoStat) {
+	for i := range infos {
+		dir := fmt.Sprintf("/sys/devices/system/cpu/cpu%d/topology", infos[i].CPU)
+		if id, err := readString(fsys, dir+"/physical_package_id"); err == nil && strings.TrimSpace(id) != "-1" {
+			infos[i].PhysicalID = strings.TrimSpace(id)
+		}
+		if id, err := readString(fsys, dir+"/core_id"); err == nil {
+			infos[i].CoreID = strings.TrimSpace(id)
+		}
+	}
+}
+
+// coreClassesPlatform classifies logical CPUs as performance or efficiency cores from sysfs
+func coreClassesPlatform(logicalCPUs int) map[int]string {
+	return coreClasses(hostfs, logicalCPUs)
+}
+
+// coreClasses reads which logical CPUs are performance and efficiency cores. Intel hybrid CPUs
+// list them under the cpu_core and cpu_atom PMUs; other hybrid CPUs, such as ARM big.LITTLE,
+// rank their cores by cpu_capacity
+func coreClasses(fsys fsReader, logicalCPUs int) map[int]string {
+	classes := map[int]string{}
+	for pmu, class := range map[string]string{"cpu_core": coreClassPerformance, "cpu_atom": coreClassEfficiency} {
+		list, err := readString(fsys, "/sys/devices/"+pmu+"/cpus")
+		if err != nil {
+			continue
+		}
+		for _, cpu := range parseCPUList(list) {
+			classes[cpu] = class
+		}
+	}
+	if len(classes) > 0 {
+		return classes
+	}
+
+	ranks := map[int]int{}
+	for cpu := 0; cpu < logicalCPUs; cpu++ {
+		if capacity, ok := readSysfsInt(fsys, fmt.Sprintf("/sys/devices/system/cpu/cpu%d/cpu_capacity", cpu)); ok {
+			ranks[cpu] = capacity
+		}
+	}
+	return rankCoreClasses(ranks)
+}
+
+// parseCPUList parses a kernel CPU list such as "0-7,16,18-19"; malformed parts are skipped
+func parseCPUList(list string) []int {
+	var cpus []int
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil || end < start {
+				continue
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus
+}
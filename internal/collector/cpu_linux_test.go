@@ -0,0 +1,82 @@
+//go:build linux
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+func TestFillTopologyIDs(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"devices/system/cpu/cpu0/topology/physical_package_id": "0",
+		"devices/system/cpu/cpu0/topology/core_id":             "0",
+		"devices/system/cpu/cpu1/topology/physical_package_id": "-1",
+		"devices/system/cpu/cpu1/topology/core_id":             "1",
+	})
+
+	// /proc/cpuinfo on ARM has no physical IDs
+	infos := []cpu.InfoStat{{CPU: 0}, {CPU: 1, PhysicalID: "0"}, {CPU: 2, PhysicalID: "1", CoreID: "5"}}
+	fillTopologyIDs(hostReader{sysfs: root}, infos)
+
+	want := [][2]string{{"0", "0"}, {"0", "1"}, {"1", "5"}}
+	for i, info := range infos {
+		if info.PhysicalID != want[i][0] || info.CoreID != want[i][1] {
+			t.Errorf("cpu%d: socket %q core %q; want %q %q", i, info.PhysicalID, info.CoreID, want[i][0], want[i][1])
+		}
+	}
+}
+
+func TestCoreClasses(t *testing.T) {
+	// Intel hybrid: P-cores with Hyper-Threading, then E-cores
+	intel := t.TempDir()
+	writeSysfs(t, intel, map[string]string{
+		"devices/cpu_core/cpus": "0-3",
+		"devices/cpu_atom/cpus": "4-5",
+	})
+	classes := coreClasses(hostReader{sysfs: intel}, 6)
+	want := map[int]string{0: "performance", 3: "performance", 4: "efficiency", 5: "efficiency"}
+	for cpu, class := range want {
+		if classes[cpu] != class {
+			t.Errorf("Intel cpu%d = %q, expected %q", cpu, classes[cpu], class)
+		}
+	}
+
+	// ARM big.LITTLE: two little cores, two big ones
+	arm := t.TempDir()
+	writeSysfs(t, arm, map[string]string{
+		"devices/system/cpu/cpu0/cpu_capacity": "446",
+		"devices/system/cpu/cpu1/cpu_capacity": "446",
+		"devices/system/cpu/cpu2/cpu_capacity": "1024",
+		"devices/system/cpu/cpu3/cpu_capacity": "1024",
+	})
+	classes = coreClasses(hostReader{sysfs: arm}, 4)
+	if classes[0] != "efficiency" || classes[1] != "efficiency" || classes[2] != "performance" || classes[3] != "performance" {
+		t.Errorf("ARM classes = %v", classes)
+	}
+
+	// Symmetric cores
+	symmetric := t.TempDir()
+	writeSysfs(t, symmetric, map[string]string{
+		"devices/system/cpu/cpu0/cpu_capacity": "1024",
+		"devices/system/cpu/cpu1/cpu_capacity": "1024",
+	})
+	if classes := coreClasses(hostReader{sysfs: symmetric}, 2); classes != nil {
+		t.Errorf("symmetric classes = %v, expected nil", classes)
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	got := parseCPUList("0-2,8,10-11,x,5-4\n")
+	want := []int{0, 1, 2, 8, 10, 11}
+	if len(got) != len(want) {
+		t.Fatalf("parseCPUList() = %v, expected %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseCPUList() = %v, expected %v", got, want)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package collector
 
 import (
+	"strconv"
 	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/cpu"
 )
 
 // TestCollectCPU verifies basic CPU collection works
@@ -88,3 +92,93 @@ func BenchmarkCollectCPU(b *testing.B) {
 		_, _ = CollectCPU()
 	}
 }
+
+func TestApplyCPUTopology(t *testing.T) {
+	// Linux lists logical CPUs: two sockets of two cores with two threads each, the second
+	// socket a later stepping
+	var linux []cpu.InfoStat
+	for i := 0; i < 8; i++ {
+		socket := i / 4
+		linux = append(linux, cpu.InfoStat{
+			CPU: int32(i), PhysicalID: strconv.Itoa(socket), CoreID: strconv.Itoa(i % 2), Cores: 1,
+			ModelName: "Intel Xeon Gold 6230", Stepping: int32(6 + socket),
+		})
+	}
+	data := &types.CPUData{}
+	applyCPUTopology(data, linux, 8)
+	if data.Sockets != 2 || data.CoresPerSocket != 2 || data.ThreadsPerCore != 2 || len(data.Packages) != 2 {
+		t.Fatalf("topology = %d sockets, %d cores/socket, %d threads/core, %+v", data.Sockets, data.CoresPerSocket, data.ThreadsPerCore, data.Packages)
+	}
+	if p := data.Packages[1]; p.Socket != 1 || p.Stepping != 7 || p.Cores != 2 || p.Threads != 4 {
+		t.Errorf("socket 1 = %+v", p)
+	}
+
+	// big.LITTLE: one socket, four little and two big cores without SMT
+	var arm []cpu.InfoStat
+	for i := 0; i < 6; i++ {
+		model := "Cortex-A55"
+		if i >= 4 {
+			model = "Cortex-A76"
+		}
+		arm = append(arm, cpu.InfoStat{CPU: int32(i), PhysicalID: "0", CoreID: strconv.Itoa(i), Cores: 1, ModelName: model})
+	}
+	data = &types.CPUData{}
+	applyCPUTopology(data, arm, 6)
+	if data.Sockets != 1 || data.CoresPerSocket != 6 || data.ThreadsPerCore != 1 || len(data.Packages) != 2 {
+		t.Fatalf("big.LITTLE topology = %+v", data)
+	}
+	if data.Packages[0].ModelName != "Cortex-A55" || data.Packages[0].Cores != 4 || data.Packages[1].Cores != 2 {
+		t.Errorf("big.LITTLE packages = %+v", data.Packages)
+	}
+
+	// Windows lists sockets with their core counts
+	windows := []cpu.InfoStat{
+		{CPU: 0, Cores: 16, ModelName: "AMD EPYC 7302", PhysicalID: "178BFBFF00830F10"},
+		{CPU: 1, Cores: 16, ModelName: "AMD EPYC 7302", PhysicalID: "178BFBFF00830F10"},
+	}
+	data = &types.CPUData{}
+	applyCPUTopology(data, windows, 64)
+	if data.Sockets != 2 || data.CoresPerSocket != 16 || data.ThreadsPerCore != 2 || len(data.Packages) != 2 || data.Packages[1].Threads != 32 {
+		t.Errorf("Windows topology = %+v", data)
+	}
+}
+
+func TestRankCoreClasses(t *testing.T) {
+	// Windows efficiency classes: 0 for E-cores, 1 for P-cores
+	classes := rankCoreClasses(map[int]int{0: 1, 1: 1, 2: 0, 3: 0})
+	if classes[0] != "performance" || classes[1] != "performance" || classes[2] != "efficiency" || classes[3] != "efficiency" {
+		t.Errorf("rankCoreClasses() = %v", classes)
+	}
+	// Three tiers (prime, big, little): only the little cores are efficiency cores
+	classes = rankCoreClasses(map[int]int{0: 400, 1: 800, 2: 1024})
+	if classes[0] != "efficiency" || classes[1] != "performance" || classes[2] != "performance" {
+		t.Errorf("rankCoreClasses() with three tiers = %v", classes)
+	}
+	if classes := rankCoreClasses(map[int]int{0: 1024, 1: 1024}); classes != nil {
+		t.Errorf("rankCoreClasses() of identical cores = %v, expected nil", classes)
+	}
+	if classes := rankCoreClasses(nil); classes != nil {
+		t.Errorf("rankCoreClasses(nil) = %v, expected nil", classes)
+	}
+}
+
+func TestApplyCoreClasses(t *testing.T) {
+	data := &types.CPUData{Usage: []float64{80, 60, 10, 0}}
+	applyCoreClasses(data, map[int]string{0: "performance", 1: "performance", 2: "efficiency", 3: "efficiency"})
+	if len(data.CoreClasses) != 2 {
+		t.Fatalf("CoreClasses = %+v", data.CoreClasses)
+	}
+	if p := data.CoreClasses[0]; p.Class != "performance" || len(p.CPUs) != 2 || p.CPUs[1] != 1 || p.Usage != 70 {
+		t.Errorf("performance = %+v", p)
+	}
+	if e := data.CoreClasses[1]; e.Class != "efficiency" || e.CPUs[0] != 2 || e.Usage != 5 {
+		t.Errorf("efficiency = %+v", e)
+	}
+
+	// A single class is not hybrid
+	data = &types.CPUData{Usage: []float64{50, 50}}
+	applyCoreClasses(data, map[int]string{0: "performance", 1: "performance"})
+	if data.CoreClasses != nil {
+		t.Errorf("CoreClasses with one class = %+v, expected nil", data.CoreClasses)
+	}
+}
@@ -0,0 +1,74 @@
+//go:build windows
+
+package collector
+
+import (
+	"encoding/binary"
+	"sort"
+	"unsafe"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"golang.org/x/sys/windows"
+)
+
+var procGetSystemCpuSetInformation = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetSystemCpuSetInformation")
+
+// fillTopologyIDsPlatform does nothing; cpu.Info lists sockets with their core counts
+func fillTopologyIDsPlatform(infos []cpu.InfoStat) {}
+
+// coreClassesPlatform classifies logical CPUs by the efficiency class Windows assigns each
+// CPU set; it is the same for every CPU on processors with one kind of core
+func coreClassesPlatform(logicalCPUs int) map[int]string {
+	var length uint32
+	// The first call fails with ERROR_INSUFFICIENT_BUFFER and sets the length needed
+	procGetSystemCpuSetInformation.Call(0, 0, uintptr(unsafe.Pointer(&length)), uintptr(windows.CurrentProcess()), 0)
+	if length == 0 {
+		return nil
+	}
+	buf := make([]byte, length)
+	ok, _, _ := procGetSystemCpuSetInformation.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(length),
+		uintptr(unsafe.Pointer(&length)), uintptr(windows.CurrentProcess()), 0)
+	if ok == 0 {
+		return nil
+	}
+	return rankCoreClasses(cpuSetEfficiencyClasses(buf[:length]))
+}
+
+// cpuSetEfficiencyClasses parses SYSTEM_CPU_SET_INFORMATION records into the efficiency class
+// of each logical CPU, numbered across processor groups in the order per-CPU usage lists them
+func cpuSetEfficiencyClasses(buf []byte) map[int]int {
+	type cpuSet struct {
+		group      uint16
+		index      uint8
+		efficiency int
+	}
+	var sets []cpuSet
+	for len(buf) >= 20 {
+		size := int(binary.LittleEndian.Uint32(buf))
+		if size < 20 || size > len(buf) {
+			break
+		}
+		// Type 0 is CpuSetInformation; Group is at 12, LogicalProcessorIndex at 14 and
+		// EfficiencyClass at 18
+		if binary.LittleEndian.Uint32(buf[4:]) == 0 {
+			sets = append(sets, cpuSet{
+				group:      binary.LittleEndian.Uint16(buf[12:]),
+				index:      buf[14],
+				efficiency: int(buf[18]),
+			})
+		}
+		buf = buf[size:]
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].group != sets[j].group {
+			return sets[i].group < sets[j].group
+		}
+		return sets[i].index < sets[j].index
+	})
+
+	classes := make(map[int]int, len(sets))
+	for i, set := range sets {
+		classes[i] = set.efficiency
+	}
+	return classes
+}
@@ -0,0 +1,38 @@
+//go:build windows
+
+package collector
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestCPUSetEfficiencyClasses(t *testing.T) {
+	record := func(group uint16, index, efficiency uint8) []byte {
+		b := make([]byte, 32)
+		binary.LittleEndian.PutUint32(b, 32)
+		binary.LittleEndian.PutUint16(b[12:], group)
+		b[14] = index
+		b[18] = efficiency
+		return b
+	}
+	// Listed out of order: two P-cores (class 1) and an E-core (class 0) in group 0, and a
+	// P-core in group 1, which is numbered after them
+	var buf []byte
+	buf = append(buf, record(1, 0, 1)...)
+	buf = append(buf, record(0, 2, 0)...)
+	buf = append(buf, record(0, 0, 1)...)
+	buf = append(buf, record(0, 1, 1)...)
+	buf = append(buf, 0, 0) // Truncated trailing data is ignored
+
+	got := cpuSetEfficiencyClasses(buf)
+	want := map[int]int{0: 1, 1: 1, 2: 0, 3: 1}
+	if len(got) != len(want) {
+		t.Fatalf("cpuSetEfficiencyClasses() = %v, expected %v", got, want)
+	}
+	for cpu, class := range want {
+		if got[cpu] != class {
+			t.Errorf("cpu%d efficiency class = %d, expected %d", cpu, got[cpu], class)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mayvqt/sysinfo/internal/types"
 	"github.com/mayvqt/sysinfo/internal/utils"
@@ -23,7 +24,8 @@ func CollectDisk(includeSMART bool) (*types.DiskData, error) {
 
 	// Collect partition information
 	for _, partition := range partitions {
-		usage, err := disk.Usage(partition.Mountpoint)
+		// Below --host-root, mount points are the host's and sit below the root
+		usage, err := disk.Usage(hostPath(partition.Mountpoint))
 		if err != nil {
 			continue // Skip partitions we can't access
 		}
@@ -77,6 +79,13 @@ func CollectDisk(includeSMART bool) (*types.DiskData, error) {
 	if len(physicalDisks) > 0 {
 		data.PhysicalDisks = physicalDisks
 	}
+	attachDiskSlots(data.PhysicalDisks, EnclosureSlots())
+
+	// Report TRIM/discard status when solid-state drives are present
+	if hasSolidStateDisk(data.PhysicalDisks) {
+		data.Trim = collectTrimStatusPlatform(partitions)
+		assessTrimStatus(data.Trim, time.Now())
+	}
 
 	// Collect SMART data if requested
 	if includeSMART {
@@ -89,5 +98,15 @@ func CollectDisk(includeSMART bool) (*types.DiskData, error) {
 // CollectSMART gathers SMART data from drives
 func CollectSMART() []types.SMARTInfo {
 	// Call platform-specific implementation
-	return collectSMARTPlatform()
+	drives, _ := collectSMARTPlatform(false)
+	attachSMARTSlots(drives, EnclosureSlots())
+	return drives
+}
+
+// CollectSMARTSkipStandby gathers SMART data without spinning up drives that are in standby or sleep,
+// returning those devices separately. On Windows SMART is read through WMI and nothing is skipped
+func CollectSMARTSkipStandby() (drives []types.SMARTInfo, standby []string) {
+	drives, standby = collectSMARTPlatform(true)
+	attachSMARTSlots(drives, EnclosureSlots())
+	return drives, standby
 }
@@ -3,10 +3,10 @@
 package collector
 
 import (
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 	"github.com/mayvqt/sysinfo/internal/utils"
 )
@@ -15,12 +15,12 @@ func collectPhysicalDisksPlatform() []types.PhysicalDisk {
 	disks := make([]types.PhysicalDisk, 0)
 
 	// Check if diskutil is available
-	if _, err := exec.LookPath("diskutil"); err != nil {
+	if _, err := sandbox.LookPath("diskutil"); err != nil {
 		return disks
 	}
 
 	// Get list of whole disks
-	cmd := exec.Command("diskutil", "list", "-plist")
+	cmd := sandbox.Command("diskutil", "list", "-plist")
 	_, err := cmd.Output()
 	if err != nil {
 		return disks
@@ -43,7 +43,7 @@ func collectPhysicalDisksPlatform() []types.PhysicalDisk {
 
 // getWholeDisks returns a list of whole disk identifiers
 func getWholeDisks() []string {
-	cmd := exec.Command("diskutil", "list")
+	cmd := sandbox.Command("diskutil", "list")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil
@@ -75,7 +75,7 @@ func getDiskInfo(diskID string) types.PhysicalDisk {
 	}
 
 	// Use diskutil info with JSON output (available on recent macOS)
-	cmd := exec.Command("diskutil", "info", "-plist", diskID)
+	cmd := sandbox.Command("diskutil", "info", "-plist", diskID)
 	output, err := cmd.Output()
 	if err != nil {
 		return disk
@@ -159,7 +159,7 @@ func extractPlistInteger(line string) uint64 {
 
 // checkSSDSystemProfiler uses system_profiler to check if a disk is SSD (fallback)
 func checkSSDSystemProfiler(_ string) bool {
-	cmd := exec.Command("system_profiler", "SPSerialATADataType", "-json")
+	cmd := sandbox.Command("system_profiler", "SPSerialATADataType", "-json")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
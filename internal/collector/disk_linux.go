@@ -5,11 +5,11 @@ package collector
 import (
 	"encoding/json"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 	"github.com/mayvqt/sysinfo/internal/utils"
 )
@@ -45,12 +45,12 @@ func collectPhysicalDisksPlatform() []types.PhysicalDisk {
 // collectDisksLsblk uses lsblk to get physical disk information
 func collectDisksLsblk() []types.PhysicalDisk {
 	// Check if lsblk is available
-	if _, err := exec.LookPath("lsblk"); err != nil {
+	if _, err := sandbox.LookPath("lsblk"); err != nil {
 		return nil
 	}
 
 	// Run lsblk with JSON output
-	cmd := exec.Command("lsblk", "-J", "-b", "-d", "-o", "NAME,TYPE,SIZE,MODEL,SERIAL,ROTA,RM,TRAN")
+	cmd := sandbox.Command("lsblk", "-J", "-b", "-d", "-o", "NAME,TYPE,SIZE,MODEL,SERIAL,ROTA,RM,TRAN")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil
@@ -125,7 +125,7 @@ func collectDisksLsblk() []types.PhysicalDisk {
 func collectDisksSysBlock() []types.PhysicalDisk {
 	disks := make([]types.PhysicalDisk, 0)
 
-	sysBlockPath := "/sys/block"
+	sysBlockPath := hostPath("/sys/block")
 	entries, err := os.ReadDir(sysBlockPath)
 	if err != nil {
 		return disks
@@ -205,8 +205,8 @@ func collectDisksSysBlock() []types.PhysicalDisk {
 // getDiskRPM attempts to get disk RPM (for HDDs)
 func getDiskRPM(deviceName string) uint32 {
 	// Try smartctl if available
-	if _, err := exec.LookPath("smartctl"); err == nil {
-		cmd := exec.Command("smartctl", "-i", "/dev/"+deviceName)
+	if _, err := sandbox.LookPath("smartctl"); err == nil {
+		cmd := sandbox.Command("smartctl", "-i", "/dev/"+deviceName)
 		output, err := cmd.Output()
 		if err == nil {
 			lines := strings.Split(string(output), "\n")
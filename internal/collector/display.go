@@ -0,0 +1,159 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// pnpVendors names the display makers' three-letter PNP IDs most often found in EDIDs
+var pnpVendors = map[string]string{
+	"ACR": "Acer",
+	"AOC": "AOC",
+	"APP": "Apple",
+	"AUO": "AU Optronics",
+	"AUS": "ASUS",
+	"BNQ": "BenQ",
+	"BOE": "BOE",
+	"CMN": "Innolux",
+	"CSO": "CSOT",
+	"DEL": "Dell",
+	"EIZ": "EIZO",
+	"ENC": "EIZO",
+	"FUS": "Fujitsu",
+	"GBT": "Gigabyte",
+	"GSM": "LG",
+	"HPN": "HP",
+	"HWP": "HP",
+	"IVM": "Iiyama",
+	"IVO": "InfoVision",
+	"LEN": "Lenovo",
+	"LGD": "LG Display",
+	"MEI": "Panasonic",
+	"MSI": "MSI",
+	"NEC": "NEC",
+	"PHL": "Philips",
+	"SAM": "Samsung",
+	"SDC": "Samsung Display",
+	"SHP": "Sharp",
+	"SNY": "Sony",
+	"TSB": "Toshiba",
+	"VSC": "ViewSonic",
+}
+
+var edidHeader = []byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+
+// CollectDisplays gathers the connected monitors with their EDID identity and current mode
+func CollectDisplays() (*types.DisplayData, error) {
+	displays := collectDisplaysPlatform()
+	if len(displays) == 0 {
+		return nil, fmt.Errorf("no displays found")
+	}
+
+	for i := range displays {
+		d := &displays[i]
+		if d.Manufacturer == "" {
+			d.Manufacturer = pnpVendors[d.ManufacturerID]
+		}
+		if d.DiagonalInches == 0 {
+			d.DiagonalInches = diagonalInches(d.WidthMM, d.HeightMM)
+		}
+	}
+	return &types.DisplayData{Displays: displays}, nil
+}
+
+// parseEDID reads a monitor's identity, physical size and preferred mode from the base
+// block of its EDID; ok is false for data that is not an EDID
+func parseEDID(edid []byte) (display types.DisplayInfo, ok bool) {
+	if len(edid) < 128 || !bytes.Equal(edid[:8], edidHeader) {
+		return display, false
+	}
+
+	display.ManufacturerID = pnpID(binary.BigEndian.Uint16(edid[8:10]))
+	display.ProductCode = fmt.Sprintf("%04x", binary.LittleEndian.Uint16(edid[10:12]))
+	if serial := binary.LittleEndian.Uint32(edid[12:16]); serial != 0 && serial != 0x01010101 {
+		display.Serial = fmt.Sprint(serial)
+	}
+	// Byte 17 counts from 1990; the week byte 0xff marks it as the model year instead
+	if edid[17] > 0 {
+		display.Year = 1990 + int(edid[17])
+	}
+	// Image size in cm, refined by the preferred timing's size in mm below
+	display.WidthMM, display.HeightMM = int(edid[21])*10, int(edid[22])*10
+
+	for offset := 54; offset <= 108; offset += 18 {
+		block := edid[offset : offset+18]
+		if block[0] != 0 || block[1] != 0 {
+			// The first detailed timing is the preferred mode
+			if display.Width == 0 {
+				edidTiming(block, &display)
+			}
+			continue
+		}
+		switch block[3] {
+		case 0xfc:
+			display.Model = edidText(block[5:])
+		case 0xff:
+			// The serial string is the one printed on the monitor, unlike the number
+			if serial := edidText(block[5:]); serial != "" {
+				display.Serial = serial
+			}
+		}
+	}
+	return display, true
+}
+
+// edidTiming reads a detailed timing descriptor's resolution, refresh rate and image size
+func edidTiming(block []byte, display *types.DisplayInfo) {
+	clock := float64(binary.LittleEndian.Uint16(block[0:2])) * 10000
+	hActive := int(block[2]) | int(block[4]>>4)<<8
+	hBlank := int(block[3]) | int(block[4]&0x0f)<<8
+	vActive := int(block[5]) | int(block[7]>>4)<<8
+	vBlank := int(block[6]) | int(block[7]&0x0f)<<8
+	display.Width, display.Height = hActive, vActive
+	if total := (hActive + hBlank) * (vActive + vBlank); total > 0 {
+		display.RefreshHz = math.Round(clock/float64(total)*100) / 100
+	}
+	if width, height := int(block[12])|int(block[14]>>4)<<8, int(block[13])|int(block[14]&0x0f)<<8; width > 0 && height > 0 {
+		display.WidthMM, display.HeightMM = width, height
+	}
+}
+
+// applyMode replaces the preferred mode with the current one, when an output is active
+func applyMode(display *types.DisplayInfo, current types.DisplayInfo) {
+	if current.Width == 0 {
+		return
+	}
+	display.Width, display.Height, display.RefreshHz = current.Width, current.Height, current.RefreshHz
+}
+
+// pnpID decodes the three letters packed in five bits each, A being 1
+func pnpID(code uint16) string {
+	letters := []byte{byte(code>>10&0x1f) + 'A' - 1, byte(code>>5&0x1f) + 'A' - 1, byte(code&0x1f) + 'A' - 1}
+	for _, c := range letters {
+		if c < 'A' || c > 'Z' {
+			return ""
+		}
+	}
+	return string(letters)
+}
+
+// edidText reads a descriptor's text, ended by a newline and padded with spaces
+func edidText(data []byte) string {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+	return strings.TrimSpace(strings.ToValidUTF8(string(data), ""))
+}
+
+// diagonalInches returns the screen diagonal from its size in mm, to 0.1 inch
+func diagonalInches(widthMM, heightMM int) float64 {
+	if widthMM <= 0 || heightMM <= 0 {
+		return 0
+	}
+	return math.Round(math.Hypot(float64(widthMM), float64(heightMM))/25.4*10) / 10
+}
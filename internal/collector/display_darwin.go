@@ -0,0 +1,108 @@
+//go:build darwin
+
+package collector
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// spDisplay is a monitor attached to a graphics processor in the SPDisplaysDataType report;
+// the IDs are hexadecimal without a prefix
+type spDisplay struct {
+	Name       string `json:"_name"`
+	DisplayID  string `json:"_spdisplays_displayID"` // The CoreGraphics display ID
+	VendorID   string `json:"_spdisplays_display-vendor-id"`
+	ProductID  string `json:"_spdisplays_display-product-id"`
+	Serial     string `json:"_spdisplays_display-serial-number"`
+	Year       string `json:"_spdisplays_display-year"`
+	Pixels     string `json:"_spdisplays_pixels"`     // e.g. "2880 x 1800"
+	Resolution string `json:"_spdisplays_resolution"` // The scaled size, e.g. "1440 x 900 @ 60.00Hz"
+	Main       string `json:"spdisplays_main"`
+	Connection string `json:"spdisplays_connection_type"` // spdisplays_internal for built-in panels
+}
+
+var spRefreshRe = regexp.MustCompile(`@\s*([\d.]+)\s*Hz`)
+
+// collectDisplaysPlatform takes the online displays' mode and EDID identity from CoreGraphics
+// and their names from system_profiler, matched by display ID. Builds without cgo report
+// system_profiler's displays alone
+func collectDisplaysPlatform() []types.DisplayInfo {
+	var named []types.DisplayInfo
+	if out, err := sandbox.Command("system_profiler", "SPDisplaysDataType", "-json").Output(); err == nil {
+		named = parseSPDisplays(out)
+	}
+	displays := coreGraphicsDisplays()
+	if len(displays) == 0 {
+		return named
+	}
+	for i := range displays {
+		for _, n := range named {
+			if n.Name != displays[i].Name {
+				continue
+			}
+			displays[i].Model, displays[i].Year = n.Model, n.Year
+			if displays[i].Serial == "" {
+				displays[i].Serial = n.Serial
+			}
+			if displays[i].RefreshHz == 0 {
+				// Built-in panels report no refresh rate to CoreGraphics
+				displays[i].RefreshHz = n.RefreshHz
+			}
+		}
+	}
+	return displays
+}
+
+// parseSPDisplays reads the monitors of every graphics processor in a system_profiler
+// SPDisplaysDataType report, each named by its CoreGraphics display ID
+func parseSPDisplays(output []byte) []types.DisplayInfo {
+	var report struct {
+		Items []struct {
+			Displays []spDisplay `json:"spdisplays_ndrvs"`
+		} `json:"SPDisplaysDataType"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil
+	}
+
+	var displays []types.DisplayInfo
+	for _, gpu := range report.Items {
+		for _, d := range gpu.Displays {
+			display := types.DisplayInfo{
+				Name:    cmp.Or(d.DisplayID, d.Name),
+				Model:   d.Name,
+				Primary: d.Main == "spdisplays_yes",
+				Builtin: d.Connection == "spdisplays_internal",
+			}
+			if vendor, err := strconv.ParseUint(d.VendorID, 16, 16); err == nil {
+				display.ManufacturerID = pnpID(uint16(vendor))
+			}
+			if product, err := strconv.ParseUint(d.ProductID, 16, 16); err == nil {
+				display.ProductCode = fmt.Sprintf("%04x", product)
+			}
+			// Written as the EDID's serial number, which Linux and CoreGraphics give in decimal
+			if serial, err := strconv.ParseUint(d.Serial, 16, 32); err == nil && serial != 0 {
+				display.Serial = fmt.Sprint(serial)
+			}
+			if year, err := strconv.Atoi(d.Year); err == nil && year > 0 {
+				display.Year = year
+			}
+			width, height, _ := strings.Cut(d.Pixels, " x ")
+			display.Width, _ = strconv.Atoi(strings.TrimSpace(width))
+			display.Height, _ = strconv.Atoi(strings.TrimSpace(height))
+			if m := spRefreshRe.FindStringSubmatch(d.Resolution); m != nil {
+				display.RefreshHz, _ = strconv.ParseFloat(m[1], 64)
+			}
+			displays = append(displays, display)
+		}
+	}
+	return displays
+}
@@ -0,0 +1,55 @@
+//go:build darwin && cgo
+
+package collector
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics
+#include <CoreGraphics/CoreGraphics.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// maxDisplays bounds the online display list; macOS drives far fewer
+const maxDisplays = 32
+
+// coreGraphicsDisplays lists the online displays, including mirrored and sleeping ones, with
+// their current mode and the vendor, model and serial numbers CoreGraphics reads from the EDID
+func coreGraphicsDisplays() []types.DisplayInfo {
+	var ids [maxDisplays]C.CGDirectDisplayID
+	var count C.uint32_t
+	if C.CGGetOnlineDisplayList(maxDisplays, &ids[0], &count) != C.kCGErrorSuccess {
+		return nil
+	}
+
+	displays := make([]types.DisplayInfo, 0, int(count))
+	for _, id := range ids[:count] {
+		display := types.DisplayInfo{
+			Name:           strconv.FormatUint(uint64(id), 10),
+			ManufacturerID: pnpID(uint16(C.CGDisplayVendorNumber(id))),
+			ProductCode:    fmt.Sprintf("%04x", uint16(C.CGDisplayModelNumber(id))),
+			Primary:        C.CGDisplayIsMain(id) != 0,
+			Builtin:        C.CGDisplayIsBuiltin(id) != 0,
+		}
+		if serial := uint32(C.CGDisplaySerialNumber(id)); serial != 0 {
+			display.Serial = fmt.Sprint(serial)
+		}
+		// The physical size in mm, estimated by macOS when the EDID gives none
+		size := C.CGDisplayScreenSize(id)
+		display.WidthMM, display.HeightMM = int(math.Round(float64(size.width))), int(math.Round(float64(size.height)))
+		if mode := C.CGDisplayCopyDisplayMode(id); mode != nil {
+			display.Width = int(C.CGDisplayModeGetPixelWidth(mode))
+			display.Height = int(C.CGDisplayModeGetPixelHeight(mode))
+			display.RefreshHz = math.Round(float64(C.CGDisplayModeGetRefreshRate(mode))*100) / 100
+			C.CGDisplayModeRelease(mode)
+		}
+		displays = append(displays, display)
+	}
+	return displays
+}
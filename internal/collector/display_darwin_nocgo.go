@@ -0,0 +1,8 @@
+//go:build darwin && !cgo
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// coreGraphicsDisplays reports no displays: CoreGraphics is only reachable through cgo
+func coreGraphicsDisplays() []types.DisplayInfo { return nil }
@@ -0,0 +1,68 @@
+//go:build darwin
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const spDisplaysSample = `{
+  "SPDisplaysDataType" : [
+    {
+      "_name" : "Apple M1 Pro",
+      "spdisplays_ndrvs" : [
+        {
+          "_name" : "Color LCD",
+          "_spdisplays_display-product-id" : "a050",
+          "_spdisplays_display-serial-number" : "fd626d62",
+          "_spdisplays_display-vendor-id" : "610",
+          "_spdisplays_display-week" : "0",
+          "_spdisplays_display-year" : "0",
+          "_spdisplays_displayID" : "1",
+          "_spdisplays_pixels" : "3024 x 1964",
+          "_spdisplays_resolution" : "1512 x 982 @ 120.00Hz",
+          "spdisplays_connection_type" : "spdisplays_internal",
+          "spdisplays_main" : "spdisplays_yes"
+        },
+        {
+          "_name" : "DELL U2720Q",
+          "_spdisplays_display-product-id" : "a0c4",
+          "_spdisplays_display-serial-number" : "4c3c4b4c",
+          "_spdisplays_display-vendor-id" : "10ac",
+          "_spdisplays_display-year" : "2020",
+          "_spdisplays_displayID" : "3",
+          "_spdisplays_pixels" : "3840 x 2160",
+          "_spdisplays_resolution" : "1920 x 1080 @ 60.00Hz"
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseSPDisplays(t *testing.T) {
+	displays := parseSPDisplays([]byte(spDisplaysSample))
+	want := []types.DisplayInfo{
+		{
+			Name: "1", ManufacturerID: "APP", ProductCode: "a050", Model: "Color LCD", Serial: "4251086178",
+			Width: 3024, Height: 1964, RefreshHz: 120, Primary: true, Builtin: true,
+		},
+		{
+			Name: "3", ManufacturerID: "DEL", ProductCode: "a0c4", Model: "DELL U2720Q", Serial: "1279019852",
+			Year: 2020, Width: 3840, Height: 2160, RefreshHz: 60,
+		},
+	}
+	if len(displays) != len(want) {
+		t.Fatalf("parseSPDisplays() = %d displays, want %d", len(displays), len(want))
+	}
+	for i := range want {
+		if displays[i] != want[i] {
+			t.Errorf("display %d = %+v, want %+v", i, displays[i], want[i])
+		}
+	}
+
+	if displays := parseSPDisplays([]byte("not json")); displays != nil {
+		t.Errorf("parseSPDisplays(invalid) = %+v, want nil", displays)
+	}
+}
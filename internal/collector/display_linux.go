@@ -0,0 +1,202 @@
+//go:build linux
+
+package collector
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const drmClassPath = "/sys/class/drm"
+
+// drmBuiltinConnectors are the connector types of laptop and tablet panels
+var drmBuiltinConnectors = []string{"eDP", "LVDS", "DSI"}
+
+var (
+	xrandrGeometryRe = regexp.MustCompile(`\b(\d+)x(\d+)\+\d+\+\d+\b`)
+	xrandrClockRe    = regexp.MustCompile(`\bclock\s+([\d.]+)Hz`)
+)
+
+// xrandrOutput is a connected output from `xrandr --verbose`
+type xrandrOutput struct {
+	display types.DisplayInfo
+	edid    []byte
+}
+
+// collectDisplaysPlatform reads the connected monitors' EDIDs from the DRM connectors in
+// sysfs, then takes the current mode and primary output from xrandr where an X server (or
+// XWayland) is reachable, matching its outputs to the connectors by EDID, as the two name
+// connectors differently. Without DRM, such as with some proprietary drivers, xrandr's
+// outputs are reported on their own
+func collectDisplaysPlatform() []types.DisplayInfo {
+	displays, edids := scanDRMConnectors(hostPath(drmClassPath))
+	if readingHost() {
+		// The X server reachable from here is not the host's
+		return displays
+	}
+	out, err := sandbox.Command("xrandr", "--verbose").Output()
+	if err != nil {
+		return displays
+	}
+	return mergeXrandr(displays, edids, parseXrandrVerbose(string(out)))
+}
+
+// scanDRMConnectors reads every connected DRM connector below root, e.g. card0-HDMI-A-1,
+// with its EDID. Without an EDID the preferred mode is taken from the connector's modes
+func scanDRMConnectors(root string) ([]types.DisplayInfo, [][]byte) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, nil
+	}
+
+	var displays []types.DisplayInfo
+	var edids [][]byte
+	for _, entry := range entries {
+		card, connector, found := strings.Cut(entry.Name(), "-")
+		if !found || !strings.HasPrefix(card, "card") {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		if status, _ := readSysFile(filepath.Join(dir, "status")); strings.TrimSpace(status) != "connected" {
+			continue
+		}
+
+		edid, _ := os.ReadFile(filepath.Join(dir, "edid"))
+		display, ok := parseEDID(edid)
+		if !ok {
+			edid = nil
+			if modes, err := readSysFile(filepath.Join(dir, "modes")); err == nil {
+				preferred, _, _ := strings.Cut(modes, "\n")
+				display.Width, display.Height = parseResolution(preferred)
+			}
+		}
+		display.Name = connector
+		for _, builtin := range drmBuiltinConnectors {
+			if strings.HasPrefix(connector, builtin+"-") {
+				display.Builtin = true
+			}
+		}
+		displays = append(displays, display)
+		edids = append(edids, edid)
+	}
+	return displays, edids
+}
+
+// parseXrandrVerbose reads the connected outputs from `xrandr --verbose`: each output's
+// line gives its current geometry and whether it is primary, followed by its indented
+// properties, including the EDID as hex lines, and its modes, the current one marked
+// *current with its refresh rate on the mode's "v:" line
+func parseXrandrVerbose(output string) []xrandrOutput {
+	var outputs []xrandrOutput
+	var current *xrandrOutput
+	var inEDID, inCurrentMode bool
+	var edidHex strings.Builder
+	finish := func() {
+		if current == nil {
+			return
+		}
+		if edid, err := hex.DecodeString(edidHex.String()); err == nil && len(edid) > 0 {
+			current.edid = edid
+		}
+		outputs = append(outputs, *current)
+		current = nil
+		edidHex.Reset()
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			finish()
+			inEDID, inCurrentMode = false, false
+			fields := strings.Fields(line)
+			if len(fields) < 2 || fields[1] != "connected" {
+				continue
+			}
+			current = &xrandrOutput{display: types.DisplayInfo{Name: fields[0]}}
+			current.display.Primary = len(fields) > 2 && fields[2] == "primary"
+			if m := xrandrGeometryRe.FindStringSubmatch(line); m != nil {
+				current.display.Width, _ = strconv.Atoi(m[1])
+				current.display.Height, _ = strconv.Atoi(m[2])
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inEDID && strings.HasPrefix(line, "\t\t"):
+			edidHex.WriteString(trimmed)
+			continue
+		case trimmed == "EDID:":
+			inEDID = true
+			continue
+		}
+		inEDID = false
+		if strings.HasPrefix(line, "  ") && !strings.HasPrefix(trimmed, "h:") && !strings.HasPrefix(trimmed, "v:") {
+			// A mode line, e.g. "  1920x1080 (0x48) 148.500MHz +HSync +VSync *current +preferred"
+			inCurrentMode = strings.Contains(trimmed, "*current")
+		} else if inCurrentMode && strings.HasPrefix(trimmed, "v:") {
+			if m := xrandrClockRe.FindStringSubmatch(trimmed); m != nil {
+				current.display.RefreshHz, _ = strconv.ParseFloat(m[1], 64)
+			}
+			inCurrentMode = false
+		}
+	}
+	finish()
+	return outputs
+}
+
+// mergeXrandr sets the connectors' current mode and primary flag from the xrandr outputs
+// with the same EDID. Without DRM connectors the outputs are used as they are, identified
+// by their own EDID
+func mergeXrandr(displays []types.DisplayInfo, edids [][]byte, outputs []xrandrOutput) []types.DisplayInfo {
+	if len(displays) == 0 {
+		for _, o := range outputs {
+			display, _ := parseEDID(o.edid)
+			display.Name, display.Primary = o.display.Name, o.display.Primary
+			applyMode(&display, o.display)
+			displays = append(displays, display)
+		}
+		return displays
+	}
+
+	// Identical monitors without serials share an EDID, so each connector is matched once
+	matched := make([]bool, len(displays))
+	for _, o := range outputs {
+		if len(o.edid) == 0 {
+			continue
+		}
+		for i, edid := range edids {
+			if !matched[i] && edid != nil && bytes.Equal(edid, o.edid) {
+				matched[i] = true
+				displays[i].Primary = o.display.Primary
+				applyMode(&displays[i], o.display)
+				break
+			}
+		}
+	}
+	return displays
+}
+
+// parseResolution reads a mode name such as 1920x1080 or 1920x1080i
+func parseResolution(mode string) (width, height int) {
+	w, h, found := strings.Cut(strings.TrimSpace(mode), "x")
+	if !found {
+		return 0, 0
+	}
+	width, _ = strconv.Atoi(w)
+	height, _ = strconv.Atoi(strings.TrimRight(h, "i"))
+	return width, height
+}
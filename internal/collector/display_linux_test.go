@@ -0,0 +1,124 @@
+//go:build linux
+
+package collector
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestScanDRMConnectors(t *testing.T) {
+	root := t.TempDir()
+	writeConnector := func(name string, files map[string][]byte) {
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, file), content, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeConnector("card0", nil)
+	writeConnector("card0-DP-1", map[string][]byte{"status": []byte("connected\n"), "edid": testEDID()})
+	// A panel whose EDID is not exposed, sized from its preferred mode
+	writeConnector("card0-eDP-1", map[string][]byte{"status": []byte("connected\n"), "edid": nil, "modes": []byte("1920x1200\n1280x800\n")})
+	writeConnector("card0-HDMI-A-1", map[string][]byte{"status": []byte("disconnected\n"), "edid": nil})
+
+	displays, edids := scanDRMConnectors(root)
+	if len(displays) != 2 || len(edids) != 2 {
+		t.Fatalf("scanDRMConnectors() found %d displays and %d EDIDs, want 2", len(displays), len(edids))
+	}
+	if d := displays[0]; d.Name != "DP-1" || d.Model != "DELL U2720Q" || d.Width != 3840 || d.Builtin {
+		t.Errorf("DP-1 = %+v", d)
+	}
+	if edids[0] == nil {
+		t.Error("DP-1's EDID was not kept")
+	}
+	want := types.DisplayInfo{Name: "eDP-1", Width: 1920, Height: 1200, Builtin: true}
+	if displays[1] != want {
+		t.Errorf("eDP-1 = %+v, want %+v", displays[1], want)
+	}
+	if edids[1] != nil {
+		t.Errorf("eDP-1's EDID = %x, want none", edids[1])
+	}
+}
+
+// xrandrSample is `xrandr --verbose` with DP-1 scaled down to 2560x1440
+func xrandrSample() string {
+	var edidLines strings.Builder
+	encoded := hex.EncodeToString(testEDID())
+	for i := 0; i < len(encoded); i += 32 {
+		edidLines.WriteString("\t\t" + encoded[i:i+32] + "\n")
+	}
+	return `Screen 0: minimum 320 x 200, current 2560 x 1440, maximum 16384 x 16384
+DP-1 connected primary 2560x1440+0+0 (0x4b) normal (normal left inverted right x axis y axis) 597mm x 336mm
+	Identifier: 0x42
+	EDID: 
+` + edidLines.String() + `	BorderDimensions: 4 
+  3840x2160 (0x4a) 533.250MHz +HSync -VSync +preferred
+        h: width  3840 start 3888 end 3920 total 4000 skew    0 clock 133.31KHz
+        v: height 2160 start 2163 end 2168 total 2222           clock  59.997Hz
+  2560x1440 (0x4b) 241.500MHz +HSync -VSync *current
+        h: width  2560 start 2608 end 2640 total 2720 skew    0 clock  88.79KHz
+        v: height 1440 start 1443 end 1448 total 1481           clock  59.95Hz
+HDMI-1 disconnected (normal left inverted right x axis y axis)
+	Identifier: 0x43
+HDMI-2 connected 1280x1024+2560+0 (0x60) normal (normal left inverted right x axis y axis) 0mm x 0mm
+	Identifier: 0x44
+  1280x1024 (0x60) 108.000MHz +HSync +VSync *current
+        h: width  1280 start 1328 end 1440 total 1688 skew    0 clock  63.98KHz
+        v: height 1024 start 1025 end 1028 total 1066           clock  60.02Hz
+`
+}
+
+func TestParseXrandrVerbose(t *testing.T) {
+	outputs := parseXrandrVerbose(xrandrSample())
+	if len(outputs) != 2 {
+		t.Fatalf("parseXrandrVerbose() found %d outputs, want 2", len(outputs))
+	}
+	want := types.DisplayInfo{Name: "DP-1", Width: 2560, Height: 1440, RefreshHz: 59.95, Primary: true}
+	if outputs[0].display != want {
+		t.Errorf("DP-1 = %+v, want %+v", outputs[0].display, want)
+	}
+	if string(outputs[0].edid) != string(testEDID()) {
+		t.Errorf("DP-1's EDID = %x", outputs[0].edid)
+	}
+	want = types.DisplayInfo{Name: "HDMI-2", Width: 1280, Height: 1024, RefreshHz: 60.02}
+	if outputs[1].display != want || outputs[1].edid != nil {
+		t.Errorf("HDMI-2 = %+v with EDID %x, want %+v without", outputs[1].display, outputs[1].edid, want)
+	}
+}
+
+func TestMergeXrandr(t *testing.T) {
+	outputs := parseXrandrVerbose(xrandrSample())
+
+	// DRM names the connector DP-3 where X says DP-1; the EDID ties them
+	connector, _ := parseEDID(testEDID())
+	connector.Name = "DP-3"
+	displays := mergeXrandr([]types.DisplayInfo{connector}, [][]byte{testEDID()}, outputs)
+	if len(displays) != 1 {
+		t.Fatalf("mergeXrandr() = %d displays, want the 1 connector", len(displays))
+	}
+	if d := displays[0]; d.Name != "DP-3" || d.Width != 2560 || d.RefreshHz != 59.95 || !d.Primary || d.Model != "DELL U2720Q" {
+		t.Errorf("merged DP-3 = %+v", d)
+	}
+
+	// Without DRM, xrandr's outputs stand on their own
+	displays = mergeXrandr(nil, nil, outputs)
+	if len(displays) != 2 {
+		t.Fatalf("mergeXrandr() without connectors = %d displays, want 2", len(displays))
+	}
+	if d := displays[0]; d.Name != "DP-1" || d.ManufacturerID != "DEL" || d.Width != 2560 || d.WidthMM != 597 {
+		t.Errorf("DP-1 = %+v", d)
+	}
+	if d := displays[1]; d.Name != "HDMI-2" || d.Width != 1280 || d.RefreshHz != 60.02 {
+		t.Errorf("HDMI-2 = %+v", d)
+	}
+}
@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// testEDID builds the base block of a Dell U2720Q's EDID: 3840x2160 at 60 Hz, 597x336 mm
+func testEDID() []byte {
+	edid := make([]byte, 128)
+	copy(edid, edidHeader)
+	edid[8], edid[9] = 0x10, 0xac   // DEL
+	edid[10], edid[11] = 0xc4, 0xa0 // Product a0c4
+	edid[12], edid[13] = 0x4c, 0x4b // Serial number 1279019852
+	edid[14], edid[15] = 0x3c, 0x4c
+	edid[17] = 30 // 2020
+	edid[21], edid[22] = 60, 34
+
+	timing := edid[54:72]
+	timing[0], timing[1] = 0x4d, 0xd0 // 533.25 MHz
+	timing[2], timing[3], timing[4] = 0x00, 0xa0, 0xf0
+	timing[5], timing[6], timing[7] = 0x70, 0x3e, 0x80
+	timing[12], timing[13], timing[14] = 0x55, 0x50, 0x21
+
+	copy(edid[72:90], append([]byte{0, 0, 0, 0xfc, 0}, "DELL U2720Q\n  "...))
+	copy(edid[90:108], append([]byte{0, 0, 0, 0xff, 0}, "ABC1234\n     "...))
+	copy(edid[108:126], []byte{0, 0, 0, 0x10})
+	return edid
+}
+
+func TestParseEDID(t *testing.T) {
+	display, ok := parseEDID(testEDID())
+	if !ok {
+		t.Fatal("parseEDID() rejected a valid EDID")
+	}
+	want := types.DisplayInfo{
+		ManufacturerID: "DEL",
+		ProductCode:    "a0c4",
+		Model:          "DELL U2720Q",
+		Serial:         "ABC1234",
+		Year:           2020,
+		Width:          3840,
+		Height:         2160,
+		RefreshHz:      60,
+		WidthMM:        597,
+		HeightMM:       336,
+	}
+	if display != want {
+		t.Errorf("parseEDID() = %+v, want %+v", display, want)
+	}
+
+	// Without a serial string the serial number is used
+	edid := testEDID()
+	edid[93] = 0x10
+	if display, _ := parseEDID(edid); display.Serial != "1279019852" {
+		t.Errorf("serial = %q, want the serial number 1279019852", display.Serial)
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":      nil,
+		"short":      testEDID()[:127],
+		"bad header": append([]byte{0xff}, testEDID()[1:]...),
+	} {
+		if _, ok := parseEDID(data); ok {
+			t.Errorf("parseEDID(%s) accepted invalid data", name)
+		}
+	}
+}
+
+func TestPNPID(t *testing.T) {
+	tests := map[uint16]string{
+		0x10ac: "DEL",
+		0x1e6d: "GSM",
+		0x0610: "APP",
+		0x0000: "",
+		0xffff: "",
+	}
+	for code, want := range tests {
+		if got := pnpID(code); got != want {
+			t.Errorf("pnpID(%#04x) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestDiagonalInches(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          float64
+	}{
+		{597, 336, 27},
+		{344, 194, 15.5},
+		{0, 336, 0},
+	}
+	for _, tt := range tests {
+		if got := diagonalInches(tt.width, tt.height); got != tt.want {
+			t.Errorf("diagonalInches(%d, %d) = %v, want %v", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows/registry"
+)
+
+// wmiMonitorID is a monitor's identity as WmiMonitorID decodes it from the EDID, each
+// string an array of UTF-16 code units padded with zeros
+type wmiMonitorID struct {
+	InstanceName      string // e.g. DISPLAY\DELA0C4\5&2a6b7c2b&0&UID4352_0
+	Active            bool
+	ManufacturerName  []uint16
+	ProductCodeID     []uint16
+	SerialNumberID    []uint16
+	UserFriendlyName  []uint16
+	YearOfManufacture uint16
+}
+
+// wmiMonitorConnectionParams gives the connector type a monitor is attached through
+type wmiMonitorConnectionParams struct {
+	InstanceName          string
+	VideoOutputTechnology uint32
+}
+
+// videoControllerMode is the desktop mode of an adapter's first output
+type videoControllerMode struct {
+	CurrentHorizontalResolution uint32
+	CurrentVerticalResolution   uint32
+	CurrentRefreshRate          uint32
+}
+
+// Connector types of built-in panels (D3DKMDT_VIDEO_OUTPUT_TECHNOLOGY)
+const (
+	votLVDS                = 6
+	votDisplayPortEmbedded = 11
+	votUDIEmbedded         = 13
+	votInternal            = 0x80000000
+)
+
+// collectDisplaysPlatform lists the active monitors from WmiMonitorID, reading each one's
+// full EDID from its device key in the registry where Windows caches it. Win32_VideoController
+// only gives the desktop mode of one output per adapter, so the current mode is only taken
+// from it when a single monitor is connected
+func collectDisplaysPlatform() []types.DisplayInfo {
+	var monitors []wmiMonitorID
+	query := "SELECT InstanceName, Active, ManufacturerName, ProductCodeID, SerialNumberID, UserFriendlyName, YearOfManufacture FROM WmiMonitorID"
+	if err := wmi.QueryNamespace(query, &monitors, `root\wmi`); err != nil {
+		return nil
+	}
+	var connections []wmiMonitorConnectionParams
+	_ = wmi.QueryNamespace("SELECT InstanceName, VideoOutputTechnology FROM WmiMonitorConnectionParams", &connections, `root\wmi`)
+	technology := map[string]uint32{}
+	for _, c := range connections {
+		technology[c.InstanceName] = c.VideoOutputTechnology
+	}
+
+	var displays []types.DisplayInfo
+	for _, monitor := range monitors {
+		if !monitor.Active {
+			continue
+		}
+		display := wmiMonitorDisplay(monitor, monitorEDID(monitor.InstanceName))
+		switch technology[monitor.InstanceName] {
+		case votLVDS, votDisplayPortEmbedded, votUDIEmbedded, votInternal:
+			display.Builtin = true
+		}
+		displays = append(displays, display)
+	}
+
+	if len(displays) == 1 {
+		var modes []videoControllerMode
+		query := "SELECT CurrentHorizontalResolution, CurrentVerticalResolution, CurrentRefreshRate FROM Win32_VideoController WHERE CurrentHorizontalResolution IS NOT NULL"
+		if err := wmi.Query(query, &modes); err == nil && len(modes) == 1 {
+			applyMode(&displays[0], types.DisplayInfo{
+				Width:     int(modes[0].CurrentHorizontalResolution),
+				Height:    int(modes[0].CurrentVerticalResolution),
+				RefreshHz: float64(modes[0].CurrentRefreshRate),
+			})
+			displays[0].Primary = true
+		}
+	}
+	return displays
+}
+
+// wmiMonitorDisplay builds a monitor from its EDID when the registry had one, and otherwise
+// from the identity WMI decoded, which lacks the size and modes
+func wmiMonitorDisplay(monitor wmiMonitorID, edid []byte) types.DisplayInfo {
+	display, ok := parseEDID(edid)
+	if !ok {
+		display.ManufacturerID = wmiString(monitor.ManufacturerName)
+		display.ProductCode = strings.ToLower(wmiString(monitor.ProductCodeID))
+		display.Model = wmiString(monitor.UserFriendlyName)
+		if serial := wmiString(monitor.SerialNumberID); serial != "0" {
+			display.Serial = serial
+		}
+		if monitor.YearOfManufacture > 0 {
+			display.Year = int(monitor.YearOfManufacture)
+		}
+	}
+	display.Name = monitor.InstanceName
+	return display
+}
+
+// monitorEDID reads the EDID Windows stores under the monitor's device key, which is its
+// instance name without the trailing _0
+func monitorEDID(instanceName string) []byte {
+	id := instanceName
+	if i := strings.LastIndexByte(id, '_'); i > 0 {
+		id = id[:i]
+	}
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Enum\`+id+`\Device Parameters`, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+	edid, _, err := key.GetBinaryValue("EDID")
+	if err != nil {
+		return nil
+	}
+	return edid
+}
+
+// wmiString decodes a zero-padded array of UTF-16 code units
+func wmiString(units []uint16) string {
+	var b strings.Builder
+	for _, u := range units {
+		if u == 0 {
+			break
+		}
+		b.WriteRune(rune(u))
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,54 @@
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// utf16Padded encodes s as WmiMonitorID does, padded with zeros to size
+func utf16Padded(s string, size int) []uint16 {
+	units := make([]uint16, size)
+	for i, r := range s {
+		units[i] = uint16(r)
+	}
+	return units
+}
+
+func TestWmiMonitorDisplay(t *testing.T) {
+	monitor := wmiMonitorID{
+		InstanceName:      `DISPLAY\DELA0C4\5&2a6b7c2b&0&UID4352_0`,
+		Active:            true,
+		ManufacturerName:  utf16Padded("DEL", 16),
+		ProductCodeID:     utf16Padded("A0C4", 16),
+		SerialNumberID:    utf16Padded("ABC1234", 16),
+		UserFriendlyName:  utf16Padded("DELL U2720Q", 13),
+		YearOfManufacture: 2020,
+	}
+
+	// The registry's EDID gives the size and preferred mode as well
+	display := wmiMonitorDisplay(monitor, testEDID())
+	if display.Name != monitor.InstanceName || display.Model != "DELL U2720Q" || display.Width != 3840 || display.WidthMM != 597 {
+		t.Errorf("wmiMonitorDisplay() with EDID = %+v", display)
+	}
+
+	want := types.DisplayInfo{
+		Name:           monitor.InstanceName,
+		ManufacturerID: "DEL",
+		ProductCode:    "a0c4",
+		Model:          "DELL U2720Q",
+		Serial:         "ABC1234",
+		Year:           2020,
+	}
+	if display := wmiMonitorDisplay(monitor, nil); display != want {
+		t.Errorf("wmiMonitorDisplay() without EDID = %+v, want %+v", display, want)
+	}
+
+	// Monitors without a serial report 0
+	monitor.SerialNumberID = utf16Padded("0", 16)
+	if display := wmiMonitorDisplay(monitor, nil); display.Serial != "" {
+		t.Errorf("serial = %q, want none", display.Serial)
+	}
+}
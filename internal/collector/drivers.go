@@ -0,0 +1,21 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectDrivers gathers the loaded kernel modules, kernel extensions or drivers with their
+// versions, sorted by name
+func CollectDrivers() (*types.DriverData, error) {
+	data := collectDriversPlatform()
+	if data == nil || len(data.Drivers) == 0 {
+		return nil, fmt.Errorf("no loaded drivers found")
+	}
+	sort.Slice(data.Drivers, func(i, j int) bool {
+		return data.Drivers[i].Name < data.Drivers[j].Name
+	})
+	return data, nil
+}
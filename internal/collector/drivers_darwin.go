@@ -0,0 +1,55 @@
+//go:build darwin
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectDriversPlatform lists the loaded kernel extensions with kmutil (macOS 11 and
+// later), or kextstat on older releases
+func collectDriversPlatform() *types.DriverData {
+	out, err := sandbox.Command("kmutil", "showloaded", "--list-only").Output()
+	if err != nil {
+		if out, err = sandbox.Command("kextstat", "-l").Output(); err != nil {
+			return nil
+		}
+	}
+	return &types.DriverData{Drivers: parseKextList(string(out))}
+}
+
+// parseKextList reads the kext table shared by kmutil showloaded and kextstat, one kext per
+// line with its index, references, load address, size, wired size, bundle ID and version:
+//
+//	 1  211 0  0  0  com.apple.kpi.bsd (22.1.0) 6B6B4C1E-... <>
+//	66    0 0xffffff7f83f6d000 0x5000 0x5000 com.apple.driver.AppleMCCSControl (1.16) 2C7E0F3B-... <12 7 6 3 1>
+//
+// The kpi pseudo-extensions stand for the kernel's own interfaces and are left out
+func parseKextList(output string) []types.KernelDriver {
+	var drivers []types.KernelDriver
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue // Header and kmutil's notices
+		}
+		name := fields[5]
+		if strings.HasPrefix(name, "com.apple.kpi.") {
+			continue
+		}
+		driver := types.KernelDriver{Name: name}
+		driver.RefCount, _ = strconv.Atoi(fields[1])
+		driver.SizeBytes, _ = strconv.ParseUint(strings.TrimPrefix(fields[3], "0x"), 16, 64)
+		if version := fields[6]; strings.HasPrefix(version, "(") {
+			driver.Version = strings.Trim(version, "()")
+		}
+		drivers = append(drivers, driver)
+	}
+	return drivers
+}
@@ -0,0 +1,25 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+const kmutilShowloaded = `No variant specified, falling back to release
+Index Refs Address            Size       Wired      Name (Version) UUID <Linked Against>
+    1  211 0                  0          0          com.apple.kpi.bsd (22.1.0) 6B6B4C1E-3A52-3B7B-9D5C-1A2B3C4D5E6F <>
+   66    0 0xffffff7f83f6d000 0x5000     0x5000     com.apple.driver.AppleMCCSControl (1.16) 2C7E0F3B-4D1A-3E2B-8C9D-0A1B2C3D4E5F <12 7 6 3 1>
+  142    2 0xffffff7f85a21000 0x2b000    0x2b000    com.example.driver.Widget (2.4.1) 9F8E7D6C-5B4A-3928-1706-F5E4D3C2B1A0 <6 3 1>
+`
+
+func TestParseKextList(t *testing.T) {
+	drivers := parseKextList(kmutilShowloaded)
+	if len(drivers) != 2 {
+		t.Fatalf("parseKextList() found %d kexts, expected 2 without the kpi one: %+v", len(drivers), drivers)
+	}
+	if mccs := drivers[0]; mccs.Name != "com.apple.driver.AppleMCCSControl" || mccs.Version != "1.16" || mccs.SizeBytes != 0x5000 {
+		t.Errorf("first kext = %+v, expected AppleMCCSControl 1.16 of 20480 bytes", mccs)
+	}
+	if widget := drivers[1]; widget.Version != "2.4.1" || widget.RefCount != 2 {
+		t.Errorf("third-party kext = %+v, expected version 2.4.1 with 2 references", widget)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build linux
+
+package collector
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	procModulesPath = "/proc/modules"
+	procTaintedPath = "/proc/sys/kernel/tainted"
+	sysModulePath   = "/sys/module"
+)
+
+// kernelTaints names the bits of /proc/sys/kernel/tainted, see
+// Documentation/admin-guide/tainted-kernels.rst
+var kernelTaints = []string{
+	"proprietary module",
+	"module force loaded",
+	"kernel running on an out of specification system",
+	"module force unloaded",
+	"processor reported a machine check exception",
+	"bad page referenced or unexpected page flags",
+	"taint requested by userspace",
+	"kernel died recently (oops or BUG)",
+	"ACPI table overridden by user",
+	"kernel issued warning",
+	"staging driver loaded",
+	"workaround for bug in platform firmware applied",
+	"externally-built (out-of-tree) module loaded",
+	"unsigned module loaded",
+	"soft lockup occurred",
+	"kernel live patched",
+	"auxiliary taint",
+	"kernel built with struct randomization plugin",
+	"in-kernel test run",
+}
+
+func collectDriversPlatform() *types.DriverData {
+	return collectKernelModules(hostfs)
+}
+
+// collectKernelModules lists the loadable modules in /proc/modules with the version they
+// declare in /sys/module; modules built into the kernel are not listed
+func collectKernelModules(fsys fsReader) *types.DriverData {
+	content, err := readString(fsys, procModulesPath)
+	if err != nil {
+		return nil
+	}
+	data := &types.DriverData{Drivers: parseProcModules(content)}
+	for i := range data.Drivers {
+		if version, err := readString(fsys, filepath.Join(sysModulePath, data.Drivers[i].Name, "version")); err == nil {
+			data.Drivers[i].Version = strings.TrimSpace(version)
+		}
+	}
+	if tainted, err := readString(fsys, procTaintedPath); err == nil {
+		if mask, err := strconv.ParseUint(strings.TrimSpace(tainted), 10, 64); err == nil {
+			data.Tainted = kernelTaintReasons(mask)
+		}
+	}
+	return data
+}
+
+// parseProcModules reads /proc/modules, one module per line with its size, reference count,
+// dependent modules, state, address and taint flags:
+//
+//	nvidia_uvm 1544192 0 - Live 0x0000000000000000 (POE)
+//	snd_hda_codec 184320 4 snd_hda_codec_hdmi,snd_hda_codec_realtek,snd_hda_intel, Live 0x0000000000000000
+func parseProcModules(content string) []types.KernelDriver {
+	var drivers []types.KernelDriver
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		driver := types.KernelDriver{Name: fields[0], State: fields[4]}
+		driver.SizeBytes, _ = strconv.ParseUint(fields[1], 10, 64)
+		driver.RefCount, _ = strconv.Atoi(fields[2])
+		if fields[3] != "-" {
+			for _, user := range strings.Split(fields[3], ",") {
+				if user != "" {
+					driver.UsedBy = append(driver.UsedBy, user)
+				}
+			}
+		}
+		if last := fields[len(fields)-1]; len(fields) > 6 && strings.HasPrefix(last, "(") {
+			driver.Taint = strings.Trim(last, "()")
+		}
+		drivers = append(drivers, driver)
+	}
+	return drivers
+}
+
+// kernelTaintReasons names the bits set in the kernel's taint mask
+func kernelTaintReasons(mask uint64) []string {
+	var reasons []string
+	for bit, reason := range kernelTaints {
+		if mask&(1<<bit) != 0 {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,75 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const procModules = `nvidia_uvm 1544192 0 - Live 0x0000000000000000 (POE)
+snd_hda_codec 184320 4 snd_hda_codec_hdmi,snd_hda_codec_realtek,snd_hda_intel, Live 0x0000000000000000
+e1000e 323584 0 - Live 0x0000000000000000
+bad
+`
+
+func TestParseProcModules(t *testing.T) {
+	drivers := parseProcModules(procModules)
+	if len(drivers) != 3 {
+		t.Fatalf("parseProcModules() found %d modules, expected 3: %+v", len(drivers), drivers)
+	}
+
+	nvidia := drivers[0]
+	if nvidia.Name != "nvidia_uvm" || nvidia.SizeBytes != 1544192 || nvidia.State != "Live" || nvidia.Taint != "POE" || nvidia.UsedBy != nil {
+		t.Errorf("nvidia_uvm = %+v, expected a live POE-tainted module without users", nvidia)
+	}
+	codec := drivers[1]
+	if codec.RefCount != 4 || !reflect.DeepEqual(codec.UsedBy, []string{"snd_hda_codec_hdmi", "snd_hda_codec_realtek", "snd_hda_intel"}) || codec.Taint != "" {
+		t.Errorf("snd_hda_codec = %+v, expected 4 references by the three codec modules", codec)
+	}
+}
+
+func TestKernelTaintReasons(t *testing.T) {
+	if reasons := kernelTaintReasons(0); reasons != nil {
+		t.Errorf("kernelTaintReasons(0) = %v, expected none", reasons)
+	}
+	// P, O and E: a proprietary, out-of-tree, unsigned module
+	expected := []string{"proprietary module", "externally-built (out-of-tree) module loaded", "unsigned module loaded"}
+	if reasons := kernelTaintReasons(1 | 1<<12 | 1<<13); !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("kernelTaintReasons(12289) = %v, expected %v", reasons, expected)
+	}
+}
+
+func TestCollectKernelModules(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	write("proc/modules", procModules)
+	write("proc/sys/kernel/tainted", "4097\n")
+	write("sys/module/nvidia_uvm/version", "550.54.14\n")
+	write("sys/module/e1000e/refcnt", "0\n")
+
+	data := collectKernelModules(hostReader{root: root})
+	if data == nil || len(data.Drivers) != 3 {
+		t.Fatalf("collectKernelModules() = %+v, expected 3 modules", data)
+	}
+	if data.Drivers[0].Version != "550.54.14" || data.Drivers[2].Version != "" {
+		t.Errorf("Versions = %q, %q; expected 550.54.14 for nvidia_uvm only", data.Drivers[0].Version, data.Drivers[2].Version)
+	}
+	if len(data.Tainted) != 2 {
+		t.Errorf("Tainted = %v, expected proprietary and out-of-tree", data.Tainted)
+	}
+
+	if data := collectKernelModules(hostReader{root: filepath.Join(root, "missing")}); data != nil {
+		t.Errorf("collectKernelModules() without /proc/modules = %+v, expected nil", data)
+	}
+}
@@ -0,0 +1,96 @@
+//go:build windows
+
+package collector
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows"
+)
+
+// win32SystemDriver is a kernel driver service
+type win32SystemDriver struct {
+	Name        string
+	DisplayName string
+	PathName    string // e.g. C:\Windows\system32\drivers\ACPI.sys, or \SystemRoot\System32\drivers\... as registered
+}
+
+// collectDriversPlatform lists the running kernel driver services with the version of
+// their driver file
+func collectDriversPlatform() *types.DriverData {
+	var services []win32SystemDriver
+	query := "SELECT Name, DisplayName, PathName FROM Win32_SystemDriver WHERE State = 'Running'"
+	if err := wmi.Query(query, &services); err != nil {
+		return nil
+	}
+
+	systemRoot := os.Getenv("SystemRoot")
+	data := &types.DriverData{}
+	for _, service := range services {
+		driver := types.KernelDriver{
+			Name:  service.Name,
+			State: "Running",
+			Path:  driverFilePath(service.PathName, systemRoot),
+		}
+		if service.DisplayName != service.Name {
+			driver.Description = service.DisplayName
+		}
+		if driver.Path != "" {
+			driver.Version = fileVersion(driver.Path)
+		}
+		data.Drivers = append(data.Drivers, driver)
+	}
+	return data
+}
+
+// driverFilePath resolves a driver's image path as the service control manager keeps it:
+// under \SystemRoot\, as an NT path (\??\C:\...), relative to the Windows directory
+// (System32\drivers\...) or already absolute
+func driverFilePath(pathName, systemRoot string) string {
+	path := strings.Trim(strings.TrimSpace(pathName), `"`)
+	if path == "" {
+		return ""
+	}
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasPrefix(lower, `\??\`):
+		return path[len(`\??\`):]
+	case strings.HasPrefix(lower, `\systemroot\`):
+		if systemRoot == "" {
+			return ""
+		}
+		return systemRoot + path[len(`\SystemRoot`):]
+	case len(path) >= 2 && path[1] == ':', strings.HasPrefix(path, `\\`):
+		return path
+	default:
+		if systemRoot == "" {
+			return ""
+		}
+		return systemRoot + `\` + strings.TrimPrefix(path, `\`)
+	}
+}
+
+// fileVersion reads the file version from a binary's version resource, e.g. 10.0.22621.2506
+func fileVersion(path string) string {
+	size, err := windows.GetFileVersionInfoSize(path, nil)
+	if err != nil || size == 0 {
+		return ""
+	}
+	buffer := make([]byte, size)
+	if err := windows.GetFileVersionInfo(path, 0, size, unsafe.Pointer(&buffer[0])); err != nil {
+		return ""
+	}
+	var info *windows.VS_FIXEDFILEINFO
+	var length uint32
+	if err := windows.VerQueryValue(unsafe.Pointer(&buffer[0]), `\`, unsafe.Pointer(&info), &length); err != nil || length == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%d.%d",
+		info.FileVersionMS>>16, info.FileVersionMS&0xffff,
+		info.FileVersionLS>>16, info.FileVersionLS&0xffff)
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestDriverFilePath(t *testing.T) {
+	tests := []struct {
+		pathName string
+		expected string
+	}{
+		{`C:\Windows\system32\drivers\ACPI.sys`, `C:\Windows\system32\drivers\ACPI.sys`},
+		{`\SystemRoot\System32\drivers\ntfs.sys`, `C:\Windows\System32\drivers\ntfs.sys`},
+		{`\??\C:\ProgramData\Vendor\widget.sys`, `C:\ProgramData\Vendor\widget.sys`},
+		{`System32\drivers\tcpip.sys`, `C:\Windows\System32\drivers\tcpip.sys`},
+		{`"C:\Program Files\Vendor\filter.sys"`, `C:\Program Files\Vendor\filter.sys`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if path := driverFilePath(tt.pathName, `C:\Windows`); path != tt.expected {
+			t.Errorf("driverFilePath(%q) = %q, expected %q", tt.pathName, path, tt.expected)
+		}
+	}
+	if path := driverFilePath(`\SystemRoot\System32\drivers\ntfs.sys`, ""); path != "" {
+		t.Errorf("driverFilePath() without SystemRoot = %q, expected none", path)
+	}
+}
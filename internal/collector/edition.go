@@ -0,0 +1,34 @@
+package collector
+
+// Windows installation types with subsystems left out, as recorded in InstallationType
+const (
+	installServerCore = "Server Core"
+	installNanoServer = "Nano Server"
+)
+
+// absentModules lists, per minimal Windows installation, the modules whose subsystems it
+// leaves out. Collecting them would only wait for WMI queries to time out and report empty
+// sections, so they are skipped with the reason noted in the report's errors
+var absentModules = map[string]map[string]string{
+	installServerCore: {
+		"battery": "Server Core has no battery class driver or its WMI classes",
+	},
+	installNanoServer: {
+		"battery":     "Nano Server has no battery class driver or its WMI classes",
+		"displays":    "Nano Server has no display driver stack or WmiMonitorID",
+		"gpu":         "Nano Server has no display driver stack or Win32_VideoController",
+		"thermal":     "Nano Server has no ACPI thermal zone WMI classes or powercfg",
+		"sensors":     "Nano Server has no ACPI thermal zone WMI classes or hardware monitor",
+		"accelerator": "Nano Server has no Win32_PnPEntity WMI class",
+		"audio":       "Nano Server has no Win32_PnPEntity WMI class",
+		"bluetooth":   "Nano Server has no Win32_PnPEntity WMI class",
+		"pci":         "Nano Server has no Win32_PnPEntity WMI class",
+		"usb":         "Nano Server has no Win32_PnPEntity WMI class",
+	},
+}
+
+// unavailableModules returns the modules to skip on this installation type, with why;
+// nil for full installations and other platforms
+func unavailableModules(installationType string) map[string]string {
+	return absentModules[installationType]
+}
@@ -0,0 +1,30 @@
+package collector
+
+import "testing"
+
+func TestUnavailableModules(t *testing.T) {
+	tests := []struct {
+		installationType string
+		want             []string
+	}{
+		{"Server Core", []string{"battery"}},
+		{"Nano Server", []string{"accelerator", "audio", "battery", "bluetooth", "displays", "gpu", "pci", "sensors", "thermal", "usb"}},
+		{"Server", nil},
+		{"Client", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.installationType, func(t *testing.T) {
+			got := unavailableModules(tt.installationType)
+			if len(got) != len(tt.want) {
+				t.Fatalf("unavailableModules(%q) = %v; want %v", tt.installationType, got, tt.want)
+			}
+			for _, module := range tt.want {
+				if got[module] == "" {
+					t.Errorf("unavailableModules(%q) missing a reason for %s", tt.installationType, module)
+				}
+			}
+		})
+	}
+}
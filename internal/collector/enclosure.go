@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// EnclosureSlots maps the drives in SCSI enclosures (SAS/SATA backplanes) to their slots
+// Drives outside an enclosure, and systems without enclosure services, yield an empty list
+func EnclosureSlots() []types.EnclosureSlot {
+	return enclosureSlotsPlatform()
+}
+
+// LocateDrive turns the identification LED of the slot holding device on or off
+func LocateDrive(device string, on bool) (*types.EnclosureSlot, error) {
+	slot := findSlot(EnclosureSlots(), device, "")
+	if slot == nil {
+		return nil, fmt.Errorf("%s is not in an enclosure slot that reports its drive", device)
+	}
+	if err := setSlotLocatePlatform(*slot, on); err != nil {
+		return slot, err
+	}
+	slot.Locate = on
+	return slot, nil
+}
+
+// findSlot returns the slot holding a drive, matched by device path or serial number
+func findSlot(slots []types.EnclosureSlot, device, serial string) *types.EnclosureSlot {
+	for i := range slots {
+		if slots[i].Device == device || (serial != "" && slots[i].Serial == serial) {
+			slot := slots[i]
+			return &slot
+		}
+	}
+	return nil
+}
+
+func attachDiskSlots(disks []types.PhysicalDisk, slots []types.EnclosureSlot) {
+	if len(slots) == 0 {
+		return
+	}
+	for i := range disks {
+		disks[i].Slot = findSlot(slots, disks[i].Name, disks[i].SerialNumber)
+	}
+}
+
+func attachSMARTSlots(drives []types.SMARTInfo, slots []types.EnclosureSlot) {
+	if len(slots) == 0 {
+		return
+	}
+	for i := range drives {
+		drives[i].Slot = findSlot(slots, drives[i].Device, drives[i].Serial)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build darwin
+
+package collector
+
+import (
+	"errors"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// macOS has no enclosure services interface for SAS/SATA backplanes
+func enclosureSlotsPlatform() []types.EnclosureSlot {
+	return nil
+}
+
+func setSlotLocatePlatform(types.EnclosureSlot, bool) error {
+	return errors.New("enclosure slot LEDs are not supported on macOS")
+}
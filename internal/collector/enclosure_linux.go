@@ -0,0 +1,124 @@
+//go:build linux
+
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// enclosureClassPath is populated by the kernel ses driver, one directory per enclosure
+const enclosureClassPath = "/sys/class/enclosure"
+
+func enclosureSlotsPlatform() []types.EnclosureSlot {
+	return scanEnclosures(hostPath(enclosureClassPath))
+}
+
+// scanEnclosures walks every enclosure component that links to a block device
+func scanEnclosures(root string) []types.EnclosureSlot {
+	enclosures, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var slots []types.EnclosureSlot
+	for _, enclosure := range enclosures {
+		dir := filepath.Join(root, enclosure.Name())
+		components, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, component := range components {
+			componentDir := filepath.Join(dir, component.Name())
+			device := componentBlockDevice(componentDir)
+			if device == "" {
+				continue
+			}
+
+			slot := types.EnclosureSlot{
+				Device:    device,
+				Enclosure: enclosure.Name(),
+				Slot:      componentSlot(componentDir, component.Name()),
+				Label:     component.Name(),
+			}
+			if locate, err := readSysFile(filepath.Join(componentDir, "locate")); err == nil {
+				slot.Locate = strings.TrimSpace(locate) == "1"
+			}
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// componentBlockDevice returns /dev/sdX for the disk linked from an enclosure component
+func componentBlockDevice(componentDir string) string {
+	blocks, err := os.ReadDir(filepath.Join(componentDir, "device", "block"))
+	if err != nil || len(blocks) == 0 {
+		return ""
+	}
+	return "/dev/" + blocks[0].Name()
+}
+
+// componentSlot reads the slot number, falling back to the trailing digits of the
+// component name on kernels without the slot attribute
+func componentSlot(componentDir, name string) int {
+	if value, err := readSysFile(filepath.Join(componentDir, "slot")); err == nil {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			return n
+		}
+	}
+	digits := strings.TrimLeft(name, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz _-")
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// setSlotLocatePlatform writes the component's locate attribute, falling back to
+// sg_ses against the enclosure's SCSI generic device when the kernel refuses
+func setSlotLocatePlatform(slot types.EnclosureSlot, on bool) error {
+	return setSlotLocate(hostPath(enclosureClassPath), slot, on)
+}
+
+func setSlotLocate(root string, slot types.EnclosureSlot, on bool) error {
+	value := "0"
+	if on {
+		value = "1"
+	}
+	locatePath := filepath.Join(root, slot.Enclosure, slot.Label, "locate")
+	err := os.WriteFile(locatePath, []byte(value), 0)
+	if err == nil {
+		return nil
+	}
+
+	generic := enclosureGenericDevice(filepath.Join(root, slot.Enclosure))
+	if generic == "" {
+		return fmt.Errorf("failed to set locate LED: %w", err)
+	}
+	if _, lookErr := sandbox.LookPath("sg_ses"); lookErr != nil {
+		return fmt.Errorf("failed to set locate LED: %w (install sg3-utils for the sg_ses fallback)", err)
+	}
+
+	action := "--clear=locate"
+	if on {
+		action = "--set=locate"
+	}
+	out, sgErr := sandbox.Command("sg_ses", "--dev-slot-num="+strconv.Itoa(slot.Slot), action, generic).CombinedOutput()
+	if sgErr != nil {
+		return fmt.Errorf("sg_ses failed: %w: %s", sgErr, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// enclosureGenericDevice returns the /dev/sgN node of an enclosure's SES device
+func enclosureGenericDevice(enclosureDir string) string {
+	nodes, err := os.ReadDir(filepath.Join(enclosureDir, "device", "scsi_generic"))
+	if err != nil || len(nodes) == 0 {
+		return ""
+	}
+	return "/dev/" + nodes[0].Name()
+}
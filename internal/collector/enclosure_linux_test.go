@@ -0,0 +1,57 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanEnclosures(t *testing.T) {
+	dir := t.TempDir()
+	writeComponent := func(name string, files map[string]string, block string) {
+		componentDir := filepath.Join(dir, "0:0:8:0", name)
+		if err := os.MkdirAll(filepath.Join(componentDir, "device", "block", block), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(componentDir, file), []byte(content+"\n"), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", file, err)
+			}
+		}
+	}
+
+	writeComponent("Slot 03", map[string]string{"slot": "3", "locate": "0"}, "sdc")
+	writeComponent("ArrayDevice04", map[string]string{"locate": "1"}, "sdd")
+	// An empty bay has no device link
+	if err := os.MkdirAll(filepath.Join(dir, "0:0:8:0", "Slot 05"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	slots := scanEnclosures(dir)
+	if len(slots) != 2 {
+		t.Fatalf("scanEnclosures() found %d slots, expected 2: %+v", len(slots), slots)
+	}
+
+	sdc := findSlot(slots, "/dev/sdc", "")
+	if sdc == nil || sdc.Slot != 3 || sdc.Enclosure != "0:0:8:0" || sdc.Label != "Slot 03" || sdc.Locate {
+		t.Errorf("slot for /dev/sdc = %+v", sdc)
+	}
+	// Without a slot attribute the number comes from the component name
+	sdd := findSlot(slots, "/dev/sdd", "")
+	if sdd == nil || sdd.Slot != 4 || !sdd.Locate {
+		t.Errorf("slot for /dev/sdd = %+v", sdd)
+	}
+	if got := sdc.String(); got != "enclosure 0:0:8:0, slot 3 (Slot 03)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if err := setSlotLocate(dir, *sdc, true); err != nil {
+		t.Fatalf("setSlotLocate() error = %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "0:0:8:0", "Slot 03", "locate"))
+	if string(data) != "1" {
+		t.Errorf("locate = %q, expected 1", data)
+	}
+}
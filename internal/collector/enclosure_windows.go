@@ -0,0 +1,62 @@
+//go:build windows
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// msftPhysicalDiskSlot is the enclosure placement Storage Management reports for a disk
+type msftPhysicalDiskSlot struct {
+	DeviceId         string // Disk number, as in \\.\PHYSICALDRIVE<n>
+	SerialNumber     string
+	FriendlyName     string
+	EnclosureNumber  uint16
+	SlotNumber       uint32
+	PhysicalLocation string
+}
+
+func enclosureSlotsPlatform() []types.EnclosureSlot {
+	var disks []msftPhysicalDiskSlot
+	query := "SELECT DeviceId, SerialNumber, FriendlyName, EnclosureNumber, SlotNumber, PhysicalLocation FROM MSFT_PhysicalDisk WHERE SlotNumber IS NOT NULL"
+	if err := wmi.QueryNamespace(query, &disks, `root\Microsoft\Windows\Storage`); err != nil {
+		return nil
+	}
+
+	slots := make([]types.EnclosureSlot, 0, len(disks))
+	for _, d := range disks {
+		slots = append(slots, types.EnclosureSlot{
+			Device:    `\\.\PHYSICALDRIVE` + d.DeviceId,
+			Serial:    strings.TrimSpace(d.SerialNumber),
+			Enclosure: strconv.Itoa(int(d.EnclosureNumber)),
+			Slot:      int(d.SlotNumber),
+			Label:     strings.TrimSpace(d.PhysicalLocation),
+		})
+	}
+	return slots
+}
+
+// setSlotLocatePlatform toggles the LED through the Storage module's identification cmdlets
+func setSlotLocatePlatform(slot types.EnclosureSlot, on bool) error {
+	number := strings.TrimPrefix(slot.Device, `\\.\PHYSICALDRIVE`)
+	if _, err := strconv.Atoi(number); err != nil {
+		return fmt.Errorf("unexpected device %s", slot.Device)
+	}
+
+	cmdlet := "Disable-PhysicalDiskIdentification"
+	if on {
+		cmdlet = "Enable-PhysicalDiskIdentification"
+	}
+	script := fmt.Sprintf("Get-PhysicalDisk | Where-Object DeviceId -eq '%s' | %s", number, cmdlet)
+	out, err := sandbox.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %w: %s", cmdlet, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
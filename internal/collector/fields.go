@@ -0,0 +1,178 @@
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// ParseFields parses --fields, dotted JSON paths such as system.hostname or
+// disk.partitions.mount_point, into a FieldSet, checking each against the report structure. Lists are selected through:
+// disk.partitions.mount_point keeps the mount point of every partition. Returns nil for no paths
+func ParseFields(paths []string) (types.FieldSet, error) {
+	var fields types.FieldSet
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if fields == nil {
+			fields = types.FieldSet{}
+		}
+
+		parts := strings.Split(path, ".")
+		t := reflect.TypeOf(types.SystemInfo{})
+		for i, part := range parts {
+			var ok bool
+			if t, ok = fieldType(t, part); !ok {
+				return nil, fmt.Errorf("invalid field %q: no field %q in %s", path, part, displayPath(parts[:i]))
+			}
+		}
+
+		level := fields
+		for i, part := range parts {
+			child, seen := level[part]
+			if seen && len(child) == 0 {
+				break // Already kept whole by a shorter path
+			}
+			if i == len(parts)-1 {
+				// Keep the whole field, even if longer paths selected parts of it
+				level[part] = types.FieldSet{}
+				break
+			}
+			if !seen {
+				child = types.FieldSet{}
+				level[part] = child
+			}
+			level = child
+		}
+	}
+	return fields, nil
+}
+
+func displayPath(parts []string) string {
+	if len(parts) == 0 {
+		return "the report"
+	}
+	return strings.Join(parts, ".")
+}
+
+// fieldType returns the type of the JSON field name within t, looking through pointers,
+// lists and maps (whose keys are field names of their own)
+func fieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	t = containedType(t)
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem(), true
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldName, embedded, ok := jsonField(field)
+			if !ok {
+				continue
+			}
+			if embedded {
+				if found, ok := fieldType(field.Type, name); ok {
+					return found, true
+				}
+			} else if fieldName == name {
+				return field.Type, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// containedType strips pointers, slices and arrays down to the type they hold
+func containedType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// jsonField returns a struct field's JSON name, or that it is an untagged embedded struct
+// whose fields encoding/json promotes into the parent; ok is false for fields never encoded
+func jsonField(field reflect.StructField) (name string, embedded, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if field.Anonymous && name == "" && containedType(field.Type).Kind() == reflect.Struct {
+		return "", true, true
+	}
+	if !field.IsExported() {
+		return "", false, false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false, true
+}
+
+// SelectFields prunes the report to the selected fields, in place, so every format writes only
+// those: JSON based formats leave the rest out, text formats list the kept values. The
+// timestamp and whether the report is redacted are always kept, as formats use them
+func SelectFields(info *types.SystemInfo, fields types.FieldSet) {
+	if len(fields) == 0 {
+		return
+	}
+	timestamp, timestampFormat, redacted := info.Timestamp, info.TimestampFormat, info.Redacted
+	pruneValue(reflect.ValueOf(info).Elem(), fields)
+	info.Timestamp, info.TimestampFormat, info.Redacted, info.Fields = timestamp, timestampFormat, redacted, fields
+}
+
+func pruneValue(v reflect.Value, fields types.FieldSet) {
+	if len(fields) == 0 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			pruneValue(v.Elem(), fields)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			pruneValue(v.Index(i), fields)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			sub, ok := fields[fmt.Sprint(key.Interface())]
+			if !ok {
+				v.SetMapIndex(key, reflect.Value{})
+				continue
+			}
+			if len(sub) > 0 {
+				// Map values cannot be modified in place
+				value := reflect.New(v.Type().Elem()).Elem()
+				value.Set(v.MapIndex(key))
+				pruneValue(value, sub)
+				v.SetMapIndex(key, value)
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, embedded, ok := jsonField(t.Field(i))
+			field := v.Field(i)
+			switch {
+			case !ok || !field.CanSet():
+			case embedded:
+				pruneValue(field, fields)
+			default:
+				if sub, keep := fields[name]; keep {
+					pruneValue(field, sub)
+				} else {
+					field.Set(reflect.Zero(field.Type()))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields([]string{"system.hostname", " cpu.model_name", "disk.partitions.mount_point", "disk", "processes.top_by_cpu.container.id", "processes.containers.id"})
+	if err != nil {
+		t.Fatalf("ParseFields failed: %v", err)
+	}
+	expected := types.FieldSet{
+		"system":    {"hostname": {}},
+		"cpu":       {"model_name": {}},
+		"disk":      {},
+		"processes": {"top_by_cpu": {"container": {"id": {}}}, "containers": {"id": {}}},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("ParseFields() = %v, expected %v", fields, expected)
+	}
+
+	if fields, err := ParseFields(nil); fields != nil || err != nil {
+		t.Errorf("ParseFields(nil) = %v, %v, expected no selection", fields, err)
+	}
+	for _, path := range []string{"hostname", "cpu.nope", "system.hostname.length", "system..hostname"} {
+		if _, err := ParseFields([]string{path}); err == nil {
+			t.Errorf("ParseFields(%q) succeeded, expected an error", path)
+		}
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	info := &types.SystemInfo{
+		System: &types.SystemData{Hostname: "host", OS: "linux"},
+		CPU:    &types.CPUData{ModelName: "Xeon"},
+		Disk: &types.DiskData{Partitions: []types.PartitionInfo{
+			{Device: "/dev/sda1", MountPoint: "/", UsedPercent: 40},
+		}},
+		Processes: &types.ProcessData{TopByCPU: []types.ProcessInfo{
+			{Name: "dockerd", Env: map[string]string{"PATH": "/usr/bin", "HOME": "/root"}},
+		}},
+	}
+	fields, err := ParseFields([]string{"system.hostname", "disk.partitions.mount_point", "processes.top_by_cpu.env.PATH"})
+	if err != nil {
+		t.Fatalf("ParseFields failed: %v", err)
+	}
+	SelectFields(info, fields)
+
+	if info.System.Hostname != "host" || info.System.OS != "" || info.CPU != nil {
+		t.Errorf("system = %+v, cpu = %+v, expected only the hostname", info.System, info.CPU)
+	}
+	if p := info.Disk.Partitions[0]; p.MountPoint != "/" || p.Device != "" || p.UsedPercent != 0 {
+		t.Errorf("partition = %+v, expected only the mount point", p)
+	}
+	if env := info.Processes.TopByCPU[0].Env; !reflect.DeepEqual(env, map[string]string{"PATH": "/usr/bin"}) {
+		t.Errorf("env = %v, expected only PATH", env)
+	}
+	if info.Fields == nil {
+		t.Error("Fields not set, so JSON would still write the emptied fields")
+	}
+}
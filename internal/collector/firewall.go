@@ -0,0 +1,28 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectFirewall reports the host's firewalls with a summary of their rules, and whether any
+// of them filters traffic
+func CollectFirewall() (*types.FirewallData, error) {
+	firewalls := collectFirewallsPlatform()
+	if len(firewalls) == 0 {
+		return nil, fmt.Errorf("no firewall found")
+	}
+	data := &types.FirewallData{Firewalls: firewalls}
+	for _, firewall := range firewalls {
+		if firewall.Active {
+			data.Active = true
+		}
+	}
+	return data, nil
+}
+
+// ruleCount is a counted number of rules, for Firewall.Rules
+func ruleCount(n int) *int {
+	return &n
+}
@@ -0,0 +1,100 @@
+//go:build darwin
+
+package collector
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// socketfilterfw configures the Application Firewall, the firewall in System Settings
+const socketfilterfw = "/usr/libexec/ApplicationFirewall/socketfilterfw"
+
+var (
+	// alfStatePattern matches "Firewall is enabled. (State = 1)"; state 2 blocks all incoming connections
+	alfStatePattern = regexp.MustCompile(`\(State = (\d)\)`)
+	// alfAppsPattern matches "ALF: total number of apps = 3" and "Total number of apps = 3"
+	alfAppsPattern = regexp.MustCompile(`(?i)total number of apps = (\d+)`)
+)
+
+// collectFirewallsPlatform reads the Application Firewall's state and the apps it has rules for,
+// and whether the pf packet filter is enabled with its rules, which needs root
+func collectFirewallsPlatform() []types.Firewall {
+	var firewalls []types.Firewall
+	if firewall := applicationFirewall(); firewall != nil {
+		firewalls = append(firewalls, *firewall)
+	}
+	if out, err := sandbox.Command("pfctl", "-s", "info").Output(); err == nil {
+		firewall := types.Firewall{Name: "pf", Active: parsePfEnabled(string(out))}
+		if rules, err := sandbox.Command("pfctl", "-s", "rules").Output(); err == nil {
+			firewall.Rules = ruleCount(countLines(string(rules)))
+		}
+		firewalls = append(firewalls, firewall)
+	}
+	return firewalls
+}
+
+// applicationFirewall asks socketfilterfw for the firewall's state and per-app rules
+func applicationFirewall() *types.Firewall {
+	out, err := sandbox.Command(socketfilterfw, "--getglobalstate").Output()
+	if err != nil {
+		return nil
+	}
+	firewall := &types.Firewall{Name: "Application Firewall"}
+	state := parseALFState(string(out))
+	firewall.Active = state > 0
+	if out, err := sandbox.Command(socketfilterfw, "--getblockall").Output(); err == nil && (state == 2 || parseALFBlockAll(string(out))) {
+		firewall.DefaultIncoming = "deny"
+	}
+	if out, err := sandbox.Command(socketfilterfw, "--listapps").Output(); err == nil {
+		if match := alfAppsPattern.FindStringSubmatch(string(out)); match != nil {
+			apps, _ := strconv.Atoi(match[1])
+			firewall.Rules = ruleCount(apps)
+		}
+	}
+	return firewall
+}
+
+// parseALFState returns the firewall state: 0 off, 1 on, 2 blocking all incoming connections
+func parseALFState(output string) int {
+	if match := alfStatePattern.FindStringSubmatch(output); match != nil {
+		state, _ := strconv.Atoi(match[1])
+		return state
+	}
+	if strings.Contains(strings.ToLower(output), "enabled") {
+		return 1
+	}
+	return 0
+}
+
+// parseALFBlockAll reads --getblockall, which prints "Block all ENABLED!" or "Firewall is set to
+// block all non-essential incoming connections" depending on the macOS version
+func parseALFBlockAll(output string) bool {
+	output = strings.ToLower(output)
+	return strings.Contains(output, "block all enabled") || strings.Contains(output, "set to block all")
+}
+
+// parsePfEnabled reads the "Status: Enabled for 0 days 01:02:03" line of pfctl -s info
+func parsePfEnabled(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if status, ok := strings.CutPrefix(strings.TrimSpace(line), "Status:"); ok {
+			return strings.HasPrefix(strings.TrimSpace(status), "Enabled")
+		}
+	}
+	return false
+}
+
+// countLines counts the non-empty lines of a tool's output, e.g. one pf rule per line
+func countLines(output string) int {
+	n := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
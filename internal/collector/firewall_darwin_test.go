@@ -0,0 +1,37 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+func TestParseALFState(t *testing.T) {
+	tests := []struct {
+		output string
+		want   int
+	}{
+		{"Firewall is enabled. (State = 1)\n", 1},
+		{"Firewall is blocking all non-essential incoming connections. (State = 2)\n", 2},
+		{"Firewall is disabled. (State = 0)\n", 0},
+		{"Firewall is enabled.\n", 1},
+	}
+	for _, tt := range tests {
+		if got := parseALFState(tt.output); got != tt.want {
+			t.Errorf("parseALFState(%q) = %d, expected %d", tt.output, got, tt.want)
+		}
+	}
+	if !parseALFBlockAll("Block all ENABLED! \n") || !parseALFBlockAll("Firewall is set to block all non-essential incoming connections\n") || parseALFBlockAll("Block all DISABLED! \n") {
+		t.Error("parseALFBlockAll() misread the block-all setting")
+	}
+}
+
+func TestParsePfEnabled(t *testing.T) {
+	if !parsePfEnabled("No ALTQ support in kernel\nStatus: Enabled for 0 days 01:02:03           Debug: Urgent\n") {
+		t.Error("parsePfEnabled() = false, expected true")
+	}
+	if parsePfEnabled("Status: Disabled                              Debug: Urgent\n") {
+		t.Error("parsePfEnabled() = true, expected false")
+	}
+	if n := countLines("scrub-anchor \"com.apple/*\" all fragment reassemble\nanchor \"com.apple/*\" all\n\n"); n != 2 {
+		t.Errorf("countLines() = %d, expected 2", n)
+	}
+}
@@ -0,0 +1,271 @@
+//go:build linux
+
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectFirewallsPlatform finds the ufw and firewalld front ends, and the nftables rules loaded
+// in the kernel, or the iptables rules where nft is not installed. Kernel rules are only
+// readable as root
+func collectFirewallsPlatform() []types.Firewall {
+	var firewalls []types.Firewall
+	if firewall := ufwFirewall(hostfs); firewall != nil {
+		firewalls = append(firewalls, *firewall)
+	}
+	if firewall := firewalldFirewall(); firewall != nil {
+		firewalls = append(firewalls, *firewall)
+	}
+	if out, err := sandbox.Command("nft", "-j", "list", "ruleset").Output(); err == nil {
+		if firewall := parseNftRuleset(out); firewall != nil {
+			firewalls = append(firewalls, *firewall)
+		}
+	} else if out, err := sandbox.Command("iptables-save").Output(); err == nil {
+		firewalls = append(firewalls, *parseIptablesSave(string(out)))
+	}
+	return firewalls
+}
+
+// ufwFirewall reads ufw's configuration: whether it is enabled, its default incoming policy and
+// the rules added with `ufw allow` and the like, which are stored root-only
+func ufwFirewall(fsys fsReader) *types.Firewall {
+	conf, err := readString(fsys, "/etc/ufw/ufw.conf")
+	if err != nil {
+		return nil
+	}
+	firewall := &types.Firewall{
+		Name:   "ufw",
+		Active: strings.EqualFold(shellVar(conf, "ENABLED"), "yes"),
+	}
+	if defaults, err := readString(fsys, "/etc/default/ufw"); err == nil {
+		firewall.DefaultIncoming = policyAction(shellVar(defaults, "DEFAULT_INPUT_POLICY"))
+	}
+
+	rules := 0
+	for _, path := range []string{"/etc/ufw/user.rules", "/etc/ufw/user6.rules"} {
+		content, err := readString(fsys, path)
+		if err != nil {
+			return firewall
+		}
+		// Each rule is recorded as a "### tuple ### allow tcp 22 ..." comment above its chains
+		for _, line := range strings.Split(content, "\n") {
+			if strings.HasPrefix(line, "### tuple ###") {
+				rules++
+			}
+		}
+	}
+	firewall.Rules = ruleCount(rules)
+	return firewall
+}
+
+// shellVar returns a variable's value from a shell-style KEY=value file, without quotes
+func shellVar(content, name string) string {
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && key == name {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
+
+// policyAction maps a netfilter or firewalld verdict to allow, deny or reject
+func policyAction(verdict string) string {
+	switch strings.ToUpper(strings.Trim(verdict, "%")) {
+	case "ACCEPT":
+		return "allow"
+	case "DROP":
+		return "deny"
+	case "REJECT", "DEFAULT":
+		// firewalld's default target rejects what the zone does not allow
+		return "reject"
+	}
+	return ""
+}
+
+// firewalldFirewall asks firewalld for its state and the zones in use; nil without firewalld
+func firewalldFirewall() *types.Firewall {
+	if _, err := sandbox.LookPath("firewall-cmd"); err != nil {
+		return nil
+	}
+	firewall := &types.Firewall{Name: "firewalld"}
+	// Exits 252 and prints "not running" when stopped
+	out, _ := sandbox.Command("firewall-cmd", "--state").Output()
+	if strings.TrimSpace(string(out)) != "running" {
+		return firewall
+	}
+	firewall.Active = true
+
+	out, err := sandbox.Command("firewall-cmd", "--list-all-zones").Output()
+	if err != nil {
+		return firewall
+	}
+	firewall.Zones = parseFirewalldZones(string(out))
+	rules := 0
+	for _, zone := range firewall.Zones {
+		rules += zone.Rules
+	}
+	firewall.Rules = ruleCount(rules)
+	return firewall
+}
+
+// firewalldRuleKeys are the zone settings that each entry of lets traffic in or forwards it
+var firewalldRuleKeys = map[string]bool{
+	"services":      true,
+	"ports":         true,
+	"protocols":     true,
+	"source-ports":  true,
+	"forward-ports": true,
+	"icmp-blocks":   true,
+}
+
+// parseFirewalldZones reads `firewall-cmd --list-all-zones`, keeping the active zones, i.e.
+// those bound to interfaces or sources:
+//
+//	public (default, active)
+//	  target: default
+//	  interfaces: eth0
+//	  services: dhcpv6-client ssh
+//	  ports: 8080/tcp
+//	  rich rules:
+//		rule family="ipv4" source address="10.0.0.0/8" accept
+//
+// A zone's rules are its services, ports and other entries, and its rich rules
+func parseFirewalldZones(output string) []types.FirewallZone {
+	var zones []types.FirewallZone
+	var zone *types.FirewallZone
+	flush := func() {
+		if zone != nil && zone.Active {
+			zones = append(zones, *zone)
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			flush()
+			name, flags, _ := strings.Cut(trimmed, " ")
+			zone = &types.FirewallZone{Name: name, Active: strings.Contains(flags, "active")}
+			continue
+		}
+		if zone == nil {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "rule ") {
+			zone.Rules++
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		switch {
+		case key == "target":
+			zone.DefaultIncoming = policyAction(strings.TrimSpace(value))
+		case key == "interfaces":
+			zone.Interfaces = strings.Fields(value)
+		case firewalldRuleKeys[key]:
+			zone.Rules += len(strings.Fields(value))
+		}
+	}
+	flush()
+	return zones
+}
+
+// nftChainRef identifies an nftables chain, which rules refer to by family, table and name
+type nftChainRef struct {
+	Family string `json:"family"`
+	Table  string `json:"table"`
+	Chain  string `json:"chain"`
+}
+
+// nftRuleset is the part of `nft -j list ruleset` that is summarized: base chains and rules.
+// Every entry of the list is an object with a single key naming its kind
+type nftRuleset struct {
+	Nftables []struct {
+		Chain *struct {
+			Family string `json:"family"`
+			Table  string `json:"table"`
+			Name   string `json:"name"`
+			Type   string `json:"type"`
+			Hook   string `json:"hook"`
+			Policy string `json:"policy"`
+		} `json:"chain"`
+		Rule *nftChainRef `json:"rule"`
+	} `json:"nftables"`
+}
+
+// parseNftRuleset counts the nftables rules. The firewall is active when a filter chain on the
+// input hook drops by default or holds rules
+func parseNftRuleset(data []byte) *types.Firewall {
+	var ruleset nftRuleset
+	if err := json.Unmarshal(data, &ruleset); err != nil {
+		return nil
+	}
+
+	firewall := &types.Firewall{Name: "nftables"}
+	inputChains := make(map[nftChainRef]bool)
+	for _, entry := range ruleset.Nftables {
+		if chain := entry.Chain; chain != nil && chain.Hook == "input" && chain.Type == "filter" {
+			inputChains[nftChainRef{Family: chain.Family, Table: chain.Table, Chain: chain.Name}] = true
+			// Packets pass every input chain, so one that drops decides
+			if action := policyAction(chain.Policy); firewall.DefaultIncoming == "" || action == "deny" {
+				firewall.DefaultIncoming = action
+			}
+		}
+	}
+
+	rules, inputRules := 0, 0
+	for _, entry := range ruleset.Nftables {
+		if entry.Rule == nil {
+			continue
+		}
+		rules++
+		if inputChains[*entry.Rule] {
+			inputRules++
+		}
+	}
+	firewall.Rules = ruleCount(rules)
+	firewall.Active = firewall.DefaultIncoming == "deny" || inputRules > 0
+	return firewall
+}
+
+// parseIptablesSave counts the rules in `iptables-save` output. The firewall is active when
+// the filter table's INPUT chain drops by default or holds rules:
+//
+//	*filter
+//	:INPUT DROP [0:0]
+//	-A INPUT -p tcp --dport 22 -j ACCEPT
+//	COMMIT
+func parseIptablesSave(output string) *types.Firewall {
+	firewall := &types.Firewall{Name: "iptables"}
+	table := ""
+	rules, inputRules := 0, 0
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "*"):
+			table = line[1:]
+		case strings.HasPrefix(line, ":INPUT ") && table == "filter":
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				firewall.DefaultIncoming = policyAction(fields[1])
+			}
+		case strings.HasPrefix(line, "-A "):
+			rules++
+			if table == "filter" && strings.HasPrefix(line, "-A INPUT ") {
+				inputRules++
+			}
+		}
+	}
+	firewall.Rules = ruleCount(rules)
+	firewall.Active = firewall.DefaultIncoming == "deny" || firewall.DefaultIncoming == "reject" || inputRules > 0
+	return firewall
+}
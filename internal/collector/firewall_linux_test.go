@@ -0,0 +1,142 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const ufwUserRules = `*filter
+:ufw-user-input - [0:0]
+### RULES ###
+
+### tuple ### allow tcp 22 0.0.0.0/0 any 0.0.0.0/0 in
+-A ufw-user-input -p tcp --dport 22 -j ACCEPT
+
+### tuple ### allow tcp 443 0.0.0.0/0 any 0.0.0.0/0 in
+-A ufw-user-input -p tcp --dport 443 -j ACCEPT
+
+### END RULES ###
+COMMIT
+`
+
+func TestUfwFirewall(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	if firewall := ufwFirewall(hostReader{root: root}); firewall != nil {
+		t.Fatalf("ufwFirewall() without ufw = %+v, expected nil", firewall)
+	}
+
+	writeFile("etc/ufw/ufw.conf", "# /etc/ufw/ufw.conf\nENABLED=yes\nLOGLEVEL=low\n")
+	writeFile("etc/default/ufw", "IPV6=yes\nDEFAULT_INPUT_POLICY=\"DROP\"\nDEFAULT_OUTPUT_POLICY=\"ACCEPT\"\n")
+	firewall := ufwFirewall(hostReader{root: root})
+	if firewall == nil || !firewall.Active || firewall.DefaultIncoming != "deny" || firewall.Rules != nil {
+		t.Fatalf("ufwFirewall() = %+v, expected an active ufw denying incoming, with unreadable rules", firewall)
+	}
+
+	writeFile("etc/ufw/user.rules", ufwUserRules)
+	writeFile("etc/ufw/user6.rules", "### tuple ### allow tcp 22 ::/0 any ::/0 in\n")
+	firewall = ufwFirewall(hostReader{root: root})
+	if firewall.Rules == nil || *firewall.Rules != 3 {
+		t.Errorf("ufwFirewall().Rules = %v, expected 3", firewall.Rules)
+	}
+}
+
+func TestParseFirewalldZones(t *testing.T) {
+	output := `block
+  target: %%REJECT%%
+  interfaces: 
+  services: 
+
+public (default, active)
+  target: default
+  icmp-block-inversion: no
+  interfaces: eth0 eth1
+  sources: 
+  services: cockpit dhcpv6-client ssh
+  ports: 8080/tcp
+  protocols: 
+  forward-ports: 
+  rich rules: 
+	rule family="ipv4" source address="10.0.0.0/8" accept
+
+trusted (active)
+  target: ACCEPT
+  interfaces: docker0
+  services: 
+`
+	want := []types.FirewallZone{
+		{Name: "public", Active: true, DefaultIncoming: "reject", Interfaces: []string{"eth0", "eth1"}, Rules: 5},
+		{Name: "trusted", Active: true, DefaultIncoming: "allow", Interfaces: []string{"docker0"}},
+	}
+	if zones := parseFirewalldZones(output); !reflect.DeepEqual(zones, want) {
+		t.Errorf("parseFirewalldZones() = %+v, expected %+v", zones, want)
+	}
+}
+
+func TestParseNftRuleset(t *testing.T) {
+	ruleset := `{"nftables": [
+		{"metainfo": {"version": "1.0.6", "json_schema_version": 1}},
+		{"table": {"family": "inet", "name": "filter", "handle": 1}},
+		{"chain": {"family": "inet", "table": "filter", "name": "input", "handle": 1, "type": "filter", "hook": "input", "prio": 0, "policy": "drop"}},
+		{"chain": {"family": "inet", "table": "filter", "name": "forward", "handle": 2, "type": "filter", "hook": "forward", "prio": 0, "policy": "accept"}},
+		{"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 4, "expr": []}},
+		{"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 5, "expr": []}},
+		{"rule": {"family": "inet", "table": "filter", "chain": "forward", "handle": 6, "expr": []}}
+	]}`
+	firewall := parseNftRuleset([]byte(ruleset))
+	if firewall == nil || !firewall.Active || firewall.DefaultIncoming != "deny" || firewall.Rules == nil || *firewall.Rules != 3 {
+		t.Fatalf("parseNftRuleset() = %+v, expected an active ruleset of 3 rules denying incoming", firewall)
+	}
+
+	// Rules only in a NAT table, as container runtimes add, do not filter incoming traffic
+	nat := `{"nftables": [
+		{"chain": {"family": "ip", "table": "nat", "name": "POSTROUTING", "type": "nat", "hook": "postrouting", "policy": "accept"}},
+		{"rule": {"family": "ip", "table": "nat", "chain": "POSTROUTING", "expr": []}}
+	]}`
+	if firewall := parseNftRuleset([]byte(nat)); firewall == nil || firewall.Active {
+		t.Errorf("parseNftRuleset(nat only) = %+v, expected inactive", firewall)
+	}
+	if firewall := parseNftRuleset([]byte("not json")); firewall != nil {
+		t.Errorf("parseNftRuleset(invalid) = %+v, expected nil", firewall)
+	}
+}
+
+func TestParseIptablesSave(t *testing.T) {
+	output := `# Generated by iptables-save v1.8.9
+*nat
+:PREROUTING ACCEPT [0:0]
+-A POSTROUTING -s 172.17.0.0/16 ! -o docker0 -j MASQUERADE
+COMMIT
+*filter
+:INPUT ACCEPT [0:0]
+:FORWARD DROP [0:0]
+:OUTPUT ACCEPT [0:0]
+-A FORWARD -o docker0 -j ACCEPT
+COMMIT
+`
+	firewall := parseIptablesSave(output)
+	if firewall.Active || firewall.DefaultIncoming != "allow" || firewall.Rules == nil || *firewall.Rules != 2 {
+		t.Errorf("parseIptablesSave() = %+v, expected an inactive firewall with 2 rules", firewall)
+	}
+
+	firewall = parseIptablesSave("*filter\n:INPUT ACCEPT [0:0]\n-A INPUT -p tcp --dport 22 -j ACCEPT\n-A INPUT -j REJECT\nCOMMIT\n")
+	if !firewall.Active || *firewall.Rules != 2 {
+		t.Errorf("parseIptablesSave() = %+v, expected active with 2 input rules", firewall)
+	}
+}
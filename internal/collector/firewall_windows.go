@@ -0,0 +1,165 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Where Windows Firewall keeps its settings and rules, and where Group Policy overrides them
+const (
+	firewallPolicyPath      = `SYSTEM\CurrentControlSet\Services\SharedAccess\Parameters\FirewallPolicy`
+	firewallGroupPolicyPath = `SOFTWARE\Policies\Microsoft\WindowsFirewall`
+)
+
+// windowsFirewallProfiles are the firewall profiles, by the name rules use for them and their
+// registry key
+var windowsFirewallProfiles = []struct{ name, key string }{
+	{"Domain", "DomainProfile"},
+	{"Private", "StandardProfile"},
+	{"Public", "PublicProfile"},
+}
+
+// firewallProduct is a firewall registered with Windows Security Center (workstations only)
+type firewallProduct struct {
+	DisplayName  string
+	ProductState uint32
+}
+
+// collectFirewallsPlatform reads the Windows Firewall profiles and enabled inbound rules from
+// the registry, and lists the third-party firewalls registered with Security Center
+func collectFirewallsPlatform() []types.Firewall {
+	var firewalls []types.Firewall
+	if firewall := windowsFirewall(); firewall != nil {
+		firewalls = append(firewalls, *firewall)
+	}
+
+	var products []firewallProduct
+	if err := wmi.QueryNamespace("SELECT DisplayName, ProductState FROM FirewallProduct", &products, `root\SecurityCenter2`); err == nil {
+		for _, product := range products {
+			firewalls = append(firewalls, types.Firewall{Name: product.DisplayName, Active: productEnabled(product.ProductState)})
+		}
+	}
+	return firewalls
+}
+
+// productEnabled decodes Security Center's productState, whose middle byte is 0x10 or 0x11
+// for a product that is on
+func productEnabled(state uint32) bool {
+	return (state>>8)&0x10 != 0
+}
+
+// windowsFirewall reads each profile's state and default inbound action, with Group Policy
+// taking precedence, and counts the enabled inbound rules that apply to each
+func windowsFirewall() *types.Firewall {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, firewallPolicyPath, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	key.Close()
+
+	var rules []string
+	rules = append(rules, registryStringValues(firewallPolicyPath+`\FirewallRules`)...)
+	rules = append(rules, registryStringValues(firewallGroupPolicyPath+`\FirewallRules`)...)
+
+	firewall := &types.Firewall{Name: "Windows Firewall", Rules: ruleCount(0)}
+	for _, profile := range windowsFirewallProfiles {
+		enabled := firewallPolicyValue(profile.key, "EnableFirewall", 1)
+		// Inbound connections are blocked unless the profile says otherwise
+		incoming := "deny"
+		if firewallPolicyValue(profile.key, "DefaultInboundAction", 1) == 0 {
+			incoming = "allow"
+		}
+		zone := types.FirewallZone{Name: profile.name, Active: enabled != 0, DefaultIncoming: incoming}
+		for _, rule := range rules {
+			if inboundRuleApplies(rule, profile.name) {
+				zone.Rules++
+			}
+		}
+		firewall.Zones = append(firewall.Zones, zone)
+		firewall.Active = firewall.Active || zone.Active
+	}
+	for _, rule := range rules {
+		if inboundRuleApplies(rule, "") {
+			*firewall.Rules++
+		}
+	}
+	return firewall
+}
+
+// firewallPolicyValue reads a profile's DWORD setting, from Group Policy when it sets one
+func firewallPolicyValue(profileKey, name string, fallback uint64) uint64 {
+	for _, path := range []string{firewallGroupPolicyPath, firewallPolicyPath} {
+		key, err := registry.OpenKey(registry.LOCAL_MACHINE, path+`\`+profileKey, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		value, _, err := key.GetIntegerValue(name)
+		key.Close()
+		if err == nil {
+			return value
+		}
+	}
+	return fallback
+}
+
+// registryStringValues returns the string values of a key, e.g. the rules of a FirewallRules key
+func registryStringValues(path string) []string {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+	names, err := key.ReadValueNames(-1)
+	if err != nil {
+		return nil
+	}
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		if value, _, err := key.GetStringValue(name); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// inboundRuleApplies reports whether a rule is an enabled inbound rule for the profile, or for
+// any profile when profile is empty. Rules are stored as
+//
+//	v2.30|Action=Allow|Active=TRUE|Dir=In|Protocol=6|Profile=Domain|Profile=Private|LPort=3389|Name=...|
+//
+// with no Profile field for rules that apply to every profile
+func inboundRuleApplies(rule, profile string) bool {
+	active, inbound := false, false
+	var profiles []string
+	for _, field := range strings.Split(rule, "|") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Active":
+			active = strings.EqualFold(value, "TRUE")
+		case "Dir":
+			inbound = value == "In"
+		case "Profile":
+			profiles = append(profiles, value)
+		}
+	}
+	if !active || !inbound {
+		return false
+	}
+	if profile == "" || len(profiles) == 0 {
+		return true
+	}
+	for _, p := range profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestInboundRuleApplies(t *testing.T) {
+	rdp := "v2.30|Action=Allow|Active=TRUE|Dir=In|Protocol=6|Profile=Domain|Profile=Private|LPort=3389|Name=@FirewallAPI.dll,-28753|"
+	all := "v2.10|Action=Allow|Active=TRUE|Dir=In|Protocol=17|LPort=5353|Name=mDNS (UDP-In)|"
+	disabled := "v2.30|Action=Allow|Active=FALSE|Dir=In|Protocol=6|LPort=80|Name=Web|"
+	outbound := "v2.30|Action=Block|Active=TRUE|Dir=Out|Name=Telemetry|"
+
+	tests := []struct {
+		rule, profile string
+		want          bool
+	}{
+		{rdp, "Private", true},
+		{rdp, "Public", false},
+		{rdp, "", true},
+		{all, "Public", true},
+		{disabled, "", false},
+		{outbound, "", false},
+	}
+	for _, tt := range tests {
+		if got := inboundRuleApplies(tt.rule, tt.profile); got != tt.want {
+			t.Errorf("inboundRuleApplies(%q, %q) = %v, expected %v", tt.rule, tt.profile, got, tt.want)
+		}
+	}
+}
+
+func TestProductEnabled(t *testing.T) {
+	// 0x061100 is on and up to date, 0x060100 off
+	if !productEnabled(0x061100) || productEnabled(0x060100) {
+		t.Error("productEnabled() misread the product state")
+	}
+}
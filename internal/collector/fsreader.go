@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fsReader is how the Linux collectors read the host's files. Paths are written as on the
+// host (/sys/class/thermal/..., /proc/1/cgroup), so tests can swap in a fixture tree and
+// --host-root and --sysfs-root can point the collectors at a host's filesystems mounted
+// elsewhere, as in a container started with -v /:/host:ro
+type fsReader interface {
+	ReadFile(path string) ([]byte, error)
+	ReadDir(path string) ([]os.DirEntry, error)
+	Glob(pattern string) ([]string, error)
+}
+
+// hostReader reads absolute paths from below root, and /sys paths from below sysfs when
+// set. With neither, paths are read as given
+type hostReader struct {
+	root  string
+	sysfs string
+}
+
+func (r hostReader) resolve(path string) string {
+	if r.sysfs != "" {
+		if rest, ok := strings.CutPrefix(path, "/sys"); ok && (rest == "" || rest[0] == '/') {
+			return filepath.Join(r.sysfs, rest)
+		}
+	}
+	if r.root != "" && filepath.IsAbs(path) {
+		return filepath.Join(r.root, path)
+	}
+	return path
+}
+
+// unresolve maps a path below sysfs or root back to the host path it was read for
+func (r hostReader) unresolve(path string) string {
+	for _, mount := range []struct{ dir, host string }{{r.sysfs, "/sys"}, {r.root, "/"}} {
+		if mount.dir == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(mount.dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return filepath.Join(mount.host, rel)
+		}
+	}
+	return path
+}
+
+func (r hostReader) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(r.resolve(path))
+}
+
+func (r hostReader) ReadDir(path string) ([]os.DirEntry, error) {
+	return os.ReadDir(r.resolve(path))
+}
+
+// Glob returns matches as host paths, so they can be passed back to the reader
+func (r hostReader) Glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(r.resolve(pattern))
+	for i, match := range matches {
+		matches[i] = r.unresolve(match)
+	}
+	return matches, err
+}
+
+// hostfs is the reader the collectors use, set once at startup by SetHostPaths
+var hostfs fsReader = hostReader{}
+
+// SetHostPaths makes the collectors read the host's files from below root instead of /,
+// e.g. /host in a container with the host's / mounted there, and sysfs from sysfsRoot
+// instead of <root>/sys. Empty values keep reading this system's own files. gopsutil,
+// which most collectors use, is pointed there through its HOST_* environment variables.
+// Only the Linux collectors read host files directly
+func SetHostPaths(root, sysfsRoot string) error {
+	root, err := hostDir("host root", root)
+	if err != nil {
+		return err
+	}
+	sysfsRoot, err = hostDir("sysfs root", sysfsRoot)
+	if err != nil {
+		return err
+	}
+
+	env := map[string]string{}
+	if root != "" {
+		env["HOST_ROOT"] = root
+		for _, dir := range []string{"proc", "sys", "etc", "var", "run", "dev"} {
+			env["HOST_"+strings.ToUpper(dir)] = filepath.Join(root, dir)
+		}
+	}
+	if sysfsRoot != "" {
+		env["HOST_SYS"] = sysfsRoot
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	hostfs = hostReader{root: root, sysfs: sysfsRoot}
+	return nil
+}
+
+// hostDir checks that a configured host directory exists
+func hostDir(name, dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if !stat.IsDir() {
+		return "", fmt.Errorf("invalid %s: %s is not a directory", name, dir)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// hostPath returns where a host path is read from, for code that takes a path rather than
+// reading through hostfs
+func hostPath(path string) string {
+	if reader, ok := hostfs.(hostReader); ok {
+		return reader.resolve(path)
+	}
+	return path
+}
+
+// readingHost reports whether --host-root is set, so files are read from another system
+// than the one sysinfo runs in
+func readingHost() bool {
+	reader, ok := hostfs.(hostReader)
+	return ok && reader.root != ""
+}
+
+// readString reads a file such as a sysfs attribute, with its trailing newline
+func readString(fsys fsReader, path string) (string, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,100 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSysfs creates a fixture tree below root from relative paths
+func writeSysfs(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func TestHostReader(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"sys/class/thermal/thermal_zone0/temp": "41000",
+		"sys/class/thermal/thermal_zone1/temp": "43000",
+		"etc/hostname":                         "db-1",
+	})
+	fsys := hostReader{root: root}
+
+	if value, err := readString(fsys, "/sys/class/thermal/thermal_zone1/temp"); err != nil || value != "43000\n" {
+		t.Errorf("readString() = %q, %v; expected 43000 from below the root", value, err)
+	}
+	if value, err := readString(fsys, "/etc/hostname"); err != nil || value != "db-1\n" {
+		t.Errorf("readString(/etc/hostname) = %q, %v; expected db-1", value, err)
+	}
+
+	// Matches come back as host paths, so they read through the same reader
+	zones, err := fsys.Glob("/sys/class/thermal/thermal_zone*")
+	if err != nil || len(zones) != 2 || zones[0] != "/sys/class/thermal/thermal_zone0" {
+		t.Fatalf("Glob() = %v, %v; expected the two zones as /sys paths", zones, err)
+	}
+	if entries, err := fsys.ReadDir("/sys/class/thermal"); err != nil || len(entries) != 2 {
+		t.Errorf("ReadDir() = %v, %v; expected two zones", entries, err)
+	}
+
+	// A separate sysfs root takes /sys, and only /sys: /system stays below the root
+	sysfsRoot := filepath.Join(root, "sys")
+	fsys = hostReader{root: "/host", sysfs: sysfsRoot}
+	if got := fsys.resolve("/sys/class/thermal"); got != filepath.Join(sysfsRoot, "class/thermal") {
+		t.Errorf("resolve(/sys/class/thermal) = %q, expected it below the sysfs root", got)
+	}
+	if got := fsys.resolve("/system/thermal"); got != "/host/system/thermal" {
+		t.Errorf("resolve(/system/thermal) = %q, expected it below the host root", got)
+	}
+	if zones, _ := fsys.Glob("/sys/class/thermal/thermal_zone*"); len(zones) != 2 || zones[1] != "/sys/class/thermal/thermal_zone1" {
+		t.Errorf("Glob() below the sysfs root = %v, expected the two zones as /sys paths", zones)
+	}
+}
+
+func TestSetHostPaths(t *testing.T) {
+	for _, key := range []string{"HOST_ROOT", "HOST_PROC", "HOST_SYS", "HOST_ETC", "HOST_VAR", "HOST_RUN", "HOST_DEV"} {
+		t.Setenv(key, "")
+	}
+	t.Cleanup(func() { hostfs = hostReader{} })
+
+	root := t.TempDir()
+	if err := SetHostPaths(root+"/", ""); err != nil {
+		t.Fatalf("SetHostPaths() = %v", err)
+	}
+	if !readingHost() || hostPath("/proc/1/cgroup") != filepath.Join(root, "proc/1/cgroup") {
+		t.Errorf("hostPath(/proc/1/cgroup) = %q, expected it below %s", hostPath("/proc/1/cgroup"), root)
+	}
+	// gopsutil follows the same root
+	if os.Getenv("HOST_PROC") != filepath.Join(root, "proc") || os.Getenv("HOST_SYS") != filepath.Join(root, "sys") {
+		t.Errorf("HOST_PROC, HOST_SYS = %q, %q; expected below %s", os.Getenv("HOST_PROC"), os.Getenv("HOST_SYS"), root)
+	}
+
+	if err := SetHostPaths("", root); err != nil || readingHost() || os.Getenv("HOST_SYS") != root {
+		t.Errorf("SetHostPaths(sysfs only) = %v; expected only /sys, and HOST_SYS, moved to %s", err, root)
+	}
+
+	file := filepath.Join(root, "file")
+	writeSysfs(t, root, map[string]string{"file": ""})
+	for _, invalid := range []string{filepath.Join(root, "missing"), file} {
+		if err := SetHostPaths(invalid, ""); err == nil {
+			t.Errorf("SetHostPaths(%s) succeeded, expected an error", invalid)
+		}
+		if err := SetHostPaths("", invalid); err == nil {
+			t.Errorf("SetHostPaths(sysfs %s) succeeded, expected an error", invalid)
+		}
+	}
+
+	if err := SetHostPaths("", ""); err != nil || hostPath("/sys") != "/sys" {
+		t.Errorf("SetHostPaths(\"\", \"\") = %v; expected this system's own files", err)
+	}
+}
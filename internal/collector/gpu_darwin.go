@@ -5,10 +5,10 @@ package collector
 
 import (
 	"encoding/xml"
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 )
 
@@ -35,7 +35,7 @@ func collectGPUPlatform() []types.GPUInfo {
 	gpus := make([]types.GPUInfo, 0)
 
 	// Use system_profiler to get display/GPU information
-	cmd := exec.Command("system_profiler", "SPDisplaysDataType", "-xml")
+	cmd := sandbox.Command("system_profiler", "SPDisplaysDataType", "-xml")
 	output, err := cmd.Output()
 	if err != nil {
 		// Fallback to text parsing
@@ -104,7 +104,7 @@ func collectGPUPlatform() []types.GPUInfo {
 func collectGPUsFromSystemProfilerText() []types.GPUInfo {
 	gpus := make([]types.GPUInfo, 0)
 
-	cmd := exec.Command("system_profiler", "SPDisplaysDataType")
+	cmd := sandbox.Command("system_profiler", "SPDisplaysDataType")
 	output, err := cmd.Output()
 	if err != nil {
 		return gpus
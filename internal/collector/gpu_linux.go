@@ -5,19 +5,26 @@ package collector
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 	"github.com/mayvqt/sysinfo/internal/utils"
 )
 
 // NvidiaSMILog represents the XML output from nvidia-smi
 type NvidiaSMILog struct {
-	GPUs []NvidiaGPU `xml:"gpu"`
+	DriverVersion string      `xml:"driver_version"`
+	CUDAVersion   string      `xml:"cuda_version"`
+	GPUs          []NvidiaGPU `xml:"gpu"`
 }
 
 type NvidiaGPU struct {
@@ -25,7 +32,9 @@ type NvidiaGPU struct {
 	UUID        string `xml:"uuid"`
 	PCIBus      string `xml:"pci>pci_bus"`
 	Temperature struct {
-		Current string `xml:"gpu_temp"`
+		Current  string `xml:"gpu_temp"`
+		Slowdown string `xml:"gpu_temp_slow_threshold"`
+		Shutdown string `xml:"gpu_temp_max_threshold"`
 	} `xml:"temperature"`
 	Utilization struct {
 		GPU    string `xml:"gpu_util"`
@@ -46,14 +55,65 @@ type NvidiaGPU struct {
 	} `xml:"clocks"`
 	FanSpeed      string `xml:"fan_speed"`
 	DriverVersion string `xml:"driver_version"`
+	MIGMode       struct {
+		Current string `xml:"current_mig"`
+	} `xml:"mig_mode"`
+	MIGDevices         []NvidiaMIGDevice `xml:"mig_devices>mig_device"`
+	VirtualizationMode string            `xml:"gpu_virtualization_mode>virtualization_mode"`
+	VGPUs              []NvidiaVGPU      `xml:"vgpus>vgpu_instance"`
+	Processes          []NvidiaProcess   `xml:"processes>process_info"`
 }
 
+// NvidiaProcess is a process using a GPU as reported by nvidia-smi -q -x
+type NvidiaProcess struct {
+	PID        string `xml:"pid"`
+	Type       string `xml:"type"`
+	Name       string `xml:"process_name"`
+	UsedMemory string `xml:"used_memory"` // e.g. "10240 MiB", or "N/A" where the driver cannot tell
+}
+
+// NvidiaMIGDevice is one MIG instance as reported by nvidia-smi -q -x
+type NvidiaMIGDevice struct {
+	Index             string `xml:"index"`
+	GPUInstanceID     string `xml:"gpu_instance_id"`
+	ComputeInstanceID string `xml:"compute_instance_id"`
+	Multiprocessors   string `xml:"device_attributes>shared>multiprocessor_count"`
+	FBMemory          struct {
+		Total string `xml:"total"`
+		Used  string `xml:"used"`
+	} `xml:"fb_memory_usage"`
+}
+
+// NvidiaVGPU is one vGPU instance on a host running the vGPU manager
+type NvidiaVGPU struct {
+	VMName   string `xml:"vm_name"`
+	Name     string `xml:"vgpu_name"`
+	UUID     string `xml:"uuid"`
+	FBMemory struct {
+		Used string `xml:"used"`
+	} `xml:"fb_memory_usage"`
+	Utilization struct {
+		GPU string `xml:"gpu"`
+	} `xml:"utilization"`
+}
+
+// nvidiaMIGListing is a MIG device line from nvidia-smi -L
+type nvidiaMIGListing struct {
+	Profile string
+	UUID    string
+}
+
+var (
+	nvidiaListGPURe = regexp.MustCompile(`^GPU (\d+):`)
+	nvidiaListMIGRe = regexp.MustCompile(`^\s+MIG (\S+)\s+Device\s+(\d+): \(UUID: ([^)]+)\)`)
+)
+
 // collectGPUPlatform implements Linux-specific GPU data collection
 func collectGPUPlatform() []types.GPUInfo {
 	gpus := make([]types.GPUInfo, 0)
 
 	// Try NVIDIA GPUs first (nvidia-smi)
-	nvidiaGPUs := collectNvidiaGPUs()
+	nvidiaGPUs, nvidiaErr := collectNvidiaGPUs()
 	gpus = append(gpus, nvidiaGPUs...)
 
 	// Try AMD GPUs (rocm-smi or lspci)
@@ -65,25 +125,40 @@ func collectGPUPlatform() []types.GPUInfo {
 		gpus = collectGPUsFromLspci()
 	}
 
+	applyNvidiaDriverStack(gpus, nvidiaErr)
+
 	return gpus
 }
 
-// collectNvidiaGPUs collects NVIDIA GPU information using nvidia-smi
-func collectNvidiaGPUs() []types.GPUInfo {
+// collectNvidiaGPUs collects NVIDIA GPU information using nvidia-smi.
+// When nvidia-smi is installed but fails, its error message is returned
+// (e.g. "Failed to initialize NVML: Driver/library version mismatch")
+func collectNvidiaGPUs() ([]types.GPUInfo, string) {
 	gpus := make([]types.GPUInfo, 0)
 
 	// Check if nvidia-smi is available
-	_, err := exec.LookPath("nvidia-smi")
+	_, err := sandbox.LookPath("nvidia-smi")
 	if err != nil {
-		return gpus
+		return gpus, ""
 	}
 
 	// Try XML format first (more detailed)
-	cmd := exec.Command("nvidia-smi", "-q", "-x")
+	cmd := sandbox.Command("nvidia-smi", "-q", "-x")
 	output, err := cmd.Output()
 	if err == nil {
 		var smiLog NvidiaSMILog
 		if err := xml.Unmarshal(output, &smiLog); err == nil {
+			// MIG profile names and UUIDs are only listed by nvidia-smi -L
+			var migListings map[int]map[int]nvidiaMIGListing
+			for _, gpu := range smiLog.GPUs {
+				if len(gpu.MIGDevices) > 0 {
+					if listOutput, err := sandbox.Command("nvidia-smi", "-L").Output(); err == nil {
+						migListings = parseNvidiaMIGListing(string(listOutput))
+					}
+					break
+				}
+			}
+
 			for i, gpu := range smiLog.GPUs {
 				gpuInfo := types.GPUInfo{
 					Index:         i,
@@ -91,6 +166,7 @@ func collectNvidiaGPUs() []types.GPUInfo {
 					Vendor:        "NVIDIA",
 					Driver:        "nvidia",
 					DriverVersion: gpu.DriverVersion,
+					CUDAVersion:   strings.TrimSpace(smiLog.CUDAVersion),
 					UUID:          gpu.UUID,
 					PCIBus:        gpu.PCIBus,
 				}
@@ -99,6 +175,12 @@ func collectNvidiaGPUs() []types.GPUInfo {
 				if temp, err := strconv.Atoi(strings.TrimSpace(strings.Replace(gpu.Temperature.Current, "C", "", -1))); err == nil {
 					gpuInfo.Temperature = temp
 				}
+				if temp, err := strconv.Atoi(strings.TrimSpace(strings.Replace(gpu.Temperature.Slowdown, "C", "", -1))); err == nil {
+					gpuInfo.TemperatureSlowdown = temp
+				}
+				if temp, err := strconv.Atoi(strings.TrimSpace(strings.Replace(gpu.Temperature.Shutdown, "C", "", -1))); err == nil {
+					gpuInfo.TemperatureShutdown = temp
+				}
 
 				// Parse utilization
 				if util, err := strconv.Atoi(strings.TrimSpace(strings.Replace(gpu.Utilization.GPU, "%", "", -1))); err == nil {
@@ -141,24 +223,27 @@ func collectNvidiaGPUs() []types.GPUInfo {
 					gpuInfo.FanSpeed = fan
 				}
 
+				applyNvidiaPartitions(&gpuInfo, gpu, migListings[i])
+				gpuInfo.Processes = nvidiaProcesses(gpu.Processes)
+
 				gpus = append(gpus, gpuInfo)
 			}
-			return gpus
+			return gpus, ""
 		}
 	}
 
 	// Fallback to CSV format for basic info
-	cmd = exec.Command("nvidia-smi", "--query-gpu=index,name,temperature.gpu,utilization.gpu,memory.total,memory.used",
+	cmd = sandbox.Command("nvidia-smi", "--query-gpu=index,name,temperature.gpu,utilization.gpu,memory.total,memory.used",
 		"--format=csv,noheader,nounits")
 	output, err = cmd.Output()
 	if err != nil {
-		return gpus
+		return gpus, nvidiaSMIError(output, err)
 	}
 
 	reader := csv.NewReader(strings.NewReader(string(output)))
 	records, err := reader.ReadAll()
 	if err != nil {
-		return gpus
+		return gpus, ""
 	}
 
 	for _, record := range records {
@@ -192,7 +277,178 @@ func collectNvidiaGPUs() []types.GPUInfo {
 		gpus = append(gpus, gpuInfo)
 	}
 
-	return gpus
+	return gpus, ""
+}
+
+// nvidiaSMIError extracts the message nvidia-smi printed when it failed
+func nvidiaSMIError(output []byte, err error) string {
+	message := strings.TrimSpace(string(output))
+	if exitErr, ok := err.(*exec.ExitError); ok && message == "" {
+		message = strings.TrimSpace(string(exitErr.Stderr))
+	}
+	if message == "" {
+		return err.Error()
+	}
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
+
+// applyNvidiaDriverStack records the loaded kernel module version, the
+// installed CUDA runtime and any nvidia-smi failure on NVIDIA GPUs so the
+// analyzer can spot driver stacks left inconsistent by an upgrade
+func applyNvidiaDriverStack(gpus []types.GPUInfo, nvidiaErr string) {
+	moduleVersion := ""
+	if version, err := readSysFile(hostPath("/sys/module/nvidia/version")); err == nil {
+		moduleVersion = strings.TrimSpace(version)
+	} else if version, err := readSysFile(hostPath("/proc/driver/nvidia/version")); err == nil {
+		moduleVersion = parseNvidiaProcVersion(version)
+	}
+	runtimeVersion := cudaRuntimeVersion(hostPath("/usr/local/cuda"))
+
+	for i := range gpus {
+		if gpus[i].Vendor != "NVIDIA" {
+			continue
+		}
+		gpus[i].KernelModuleVersion = moduleVersion
+		gpus[i].CUDARuntimeVersion = runtimeVersion
+		if gpus[i].DriverVersion == "" && nvidiaErr != "" {
+			gpus[i].DriverError = nvidiaErr
+		}
+	}
+}
+
+var nvidiaProcVersionRe = regexp.MustCompile(`Kernel Module(?: for [^ ]+)?\s+(\d+\.\d+(?:\.\d+)?)`)
+
+// parseNvidiaProcVersion extracts the module version from /proc/driver/nvidia/version:
+//
+//	NVRM version: NVIDIA UNIX x86_64 Kernel Module  535.129.03  Thu Oct 19 18:56:32 UTC 2023
+func parseNvidiaProcVersion(content string) string {
+	if m := nvidiaProcVersionRe.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// cudaRuntimeVersion reads the version of the CUDA toolkit installed at root
+// from version.json (CUDA 11.1+) or version.txt (older releases)
+func cudaRuntimeVersion(root string) string {
+	if data, err := os.ReadFile(filepath.Join(root, "version.json")); err == nil {
+		var manifest struct {
+			CUDA struct {
+				Version string `json:"version"`
+			} `json:"cuda"`
+		}
+		if json.Unmarshal(data, &manifest) == nil && manifest.CUDA.Version != "" {
+			return manifest.CUDA.Version
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "version.txt")); err == nil {
+		// CUDA Version 10.2.89
+		fields := strings.Fields(string(data))
+		if len(fields) >= 3 && fields[0] == "CUDA" {
+			return fields[2]
+		}
+	}
+	return ""
+}
+
+// applyNvidiaPartitions records MIG mode, virtualization mode and the
+// MIG/vGPU instances of one GPU; listings adds MIG profiles by device index
+func applyNvidiaPartitions(gpuInfo *types.GPUInfo, gpu NvidiaGPU, listings map[int]nvidiaMIGListing) {
+	if mode := strings.TrimSpace(gpu.MIGMode.Current); mode != "" && mode != "N/A" {
+		gpuInfo.MIGMode = mode
+	}
+	if mode := strings.TrimSpace(gpu.VirtualizationMode); mode != "" && mode != "N/A" {
+		gpuInfo.VirtualizationMode = mode
+	}
+
+	for _, mig := range gpu.MIGDevices {
+		partition := types.GPUPartition{Kind: "mig"}
+		if idx, err := strconv.Atoi(strings.TrimSpace(mig.Index)); err == nil {
+			partition.Index = idx
+		}
+		if id, err := strconv.Atoi(strings.TrimSpace(mig.GPUInstanceID)); err == nil {
+			partition.GPUInstanceID = &id
+		}
+		if id, err := strconv.Atoi(strings.TrimSpace(mig.ComputeInstanceID)); err == nil {
+			partition.ComputeInstanceID = &id
+		}
+		if sms, err := strconv.Atoi(strings.TrimSpace(mig.Multiprocessors)); err == nil {
+			partition.Multiprocessors = sms
+		}
+		partition.MemoryTotal = parseMemoryMiB(mig.FBMemory.Total)
+		partition.MemoryUsed = parseMemoryMiB(mig.FBMemory.Used)
+		if listing, ok := listings[partition.Index]; ok {
+			partition.Profile = listing.Profile
+			partition.UUID = listing.UUID
+		}
+		gpuInfo.Partitions = append(gpuInfo.Partitions, partition)
+	}
+
+	for i, vgpu := range gpu.VGPUs {
+		partition := types.GPUPartition{
+			Kind:       "vgpu",
+			Index:      i,
+			Profile:    strings.TrimSpace(vgpu.Name),
+			UUID:       strings.TrimSpace(vgpu.UUID),
+			VMName:     strings.TrimSpace(vgpu.VMName),
+			MemoryUsed: parseMemoryMiB(vgpu.FBMemory.Used),
+		}
+		if util, err := strconv.Atoi(strings.TrimSpace(strings.Replace(vgpu.Utilization.GPU, "%", "", -1))); err == nil {
+			partition.Utilization = util
+		}
+		gpuInfo.Partitions = append(gpuInfo.Partitions, partition)
+	}
+}
+
+// nvidiaProcesses converts the processes nvidia-smi lists for a GPU; processes whose memory
+// use is not reported are left out
+func nvidiaProcesses(processes []NvidiaProcess) []types.GPUProcess {
+	var result []types.GPUProcess
+	for _, p := range processes {
+		pid, err := strconv.Atoi(strings.TrimSpace(p.PID))
+		used := parseMemoryMiB(p.UsedMemory)
+		if err != nil || used == 0 {
+			continue
+		}
+		result = append(result, types.GPUProcess{
+			PID:        pid,
+			Name:       strings.TrimSpace(p.Name),
+			Type:       strings.TrimSpace(p.Type),
+			MemoryUsed: used,
+		})
+	}
+	return result
+}
+
+// parseNvidiaMIGListing maps GPU index to MIG device index to the profile
+// and UUID shown by nvidia-smi -L:
+//
+//	GPU 0: NVIDIA A100-SXM4-80GB (UUID: GPU-...)
+//	  MIG 3g.40gb     Device  0: (UUID: MIG-...)
+func parseNvidiaMIGListing(output string) map[int]map[int]nvidiaMIGListing {
+	listings := make(map[int]map[int]nvidiaMIGListing)
+	gpuIndex := -1
+
+	for _, line := range strings.Split(output, "\n") {
+		if m := nvidiaListGPURe.FindStringSubmatch(line); m != nil {
+			gpuIndex, _ = strconv.Atoi(m[1])
+			continue
+		}
+		m := nvidiaListMIGRe.FindStringSubmatch(line)
+		if m == nil || gpuIndex < 0 {
+			continue
+		}
+		deviceIndex, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		if listings[gpuIndex] == nil {
+			listings[gpuIndex] = make(map[int]nvidiaMIGListing)
+		}
+		listings[gpuIndex][deviceIndex] = nvidiaMIGListing{Profile: m[1], UUID: strings.TrimSpace(m[3])}
+	}
+
+	return listings
 }
 
 // collectAMDGPUs collects AMD GPU information using rocm-smi
@@ -200,13 +456,13 @@ func collectAMDGPUs() []types.GPUInfo {
 	gpus := make([]types.GPUInfo, 0)
 
 	// Check if rocm-smi is available
-	_, err := exec.LookPath("rocm-smi")
+	_, err := sandbox.LookPath("rocm-smi")
 	if err != nil {
 		return gpus
 	}
 
 	// rocm-smi --showproductname --showtemp --showuse --showmeminfo vram
-	cmd := exec.Command("rocm-smi", "--showproductname", "--showtemp", "--showuse", "--showmeminfo", "vram", "--csv")
+	cmd := sandbox.Command("rocm-smi", "--showproductname", "--showtemp", "--showuse", "--showmeminfo", "vram", "--csv")
 	output, err := cmd.Output()
 	if err != nil {
 		return gpus
@@ -248,7 +504,7 @@ func collectAMDGPUs() []types.GPUInfo {
 func collectGPUsFromLspci() []types.GPUInfo {
 	gpus := make([]types.GPUInfo, 0)
 
-	cmd := exec.Command("lspci")
+	cmd := sandbox.Command("lspci")
 	output, err := cmd.Output()
 	if err != nil {
 		return gpus
@@ -4,7 +4,13 @@
 package collector
 
 import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
 )
 
 // TestParseMemoryMiB tests the memory parsing helper
@@ -170,7 +176,10 @@ func TestCollectGPUsFromLspci(t *testing.T) {
 
 // TestCollectNvidiaGPUs tests NVIDIA GPU collection
 func TestCollectNvidiaGPUs(t *testing.T) {
-	gpus := collectNvidiaGPUs()
+	gpus, nvidiaErr := collectNvidiaGPUs()
+	if nvidiaErr != "" {
+		t.Logf("nvidia-smi failed: %s", nvidiaErr)
+	}
 
 	if len(gpus) > 0 {
 		t.Logf("Found %d NVIDIA GPU(s)", len(gpus))
@@ -226,7 +235,7 @@ func TestCollectAMDGPUs(t *testing.T) {
 // TestNvidiaGPUDataValidation tests NVIDIA-specific data validation
 func TestNvidiaGPUDataValidation(t *testing.T) {
 	// This test validates that if we get NVIDIA GPU data, it's properly structured
-	gpus := collectNvidiaGPUs()
+	gpus, _ := collectNvidiaGPUs()
 
 	for i, gpu := range gpus {
 		// All NVIDIA GPUs should have these fields set
@@ -285,7 +294,7 @@ func BenchmarkParseClockMHz(b *testing.B) {
 // BenchmarkCollectNvidiaGPUs benchmarks NVIDIA GPU collection
 func BenchmarkCollectNvidiaGPUs(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_ = collectNvidiaGPUs()
+		_, _ = collectNvidiaGPUs()
 	}
 }
 
@@ -302,3 +311,167 @@ func BenchmarkCollectGPUsFromLspci(b *testing.B) {
 		_ = collectGPUsFromLspci()
 	}
 }
+
+// TestNvidiaPartitions tests MIG and vGPU parsing from nvidia-smi output
+func TestNvidiaPartitions(t *testing.T) {
+	smiXML := `<?xml version="1.0" ?>
+<nvidia_smi_log>
+	<gpu id="00000000:07:00.0">
+		<product_name>NVIDIA A100-SXM4-80GB</product_name>
+		<mig_mode><current_mig>Enabled</current_mig><pending_mig>Enabled</pending_mig></mig_mode>
+		<mig_devices>
+			<mig_device>
+				<index>0</index>
+				<gpu_instance_id>2</gpu_instance_id>
+				<compute_instance_id>0</compute_instance_id>
+				<device_attributes><shared><multiprocessor_count>42</multiprocessor_count></shared></device_attributes>
+				<fb_memory_usage><total>40192 MiB</total><used>37 MiB</used></fb_memory_usage>
+			</mig_device>
+			<mig_device>
+				<index>1</index>
+				<gpu_instance_id>9</gpu_instance_id>
+				<compute_instance_id>0</compute_instance_id>
+				<device_attributes><shared><multiprocessor_count>14</multiprocessor_count></shared></device_attributes>
+				<fb_memory_usage><total>9728 MiB</total><used>13 MiB</used></fb_memory_usage>
+			</mig_device>
+		</mig_devices>
+		<gpu_virtualization_mode><virtualization_mode>None</virtualization_mode></gpu_virtualization_mode>
+	</gpu>
+	<gpu id="00000000:41:00.0">
+		<product_name>NVIDIA A40</product_name>
+		<mig_mode><current_mig>N/A</current_mig></mig_mode>
+		<gpu_virtualization_mode><virtualization_mode>Host VGPU</virtualization_mode></gpu_virtualization_mode>
+		<vgpus>
+			<vgpu_instance id="3251634191">
+				<vm_name>render-01</vm_name>
+				<vgpu_name>NVIDIA A40-12Q</vgpu_name>
+				<uuid>8d7c6a0e-0c46-11ee-be56-0242ac120002</uuid>
+				<fb_memory_usage><used>2048 MiB</used></fb_memory_usage>
+				<utilization><gpu>35 %</gpu></utilization>
+			</vgpu_instance>
+		</vgpus>
+	</gpu>
+</nvidia_smi_log>`
+
+	listOutput := `GPU 0: NVIDIA A100-SXM4-80GB (UUID: GPU-5d6f2c1a-0000-0000-0000-000000000000)
+  MIG 3g.40gb     Device  0: (UUID: MIG-aaaa)
+  MIG 1g.10gb     Device  1: (UUID: MIG-bbbb)
+GPU 1: NVIDIA A40 (UUID: GPU-7e8f9a0b-0000-0000-0000-000000000000)
+`
+
+	var smiLog NvidiaSMILog
+	if err := xml.Unmarshal([]byte(smiXML), &smiLog); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	listings := parseNvidiaMIGListing(listOutput)
+
+	var a100, a40 types.GPUInfo
+	applyNvidiaPartitions(&a100, smiLog.GPUs[0], listings[0])
+	applyNvidiaPartitions(&a40, smiLog.GPUs[1], listings[1])
+
+	if a100.MIGMode != "Enabled" {
+		t.Errorf("MIGMode = %q, expected Enabled", a100.MIGMode)
+	}
+	if len(a100.Partitions) != 2 {
+		t.Fatalf("A100 partitions = %d, expected 2", len(a100.Partitions))
+	}
+	mig := a100.Partitions[1]
+	if mig.Kind != "mig" || mig.Profile != "1g.10gb" || mig.UUID != "MIG-bbbb" {
+		t.Errorf("MIG partition = %+v, expected kind mig, profile 1g.10gb, UUID MIG-bbbb", mig)
+	}
+	if mig.GPUInstanceID == nil || *mig.GPUInstanceID != 9 || mig.ComputeInstanceID == nil || *mig.ComputeInstanceID != 0 {
+		t.Errorf("MIG instance IDs = %v/%v, expected 9/0", mig.GPUInstanceID, mig.ComputeInstanceID)
+	}
+	if mig.Multiprocessors != 14 || mig.MemoryTotal != 9728*1024*1024 {
+		t.Errorf("MIG slice = %d SMs, %d bytes, expected 14 SMs, %d bytes", mig.Multiprocessors, mig.MemoryTotal, 9728*1024*1024)
+	}
+
+	if a40.MIGMode != "" {
+		t.Errorf("A40 MIGMode = %q, expected empty for N/A", a40.MIGMode)
+	}
+	if a40.VirtualizationMode != "Host VGPU" {
+		t.Errorf("VirtualizationMode = %q, expected Host VGPU", a40.VirtualizationMode)
+	}
+	if len(a40.Partitions) != 1 {
+		t.Fatalf("A40 partitions = %d, expected 1", len(a40.Partitions))
+	}
+	vgpu := a40.Partitions[0]
+	if vgpu.Kind != "vgpu" || vgpu.Profile != "NVIDIA A40-12Q" || vgpu.VMName != "render-01" || vgpu.Utilization != 35 {
+		t.Errorf("vGPU partition = %+v", vgpu)
+	}
+	if vgpu.GPUInstanceID != nil {
+		t.Error("vGPU partition should not have a GPU instance ID")
+	}
+}
+
+func TestNvidiaProcesses(t *testing.T) {
+	smiXML := `<?xml version="1.0" ?>
+<nvidia_smi_log>
+	<gpu id="00000000:07:00.0">
+		<product_name>NVIDIA A100-SXM4-80GB</product_name>
+		<processes>
+			<process_info>
+				<pid>48213</pid>
+				<type>C</type>
+				<process_name>/usr/bin/python3</process_name>
+				<used_memory>30512 MiB</used_memory>
+			</process_info>
+			<process_info>
+				<pid>2211</pid>
+				<type>G</type>
+				<process_name>/usr/lib/xorg/Xorg</process_name>
+				<used_memory>N/A</used_memory>
+			</process_info>
+		</processes>
+	</gpu>
+</nvidia_smi_log>`
+
+	var smiLog NvidiaSMILog
+	if err := xml.Unmarshal([]byte(smiXML), &smiLog); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	processes := nvidiaProcesses(smiLog.GPUs[0].Processes)
+	if len(processes) != 1 {
+		t.Fatalf("processes = %+v, expected python3 without the process of unknown usage", processes)
+	}
+	if p := processes[0]; p.PID != 48213 || p.Name != "/usr/bin/python3" || p.Type != "C" || p.MemoryUsed != 30512*1024*1024 {
+		t.Errorf("process = %+v, expected python3 (48213) using 30512 MiB", p)
+	}
+}
+
+// TestNvidiaDriverStackParsing tests the kernel module and CUDA runtime version helpers
+func TestNvidiaDriverStackParsing(t *testing.T) {
+	proc := "NVRM version: NVIDIA UNIX x86_64 Kernel Module  535.129.03  Thu Oct 19 18:56:32 UTC 2023\nGCC version:  gcc version 12.2.0 (Debian 12.2.0-14)\n"
+	if version := parseNvidiaProcVersion(proc); version != "535.129.03" {
+		t.Errorf("parseNvidiaProcVersion = %q, expected 535.129.03", version)
+	}
+	openProc := "NVRM version: NVIDIA UNIX Open Kernel Module for x86_64  550.54.14  Release Build\n"
+	if version := parseNvidiaProcVersion(openProc); version != "550.54.14" {
+		t.Errorf("parseNvidiaProcVersion (open module) = %q, expected 550.54.14", version)
+	}
+
+	jsonRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(jsonRoot, "version.json"), []byte(`{"cuda": {"name": "CUDA SDK", "version": "12.2.140"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if version := cudaRuntimeVersion(jsonRoot); version != "12.2.140" {
+		t.Errorf("cudaRuntimeVersion (json) = %q, expected 12.2.140", version)
+	}
+
+	txtRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(txtRoot, "version.txt"), []byte("CUDA Version 10.2.89\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if version := cudaRuntimeVersion(txtRoot); version != "10.2.89" {
+		t.Errorf("cudaRuntimeVersion (txt) = %q, expected 10.2.89", version)
+	}
+
+	if version := cudaRuntimeVersion(t.TempDir()); version != "" {
+		t.Errorf("cudaRuntimeVersion (missing) = %q, expected empty", version)
+	}
+
+	message := nvidiaSMIError([]byte("Failed to initialize NVML: Driver/library version mismatch\nNVML library version: 550.54\n"), errors.New("exit status 18"))
+	if message != "Failed to initialize NVML: Driver/library version mismatch" {
+		t.Errorf("nvidiaSMIError = %q", message)
+	}
+}
@@ -5,10 +5,10 @@ package collector
 
 import (
 	"encoding/csv"
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 	"github.com/mayvqt/sysinfo/internal/utils"
 	"github.com/yusufpapurcu/wmi"
@@ -98,10 +98,10 @@ func collectGPUPlatform() []types.GPUInfo {
 // enrichNvidiaGPUsWindows uses nvidia-smi to get additional information for NVIDIA GPUs
 func enrichNvidiaGPUsWindows(gpus []types.GPUInfo) {
 	// Check if nvidia-smi is available (usually in C:\Program Files\NVIDIA Corporation\NVSMI\)
-	_, err := exec.LookPath("nvidia-smi")
+	_, err := sandbox.LookPath("nvidia-smi")
 	if err != nil {
 		// Try common installation path
-		cmd := exec.Command("C:\\Program Files\\NVIDIA Corporation\\NVSMI\\nvidia-smi.exe", "--help")
+		cmd := sandbox.Command("C:\\Program Files\\NVIDIA Corporation\\NVSMI\\nvidia-smi.exe", "--help")
 		if err := cmd.Run(); err != nil {
 			// nvidia-smi not available
 			return
@@ -109,7 +109,7 @@ func enrichNvidiaGPUsWindows(gpus []types.GPUInfo) {
 	}
 
 	// Get detailed NVIDIA GPU information using CSV format
-	cmd := exec.Command("nvidia-smi",
+	cmd := sandbox.Command("nvidia-smi",
 		"--query-gpu=index,name,temperature.gpu,utilization.gpu,utilization.memory,memory.total,memory.used,memory.free,power.draw,power.limit,clocks.gr,clocks.mem,fan.speed,uuid",
 		"--format=csv,noheader,nounits")
 
@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectIntegrity hashes the given files, or the platform's critical binaries and
+// configuration files when none are given. Glob patterns are expanded in sorted order; files
+// that are missing or unreadable are listed with the reason, as a file disappearing or
+// becoming unreadable is drift too
+func CollectIntegrity(paths []string) (*types.IntegrityData, error) {
+	if len(paths) == 0 {
+		paths = defaultIntegrityPaths()
+	}
+
+	data := &types.IntegrityData{Files: []types.FileChecksum{}}
+	seen := map[string]bool{}
+	for _, pattern := range paths {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			if globbed, err := hostfs.Glob(pattern); err == nil && len(globbed) > 0 {
+				matches = globbed
+			}
+		}
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			data.Files = append(data.Files, fileChecksum(path))
+		}
+	}
+	return data, nil
+}
+
+// fileChecksum hashes a regular file read from the host, following symlinks
+func fileChecksum(path string) types.FileChecksum {
+	checksum := types.FileChecksum{Path: path}
+	f, err := os.Open(hostPath(path))
+	if err != nil {
+		checksum.Error = checksumError(err)
+		return checksum
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		checksum.Error = checksumError(err)
+		return checksum
+	}
+	if !stat.Mode().IsRegular() {
+		checksum.Error = "not a regular file"
+		return checksum
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		checksum.Error = checksumError(err)
+		return checksum
+	}
+	checksum.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	checksum.Size = stat.Size()
+	checksum.Mode = stat.Mode().String()
+	return checksum
+}
+
+// checksumError describes why a file could not be hashed without repeating its path
+func checksumError(err error) string {
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "not found"
+	case errors.Is(err, fs.ErrPermission):
+		return "permission denied"
+	case errors.As(err, &pathErr):
+		return pathErr.Err.Error()
+	default:
+		return err.Error()
+	}
+}
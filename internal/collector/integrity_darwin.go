@@ -0,0 +1,25 @@
+//go:build darwin
+
+package collector
+
+// defaultIntegrityPaths are binaries attackers replace to hide or keep access and the files
+// deciding who may log in and elevate. The sealed system volume protects most of /usr and
+// /bin, so these mostly catch changes made with SIP disabled
+func defaultIntegrityPaths() []string {
+	return []string{
+		"/usr/bin/sudo",
+		"/usr/bin/su",
+		"/usr/bin/login",
+		"/usr/bin/ssh",
+		"/usr/sbin/sshd",
+		"/bin/bash",
+		"/bin/zsh",
+		"/bin/ls",
+		"/bin/ps",
+		"/usr/bin/crontab",
+		"/etc/sudoers",
+		"/etc/ssh/sshd_config",
+		"/etc/pam.d/sudo",
+		"/etc/hosts",
+	}
+}
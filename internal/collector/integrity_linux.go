@@ -0,0 +1,30 @@
+//go:build linux
+
+package collector
+
+// defaultIntegrityPaths are binaries attackers replace to hide or keep access, the files
+// deciding who may log in and elevate, and the dynamic loader's preload list. Paths below
+// /bin and /lib work both where /usr is merged and where it is not
+func defaultIntegrityPaths() []string {
+	return []string{
+		"/usr/bin/sudo",
+		"/bin/su",
+		"/usr/bin/passwd",
+		"/bin/login",
+		"/usr/bin/ssh",
+		"/usr/sbin/sshd",
+		"/bin/bash",
+		"/bin/ls",
+		"/bin/ps",
+		"/usr/bin/find",
+		"/usr/bin/crontab",
+		"/lib/systemd/systemd",
+		"/etc/passwd",
+		"/etc/group",
+		"/etc/sudoers",
+		"/etc/ssh/sshd_config",
+		"/etc/pam.d/sshd",
+		"/etc/hosts",
+		"/etc/ld.so.preload",
+	}
+}
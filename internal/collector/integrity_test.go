@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCollectIntegrity(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"sshd_config": "PermitRootLogin no\n", "a.conf": "", "b.conf": "b\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config, missing := filepath.Join(dir, "sshd_config"), filepath.Join(dir, "missing")
+	// Independent of the umask
+	if err := os.Chmod(config, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := CollectIntegrity([]string{config, missing, filepath.Join(dir, "*.conf"), config, dir, filepath.Join(dir, "*.none")})
+	if err != nil {
+		t.Fatalf("CollectIntegrity() error = %v", err)
+	}
+	if len(data.Files) != 6 {
+		t.Fatalf("Files = %+v, expected 6 with the duplicate left out", data.Files)
+	}
+	if f := data.Files[0]; f.Path != config || f.SHA256 != "44c91857f34b1ec68e246ebcbad66c4b9380b41c3490c719bdfd6696ba7b40b5" || f.Size != 19 || f.Error != "" {
+		t.Errorf("Files[0] = %+v", f)
+	}
+	if f := data.Files[1]; f.Path != missing || f.Error != "not found" || f.SHA256 != "" {
+		t.Errorf("Files[1] = %+v, expected not found", f)
+	}
+	// Globs expand in sorted order; an empty file still has a checksum
+	if f := data.Files[2]; f.Path != filepath.Join(dir, "a.conf") || f.SHA256 != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("Files[2] = %+v, expected the empty a.conf", f)
+	}
+	if f := data.Files[3]; f.Path != filepath.Join(dir, "b.conf") {
+		t.Errorf("Files[3] = %+v, expected b.conf", f)
+	}
+	if f := data.Files[4]; f.Error != "not a regular file" {
+		t.Errorf("Files[4] = %+v, expected the directory to be rejected", f)
+	}
+	// A pattern matching nothing is listed as missing, like a missing file
+	if f := data.Files[5]; f.Path != filepath.Join(dir, "*.none") || f.Error != "not found" {
+		t.Errorf("Files[5] = %+v, expected the unmatched pattern as not found", f)
+	}
+	if runtime.GOOS != "windows" && data.Files[0].Mode != "-rw-r--r--" {
+		t.Errorf("Mode = %q, expected -rw-r--r--", data.Files[0].Mode)
+	}
+}
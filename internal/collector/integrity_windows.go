@@ -0,0 +1,29 @@
+//go:build windows
+
+package collector
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+)
+
+// defaultIntegrityPaths are the kernel and the processes handling logons and services, the
+// accessibility tools replaced to open a shell on the logon screen, and the hosts file
+func defaultIntegrityPaths() []string {
+	root := cmp.Or(os.Getenv("SystemRoot"), `C:\Windows`)
+	system32 := filepath.Join(root, "System32")
+	return []string{
+		filepath.Join(system32, "ntoskrnl.exe"),
+		filepath.Join(system32, "winlogon.exe"),
+		filepath.Join(system32, "lsass.exe"),
+		filepath.Join(system32, "services.exe"),
+		filepath.Join(system32, "svchost.exe"),
+		filepath.Join(system32, "cmd.exe"),
+		filepath.Join(system32, "WindowsPowerShell", "v1.0", "powershell.exe"),
+		filepath.Join(system32, "sethc.exe"),
+		filepath.Join(system32, "utilman.exe"),
+		filepath.Join(system32, "osk.exe"),
+		filepath.Join(system32, "drivers", "etc", "hosts"),
+	}
+}
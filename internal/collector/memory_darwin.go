@@ -5,10 +5,10 @@ package collector
 
 import (
 	"encoding/xml"
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 )
 
@@ -43,7 +43,7 @@ func collectMemoryModulesPlatform() []types.MemoryModule {
 	modules := make([]types.MemoryModule, 0)
 
 	// Run system_profiler to get memory information in XML format
-	cmd := exec.Command("system_profiler", "SPMemoryDataType", "-xml")
+	cmd := sandbox.Command("system_profiler", "SPMemoryDataType", "-xml")
 	output, err := cmd.Output()
 	if err != nil {
 		return modules
@@ -4,10 +4,10 @@
 package collector
 
 import (
-	"os/exec"
 	"strconv"
 	"strings"
 
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 	"github.com/mayvqt/sysinfo/internal/types"
 )
 
@@ -32,11 +32,11 @@ func collectMemoryModulesPlatform() []types.MemoryModule {
 	modules := make([]types.MemoryModule, 0)
 
 	// Try dmidecode first (requires root)
-	cmd := exec.Command("dmidecode", "-t", "memory", "-q")
+	cmd := sandbox.Command("dmidecode", "-t", "memory", "-q")
 	output, err := cmd.Output()
 	if err != nil {
 		// Fall back to trying without -q flag
-		cmd = exec.Command("dmidecode", "-t", "17")
+		cmd = sandbox.Command("dmidecode", "-t", "17")
 		output, err = cmd.Output()
 		if err != nil {
 			return modules
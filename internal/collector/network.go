@@ -3,6 +3,7 @@ package collector
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/mayvqt/sysinfo/internal/types"
 	psnet "github.com/shirou/gopsutil/v3/net"
@@ -10,14 +11,41 @@ import (
 
 // CollectNetwork gathers network interface information
 func CollectNetwork() (*types.NetworkData, error) {
+	data := &types.NetworkData{}
+
+	// Below --host-root or --sysfs-root the interfaces are those of the host whose /sys is
+	// mounted there, rather than of the network namespace sysinfo runs in
+	if hostPath("/sys") != "/sys" {
+		data.Interfaces = collectSysfsInterfaces(hostfs)
+	}
+	if data.Interfaces == nil {
+		interfaces, err := collectInterfaces()
+		if err != nil {
+			return nil, err
+		}
+		data.Interfaces = interfaces
+	}
+
+	attachWiFi(data.Interfaces)
+
+	// Get connection count
+	connections, err := psnet.Connections("all")
+	if err == nil {
+		data.Connections = len(connections)
+	}
+
+	return data, nil
+}
+
+// collectInterfaces lists the interfaces of this process's network namespace with their
+// addresses and I/O counters
+func collectInterfaces() ([]types.NetworkInterface, error) {
 	interfaces, err := net.Interfaces()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
 	}
 
-	data := &types.NetworkData{
-		Interfaces: make([]types.NetworkInterface, 0),
-	}
+	result := make([]types.NetworkInterface, 0)
 
 	// Get I/O counters
 	ioCounters, _ := psnet.IOCounters(true)
@@ -67,14 +95,36 @@ func CollectNetwork() (*types.NetworkData, error) {
 			netInterface.DropsOut = io.Dropout
 		}
 
-		data.Interfaces = append(data.Interfaces, netInterface)
+		result = append(result, netInterface)
 	}
 
-	// Get connection count
-	connections, err := psnet.Connections("all")
-	if err == nil {
-		data.Connections = len(connections)
+	return result, nil
+}
+
+// CalculateNetworkRates fills per-interface throughput from two samples taken elapsed apart
+func CalculateNetworkRates(prev, curr *types.NetworkData, elapsed time.Duration) {
+	if prev == nil || curr == nil || elapsed <= 0 {
+		return
 	}
 
-	return data, nil
+	previous := make(map[string]types.NetworkInterface, len(prev.Interfaces))
+	for _, iface := range prev.Interfaces {
+		previous[iface.Name] = iface
+	}
+
+	seconds := elapsed.Seconds()
+	for i := range curr.Interfaces {
+		iface := &curr.Interfaces[i]
+		before, ok := previous[iface.Name]
+		if !ok {
+			continue
+		}
+		// Counters reset when an interface is recreated; skip rather than report a huge rate
+		if iface.BytesSent >= before.BytesSent {
+			iface.SentBytesPerSec = float64(iface.BytesSent-before.BytesSent) / seconds
+		}
+		if iface.BytesRecv >= before.BytesRecv {
+			iface.RecvBytesPerSec = float64(iface.BytesRecv-before.BytesRecv) / seconds
+		}
+	}
 }
@@ -0,0 +1,10 @@
+//go:build darwin
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectSysfsInterfaces returns nil; there is no sysfs on macOS
+func collectSysfsInterfaces(fsys fsReader) []types.NetworkInterface {
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build linux
+
+package collector
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const netClassPath = "/sys/class/net"
+
+// Interface flags, from include/uapi/linux/if.h
+const (
+	iffUp        = 0x1
+	iffBroadcast = 0x2
+	iffLoopback  = 0x8
+	iffMulticast = 0x1000
+)
+
+// collectSysfsInterfaces reads network interfaces and their counters from sysfs, which lists
+// the interfaces of the network namespace it was mounted in: a host's /sys shows the host's
+// interfaces even from inside a container. Addresses are not in sysfs and are left empty.
+// Returns nil when no interfaces are listed
+func collectSysfsInterfaces(fsys fsReader) []types.NetworkInterface {
+	entries, err := fsys.ReadDir(netClassPath)
+	if err != nil {
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var interfaces []types.NetworkInterface
+	for _, entry := range entries {
+		// Entries are symlinks into the device tree
+		dir := filepath.Join(netClassPath, entry.Name())
+		iface := types.NetworkInterface{
+			Name:      entry.Name(),
+			Addresses: []string{},
+			Flags:     []string{},
+		}
+
+		// Loopback and tunnel devices report an all-zero address, which is no address at all
+		if addr, err := readString(fsys, filepath.Join(dir, "address")); err == nil {
+			addr = strings.TrimSpace(addr)
+			if strings.Trim(addr, "0:") != "" {
+				iface.HardwareAddr = addr
+			}
+		}
+		if mtu, err := readSysfsUint(fsys, filepath.Join(dir, "mtu")); err == nil {
+			iface.MTU = int(mtu)
+		}
+		if value, err := readString(fsys, filepath.Join(dir, "flags")); err == nil {
+			if flags, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(value), "0x"), 16, 32); err == nil {
+				iface.Flags = interfaceFlags(flags)
+			}
+		}
+
+		stats := filepath.Join(dir, "statistics")
+		for file, counter := range map[string]*uint64{
+			"tx_bytes":   &iface.BytesSent,
+			"rx_bytes":   &iface.BytesRecv,
+			"tx_packets": &iface.PacketsSent,
+			"rx_packets": &iface.PacketsRecv,
+			"rx_errors":  &iface.ErrorsIn,
+			"tx_errors":  &iface.ErrorsOut,
+			"rx_dropped": &iface.DropsIn,
+			"tx_dropped": &iface.DropsOut,
+		} {
+			if value, err := readSysfsUint(fsys, filepath.Join(stats, file)); err == nil {
+				*counter = value
+			}
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+// interfaceFlags names flags the way the net package's interfaces are reported
+func interfaceFlags(flags uint64) []string {
+	names := []string{}
+	for _, flag := range []struct {
+		bit  uint64
+		name string
+	}{
+		{iffUp, "UP"},
+		{iffBroadcast, "BROADCAST"},
+		{iffLoopback, "LOOPBACK"},
+		{iffMulticast, "MULTICAST"},
+	} {
+		if flags&flag.bit != 0 {
+			names = append(names, flag.name)
+		}
+	}
+	return names
+}
+
+// readSysfsUint reads a sysfs attribute holding one decimal number
+func readSysfsUint(fsys fsReader, path string) (uint64, error) {
+	value, err := readString(fsys, path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+}
@@ -0,0 +1,48 @@
+//go:build linux
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollectSysfsInterfaces(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/net/lo/address":                 "00:00:00:00:00:00",
+		"class/net/lo/mtu":                     "65536",
+		"class/net/lo/flags":                   "0x9",
+		"class/net/eth0/address":               "52:54:00:12:34:56",
+		"class/net/eth0/mtu":                   "1500",
+		"class/net/eth0/flags":                 "0x1003",
+		"class/net/eth0/statistics/rx_bytes":   "1048576",
+		"class/net/eth0/statistics/tx_bytes":   "524288",
+		"class/net/eth0/statistics/rx_dropped": "3",
+	})
+
+	interfaces := collectSysfsInterfaces(hostReader{sysfs: root})
+	if len(interfaces) != 2 {
+		t.Fatalf("collectSysfsInterfaces() found %d interfaces, expected 2: %+v", len(interfaces), interfaces)
+	}
+
+	eth0 := interfaces[0]
+	if eth0.Name != "eth0" || eth0.HardwareAddr != "52:54:00:12:34:56" || eth0.MTU != 1500 {
+		t.Errorf("eth0 = %+v", eth0)
+	}
+	if !reflect.DeepEqual(eth0.Flags, []string{"UP", "BROADCAST", "MULTICAST"}) {
+		t.Errorf("eth0 flags = %v, expected UP BROADCAST MULTICAST", eth0.Flags)
+	}
+	if eth0.BytesRecv != 1048576 || eth0.BytesSent != 524288 || eth0.DropsIn != 3 {
+		t.Errorf("eth0 counters = %+v", eth0)
+	}
+
+	lo := interfaces[1]
+	if lo.HardwareAddr != "" || !reflect.DeepEqual(lo.Flags, []string{"UP", "LOOPBACK"}) {
+		t.Errorf("lo = %+v, expected no hardware address and UP LOOPBACK", lo)
+	}
+
+	if interfaces := collectSysfsInterfaces(hostReader{sysfs: t.TempDir()}); interfaces != nil {
+		t.Errorf("collectSysfsInterfaces() without class/net = %+v, expected nil", interfaces)
+	}
+}
@@ -2,6 +2,9 @@ package collector
 
 import (
 	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
 )
 
 // TestCollectNetwork verifies basic network collection works
@@ -112,3 +115,39 @@ func containsFlag(flags []string, target string) bool {
 	}
 	return false
 }
+
+func TestCalculateNetworkRates(t *testing.T) {
+	prev := &types.NetworkData{Interfaces: []types.NetworkInterface{
+		{Name: "eth0", BytesSent: 1000, BytesRecv: 5000},
+		{Name: "wlan0", BytesSent: 9000, BytesRecv: 9000},
+	}}
+	curr := &types.NetworkData{Interfaces: []types.NetworkInterface{
+		{Name: "eth0", BytesSent: 3000, BytesRecv: 9000},
+		{Name: "wlan0", BytesSent: 100, BytesRecv: 100}, // counter reset
+		{Name: "tun0", BytesSent: 500, BytesRecv: 500},  // new interface
+	}}
+
+	CalculateNetworkRates(prev, curr, 2*time.Second)
+
+	tests := []struct {
+		name     string
+		sent     float64
+		received float64
+	}{
+		{"eth0", 1000, 2000},
+		{"wlan0", 0, 0},
+		{"tun0", 0, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iface := curr.Interfaces[i]
+			if iface.SentBytesPerSec != tt.sent {
+				t.Errorf("SentBytesPerSec = %v, expected %v", iface.SentBytesPerSec, tt.sent)
+			}
+			if iface.RecvBytesPerSec != tt.received {
+				t.Errorf("RecvBytesPerSec = %v, expected %v", iface.RecvBytesPerSec, tt.received)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"math"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// defaultFanModel is assumed for fans no configured model matches:
+// a typical 120 mm case fan
+var defaultFanModel = config.FanModel{Name: "generic 120 mm fan", MaxRPM: 1500, MaxDBA: 25}
+
+// estimateNoise approximates the sound pressure level of a machine from its fan speeds.
+// Each fan follows the fan law L = Lmax + 50·log10(rpm/maxRPM) and the fans add
+// up as incoherent sources. Stopped fans are left out
+func estimateNoise(fans []types.FanInfo, models []config.FanModel) *types.NoiseEstimate {
+	estimate := &types.NoiseEstimate{}
+	var power float64
+	for _, fan := range fans {
+		if fan.RPM <= 0 {
+			continue
+		}
+		model := matchFanModel(fan.Name, models)
+		dba := model.MaxDBA + 50*math.Log10(float64(fan.RPM)/model.MaxRPM)
+		estimate.Fans = append(estimate.Fans, types.FanNoise{
+			Name:  fan.Name,
+			RPM:   fan.RPM,
+			DBA:   math.Round(dba*10) / 10,
+			Model: model.Name,
+		})
+		power += math.Pow(10, dba/10)
+	}
+	if len(estimate.Fans) == 0 {
+		return nil
+	}
+	estimate.TotalDBA = math.Round(10*math.Log10(power)*10) / 10
+	return estimate
+}
+
+// matchFanModel returns the first model whose Match is part of the fan name
+func matchFanModel(name string, models []config.FanModel) config.FanModel {
+	lower := strings.ToLower(name)
+	for _, model := range models {
+		if model.MaxRPM <= 0 || !strings.Contains(lower, strings.ToLower(model.Match)) {
+			continue
+		}
+		if model.Name == "" {
+			model.Name = model.Match
+		}
+		return model
+	}
+	return defaultFanModel
+}
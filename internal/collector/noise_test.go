@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestEstimateNoise(t *testing.T) {
+	models := []config.FanModel{
+		{Match: "cpu", Name: "Noctua NF-A12x25", MaxRPM: 2000, MaxDBA: 22.6},
+		{Match: "broken", MaxRPM: 0, MaxDBA: 40}, // Invalid, ignored
+	}
+	fans := []types.FanInfo{
+		{Name: "CPU Fan", RPM: 2000},
+		{Name: "nct6798/fan2", RPM: 750},
+		{Name: "broken/fan3", RPM: 1500},
+		{Name: "nct6798/fan4", RPM: 0},
+	}
+
+	estimate := estimateNoise(fans, models)
+	if estimate == nil || len(estimate.Fans) != 3 {
+		t.Fatalf("estimateNoise() = %+v, expected 3 spinning fans", estimate)
+	}
+
+	cpu := estimate.Fans[0]
+	if cpu.Model != "Noctua NF-A12x25" || cpu.DBA != 22.6 {
+		t.Errorf("CPU fan = %+v, expected 22.6 dBA at rated speed", cpu)
+	}
+	// Half speed is 50·log10(0.5) ≈ 15 dB quieter
+	if half := estimate.Fans[1]; half.Model != defaultFanModel.Name || half.DBA != 9.9 {
+		t.Errorf("half-speed fan = %+v, expected 9.9 dBA on the default model", half)
+	}
+	if broken := estimate.Fans[2]; broken.Model != defaultFanModel.Name {
+		t.Errorf("fan matching an invalid model = %+v, expected the default model", broken)
+	}
+
+	// Two equal sources are 3 dB louder than one
+	if estimate.TotalDBA <= 25 || estimate.TotalDBA >= 28.3 {
+		t.Errorf("TotalDBA = %v, expected between the loudest fan and their sum", estimate.TotalDBA)
+	}
+	twin := estimateNoise([]types.FanInfo{{Name: "a", RPM: 1500}, {Name: "b", RPM: 1500}}, nil)
+	if twin.TotalDBA != 28 {
+		t.Errorf("TotalDBA of two default fans at full speed = %v, expected 28", twin.TotalDBA)
+	}
+
+	if estimate := estimateNoise([]types.FanInfo{{Name: "idle", RPM: 0}}, nil); estimate != nil {
+		t.Errorf("estimateNoise() with stopped fans = %+v, expected nil", estimate)
+	}
+}
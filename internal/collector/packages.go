@@ -0,0 +1,34 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectPackages gathers the installed packages of every package database found, sorted by
+// name
+func CollectPackages() (*types.PackageData, error) {
+	data := collectPackagesPlatform()
+	if data == nil || len(data.Packages) == 0 {
+		return nil, fmt.Errorf("no installed packages found")
+	}
+	sort.SliceStable(data.Packages, func(i, j int) bool {
+		a, b := data.Packages[i], data.Packages[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Manager < b.Manager
+	})
+	return data, nil
+}
+
+// addPackages appends the packages of one database, recording it among the managers
+func addPackages(data *types.PackageData, manager string, packages []types.InstalledPackage) {
+	if len(packages) == 0 {
+		return
+	}
+	data.Managers = append(data.Managers, manager)
+	data.Packages = append(data.Packages, packages...)
+}
@@ -0,0 +1,53 @@
+//go:build darwin
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// homebrewPrefixes are where Homebrew installs: /opt/homebrew on Apple silicon, /usr/local on Intel
+var homebrewPrefixes = []string{"/opt/homebrew", "/usr/local"}
+
+// collectPackagesPlatform lists the Homebrew formulae and casks from their install
+// directories, as brew itself refuses to run as root
+func collectPackagesPlatform() *types.PackageData {
+	data := &types.PackageData{}
+	var formulae, casks []types.InstalledPackage
+	for _, prefix := range homebrewPrefixes {
+		formulae = append(formulae, homebrewPackages(filepath.Join(prefix, "Cellar"), "homebrew")...)
+		casks = append(casks, homebrewPackages(filepath.Join(prefix, "Caskroom"), "homebrew-cask")...)
+	}
+	addPackages(data, "homebrew", formulae)
+	addPackages(data, "homebrew-cask", casks)
+	return data
+}
+
+// homebrewPackages reads a Cellar or Caskroom, a directory per package holding a directory
+// per installed version; a package with several versions installed is listed once for each
+func homebrewPackages(dir, manager string) []types.InstalledPackage {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var packages []types.InstalledPackage
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		versions, err := os.ReadDir(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		for _, version := range versions {
+			if version.IsDir() && !strings.HasPrefix(version.Name(), ".") {
+				packages = append(packages, types.InstalledPackage{Name: entry.Name(), Version: version.Name(), Manager: manager})
+			}
+		}
+	}
+	return packages
+}
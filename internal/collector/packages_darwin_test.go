@@ -0,0 +1,29 @@
+//go:build darwin
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHomebrewPackages(t *testing.T) {
+	cellar := t.TempDir()
+	for _, dir := range []string{"python@3.12/3.12.4", "openssl@3/3.3.0", "openssl@3/3.3.1", ".keepme", "wget/.metadata"} {
+		if err := os.MkdirAll(filepath.Join(cellar, dir), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	packages := homebrewPackages(cellar, "homebrew")
+	if len(packages) != 3 {
+		t.Fatalf("homebrewPackages() = %+v, expected both openssl versions and python", packages)
+	}
+	if openssl := packages[1]; openssl.Name != "openssl@3" || openssl.Version != "3.3.1" || openssl.Manager != "homebrew" {
+		t.Errorf("second package = %+v, expected openssl@3 3.3.1", openssl)
+	}
+	if packages := homebrewPackages(filepath.Join(cellar, "missing"), "homebrew"); packages != nil {
+		t.Errorf("homebrewPackages() without a Cellar = %+v, expected none", packages)
+	}
+}
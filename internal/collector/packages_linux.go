@@ -0,0 +1,133 @@
+//go:build linux
+
+package collector
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	dpkgStatusPath  = "/var/lib/dpkg/status"
+	pacmanLocalPath = "/var/lib/pacman/local"
+)
+
+// rpmQueryFormat writes one package per line with its epoch, when it has one, before the version
+const rpmQueryFormat = `%{NAME}\t%|EPOCH?{%{EPOCH}:}|%{VERSION}-%{RELEASE}\t%{ARCH}\n`
+
+func collectPackagesPlatform() *types.PackageData {
+	data := collectPackageDatabases(hostfs)
+	addPackages(data, "rpm", collectRPMPackages())
+	return data
+}
+
+// collectPackageDatabases reads the dpkg and pacman databases, which are plain files and
+// need neither tool installed
+func collectPackageDatabases(fsys fsReader) *types.PackageData {
+	data := &types.PackageData{}
+	if status, err := readString(fsys, dpkgStatusPath); err == nil {
+		addPackages(data, "dpkg", parseDpkgStatus(status))
+	}
+	if entries, err := fsys.ReadDir(pacmanLocalPath); err == nil {
+		var packages []types.InstalledPackage
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if desc, err := readString(fsys, filepath.Join(pacmanLocalPath, entry.Name(), "desc")); err == nil {
+				if pkg, ok := parsePacmanDesc(desc); ok {
+					packages = append(packages, pkg)
+				}
+			}
+		}
+		addPackages(data, "pacman", packages)
+	}
+	return data
+}
+
+// collectRPMPackages queries the rpm database, the host's when reading one mounted elsewhere
+func collectRPMPackages() []types.InstalledPackage {
+	if _, err := sandbox.LookPath("rpm"); err != nil {
+		return nil
+	}
+	args := []string{"-qa", "--qf", rpmQueryFormat}
+	if readingHost() {
+		args = append([]string{"--root", hostPath("/")}, args...)
+	}
+	out, err := sandbox.Command("rpm", args...).Output()
+	if err != nil {
+		return nil
+	}
+	return parseRPMQuery(string(out))
+}
+
+// parseDpkgStatus reads the dpkg status file, one stanza per package separated by blank
+// lines. Packages removed but with their configuration kept are listed there too; only those
+// in the "installed" state are returned
+func parseDpkgStatus(content string) []types.InstalledPackage {
+	var packages []types.InstalledPackage
+	for _, stanza := range strings.Split(content, "\n\n") {
+		fields := make(map[string]string)
+		for _, line := range strings.Split(stanza, "\n") {
+			if key, value, found := strings.Cut(line, ": "); found && !strings.HasPrefix(line, " ") {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+		if fields["Package"] == "" || !strings.HasSuffix(fields["Status"], " installed") {
+			continue
+		}
+		packages = append(packages, types.InstalledPackage{
+			Name:    fields["Package"],
+			Version: fields["Version"],
+			Arch:    fields["Architecture"],
+			Manager: "dpkg",
+		})
+	}
+	return packages
+}
+
+// parsePacmanDesc reads a package's desc file in the pacman database, %KEY% headers each
+// followed by their values:
+//
+//	%NAME%
+//	linux
+//
+//	%VERSION%
+//	6.9.7.arch1-1
+func parsePacmanDesc(content string) (types.InstalledPackage, bool) {
+	pkg := types.InstalledPackage{Manager: "pacman"}
+	lines := strings.Split(content, "\n")
+	for i := 0; i+1 < len(lines); i++ {
+		value := strings.TrimSpace(lines[i+1])
+		switch strings.TrimSpace(lines[i]) {
+		case "%NAME%":
+			pkg.Name = value
+		case "%VERSION%":
+			pkg.Version = value
+		case "%ARCH%":
+			pkg.Arch = value
+		}
+	}
+	return pkg, pkg.Name != ""
+}
+
+// parseRPMQuery reads the output of rpm -qa with rpmQueryFormat. The gpg-pubkey entries
+// are the repository signing keys rpm stores as packages and are left out
+func parseRPMQuery(output string) []types.InstalledPackage {
+	var packages []types.InstalledPackage
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 || fields[0] == "" || fields[0] == "gpg-pubkey" {
+			continue
+		}
+		pkg := types.InstalledPackage{Name: fields[0], Version: fields[1], Manager: "rpm"}
+		if fields[2] != "(none)" {
+			pkg.Arch = fields[2]
+		}
+		packages = append(packages, pkg)
+	}
+	return packages
+}
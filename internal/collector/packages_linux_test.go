@@ -0,0 +1,94 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const dpkgStatus = `Package: bash
+Status: install ok installed
+Priority: required
+Architecture: amd64
+Version: 5.2.15-2+b7
+Description: GNU Bourne Again SHell
+ Bash is an sh-compatible command language interpreter.
+ Version: not a field
+
+Package: nginx
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.22.1-9
+
+Package: tzdata
+Status: install ok installed
+Architecture: all
+Version: 2024a-0+deb12u1
+`
+
+const pacmanDesc = `%NAME%
+linux
+
+%VERSION%
+6.9.7.arch1-1
+
+%BASE%
+linux
+
+%ARCH%
+x86_64
+`
+
+func TestParseDpkgStatus(t *testing.T) {
+	expected := []types.InstalledPackage{
+		{Name: "bash", Version: "5.2.15-2+b7", Arch: "amd64", Manager: "dpkg"},
+		{Name: "tzdata", Version: "2024a-0+deb12u1", Arch: "all", Manager: "dpkg"},
+	}
+	if packages := parseDpkgStatus(dpkgStatus); !reflect.DeepEqual(packages, expected) {
+		t.Errorf("parseDpkgStatus() = %+v, expected the installed packages without nginx's leftover configuration: %+v", packages, expected)
+	}
+}
+
+func TestParseRPMQuery(t *testing.T) {
+	output := "bash\t5.1.8-9.el9\tx86_64\nopenssl\t1:3.0.7-27.el9\tx86_64\ngpg-pubkey\tfd431d51-4ae0493b\t(none)\nbad line\n"
+	expected := []types.InstalledPackage{
+		{Name: "bash", Version: "5.1.8-9.el9", Arch: "x86_64", Manager: "rpm"},
+		{Name: "openssl", Version: "1:3.0.7-27.el9", Arch: "x86_64", Manager: "rpm"},
+	}
+	if packages := parseRPMQuery(output); !reflect.DeepEqual(packages, expected) {
+		t.Errorf("parseRPMQuery() = %+v, expected %+v", packages, expected)
+	}
+}
+
+func TestCollectPackageDatabases(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	write("var/lib/dpkg/status", dpkgStatus)
+	write("var/lib/pacman/local/linux-6.9.7.arch1-1/desc", pacmanDesc)
+	write("var/lib/pacman/local/ALPM_DB_VERSION", "9\n")
+
+	data := collectPackageDatabases(hostReader{root: root})
+	if !reflect.DeepEqual(data.Managers, []string{"dpkg", "pacman"}) || len(data.Packages) != 3 {
+		t.Fatalf("collectPackageDatabases() = %+v, expected 2 dpkg packages and 1 pacman package", data)
+	}
+	if linux := data.Packages[2]; linux != (types.InstalledPackage{Name: "linux", Version: "6.9.7.arch1-1", Arch: "x86_64", Manager: "pacman"}) {
+		t.Errorf("pacman package = %+v, expected linux 6.9.7.arch1-1", linux)
+	}
+
+	if data := collectPackageDatabases(hostReader{root: filepath.Join(root, "missing")}); len(data.Packages) != 0 || data.Managers != nil {
+		t.Errorf("collectPackageDatabases() without databases = %+v, expected none", data)
+	}
+}
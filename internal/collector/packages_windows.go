@@ -0,0 +1,116 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"golang.org/x/sys/windows/registry"
+)
+
+// uninstallKeys are where installers register their programs for Programs and Features:
+// 64-bit and 32-bit machine-wide installs, and per-user installs of the user running sysinfo
+var uninstallKeys = []struct {
+	root registry.Key
+	path string
+}{
+	{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.LOCAL_MACHINE, `SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall`},
+	{registry.CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Uninstall`},
+}
+
+// uninstallEntry is the registration of an installed program
+type uninstallEntry struct {
+	DisplayName      string
+	DisplayVersion   string
+	Publisher        string
+	WindowsInstaller uint64 // 1 for MSI packages
+	SystemComponent  uint64 // 1 for parts of other programs hidden from Programs and Features
+	ParentKeyName    string // Set for updates of another program
+	ReleaseType      string // e.g. Update, Hotfix, Security Update
+}
+
+// collectPackagesPlatform lists the programs registered for uninstallation, which covers
+// MSI packages and the installers winget and other tools run
+func collectPackagesPlatform() *types.PackageData {
+	var entries []uninstallEntry
+	for _, location := range uninstallKeys {
+		entries = append(entries, readUninstallEntries(location.root, location.path)...)
+	}
+	data := &types.PackageData{}
+	packages := installedPrograms(entries)
+	var msi, programs []types.InstalledPackage
+	for _, pkg := range packages {
+		if pkg.Manager == "msi" {
+			msi = append(msi, pkg)
+		} else {
+			programs = append(programs, pkg)
+		}
+	}
+	addPackages(data, "msi", msi)
+	addPackages(data, "programs", programs)
+	return data
+}
+
+// readUninstallEntries reads the program registrations below an Uninstall key
+func readUninstallEntries(root registry.Key, path string) []uninstallEntry {
+	key, err := registry.OpenKey(root, path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+	names, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil
+	}
+
+	var entries []uninstallEntry
+	for _, name := range names {
+		sub, err := registry.OpenKey(key, name, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		var entry uninstallEntry
+		entry.DisplayName, _, _ = sub.GetStringValue("DisplayName")
+		entry.DisplayVersion, _, _ = sub.GetStringValue("DisplayVersion")
+		entry.Publisher, _, _ = sub.GetStringValue("Publisher")
+		entry.WindowsInstaller, _, _ = sub.GetIntegerValue("WindowsInstaller")
+		entry.SystemComponent, _, _ = sub.GetIntegerValue("SystemComponent")
+		entry.ParentKeyName, _, _ = sub.GetStringValue("ParentKeyName")
+		entry.ReleaseType, _, _ = sub.GetStringValue("ReleaseType")
+		sub.Close()
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// installedPrograms lists the programs Programs and Features shows: registrations without a
+// name, hidden components and updates of other programs are left out, and a program
+// registered under several keys is listed once
+func installedPrograms(entries []uninstallEntry) []types.InstalledPackage {
+	var packages []types.InstalledPackage
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		name := strings.TrimSpace(entry.DisplayName)
+		if name == "" || entry.SystemComponent == 1 || entry.ParentKeyName != "" || strings.Contains(entry.ReleaseType, "Update") || entry.ReleaseType == "Hotfix" {
+			continue
+		}
+		version := strings.TrimSpace(entry.DisplayVersion)
+		if seen[name+"\x00"+version] {
+			continue
+		}
+		seen[name+"\x00"+version] = true
+		pkg := types.InstalledPackage{
+			Name:      name,
+			Version:   version,
+			Manager:   "programs",
+			Publisher: strings.TrimSpace(entry.Publisher),
+		}
+		if entry.WindowsInstaller == 1 {
+			pkg.Manager = "msi"
+		}
+		packages = append(packages, pkg)
+	}
+	return packages
+}
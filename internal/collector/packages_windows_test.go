@@ -0,0 +1,26 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestInstalledPrograms(t *testing.T) {
+	packages := installedPrograms([]uninstallEntry{
+		{DisplayName: "7-Zip 23.01 (x64)", DisplayVersion: "23.01", Publisher: "Igor Pavlov"},
+		{DisplayName: "Microsoft Visual C++ 2022 X64 Minimum Runtime", DisplayVersion: "14.38.33135", WindowsInstaller: 1, SystemComponent: 1},
+		{DisplayName: "Git", DisplayVersion: "2.45.1", Publisher: "The Git Development Community"},
+		{DisplayName: "Git", DisplayVersion: "2.45.1", Publisher: "The Git Development Community"},
+		{DisplayName: "Security Update for Office (KB5002561)", ParentKeyName: "Office16.PROPLUS", ReleaseType: "Security Update"},
+		{DisplayName: "Google Chrome", DisplayVersion: "125.0.6422.142", Publisher: "Google LLC", WindowsInstaller: 1},
+		{DisplayVersion: "1.0"},
+	})
+	if len(packages) != 3 {
+		t.Fatalf("installedPrograms() = %+v, expected 7-Zip, Git and Chrome", packages)
+	}
+	if zip := packages[0]; zip.Name != "7-Zip 23.01 (x64)" || zip.Manager != "programs" || zip.Publisher != "Igor Pavlov" {
+		t.Errorf("first program = %+v, expected 7-Zip from its installer", zip)
+	}
+	if chrome := packages[2]; chrome.Manager != "msi" || chrome.Version != "125.0.6422.142" {
+		t.Errorf("third program = %+v, expected Chrome from an MSI", chrome)
+	}
+}
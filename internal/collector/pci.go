@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// pciClassNames names PCI base classes (two hex digits) and, for the common ones, their
+// subclasses (four), as pci.ids does
+var pciClassNames = map[string]string{
+	"00": "Unclassified device",
+	"01": "Mass storage controller", "0100": "SCSI storage controller", "0101": "IDE interface",
+	"0104": "RAID bus controller", "0105": "ATA controller", "0106": "SATA controller",
+	"0107": "Serial Attached SCSI controller", "0108": "Non-Volatile memory controller",
+	"02": "Network controller", "0200": "Ethernet controller", "0207": "Infiniband controller",
+	"03": "Display controller", "0300": "VGA compatible controller", "0302": "3D controller",
+	"04": "Multimedia controller", "0400": "Multimedia video controller",
+	"0401": "Multimedia audio controller", "0403": "Audio device",
+	"05": "Memory controller", "0500": "RAM memory",
+	"06": "Bridge", "0600": "Host bridge", "0601": "ISA bridge", "0604": "PCI bridge",
+	"07": "Communication controller", "0700": "Serial controller",
+	"08": "Generic system peripheral", "0805": "SD Host controller", "0806": "IOMMU",
+	"09": "Input device controller",
+	"0a": "Docking station",
+	"0b": "Processor",
+	"0c": "Serial bus controller", "0c00": "FireWire (IEEE 1394)", "0c03": "USB controller",
+	"0c04": "Fibre Channel", "0c05": "SMBus",
+	"0d": "Wireless controller",
+	"0e": "Intelligent controller",
+	"0f": "Satellite communications controller",
+	"10": "Encryption controller",
+	"11": "Signal processing controller",
+	"12": "Processing accelerators",
+	"13": "Non-Essential Instrumentation",
+	"40": "Coprocessor",
+	"ff": "Unassigned class",
+}
+
+// CollectPCI gathers every PCI function with its IDs, class and bound driver
+func CollectPCI() (*types.PCIData, error) {
+	devices := collectPCIPlatform()
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no PCI devices found")
+	}
+
+	for i := range devices {
+		if devices[i].ClassName == "" {
+			devices[i].ClassName = pciClassName(devices[i].Class)
+		}
+	}
+	sort.SliceStable(devices, func(i, j int) bool {
+		return devices[i].Address < devices[j].Address
+	})
+	return &types.PCIData{Devices: devices}, nil
+}
+
+// pciClassName names a class code such as 040300 or 0403 by its subclass, falling back to
+// its base class
+func pciClassName(class string) string {
+	class = normalizeHexID(class)
+	if len(class) >= 4 {
+		if name, ok := pciClassNames[class[:4]]; ok {
+			return name
+		}
+	}
+	if len(class) >= 2 {
+		return pciClassNames[class[:2]]
+	}
+	return ""
+}
@@ -0,0 +1,11 @@
+//go:build darwin
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectPCIPlatform reports no PCI devices on macOS; Apple silicon's devices are part of
+// the SoC and IOKit does not list them by PCI identity
+func collectPCIPlatform() []types.PCIDevice {
+	return nil
+}
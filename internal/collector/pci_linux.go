@@ -0,0 +1,145 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectPCIPlatform reads every PCI function from sysfs, then names them with lspci, which
+// looks the IDs up in pci.ids. Without sysfs, such as in some containers, lspci's own
+// listing is used
+func collectPCIPlatform() []types.PCIDevice {
+	devices := scanPCIDevices(hostPath(pciDevicesPath))
+	if out, err := sandbox.Command("lspci", "-vmm", "-nn", "-k", "-D").Output(); err == nil {
+		devices = mergeLspci(devices, parseLspci(string(out)))
+	}
+	return devices
+}
+
+// scanPCIDevices reads the IDs, class and bound driver of every PCI function below root
+func scanPCIDevices(root string) []types.PCIDevice {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var devices []types.PCIDevice
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		read := func(name string) string {
+			value, _ := readSysFile(filepath.Join(dir, name))
+			return normalizeHexID(value)
+		}
+		vendor := read("vendor")
+		if vendor == "" {
+			continue
+		}
+		device := types.PCIDevice{
+			Address:           entry.Name(),
+			VendorID:          vendor,
+			DeviceID:          read("device"),
+			SubsystemVendorID: read("subsystem_vendor"),
+			SubsystemID:       read("subsystem_device"),
+			Revision:          read("revision"),
+			Class:             read("class"),
+		}
+		device.Driver, _ = boundDriver(dir)
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// parseLspci parses `lspci -vmm -nn -k -D` output: one block of "Key:<tab>Value" lines per
+// function, separated by blank lines, with names followed by their IDs as in
+// "Intel Corporation [8086]"
+func parseLspci(output string) []types.PCIDevice {
+	var devices []types.PCIDevice
+	var current *types.PCIDevice
+	var progIf string
+	finish := func() {
+		if current != nil && current.Address != "" {
+			if current.Class != "" {
+				if progIf == "" {
+					progIf = "00"
+				}
+				current.Class += progIf
+			}
+			devices = append(devices, *current)
+		}
+		current, progIf = nil, ""
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			finish()
+			continue
+		}
+		if current == nil {
+			current = &types.PCIDevice{}
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Slot":
+			current.Address = value
+		case "Class":
+			current.ClassName, current.Class = lspciName(value)
+		case "Vendor":
+			current.Vendor, current.VendorID = lspciName(value)
+		case "Device":
+			current.Name, current.DeviceID = lspciName(value)
+		case "SVendor":
+			_, current.SubsystemVendorID = lspciName(value)
+		case "SDevice":
+			_, current.SubsystemID = lspciName(value)
+		case "Rev":
+			current.Revision = normalizeHexID(value)
+		case "ProgIf":
+			progIf = normalizeHexID(value)
+		case "Driver":
+			current.Driver = value
+		}
+	}
+	finish()
+	return devices
+}
+
+// lspciName splits "Audio device [0403]" into its name and lowercase hex ID. lspci names
+// IDs missing from pci.ids just "Device" or "Vendor", which says nothing
+func lspciName(value string) (name, id string) {
+	name = value
+	if open := strings.LastIndex(value, " ["); open >= 0 && strings.HasSuffix(value, "]") {
+		name, id = value[:open], normalizeHexID(value[open+2:len(value)-1])
+	}
+	if name == "Device" || name == "Vendor" {
+		name = ""
+	}
+	return name, id
+}
+
+// mergeLspci names the sysfs devices after lspci's listing, adding the functions only lspci saw
+func mergeLspci(devices, listed []types.PCIDevice) []types.PCIDevice {
+	byAddress := make(map[string]int, len(devices))
+	for i, d := range devices {
+		byAddress[d.Address] = i
+	}
+	for _, l := range listed {
+		i, ok := byAddress[l.Address]
+		if !ok {
+			devices = append(devices, l)
+			continue
+		}
+		d := &devices[i]
+		d.Vendor, d.Name, d.ClassName = l.Vendor, l.Name, l.ClassName
+		if d.Driver == "" {
+			d.Driver = l.Driver
+		}
+	}
+	return devices
+}
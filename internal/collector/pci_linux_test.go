@@ -0,0 +1,117 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const lspciOutput = `Slot:	0000:00:1f.3
+Class:	Audio device [0403]
+Vendor:	Intel Corporation [8086]
+Device:	Cannon Lake PCH cAVS [a348]
+SVendor:	Lenovo [17aa]
+SDevice:	Device [2292]
+Rev:	10
+ProgIf:	80
+Driver:	snd_hda_intel
+Module:	snd_hda_intel
+Module:	snd_sof_pci
+
+Slot:	0000:3d:00.0
+Class:	Non-Volatile memory controller [0108]
+Vendor:	Vendor [1e0f]
+Device:	Device [0001]
+ProgIf:	02
+Driver:	nvme
+
+Slot:	0000:00:14.0
+Class:	USB controller [0c03]
+Vendor:	Intel Corporation [8086]
+Device:	Cannon Lake PCH USB 3.1 xHCI Host Controller [a36d]
+ProgIf:	30
+`
+
+func TestParseLspci(t *testing.T) {
+	devices := parseLspci(lspciOutput)
+	if len(devices) != 3 {
+		t.Fatalf("parseLspci() found %d devices, expected 3: %+v", len(devices), devices)
+	}
+	expected := types.PCIDevice{
+		Address:           "0000:00:1f.3",
+		VendorID:          "8086",
+		DeviceID:          "a348",
+		SubsystemVendorID: "17aa",
+		SubsystemID:       "2292",
+		Revision:          "10",
+		Class:             "040380",
+		ClassName:         "Audio device",
+		Vendor:            "Intel Corporation",
+		Name:              "Cannon Lake PCH cAVS",
+		Driver:            "snd_hda_intel",
+	}
+	if devices[0] != expected {
+		t.Errorf("parseLspci()[0] = %+v, expected %+v", devices[0], expected)
+	}
+	// IDs missing from pci.ids keep their IDs but no placeholder names
+	if d := devices[1]; d.Vendor != "" || d.Name != "" || d.VendorID != "1e0f" || d.Class != "010802" {
+		t.Errorf("parseLspci()[1] = %+v, expected unnamed 1e0f:0001", d)
+	}
+	// The last block needs no trailing blank line; no driver is bound
+	if d := devices[2]; d.Address != "0000:00:14.0" || d.Driver != "" || d.Class != "0c0330" {
+		t.Errorf("parseLspci()[2] = %+v", d)
+	}
+}
+
+func TestScanPCIDevices(t *testing.T) {
+	root := t.TempDir()
+	writeFunction := func(address string, files map[string]string, driver string) {
+		dir := filepath.Join(root, address)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if driver != "" {
+			if err := os.Symlink(filepath.Join("drivers", driver), filepath.Join(dir, "driver")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeFunction("0000:00:1f.3", map[string]string{
+		"vendor": "0x8086", "device": "0xa348", "subsystem_vendor": "0x17aa", "subsystem_device": "0x2292",
+		"revision": "0x10", "class": "0x040380",
+	}, "snd_hda_intel")
+	writeFunction("0000:00:14.0", map[string]string{"vendor": "0x8086", "device": "0xa36d", "class": "0x0c0330"}, "")
+
+	devices := scanPCIDevices(root)
+	if len(devices) != 2 {
+		t.Fatalf("scanPCIDevices() found %d devices, expected 2: %+v", len(devices), devices)
+	}
+	byAddress := map[string]types.PCIDevice{}
+	for _, d := range devices {
+		byAddress[d.Address] = d
+	}
+	audio := byAddress["0000:00:1f.3"]
+	if audio.VendorID != "8086" || audio.SubsystemID != "2292" || audio.Revision != "10" || audio.Class != "040380" || audio.Driver != "snd_hda_intel" {
+		t.Errorf("audio = %+v", audio)
+	}
+
+	// lspci names the sysfs functions and adds the ones sysfs lacks
+	merged := mergeLspci(devices, parseLspci(lspciOutput))
+	if len(merged) != 3 {
+		t.Fatalf("mergeLspci() = %d devices, expected 3", len(merged))
+	}
+	for _, d := range merged {
+		if d.Address == "0000:00:1f.3" && (d.Name != "Cannon Lake PCH cAVS" || d.Vendor != "Intel Corporation" || d.ClassName != "Audio device") {
+			t.Errorf("merged audio = %+v, expected lspci's names", d)
+		}
+	}
+}
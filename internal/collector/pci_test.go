@@ -0,0 +1,20 @@
+package collector
+
+import "testing"
+
+func TestPCIClassName(t *testing.T) {
+	tests := map[string]string{
+		"010802":   "Non-Volatile memory controller",
+		"0x030000": "VGA compatible controller",
+		"0403":     "Audio device",
+		"078000":   "Communication controller", // Subclass not listed
+		"120000":   "Processing accelerators",
+		"":         "",
+		"99":       "",
+	}
+	for class, expected := range tests {
+		if got := pciClassName(class); got != expected {
+			t.Errorf("pciClassName(%q) = %q, expected %q", class, got, expected)
+		}
+	}
+}
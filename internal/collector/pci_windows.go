@@ -0,0 +1,76 @@
+//go:build windows
+
+package collector
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// pnpPCIEntity is a PCI function as Win32_PnPEntity reports it
+type pnpPCIEntity struct {
+	Name                   string
+	Manufacturer           string
+	PNPDeviceID            string   // e.g. PCI\VEN_8086&DEV_A348&SUBSYS_229217AA&REV_10\3&11583659&0&FB
+	CompatibleID           []string // Includes the class code, e.g. PCI\CC_040380
+	Service                string
+	ConfigManagerErrorCode uint32
+}
+
+var (
+	pnpSubsysRe   = regexp.MustCompile(`(?i)&SUBSYS_([0-9A-F]{4})([0-9A-F]{4})`)
+	pnpRevisionRe = regexp.MustCompile(`(?i)&REV_([0-9A-F]{2})`)
+	pnpClassRe    = regexp.MustCompile(`(?i)^PCI\\CC_([0-9A-F]{4,6})$`)
+)
+
+// collectPCIPlatform lists the PCI PnP devices
+func collectPCIPlatform() []types.PCIDevice {
+	var entities []pnpPCIEntity
+	query := "SELECT Name, Manufacturer, PNPDeviceID, CompatibleID, Service, ConfigManagerErrorCode FROM Win32_PnPEntity WHERE PNPDeviceID LIKE 'PCI\\\\%'"
+	if err := wmi.Query(query, &entities); err != nil {
+		return nil
+	}
+
+	var devices []types.PCIDevice
+	for _, entity := range entities {
+		if device, ok := pnpPCIDevice(entity); ok {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// pnpPCIDevice reads the IDs from a PnP device ID and the most specific class code from
+// the compatible IDs. The driver is only reported while the device is started
+func pnpPCIDevice(entity pnpPCIEntity) (types.PCIDevice, bool) {
+	m := pnpPCIIDRe.FindStringSubmatch(entity.PNPDeviceID)
+	if m == nil {
+		return types.PCIDevice{}, false
+	}
+	device := types.PCIDevice{
+		Address:  strings.TrimSpace(entity.PNPDeviceID),
+		VendorID: normalizeHexID(m[1]),
+		DeviceID: normalizeHexID(m[2]),
+		Name:     strings.TrimSpace(entity.Name),
+		Vendor:   strings.TrimSpace(entity.Manufacturer),
+	}
+	// SUBSYS is the subsystem device ID followed by the subsystem vendor ID
+	if s := pnpSubsysRe.FindStringSubmatch(entity.PNPDeviceID); s != nil {
+		device.SubsystemID, device.SubsystemVendorID = normalizeHexID(s[1]), normalizeHexID(s[2])
+	}
+	if r := pnpRevisionRe.FindStringSubmatch(entity.PNPDeviceID); r != nil {
+		device.Revision = normalizeHexID(r[1])
+	}
+	for _, id := range entity.CompatibleID {
+		if c := pnpClassRe.FindStringSubmatch(id); c != nil && len(c[1]) > len(device.Class) {
+			device.Class = normalizeHexID(c[1])
+		}
+	}
+	if entity.Service != "" && entity.ConfigManagerErrorCode == 0 {
+		device.Driver = entity.Service
+	}
+	return device, true
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestPnPPCIDevice(t *testing.T) {
+	device, ok := pnpPCIDevice(pnpPCIEntity{
+		Name:         "High Definition Audio Controller",
+		Manufacturer: "Microsoft",
+		PNPDeviceID:  `PCI\VEN_8086&DEV_A348&SUBSYS_229217AA&REV_10\3&11583659&0&FB`,
+		CompatibleID: []string{`PCI\VEN_8086&DEV_A348&REV_10`, `PCI\VEN_8086&CC_040380`, `PCI\CC_040380`, `PCI\CC_0403`},
+		Service:      "HDAudBus",
+	})
+	expected := types.PCIDevice{
+		Address:           `PCI\VEN_8086&DEV_A348&SUBSYS_229217AA&REV_10\3&11583659&0&FB`,
+		VendorID:          "8086",
+		DeviceID:          "a348",
+		SubsystemVendorID: "17aa",
+		SubsystemID:       "2292",
+		Revision:          "10",
+		Class:             "040380",
+		Vendor:            "Microsoft",
+		Name:              "High Definition Audio Controller",
+		Driver:            "HDAudBus",
+	}
+	if !ok || device != expected {
+		t.Errorf("pnpPCIDevice() = %+v, expected %+v", device, expected)
+	}
+
+	// A device without a working driver
+	device, _ = pnpPCIDevice(pnpPCIEntity{PNPDeviceID: `PCI\VEN_10EE&DEV_903F\4&1`, Service: "xdma", ConfigManagerErrorCode: 28})
+	if device.Driver != "" || device.Class != "" {
+		t.Errorf("pnpPCIDevice() = %+v, expected no driver or class", device)
+	}
+
+	if _, ok := pnpPCIDevice(pnpPCIEntity{PNPDeviceID: `ACPI\PNP0C0A\1`}); ok {
+		t.Error("pnpPCIDevice() accepted a non-PCI device")
+	}
+}
@@ -2,14 +2,23 @@ package collector
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 	"github.com/shirou/gopsutil/v3/process"
 )
 
+// ProcessOptions controls optional per-process details captured for the top processes
+type ProcessOptions struct {
+	Cmdline bool     // Capture the redacted command line
+	Env     []string // Environment variable names to capture (redacted)
+}
+
 // CollectProcesses gathers process information
-func CollectProcesses() (*types.ProcessData, error) {
+func CollectProcesses(opts ProcessOptions) (*types.ProcessData, error) {
 	processes, err := process.Processes()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get processes: %w", err)
@@ -22,8 +31,16 @@ func CollectProcesses() (*types.ProcessData, error) {
 	}
 
 	processInfos := make([]types.ProcessInfo, 0)
+	handles := make(map[int32]*process.Process, len(processes))
 	running := 0
 	sleeping := 0
+	hasIO := false
+	hasFDs := false
+	// Zombie count per parent PID
+	zombies := make(map[int32]int)
+
+	// Per-process GPU engine usage is gathered in one query rather than per process
+	gpuUsage := collectProcessGPUPlatform()
 
 	for _, proc := range processes {
 		name, _ := proc.Name()
@@ -41,6 +58,10 @@ func CollectProcesses() (*types.ProcessData, error) {
 				running++
 			case "S":
 				sleeping++
+			case process.Zombie:
+				if ppid, err := proc.Ppid(); err == nil {
+					zombies[ppid]++
+				}
 			}
 		}
 
@@ -58,37 +79,209 @@ func CollectProcesses() (*types.ProcessData, error) {
 			MemoryMB:      memMB,
 			Status:        status[0],
 			CreateTime:    createTime,
+			Container:     collectProcessContainerPlatform(proc.Pid),
+			GPUPercent:    gpuUsage[proc.Pid],
+		}
+
+		if io, err := proc.IOCounters(); err == nil && io != nil {
+			pInfo.DiskReadBytes = io.ReadBytes
+			pInfo.DiskWriteBytes = io.WriteBytes
+			hasIO = true
+		}
+		if fds, err := proc.NumFDs(); err == nil {
+			pInfo.OpenFiles = fds
+			hasFDs = true
 		}
 
 		processInfos = append(processInfos, pInfo)
+		handles[proc.Pid] = proc
 	}
 
 	data.Running = running
 	data.Sleeping = sleeping
+	data.Containers = aggregateContainers(processInfos)
+	data.ZombieParents = zombieParents(zombies, processInfos)
+	for _, parent := range data.ZombieParents {
+		data.Zombies += parent.Zombies
+	}
+	data.FileHandles = collectFileHandlesPlatform()
 
-	// Get top 10 by memory
-	sortedByMem := make([]types.ProcessInfo, len(processInfos))
-	copy(sortedByMem, processInfos)
-	sort.Slice(sortedByMem, func(i, j int) bool {
-		return sortedByMem[i].MemoryMB > sortedByMem[j].MemoryMB
+	data.TopByMemory = topProcesses(processInfos, func(a, b types.ProcessInfo) bool {
+		return a.MemoryMB > b.MemoryMB
+	})
+	data.TopByCPU = topProcesses(processInfos, func(a, b types.ProcessInfo) bool {
+		return a.CPUPercent > b.CPUPercent
 	})
-	if len(sortedByMem) > 10 {
-		data.TopByMemory = sortedByMem[:10]
-	} else {
-		data.TopByMemory = sortedByMem
+
+	// I/O and GPU lists are only reported when the platform exposes the counters
+	if hasIO {
+		data.TopByDiskIO = topProcesses(processInfos, func(a, b types.ProcessInfo) bool {
+			return a.DiskReadBytes+a.DiskWriteBytes > b.DiskReadBytes+b.DiskWriteBytes
+		})
+	}
+	if len(gpuUsage) > 0 {
+		data.TopByGPU = topProcesses(processInfos, func(a, b types.ProcessInfo) bool {
+			return a.GPUPercent > b.GPUPercent
+		})
+	}
+	if hasFDs {
+		data.TopByOpenFiles = topProcesses(processInfos, func(a, b types.ProcessInfo) bool {
+			return a.OpenFiles > b.OpenFiles
+		})
 	}
 
-	// Get top 10 by CPU
-	sortedByCPU := make([]types.ProcessInfo, len(processInfos))
-	copy(sortedByCPU, processInfos)
-	sort.Slice(sortedByCPU, func(i, j int) bool {
-		return sortedByCPU[i].CPUPercent > sortedByCPU[j].CPUPercent
-	})
-	if len(sortedByCPU) > 10 {
-		data.TopByCPU = sortedByCPU[:10]
-	} else {
-		data.TopByCPU = sortedByCPU
+	// Executable paths, command lines, environments, services and limits are only read for the
+	// processes that are reported
+	details := make(map[int32]processDetails)
+	for _, top := range [][]types.ProcessInfo{data.TopByMemory, data.TopByCPU, data.TopByDiskIO, data.TopByGPU, data.TopByOpenFiles} {
+		for i := range top {
+			pid := top[i].PID
+			d, ok := details[pid]
+			if !ok {
+				d = captureProcessDetails(handles[pid], opts)
+				details[pid] = d
+			}
+			top[i].Name = fullProcessName(top[i].Name, d.exe)
+			top[i].Exe = d.exe
+			top[i].Cmdline = d.cmdline
+			top[i].Env = d.env
+			top[i].Service = d.service
+			top[i].OpenFilesLimit = d.openFilesLimit
+		}
 	}
 
 	return data, nil
 }
+
+// topProcesses returns the first 10 processes ordered by less, without reordering processes
+func topProcesses(processes []types.ProcessInfo, less func(a, b types.ProcessInfo) bool) []types.ProcessInfo {
+	sorted := make([]types.ProcessInfo, len(processes))
+	copy(sorted, processes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	if len(sorted) > 10 {
+		return sorted[:10]
+	}
+	return sorted
+}
+
+// zombieParents lists the processes with unreaped children, most zombies first, then by PID
+// Returns nil when there are no zombies
+func zombieParents(zombies map[int32]int, processes []types.ProcessInfo) []types.ZombieParent {
+	if len(zombies) == 0 {
+		return nil
+	}
+	names := make(map[int32]string, len(processes))
+	for _, proc := range processes {
+		names[proc.PID] = proc.Name
+	}
+
+	parents := make([]types.ZombieParent, 0, len(zombies))
+	for pid, count := range zombies {
+		parents = append(parents, types.ZombieParent{PID: pid, Name: names[pid], Zombies: count})
+	}
+	sort.Slice(parents, func(i, j int) bool {
+		if parents[i].Zombies != parents[j].Zombies {
+			return parents[i].Zombies > parents[j].Zombies
+		}
+		return parents[i].PID < parents[j].PID
+	})
+	return parents
+}
+
+// aggregateContainers sums resource usage per container, busiest by CPU first
+// Returns nil when no process runs in a container
+func aggregateContainers(processes []types.ProcessInfo) []types.ContainerUsage {
+	index := make(map[string]int)
+	var usage []types.ContainerUsage
+
+	for _, proc := range processes {
+		if proc.Container == nil {
+			continue
+		}
+		i, ok := index[proc.Container.ID]
+		if !ok {
+			i = len(usage)
+			index[proc.Container.ID] = i
+			usage = append(usage, types.ContainerUsage{ContainerRef: *proc.Container})
+		}
+		usage[i].Processes++
+		usage[i].CPUPercent += proc.CPUPercent
+		usage[i].MemoryPercent += proc.MemoryPercent
+		usage[i].MemoryMB += proc.MemoryMB
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].CPUPercent != usage[j].CPUPercent {
+			return usage[i].CPUPercent > usage[j].CPUPercent
+		}
+		return usage[i].MemoryMB > usage[j].MemoryMB
+	})
+	return usage
+}
+
+// maxCmdlineLength caps captured command lines, which can embed whole scripts
+const maxCmdlineLength = 1024
+
+// processDetails holds the details captured for one reported process
+type processDetails struct {
+	exe            string
+	cmdline        string
+	env            map[string]string
+	service        string
+	openFilesLimit uint64
+}
+
+// captureProcessDetails reads a process's executable path and, when requested, its command
+// line and allowlisted environment. All are usually only readable for the current user's
+// processes unless running elevated
+func captureProcessDetails(proc *process.Process, opts ProcessOptions) processDetails {
+	var d processDetails
+	if proc == nil {
+		return d
+	}
+
+	if exe, err := proc.Exe(); err == nil {
+		d.exe = exe
+	}
+	d.service = collectProcessServicePlatform(proc.Pid)
+
+	// Only read on Linux, from /proc/<pid>/limits
+	if limits, err := proc.Rlimit(); err == nil {
+		for _, limit := range limits {
+			if limit.Resource == process.RLIMIT_NOFILE {
+				d.openFilesLimit = limit.Soft
+			}
+		}
+	}
+
+	if opts.Cmdline {
+		if args, err := proc.CmdlineSlice(); err == nil && len(args) > 0 {
+			d.cmdline = utils.Truncate(redactCmdline(args), maxCmdlineLength)
+		}
+	}
+
+	if len(opts.Env) > 0 {
+		if environ, err := proc.Environ(); err == nil {
+			d.env = filterEnv(environ, opts.Env)
+		}
+	}
+
+	return d
+}
+
+// fullProcessName restores a name the kernel cut short, 15 characters on Linux and 16 on macOS,
+// from the executable's file name when that starts with it. Names shorter than the limit and
+// processes renamed by their own code are left alone
+func fullProcessName(name, exe string) string {
+	if len(name) < 15 || exe == "" {
+		return name
+	}
+	// Linux marks executables replaced on disk, e.g. after a package upgrade
+	base := filepath.Base(strings.TrimSuffix(exe, " (deleted)"))
+	if len(base) > len(name) && strings.HasPrefix(base, name) {
+		return base
+	}
+	return name
+}
@@ -0,0 +1,38 @@
+//go:build darwin
+// +build darwin
+
+package collector
+
+import (
+	"github.com/mayvqt/sysinfo/internal/types"
+	"golang.org/x/sys/unix"
+)
+
+// collectProcessContainerPlatform returns nil; container attribution uses Linux cgroups
+func collectProcessContainerPlatform(pid int32) *types.ContainerRef {
+	return nil
+}
+
+// collectProcessGPUPlatform returns nil; per-process GPU usage is only exposed on Windows
+func collectProcessGPUPlatform() map[int32]float64 {
+	return nil
+}
+
+// collectProcessServicePlatform returns ""; services are found from Linux cgroups
+func collectProcessServicePlatform(pid int32) string {
+	return ""
+}
+
+// collectFileHandlesPlatform reads the open files and their limit from the kern.num_files and
+// kern.maxfiles sysctls
+func collectFileHandlesPlatform() *types.FileHandleUsage {
+	open, err := unix.SysctlUint32("kern.num_files")
+	if err != nil {
+		return nil
+	}
+	limit, err := unix.SysctlUint32("kern.maxfiles")
+	if err != nil || limit == 0 {
+		return nil
+	}
+	return &types.FileHandleUsage{Open: uint64(open), Max: uint64(limit)}
+}
@@ -0,0 +1,146 @@
+//go:build linux
+// +build linux
+
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+var (
+	// containerIDPattern matches a 64-character container ID, optionally prefixed by the runtime's scope name
+	containerIDPattern = regexp.MustCompile(`(?:(docker|cri-containerd|crio|libpod)-)?([0-9a-f]{64})(?:\.scope)?$`)
+	// podUIDPattern matches the pod segment under kubepods in both cgroupfs and systemd layouts
+	podUIDPattern = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+	// lxcPattern matches LXC containers, which are named rather than hashed
+	lxcPattern = regexp.MustCompile(`/lxc(?:\.payload)?[./]([^/]+)`)
+)
+
+// collectProcessContainerPlatform reads /proc/<pid>/cgroup to find the process's container
+func collectProcessContainerPlatform(pid int32) *types.ContainerRef {
+	content, err := os.ReadFile(hostPath(fmt.Sprintf("/proc/%d/cgroup", pid)))
+	if err != nil {
+		return nil
+	}
+	return parseCgroupContainer(string(content))
+}
+
+// parseCgroupContainer extracts the container from /proc/<pid>/cgroup content
+// Handles cgroup v1 and v2, and both the cgroupfs and systemd cgroup drivers
+func parseCgroupContainer(content string) *types.ContainerRef {
+	for _, line := range strings.Split(content, "\n") {
+		// Lines are "hierarchy-ID:controllers:path"
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if ref := parseCgroupPath(parts[2]); ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// parseCgroupPath identifies the container runtime and pod from a single cgroup path
+func parseCgroupPath(path string) *types.ContainerRef {
+	if match := lxcPattern.FindStringSubmatch(path); match != nil {
+		return &types.ContainerRef{ID: match[1], Runtime: "lxc"}
+	}
+
+	match := containerIDPattern.FindStringSubmatch(path)
+	if match == nil {
+		return nil
+	}
+
+	ref := &types.ContainerRef{ID: match[2]}
+	if pod := podUIDPattern.FindStringSubmatch(path); pod != nil {
+		// The systemd driver replaces the UID's dashes with underscores
+		ref.PodUID = strings.ReplaceAll(pod[1], "_", "-")
+	}
+
+	switch {
+	case match[1] == "cri-containerd":
+		ref.Runtime = "containerd"
+	case match[1] == "crio" || strings.Contains(path, "/crio"):
+		ref.Runtime = "crio"
+	case match[1] == "libpod" || strings.Contains(path, "/libpod"):
+		ref.Runtime = "podman"
+	case match[1] == "docker" || strings.Contains(path, "/docker"):
+		ref.Runtime = "docker"
+	case ref.PodUID != "":
+		// cgroupfs kubelet paths carry no runtime prefix; containerd is the common default
+		ref.Runtime = "containerd"
+	default:
+		return nil
+	}
+	return ref
+}
+
+// collectProcessGPUPlatform returns nil; per-process GPU usage is only exposed on Windows
+func collectProcessGPUPlatform() map[int32]float64 {
+	return nil
+}
+
+// collectProcessServicePlatform reads /proc/<pid>/cgroup to find the systemd service the process runs in
+func collectProcessServicePlatform(pid int32) string {
+	content, err := readString(hostfs, fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return ""
+	}
+	return parseCgroupService(content)
+}
+
+// parseCgroupService returns the system service unit from /proc/<pid>/cgroup content, e.g.
+// nginx.service for /system.slice/nginx.service. Processes in user sessions, which are restarted
+// differently, and in containers are left out
+func parseCgroupService(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) != 3 || !strings.HasPrefix(parts[2], "/system.slice/") {
+			continue
+		}
+		// Services nest under slices such as system-getty.slice; the innermost one holds the process
+		for dir := parts[2]; dir != "/"; dir = path.Dir(dir) {
+			if unit := path.Base(dir); strings.HasSuffix(unit, ".service") {
+				return unit
+			}
+		}
+	}
+	return ""
+}
+
+// collectFileHandlesPlatform reads /proc/sys/fs/file-nr for the open file handles and their limit
+func collectFileHandlesPlatform() *types.FileHandleUsage {
+	content, err := readString(hostfs, "/proc/sys/fs/file-nr")
+	if err != nil {
+		return nil
+	}
+	return parseFileNr(content)
+}
+
+// parseFileNr parses "allocated unused max"; since Linux 2.6 unused is always 0
+func parseFileNr(content string) *types.FileHandleUsage {
+	fields := strings.Fields(content)
+	if len(fields) != 3 {
+		return nil
+	}
+	var values [3]uint64
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil
+		}
+		values[i] = value
+	}
+	if values[1] > values[0] || values[2] == 0 {
+		return nil
+	}
+	return &types.FileHandleUsage{Open: values[0] - values[1], Max: values[2]}
+}
@@ -0,0 +1,132 @@
+//go:build linux
+// +build linux
+
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseCgroupContainer(t *testing.T) {
+	const id = "3f4e1c0d2b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+
+	tests := []struct {
+		name    string
+		content string
+		id      string
+		runtime string
+		podUID  string
+	}{
+		{
+			name:    "host_process",
+			content: "0::/user.slice/user-1000.slice/session-2.scope\n",
+		},
+		{
+			name:    "docker_cgroupfs_v1",
+			content: "12:memory:/docker/" + id + "\n11:cpu,cpuacct:/docker/" + id + "\n",
+			id:      id,
+			runtime: "docker",
+		},
+		{
+			name:    "docker_systemd_v2",
+			content: "0::/system.slice/docker-" + id + ".scope\n",
+			id:      id,
+			runtime: "docker",
+		},
+		{
+			name:    "kubelet_systemd_containerd",
+			content: "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1a2b3c4d_5e6f_7a8b_9c0d_1e2f3a4b5c6d.slice/cri-containerd-" + id + ".scope\n",
+			id:      id,
+			runtime: "containerd",
+			podUID:  "1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d",
+		},
+		{
+			name:    "kubelet_cgroupfs",
+			content: "4:pids:/kubepods/besteffort/pod1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d/" + id + "\n",
+			id:      id,
+			runtime: "containerd",
+			podUID:  "1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d",
+		},
+		{
+			name:    "crio",
+			content: "0::/kubepods.slice/kubepods-pod1a2b3c4d_5e6f_7a8b_9c0d_1e2f3a4b5c6d.slice/crio-" + id + ".scope\n",
+			id:      id,
+			runtime: "crio",
+			podUID:  "1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d",
+		},
+		{
+			name:    "podman",
+			content: "0::/machine.slice/libpod-" + id + ".scope/container\n0::/machine.slice/libpod-" + id + ".scope\n",
+			id:      id,
+			runtime: "podman",
+		},
+		{
+			name:    "lxc",
+			content: "0::/lxc.payload.web01/init.scope\n",
+			id:      "web01",
+			runtime: "lxc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := parseCgroupContainer(tt.content)
+			if tt.id == "" {
+				if ref != nil {
+					t.Errorf("parseCgroupContainer = %+v, expected nil", ref)
+				}
+				return
+			}
+			if ref == nil {
+				t.Fatal("parseCgroupContainer returned nil")
+			}
+			if ref.ID != tt.id {
+				t.Errorf("ID = %s, expected %s", ref.ID, tt.id)
+			}
+			if ref.Runtime != tt.runtime {
+				t.Errorf("Runtime = %s, expected %s", ref.Runtime, tt.runtime)
+			}
+			if ref.PodUID != tt.podUID {
+				t.Errorf("PodUID = %s, expected %s", ref.PodUID, tt.podUID)
+			}
+		})
+	}
+}
+
+func TestParseCgroupService(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"service_v2", "0::/system.slice/nginx.service\n", "nginx.service"},
+		{"nested_slice", "0::/system.slice/system-getty.slice/getty@tty1.service\n", "getty@tty1.service"},
+		{"v1_named_hierarchy", "12:memory:/system.slice/postgresql.service\n1:name=systemd:/system.slice/postgresql.service\n", "postgresql.service"},
+		{"user_session", "0::/user.slice/user-1000.slice/user@1000.service/app.slice/dbus.service\n", ""},
+		{"container_scope", "0::/system.slice/docker-3f4e1c0d.scope\n", ""},
+		{"init_scope", "0::/init.scope\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCgroupService(tt.content); got != tt.want {
+				t.Errorf("parseCgroupService() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileNr(t *testing.T) {
+	got := parseFileNr("10784\t0\t9223372036854775807\n")
+	if got == nil || got.Open != 10784 || got.Max != 9223372036854775807 {
+		t.Errorf("parseFileNr() = %+v, expected 10784 open", got)
+	}
+	// Older kernels count freed handles as unused
+	if got := parseFileNr("5000 1000 100000"); got == nil || got.Open != 4000 {
+		t.Errorf("parseFileNr() = %+v, expected 4000 open", got)
+	}
+	for _, content := range []string{"", "1 2", "a 0 100", "10 0 0"} {
+		if got := parseFileNr(content); got != nil {
+			t.Errorf("parseFileNr(%q) = %+v, expected nil", content, got)
+		}
+	}
+}
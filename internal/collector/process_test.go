@@ -1,12 +1,15 @@
 package collector
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
 )
 
 // TestCollectProcesses verifies basic process collection works
 func TestCollectProcesses(t *testing.T) {
-	data, err := CollectProcesses()
+	data, err := CollectProcesses(ProcessOptions{})
 	if err != nil {
 		t.Fatalf("CollectProcesses failed: %v", err)
 	}
@@ -88,7 +91,7 @@ func TestCollectProcesses(t *testing.T) {
 }
 
 func TestCollectProcessesStatusCounts(t *testing.T) {
-	data, err := CollectProcesses()
+	data, err := CollectProcesses(ProcessOptions{})
 	if err != nil {
 		t.Fatalf("CollectProcesses failed: %v", err)
 	}
@@ -109,7 +112,7 @@ func TestCollectProcessesStatusCounts(t *testing.T) {
 }
 
 func TestCollectProcessesTopListSizes(t *testing.T) {
-	data, err := CollectProcesses()
+	data, err := CollectProcesses(ProcessOptions{})
 	if err != nil {
 		t.Fatalf("CollectProcesses failed: %v", err)
 	}
@@ -126,6 +129,154 @@ func TestCollectProcessesTopListSizes(t *testing.T) {
 
 func BenchmarkCollectProcesses(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_, _ = CollectProcesses()
+		_, _ = CollectProcesses(ProcessOptions{})
+	}
+}
+
+func TestRedactCmdline(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"Plain", []string{"nginx", "-g", "daemon off;"}, `nginx -g "daemon off;"`},
+		{"Flag with separate value", []string{"app", "--password", "hunter2", "--port", "80"}, "app --password *** --port 80"},
+		{"Flag with inline value", []string{"app", "--api-key=abc123"}, "app --api-key=***"},
+		{"Key value pair", []string{"env", "DB_PASSWORD=hunter2", "DB_HOST=db"}, "env DB_PASSWORD=*** DB_HOST=db"},
+		{"URL credentials", []string{"psql", "postgres://admin:hunter2@db:5432/app"}, "psql postgres://admin:***@db:5432/app"},
+		{"Executable name is never masked", []string{"/usr/bin/token-server", "--verbose"}, "/usr/bin/token-server --verbose"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactCmdline(tt.args); got != tt.want {
+				t.Errorf("redactCmdline(%q) = %q, expected %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterEnv(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"HOME=/root",
+		"AWS_SECRET_ACCESS_KEY=abc",
+		"DATABASE_URL=mysql://app:hunter2@db/app",
+	}
+
+	env := filterEnv(environ, []string{"PATH", "AWS_SECRET_ACCESS_KEY", "DATABASE_URL", "MISSING"})
+
+	expected := map[string]string{
+		"PATH":                  "/usr/bin",
+		"AWS_SECRET_ACCESS_KEY": "***",
+		"DATABASE_URL":          "mysql://app:***@db/app",
+	}
+	if len(env) != len(expected) {
+		t.Fatalf("filterEnv() = %v, expected %v", env, expected)
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("env[%s] = %q, expected %q", key, env[key], value)
+		}
+	}
+
+	if env := filterEnv(environ, []string{"MISSING"}); env != nil {
+		t.Errorf("filterEnv() = %v, expected nil when nothing matches", env)
+	}
+}
+
+func TestCollectProcessesCmdline(t *testing.T) {
+	data, err := CollectProcesses(ProcessOptions{Cmdline: true})
+	if err != nil {
+		t.Fatalf("CollectProcesses failed: %v", err)
+	}
+
+	// Our own processes are always readable, so at least one top process should have a command line
+	found := false
+	for _, proc := range append(data.TopByMemory, data.TopByCPU...) {
+		if proc.Cmdline != "" {
+			found = true
+		}
+		if proc.Env != nil {
+			t.Errorf("PID %d has environment captured without an allowlist", proc.PID)
+		}
+	}
+	if !found {
+		t.Log("No command lines captured (processes may belong to other users)")
+	}
+}
+
+func TestFullProcessName(t *testing.T) {
+	tests := []struct {
+		name, exe, want string
+	}{
+		{"systemd-journal", "/usr/lib/systemd/systemd-journald", "systemd-journald"},
+		{"kube-controller", "/usr/local/bin/kube-controller-manager", "kube-controller-manager"},
+		{"VBoxHeadlessSer", "/opt/VirtualBox/VBoxHeadlessService (deleted)", "VBoxHeadlessService"},
+		{"python3", "/usr/bin/python3.12", "python3"},                                 // Not cut short
+		{"postgres: check", "/usr/lib/postgresql/16/bin/postgres", "postgres: check"}, // Renamed itself
+		{"gnome-shell-cal", "", "gnome-shell-cal"},
+	}
+	for _, tt := range tests {
+		if got := fullProcessName(tt.name, tt.exe); got != tt.want {
+			t.Errorf("fullProcessName(%q, %q) = %q, expected %q", tt.name, tt.exe, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateContainers(t *testing.T) {
+	web := &types.ContainerRef{ID: "aaa", Runtime: "containerd", PodUID: "pod-1"}
+	db := &types.ContainerRef{ID: "bbb", Runtime: "docker"}
+	processes := []types.ProcessInfo{
+		{PID: 1, Name: "systemd", CPUPercent: 50, MemoryMB: 10},
+		{PID: 2, Name: "nginx", CPUPercent: 5, MemoryMB: 100, MemoryPercent: 1, Container: web},
+		{PID: 3, Name: "nginx", CPUPercent: 10, MemoryMB: 50, MemoryPercent: 0.5, Container: web},
+		{PID: 4, Name: "postgres", CPUPercent: 20, MemoryMB: 400, MemoryPercent: 4, Container: db},
+	}
+
+	usage := aggregateContainers(processes)
+	if len(usage) != 2 {
+		t.Fatalf("aggregateContainers returned %d containers, expected 2", len(usage))
+	}
+
+	if usage[0].ID != "bbb" {
+		t.Errorf("usage[0].ID = %s, expected bbb (highest CPU first)", usage[0].ID)
+	}
+	if usage[1].Processes != 2 {
+		t.Errorf("usage[1].Processes = %d, expected 2", usage[1].Processes)
+	}
+	if usage[1].CPUPercent != 15 {
+		t.Errorf("usage[1].CPUPercent = %v, expected 15", usage[1].CPUPercent)
+	}
+	if usage[1].MemoryMB != 150 {
+		t.Errorf("usage[1].MemoryMB = %d, expected 150", usage[1].MemoryMB)
+	}
+	if usage[1].PodUID != "pod-1" {
+		t.Errorf("usage[1].PodUID = %s, expected pod-1", usage[1].PodUID)
+	}
+
+	if got := aggregateContainers(processes[:1]); got != nil {
+		t.Errorf("aggregateContainers without containers = %v, expected nil", got)
+	}
+}
+
+func TestZombieParents(t *testing.T) {
+	processes := []types.ProcessInfo{
+		{PID: 1, Name: "systemd"},
+		{PID: 2301, Name: "php-fpm"},
+		{PID: 900, Name: "bash"},
+	}
+	parents := zombieParents(map[int32]int{900: 2, 2301: 12, 4000: 2}, processes)
+	want := []types.ZombieParent{
+		{PID: 2301, Name: "php-fpm", Zombies: 12},
+		{PID: 900, Name: "bash", Zombies: 2},
+		{PID: 4000, Zombies: 2},
+	}
+	if !reflect.DeepEqual(parents, want) {
+		t.Errorf("zombieParents() = %+v, expected %+v", parents, want)
+	}
+
+	if got := zombieParents(map[int32]int{}, processes); got != nil {
+		t.Errorf("zombieParents without zombies = %v, expected nil", got)
 	}
 }
@@ -0,0 +1,89 @@
+//go:build windows
+// +build windows
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine represents one GPU engine counter instance
+type Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine struct {
+	Name                  string
+	UtilizationPercentage uint64
+}
+
+// collectProcessContainerPlatform returns nil; container attribution uses Linux cgroups
+func collectProcessContainerPlatform(pid int32) *types.ContainerRef {
+	return nil
+}
+
+// collectProcessServicePlatform returns ""; services are found from Linux cgroups
+func collectProcessServicePlatform(pid int32) string {
+	return ""
+}
+
+// collectFileHandlesPlatform returns nil; Windows has no system-wide handle limit to report
+func collectFileHandlesPlatform() *types.FileHandleUsage {
+	return nil
+}
+
+// collectProcessGPUPlatform reads per-process GPU engine utilization from performance counters
+// Requires Windows 10 1709 or later; returns nil when the counters are unavailable
+func collectProcessGPUPlatform() map[int32]float64 {
+	var engines []Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine
+	query := "SELECT Name, UtilizationPercentage FROM Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine"
+	if err := wmi.Query(query, &engines); err != nil {
+		return nil
+	}
+	return aggregateGPUEngines(engines)
+}
+
+// aggregateGPUEngines sums each process's utilization per engine type and reports the busiest type,
+// matching the GPU column in Task Manager
+func aggregateGPUEngines(engines []Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine) map[int32]float64 {
+	perType := make(map[int32]map[string]float64)
+	for _, engine := range engines {
+		pid, engineType, ok := parseGPUEngineName(engine.Name)
+		if !ok || engine.UtilizationPercentage == 0 {
+			continue
+		}
+		if perType[pid] == nil {
+			perType[pid] = make(map[string]float64)
+		}
+		perType[pid][engineType] += float64(engine.UtilizationPercentage)
+	}
+
+	usage := make(map[int32]float64, len(perType))
+	for pid, engineTypes := range perType {
+		for _, percent := range engineTypes {
+			if percent > usage[pid] {
+				usage[pid] = percent
+			}
+		}
+	}
+	return usage
+}
+
+// parseGPUEngineName parses instance names like "pid_1234_luid_0x0_0x1_phys_0_eng_0_engtype_3D"
+func parseGPUEngineName(name string) (int32, string, bool) {
+	rest, ok := strings.CutPrefix(name, "pid_")
+	if !ok {
+		return 0, "", false
+	}
+	pidStr, _, _ := strings.Cut(rest, "_")
+	pid, err := strconv.ParseInt(pidStr, 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+
+	_, engineType, ok := strings.Cut(rest, "_engtype_")
+	if !ok {
+		return 0, "", false
+	}
+	return int32(pid), engineType, true
+}
@@ -0,0 +1,48 @@
+//go:build windows
+// +build windows
+
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseGPUEngineName(t *testing.T) {
+	tests := []struct {
+		name       string
+		pid        int32
+		engineType string
+		ok         bool
+	}{
+		{"pid_1234_luid_0x00000000_0x0000D1F3_phys_0_eng_0_engtype_3D", 1234, "3D", true},
+		{"pid_88_luid_0x00000000_0x0000D1F3_phys_0_eng_3_engtype_VideoDecode", 88, "VideoDecode", true},
+		{"_Total", 0, "", false},
+		{"pid_abc_luid_0x0_engtype_3D", 0, "", false},
+		{"pid_12_luid_0x0_phys_0", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		pid, engineType, ok := parseGPUEngineName(tt.name)
+		if ok != tt.ok || pid != tt.pid || engineType != tt.engineType {
+			t.Errorf("parseGPUEngineName(%q) = (%d, %q, %v), expected (%d, %q, %v)",
+				tt.name, pid, engineType, ok, tt.pid, tt.engineType, tt.ok)
+		}
+	}
+}
+
+func TestAggregateGPUEngines(t *testing.T) {
+	engines := []Win32_PerfFormattedData_GPUPerformanceCounters_GPUEngine{
+		{Name: "pid_10_luid_0x0_0x1_phys_0_eng_0_engtype_3D", UtilizationPercentage: 20},
+		{Name: "pid_10_luid_0x0_0x1_phys_0_eng_1_engtype_3D", UtilizationPercentage: 15},
+		{Name: "pid_10_luid_0x0_0x1_phys_0_eng_2_engtype_Copy", UtilizationPercentage: 30},
+		{Name: "pid_20_luid_0x0_0x1_phys_0_eng_0_engtype_3D", UtilizationPercentage: 0},
+	}
+
+	usage := aggregateGPUEngines(engines)
+	if usage[10] != 35 {
+		t.Errorf("usage[10] = %v, expected 35 (3D engines summed)", usage[10])
+	}
+	if _, ok := usage[20]; ok {
+		t.Error("idle process should not be reported")
+	}
+}
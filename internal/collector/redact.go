@@ -0,0 +1,269 @@
+package collector
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Kinds of identifiers --redact masks, as named in their placeholders
+const (
+	redactSerial   = "serial"
+	redactHostname = "host"
+	redactUUID     = "uuid"
+	redactMAC      = "mac"
+	redactIP       = "ip"
+	redactSSID     = "ssid"
+)
+
+// redactFields are JSON fields holding an identifier as their whole value
+var redactFields = map[string]string{
+	"serial":              redactSerial,
+	"serial_number":       redactSerial,
+	"system_serial":       redactSerial,
+	"partial_product_key": redactSerial,
+	"hostname":            redactHostname,
+	"remote_host":         redactHostname, // Where a user logged in from
+	"uuid":                redactUUID,
+	"hardware_addr":       redactMAC,
+	"ssid":                redactSSID, // Wi-Fi network names can locate the host
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	macPattern  = regexp.MustCompile(`(?i)\b[0-9a-f]{2}(?:[:-][0-9a-f]{2}){5}\b`)
+	// Runs of characters an IP address is written with; net.ParseIP decides which are one,
+	// so versions such as 10.0.19045.1 and times such as 12:30:45 are left alone
+	ipCandidatePattern = regexp.MustCompile(`[0-9A-Fa-f:.]+`)
+	// Windows PnP device IDs: enumerator, device and instance, e.g. USB\VID_0781&PID_5581\4C5300
+	pnpIDPattern = regexp.MustCompile(`^([A-Z][A-Z0-9_]*\\[^\\]+)\\([^\\]+)$`)
+	// Instance IDs Windows makes up for devices without a serial, e.g. 5&2A7C1B0&0&2
+	pnpGeneratedPattern = regexp.MustCompile(`^[0-9A-Fa-f]+(&[0-9A-Fa-f]+){2,}$`)
+)
+
+// pnpSerial splits a Windows PnP device ID whose instance ID is the device's serial number
+// into the ID without it and the serial
+func pnpSerial(text string) (device, serial string, ok bool) {
+	match := pnpIDPattern.FindStringSubmatch(text)
+	if match == nil || pnpGeneratedPattern.MatchString(match[2]) {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// Redact masks identifiers in the report, in place, so it can be attached to a public bug
+// report: serial numbers and product keys, MAC addresses, IP addresses, the hostname,
+// Wi-Fi network names and UUIDs. Each distinct value becomes a numbered placeholder such as <serial-1>, the same
+// wherever it appears, so a drive can still be followed through the report. Loopback and
+// unspecified addresses are kept, as they identify nothing
+func Redact(info *types.SystemInfo) {
+	hostname := ""
+	if info.System != nil {
+		hostname = info.System.Hostname
+	}
+	NewRedactor(hostname).Redact(info)
+}
+
+// Redactor masks identifiers as Redact does, keeping its placeholders between calls so the
+// samples of an event stream number each value the same way
+type Redactor struct {
+	placeholders map[string]string // kind/value to its placeholder
+	counts       map[string]int
+	hostnames    *strings.Replacer
+}
+
+// NewRedactor returns a Redactor that also masks hostname, and its short name, within text
+// such as an FQDN
+func NewRedactor(hostname string) *Redactor {
+	r := &Redactor{placeholders: map[string]string{}, counts: map[string]int{}}
+	if len(hostname) >= 3 {
+		p := r.placeholder(redactHostname, hostname)
+		replacements := []string{hostname, p}
+		if short, _, found := strings.Cut(hostname, "."); found && len(short) >= 3 {
+			replacements = append(replacements, short, p)
+		}
+		r.hostnames = strings.NewReplacer(replacements...)
+	}
+	return r
+}
+
+// Redact masks identifiers in a report or one module's data, in place
+func (r *Redactor) Redact(data any) {
+	r.redactValue(reflect.ValueOf(data), "")
+	if info, ok := data.(*types.SystemInfo); ok {
+		info.Redacted = true
+	}
+}
+
+// placeholder returns the placeholder standing for value, numbering values of each kind
+// in the order they are first seen
+func (r *Redactor) placeholder(kind, value string) string {
+	key := kind + "/" + value
+	if kind == redactMAC || kind == redactUUID {
+		key = strings.ToLower(key)
+	}
+	if p, ok := r.placeholders[key]; ok {
+		return p
+	}
+	r.counts[kind]++
+	p := fmt.Sprintf("<%s-%d>", kind, r.counts[kind])
+	r.placeholders[key] = p
+	return p
+}
+
+func (r *Redactor) redactValue(v reflect.Value, kind string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			r.redactValue(v.Elem(), kind)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.redactValue(v.Index(i), kind)
+		}
+	case reflect.Map:
+		// Sorted, so placeholders are numbered the same in every run
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+		for _, key := range keys {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			r.redactValue(value, kind)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, embedded, ok := jsonField(t.Field(i))
+			field := v.Field(i)
+			if !ok || !field.CanSet() {
+				continue
+			}
+			if embedded {
+				r.redactValue(field, "")
+			} else {
+				r.redactValue(field, redactFields[name])
+			}
+		}
+	case reflect.String:
+		if v.CanSet() && v.String() != "" {
+			if kind != "" {
+				v.SetString(r.placeholder(kind, v.String()))
+			} else {
+				v.SetString(r.scrub(v.String()))
+			}
+		}
+	}
+}
+
+// StripSerials clears serial numbers, product keys and UUIDs, the fields Redact masks as
+// <serial-N> and <uuid-N>, from a report or one module's data, in place. Used where a client
+// may see the hardware but not identify it, so the fields are emptied rather than numbered
+func StripSerials(data any) {
+	stripSerialValue(reflect.ValueOf(data), false)
+}
+
+func stripSerialValue(v reflect.Value, strip bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			stripSerialValue(v.Elem(), strip)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			stripSerialValue(v.Index(i), strip)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			stripSerialValue(value, strip)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, embedded, ok := jsonField(t.Field(i))
+			field := v.Field(i)
+			if !ok || !field.CanSet() {
+				continue
+			}
+			kind := redactFields[name]
+			stripSerialValue(field, !embedded && (kind == redactSerial || kind == redactUUID))
+		}
+	case reflect.String:
+		if !v.CanSet() {
+			return
+		}
+		if strip {
+			v.SetString("")
+		} else if device, _, ok := pnpSerial(v.String()); ok {
+			// A device ID such as the USB address on Windows ends in the serial
+			v.SetString(device)
+		}
+	}
+}
+
+// scrub masks identifiers found within free text, such as addresses, command lines and
+// device descriptions
+func (r *Redactor) scrub(text string) string {
+	if device, serial, ok := pnpSerial(text); ok {
+		text = device + `\` + r.placeholder(redactSerial, serial)
+	}
+	text = uuidPattern.ReplaceAllStringFunc(text, func(uuid string) string {
+		return r.placeholder(redactUUID, uuid)
+	})
+	text = macPattern.ReplaceAllStringFunc(text, func(mac string) string {
+		if strings.Trim(mac, "0:-") == "" {
+			return mac
+		}
+		return r.placeholder(redactMAC, mac)
+	})
+	text = r.scrubIPs(text)
+	if r.hostnames != nil {
+		text = r.hostnames.Replace(text)
+	}
+	return text
+}
+
+// scrubIPs masks IPv4 and IPv6 addresses standing on their own in text, not part of a
+// longer word such as std::vector
+func (r *Redactor) scrubIPs(text string) string {
+	matches := ipCandidatePattern.FindAllStringIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if (start > 0 && isWordByte(text[start-1])) || (end < len(text) && isWordByte(text[end])) {
+			continue
+		}
+		// Punctuation around an address, as in "addr: 10.0.0.5." or "[fe80::1]:"
+		run := strings.TrimRight(text[start:end], ".:")
+		candidate := run
+		if net.ParseIP(candidate) == nil {
+			candidate = strings.TrimLeft(run, ":")
+			start += len(run) - len(candidate)
+		}
+		ip := net.ParseIP(candidate)
+		if ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(r.placeholder(redactIP, ip.String()))
+		last = start + len(candidate)
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
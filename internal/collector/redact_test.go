@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestRedact(t *testing.T) {
+	info := &types.SystemInfo{
+		System: &types.SystemData{Hostname: "db-1.example.com", OS: "linux", KernelVersion: "6.8.0-45-generic"},
+		Network: &types.NetworkData{Interfaces: []types.NetworkInterface{
+			{Name: "eth0", HardwareAddr: "52:54:00:12:34:56", Addresses: []string{"192.168.1.20/24", "fe80::5054:ff:fe12:3456/64"}},
+			{Name: "lo", Addresses: []string{"127.0.0.1/8", "::1/128"}},
+			{Name: "wlan0", WiFi: &types.WiFiLink{Connected: true, SSID: "Smith Family", BSSID: "3c:37:86:aa:bb:cc", Channel: 36}},
+		}},
+		Disk: &types.DiskData{
+			PhysicalDisks: []types.PhysicalDisk{{Name: "/dev/sda", SerialNumber: "S3Z9NB0K123456"}},
+			SMARTData:     []types.SMARTInfo{{Device: "/dev/sda", Serial: "S3Z9NB0K123456", FirmwareVersion: "2B6Q"}},
+		},
+		GPU: &types.GPUData{GPUs: []types.GPUInfo{{Name: "Tesla T4", UUID: "GPU-8a1b2c3d-1111-2222-3333-444455556666"}}},
+		Processes: &types.ProcessData{TopByCPU: []types.ProcessInfo{
+			{Name: "psql", Cmdline: "psql -h 10.0.0.5 --host db-1 std::vector 10.0.19045.1 12:30:45"},
+		}},
+		USB: &types.USBData{Devices: []types.USBDevice{
+			{Address: `USB\VID_0781&PID_5581\4C530001071205117433`, Serial: "4C530001071205117433"},
+		}},
+		Users: &types.UserData{Sessions: []types.UserSession{
+			{User: "alice", Terminal: "pts/0", RemoteHost: "laptop-alice.corp"},
+			{User: "bob", Terminal: "pts/1", RemoteHost: "laptop-alice.corp"},
+		}},
+	}
+
+	Redact(info)
+
+	if !info.Redacted || info.System.Hostname != "<host-1>" || info.System.KernelVersion != "6.8.0-45-generic" {
+		t.Errorf("system = %+v, redacted %v; expected only the hostname masked", info.System, info.Redacted)
+	}
+	eth0 := info.Network.Interfaces[0]
+	if eth0.HardwareAddr != "<mac-1>" || !reflect.DeepEqual(eth0.Addresses, []string{"<ip-1>/24", "<ip-2>/64"}) {
+		t.Errorf("eth0 = %s %v, expected its MAC and addresses masked", eth0.HardwareAddr, eth0.Addresses)
+	}
+	if lo := info.Network.Interfaces[1]; !reflect.DeepEqual(lo.Addresses, []string{"127.0.0.1/8", "::1/128"}) {
+		t.Errorf("lo addresses = %v, expected loopback kept", lo.Addresses)
+	}
+	if wifi := info.Network.Interfaces[2].WiFi; wifi.SSID != "<ssid-1>" || wifi.BSSID != "<mac-2>" || wifi.Channel != 36 {
+		t.Errorf("wlan0 Wi-Fi = %+v, expected the SSID and BSSID masked", wifi)
+	}
+
+	// The same serial gets the same placeholder, so the drive can be followed
+	smart := info.Disk.SMARTData[0]
+	if serial := info.Disk.PhysicalDisks[0].SerialNumber; serial != "<serial-1>" || smart.Serial != "<serial-1>" || smart.FirmwareVersion != "2B6Q" {
+		t.Errorf("serials = %q and %q, firmware %q; expected both <serial-1>, firmware kept", serial, smart.Serial, smart.FirmwareVersion)
+	}
+	if uuid := info.GPU.GPUs[0].UUID; uuid != "<uuid-1>" {
+		t.Errorf("GPU UUID = %q, expected <uuid-1>", uuid)
+	}
+
+	// A Windows device ID carrying the serial masks it with the serial's placeholder
+	if usb := info.USB.Devices[0]; usb.Address != `USB\VID_0781&PID_5581\<serial-2>` || usb.Serial != "<serial-2>" {
+		t.Errorf("USB device = %+v, expected the serial masked in its address", usb)
+	}
+
+	cmdline := info.Processes.TopByCPU[0].Cmdline
+	if expected := "psql -h <ip-3> --host <host-1> std::vector 10.0.19045.1 12:30:45"; cmdline != expected {
+		t.Errorf("cmdline = %q, expected %q", cmdline, expected)
+	}
+
+	if sessions := info.Users.Sessions; sessions[0].RemoteHost != "<host-2>" || sessions[1].RemoteHost != "<host-2>" || sessions[0].User != "alice" {
+		t.Errorf("sessions = %+v, expected the remote host masked", sessions)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	for _, leaked := range []string{"db-1", "192.168.1.20", "52:54:00", "S3Z9NB0K", "8a1b2c3d", "10.0.0.5", "laptop-alice", "4C5300"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("redacted JSON still contains %q", leaked)
+		}
+	}
+}
+
+func TestStripSerials(t *testing.T) {
+	disk := &types.DiskData{
+		PhysicalDisks: []types.PhysicalDisk{{
+			Name:         "/dev/sda",
+			SerialNumber: "S3Z9NB0K123456",
+			Slot:         &types.EnclosureSlot{Device: "/dev/sda", Serial: "S3Z9NB0K123456", Enclosure: "0:0:8:0", Slot: 3},
+		}},
+	}
+	StripSerials(disk)
+	if d := disk.PhysicalDisks[0]; d.SerialNumber != "" || d.Slot.Serial != "" || d.Name != "/dev/sda" || d.Slot.Enclosure != "0:0:8:0" {
+		t.Errorf("disk = %+v, slot %+v; expected only the serials cleared", d, d.Slot)
+	}
+
+	// Identifiers Redact masks other than serials and UUIDs are kept
+	info := &types.SystemInfo{
+		System:  &types.SystemData{Hostname: "db-1"},
+		Network: &types.NetworkData{Interfaces: []types.NetworkInterface{{Name: "eth0", HardwareAddr: "52:54:00:12:34:56"}}},
+		GPU:     &types.GPUData{GPUs: []types.GPUInfo{{Name: "Tesla T4", UUID: "GPU-8a1b2c3d-1111-2222-3333-444455556666"}}},
+	}
+	StripSerials(info)
+	if info.GPU.GPUs[0].UUID != "" || info.System.Hostname != "db-1" || info.Network.Interfaces[0].HardwareAddr == "" {
+		t.Errorf("report = %+v, expected only the GPU UUID cleared", info)
+	}
+
+	// Windows device IDs end in the serial where the device reports one, and in an instance ID
+	// Windows makes up otherwise
+	usb := &types.USBData{Devices: []types.USBDevice{
+		{Address: `USB\VID_0781&PID_5581\4C530001071205117433`, Serial: "4C530001071205117433"},
+		{Address: `USB\VID_046D&PID_C52B\5&2A7C1B0&0&2`},
+	}}
+	StripSerials(usb)
+	if address := usb.Devices[0].Address; address != `USB\VID_0781&PID_5581` {
+		t.Errorf("Address = %s, expected the serial cut off", address)
+	}
+	if address := usb.Devices[1].Address; address != `USB\VID_046D&PID_C52B\5&2A7C1B0&0&2` {
+		t.Errorf("Address = %s, expected the generated instance ID kept", address)
+	}
+
+	// Modules that were not collected are left alone
+	StripSerials(&types.SystemInfo{})
+	StripSerials((*types.DiskData)(nil))
+}
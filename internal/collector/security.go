@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"slices"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// caExpiryWindow is how far ahead trusted CAs are reported as expiring soon
+const caExpiryWindow = 90 * 24 * time.Hour
+
+// CollectSecurity gathers OS security and compliance posture
+func CollectSecurity() (*types.SecurityData, error) {
+	data := &types.SecurityData{}
+	collectSecurityPlatform(data)
+	return data, nil
+}
+
+// trustedCA is a certificate read from a trust store, with where it was found
+type trustedCA struct {
+	cert   *x509.Certificate
+	source string
+}
+
+// parseCertificates reads the certificates of a PEM bundle, or of a single DER certificate
+// when the data holds no PEM blocks. Blocks that fail to parse are skipped
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		found = true
+		if block.Type != "CERTIFICATE" && block.Type != "TRUSTED CERTIFICATE" {
+			continue
+		}
+		// OpenSSL's TRUSTED CERTIFICATE appends trust settings after the certificate
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		} else if cert := parseLeadingCertificate(block.Bytes); cert != nil {
+			certs = append(certs, cert)
+		}
+	}
+	if !found && len(data) > 0 {
+		if cert, err := x509.ParseCertificate(data); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+// parseLeadingCertificate parses the DER certificate at the start of data, ignoring what follows
+func parseLeadingCertificate(data []byte) *x509.Certificate {
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(raw.FullBytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+// summarizeTrustStore counts the trusted CAs, the ones expired or expiring by the window
+// after now, and lists the locally added ones. A certificate in both lists is counted once
+func summarizeTrustStore(source string, roots []*x509.Certificate, local []trustedCA, now time.Time) *types.TrustStore {
+	store := &types.TrustStore{Source: source}
+	seen := make(map[string]bool)
+	count := func(ca trustedCA) *types.CACertificate {
+		entry := caCertificate(ca)
+		if seen[entry.SHA256] {
+			return nil
+		}
+		seen[entry.SHA256] = true
+		store.Total++
+		switch {
+		case now.After(ca.cert.NotAfter):
+			store.Expired++
+		case ca.cert.NotAfter.Sub(now) <= caExpiryWindow:
+			store.ExpiringSoon = append(store.ExpiringSoon, entry)
+		}
+		return &entry
+	}
+
+	for _, ca := range local {
+		if entry := count(ca); entry != nil {
+			store.Local = append(store.Local, *entry)
+		}
+	}
+	for _, cert := range roots {
+		count(trustedCA{cert: cert})
+	}
+
+	slices.SortFunc(store.ExpiringSoon, func(a, b types.CACertificate) int {
+		return a.NotAfter.Compare(b.NotAfter)
+	})
+	return store
+}
+
+// caCertificate describes a certificate by its subject, issuer and fingerprint
+func caCertificate(ca trustedCA) types.CACertificate {
+	sum := sha256.Sum256(ca.cert.Raw)
+	entry := types.CACertificate{
+		Subject:  certificateName(ca.cert.Subject.CommonName, ca.cert.Subject.Organization, ca.cert.Subject.String()),
+		NotAfter: ca.cert.NotAfter.UTC(),
+		SHA256:   hex.EncodeToString(sum[:]),
+		Source:   ca.source,
+	}
+	if issuer := certificateName(ca.cert.Issuer.CommonName, ca.cert.Issuer.Organization, ca.cert.Issuer.String()); issuer != entry.Subject {
+		entry.Issuer = issuer
+	}
+	return entry
+}
+
+// certificateName prefers the common name, then the organization, then the full distinguished name
+func certificateName(commonName string, organization []string, dn string) string {
+	var org string
+	if len(organization) > 0 {
+		org = organization[0]
+	}
+	return cmp.Or(commonName, org, dn)
+}
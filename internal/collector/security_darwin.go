@@ -0,0 +1,150 @@
+//go:build darwin
+
+package collector
+
+import (
+	"crypto/x509"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectSecurityPlatform gathers SIP, Gatekeeper, FileVault and MDM enrollment status
+func collectSecurityPlatform(data *types.SecurityData) {
+	if out, err := sandbox.Command("csrutil", "status").Output(); err == nil {
+		data.SIP = parseSIPStatus(string(out))
+	}
+
+	// spctl exits non-zero when assessments are disabled, so read output regardless of error
+	if out, _ := sandbox.Command("spctl", "--status").CombinedOutput(); len(out) > 0 {
+		data.Gatekeeper = parseGatekeeperStatus(string(out))
+	}
+
+	if out, err := sandbox.Command("fdesetup", "status").Output(); err == nil {
+		data.FileVault = parseFileVaultStatus(string(out))
+	}
+
+	if out, err := sandbox.Command("profiles", "status", "-type", "enrollment").Output(); err == nil {
+		data.MDM = parseMDMEnrollment(string(out))
+	}
+
+	data.TrustStore = collectTrustStore(time.Now())
+}
+
+const (
+	systemRootsKeychain = "/System/Library/Keychains/SystemRootCertificates.keychain"
+	systemKeychain      = "/Library/Keychains/System.keychain"
+)
+
+// collectTrustStore summarizes the roots shipped with macOS and the CAs an administrator
+// or MDM profile added to the System keychain
+func collectTrustStore(now time.Time) *types.TrustStore {
+	out, err := sandbox.Command("security", "find-certificate", "-a", "-p", systemRootsKeychain).Output()
+	if err != nil {
+		return nil
+	}
+	roots := parseCertificates(out)
+
+	var local []trustedCA
+	if out, err := sandbox.Command("security", "find-certificate", "-a", "-p", systemKeychain).Output(); err == nil {
+		local = keychainCAs(parseCertificates(out), systemKeychain)
+	}
+	return summarizeTrustStore(systemRootsKeychain, roots, local, now)
+}
+
+// keychainCAs keeps the CA certificates of a keychain, which also holds the machine's
+// own identities and leaf certificates
+func keychainCAs(certs []*x509.Certificate, keychain string) []trustedCA {
+	var cas []trustedCA
+	for _, cert := range certs {
+		if cert.IsCA {
+			cas = append(cas, trustedCA{cert: cert, source: keychain})
+		}
+	}
+	return cas
+}
+
+// parseSIPStatus parses `csrutil status` output
+// e.g. "System Integrity Protection status: enabled."
+func parseSIPStatus(output string) string {
+	_, status, found := strings.Cut(output, "status:")
+	if !found {
+		return ""
+	}
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case strings.HasPrefix(status, "enabled") && strings.Contains(status, "custom configuration"):
+		return "custom"
+	case strings.HasPrefix(status, "enabled"):
+		return "enabled"
+	case strings.HasPrefix(status, "disabled"):
+		return "disabled"
+	default:
+		return ""
+	}
+}
+
+// parseGatekeeperStatus parses `spctl --status` output ("assessments enabled")
+func parseGatekeeperStatus(output string) string {
+	output = strings.ToLower(output)
+	switch {
+	case strings.Contains(output, "assessments enabled"):
+		return "enabled"
+	case strings.Contains(output, "assessments disabled"):
+		return "disabled"
+	default:
+		return ""
+	}
+}
+
+// parseFileVaultStatus parses `fdesetup status` output
+// e.g. "FileVault is On." or "Encryption in progress: Percent completed = 42"
+func parseFileVaultStatus(output string) string {
+	switch {
+	case strings.Contains(output, "Encryption in progress"):
+		return "encrypting"
+	case strings.Contains(output, "Decryption in progress"):
+		return "decrypting"
+	case strings.Contains(output, "FileVault is On"):
+		return "on"
+	case strings.Contains(output, "FileVault is Off"):
+		return "off"
+	default:
+		return ""
+	}
+}
+
+// parseMDMEnrollment parses `profiles status -type enrollment` output
+// e.g. "Enrolled via DEP: Yes\nMDM enrollment: Yes (User Approved)"
+func parseMDMEnrollment(output string) *types.MDMEnrollment {
+	mdm := &types.MDMEnrollment{}
+	found := false
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Enrolled via DEP":
+			mdm.DEP = strings.HasPrefix(value, "Yes")
+			found = true
+		case "MDM enrollment":
+			mdm.Enrolled = strings.HasPrefix(value, "Yes")
+			mdm.UserApproved = strings.Contains(value, "User Approved")
+			found = true
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	// DEP enrollments are implicitly approved
+	if mdm.DEP && mdm.Enrolled {
+		mdm.UserApproved = true
+	}
+	return mdm
+}
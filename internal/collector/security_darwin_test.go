@@ -0,0 +1,106 @@
+//go:build darwin
+
+package collector
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestParseSIPStatus(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"System Integrity Protection status: enabled.\n", "enabled"},
+		{"System Integrity Protection status: disabled.\n", "disabled"},
+		{"System Integrity Protection status: enabled (Custom Configuration).\n", "custom"},
+		{"garbage", ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseSIPStatus(tt.output); got != tt.expected {
+			t.Errorf("parseSIPStatus(%q) = %q, expected %q", tt.output, got, tt.expected)
+		}
+	}
+}
+
+func TestParseGatekeeperStatus(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"assessments enabled\n", "enabled"},
+		{"assessments disabled\n", "disabled"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseGatekeeperStatus(tt.output); got != tt.expected {
+			t.Errorf("parseGatekeeperStatus(%q) = %q, expected %q", tt.output, got, tt.expected)
+		}
+	}
+}
+
+func TestParseFileVaultStatus(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"FileVault is On.\n", "on"},
+		{"FileVault is Off.\n", "off"},
+		{"FileVault is On.\nEncryption in progress: Percent completed = 42\n", "encrypting"},
+		{"FileVault is On.\nDecryption in progress: Percent completed = 10\n", "decrypting"},
+	}
+
+	for _, tt := range tests {
+		if got := parseFileVaultStatus(tt.output); got != tt.expected {
+			t.Errorf("parseFileVaultStatus(%q) = %q, expected %q", tt.output, got, tt.expected)
+		}
+	}
+}
+
+func TestParseMDMEnrollment(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected *struct{ enrolled, approved, dep bool }
+	}{
+		{"not enrolled", "Enrolled via DEP: No\nMDM enrollment: No\n", &struct{ enrolled, approved, dep bool }{false, false, false}},
+		{"user approved", "Enrolled via DEP: No\nMDM enrollment: Yes (User Approved)\n", &struct{ enrolled, approved, dep bool }{true, true, false}},
+		{"dep", "Enrolled via DEP: Yes\nMDM enrollment: Yes\n", &struct{ enrolled, approved, dep bool }{true, true, true}},
+		{"unparseable", "profiles: command requires root\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mdm := parseMDMEnrollment(tt.output)
+			if tt.expected == nil {
+				if mdm != nil {
+					t.Errorf("parseMDMEnrollment() = %+v, expected nil", mdm)
+				}
+				return
+			}
+			if mdm == nil {
+				t.Fatal("parseMDMEnrollment() = nil")
+			}
+			if mdm.Enrolled != tt.expected.enrolled || mdm.UserApproved != tt.expected.approved || mdm.DEP != tt.expected.dep {
+				t.Errorf("parseMDMEnrollment() = %+v, expected %+v", mdm, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestKeychainCAs(t *testing.T) {
+	now := time.Now()
+	ca := testCertificate(t, "Corporate Root", now.AddDate(5, 0, 0))
+	leaf := testCertificate(t, "host.example.com", now.AddDate(1, 0, 0))
+	leaf.IsCA = false
+
+	cas := keychainCAs(parseCertificates(pemEncode(ca)), systemKeychain)
+	cas = append(cas, keychainCAs([]*x509.Certificate{leaf}, systemKeychain)...)
+	if len(cas) != 1 || cas[0].cert.Subject.CommonName != "Corporate Root" || cas[0].source != systemKeychain {
+		t.Errorf("keychainCAs() = %+v, expected Corporate Root from the System keychain", cas)
+	}
+}
@@ -0,0 +1,186 @@
+//go:build linux
+
+package collector
+
+import (
+	"crypto/x509"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	selinuxFSPath        = "/sys/fs/selinux"
+	selinuxConfigPath    = "/etc/selinux/config"
+	apparmorEnabledPath  = "/sys/module/apparmor/parameters/enabled"
+	apparmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+)
+
+// caBundlePaths are the trust bundles distributions generate from their CA packages,
+// in the order they are looked for
+var caBundlePaths = []string{
+	"/etc/ssl/certs/ca-certificates.crt", // Debian, Ubuntu, Alpine, Gentoo
+	"/etc/pki/tls/certs/ca-bundle.crt",   // Fedora, RHEL
+	"/etc/ssl/ca-bundle.pem",             // openSUSE
+	"/etc/ssl/cert.pem",                  // Arch, Alpine
+}
+
+// caAnchorDirs are where administrators add CAs for update-ca-certificates or
+// update-ca-trust to merge into the bundle
+var caAnchorDirs = []string{
+	"/usr/local/share/ca-certificates",          // Debian, Ubuntu, Alpine
+	"/etc/pki/ca-trust/source/anchors",          // Fedora, RHEL
+	"/etc/ca-certificates/trust-source/anchors", // Arch
+	"/etc/pki/trust/anchors",                    // openSUSE
+}
+
+// collectSecurityPlatform gathers SELinux or AppArmor enforcement status
+func collectSecurityPlatform(data *types.SecurityData) {
+	data.SELinux = collectSELinux(hostPath(selinuxFSPath), hostPath(selinuxConfigPath))
+	data.AppArmor = collectAppArmor(hostPath(apparmorEnabledPath), hostPath(apparmorProfilesPath))
+	data.TrustStore = collectTrustStore(caBundlePaths, caAnchorDirs, time.Now())
+}
+
+// collectSELinux reads the live mode from selinuxfs and the boot mode/policy from the config file
+func collectSELinux(selinuxFS, configPath string) *types.SELinuxStatus {
+	status := &types.SELinuxStatus{Mode: "disabled"}
+	found := false
+
+	if config, err := os.ReadFile(configPath); err == nil {
+		status.ConfigMode, status.Policy = parseSELinuxConfig(string(config))
+		found = true
+	}
+
+	// selinuxfs is only mounted when SELinux is active
+	if enforce, err := os.ReadFile(selinuxFS + "/enforce"); err == nil {
+		found = true
+		if strings.TrimSpace(string(enforce)) == "1" {
+			status.Mode = "enforcing"
+		} else {
+			status.Mode = "permissive"
+		}
+		if version, err := os.ReadFile(selinuxFS + "/policyvers"); err == nil {
+			status.PolicyVersion, _ = strconv.Atoi(strings.TrimSpace(string(version)))
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return status
+}
+
+// parseSELinuxConfig extracts SELINUX= and SELINUXTYPE= from /etc/selinux/config
+func parseSELinuxConfig(content string) (string, string) {
+	var mode, policy string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch strings.TrimSpace(key) {
+		case "SELINUX":
+			mode = value
+		case "SELINUXTYPE":
+			policy = value
+		}
+	}
+	return mode, policy
+}
+
+// collectAppArmor reads whether AppArmor is enabled and counts loaded profiles by mode
+func collectAppArmor(enabledPath, profilesPath string) *types.AppArmorStatus {
+	enabled, err := os.ReadFile(enabledPath)
+	if err != nil {
+		return nil
+	}
+
+	status := &types.AppArmorStatus{Enabled: strings.TrimSpace(string(enabled)) == "Y"}
+	if !status.Enabled {
+		return status
+	}
+
+	// The profile list is only readable by root
+	if profiles, err := os.ReadFile(profilesPath); err == nil {
+		status.Enforce, status.Complain, status.Other = parseAppArmorProfiles(string(profiles))
+		status.Profiles = status.Enforce + status.Complain + status.Other
+	}
+
+	return status
+}
+
+// parseAppArmorProfiles counts profiles by mode
+// Each line looks like "/usr/sbin/cupsd (enforce)"
+func parseAppArmorProfiles(content string) (enforce, complain, other int) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		open := strings.LastIndex(line, "(")
+		if line == "" || open == -1 || !strings.HasSuffix(line, ")") {
+			continue
+		}
+		switch line[open+1 : len(line)-1] {
+		case "enforce":
+			enforce++
+		case "complain":
+			complain++
+		default:
+			other++
+		}
+	}
+	return enforce, complain, other
+}
+
+// collectTrustStore summarizes the first CA bundle found, with the certificates in the
+// anchor directories as the locally added ones. Anchors are listed even before they are
+// merged into the bundle, since they are trusted as soon as the update tool runs
+func collectTrustStore(bundles, anchorDirs []string, now time.Time) *types.TrustStore {
+	var source string
+	var roots []*x509.Certificate
+	for _, bundle := range bundles {
+		if content, err := os.ReadFile(hostPath(bundle)); err == nil {
+			source, roots = bundle, parseCertificates(content)
+			break
+		}
+	}
+
+	var local []trustedCA
+	for _, dir := range anchorDirs {
+		local = append(local, anchorCertificates(dir)...)
+	}
+
+	if source == "" && len(local) == 0 {
+		return nil
+	}
+	return summarizeTrustStore(source, roots, local, now)
+}
+
+// anchorCertificates reads the PEM or DER certificates below an anchor directory
+func anchorCertificates(dir string) []trustedCA {
+	var cas []trustedCA
+	root := hostPath(dir)
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		for _, cert := range parseCertificates(content) {
+			cas = append(cas, trustedCA{cert: cert, source: filepath.Join(dir, rel)})
+		}
+		return nil
+	})
+	return cas
+}
@@ -0,0 +1,131 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSELinuxConfig(t *testing.T) {
+	content := `# This file controls the state of SELinux on the system.
+# SELINUX=disabled
+SELINUX=enforcing
+SELINUXTYPE="targeted"
+`
+	mode, policy := parseSELinuxConfig(content)
+	if mode != "enforcing" {
+		t.Errorf("mode = %q, expected %q", mode, "enforcing")
+	}
+	if policy != "targeted" {
+		t.Errorf("policy = %q, expected %q", policy, "targeted")
+	}
+}
+
+func TestCollectSELinux(t *testing.T) {
+	dir := t.TempDir()
+	selinuxFS := filepath.Join(dir, "selinux")
+	configPath := filepath.Join(dir, "config")
+	writeFile := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	if status := collectSELinux(selinuxFS, configPath); status != nil {
+		t.Errorf("collectSELinux() without SELinux = %+v, expected nil", status)
+	}
+
+	writeFile(configPath, "SELINUX=enforcing\nSELINUXTYPE=targeted\n")
+	status := collectSELinux(selinuxFS, configPath)
+	if status == nil || status.Mode != "disabled" || status.ConfigMode != "enforcing" {
+		t.Errorf("collectSELinux() with config only = %+v, expected disabled/enforcing", status)
+	}
+
+	writeFile(filepath.Join(selinuxFS, "enforce"), "0\n")
+	writeFile(filepath.Join(selinuxFS, "policyvers"), "33\n")
+	status = collectSELinux(selinuxFS, configPath)
+	if status.Mode != "permissive" || status.Policy != "targeted" || status.PolicyVersion != 33 {
+		t.Errorf("collectSELinux() permissive = %+v", status)
+	}
+
+	writeFile(filepath.Join(selinuxFS, "enforce"), "1\n")
+	if status = collectSELinux(selinuxFS, configPath); status.Mode != "enforcing" {
+		t.Errorf("Mode = %q, expected enforcing", status.Mode)
+	}
+}
+
+func TestParseAppArmorProfiles(t *testing.T) {
+	content := `/usr/sbin/cupsd (enforce)
+/usr/bin/man (enforce)
+man_filter (enforce)
+/usr/lib/snapd/snap-confine (complain)
+snap.firefox.firefox (kill)
+unconfined-app (unconfined)
+`
+	enforce, complain, other := parseAppArmorProfiles(content)
+	if enforce != 3 || complain != 1 || other != 2 {
+		t.Errorf("parseAppArmorProfiles() = %d, %d, %d, expected 3, 1, 2", enforce, complain, other)
+	}
+}
+
+func TestCollectAppArmor(t *testing.T) {
+	dir := t.TempDir()
+	enabledPath := filepath.Join(dir, "enabled")
+	profilesPath := filepath.Join(dir, "profiles")
+
+	if status := collectAppArmor(enabledPath, profilesPath); status != nil {
+		t.Errorf("collectAppArmor() without module = %+v, expected nil", status)
+	}
+
+	if err := os.WriteFile(enabledPath, []byte("Y\n"), 0644); err != nil {
+		t.Fatalf("Failed to write enabled: %v", err)
+	}
+	if err := os.WriteFile(profilesPath, []byte("/usr/bin/man (enforce)\n/usr/sbin/tcpdump (complain)\n"), 0644); err != nil {
+		t.Fatalf("Failed to write profiles: %v", err)
+	}
+
+	status := collectAppArmor(enabledPath, profilesPath)
+	if status == nil || !status.Enabled || status.Profiles != 2 || status.Enforce != 1 || status.Complain != 1 {
+		t.Errorf("collectAppArmor() = %+v", status)
+	}
+}
+
+func TestCollectTrustStore(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	root := testCertificate(t, "Distribution Root", now.AddDate(5, 0, 0))
+	corporate := testCertificate(t, "Corporate Root", now.AddDate(5, 0, 0))
+
+	bundle := filepath.Join(dir, "ca-certificates.crt")
+	anchors := filepath.Join(dir, "anchors")
+	missing := filepath.Join(dir, "missing.crt")
+
+	if store := collectTrustStore([]string{missing}, []string{anchors}, now); store != nil {
+		t.Errorf("collectTrustStore() without a bundle = %+v, expected nil", store)
+	}
+
+	if err := os.WriteFile(bundle, pemEncode(root, corporate), 0644); err != nil {
+		t.Fatalf("Failed to write bundle: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(anchors, "corp"), 0755); err != nil {
+		t.Fatalf("Failed to create anchors: %v", err)
+	}
+	// Anchors may be PEM or DER
+	if err := os.WriteFile(filepath.Join(anchors, "corp", "root.der"), corporate.Raw, 0644); err != nil {
+		t.Fatalf("Failed to write anchor: %v", err)
+	}
+
+	store := collectTrustStore([]string{missing, bundle}, []string{anchors}, now)
+	if store == nil || store.Source != bundle || store.Total != 2 {
+		t.Fatalf("collectTrustStore() = %+v, expected 2 roots from %s", store, bundle)
+	}
+	if len(store.Local) != 1 || store.Local[0].Subject != "Corporate Root" || store.Local[0].Source != filepath.Join(anchors, "corp", "root.der") {
+		t.Errorf("Local = %+v, expected Corporate Root from the anchors", store.Local)
+	}
+}
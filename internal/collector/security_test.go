@@ -0,0 +1,123 @@
+package collector
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// TestCollectSecurity verifies security collection never fails outright
+func TestCollectSecurity(t *testing.T) {
+	data, err := CollectSecurity()
+	if err != nil {
+		t.Fatalf("CollectSecurity failed: %v", err)
+	}
+
+	if data == nil {
+		t.Fatal("CollectSecurity returned nil data")
+	}
+
+	t.Logf("Security: %+v", data)
+}
+
+// testCertificate creates a self-signed CA certificate expiring at notAfter
+func testCertificate(t *testing.T, name string, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notAfter.AddDate(-10, 0, 0),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+// pemEncode writes certificates as a PEM bundle
+func pemEncode(certs ...*x509.Certificate) []byte {
+	var bundle []byte
+	for _, cert := range certs {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return bundle
+}
+
+func TestParseCertificates(t *testing.T) {
+	now := time.Now()
+	first := testCertificate(t, "First Root", now.AddDate(5, 0, 0))
+	second := testCertificate(t, "Second Root", now.AddDate(5, 0, 0))
+
+	bundle := append([]byte("# comment\n"), pemEncode(first, second)...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: []byte{1}})...)
+	if certs := parseCertificates(bundle); len(certs) != 2 || certs[1].Subject.CommonName != "Second Root" {
+		t.Errorf("parseCertificates(PEM) returned %d certificates, expected 2", len(certs))
+	}
+
+	if certs := parseCertificates(first.Raw); len(certs) != 1 || certs[0].Subject.CommonName != "First Root" {
+		t.Errorf("parseCertificates(DER) = %v, expected First Root", certs)
+	}
+
+	// OpenSSL trusted certificates carry trust settings after the certificate
+	trusted := pem.EncodeToMemory(&pem.Block{Type: "TRUSTED CERTIFICATE", Bytes: append(append([]byte{}, second.Raw...), 0x30, 0x00)})
+	if certs := parseCertificates(trusted); len(certs) != 1 || certs[0].Subject.CommonName != "Second Root" {
+		t.Errorf("parseCertificates(TRUSTED CERTIFICATE) = %v, expected Second Root", certs)
+	}
+
+	if certs := parseCertificates([]byte("not a certificate")); len(certs) != 0 {
+		t.Errorf("parseCertificates(garbage) = %v, expected none", certs)
+	}
+}
+
+func TestSummarizeTrustStore(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := testCertificate(t, "Valid Root", now.AddDate(10, 0, 0))
+	expired := testCertificate(t, "Expired Root", now.AddDate(0, -1, 0))
+	later := testCertificate(t, "Later Root", now.AddDate(0, 0, 60))
+	sooner := testCertificate(t, "Sooner Root", now.AddDate(0, 0, 10))
+	corporate := testCertificate(t, "Corporate Inspection CA", now.AddDate(2, 0, 0))
+
+	roots := []*x509.Certificate{valid, expired, later, sooner, corporate}
+	local := []trustedCA{{cert: corporate, source: "/usr/local/share/ca-certificates/corp.crt"}}
+	store := summarizeTrustStore("/etc/ssl/certs/ca-certificates.crt", roots, local, now)
+
+	if store.Source != "/etc/ssl/certs/ca-certificates.crt" {
+		t.Errorf("Source = %q", store.Source)
+	}
+	// The local CA is also in the bundle and counted once
+	if store.Total != 5 || store.Expired != 1 {
+		t.Errorf("Total = %d, Expired = %d, expected 5 and 1", store.Total, store.Expired)
+	}
+	if len(store.ExpiringSoon) != 2 || store.ExpiringSoon[0].Subject != "Sooner Root" || store.ExpiringSoon[1].Subject != "Later Root" {
+		t.Errorf("ExpiringSoon = %+v, expected Sooner Root then Later Root", store.ExpiringSoon)
+	}
+	if len(store.Local) != 1 {
+		t.Fatalf("Local = %+v, expected one certificate", store.Local)
+	}
+	ca := store.Local[0]
+	sum := sha256.Sum256(corporate.Raw)
+	if ca.Subject != "Corporate Inspection CA" || ca.Issuer != "" || ca.Source != "/usr/local/share/ca-certificates/corp.crt" || ca.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("Local[0] = %+v", ca)
+	}
+}
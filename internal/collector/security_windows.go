@@ -0,0 +1,137 @@
+//go:build windows
+
+package collector
+
+import (
+	"crypto/x509"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"golang.org/x/sys/windows/registry"
+)
+
+// collectSecurityPlatform gathers Windows security posture
+// Activation status is reported with the system section (see collectLicensePlatform)
+func collectSecurityPlatform(data *types.SecurityData) {
+	data.TrustStore = collectTrustStore(time.Now())
+}
+
+// certificateStore is a registry-backed system certificate store
+type certificateStore struct {
+	root registry.Key
+	path string
+	name string // How the store is reported as a source
+}
+
+// Registry stores holding trusted roots. AuthRoot is the Microsoft root program, kept up
+// to date by Windows Update; ROOT holds the roots Windows ships with and the ones added
+// by an administrator, and the policy and enterprise stores the ones pushed by Group Policy
+var (
+	authRootStore   = certificateStore{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\SystemCertificates\AuthRoot\Certificates`, `LocalMachine\AuthRoot`}
+	localRootStores = []certificateStore{
+		{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\SystemCertificates\ROOT\Certificates`, `LocalMachine\Root`},
+		{registry.LOCAL_MACHINE, `SOFTWARE\Policies\Microsoft\SystemCertificates\Root\Certificates`, `LocalMachine\Root (group policy)`},
+		{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\EnterpriseCertificates\Root\Certificates`, `LocalMachine\Root (enterprise)`},
+		{registry.CURRENT_USER, `SOFTWARE\Microsoft\SystemCertificates\Root\Certificates`, `CurrentUser\Root`},
+	}
+)
+
+// builtinRoots are the SHA-1 thumbprints of the roots Windows itself installs into the
+// LocalMachine ROOT store rather than AuthRoot
+var builtinRoots = map[string]bool{
+	"cdd4eeae6000ac7f40c3802c171e30148030c072": true, // Microsoft Root Certificate Authority
+	"3b1efd3a66ea28b16697394703a72ca340a05bd5": true, // Microsoft Root Certificate Authority 2010
+	"8f43288ad272f3103b6fb1428485ea3014c0bcfe": true, // Microsoft Root Certificate Authority 2011
+	"a43489159a520f0d93d032ccaf37e7fe20a8b419": true, // Microsoft Root Authority
+	"7f88cd7223f3c813818c994614a89c99fa3b5247": true, // Microsoft Authenticode(tm) Root Authority
+	"245c97df7514e7cf2df8be72ae957b9e04741e85": true, // Copyright (c) 1997 Microsoft Corp.
+	"18f7c1fcc3090203fd5baa2f861a754976c8dd25": true, // NO LIABILITY ACCEPTED, (c)97 VeriSign, Inc.
+	"be36a4562fb2ee05dbb3d32323adf445084ed656": true, // Thawte Timestamping CA
+	"742c3192e607e424eb4549542be1bbc53e6174e2": true, // Class 3 Public Primary Certification Authority
+}
+
+// collectTrustStore summarizes the Microsoft root program and lists the roots of the other
+// stores as locally added, leaving out the ones Windows installs itself
+func collectTrustStore(now time.Time) *types.TrustStore {
+	var roots []*x509.Certificate
+	for _, ca := range storeCertificates(authRootStore) {
+		roots = append(roots, ca.cert)
+	}
+
+	var local []trustedCA
+	for _, store := range localRootStores {
+		for _, ca := range storeCertificates(store) {
+			if !builtinRoots[ca.thumbprint] {
+				local = append(local, ca.trustedCA)
+			}
+		}
+	}
+
+	if len(roots) == 0 && len(local) == 0 {
+		return nil
+	}
+	return summarizeTrustStore(authRootStore.name, roots, local, now)
+}
+
+// storedCertificate is a certificate with the thumbprint it is stored under
+type storedCertificate struct {
+	trustedCA
+	thumbprint string
+}
+
+// storeCertificates reads the certificates of a store, one subkey per SHA-1 thumbprint
+// with the certificate and its properties serialized in the Blob value
+func storeCertificates(store certificateStore) []storedCertificate {
+	key, err := registry.OpenKey(store.root, store.path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+	thumbprints, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil
+	}
+
+	var certs []storedCertificate
+	for _, thumbprint := range thumbprints {
+		entry, err := registry.OpenKey(key, thumbprint, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		blob, _, err := entry.GetBinaryValue("Blob")
+		entry.Close()
+		if err != nil {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(certificateFromBlob(blob)); err == nil {
+			certs = append(certs, storedCertificate{
+				trustedCA:  trustedCA{cert: cert, source: store.name},
+				thumbprint: strings.ToLower(thumbprint),
+			})
+		}
+	}
+	return certs
+}
+
+// certPropEncodedCert is the ID of the serialized property holding the DER certificate
+const certPropEncodedCert = 0x20
+
+// certificateFromBlob extracts the DER certificate from a serialized store entry: a list of
+// properties, each a little-endian property ID, a reserved word and a length, then the value
+func certificateFromBlob(blob []byte) []byte {
+	for len(blob) >= 12 {
+		id := binary.LittleEndian.Uint32(blob[0:4])
+		size := binary.LittleEndian.Uint32(blob[8:12])
+		blob = blob[12:]
+		if uint64(size) > uint64(len(blob)) {
+			return nil
+		}
+		if id == certPropEncodedCert {
+			return blob[:size]
+		}
+		blob = blob[size:]
+	}
+	return nil
+}
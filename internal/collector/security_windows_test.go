@@ -0,0 +1,38 @@
+//go:build windows
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestCertificateFromBlob(t *testing.T) {
+	cert := testCertificate(t, "Corporate Root", time.Now().AddDate(5, 0, 0))
+	property := func(id uint32, value []byte) []byte {
+		header := make([]byte, 12)
+		binary.LittleEndian.PutUint32(header[0:4], id)
+		binary.LittleEndian.PutUint32(header[4:8], 1)
+		binary.LittleEndian.PutUint32(header[8:12], uint32(len(value)))
+		return append(header, value...)
+	}
+
+	// A SHA-1 hash property (3) and a friendly name (11) usually come first
+	blob := append(property(3, make([]byte, 20)), property(11, []byte("C\x00o\x00r\x00p\x00\x00\x00"))...)
+	blob = append(blob, property(certPropEncodedCert, cert.Raw)...)
+	if der := certificateFromBlob(blob); !bytes.Equal(der, cert.Raw) {
+		t.Errorf("certificateFromBlob() returned %d bytes, expected the %d byte certificate", len(der), len(cert.Raw))
+	}
+
+	if der := certificateFromBlob(property(3, make([]byte, 20))); der != nil {
+		t.Errorf("certificateFromBlob() without a certificate = %d bytes, expected nil", len(der))
+	}
+
+	// A length running past the end of the blob
+	truncated := property(certPropEncodedCert, cert.Raw)[:100]
+	if der := certificateFromBlob(truncated); der != nil {
+		t.Errorf("certificateFromBlob(truncated) = %d bytes, expected nil", len(der))
+	}
+}
@@ -0,0 +1,16 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectSensors gathers the temperature inputs of the hardware monitoring chips
+func CollectSensors() (*types.SensorsData, error) {
+	temperatures := collectSensorsPlatform()
+	if len(temperatures) == 0 {
+		return nil, fmt.Errorf("no temperature sensors found")
+	}
+	return &types.SensorsData{Temperatures: temperatures}, nil
+}
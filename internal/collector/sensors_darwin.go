@@ -0,0 +1,62 @@
+//go:build darwin
+
+package collector
+
+import (
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// smcSensorNames describes the SMC temperature keys gopsutil reads
+var smcSensorNames = map[string]string{
+	"TA0P": "Ambient Air 0",
+	"TA1P": "Ambient Air 1",
+	"TC0D": "CPU Diode",
+	"TC0H": "CPU Heatsink",
+	"TC0P": "CPU Proximity",
+	"TB0T": "Enclosure Base 0",
+	"TB1T": "Enclosure Base 1",
+	"TB2T": "Enclosure Base 2",
+	"TB3T": "Enclosure Base 3",
+	"TG0D": "GPU Diode",
+	"TG0H": "GPU Heatsink",
+	"TG0P": "GPU Proximity",
+	"TH0P": "Hard Drive Bay",
+	"TM0S": "Memory Slot 0",
+	"TM0P": "Memory Slots Proximity",
+	"TN0H": "Northbridge",
+	"TN0D": "Northbridge Diode",
+	"TN0P": "Northbridge Proximity",
+	"TI0P": "Thunderbolt 0",
+	"TI1P": "Thunderbolt 1",
+	"TW0P": "Wireless Module",
+}
+
+// collectSensorsPlatform reads temperatures from the SMC. gopsutil reads the SMC through
+// cgo, so builds without cgo, and Apple Silicon Macs without those keys, report none
+func collectSensorsPlatform() []types.TemperatureSensor {
+	stats, err := host.SensorsTemperatures()
+	if err != nil && len(stats) == 0 {
+		return nil
+	}
+
+	var sensors []types.TemperatureSensor
+	for _, stat := range stats {
+		if stat.Temperature <= 0 {
+			continue
+		}
+		label := stat.SensorKey
+		if name, ok := smcSensorNames[stat.SensorKey]; ok {
+			label = name
+		}
+		sensors = append(sensors, types.TemperatureSensor{
+			Chip:        "SMC",
+			Label:       label,
+			Source:      "smc",
+			Temperature: stat.Temperature,
+			Max:         stat.High,
+			Critical:    stat.Critical,
+		})
+	}
+	return sensors
+}
@@ -0,0 +1,71 @@
+//go:build linux
+
+package collector
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectSensorsPlatform reads temperature inputs of the hwmon chips in sysfs
+func collectSensorsPlatform() []types.TemperatureSensor {
+	return collectHwmonTemperatures(hostfs, hwmonClassPath)
+}
+
+// collectHwmonTemperatures reads tempN_input of every hwmon chip below root, with the
+// input's label and its min, max and crit limits where the driver provides them.
+// Temperatures are in millidegrees Celsius; unreadable inputs are skipped
+func collectHwmonTemperatures(fsys fsReader, root string) []types.TemperatureSensor {
+	chips, err := fsys.Glob(filepath.Join(root, "hwmon*"))
+	if err != nil {
+		return nil
+	}
+	sort.Slice(chips, func(i, j int) bool {
+		return hwmonIndex(chips[i], "hwmon", "") < hwmonIndex(chips[j], "hwmon", "")
+	})
+
+	var sensors []types.TemperatureSensor
+	for _, chip := range chips {
+		chipName, _ := readString(fsys, filepath.Join(chip, "name"))
+		chipName = strings.TrimSpace(chipName)
+
+		inputs, _ := fsys.Glob(filepath.Join(chip, "temp*_input"))
+		sort.Slice(inputs, func(i, j int) bool {
+			return hwmonIndex(inputs[i], "temp", "_input") < hwmonIndex(inputs[j], "temp", "_input")
+		})
+		for _, input := range inputs {
+			temp, ok := readMillidegrees(fsys, input)
+			if !ok {
+				continue
+			}
+			prefix := strings.TrimSuffix(input, "_input")
+			sensor := types.TemperatureSensor{
+				Chip:        chipName,
+				Label:       filepath.Base(prefix),
+				Source:      "hwmon",
+				Temperature: temp,
+			}
+			if label, err := readString(fsys, prefix+"_label"); err == nil && strings.TrimSpace(label) != "" {
+				sensor.Label = strings.TrimSpace(label)
+			}
+			sensor.Min, _ = readMillidegrees(fsys, prefix+"_min")
+			sensor.Max, _ = readMillidegrees(fsys, prefix+"_max")
+			sensor.Critical, _ = readMillidegrees(fsys, prefix+"_crit")
+			sensors = append(sensors, sensor)
+		}
+	}
+	return sensors
+}
+
+// hwmonIndex extracts N from paths such as hwmon3 or temp12_input, so 10 sorts after 9
+func hwmonIndex(path, prefix, suffix string) int {
+	index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), prefix), suffix))
+	if err != nil {
+		return -1
+	}
+	return index
+}
@@ -0,0 +1,51 @@
+//go:build linux
+
+package collector
+
+import "testing"
+
+func TestCollectHwmonTemperatures(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, root, map[string]string{
+		"class/hwmon/hwmon0/name":         "nvme",
+		"class/hwmon/hwmon0/temp1_input":  "38850",
+		"class/hwmon/hwmon0/temp1_label":  "Composite",
+		"class/hwmon/hwmon0/temp1_min":    "-273150",
+		"class/hwmon/hwmon0/temp1_max":    "81850",
+		"class/hwmon/hwmon0/temp1_crit":   "84850",
+		"class/hwmon/hwmon2/name":         "coretemp",
+		"class/hwmon/hwmon2/temp1_input":  "52000",
+		"class/hwmon/hwmon2/temp1_label":  "Package id 0",
+		"class/hwmon/hwmon2/temp1_max":    "100000",
+		"class/hwmon/hwmon2/temp10_input": "47000",
+		"class/hwmon/hwmon2/temp2_input":  "49000",
+		"class/hwmon/hwmon2/temp2_label":  "Core 0",
+		"class/hwmon/hwmon2/temp3_input":  "unreadable",
+		"class/hwmon/hwmon10/name":        "acpitz",
+		"class/hwmon/hwmon10/temp1_input": "27800",
+	})
+
+	sensors := collectHwmonTemperatures(hostReader{sysfs: root}, hwmonClassPath)
+	if len(sensors) != 5 {
+		t.Fatalf("collectHwmonTemperatures() = %+v, expected 5 readable inputs", sensors)
+	}
+
+	nvme := sensors[0]
+	if nvme.Chip != "nvme" || nvme.Label != "Composite" || nvme.Source != "hwmon" || nvme.Temperature != 38.85 ||
+		nvme.Min != -273.15 || nvme.Max != 81.85 || nvme.Critical != 84.85 {
+		t.Errorf("nvme sensor = %+v", nvme)
+	}
+
+	// Chips and inputs in numeric order; unlabelled inputs keep their file name
+	var order []string
+	for _, s := range sensors[1:] {
+		order = append(order, s.Chip+"/"+s.Label)
+	}
+	want := []string{"coretemp/Package id 0", "coretemp/Core 0", "coretemp/temp10", "acpitz/temp1"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("sensor order = %v, want %v", order, want)
+			break
+		}
+	}
+}
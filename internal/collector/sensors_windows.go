@@ -0,0 +1,109 @@
+//go:build windows
+
+package collector
+
+import (
+	"math"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// OHMSensor is a sensor published by OpenHardwareMonitor or LibreHardwareMonitor, which share
+// the WMI schema. Min and Max are the lowest and highest readings since the monitor started
+type OHMSensor struct {
+	Identifier string
+	Name       string
+	Parent     string
+	Value      float32
+	Min        float32
+	Max        float32
+}
+
+// OHMHardware is a monitored component, such as a CPU, motherboard chip or drive
+type OHMHardware struct {
+	Identifier string
+	Name       string
+}
+
+// hardwareMonitors are the WMI namespaces of hardware monitors that read the chips Windows
+// itself does not expose, with the source name they are reported under
+var hardwareMonitors = []struct{ namespace, source string }{
+	{`root\LibreHardwareMonitor`, "librehardwaremonitor"},
+	{`root\OpenHardwareMonitor`, "openhardwaremonitor"},
+}
+
+// collectSensorsPlatform reads temperatures from a running LibreHardwareMonitor or
+// OpenHardwareMonitor, falling back to the ACPI thermal zones Windows exposes on its own
+func collectSensorsPlatform() []types.TemperatureSensor {
+	for _, monitor := range hardwareMonitors {
+		var sensors []OHMSensor
+		query := "SELECT Identifier, Name, Parent, Value, Min, Max FROM Sensor WHERE SensorType = 'Temperature'"
+		if err := wmi.QueryNamespace(query, &sensors, monitor.namespace); err != nil || len(sensors) == 0 {
+			continue
+		}
+		var hardware []OHMHardware
+		_ = wmi.QueryNamespace("SELECT Identifier, Name FROM Hardware", &hardware, monitor.namespace)
+		return monitorTemperatures(sensors, hardware, monitor.source)
+	}
+
+	var zones []MSAcpi_ThermalZoneTemperature
+	query := "SELECT InstanceName, CurrentTemperature, PassiveTripPoint, CriticalTripPoint, ActiveTripPoint, ActiveTripPointCount FROM MSAcpi_ThermalZoneTemperature"
+	if err := wmi.QueryNamespace(query, &zones, `root\wmi`); err != nil {
+		return nil
+	}
+	var temperatures []types.TemperatureSensor
+	for _, zone := range zones {
+		if zone.CurrentTemperature == 0 {
+			continue
+		}
+		sensor := types.TemperatureSensor{
+			Chip:        "ACPI",
+			Label:       zone.InstanceName,
+			Source:      "acpi",
+			Temperature: decikelvinToCelsius(zone.CurrentTemperature),
+		}
+		if zone.CriticalTripPoint > 0 {
+			sensor.Critical = decikelvinToCelsius(zone.CriticalTripPoint)
+		}
+		temperatures = append(temperatures, sensor)
+	}
+	return temperatures
+}
+
+// monitorTemperatures names each sensor's chip after the hardware it belongs to
+func monitorTemperatures(sensors []OHMSensor, hardware []OHMHardware, source string) []types.TemperatureSensor {
+	chips := hardwareNames(hardware)
+	var temperatures []types.TemperatureSensor
+	for _, s := range sensors {
+		temperatures = append(temperatures, types.TemperatureSensor{
+			Chip:        chips(s.Parent),
+			Label:       s.Name,
+			Source:      source,
+			Temperature: roundTenth(float64(s.Value)),
+			Min:         roundTenth(float64(s.Min)),
+			Max:         roundTenth(float64(s.Max)),
+		})
+	}
+	return temperatures
+}
+
+// hardwareNames returns a lookup from a sensor's parent to the name of its hardware,
+// falling back to the parent identifier for hardware the monitor did not list
+func hardwareNames(hardware []OHMHardware) func(parent string) string {
+	names := map[string]string{}
+	for _, h := range hardware {
+		names[h.Identifier] = h.Name
+	}
+	return func(parent string) string {
+		if name := names[parent]; name != "" {
+			return name
+		}
+		return parent
+	}
+}
+
+// roundTenth rounds a WMI float32 reading to 0.1, dropping float32 noise such as 45.099998
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestMonitorTemperatures(t *testing.T) {
+	sensors := []OHMSensor{
+		{Identifier: "/intelcpu/0/temperature/0", Name: "CPU Package", Parent: "/intelcpu/0", Value: 45.1, Min: 38, Max: 71.5},
+		{Identifier: "/lpc/nct6798d/temperature/1", Name: "Temperature #1", Parent: "/lpc/nct6798d", Value: 33},
+	}
+	hardware := []OHMHardware{{Identifier: "/intelcpu/0", Name: "Intel Core i7-12700K"}}
+
+	got := monitorTemperatures(sensors, hardware, "librehardwaremonitor")
+	if len(got) != 2 {
+		t.Fatalf("monitorTemperatures() = %+v", got)
+	}
+	if got[0].Chip != "Intel Core i7-12700K" || got[0].Temperature != 45.1 || got[0].Min != 38 || got[0].Max != 71.5 {
+		t.Errorf("CPU sensor = %+v", got[0])
+	}
+	// Hardware the monitor did not list keeps its identifier
+	if got[1].Chip != "/lpc/nct6798d" || got[1].Source != "librehardwaremonitor" {
+		t.Errorf("chip sensor = %+v", got[1])
+	}
+}
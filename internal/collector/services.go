@@ -0,0 +1,22 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectServices summarizes the states of the services the init system or service manager
+// runs, with the failed ones sorted by name
+func CollectServices() (*types.ServiceData, error) {
+	data := collectServicesPlatform()
+	if data == nil || data.Total == 0 {
+		return nil, fmt.Errorf("no services found")
+	}
+	data.Failed = len(data.FailedServices)
+	sort.Slice(data.FailedServices, func(i, j int) bool {
+		return data.FailedServices[i].Name < data.FailedServices[j].Name
+	})
+	return data, nil
+}
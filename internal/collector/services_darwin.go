@@ -0,0 +1,56 @@
+//go:build darwin
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectServicesPlatform counts the launchd jobs of the domain sysinfo runs in: the system
+// domain as root, the user's own jobs otherwise
+func collectServicesPlatform() *types.ServiceData {
+	out, err := sandbox.Command("launchctl", "list").Output()
+	if err != nil {
+		return nil
+	}
+	return parseLaunchctlList(string(out))
+}
+
+// parseLaunchctlList reads `launchctl list`, one job per line with its PID (- when not
+// running), the status it last exited with and its label:
+//
+//	PID	Status	Label
+//	312	0	com.apple.Finder
+//	-	78	com.example.backup
+//	-	-9	com.example.agent
+//
+// A negative status is the signal that killed the job. Jobs that are not running and last
+// exited with a nonzero status are failed
+func parseLaunchctlList(output string) *types.ServiceData {
+	data := &types.ServiceData{Manager: "launchd"}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] == "PID" {
+			continue
+		}
+		data.Total++
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			data.Running++
+			continue
+		}
+		status, err := strconv.Atoi(fields[1])
+		if err != nil || status == 0 {
+			continue
+		}
+		failed := types.ServiceStatus{Name: fields[2], State: "stopped", Reason: "exit code " + fields[1]}
+		if status < 0 {
+			failed.Reason = "signal " + strconv.Itoa(-status)
+		}
+		data.FailedServices = append(data.FailedServices, failed)
+	}
+	return data
+}
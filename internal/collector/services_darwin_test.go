@@ -0,0 +1,25 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+const launchctlList = `PID	Status	Label
+312	0	com.apple.Finder
+-	0	com.apple.mdworker.shared
+-	78	com.example.backup
+-	-9	com.example.agent
+`
+
+func TestParseLaunchctlList(t *testing.T) {
+	data := parseLaunchctlList(launchctlList)
+	if data.Manager != "launchd" || data.Total != 4 || data.Running != 1 || len(data.FailedServices) != 2 {
+		t.Fatalf("data = %+v, expected 4 jobs with 1 running and 2 failed", data)
+	}
+	if backup := data.FailedServices[0]; backup.Name != "com.example.backup" || backup.Reason != "exit code 78" {
+		t.Errorf("failed job = %+v, expected com.example.backup with exit code 78", backup)
+	}
+	if agent := data.FailedServices[1]; agent.Reason != "signal 9" {
+		t.Errorf("failed job = %+v, expected com.example.agent killed by signal 9", agent)
+	}
+}
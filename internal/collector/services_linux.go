@@ -0,0 +1,92 @@
+//go:build linux
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectServicesPlatform counts the systemd service units and explains why the failed ones
+// failed; nil without systemd
+func collectServicesPlatform() *types.ServiceData {
+	out, err := sandbox.Command("systemctl", "list-units", "--type=service", "--all", "--no-legend", "--plain").Output()
+	if err != nil {
+		return nil
+	}
+	data := parseServiceUnits(string(out))
+	if len(data.FailedServices) == 0 {
+		return data
+	}
+
+	args := []string{"show", "-p", "Id", "-p", "Result", "-p", "ExecMainCode", "-p", "ExecMainStatus"}
+	for _, service := range data.FailedServices {
+		args = append(args, service.Name)
+	}
+	if out, err := sandbox.Command("systemctl", args...).Output(); err == nil {
+		reasons := parseServiceResults(string(out))
+		for i := range data.FailedServices {
+			data.FailedServices[i].Reason = reasons[data.FailedServices[i].Name]
+		}
+	}
+	return data
+}
+
+// parseServiceUnits reads `systemctl list-units --plain`, one unit per line with its load,
+// active and sub states and description:
+//
+//	ssh.service       loaded    active   running SSH server
+//	backup.service    loaded    failed   failed  Nightly backup
+//	foo.service       not-found inactive dead    foo.service
+//
+// Units that are referenced but not installed are left out
+func parseServiceUnits(output string) *types.ServiceData {
+	data := &types.ServiceData{Manager: "systemd"}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(line), "●* "))
+		if len(fields) < 4 || fields[1] == "not-found" {
+			continue
+		}
+		data.Total++
+		if fields[3] == "running" {
+			data.Running++
+		}
+		if fields[2] == "failed" {
+			data.FailedServices = append(data.FailedServices, types.ServiceStatus{
+				Name:        fields[0],
+				Description: strings.Join(fields[4:], " "),
+				State:       fields[2],
+			})
+		}
+	}
+	return data
+}
+
+// parseServiceResults reads `systemctl show` for several units, blocks of properties separated
+// by blank lines, into the reason each unit failed, by unit name: "exit-code 1", "signal 9",
+// "timeout" and so on
+func parseServiceResults(output string) map[string]string {
+	reasons := make(map[string]string)
+	for _, block := range strings.Split(output, "\n\n") {
+		props := parseSystemctlShow(block)
+		if props["Id"] == "" || props["Result"] == "" || props["Result"] == "success" {
+			continue
+		}
+		reason := props["Result"]
+		status := props["ExecMainStatus"]
+		switch {
+		case reason == "exit-code" && status != "" && status != "0":
+			reason += " " + status
+		case (reason == "signal" || reason == "core-dump") && status != "" && status != "0":
+			// ExecMainStatus holds the signal when the main process was killed
+			reason = "signal " + status
+			if props["Result"] == "core-dump" {
+				reason += ", core dumped"
+			}
+		}
+		reasons[props["Id"]] = reason
+	}
+	return reasons
+}
@@ -0,0 +1,54 @@
+//go:build linux
+
+package collector
+
+import "testing"
+
+const systemctlServiceUnits = `  ssh.service              loaded    active   running OpenBSD Secure Shell server
+● backup.service           loaded    failed   failed  Nightly backup
+  cron.service             loaded    active   running Regular background program processing daemon
+  systemd-fsck@dev.service loaded    inactive dead    File System Check on /dev
+● nfs.service              not-found inactive dead    nfs.service
+`
+
+func TestParseServiceUnits(t *testing.T) {
+	data := parseServiceUnits(systemctlServiceUnits)
+	if data.Manager != "systemd" || data.Total != 4 || data.Running != 2 {
+		t.Errorf("data = %+v, expected 4 systemd services with 2 running, without the missing one", data)
+	}
+	if len(data.FailedServices) != 1 {
+		t.Fatalf("FailedServices = %+v, expected the backup service", data.FailedServices)
+	}
+	if backup := data.FailedServices[0]; backup.Name != "backup.service" || backup.Description != "Nightly backup" || backup.State != "failed" {
+		t.Errorf("failed service = %+v, expected backup.service", backup)
+	}
+}
+
+func TestParseServiceResults(t *testing.T) {
+	output := `Id=backup.service
+Result=exit-code
+ExecMainCode=1
+ExecMainStatus=2
+
+Id=worker.service
+Result=core-dump
+ExecMainCode=3
+ExecMainStatus=11
+
+Id=slow.service
+Result=timeout
+ExecMainCode=0
+ExecMainStatus=0
+`
+	reasons := parseServiceResults(output)
+	expected := map[string]string{
+		"backup.service": "exit-code 2",
+		"worker.service": "signal 11, core dumped",
+		"slow.service":   "timeout",
+	}
+	for unit, reason := range expected {
+		if reasons[unit] != reason {
+			t.Errorf("reason of %s = %q, expected %q", unit, reasons[unit], reason)
+		}
+	}
+}
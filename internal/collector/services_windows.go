@@ -0,0 +1,62 @@
+//go:build windows
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// errorServiceSpecificError is the exit code of services that report their own error code in
+// ServiceSpecificExitCode instead
+const errorServiceSpecificError = 1066
+
+// win32ServiceState is a service with its state and how it last stopped
+type win32ServiceState struct {
+	Name                    string
+	DisplayName             string
+	State                   string // Running, Stopped, Paused, Start Pending, ...
+	StartMode               string // Auto, Manual, Disabled, Boot or System
+	ExitCode                uint32
+	ServiceSpecificExitCode uint32
+}
+
+// collectServicesPlatform counts the services of the service control manager
+func collectServicesPlatform() *types.ServiceData {
+	var services []win32ServiceState
+	query := "SELECT Name, DisplayName, State, StartMode, ExitCode, ServiceSpecificExitCode FROM Win32_Service"
+	if err := wmi.Query(query, &services); err != nil {
+		return nil
+	}
+	return summarizeWindowsServices(services)
+}
+
+// summarizeWindowsServices counts the services, taking automatic services that stopped with
+// an error for failed, as collectFailedServicesPlatform does; services that stop cleanly after
+// their work is done (trigger-start ones, for instance) exit with 0
+func summarizeWindowsServices(services []win32ServiceState) *types.ServiceData {
+	data := &types.ServiceData{Manager: "scm", Total: len(services)}
+	for _, service := range services {
+		if service.State == "Running" {
+			data.Running++
+		}
+		if service.StartMode != "Auto" || service.State != "Stopped" || service.ExitCode == 0 {
+			continue
+		}
+		status := types.ServiceStatus{
+			Name:   service.Name,
+			State:  service.State,
+			Reason: fmt.Sprintf("exit code %d", service.ExitCode),
+		}
+		if service.ExitCode == errorServiceSpecificError {
+			status.Reason = fmt.Sprintf("service-specific error %d", service.ServiceSpecificExitCode)
+		}
+		if service.DisplayName != service.Name {
+			status.Description = service.DisplayName
+		}
+		data.FailedServices = append(data.FailedServices, status)
+	}
+	return data
+}
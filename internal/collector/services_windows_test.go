@@ -0,0 +1,24 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestSummarizeWindowsServices(t *testing.T) {
+	data := summarizeWindowsServices([]win32ServiceState{
+		{Name: "Dnscache", DisplayName: "DNS Client", State: "Running", StartMode: "Auto"},
+		{Name: "sppsvc", DisplayName: "Software Protection", State: "Stopped", StartMode: "Auto"},
+		{Name: "VendorSvc", DisplayName: "Vendor Updater", State: "Stopped", StartMode: "Auto", ExitCode: 1067},
+		{Name: "AppSvc", DisplayName: "AppSvc", State: "Stopped", StartMode: "Auto", ExitCode: 1066, ServiceSpecificExitCode: 5},
+		{Name: "Fax", DisplayName: "Fax", State: "Stopped", StartMode: "Manual", ExitCode: 1077},
+	})
+	if data.Manager != "scm" || data.Total != 5 || data.Running != 1 || len(data.FailedServices) != 2 {
+		t.Fatalf("data = %+v, expected 5 services with 1 running and 2 failed", data)
+	}
+	if vendor := data.FailedServices[0]; vendor.Name != "VendorSvc" || vendor.Description != "Vendor Updater" || vendor.Reason != "exit code 1067" {
+		t.Errorf("failed service = %+v, expected VendorSvc with exit code 1067", vendor)
+	}
+	if app := data.FailedServices[1]; app.Description != "" || app.Reason != "service-specific error 5" {
+		t.Errorf("failed service = %+v, expected AppSvc with its own error code", app)
+	}
+}
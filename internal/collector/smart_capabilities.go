@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smartctlCapabilities mirrors the capability-related sections of smartctl JSON output
+type smartctlCapabilities struct {
+	Device struct {
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	SmartSupport struct {
+		Available bool `json:"available"`
+		Enabled   bool `json:"enabled"`
+	} `json:"smart_support"`
+	AtaSmartData struct {
+		Capabilities struct {
+			SelfTestsSupported          bool `json:"self_tests_supported"`
+			ConveyanceSelfTestSupported bool `json:"conveyance_self_test_supported"`
+			SelectiveSelfTestSupported  bool `json:"selective_self_test_supported"`
+			ErrorLoggingSupported       bool `json:"error_logging_supported"`
+		} `json:"capabilities"`
+	} `json:"ata_smart_data"`
+	AtaSCTCapabilities *struct {
+		ErrorRecoveryControlSupported bool `json:"error_recovery_control_supported"`
+	} `json:"ata_sct_capabilities"`
+	Trim struct {
+		Supported bool `json:"supported"`
+	} `json:"trim"`
+	NvmeOptionalAdminCommands struct {
+		SelfTest bool `json:"self_test"`
+	} `json:"nvme_optional_admin_commands"`
+	NvmeOptionalNvmCommands struct {
+		DatasetManagement bool `json:"dataset_management"`
+	} `json:"nvme_optional_nvm_commands"`
+}
+
+// CollectSMARTCapabilities probes a device for the SMART features it supports
+func CollectSMARTCapabilities(device string) (*types.SMARTCapabilities, error) {
+	if _, err := sandbox.LookPath("smartctl"); err != nil {
+		return nil, fmt.Errorf("smartctl not found (install smartmontools)")
+	}
+
+	// -i reports identity and TRIM, -c reports SMART and SCT capabilities
+	cmd := sandbox.Command("smartctl", "-i", "-c", "-j", device)
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		// smartctl returns non-zero for disks with warnings, so only fail without output
+		return nil, fmt.Errorf("failed to query %s: %w", device, err)
+	}
+
+	caps, err := parseSMARTCapabilities(output)
+	if err != nil {
+		return nil, err
+	}
+	caps.Device = device
+
+	// Sanitize is not reported by smartctl; hdparm lists it for ATA drives on Linux
+	if caps.Protocol == "ATA" {
+		if _, err := sandbox.LookPath("hdparm"); err == nil {
+			if out, err := sandbox.Command("hdparm", "-I", device).Output(); err == nil {
+				caps.Sanitize = parseHdparmSanitize(string(out))
+			}
+		}
+	}
+
+	return caps, nil
+}
+
+// parseSMARTCapabilities converts smartctl JSON output into SMARTCapabilities
+func parseSMARTCapabilities(output []byte) (*types.SMARTCapabilities, error) {
+	var raw smartctlCapabilities
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse smartctl output: %w", err)
+	}
+
+	caps := &types.SMARTCapabilities{
+		Protocol: raw.Device.Protocol,
+	}
+
+	switch raw.Device.Protocol {
+	case "NVMe":
+		// The SMART/health log and error log are mandatory for NVMe controllers
+		caps.SMARTSupported = true
+		caps.SMARTEnabled = true
+		caps.ErrorLogging = true
+		caps.SelfTest = raw.NvmeOptionalAdminCommands.SelfTest
+		caps.TRIM = raw.NvmeOptionalNvmCommands.DatasetManagement
+	default:
+		caps.SMARTSupported = raw.SmartSupport.Available
+		caps.SMARTEnabled = raw.SmartSupport.Enabled
+		caps.SelfTest = raw.AtaSmartData.Capabilities.SelfTestsSupported
+		caps.ConveyanceSelfTest = raw.AtaSmartData.Capabilities.ConveyanceSelfTestSupported
+		caps.SelectiveSelfTest = raw.AtaSmartData.Capabilities.SelectiveSelfTestSupported
+		caps.ErrorLogging = raw.AtaSmartData.Capabilities.ErrorLoggingSupported
+		caps.SCT = raw.AtaSCTCapabilities != nil
+		if raw.AtaSCTCapabilities != nil {
+			caps.SCTErrorRecovery = raw.AtaSCTCapabilities.ErrorRecoveryControlSupported
+		}
+		caps.TRIM = raw.Trim.Supported
+	}
+
+	return caps, nil
+}
+
+// parseHdparmSanitize reports whether hdparm -I lists the SANITIZE feature set as supported
+func parseHdparmSanitize(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		// Supported features are prefixed with '*' in the Commands/features table
+		if strings.HasPrefix(line, "*") && strings.Contains(line, "SANITIZE feature set") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseSMARTCapabilities_ATA(t *testing.T) {
+	output := []byte(`{
+		"device": {"name": "/dev/sda", "protocol": "ATA"},
+		"smart_support": {"available": true, "enabled": true},
+		"ata_smart_data": {
+			"capabilities": {
+				"self_tests_supported": true,
+				"conveyance_self_test_supported": false,
+				"selective_self_test_supported": true,
+				"error_logging_supported": true
+			}
+		},
+		"ata_sct_capabilities": {"value": 61, "error_recovery_control_supported": true},
+		"trim": {"supported": true}
+	}`)
+
+	caps, err := parseSMARTCapabilities(output)
+	if err != nil {
+		t.Fatalf("parseSMARTCapabilities failed: %v", err)
+	}
+
+	if caps.Protocol != "ATA" {
+		t.Errorf("Expected protocol ATA, got %s", caps.Protocol)
+	}
+	if !caps.SMARTSupported || !caps.SMARTEnabled {
+		t.Error("Expected SMART to be supported and enabled")
+	}
+	if !caps.SelfTest {
+		t.Error("Expected self-test support")
+	}
+	if caps.ConveyanceSelfTest {
+		t.Error("Expected no conveyance self-test support")
+	}
+	if !caps.SelectiveSelfTest {
+		t.Error("Expected selective self-test support")
+	}
+	if !caps.ErrorLogging {
+		t.Error("Expected error logging support")
+	}
+	if !caps.SCT || !caps.SCTErrorRecovery {
+		t.Error("Expected SCT and SCT ERC support")
+	}
+	if !caps.TRIM {
+		t.Error("Expected TRIM support")
+	}
+}
+
+func TestParseSMARTCapabilities_ATANoSCT(t *testing.T) {
+	output := []byte(`{
+		"device": {"protocol": "ATA"},
+		"smart_support": {"available": true, "enabled": false}
+	}`)
+
+	caps, err := parseSMARTCapabilities(output)
+	if err != nil {
+		t.Fatalf("parseSMARTCapabilities failed: %v", err)
+	}
+
+	if caps.SMARTEnabled {
+		t.Error("Expected SMART to be disabled")
+	}
+	if caps.SCT || caps.SCTErrorRecovery {
+		t.Error("Expected no SCT support when section is absent")
+	}
+	if caps.TRIM {
+		t.Error("Expected no TRIM support when section is absent")
+	}
+}
+
+func TestParseSMARTCapabilities_NVMe(t *testing.T) {
+	output := []byte(`{
+		"device": {"name": "/dev/nvme0", "protocol": "NVMe"},
+		"nvme_optional_admin_commands": {"self_test": true, "format_nvm": true},
+		"nvme_optional_nvm_commands": {"dataset_management": true}
+	}`)
+
+	caps, err := parseSMARTCapabilities(output)
+	if err != nil {
+		t.Fatalf("parseSMARTCapabilities failed: %v", err)
+	}
+
+	if !caps.SMARTSupported || !caps.SMARTEnabled || !caps.ErrorLogging {
+		t.Error("NVMe devices should always report SMART and error logging")
+	}
+	if !caps.SelfTest {
+		t.Error("Expected self-test support")
+	}
+	if !caps.TRIM {
+		t.Error("Expected TRIM support from dataset management")
+	}
+	if caps.SCT {
+		t.Error("SCT is ATA-only")
+	}
+}
+
+func TestParseSMARTCapabilities_InvalidJSON(t *testing.T) {
+	if _, err := parseSMARTCapabilities([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestParseHdparmSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected bool
+	}{
+		{
+			name: "supported",
+			output: `Commands/features:
+	Enabled	Supported:
+	   *	SMART feature set
+	   *	SANITIZE feature set
+	   *	CRYPTO_SCRAMBLE_EXT command`,
+			expected: true,
+		},
+		{
+			name: "listed but not supported",
+			output: `Commands/features:
+	Enabled	Supported:
+	   *	SMART feature set
+	    	SANITIZE feature set`,
+			expected: false,
+		},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseHdparmSanitize(tt.output); got != tt.expected {
+				t.Errorf("parseHdparmSanitize() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
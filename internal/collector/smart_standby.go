@@ -0,0 +1,21 @@
+package collector
+
+import "regexp"
+
+// standbyPattern matches smartctl's report that -n standby left the drive alone,
+// e.g. "Device is in STANDBY mode, exit(2)" or "Device is in SLEEP mode, exit(2)"
+var standbyPattern = regexp.MustCompile(`Device is in (STANDBY|SLEEP)\S* mode`)
+
+// smartctlArgs returns the arguments for a full JSON SMART read of device
+// With skipStandby, smartctl checks the power mode first and exits without spinning up the drive
+func smartctlArgs(device string, skipStandby bool) []string {
+	if skipStandby {
+		return []string{"-n", "standby", "-a", "-j", device}
+	}
+	return []string{"-a", "-j", device}
+}
+
+// isStandbyOutput reports whether smartctl skipped the drive because it was spun down
+func isStandbyOutput(output []byte) bool {
+	return standbyPattern.Match(output)
+}
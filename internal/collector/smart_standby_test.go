@@ -0,0 +1,38 @@
+package collector
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSmartctlArgs(t *testing.T) {
+	if got, expected := smartctlArgs("/dev/sda", false), []string{"-a", "-j", "/dev/sda"}; !slices.Equal(got, expected) {
+		t.Errorf("smartctlArgs() = %v, expected %v", got, expected)
+	}
+	if got, expected := smartctlArgs("/dev/sda", true), []string{"-n", "standby", "-a", "-j", "/dev/sda"}; !slices.Equal(got, expected) {
+		t.Errorf("smartctlArgs() = %v, expected %v", got, expected)
+	}
+}
+
+func TestIsStandbyOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected bool
+	}{
+		{"standby json", `{"smartctl": {"messages": [{"string": "Device is in STANDBY mode, exit(2)", "severity": "information"}], "exit_status": 2}}`, true},
+		{"standby text", "Device is in STANDBY mode, exit(2)\n", true},
+		{"sleep", "Device is in SLEEP mode, exit(2)\n", true},
+		{"standby_y", "Device is in STANDBY_Y mode, exit(2)\n", true},
+		{"active drive", `{"device": {"name": "/dev/sda"}, "power_mode": "ACTIVE or IDLE", "smart_status": {"passed": true}}`, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isStandbyOutput([]byte(tt.output)); got != tt.expected {
+				t.Errorf("isStandbyOutput() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 	"github.com/yusufpapurcu/wmi"
 )
 
@@ -34,14 +35,15 @@ type MSStorageDriver_FailurePredictThresholds struct {
 }
 
 // collectSMARTPlatform implements Windows-specific SMART data collection
-func collectSMARTPlatform() []types.SMARTInfo {
+// WMI reports the driver's view of the drives, so there is no standby check
+func collectSMARTPlatform(_ bool) ([]types.SMARTInfo, []string) {
 	smartData := make([]types.SMARTInfo, 0)
 
 	var drives []Win32DiskDrive
 	query := "SELECT * FROM Win32_DiskDrive"
 	err := wmi.Query(query, &drives)
 	if err != nil {
-		return smartData
+		return smartData, nil
 	}
 
 	// Process each drive found
@@ -146,7 +148,7 @@ func collectSMARTPlatform() []types.SMARTInfo {
 		smartData = append(smartData, info)
 	}
 
-	return smartData
+	return smartData, nil
 }
 
 // checkDiskHealth queries WMI for disk failure prediction
@@ -443,12 +445,8 @@ func formatRawValue(attributeID uint8, rawValue uint64) string {
 		}
 		return "0"
 	case 241, 242: // Total LBAs Written/Read
-		// Convert to approximate GB (512 byte sectors)
-		gb := float64(rawValue) * 512.0 / 1000000000.0
-		if gb > 1000 {
-			return fmt.Sprintf("%.2f TB", gb/1000.0)
-		}
-		return fmt.Sprintf("%.2f GB", gb)
+		// Approximate, assuming 512 byte sectors
+		return utils.FormatBytes(rawValue * 512)
 	case 231: // SSD Life Left
 		return fmt.Sprintf("%d%%", rawValue)
 	case 233: // Media Wearout Indicator
@@ -0,0 +1,251 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smartctlOutput represents the JSON output of `smartctl -a -j`
+type smartctlOutput struct {
+	Device struct {
+		Name     string `json:"name"`
+		InfoName string `json:"info_name"`
+		Type     string `json:"type"`
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	ModelFamily     string `json:"model_family"`
+	ModelName       string `json:"model_name"`
+	SerialNumber    string `json:"serial_number"`
+	FirmwareVersion string `json:"firmware_version"`
+	UserCapacity    struct {
+		Blocks uint64 `json:"blocks"`
+		Bytes  uint64 `json:"bytes"`
+	} `json:"user_capacity"`
+	RotationRate int `json:"rotation_rate"`
+	FormFactor   struct {
+		Name string `json:"name"`
+	} `json:"form_factor"`
+	SmartStatus struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount uint64 `json:"power_cycle_count"`
+	AtaSmartAttrs   struct {
+		Table []smartctlAttribute `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NvmeSmartLog *smartctlNvmeLog `json:"nvme_smart_health_information_log"`
+}
+
+type smartctlAttribute struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Value      int    `json:"value"`
+	Worst      int    `json:"worst"`
+	Threshold  int    `json:"thresh"`
+	WhenFailed string `json:"when_failed"` // "", "now" or "past"
+	Flags      struct {
+		Value         int  `json:"value"`
+		Prefailure    bool `json:"prefailure"`
+		UpdatedOnline bool `json:"updated_online"`
+	} `json:"flags"`
+	Raw struct {
+		Value  int64  `json:"value"`
+		String string `json:"string"`
+	} `json:"raw"`
+}
+
+type smartctlNvmeLog struct {
+	CriticalWarning  int    `json:"critical_warning"`
+	Temperature      int    `json:"temperature"`
+	AvailableSpare   int    `json:"available_spare"`
+	PercentageUsed   int    `json:"percentage_used"`
+	DataUnitsRead    uint64 `json:"data_units_read"`
+	DataUnitsWritten uint64 `json:"data_units_written"`
+	PowerCycles      uint64 `json:"power_cycles"`
+	PowerOnHours     uint64 `json:"power_on_hours"`
+	MediaErrors      uint64 `json:"media_errors"`
+}
+
+// criticalSMARTAttributes are counters that indicate media damage when non-zero
+var criticalSMARTAttributes = map[string]bool{
+	"Reallocated_Sector_Ct":  true,
+	"Current_Pending_Sector": true,
+	"Offline_Uncorrectable":  true,
+	"Reported_Uncorrect":     true,
+}
+
+// ParseSmartctlJSON converts `smartctl -a -j` output for one device into SMARTInfo
+func ParseSmartctlJSON(device string, data []byte) (*types.SMARTInfo, error) {
+	var smartOutput smartctlOutput
+	if err := json.Unmarshal(data, &smartOutput); err != nil {
+		return nil, fmt.Errorf("failed to parse smartctl output: %w", err)
+	}
+
+	info := &types.SMARTInfo{
+		Device:          device,
+		ModelFamily:     smartOutput.ModelFamily,
+		DeviceModel:     smartOutput.ModelName,
+		Serial:          smartOutput.SerialNumber,
+		FirmwareVersion: smartOutput.FirmwareVersion,
+		Capacity:        smartOutput.UserCapacity.Bytes,
+		Healthy:         smartOutput.SmartStatus.Passed,
+		RotationRate:    uint32(smartOutput.RotationRate),
+		FormFactor:      smartOutput.FormFactor.Name,
+		PowerCycleCount: smartOutput.PowerCycleCount,
+		Attributes:      make(map[string]string),
+		DetailedAttribs: make([]types.SMARTAttribute, 0),
+	}
+
+	// Extract temperature
+	if smartOutput.Temperature.Current > 0 {
+		info.Temperature = smartOutput.Temperature.Current
+	}
+
+	// Extract power-on hours
+	if smartOutput.PowerOnTime.Hours > 0 {
+		info.PowerOnHours = smartOutput.PowerOnTime.Hours
+	}
+
+	failingAttrs := make([]string, 0)
+	warningAttrs := make([]string, 0)
+
+	// For NVMe devices, use NVMe-specific data
+	nvme := smartOutput.NvmeSmartLog
+	if nvme != nil {
+		if nvme.Temperature > 0 {
+			info.Temperature = nvme.Temperature
+		}
+		if nvme.PowerOnHours > 0 {
+			info.PowerOnHours = nvme.PowerOnHours
+		}
+		if nvme.PowerCycles > 0 {
+			info.PowerCycleCount = nvme.PowerCycles
+		}
+		info.Attributes["Data_Units_Read"] = fmt.Sprintf("%d", nvme.DataUnitsRead)
+		info.Attributes["Data_Units_Written"] = fmt.Sprintf("%d", nvme.DataUnitsWritten)
+		info.Attributes["Media_Errors"] = fmt.Sprintf("%d", nvme.MediaErrors)
+
+		if nvme.CriticalWarning != 0 {
+			failingAttrs = append(failingAttrs, fmt.Sprintf("Critical_Warning = 0x%02x", nvme.CriticalWarning))
+		}
+		if nvme.MediaErrors > 0 {
+			warningAttrs = append(warningAttrs, fmt.Sprintf("Media_Errors = %d", nvme.MediaErrors))
+		}
+	}
+
+	// Parse ATA SMART attributes with detailed information
+	for _, attr := range smartOutput.AtaSmartAttrs.Table {
+		info.Attributes[attr.Name] = fmt.Sprintf("%d", attr.Raw.Value)
+		info.Attributes[attr.Name+"_Current"] = fmt.Sprintf("%d", attr.Value)
+		info.Attributes[attr.Name+"_Worst"] = fmt.Sprintf("%d", attr.Worst)
+		info.Attributes[attr.Name+"_Threshold"] = fmt.Sprintf("%d", attr.Threshold)
+
+		detailedAttr := types.SMARTAttribute{
+			ID:         uint8(attr.ID),
+			Name:       attr.Name,
+			Flag:       uint16(attr.Flags.Value),
+			Value:      uint8(attr.Value),
+			Worst:      uint8(attr.Worst),
+			Threshold:  uint8(attr.Threshold),
+			RawValue:   uint64(attr.Raw.Value),
+			RawString:  attr.Raw.String,
+			WhenFailed: smartctlWhenFailed(attr.WhenFailed),
+			Type:       "Old_age",
+			Updated:    "Offline",
+		}
+		if attr.Flags.Prefailure {
+			detailedAttr.Type = "Pre-fail"
+		}
+		if attr.Flags.UpdatedOnline {
+			detailedAttr.Updated = "Always"
+		}
+		info.DetailedAttribs = append(info.DetailedAttribs, detailedAttr)
+
+		// Check for failures
+		switch detailedAttr.WhenFailed {
+		case "FAILING_NOW":
+			info.Healthy = false
+			failingAttrs = append(failingAttrs, fmt.Sprintf("%s (Value: %d, Threshold: %d)",
+				attr.Name, attr.Value, attr.Threshold))
+		case "In_the_past":
+			info.Healthy = false
+		}
+
+		// Check for critical attributes with non-zero values
+		if criticalSMARTAttributes[attr.Name] && attr.Raw.Value > 0 {
+			warningAttrs = append(warningAttrs, fmt.Sprintf("%s = %d", attr.Name, attr.Raw.Value))
+		}
+
+		// Extract common values
+		switch attr.ID {
+		case 9: // Power-on hours
+			info.PowerOnHours = uint64(attr.Raw.Value)
+		case 12: // Power cycle count
+			info.PowerCycleCount = uint64(attr.Raw.Value)
+		case 194: // Temperature (the low byte, the rest holds min/max)
+			if info.Temperature == 0 {
+				info.Temperature = int(attr.Raw.Value & 0xff)
+			}
+		}
+	}
+
+	// Create health assessment
+	if nvme != nil || len(failingAttrs) > 0 || len(warningAttrs) > 0 || !smartOutput.SmartStatus.Passed {
+		info.HealthAssessment = &types.SMARTHealthStatus{
+			Passed:            smartOutput.SmartStatus.Passed,
+			FailingAttributes: failingAttrs,
+			WarningAttributes: warningAttrs,
+		}
+
+		if nvme != nil {
+			info.HealthAssessment.PercentUsed = float64(nvme.PercentageUsed)
+			info.HealthAssessment.AvailableSpare = float64(nvme.AvailableSpare)
+			if nvme.CriticalWarning != 0 {
+				info.HealthAssessment.CriticalWarning = fmt.Sprintf("0x%02x", nvme.CriticalWarning)
+			}
+		}
+
+		if len(failingAttrs) > 0 || !smartOutput.SmartStatus.Passed {
+			info.HealthAssessment.OverallAssessment = "FAIL"
+		} else if len(warningAttrs) > 0 {
+			info.HealthAssessment.OverallAssessment = "WARN"
+		} else {
+			info.HealthAssessment.OverallAssessment = "PASS"
+		}
+
+		// Temperature assessment
+		if info.Temperature > 70 {
+			info.HealthAssessment.TemperatureStatus = "CRITICAL"
+		} else if info.Temperature > 60 {
+			info.HealthAssessment.TemperatureStatus = "HIGH"
+		} else if info.Temperature > 45 {
+			info.HealthAssessment.TemperatureStatus = "WARM"
+		} else if info.Temperature > 0 {
+			info.HealthAssessment.TemperatureStatus = "NORMAL"
+		}
+	}
+
+	return info, nil
+}
+
+// smartctlWhenFailed maps smartctl's JSON when_failed values onto the
+// attribute table spelling the analyzer understands
+func smartctlWhenFailed(value string) string {
+	switch value {
+	case "now", "FAILING_NOW":
+		return "FAILING_NOW"
+	case "past", "In_the_past":
+		return "In_the_past"
+	case "":
+		return "-"
+	default:
+		return value
+	}
+}
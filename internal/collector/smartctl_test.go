@@ -0,0 +1,123 @@
+package collector
+
+import "testing"
+
+func TestParseSmartctlJSON_ATA(t *testing.T) {
+	data := []byte(`{
+		"model_name": "TEST HDD",
+		"rotation_rate": 7200,
+		"smart_status": {"passed": true},
+		"temperature": {"current": 35},
+		"ata_smart_attributes": {"table": [
+			{"id": 5, "name": "Reallocated_Sector_Ct", "value": 5, "worst": 5, "thresh": 10, "when_failed": "now",
+			 "flags": {"value": 51, "prefailure": true, "updated_online": true},
+			 "raw": {"value": 4000, "string": "4000"}},
+			{"id": 194, "name": "Temperature_Celsius", "value": 65, "worst": 48, "thresh": 0, "when_failed": "",
+			 "flags": {"value": 34, "prefailure": false, "updated_online": true},
+			 "raw": {"value": 188978561059, "string": "35 (Min/Max 20/44)"}},
+			{"id": 198, "name": "Offline_Uncorrectable", "value": 100, "worst": 99, "thresh": 0, "when_failed": "past",
+			 "flags": {"value": 16, "prefailure": false, "updated_online": false},
+			 "raw": {"value": 2, "string": "2"}}
+		]}
+	}`)
+
+	info, err := ParseSmartctlJSON("/dev/sda", data)
+	if err != nil {
+		t.Fatalf("ParseSmartctlJSON failed: %v", err)
+	}
+
+	if info.Healthy {
+		t.Error("Healthy = true, expected false with a failing attribute")
+	}
+	if info.RotationRate != 7200 {
+		t.Errorf("RotationRate = %d, expected 7200", info.RotationRate)
+	}
+	if info.Temperature != 35 {
+		t.Errorf("Temperature = %d, expected 35", info.Temperature)
+	}
+	if len(info.DetailedAttribs) != 3 {
+		t.Fatalf("DetailedAttribs = %d, expected 3", len(info.DetailedAttribs))
+	}
+
+	tests := []struct {
+		index      int
+		rawValue   uint64
+		attrType   string
+		updated    string
+		whenFailed string
+	}{
+		{0, 4000, "Pre-fail", "Always", "FAILING_NOW"},
+		{1, 188978561059, "Old_age", "Always", "-"},
+		{2, 2, "Old_age", "Offline", "In_the_past"},
+	}
+	for _, tt := range tests {
+		attr := info.DetailedAttribs[tt.index]
+		if attr.RawValue != tt.rawValue {
+			t.Errorf("%s RawValue = %d, expected %d", attr.Name, attr.RawValue, tt.rawValue)
+		}
+		if attr.Type != tt.attrType {
+			t.Errorf("%s Type = %s, expected %s", attr.Name, attr.Type, tt.attrType)
+		}
+		if attr.Updated != tt.updated {
+			t.Errorf("%s Updated = %s, expected %s", attr.Name, attr.Updated, tt.updated)
+		}
+		if attr.WhenFailed != tt.whenFailed {
+			t.Errorf("%s WhenFailed = %s, expected %s", attr.Name, attr.WhenFailed, tt.whenFailed)
+		}
+	}
+
+	if info.HealthAssessment == nil {
+		t.Fatal("HealthAssessment = nil, expected an assessment")
+	}
+	if info.HealthAssessment.OverallAssessment != "FAIL" {
+		t.Errorf("OverallAssessment = %s, expected FAIL", info.HealthAssessment.OverallAssessment)
+	}
+	if len(info.HealthAssessment.WarningAttributes) != 2 {
+		t.Errorf("WarningAttributes = %v, expected 2 entries", info.HealthAssessment.WarningAttributes)
+	}
+}
+
+func TestParseSmartctlJSON_NVMe(t *testing.T) {
+	data := []byte(`{
+		"model_name": "TEST NVME",
+		"smart_status": {"passed": false},
+		"nvme_smart_health_information_log": {
+			"critical_warning": 4, "temperature": 44, "available_spare": 3, "percentage_used": 97,
+			"data_units_read": 100, "data_units_written": 200, "power_cycles": 12,
+			"power_on_hours": 9000, "media_errors": 7
+		}
+	}`)
+
+	info, err := ParseSmartctlJSON("/dev/nvme0", data)
+	if err != nil {
+		t.Fatalf("ParseSmartctlJSON failed: %v", err)
+	}
+
+	if info.Temperature != 44 || info.PowerOnHours != 9000 || info.PowerCycleCount != 12 {
+		t.Errorf("Temperature/PowerOnHours/PowerCycleCount = %d/%d/%d, expected 44/9000/12",
+			info.Temperature, info.PowerOnHours, info.PowerCycleCount)
+	}
+	if info.Attributes["Media_Errors"] != "7" {
+		t.Errorf("Media_Errors = %s, expected 7", info.Attributes["Media_Errors"])
+	}
+
+	health := info.HealthAssessment
+	if health == nil {
+		t.Fatal("HealthAssessment = nil, expected an assessment")
+	}
+	if health.PercentUsed != 97 || health.AvailableSpare != 3 {
+		t.Errorf("PercentUsed/AvailableSpare = %.0f/%.0f, expected 97/3", health.PercentUsed, health.AvailableSpare)
+	}
+	if health.CriticalWarning != "0x04" {
+		t.Errorf("CriticalWarning = %s, expected 0x04", health.CriticalWarning)
+	}
+	if health.OverallAssessment != "FAIL" {
+		t.Errorf("OverallAssessment = %s, expected FAIL", health.OverallAssessment)
+	}
+}
+
+func TestParseSmartctlJSON_Invalid(t *testing.T) {
+	if _, err := ParseSmartctlJSON("/dev/sda", []byte("not json")); err == nil {
+		t.Error("Expected an error for invalid output")
+	}
+}
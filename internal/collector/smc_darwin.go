@@ -0,0 +1,74 @@
+//go:build darwin
+
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smcRead returns the SMC data type (e.g. "fpe2", "flt ") and bytes stored under a four
+// character key, or false when the key does not exist
+type smcRead func(key string) (dataType string, data []byte, ok bool)
+
+// smcFans reads the fans the SMC reports in FNum: the actual speed (FnAc) and the range
+// the SMC drives it in (FnMn, FnMx). Fans whose speed cannot be read are skipped
+func smcFans(read smcRead) []types.FanInfo {
+	count, ok := smcNumber(read, "FNum")
+	if !ok {
+		return nil
+	}
+
+	var fans []types.FanInfo
+	for i := 0; i < int(count); i++ {
+		rpm, ok := smcNumber(read, fmt.Sprintf("F%dAc", i))
+		if !ok {
+			continue
+		}
+		fan := types.FanInfo{
+			Name:   fmt.Sprintf("SMC/fan%d", i+1),
+			Chip:   "SMC",
+			Source: "smc",
+			RPM:    int(math.Round(rpm)),
+		}
+		if min, ok := smcNumber(read, fmt.Sprintf("F%dMn", i)); ok {
+			fan.MinRPM = int(math.Round(min))
+		}
+		if max, ok := smcNumber(read, fmt.Sprintf("F%dMx", i)); ok {
+			fan.MaxRPM = int(math.Round(max))
+		}
+		fans = append(fans, fan)
+	}
+	return fans
+}
+
+// smcNumber reads a numeric SMC key
+func smcNumber(read smcRead, key string) (float64, bool) {
+	dataType, data, ok := read(key)
+	if !ok {
+		return 0, false
+	}
+	return smcDecode(dataType, data)
+}
+
+// smcDecode converts SMC bytes of a numeric type. Intel Macs store fan speeds as fpe2,
+// unsigned 14.2 fixed point; Apple Silicon stores them as little-endian float32
+func smcDecode(dataType string, data []byte) (float64, bool) {
+	switch {
+	case dataType == "ui8 " && len(data) >= 1:
+		return float64(data[0]), true
+	case dataType == "ui16" && len(data) >= 2:
+		return float64(binary.BigEndian.Uint16(data)), true
+	case dataType == "ui32" && len(data) >= 4:
+		return float64(binary.BigEndian.Uint32(data)), true
+	case dataType == "fpe2" && len(data) >= 2:
+		return float64(binary.BigEndian.Uint16(data)) / 4, true
+	case dataType == "flt " && len(data) >= 4:
+		value := float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+		return value, !math.IsNaN(value) && !math.IsInf(value, 0)
+	}
+	return 0, false
+}
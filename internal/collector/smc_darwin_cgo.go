@@ -0,0 +1,115 @@
+//go:build darwin && cgo
+
+package collector
+
+/*
+#cgo LDFLAGS: -framework IOKit
+#include <IOKit/IOKitLib.h>
+#include <mach/mach.h>
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+
+// Layout of the AppleSMC user client's struct method argument
+typedef struct {
+	char major, minor, build, reserved[1];
+	uint16_t release;
+} smc_vers_t;
+
+typedef struct {
+	uint16_t version, length;
+	uint32_t cpuPLimit, gpuPLimit, memPLimit;
+} smc_plimit_t;
+
+typedef struct {
+	uint32_t dataSize;
+	uint32_t dataType;
+	char dataAttributes;
+} smc_keyinfo_t;
+
+typedef struct {
+	uint32_t key;
+	smc_vers_t vers;
+	smc_plimit_t pLimitData;
+	smc_keyinfo_t keyInfo;
+	char result, status, data8;
+	uint32_t data32;
+	unsigned char bytes[32];
+} smc_keydata_t;
+
+enum {
+	smc_kernel_index = 2,
+	smc_cmd_read_bytes = 5,
+	smc_cmd_read_keyinfo = 9,
+};
+
+static io_connect_t sysinfo_smc_open(void) {
+	io_service_t service = IOServiceGetMatchingService(MACH_PORT_NULL, IOServiceMatching("AppleSMC"));
+	if (service == IO_OBJECT_NULL) {
+		return IO_OBJECT_NULL;
+	}
+	io_connect_t conn = IO_OBJECT_NULL;
+	kern_return_t result = IOServiceOpen(service, mach_task_self(), 0, &conn);
+	IOObjectRelease(service);
+	return result == KERN_SUCCESS ? conn : IO_OBJECT_NULL;
+}
+
+static int sysinfo_smc_call(io_connect_t conn, smc_keydata_t *in, smc_keydata_t *out) {
+	size_t size = sizeof(smc_keydata_t);
+	memset(out, 0, sizeof(smc_keydata_t));
+	return IOConnectCallStructMethod(conn, smc_kernel_index, in, sizeof(smc_keydata_t), out, &size) == kIOReturnSuccess && out->result == 0;
+}
+
+static int sysinfo_smc_read(io_connect_t conn, const char *key, uint32_t *dataType, uint32_t *dataSize, unsigned char *bytes) {
+	smc_keydata_t in, out;
+	memset(&in, 0, sizeof(in));
+	in.key = ((uint32_t)key[0] << 24) | ((uint32_t)key[1] << 16) | ((uint32_t)key[2] << 8) | (uint32_t)key[3];
+	in.data8 = smc_cmd_read_keyinfo;
+	if (!sysinfo_smc_call(conn, &in, &out)) {
+		return 0;
+	}
+	*dataType = out.keyInfo.dataType;
+	*dataSize = out.keyInfo.dataSize;
+
+	in.keyInfo.dataSize = out.keyInfo.dataSize;
+	in.data8 = smc_cmd_read_bytes;
+	if (!sysinfo_smc_call(conn, &in, &out)) {
+		return 0;
+	}
+	memcpy(bytes, out.bytes, sizeof(out.bytes));
+	return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectSMCFans reads fan speeds from the AppleSMC driver, which any user may query
+func collectSMCFans() []types.FanInfo {
+	conn := C.sysinfo_smc_open()
+	if conn == 0 {
+		return nil
+	}
+	defer C.IOServiceClose(conn)
+
+	return smcFans(func(key string) (string, []byte, bool) {
+		ckey := C.CString(key)
+		defer C.free(unsafe.Pointer(ckey))
+		var dataType, dataSize C.uint32_t
+		var bytes [32]C.uchar
+		if C.sysinfo_smc_read(conn, ckey, &dataType, &dataSize, &bytes[0]) == 0 {
+			return "", nil, false
+		}
+		size := int(dataSize)
+		if size > len(bytes) {
+			size = len(bytes)
+		}
+		data := C.GoBytes(unsafe.Pointer(&bytes[0]), C.int(size))
+		t := uint32(dataType)
+		return string([]byte{byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t)}), data, true
+	})
+}
@@ -0,0 +1,8 @@
+//go:build darwin && !cgo
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectSMCFans reports no fans: the SMC is only reachable through IOKit, which needs cgo
+func collectSMCFans() []types.FanInfo { return nil }
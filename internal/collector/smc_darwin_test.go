@@ -0,0 +1,71 @@
+//go:build darwin
+
+package collector
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestSMCFans(t *testing.T) {
+	flt := func(v float32) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, math.Float32bits(v))
+		return b
+	}
+	keys := map[string]struct {
+		dataType string
+		data     []byte
+	}{
+		"FNum": {"ui8 ", []byte{3}},
+		// Intel: fpe2, 1200 RPM is 4800 in 14.2 fixed point
+		"F0Ac": {"fpe2", []byte{0x12, 0xc0}},
+		"F0Mn": {"fpe2", []byte{0x0f, 0xa0}},
+		"F0Mx": {"fpe2", []byte{0x5d, 0xc0}},
+		// Apple Silicon: float32, fan stopped
+		"F1Ac": {"flt ", flt(0)},
+		"F1Mn": {"flt ", flt(1200)},
+		"F1Mx": {"flt ", flt(4900.4)},
+	}
+	read := func(key string) (string, []byte, bool) {
+		value, ok := keys[key]
+		return value.dataType, value.data, ok
+	}
+
+	fans := smcFans(read)
+	if len(fans) != 2 {
+		t.Fatalf("smcFans() = %+v, expected the two fans with a readable speed", fans)
+	}
+	if f := fans[0]; f.Name != "SMC/fan1" || f.Chip != "SMC" || f.Source != "smc" || f.RPM != 1200 || f.MinRPM != 1000 || f.MaxRPM != 6000 {
+		t.Errorf("fans[0] = %+v", f)
+	}
+	if f := fans[1]; f.Name != "SMC/fan2" || f.RPM != 0 || f.MinRPM != 1200 || f.MaxRPM != 4900 {
+		t.Errorf("fans[1] = %+v", f)
+	}
+
+	if fans := smcFans(func(string) (string, []byte, bool) { return "", nil, false }); fans != nil {
+		t.Errorf("smcFans() without FNum = %+v, expected nil", fans)
+	}
+}
+
+func TestSMCDecode(t *testing.T) {
+	tests := []struct {
+		dataType string
+		data     []byte
+		want     float64
+		ok       bool
+	}{
+		{"ui8 ", []byte{2}, 2, true},
+		{"ui16", []byte{0x01, 0x00}, 256, true},
+		{"fpe2", []byte{0x00, 0x0a}, 2.5, true},
+		{"fpe2", []byte{0x00}, 0, false},
+		{"sp78", []byte{0x2d, 0x00}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := smcDecode(tt.dataType, tt.data)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("smcDecode(%q, %v) = %v, %v; expected %v, %v", tt.dataType, tt.data, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package collector
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// StableTimestamp replaces the collection time in --stable reports unless another time is given
+var StableTimestamp = time.Unix(0, 0).UTC()
+
+// Stabilize makes a report deterministic so repeated runs can be diffed and checksummed
+// Lists the OS returns in arbitrary order are sorted by name, device or serial; ranked
+// process lists keep their ranking and break ties by name and PID. Lists whose order carries
+// meaning keep it: per-CPU usage, SMART logs and history, health components, recommendations
+// and collection errors. Map keys are already sorted by the JSON encoder and the text formatters.
+func Stabilize(info *types.SystemInfo, timestamp time.Time) {
+	info.Timestamp = timestamp
+
+	if info.System != nil {
+		sort.Strings(info.System.FailedServices)
+	}
+
+	if info.CPU != nil {
+		stabilizeCPU(info.CPU)
+	}
+
+	if info.Memory != nil {
+		sort.SliceStable(info.Memory.Modules, func(i, j int) bool {
+			a, b := info.Memory.Modules[i], info.Memory.Modules[j]
+			if a.Locator != b.Locator {
+				return a.Locator < b.Locator
+			}
+			return a.SerialNumber < b.SerialNumber
+		})
+	}
+
+	if info.Disk != nil {
+		stabilizeDisk(info.Disk)
+	}
+
+	if info.Network != nil {
+		sort.SliceStable(info.Network.Interfaces, func(i, j int) bool {
+			return info.Network.Interfaces[i].Name < info.Network.Interfaces[j].Name
+		})
+		for i := range info.Network.Interfaces {
+			sort.Strings(info.Network.Interfaces[i].Addresses)
+			sort.Strings(info.Network.Interfaces[i].Flags)
+		}
+	}
+
+	if info.Processes != nil {
+		stabilizeProcesses(info.Processes)
+	}
+
+	if info.GPU != nil {
+		sort.SliceStable(info.GPU.GPUs, func(i, j int) bool {
+			return info.GPU.GPUs[i].Index < info.GPU.GPUs[j].Index
+		})
+		for _, gpu := range info.GPU.GPUs {
+			sort.SliceStable(gpu.Partitions, func(i, j int) bool {
+				a, b := gpu.Partitions[i], gpu.Partitions[j]
+				if a.Kind != b.Kind {
+					return a.Kind < b.Kind
+				}
+				return a.Index < b.Index
+			})
+			sort.SliceStable(gpu.Processes, func(i, j int) bool {
+				return gpu.Processes[i].PID < gpu.Processes[j].PID
+			})
+		}
+		sort.SliceStable(info.GPU.DriverIssues, func(i, j int) bool {
+			a, b := info.GPU.DriverIssues[i], info.GPU.DriverIssues[j]
+			if a.GPUIndex != b.GPUIndex {
+				return a.GPUIndex < b.GPUIndex
+			}
+			return a.Code < b.Code
+		})
+	}
+
+	if info.Battery != nil {
+		sort.SliceStable(info.Battery.Batteries, func(i, j int) bool {
+			return info.Battery.Batteries[i].Name < info.Battery.Batteries[j].Name
+		})
+		sort.SliceStable(info.Battery.UPSDevices, func(i, j int) bool {
+			return info.Battery.UPSDevices[i].Name < info.Battery.UPSDevices[j].Name
+		})
+	}
+
+	if info.Security != nil && info.Security.TrustStore != nil {
+		store := info.Security.TrustStore
+		sort.SliceStable(store.ExpiringSoon, func(i, j int) bool {
+			a, b := store.ExpiringSoon[i], store.ExpiringSoon[j]
+			if !a.NotAfter.Equal(b.NotAfter) {
+				return a.NotAfter.Before(b.NotAfter)
+			}
+			return a.SHA256 < b.SHA256
+		})
+		sort.SliceStable(store.Local, func(i, j int) bool {
+			a, b := store.Local[i], store.Local[j]
+			if a.Subject != b.Subject {
+				return a.Subject < b.Subject
+			}
+			return a.SHA256 < b.SHA256
+		})
+	}
+
+	stabilizeDevices(info)
+
+	if info.Drivers != nil {
+		sort.SliceStable(info.Drivers.Drivers, func(i, j int) bool {
+			return info.Drivers.Drivers[i].Name < info.Drivers.Drivers[j].Name
+		})
+		for i := range info.Drivers.Drivers {
+			sort.Strings(info.Drivers.Drivers[i].UsedBy)
+		}
+		sort.Strings(info.Drivers.Tainted)
+	}
+
+	if info.Services != nil {
+		sort.SliceStable(info.Services.FailedServices, func(i, j int) bool {
+			return info.Services.FailedServices[i].Name < info.Services.FailedServices[j].Name
+		})
+	}
+
+	if info.Firewall != nil {
+		sort.SliceStable(info.Firewall.Firewalls, func(i, j int) bool {
+			return info.Firewall.Firewalls[i].Name < info.Firewall.Firewalls[j].Name
+		})
+		for _, firewall := range info.Firewall.Firewalls {
+			sort.SliceStable(firewall.Zones, func(i, j int) bool {
+				return firewall.Zones[i].Name < firewall.Zones[j].Name
+			})
+			for i := range firewall.Zones {
+				sort.Strings(firewall.Zones[i].Interfaces)
+			}
+		}
+	}
+
+	if info.Packages != nil {
+		sort.Strings(info.Packages.Managers)
+		sort.SliceStable(info.Packages.Packages, func(i, j int) bool {
+			a, b := info.Packages.Packages[i], info.Packages.Packages[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			if a.Manager != b.Manager {
+				return a.Manager < b.Manager
+			}
+			if a.Arch != b.Arch {
+				return a.Arch < b.Arch
+			}
+			return a.Version < b.Version
+		})
+	}
+
+	if info.Users != nil {
+		sort.SliceStable(info.Users.Sessions, func(i, j int) bool {
+			a, b := info.Users.Sessions[i], info.Users.Sessions[j]
+			if a.User != b.User {
+				return a.User < b.User
+			}
+			if a.Terminal != b.Terminal {
+				return a.Terminal < b.Terminal
+			}
+			return a.LoginTime.Before(b.LoginTime)
+		})
+	}
+
+	if info.Thermal != nil {
+		stabilizeThermal(info.Thermal)
+	}
+
+	if info.Sensors != nil {
+		sort.SliceStable(info.Sensors.Temperatures, func(i, j int) bool {
+			a, b := info.Sensors.Temperatures[i], info.Sensors.Temperatures[j]
+			if a.Chip != b.Chip {
+				return a.Chip < b.Chip
+			}
+			return a.Label < b.Label
+		})
+	}
+
+	if info.Integrity != nil {
+		sort.SliceStable(info.Integrity.Files, func(i, j int) bool {
+			return info.Integrity.Files[i].Path < info.Integrity.Files[j].Path
+		})
+	}
+}
+
+// UseUTC converts every time in a report to UTC
+func UseUTC(info *types.SystemInfo) {
+	info.Timestamp = info.Timestamp.UTC()
+	if info.System != nil && info.System.License != nil && info.System.License.InstallDate != nil {
+		installDate := info.System.License.InstallDate.UTC()
+		info.System.License.InstallDate = &installDate
+	}
+	if info.Disk != nil && info.Disk.Trim != nil && info.Disk.Trim.LastTrim != nil {
+		lastTrim := info.Disk.Trim.LastTrim.UTC()
+		info.Disk.Trim.LastTrim = &lastTrim
+	}
+	if info.Users != nil {
+		for i := range info.Users.Sessions {
+			info.Users.Sessions[i].LoginTime = info.Users.Sessions[i].LoginTime.UTC()
+		}
+	}
+}
+
+// stabilizeCPU sorts flags, packages by socket and core classes by class
+func stabilizeCPU(cpu *types.CPUData) {
+	sort.Strings(cpu.Flags)
+	sort.SliceStable(cpu.Packages, func(i, j int) bool {
+		a, b := cpu.Packages[i], cpu.Packages[j]
+		if a.Socket != b.Socket {
+			return a.Socket < b.Socket
+		}
+		return a.ModelName < b.ModelName
+	})
+	sort.SliceStable(cpu.CoreClasses, func(i, j int) bool {
+		return cpu.CoreClasses[i].Class < cpu.CoreClasses[j].Class
+	})
+	for i := range cpu.CoreClasses {
+		sort.Ints(cpu.CoreClasses[i].CPUs)
+	}
+}
+
+// stabilizeDevices sorts the buses' devices by address and the peripherals by name
+func stabilizeDevices(info *types.SystemInfo) {
+	if info.Accelerators != nil {
+		list := info.Accelerators.Accelerators
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Bus != list[j].Bus {
+				return list[i].Bus < list[j].Bus
+			}
+			return list[i].Address < list[j].Address
+		})
+	}
+
+	if info.PCI != nil {
+		sort.SliceStable(info.PCI.Devices, func(i, j int) bool {
+			return info.PCI.Devices[i].Address < info.PCI.Devices[j].Address
+		})
+	}
+
+	if info.USB != nil {
+		sort.SliceStable(info.USB.Devices, func(i, j int) bool {
+			return info.USB.Devices[i].Address < info.USB.Devices[j].Address
+		})
+		for i := range info.USB.Devices {
+			sort.Strings(info.USB.Devices[i].Drivers)
+		}
+	}
+
+	if info.Displays != nil {
+		sort.SliceStable(info.Displays.Displays, func(i, j int) bool {
+			return info.Displays.Displays[i].Name < info.Displays.Displays[j].Name
+		})
+	}
+
+	if info.Audio != nil {
+		sort.SliceStable(info.Audio.Devices, func(i, j int) bool {
+			a, b := info.Audio.Devices[i], info.Audio.Devices[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID < b.ID
+		})
+		for i := range info.Audio.Devices {
+			device := &info.Audio.Devices[i]
+			sort.Strings(device.Codecs)
+			sort.Strings(device.Playback)
+			sort.Strings(device.Capture)
+		}
+	}
+
+	if info.Bluetooth != nil {
+		sort.SliceStable(info.Bluetooth.Adapters, func(i, j int) bool {
+			return info.Bluetooth.Adapters[i].Name < info.Bluetooth.Adapters[j].Name
+		})
+		sort.SliceStable(info.Bluetooth.Devices, func(i, j int) bool {
+			a, b := info.Bluetooth.Devices[i], info.Bluetooth.Devices[j]
+			if a.Address != b.Address {
+				return a.Address < b.Address
+			}
+			return a.Name < b.Name
+		})
+	}
+}
+
+// stabilizeThermal sorts sensors by name, their trip points by temperature and fans by chip
+func stabilizeThermal(thermal *types.ThermalData) {
+	sort.SliceStable(thermal.Sensors, func(i, j int) bool {
+		return thermal.Sensors[i].Name < thermal.Sensors[j].Name
+	})
+	for _, sensor := range thermal.Sensors {
+		sort.SliceStable(sensor.TripPoints, func(i, j int) bool {
+			a, b := sensor.TripPoints[i], sensor.TripPoints[j]
+			if a.Temperature != b.Temperature {
+				return a.Temperature < b.Temperature
+			}
+			return a.Type < b.Type
+		})
+	}
+	sort.SliceStable(thermal.Fans, func(i, j int) bool {
+		a, b := thermal.Fans[i], thermal.Fans[j]
+		if a.Chip != b.Chip {
+			return a.Chip < b.Chip
+		}
+		return a.Name < b.Name
+	})
+	if thermal.Noise != nil {
+		sort.SliceStable(thermal.Noise.Fans, func(i, j int) bool {
+			return thermal.Noise.Fans[i].Name < thermal.Noise.Fans[j].Name
+		})
+	}
+}
+
+// stabilizeDisk sorts partitions, disks and SMART data by device and serial
+func stabilizeDisk(disk *types.DiskData) {
+	sort.SliceStable(disk.Partitions, func(i, j int) bool {
+		a, b := disk.Partitions[i], disk.Partitions[j]
+		if a.Device != b.Device {
+			return a.Device < b.Device
+		}
+		return a.MountPoint < b.MountPoint
+	})
+	sort.SliceStable(disk.PhysicalDisks, func(i, j int) bool {
+		a, b := disk.PhysicalDisks[i], disk.PhysicalDisks[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.SerialNumber < b.SerialNumber
+	})
+	sort.SliceStable(disk.IOStats, func(i, j int) bool {
+		return disk.IOStats[i].Name < disk.IOStats[j].Name
+	})
+	sort.SliceStable(disk.SMARTData, func(i, j int) bool {
+		a, b := disk.SMARTData[i], disk.SMARTData[j]
+		if a.Device != b.Device {
+			return a.Device < b.Device
+		}
+		return a.Serial < b.Serial
+	})
+
+	for i := range disk.SMARTData {
+		smart := &disk.SMARTData[i]
+		sort.SliceStable(smart.DetailedAttribs, func(a, b int) bool {
+			return smart.DetailedAttribs[a].ID < smart.DetailedAttribs[b].ID
+		})
+		if smart.HealthAssessment != nil {
+			sort.Strings(smart.HealthAssessment.FailingAttributes)
+			sort.Strings(smart.HealthAssessment.WarningAttributes)
+		}
+	}
+
+	if disk.Trim != nil {
+		sort.SliceStable(disk.Trim.Filesystems, func(i, j int) bool {
+			return disk.Trim.Filesystems[i].MountPoint < disk.Trim.Filesystems[j].MountPoint
+		})
+	}
+}
+
+// stabilizeProcesses breaks ranking ties in the top process lists by name and PID
+func stabilizeProcesses(data *types.ProcessData) {
+	rank := func(list []types.ProcessInfo, metric func(types.ProcessInfo) float64) {
+		sort.SliceStable(list, func(i, j int) bool {
+			a, b := list[i], list[j]
+			if ma, mb := metric(a), metric(b); ma != mb {
+				return ma > mb
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.PID < b.PID
+		})
+	}
+
+	rank(data.TopByMemory, func(p types.ProcessInfo) float64 { return float64(p.MemoryMB) })
+	rank(data.TopByCPU, func(p types.ProcessInfo) float64 { return p.CPUPercent })
+	rank(data.TopByDiskIO, func(p types.ProcessInfo) float64 { return float64(p.DiskReadBytes + p.DiskWriteBytes) })
+	rank(data.TopByGPU, func(p types.ProcessInfo) float64 { return p.GPUPercent })
+	rank(data.TopByOpenFiles, func(p types.ProcessInfo) float64 { return float64(p.OpenFiles) })
+
+	sort.SliceStable(data.ZombieParents, func(i, j int) bool {
+		a, b := data.ZombieParents[i], data.ZombieParents[j]
+		if a.Zombies != b.Zombies {
+			return a.Zombies > b.Zombies
+		}
+		return a.PID < b.PID
+	})
+
+	sort.SliceStable(data.Containers, func(i, j int) bool {
+		a, b := data.Containers[i], data.Containers[j]
+		if a.CPUPercent != b.CPUPercent {
+			return a.CPUPercent > b.CPUPercent
+		}
+		if a.MemoryMB != b.MemoryMB {
+			return a.MemoryMB > b.MemoryMB
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,202 @@
+package collector
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestStabilize(t *testing.T) {
+	info := &types.SystemInfo{
+		Timestamp: time.Now(),
+		CPU:       &types.CPUData{Flags: []string{"sse2", "avx", "fpu"}},
+		Memory: &types.MemoryData{Modules: []types.MemoryModule{
+			{Locator: "DIMM1"}, {Locator: "DIMM0"},
+		}},
+		Disk: &types.DiskData{
+			Partitions:    []types.PartitionInfo{{Device: "/dev/sdb1"}, {Device: "/dev/sda1"}},
+			PhysicalDisks: []types.PhysicalDisk{{Name: "sdb"}, {Name: "sda"}},
+			SMARTData: []types.SMARTInfo{{
+				Device:          "/dev/sda",
+				DetailedAttribs: []types.SMARTAttribute{{ID: 194}, {ID: 5}},
+			}},
+		},
+		Network: &types.NetworkData{Interfaces: []types.NetworkInterface{
+			{Name: "wlan0", Addresses: []string{"fe80::1/64", "192.168.1.2/24"}},
+			{Name: "eth0"},
+		}},
+		Processes: &types.ProcessData{TopByCPU: []types.ProcessInfo{
+			{PID: 30, Name: "b", CPUPercent: 1},
+			{PID: 20, Name: "a", CPUPercent: 1},
+			{PID: 10, Name: "z", CPUPercent: 5},
+		}},
+		Battery: &types.BatteryData{Batteries: []types.BatteryInfo{{Name: "BAT1"}, {Name: "BAT0"}}},
+	}
+
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	Stabilize(info, fixed)
+
+	if !info.Timestamp.Equal(fixed) {
+		t.Errorf("Timestamp = %v, expected %v", info.Timestamp, fixed)
+	}
+	if info.CPU.Flags[0] != "avx" {
+		t.Errorf("CPU.Flags[0] = %s, expected avx", info.CPU.Flags[0])
+	}
+	if info.Memory.Modules[0].Locator != "DIMM0" {
+		t.Errorf("Memory.Modules[0].Locator = %s, expected DIMM0", info.Memory.Modules[0].Locator)
+	}
+	if info.Disk.Partitions[0].Device != "/dev/sda1" {
+		t.Errorf("Disk.Partitions[0].Device = %s, expected /dev/sda1", info.Disk.Partitions[0].Device)
+	}
+	if info.Disk.PhysicalDisks[0].Name != "sda" {
+		t.Errorf("Disk.PhysicalDisks[0].Name = %s, expected sda", info.Disk.PhysicalDisks[0].Name)
+	}
+	if info.Disk.SMARTData[0].DetailedAttribs[0].ID != 5 {
+		t.Errorf("DetailedAttribs[0].ID = %d, expected 5", info.Disk.SMARTData[0].DetailedAttribs[0].ID)
+	}
+	if info.Network.Interfaces[0].Name != "eth0" {
+		t.Errorf("Network.Interfaces[0].Name = %s, expected eth0", info.Network.Interfaces[0].Name)
+	}
+	if info.Network.Interfaces[1].Addresses[0] != "192.168.1.2/24" {
+		t.Errorf("Addresses[0] = %s, expected 192.168.1.2/24", info.Network.Interfaces[1].Addresses[0])
+	}
+	if info.Battery.Batteries[0].Name != "BAT0" {
+		t.Errorf("Batteries[0].Name = %s, expected BAT0", info.Battery.Batteries[0].Name)
+	}
+
+	// Ranking is kept, ties are broken by name
+	var pids []int32
+	for _, p := range info.Processes.TopByCPU {
+		pids = append(pids, p.PID)
+	}
+	if pids[0] != 10 || pids[1] != 20 || pids[2] != 30 {
+		t.Errorf("TopByCPU PIDs = %v, expected [10 20 30]", pids)
+	}
+}
+
+// stableOrdered are the lists Stabilize leaves in collection order, as their order carries meaning
+var stableOrdered = map[string]bool{
+	"cpu.usage_percent":                   true, // Indexed by logical CPU
+	"disk.smart_data.error_log.errors":    true, // Drive's log order
+	"disk.smart_data.self_test_log.tests": true,
+	"disk.smart_data.history.points":      true, // Oldest first
+	"health.components":                   true, // Fixed order
+	"recommendations":                     true, // Most severe first
+	"errors":                              true, // Module order
+}
+
+// TestStabilizeEveryList fills every list in a report with two elements, in one order and then
+// the other, and expects Stabilize to make both reports identical. A list added to the types
+// needs a sort in Stabilize or an entry in stableOrdered
+func TestStabilizeEveryList(t *testing.T) {
+	seen := map[string]bool{}
+	forward, reversed := &types.SystemInfo{}, &types.SystemInfo{}
+	fillStableTest(reflect.ValueOf(forward).Elem(), "", 0, false, seen)
+	fillStableTest(reflect.ValueOf(reversed).Elem(), "", 0, true, seen)
+
+	for path := range stableOrdered {
+		if !seen[path] {
+			t.Errorf("stableOrdered lists %s, which is not a list in the report", path)
+		}
+	}
+
+	Stabilize(forward, StableTimestamp)
+	Stabilize(reversed, StableTimestamp)
+	a, err := json.Marshal(forward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(reversed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a) == string(b) {
+		return
+	}
+
+	// Name the lists that kept their input order
+	var diff func(path string, x, y any)
+	diff = func(path string, x, y any) {
+		switch x := x.(type) {
+		case map[string]any:
+			y, _ := y.(map[string]any)
+			for key := range x {
+				diff(joinPath(path, key), x[key], y[key])
+			}
+		case []any:
+			y, _ := y.([]any)
+			if len(x) > 0 && len(y) > 0 && !reflect.DeepEqual(x[0], y[0]) {
+				t.Errorf("%s is not sorted by Stabilize", path)
+				return
+			}
+			for i := range x {
+				if i < len(y) {
+					diff(path, x[i], y[i])
+				}
+			}
+		}
+	}
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+	diff("", x, y)
+}
+
+// fillStableTest sets every field of v, giving slices two elements whose values all differ,
+// swapped when reverse is set
+func fillStableTest(v reflect.Value, path string, variant int, reverse bool, seen map[string]bool) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Unix(int64(variant+1)*3600, 0).UTC()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			name, embedded, ok := jsonField(v.Type().Field(i))
+			if !ok {
+				continue
+			}
+			fieldPath := path
+			if !embedded {
+				fieldPath = joinPath(path, name)
+			}
+			fillStableTest(v.Field(i), fieldPath, variant, reverse, seen)
+		}
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillStableTest(v.Elem(), path, variant, reverse, seen)
+	case reflect.Slice:
+		seen[path] = true
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		first, second := 0, 1
+		if reverse && !stableOrdered[path] {
+			first, second = 1, 0
+		}
+		fillStableTest(v.Index(first), path, 0, reverse, seen)
+		fillStableTest(v.Index(second), path, 1, reverse, seen)
+	case reflect.String:
+		v.SetString(string(rune('a' + variant)))
+	case reflect.Bool:
+		v.SetBool(variant == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(variant + 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(variant + 1))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(variant + 1))
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
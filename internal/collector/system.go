@@ -2,6 +2,7 @@ package collector
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mayvqt/sysinfo/internal/types"
@@ -17,21 +18,54 @@ func CollectSystem() (*types.SystemData, error) {
 
 	uptime := formatUptime(info.Uptime)
 
+	// A container has a hostname of its own; below --host-root, use the host's
+	hostname := info.Hostname
+	if readingHost() {
+		if name, err := readString(hostfs, "/etc/hostname"); err == nil && strings.TrimSpace(name) != "" {
+			hostname = strings.TrimSpace(name)
+		}
+	}
+
 	return &types.SystemData{
-		Hostname:        info.Hostname,
-		OS:              info.OS,
-		Platform:        info.Platform,
-		PlatformFamily:  info.PlatformFamily,
-		PlatformVersion: info.PlatformVersion,
-		KernelVersion:   info.KernelVersion,
-		KernelArch:      info.KernelArch,
-		Uptime:          info.Uptime,
-		UptimeFormatted: uptime,
-		BootTime:        info.BootTime,
-		Procs:           info.Procs,
+		Hostname:         hostname,
+		OS:               info.OS,
+		Platform:         info.Platform,
+		PlatformFamily:   info.PlatformFamily,
+		PlatformVersion:  info.PlatformVersion,
+		KernelVersion:    info.KernelVersion,
+		KernelArch:       info.KernelArch,
+		Uptime:           info.Uptime,
+		UptimeFormatted:  uptime,
+		BootTime:         info.BootTime,
+		Procs:            info.Procs,
+		InstallationType: collectInstallationTypePlatform(),
+		License:          collectLicensePlatform(),
+		FailedServices:   collectFailedServicesPlatform(),
 	}, nil
 }
 
+// parseFailedUnits reads unit names from `systemctl list-units --no-legend --plain` output;
+// older systemd versions mark failed units with a leading bullet even with --plain
+func parseFailedUnits(output string) []string {
+	var units []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(line), "●* "))
+		if len(fields) > 0 {
+			units = append(units, fields[0])
+		}
+	}
+	return units
+}
+
+// BootTime returns when the system last booted
+func BootTime() (time.Time, error) {
+	bootTime, err := host.BootTime()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get boot time: %w", err)
+	}
+	return time.Unix(int64(bootTime), 0), nil
+}
+
 // formatUptime converts seconds to a human-readable format
 func formatUptime(seconds uint64) string {
 	duration := time.Duration(seconds) * time.Second
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectLicensePlatform returns nil; OS licensing only applies to Windows
+func collectLicensePlatform() *types.OSLicense {
+	return nil
+}
+
+// collectFailedServicesPlatform returns nil; launchd keeps no failed state to report
+func collectFailedServicesPlatform() []string {
+	return nil
+}
+
+// collectInstallationTypePlatform returns ""; installation types only apply to Windows
+func collectInstallationTypePlatform() string {
+	return ""
+}
@@ -0,0 +1,27 @@
+//go:build linux
+
+package collector
+
+import (
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectLicensePlatform returns nil; OS licensing only applies to Windows
+func collectLicensePlatform() *types.OSLicense {
+	return nil
+}
+
+// collectFailedServicesPlatform lists failed systemd services; nil without systemd
+func collectFailedServicesPlatform() []string {
+	out, err := sandbox.Command("systemctl", "list-units", "--type=service", "--state=failed", "--no-legend", "--plain").Output()
+	if err != nil {
+		return nil
+	}
+	return parseFailedUnits(string(out))
+}
+
+// collectInstallationTypePlatform returns ""; installation types only apply to Windows
+func collectInstallationTypePlatform() string {
+	return ""
+}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -82,3 +83,15 @@ func BenchmarkFormatUptime(b *testing.B) {
 		})
 	}
 }
+
+func TestParseFailedUnits(t *testing.T) {
+	output := "nginx.service loaded failed failed A high performance web server\n" +
+		"\u25cf cron.service loaded failed failed Regular background program processing daemon\n\n"
+	expected := []string{"nginx.service", "cron.service"}
+	if units := parseFailedUnits(output); !reflect.DeepEqual(units, expected) {
+		t.Errorf("parseFailedUnits() = %v, expected %v", units, expected)
+	}
+	if units := parseFailedUnits(""); units != nil {
+		t.Errorf("parseFailedUnits(empty) = %v, expected nil", units)
+	}
+}
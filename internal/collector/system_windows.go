@@ -0,0 +1,127 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows/registry"
+)
+
+// windowsApplicationID identifies Windows (as opposed to Office) in SoftwareLicensingProduct
+const windowsApplicationID = "55c92734-d682-4d71-983e-d6ec3f16059f"
+
+// Win32_OperatingSystem represents WMI operating system data
+type Win32_OperatingSystem struct {
+	Caption     string
+	InstallDate time.Time
+}
+
+// SoftwareLicensingProduct represents WMI license data (the same source slmgr /dli uses)
+type SoftwareLicensingProduct struct {
+	Name                 string
+	Description          string
+	LicenseStatus        uint32
+	PartialProductKey    string
+	GracePeriodRemaining uint32
+}
+
+// Win32_Service represents WMI service data
+type Win32_Service struct {
+	Name string
+}
+
+// collectFailedServicesPlatform lists automatic services that stopped with an error; services
+// that stop cleanly after their work is done (trigger-start ones, for instance) exit with 0
+func collectFailedServicesPlatform() []string {
+	var services []Win32_Service
+	if err := wmi.Query("SELECT Name FROM Win32_Service WHERE StartMode = 'Auto' AND State = 'Stopped' AND ExitCode <> 0", &services); err != nil {
+		return nil
+	}
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	return names
+}
+
+// collectInstallationTypePlatform reads the installation type Windows records at setup:
+// Client, Server, Server Core or Nano Server. Read from the registry, as minimal
+// installations may lack the WMI classes that describe them
+func collectInstallationTypePlatform() string {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer key.Close()
+	installationType, _, err := key.GetStringValue("InstallationType")
+	if err != nil {
+		return ""
+	}
+	return installationType
+}
+
+// collectLicensePlatform gathers Windows edition, activation status and install date
+func collectLicensePlatform() *types.OSLicense {
+	var osInfo []Win32_OperatingSystem
+	if err := wmi.Query("SELECT Caption, InstallDate FROM Win32_OperatingSystem", &osInfo); err != nil || len(osInfo) == 0 {
+		return nil
+	}
+
+	license := &types.OSLicense{
+		Edition: strings.TrimSpace(osInfo[0].Caption),
+		Status:  "Unknown",
+	}
+	if !osInfo[0].InstallDate.IsZero() {
+		installDate := osInfo[0].InstallDate
+		license.InstallDate = &installDate
+	}
+
+	// Only the installed key has a PartialProductKey; other rows are inactive SKUs
+	var products []SoftwareLicensingProduct
+	query := "SELECT Name, Description, LicenseStatus, PartialProductKey, GracePeriodRemaining FROM SoftwareLicensingProduct " +
+		"WHERE ApplicationID = '" + windowsApplicationID + "' AND PartialProductKey IS NOT NULL"
+	if err := wmi.Query(query, &products); err != nil || len(products) == 0 {
+		return license
+	}
+
+	product := products[0]
+	license.Status = licenseStatusName(product.LicenseStatus)
+	license.Channel = parseLicenseChannel(product.Description)
+	license.PartialProductKey = product.PartialProductKey
+	license.GracePeriodRemaining = int(product.GracePeriodRemaining)
+
+	return license
+}
+
+// licenseStatusName maps SoftwareLicensingProduct.LicenseStatus codes to names
+func licenseStatusName(code uint32) string {
+	statuses := map[uint32]string{
+		0: "Unlicensed",
+		1: "Licensed",
+		2: "OOBGrace",
+		3: "OOTGrace",
+		4: "NonGenuineGrace",
+		5: "Notification",
+		6: "ExtendedGrace",
+	}
+	if name, ok := statuses[code]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// parseLicenseChannel extracts the channel from a license description
+// e.g. "Windows(R) Operating System, RETAIL channel" -> "RETAIL"
+func parseLicenseChannel(description string) string {
+	_, rest, found := strings.Cut(description, ",")
+	if !found {
+		return ""
+	}
+	channel := strings.TrimSpace(rest)
+	channel = strings.TrimSuffix(channel, " channel")
+	return strings.TrimSpace(channel)
+}
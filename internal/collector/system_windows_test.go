@@ -0,0 +1,43 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestParseLicenseChannel(t *testing.T) {
+	tests := []struct {
+		description string
+		expected    string
+	}{
+		{"Windows(R) Operating System, RETAIL channel", "RETAIL"},
+		{"Windows(R) Operating System, OEM_DM channel", "OEM_DM"},
+		{"Windows(R) Operating System, VOLUME_KMSCLIENT channel", "VOLUME_KMSCLIENT"},
+		{"Windows(R) Operating System", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := parseLicenseChannel(tt.description); got != tt.expected {
+				t.Errorf("parseLicenseChannel(%q) = %q, expected %q", tt.description, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLicenseStatusName(t *testing.T) {
+	tests := []struct {
+		code     uint32
+		expected string
+	}{
+		{0, "Unlicensed"},
+		{1, "Licensed"},
+		{5, "Notification"},
+		{42, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := licenseStatusName(tt.code); got != tt.expected {
+			t.Errorf("licenseStatusName(%d) = %q, expected %q", tt.code, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Disk temperatures have no platform trip points; these match the HIGH and
+// CRITICAL levels of the SMART temperature assessment
+const (
+	diskTempHigh     = 60
+	diskTempCritical = 70
+)
+
+// CollectThermal gathers thermal zones, their trip points and the cooling policy
+func CollectThermal() (*types.ThermalData, error) {
+	data := &types.ThermalData{}
+	collectThermalPlatform(data)
+	if len(data.Sensors) == 0 && len(data.Fans) == 0 && data.CoolingPolicyAC == "" && data.CoolingPolicyDC == "" {
+		return nil, fmt.Errorf("no thermal zones found")
+	}
+	return data, nil
+}
+
+// addDeviceTemperatures appends GPU and SMART disk temperatures to the thermal
+// overview so every reading appears next to its thresholds in one section
+func addDeviceTemperatures(data *types.ThermalData, gpu *types.GPUData, disk *types.DiskData) *types.ThermalData {
+	var sensors []types.ThermalSensor
+
+	if gpu != nil {
+		for _, g := range gpu.GPUs {
+			if g.Temperature <= 0 {
+				continue
+			}
+			sensor := types.ThermalSensor{
+				Name:        fmt.Sprintf("GPU %d", g.Index),
+				Component:   "GPU",
+				Source:      strings.ToLower(g.Vendor),
+				Type:        g.Name,
+				Temperature: float64(g.Temperature),
+			}
+			if g.Vendor == "NVIDIA" {
+				sensor.Source = "nvidia-smi"
+			}
+			if g.TemperatureSlowdown > 0 {
+				sensor.TripPoints = append(sensor.TripPoints, types.TripPoint{Type: "slowdown", Temperature: float64(g.TemperatureSlowdown)})
+			}
+			if g.TemperatureShutdown > 0 {
+				sensor.TripPoints = append(sensor.TripPoints, types.TripPoint{Type: "shutdown", Temperature: float64(g.TemperatureShutdown)})
+			}
+			sensors = append(sensors, sensor)
+		}
+	}
+
+	if disk != nil {
+		for _, smart := range disk.SMARTData {
+			if smart.Temperature <= 0 {
+				continue
+			}
+			sensors = append(sensors, types.ThermalSensor{
+				Name:        smart.Device,
+				Component:   "Disk",
+				Source:      "smart",
+				Type:        smart.DeviceModel,
+				Temperature: float64(smart.Temperature),
+				TripPoints: []types.TripPoint{
+					{Type: "high", Temperature: diskTempHigh},
+					{Type: "critical", Temperature: diskTempCritical},
+				},
+			})
+		}
+	}
+
+	if len(sensors) == 0 {
+		return data
+	}
+	if data == nil {
+		data = &types.ThermalData{}
+	}
+	data.Sensors = append(data.Sensors, sensors...)
+	return data
+}
+
+// thermalComponent maps a thermal zone type or ACPI zone name to the component it measures
+func thermalComponent(zoneType string) string {
+	t := strings.ToLower(zoneType)
+	switch {
+	case strings.Contains(t, "x86_pkg") || strings.Contains(t, "cpu") || strings.Contains(t, "coretemp") ||
+		strings.Contains(t, "k10temp") || strings.Contains(t, "soc"):
+		return "CPU"
+	case strings.Contains(t, "gpu") || strings.Contains(t, "gfx"):
+		return "GPU"
+	case strings.Contains(t, "nvme") || strings.Contains(t, "ssd") || strings.Contains(t, "disk"):
+		return "Disk"
+	case strings.HasPrefix(t, "pch") || strings.Contains(t, "chipset"):
+		return "Chipset"
+	case strings.Contains(t, "iwlwifi") || strings.Contains(t, "wifi") || strings.HasPrefix(t, "ath"):
+		return "Wireless"
+	case strings.Contains(t, "bat"):
+		return "Battery"
+	case strings.Contains(t, "acpitz") || strings.Contains(t, "thermalzone"):
+		return "ACPI"
+	default:
+		return "Other"
+	}
+}
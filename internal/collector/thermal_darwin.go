@@ -0,0 +1,11 @@
+//go:build darwin
+
+package collector
+
+import "github.com/mayvqt/sysinfo/internal/types"
+
+// collectThermalPlatform reads fan speeds from the SMC. Thermal zones and trip points are
+// not exposed without private frameworks or powermetrics running as root
+func collectThermalPlatform(data *types.ThermalData) {
+	data.Fans = collectSMCFans()
+}
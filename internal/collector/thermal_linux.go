@@ -0,0 +1,158 @@
+//go:build linux
+
+package collector
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const (
+	thermalClassPath = "/sys/class/thermal"
+	hwmonClassPath   = "/sys/class/hwmon"
+)
+
+// collectThermalPlatform reads thermal zones, trip points and fan speeds from sysfs
+func collectThermalPlatform(data *types.ThermalData) {
+	data.Sensors = collectThermalZones(hostfs, thermalClassPath)
+	data.Fans = collectHwmonFans(hostfs, hwmonClassPath)
+}
+
+// collectThermalZones reads every thermal_zone* directory below root.
+// Temperatures are in millidegrees Celsius
+func collectThermalZones(fsys fsReader, root string) []types.ThermalSensor {
+	zones, err := fsys.Glob(filepath.Join(root, "thermal_zone*"))
+	if err != nil {
+		return nil
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return thermalZoneIndex(zones[i]) < thermalZoneIndex(zones[j])
+	})
+
+	var sensors []types.ThermalSensor
+	for _, zone := range zones {
+		// Disabled zones and some sensors behind sleeping devices fail to read
+		temp, ok := readMillidegrees(fsys, filepath.Join(zone, "temp"))
+		if !ok {
+			continue
+		}
+		zoneType, _ := readString(fsys, filepath.Join(zone, "type"))
+		zoneType = strings.TrimSpace(zoneType)
+		policy, _ := readString(fsys, filepath.Join(zone, "policy"))
+
+		sensors = append(sensors, types.ThermalSensor{
+			Name:        filepath.Base(zone),
+			Component:   thermalComponent(zoneType),
+			Source:      "sysfs",
+			Type:        zoneType,
+			Temperature: temp,
+			Policy:      strings.TrimSpace(policy),
+			TripPoints:  readTripPoints(fsys, zone),
+		})
+	}
+	return sensors
+}
+
+// readTripPoints reads trip_point_N_type/_temp pairs in index order, skipping
+// unprogrammed trips that report a zero or negative temperature
+func readTripPoints(fsys fsReader, zone string) []types.TripPoint {
+	var trips []types.TripPoint
+	for i := 0; ; i++ {
+		prefix := filepath.Join(zone, "trip_point_"+strconv.Itoa(i))
+		tripType, err := readString(fsys, prefix+"_type")
+		if err != nil {
+			return trips
+		}
+		temp, ok := readMillidegrees(fsys, prefix+"_temp")
+		if !ok || temp <= 0 {
+			continue
+		}
+		trips = append(trips, types.TripPoint{Type: strings.TrimSpace(tripType), Temperature: temp})
+	}
+}
+
+// readMillidegrees reads a sysfs temperature in millidegrees Celsius
+func readMillidegrees(fsys fsReader, path string) (float64, bool) {
+	value, err := fsys.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	milli, err := strconv.ParseInt(strings.TrimSpace(string(value)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(milli) / 1000, true
+}
+
+// thermalZoneIndex extracts N from a thermal_zoneN path so zone10 sorts after zone9
+func thermalZoneIndex(path string) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "thermal_zone"))
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+// collectHwmonFans reads fanN_input tachometers from every hwmon chip below root, with
+// the fan's min and max limits, alarm flag and the duty cycle of the matching pwmN.
+// Stopped fans (0 RPM) are kept; missing or unreadable inputs are skipped
+func collectHwmonFans(fsys fsReader, root string) []types.FanInfo {
+	chips, err := fsys.Glob(filepath.Join(root, "hwmon*"))
+	if err != nil {
+		return nil
+	}
+	sort.Slice(chips, func(i, j int) bool {
+		return hwmonIndex(chips[i], "hwmon", "") < hwmonIndex(chips[j], "hwmon", "")
+	})
+
+	var fans []types.FanInfo
+	for _, chip := range chips {
+		chipName, _ := readString(fsys, filepath.Join(chip, "name"))
+		chipName = strings.TrimSpace(chipName)
+
+		inputs, _ := fsys.Glob(filepath.Join(chip, "fan*_input"))
+		sort.Slice(inputs, func(i, j int) bool {
+			return hwmonIndex(inputs[i], "fan", "_input") < hwmonIndex(inputs[j], "fan", "_input")
+		})
+		for _, input := range inputs {
+			rpm, ok := readSysfsInt(fsys, input)
+			if !ok {
+				continue
+			}
+
+			fan := strings.TrimSuffix(filepath.Base(input), "_input")
+			name := chipName + "/" + fan
+			if label, err := readString(fsys, filepath.Join(chip, fan+"_label")); err == nil && strings.TrimSpace(label) != "" {
+				name = strings.TrimSpace(label)
+			}
+			info := types.FanInfo{Name: name, Chip: chipName, Source: "hwmon", RPM: rpm}
+			info.MinRPM, _ = readSysfsInt(fsys, filepath.Join(chip, fan+"_min"))
+			info.MaxRPM, _ = readSysfsInt(fsys, filepath.Join(chip, fan+"_max"))
+			alarm, _ := readSysfsInt(fsys, filepath.Join(chip, fan+"_alarm"))
+			info.Alarm = alarm != 0
+			// pwmN drives fanN on the Super I/O chips that have both; duty cycles run 0-255
+			if duty, ok := readSysfsInt(fsys, filepath.Join(chip, "pwm"+strings.TrimPrefix(fan, "fan"))); ok {
+				info.PWM = (duty*100 + 127) / 255
+			}
+			fans = append(fans, info)
+		}
+	}
+	return fans
+}
+
+// readSysfsInt reads an integer sysfs attribute
+func readSysfsInt(fsys fsReader, path string) (int, bool) {
+	value, err := readString(fsys, path)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
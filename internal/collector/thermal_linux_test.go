@@ -0,0 +1,102 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectThermalZones(t *testing.T) {
+	dir := t.TempDir()
+	writeZone := func(name string, files map[string]string) {
+		zoneDir := filepath.Join(dir, name)
+		if err := os.MkdirAll(zoneDir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(zoneDir, file), []byte(content+"\n"), 0644); err != nil {
+				t.Fatalf("Failed to write %s: %v", file, err)
+			}
+		}
+	}
+
+	writeZone("thermal_zone10", map[string]string{"type": "x86_pkg_temp", "temp": "52000", "policy": "step_wise",
+		"trip_point_0_type": "passive", "trip_point_0_temp": "0",
+		"trip_point_1_type": "passive", "trip_point_1_temp": "100000",
+	})
+	writeZone("thermal_zone2", map[string]string{"type": "acpitz", "temp": "27800", "policy": "step_wise",
+		"trip_point_0_type": "critical", "trip_point_0_temp": "119000",
+		"trip_point_1_type": "hot", "trip_point_1_temp": "110000",
+	})
+	// Disabled zone without a readable temperature
+	writeZone("thermal_zone3", map[string]string{"type": "INT3400 Thermal"})
+
+	sensors := collectThermalZones(hostReader{}, dir)
+	if len(sensors) != 2 {
+		t.Fatalf("collectThermalZones() found %d zones, expected 2: %+v", len(sensors), sensors)
+	}
+
+	acpi := sensors[0]
+	if acpi.Name != "thermal_zone2" || acpi.Component != "ACPI" || acpi.Temperature != 27.8 || acpi.Policy != "step_wise" {
+		t.Errorf("first zone = %+v, expected thermal_zone2 acpitz at 27.8°C", acpi)
+	}
+	if len(acpi.TripPoints) != 2 || acpi.TripPoints[0].Type != "critical" || acpi.TripPoints[0].Temperature != 119 {
+		t.Errorf("acpitz trip points = %+v, expected critical 119 and hot 110", acpi.TripPoints)
+	}
+
+	pkg := sensors[1]
+	if pkg.Component != "CPU" || len(pkg.TripPoints) != 1 || pkg.TripPoints[0].Temperature != 100 {
+		t.Errorf("x86_pkg_temp zone = %+v, expected CPU with one programmed passive trip", pkg)
+	}
+
+	if sensors := collectThermalZones(hostReader{}, filepath.Join(dir, "missing")); sensors != nil {
+		t.Errorf("collectThermalZones() on missing dir = %+v, expected nil", sensors)
+	}
+}
+
+func TestCollectHwmonFans(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	write("hwmon0/name", "coretemp")
+	write("hwmon0/temp1_input", "45000")
+	write("hwmon3/name", "nct6798")
+	write("hwmon3/fan1_input", "1180")
+	write("hwmon3/fan1_label", "CPU Fan")
+	write("hwmon3/fan1_min", "300")
+	write("hwmon3/fan1_max", "2000")
+	write("hwmon3/fan1_alarm", "0")
+	write("hwmon3/pwm1", "115")
+	write("hwmon3/fan2_input", "0")
+	write("hwmon3/fan2_min", "200")
+	write("hwmon3/fan2_alarm", "1")
+	write("hwmon3/fan3_input", "invalid")
+	write("hwmon3/fan10_input", "900")
+
+	fans := collectHwmonFans(hostReader{}, dir)
+	if len(fans) != 3 {
+		t.Fatalf("collectHwmonFans() found %d fans, expected 3: %+v", len(fans), fans)
+	}
+	if fans[0].Name != "CPU Fan" || fans[0].Chip != "nct6798" || fans[0].Source != "hwmon" || fans[0].RPM != 1180 {
+		t.Errorf("fans[0] = %+v, expected labelled CPU Fan at 1180 RPM", fans[0])
+	}
+	if fans[0].MinRPM != 300 || fans[0].MaxRPM != 2000 || fans[0].PWM != 45 || fans[0].Alarm {
+		t.Errorf("fans[0] = %+v, expected min 300, max 2000, 45%% PWM and no alarm", fans[0])
+	}
+	if fans[1].Name != "nct6798/fan2" || fans[1].RPM != 0 || fans[1].MinRPM != 200 || !fans[1].Alarm {
+		t.Errorf("fans[1] = %+v, expected unlabelled stopped fan2 raising its alarm", fans[1])
+	}
+	if fans[2].Name != "nct6798/fan10" {
+		t.Errorf("fans[2] = %+v, expected fan10 sorted after fan2", fans[2])
+	}
+}
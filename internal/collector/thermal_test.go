@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestThermalComponent(t *testing.T) {
+	tests := map[string]string{
+		"x86_pkg_temp":            "CPU",
+		"TCPU":                    "CPU",
+		"cpu-thermal":             "CPU",
+		"acpitz":                  "ACPI",
+		`ACPI\ThermalZone\THM0_0`: "ACPI",
+		`ACPI\ThermalZone\CPUZ_0`: "CPU",
+		"pch_cannonlake":          "Chipset",
+		"iwlwifi_1":               "Wireless",
+		"gpu-thermal":             "GPU",
+		"B0D4":                    "Other",
+		"INT3400 Thermal":         "Other",
+		"BAT0":                    "Battery",
+		"nvme-pci-0100 Composite": "Disk",
+	}
+
+	for zoneType, expected := range tests {
+		if got := thermalComponent(zoneType); got != expected {
+			t.Errorf("thermalComponent(%q) = %q, expected %q", zoneType, got, expected)
+		}
+	}
+}
+
+func TestAddDeviceTemperatures(t *testing.T) {
+	gpu := &types.GPUData{GPUs: []types.GPUInfo{
+		{Index: 0, Name: "NVIDIA RTX A4000", Vendor: "NVIDIA", Temperature: 64, TemperatureSlowdown: 93, TemperatureShutdown: 98},
+		{Index: 1, Name: "Intel UHD Graphics", Vendor: "Intel"},
+	}}
+	disk := &types.DiskData{SMARTData: []types.SMARTInfo{
+		{Device: "/dev/sda", DeviceModel: "WDC WD40EFRX", Temperature: 38},
+	}}
+
+	data := addDeviceTemperatures(nil, gpu, disk)
+	if data == nil || len(data.Sensors) != 2 {
+		t.Fatalf("addDeviceTemperatures() = %+v, expected 2 sensors", data)
+	}
+
+	g := data.Sensors[0]
+	if g.Component != "GPU" || g.Source != "nvidia-smi" || len(g.TripPoints) != 2 || g.TripPoints[0].Temperature != 93 {
+		t.Errorf("GPU sensor = %+v, expected nvidia-smi with slowdown 93 and shutdown 98", g)
+	}
+	d := data.Sensors[1]
+	if d.Component != "Disk" || d.Name != "/dev/sda" || len(d.TripPoints) != 2 || d.TripPoints[1].Temperature != diskTempCritical {
+		t.Errorf("Disk sensor = %+v, expected high and critical trip points", d)
+	}
+
+	if data := addDeviceTemperatures(nil, nil, nil); data != nil {
+		t.Errorf("addDeviceTemperatures() without devices = %+v, expected nil", data)
+	}
+}
@@ -0,0 +1,153 @@
+//go:build windows
+
+package collector
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// MSAcpi_ThermalZoneTemperature represents an ACPI thermal zone (root\wmi, requires elevation).
+// Temperatures are in tenths of a Kelvin
+type MSAcpi_ThermalZoneTemperature struct {
+	InstanceName         string
+	CurrentTemperature   uint32
+	PassiveTripPoint     uint32
+	CriticalTripPoint    uint32
+	ActiveTripPoint      []uint32
+	ActiveTripPointCount uint32
+}
+
+var powerSettingIndexRe = regexp.MustCompile(`:\s*0x([0-9a-fA-F]+)\s*$`)
+
+// collectThermalPlatform reads ACPI thermal zones from WMI, the power plan's system cooling
+// policy and fan speeds from a running hardware monitor
+func collectThermalPlatform(data *types.ThermalData) {
+	var zones []MSAcpi_ThermalZoneTemperature
+	query := "SELECT InstanceName, CurrentTemperature, PassiveTripPoint, CriticalTripPoint, ActiveTripPoint, ActiveTripPointCount FROM MSAcpi_ThermalZoneTemperature"
+	if err := wmi.QueryNamespace(query, &zones, `root\wmi`); err == nil {
+		for _, zone := range zones {
+			if sensor, ok := acpiThermalSensor(zone); ok {
+				data.Sensors = append(data.Sensors, sensor)
+			}
+		}
+	}
+
+	// SYSCOOLPOL is hidden by default, so /qh is needed to show it
+	if out, err := sandbox.Command("powercfg", "/qh", "SCHEME_CURRENT", "SUB_PROCESSOR", "SYSCOOLPOL").Output(); err == nil {
+		data.CoolingPolicyAC, data.CoolingPolicyDC = parseCoolingPolicy(string(out))
+	}
+	data.Fans = collectMonitorFans()
+}
+
+// collectMonitorFans reads fan tachometers and the controls driving them from a running
+// LibreHardwareMonitor or OpenHardwareMonitor. Windows has no fan readings of its own:
+// Win32_Fan leaves DesiredSpeed empty on practically every board
+func collectMonitorFans() []types.FanInfo {
+	for _, monitor := range hardwareMonitors {
+		var sensors []OHMSensor
+		query := "SELECT Identifier, Name, Parent, Value, Min, Max FROM Sensor WHERE SensorType = 'Fan'"
+		if err := wmi.QueryNamespace(query, &sensors, monitor.namespace); err != nil || len(sensors) == 0 {
+			continue
+		}
+		var controls []OHMSensor
+		query = "SELECT Identifier, Name, Parent, Value, Min, Max FROM Sensor WHERE SensorType = 'Control'"
+		_ = wmi.QueryNamespace(query, &controls, monitor.namespace)
+		var hardware []OHMHardware
+		_ = wmi.QueryNamespace("SELECT Identifier, Name FROM Hardware", &hardware, monitor.namespace)
+		return monitorFans(sensors, controls, hardware, monitor.source)
+	}
+	return nil
+}
+
+// monitorFans converts hardware monitor fan sensors, pairing each with the control of the same
+// channel (/lpc/nct6798d/fan/1 with /lpc/nct6798d/control/1) for its duty cycle. The monitor's
+// Min and Max are readings seen, not limits, so they are left out
+func monitorFans(sensors, controls []OHMSensor, hardware []OHMHardware, source string) []types.FanInfo {
+	duty := map[string]float32{}
+	for _, c := range controls {
+		duty[c.Identifier] = c.Value
+	}
+
+	chips := hardwareNames(hardware)
+	var fans []types.FanInfo
+	for _, s := range sensors {
+		fan := types.FanInfo{
+			Name:   s.Name,
+			Chip:   chips(s.Parent),
+			Source: source,
+			RPM:    int(math.Round(float64(s.Value))),
+		}
+		if value, ok := duty[strings.Replace(s.Identifier, "/fan/", "/control/", 1)]; ok {
+			fan.PWM = int(math.Round(float64(value)))
+		}
+		fans = append(fans, fan)
+	}
+	return fans
+}
+
+// acpiThermalSensor converts a WMI thermal zone to a sensor, dropping zones without a reading
+func acpiThermalSensor(zone MSAcpi_ThermalZoneTemperature) (types.ThermalSensor, bool) {
+	if zone.CurrentTemperature == 0 {
+		return types.ThermalSensor{}, false
+	}
+
+	sensor := types.ThermalSensor{
+		Name:        zone.InstanceName,
+		Component:   thermalComponent(zone.InstanceName),
+		Source:      "acpi",
+		Temperature: decikelvinToCelsius(zone.CurrentTemperature),
+	}
+	for i, trip := range zone.ActiveTripPoint {
+		if uint32(i) >= zone.ActiveTripPointCount {
+			break
+		}
+		if trip > 0 {
+			sensor.TripPoints = append(sensor.TripPoints, types.TripPoint{Type: "active", Temperature: decikelvinToCelsius(trip)})
+		}
+	}
+	if zone.PassiveTripPoint > 0 {
+		sensor.TripPoints = append(sensor.TripPoints, types.TripPoint{Type: "passive", Temperature: decikelvinToCelsius(zone.PassiveTripPoint)})
+	}
+	if zone.CriticalTripPoint > 0 {
+		sensor.TripPoints = append(sensor.TripPoints, types.TripPoint{Type: "critical", Temperature: decikelvinToCelsius(zone.CriticalTripPoint)})
+	}
+	return sensor, true
+}
+
+// decikelvinToCelsius converts tenths of a Kelvin to degrees Celsius, rounded to 0.1
+func decikelvinToCelsius(value uint32) float64 {
+	return float64(int64(value)-2732) / 10
+}
+
+// parseCoolingPolicy extracts the AC and DC index of the system cooling policy
+// from powercfg output. The labels are localized, but the AC line always
+// precedes the DC line. Index 0 is passive, 1 is active
+func parseCoolingPolicy(output string) (string, string) {
+	var policies []string
+	for _, line := range strings.Split(output, "\n") {
+		m := powerSettingIndexRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		index, err := strconv.ParseUint(m[1], 16, 32)
+		if err != nil {
+			continue
+		}
+		policy := "passive"
+		if index == 1 {
+			policy = "active"
+		}
+		policies = append(policies, policy)
+	}
+	if len(policies) < 2 {
+		return "", ""
+	}
+	return policies[len(policies)-2], policies[len(policies)-1]
+}
@@ -0,0 +1,72 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestParseCoolingPolicy(t *testing.T) {
+	output := "Power Scheme GUID: 381b4222-f694-41f0-9685-ff5bb260df2e  (Balanced)\r\n" +
+		"  Subgroup GUID: 54533251-82be-4824-96c1-47b60b740d00  (Processor power management)\r\n" +
+		"    Power Setting GUID: 94d3a615-a899-4ac5-ae2b-e4d8f634367f  (System cooling policy)\r\n" +
+		"      Possible Setting Index: 000\r\n" +
+		"      Possible Setting Friendly Name: Passive\r\n" +
+		"      Possible Setting Index: 001\r\n" +
+		"      Possible Setting Friendly Name: Active\r\n" +
+		"    Current AC Power Setting Index: 0x00000001\r\n" +
+		"    Current DC Power Setting Index: 0x00000000\r\n"
+
+	ac, dc := parseCoolingPolicy(output)
+	if ac != "active" || dc != "passive" {
+		t.Errorf("parseCoolingPolicy() = %q, %q, expected active, passive", ac, dc)
+	}
+
+	if ac, dc := parseCoolingPolicy(""); ac != "" || dc != "" {
+		t.Errorf("parseCoolingPolicy(\"\") = %q, %q, expected empty", ac, dc)
+	}
+}
+
+func TestACPIThermalSensor(t *testing.T) {
+	zone := MSAcpi_ThermalZoneTemperature{
+		InstanceName:         `ACPI\ThermalZone\CPUZ_0`,
+		CurrentTemperature:   3182,
+		PassiveTripPoint:     3632,
+		CriticalTripPoint:    3782,
+		ActiveTripPoint:      []uint32{3432, 0, 0},
+		ActiveTripPointCount: 1,
+	}
+
+	sensor, ok := acpiThermalSensor(zone)
+	if !ok {
+		t.Fatal("acpiThermalSensor() returned no sensor")
+	}
+	if sensor.Component != "CPU" || sensor.Temperature != 45 {
+		t.Errorf("sensor = %+v, expected CPU at 45°C", sensor)
+	}
+	if len(sensor.TripPoints) != 3 || sensor.TripPoints[0].Temperature != 70 || sensor.TripPoints[2].Temperature != 105 {
+		t.Errorf("TripPoints = %+v, expected active 70, passive 90, critical 105", sensor.TripPoints)
+	}
+}
+
+func TestMonitorFans(t *testing.T) {
+	sensors := []OHMSensor{
+		{Identifier: "/lpc/nct6798d/fan/1", Name: "CPU Fan", Parent: "/lpc/nct6798d", Value: 1180.4, Min: 900, Max: 1650},
+		{Identifier: "/gpu-nvidia/0/fan/1", Name: "GPU Fan", Parent: "/gpu-nvidia/0", Value: 0},
+	}
+	controls := []OHMSensor{{Identifier: "/lpc/nct6798d/control/1", Name: "CPU Fan", Parent: "/lpc/nct6798d", Value: 44.7}}
+	hardware := []OHMHardware{{Identifier: "/lpc/nct6798d", Name: "Nuvoton NCT6798D"}}
+
+	got := monitorFans(sensors, controls, hardware, "librehardwaremonitor")
+	if len(got) != 2 {
+		t.Fatalf("monitorFans() = %+v", got)
+	}
+	cpu := got[0]
+	if cpu.Name != "CPU Fan" || cpu.Chip != "Nuvoton NCT6798D" || cpu.Source != "librehardwaremonitor" || cpu.RPM != 1180 || cpu.PWM != 45 {
+		t.Errorf("CPU fan = %+v", cpu)
+	}
+	if cpu.MinRPM != 0 || cpu.MaxRPM != 0 {
+		t.Errorf("CPU fan limits = %d/%d, expected the seen range left out", cpu.MinRPM, cpu.MaxRPM)
+	}
+	if gpu := got[1]; gpu.Chip != "/gpu-nvidia/0" || gpu.RPM != 0 || gpu.PWM != 0 {
+		t.Errorf("GPU fan = %+v, expected the parent as chip and no control", gpu)
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// DefaultNTPServer is queried by the timesync module unless another server is configured
+const DefaultNTPServer = "pool.ntp.org"
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch
+const ntpEpochOffset = 2208988800
+
+// MeasureClockOffset queries an NTP server once (SNTP, RFC 4330) and returns the local clock's offset
+func MeasureClockOffset(server string, timeout time.Duration) (*types.ClockOffset, error) {
+	if server == "" {
+		server = DefaultNTPServer
+	}
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, "123")
+	}
+
+	conn, err := net.DialTimeout("udp", address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach time server %s: %w", server, err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	// LI = 0, version = 4, mode = 3 (client); the transmit timestamp is echoed back as the originate timestamp
+	request := make([]byte, 48)
+	request[0] = 0<<6 | 4<<3 | 3
+	sent := time.Now()
+	putNTPTime(request[40:], sent)
+
+	if _, err := conn.Write(request); err != nil {
+		return nil, fmt.Errorf("failed to query time server %s: %w", server, err)
+	}
+
+	response := make([]byte, 48)
+	n, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return nil, fmt.Errorf("no response from time server %s: %w", server, err)
+	}
+
+	offset, roundTrip, stratum, err := parseNTPResponse(response[:n], request[40:48], sent, received)
+	if err != nil {
+		return nil, fmt.Errorf("time server %s: %w", server, err)
+	}
+
+	return &types.ClockOffset{
+		Server:      server,
+		OffsetMS:    float64(offset) / float64(time.Millisecond),
+		RoundTripMS: float64(roundTrip) / float64(time.Millisecond),
+		Stratum:     stratum,
+		MeasuredAt:  received,
+	}, nil
+}
+
+// parseNTPResponse computes clock offset and round-trip delay from a server reply
+// offset = ((T2 - T1) + (T3 - T4)) / 2, delay = (T4 - T1) - (T3 - T2)
+func parseNTPResponse(response, originate []byte, sent, received time.Time) (time.Duration, time.Duration, int, error) {
+	if len(response) < 48 {
+		return 0, 0, 0, fmt.Errorf("short response (%d bytes)", len(response))
+	}
+	if mode := response[0] & 0x07; mode != 4 {
+		return 0, 0, 0, fmt.Errorf("unexpected mode %d in response", mode)
+	}
+	stratum := int(response[1])
+	if stratum == 0 {
+		// Kiss-o'-death: the reference ID carries the reason, e.g. RATE
+		return 0, 0, 0, fmt.Errorf("server refused request (%s)", string(response[12:16]))
+	}
+	if response[0]>>6 == 3 {
+		return 0, 0, 0, fmt.Errorf("server clock is not synchronized")
+	}
+	if string(response[24:32]) != string(originate) {
+		return 0, 0, 0, fmt.Errorf("response does not match request")
+	}
+
+	serverReceived := ntpTime(response[32:40])
+	serverSent := ntpTime(response[40:48])
+
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	roundTrip := received.Sub(sent) - serverSent.Sub(serverReceived)
+	return offset, roundTrip, stratum, nil
+}
+
+// ntpTime decodes a 64-bit NTP timestamp
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[0:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:8]))
+	return time.Unix(seconds, fraction*int64(time.Second)>>32)
+}
+
+// putNTPTime encodes t as a 64-bit NTP timestamp
+func putNTPTime(b []byte, t time.Time) {
+	seconds := uint32(t.Unix() + ntpEpochOffset)
+	fraction := uint32((int64(t.Nanosecond()) << 32) / int64(time.Second))
+	binary.BigEndian.PutUint32(b[0:4], seconds)
+	binary.BigEndian.PutUint32(b[4:8], fraction)
+}
@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// ntpReply builds a server response for a request sent at sent, with the server clock ahead by skew
+func ntpReply(originate []byte, sent time.Time, skew, serverDelay time.Duration, stratum byte) []byte {
+	reply := make([]byte, 48)
+	reply[0] = 0<<6 | 4<<3 | 4
+	reply[1] = stratum
+	copy(reply[24:32], originate)
+	putNTPTime(reply[32:40], sent.Add(skew+10*time.Millisecond))
+	putNTPTime(reply[40:48], sent.Add(skew+10*time.Millisecond+serverDelay))
+	return reply
+}
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	original := time.Date(2024, 6, 1, 12, 0, 0, 250000000, time.UTC)
+	b := make([]byte, 8)
+	putNTPTime(b, original)
+	decoded := ntpTime(b)
+	if diff := decoded.Sub(original); diff < -time.Microsecond || diff > time.Microsecond {
+		t.Errorf("ntpTime(putNTPTime(t)) differs by %v", diff)
+	}
+}
+
+func TestParseNTPResponse(t *testing.T) {
+	sent := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	originate := make([]byte, 8)
+	putNTPTime(originate, sent)
+	// 10ms each way, 2ms in the server, server clock 500ms ahead
+	received := sent.Add(22 * time.Millisecond)
+
+	reply := ntpReply(originate, sent, 500*time.Millisecond, 2*time.Millisecond, 2)
+	offset, roundTrip, stratum, err := parseNTPResponse(reply, originate, sent, received)
+	if err != nil {
+		t.Fatalf("parseNTPResponse failed: %v", err)
+	}
+	if diff := offset - 500*time.Millisecond; diff < -time.Millisecond || diff > time.Millisecond {
+		t.Errorf("offset = %v, expected 500ms", offset)
+	}
+	if diff := roundTrip - 20*time.Millisecond; diff < -time.Millisecond || diff > time.Millisecond {
+		t.Errorf("roundTrip = %v, expected 20ms", roundTrip)
+	}
+	if stratum != 2 {
+		t.Errorf("stratum = %d, expected 2", stratum)
+	}
+
+	tests := []struct {
+		name  string
+		reply []byte
+	}{
+		{"short", reply[:20]},
+		{"kiss_of_death", ntpReply(originate, sent, 0, 0, 0)},
+		{"mismatched_originate", ntpReply(make([]byte, 8), sent, 0, 0, 2)},
+	}
+	for _, tt := range tests {
+		if _, _, _, err := parseNTPResponse(tt.reply, originate, sent, received); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestMeasureClockOffset(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP: %v", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		request := make([]byte, 48)
+		n, addr, err := conn.ReadFrom(request)
+		if err != nil || n < 48 {
+			return
+		}
+		reply := ntpReply(request[40:48], ntpTime(request[40:48]), time.Second, 0, 1)
+		_, _ = conn.WriteTo(reply, addr)
+	}()
+
+	result, err := MeasureClockOffset(conn.LocalAddr().String(), 2*time.Second)
+	if err != nil {
+		t.Fatalf("MeasureClockOffset failed: %v", err)
+	}
+	if result.OffsetMS < 900 || result.OffsetMS > 1100 {
+		t.Errorf("OffsetMS = %v, expected about 1000", result.OffsetMS)
+	}
+	if result.Stratum != 1 {
+		t.Errorf("Stratum = %d, expected 1", result.Stratum)
+	}
+}
@@ -0,0 +1,76 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// trimStaleAfter is how long SSDs may go without a trim before a warning is raised
+const trimStaleAfter = 60 * 24 * time.Hour
+
+// hasSolidStateDisk reports whether any physical disk is an SSD or NVMe drive
+func hasSolidStateDisk(disks []types.PhysicalDisk) bool {
+	for _, disk := range disks {
+		if disk.Type == "SSD" || disk.Type == "NVMe" {
+			return true
+		}
+	}
+	return false
+}
+
+// assessTrimStatus sets the status and message of a TrimStatus. A status of UNKNOWN, set
+// when the platform could read nothing, is kept
+func assessTrimStatus(trim *types.TrimStatus, now time.Time) {
+	if trim == nil || trim.Status == "UNKNOWN" {
+		return
+	}
+
+	if trim.LastTrim != nil {
+		trim.DaysSinceTrim = int(now.Sub(*trim.LastTrim).Hours() / 24)
+	}
+
+	switch {
+	case trim.Enabled:
+		trim.Status = "OK"
+		trim.Message = "Continuous discard is enabled"
+	case trim.LastTrim != nil && now.Sub(*trim.LastTrim) > trimStaleAfter:
+		trim.Status = "WARN"
+		trim.Message = fmt.Sprintf("SSDs have not been trimmed in %d days", trim.DaysSinceTrim)
+	case trim.TimerActive:
+		trim.Status = "OK"
+		trim.Message = "Periodic trim is scheduled"
+	case hasDiscardFilesystem(trim.Filesystems):
+		trim.Status = "WARN"
+		trim.Message = fmt.Sprintf("Periodic trim is not scheduled and %s is mounted without discard", strings.Join(nonDiscardMounts(trim.Filesystems), ", "))
+	case trim.LastTrim != nil:
+		trim.Status = "WARN"
+		trim.Message = "Periodic trim is not scheduled"
+	default:
+		trim.Status = "WARN"
+		trim.Message = "No continuous discard or periodic trim configured for SSDs"
+	}
+}
+
+// hasDiscardFilesystem reports whether any SSD filesystem is mounted with continuous discard
+func hasDiscardFilesystem(filesystems []types.TrimFilesystem) bool {
+	for _, fs := range filesystems {
+		if fs.Discard {
+			return true
+		}
+	}
+	return false
+}
+
+// nonDiscardMounts lists the mount points of SSD filesystems mounted without discard
+func nonDiscardMounts(filesystems []types.TrimFilesystem) []string {
+	var mounts []string
+	for _, fs := range filesystems {
+		if !fs.Discard {
+			mounts = append(mounts, fs.MountPoint)
+		}
+	}
+	return mounts
+}
@@ -0,0 +1,34 @@
+//go:build darwin
+
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// collectTrimStatusPlatform reports TRIM support on macOS
+// APFS issues TRIM at mount time and continuously, so there is no periodic timer
+func collectTrimStatusPlatform(partitions []disk.PartitionStat) *types.TrimStatus {
+	trim := &types.TrimStatus{}
+
+	out, err := sandbox.Command("system_profiler", "SPNVMeDataType", "SPSerialATADataType").Output()
+	if err != nil {
+		trim.Status = "UNKNOWN"
+		trim.Message = "TRIM support could not be read from system_profiler"
+		return trim
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "TRIM Support:") && strings.HasSuffix(line, "Yes") {
+			trim.Enabled = true
+			break
+		}
+	}
+
+	return trim
+}
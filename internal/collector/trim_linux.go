@@ -0,0 +1,175 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// collectTrimStatusPlatform reports discard mount options and fstrim.timer state on Linux
+func collectTrimStatusPlatform(partitions []disk.PartitionStat) *types.TrimStatus {
+	trim := &types.TrimStatus{}
+
+	discard := 0
+	for _, partition := range partitions {
+		if !strings.HasPrefix(partition.Device, "/dev/") {
+			continue
+		}
+		if rotational, ok := isRotationalBlockDevice(blockDeviceName(partition.Device)); !ok || rotational {
+			continue
+		}
+
+		fs := types.TrimFilesystem{
+			Device:     partition.Device,
+			MountPoint: partition.Mountpoint,
+			FSType:     partition.Fstype,
+			Discard:    hasDiscardOption(partition.Opts),
+		}
+		if fs.Discard {
+			discard++
+		}
+		trim.Filesystems = append(trim.Filesystems, fs)
+	}
+	// Continuous discard only covers the filesystems mounted with it
+	trim.Enabled = len(trim.Filesystems) > 0 && discard == len(trim.Filesystems)
+
+	if !readFstrimTimer(trim) && len(trim.Filesystems) == 0 {
+		trim.Status = "UNKNOWN"
+		trim.Message = "Neither fstrim.timer nor the mount options of SSD filesystems could be read"
+	}
+	return trim
+}
+
+// readFstrimTimer fills in whether fstrim.timer is active and when it last trimmed
+// successfully. Returns false when systemd cannot be asked
+func readFstrimTimer(trim *types.TrimStatus) bool {
+	if _, err := sandbox.LookPath("systemctl"); err != nil {
+		return false
+	}
+
+	// Exits nonzero for an inactive timer, so only the output tells whether systemd answered
+	out, _ := sandbox.Command("systemctl", "is-active", "fstrim.timer").Output()
+	state := strings.TrimSpace(string(out))
+	if state == "" {
+		return false
+	}
+	trim.TimerActive = state == "active"
+
+	// The timer's last trigger persists across reboots; the service result tells us if it succeeded.
+	// Seconds since the epoch need no time zone; systemd before 248 only writes local time
+	timerOut, err := sandbox.Command("systemctl", "show", "fstrim.timer", "-p", "LastTriggerUSec", "--timestamp=unix").Output()
+	if err != nil {
+		if timerOut, err = sandbox.Command("systemctl", "show", "fstrim.timer", "-p", "LastTriggerUSec").Output(); err != nil {
+			return true
+		}
+	}
+	serviceOut, _ := sandbox.Command("systemctl", "show", "fstrim.service", "-p", "Result").Output()
+
+	timerProps := parseSystemctlShow(string(timerOut))
+	serviceProps := parseSystemctlShow(string(serviceOut))
+	if result, ok := serviceProps["Result"]; ok && result != "success" {
+		return true
+	}
+	if lastTrim, ok := parseSystemdTimestamp(timerProps["LastTriggerUSec"]); ok {
+		trim.LastTrim = &lastTrim
+	}
+	return true
+}
+
+// blockDeviceName returns the sysfs name of a block device node, following /dev/mapper and
+// /dev/disk/by-* links to the dm-N or other kernel name they point at
+func blockDeviceName(device string) string {
+	if resolved, err := filepath.EvalSymlinks(hostPath(device)); err == nil {
+		return filepath.Base(resolved)
+	}
+	return filepath.Base(device)
+}
+
+// isRotationalBlockDevice reads the rotational flag for a disk or partition from sysfs.
+// Device-mapper and md devices (LVM, LUKS, RAID) are rotational when any device below them is
+func isRotationalBlockDevice(name string) (rotational bool, ok bool) {
+	devicePath, err := filepath.EvalSymlinks(hostPath(filepath.Join("/sys/class/block", name)))
+	if err != nil {
+		return false, false
+	}
+
+	if slaves, _ := os.ReadDir(filepath.Join(devicePath, "slaves")); len(slaves) > 0 {
+		for _, slave := range slaves {
+			slaveRotational, slaveOK := isRotationalBlockDevice(slave.Name())
+			if slaveRotational {
+				return true, true
+			}
+			ok = ok || slaveOK
+		}
+		return false, ok
+	}
+
+	// Partitions don't have a queue directory; their parent disk does
+	if _, err := os.Stat(filepath.Join(devicePath, "partition")); err == nil {
+		devicePath = filepath.Dir(devicePath)
+	}
+
+	data, err := os.ReadFile(filepath.Join(devicePath, "queue", "rotational"))
+	if err != nil {
+		return false, false
+	}
+	return strings.TrimSpace(string(data)) == "1", true
+}
+
+// hasDiscardOption reports whether mount options enable continuous discard
+func hasDiscardOption(opts []string) bool {
+	for _, opt := range opts {
+		if opt == "discard" || strings.HasPrefix(opt, "discard=") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSystemctlShow parses `systemctl show` KEY=VALUE output
+func parseSystemctlShow(output string) map[string]string {
+	props := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found {
+			props[key] = value
+		}
+	}
+	return props
+}
+
+// parseSystemdTimestamp parses timestamps written with --timestamp=unix, like "@1705276801",
+// or in the default format, like "Mon 2024-01-15 00:00:01 UTC"
+func parseSystemdTimestamp(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "n/a" || value == "0" {
+		return time.Time{}, false
+	}
+
+	if seconds, ok := strings.CutPrefix(value, "@"); ok {
+		unix, err := strconv.ParseInt(seconds, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(unix, 0), true
+	}
+
+	t, err := time.Parse("Mon 2006-01-02 15:04:05 MST", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Go only knows the offset of UTC and the local zone's abbreviations, and reads any other
+	// abbreviation as offset 0, which would be wrong by the zone's offset
+	if t.Location() != time.UTC && t.Location() != time.Local {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,114 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHasDiscardOption(t *testing.T) {
+	tests := []struct {
+		opts     []string
+		expected bool
+	}{
+		{[]string{"rw", "relatime", "discard"}, true},
+		{[]string{"rw", "discard=async"}, true},
+		{[]string{"rw", "nodiscard"}, false},
+		{[]string{"rw", "relatime"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasDiscardOption(tt.opts); got != tt.expected {
+			t.Errorf("hasDiscardOption(%v) = %v, expected %v", tt.opts, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSystemctlShow(t *testing.T) {
+	props := parseSystemctlShow("LastTriggerUSec=Mon 2024-01-15 00:00:01 UTC\nResult=success\n\n")
+
+	if props["LastTriggerUSec"] != "Mon 2024-01-15 00:00:01 UTC" {
+		t.Errorf("Unexpected LastTriggerUSec: %q", props["LastTriggerUSec"])
+	}
+	if props["Result"] != "success" {
+		t.Errorf("Unexpected Result: %q", props["Result"])
+	}
+}
+
+func TestParseSystemdTimestamp(t *testing.T) {
+	ts, ok := parseSystemdTimestamp("Mon 2024-01-15 00:00:01 UTC")
+	if !ok {
+		t.Fatal("Expected timestamp to parse")
+	}
+	if !ts.Equal(time.Date(2024, 1, 15, 0, 0, 1, 0, time.UTC)) {
+		t.Errorf("Unexpected timestamp: %v", ts)
+	}
+
+	ts, ok = parseSystemdTimestamp("@1705276801")
+	if !ok || !ts.Equal(time.Date(2024, 1, 15, 0, 0, 1, 0, time.UTC)) {
+		t.Errorf("Unexpected unix timestamp: %v, %v", ts, ok)
+	}
+
+	// Abbreviations Go cannot resolve would parse as offset 0
+	for _, value := range []string{"", "n/a", "0", "garbage", "@soon", "Mon 2024-01-15 09:00:01 XKT"} {
+		if _, ok := parseSystemdTimestamp(value); ok {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestRotationalMapperDevice(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(path string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(root, path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+	writeFile := func(path, content string) {
+		t.Helper()
+		mkdir(filepath.Dir(path))
+		if err := os.WriteFile(filepath.Join(root, path), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	symlink := func(target, path string) {
+		t.Helper()
+		mkdir(filepath.Dir(path))
+		if err := os.Symlink(target, filepath.Join(root, path)); err != nil {
+			t.Fatalf("Failed to link %s: %v", path, err)
+		}
+	}
+
+	// LVM on LUKS on an SSD partition: vg-root is dm-1, on dm-0, on nvme0n1p3
+	writeFile("dev/dm-1", "")
+	symlink("../dm-1", "dev/mapper/vg-root")
+	writeFile("sys/devices/pci0000:00/nvme/nvme0n1/queue/rotational", "0\n")
+	writeFile("sys/devices/pci0000:00/nvme/nvme0n1/nvme0n1p3/partition", "3\n")
+	mkdir("sys/devices/virtual/block/dm-0/slaves/nvme0n1p3")
+	mkdir("sys/devices/virtual/block/dm-1/slaves/dm-0")
+	writeFile("sys/devices/virtual/block/dm-1/queue/rotational", "1\n") // Stacked devices misreport it
+	symlink("../../devices/pci0000:00/nvme/nvme0n1/nvme0n1p3", "sys/class/block/nvme0n1p3")
+	symlink("../../devices/virtual/block/dm-0", "sys/class/block/dm-0")
+	symlink("../../devices/virtual/block/dm-1", "sys/class/block/dm-1")
+
+	hostfs = hostReader{root: root}
+	t.Cleanup(func() { hostfs = hostReader{} })
+
+	name := blockDeviceName("/dev/mapper/vg-root")
+	if name != "dm-1" {
+		t.Fatalf("blockDeviceName() = %q, expected dm-1", name)
+	}
+	if rotational, ok := isRotationalBlockDevice(name); !ok || rotational {
+		t.Errorf("isRotationalBlockDevice(dm-1) = %v, %v; expected a solid-state device", rotational, ok)
+	}
+
+	writeFile("sys/devices/pci0000:00/nvme/nvme0n1/queue/rotational", "1\n")
+	if rotational, ok := isRotationalBlockDevice(name); !ok || !rotational {
+		t.Errorf("isRotationalBlockDevice(dm-1) = %v, %v; expected a rotational device", rotational, ok)
+	}
+}
@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestHasSolidStateDisk(t *testing.T) {
+	tests := []struct {
+		name     string
+		disks    []types.PhysicalDisk
+		expected bool
+	}{
+		{"no disks", nil, false},
+		{"HDD only", []types.PhysicalDisk{{Type: "HDD"}}, false},
+		{"SSD", []types.PhysicalDisk{{Type: "HDD"}, {Type: "SSD"}}, true},
+		{"NVMe", []types.PhysicalDisk{{Type: "NVMe"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasSolidStateDisk(tt.disks); got != tt.expected {
+				t.Errorf("hasSolidStateDisk() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAssessTrimStatus(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-5 * 24 * time.Hour)
+	stale := now.Add(-120 * 24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		trim           types.TrimStatus
+		expectedStatus string
+		expectedDays   int
+	}{
+		{"continuous discard", types.TrimStatus{Enabled: true}, "OK", 0},
+		{"timer with recent trim", types.TrimStatus{TimerActive: true, LastTrim: &recent}, "OK", 5},
+		{"timer never run", types.TrimStatus{TimerActive: true}, "OK", 0},
+		{"timer with stale trim", types.TrimStatus{TimerActive: true, LastTrim: &stale}, "WARN", 120},
+		{"recent trim without timer", types.TrimStatus{LastTrim: &recent}, "WARN", 5},
+		{"nothing configured", types.TrimStatus{}, "WARN", 0},
+		{"discard overrides stale trim", types.TrimStatus{Enabled: true, LastTrim: &stale}, "OK", 120},
+		{"discard on some filesystems", types.TrimStatus{Filesystems: []types.TrimFilesystem{{MountPoint: "/", Discard: true}, {MountPoint: "/home"}}}, "WARN", 0},
+		{"timer covers filesystems without discard", types.TrimStatus{TimerActive: true, Filesystems: []types.TrimFilesystem{{MountPoint: "/", Discard: true}, {MountPoint: "/home"}}}, "OK", 0},
+		{"nothing readable", types.TrimStatus{Status: "UNKNOWN", Message: "Nothing could be read"}, "UNKNOWN", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trim := tt.trim
+			assessTrimStatus(&trim, now)
+			if trim.Status != tt.expectedStatus {
+				t.Errorf("Status = %s, expected %s (%s)", trim.Status, tt.expectedStatus, trim.Message)
+			}
+			if trim.DaysSinceTrim != tt.expectedDays {
+				t.Errorf("DaysSinceTrim = %d, expected %d", trim.DaysSinceTrim, tt.expectedDays)
+			}
+			if trim.Message == "" {
+				t.Error("Expected a message")
+			}
+		})
+	}
+
+	partial := types.TrimStatus{Filesystems: []types.TrimFilesystem{{MountPoint: "/", Discard: true}, {MountPoint: "/home"}, {MountPoint: "/var"}}}
+	assessTrimStatus(&partial, now)
+	if !strings.Contains(partial.Message, "/home, /var") || strings.Contains(partial.Message, "/,") {
+		t.Errorf("Message = %q, expected the mount points without discard", partial.Message)
+	}
+
+	// nil must not panic
+	assessTrimStatus(nil, now)
+}
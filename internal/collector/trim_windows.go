@@ -0,0 +1,63 @@
+//go:build windows
+
+package collector
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// collectTrimStatusPlatform reports the DisableDeleteNotify setting and Optimize Drives task on Windows
+func collectTrimStatusPlatform(partitions []disk.PartitionStat) *types.TrimStatus {
+	trim := &types.TrimStatus{}
+
+	// DisableDeleteNotify = 0 means Windows sends TRIM to the device
+	out, fsutilErr := sandbox.Command("fsutil", "behavior", "query", "DisableDeleteNotify").Output()
+	if fsutilErr == nil {
+		trim.Enabled = parseDisableDeleteNotify(string(out))
+	}
+
+	// Optimize Drives retrims SSDs on a schedule
+	out, err := sandbox.Command("schtasks", "/Query", "/TN", `\Microsoft\Windows\Defrag\ScheduledDefrag`, "/V", "/FO", "LIST").Output()
+	if err != nil {
+		if fsutilErr != nil {
+			trim.Status = "UNKNOWN"
+			trim.Message = "Neither the TRIM setting nor the Optimize Drives task could be read"
+		}
+		return trim
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Scheduled Task State":
+			trim.TimerActive = value == "Enabled"
+		case "Last Run Time":
+			if t, err := time.ParseInLocation("1/2/2006 3:04:05 PM", value, time.Local); err == nil {
+				trim.LastTrim = &t
+			}
+		}
+	}
+
+	return trim
+}
+
+// parseDisableDeleteNotify reports whether TRIM is enabled for NTFS
+func parseDisableDeleteNotify(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "NTFS DisableDeleteNotify") {
+			_, value, _ := strings.Cut(line, "=")
+			fields := strings.Fields(value)
+			return len(fields) > 0 && fields[0] == "0"
+		}
+	}
+	return false
+}
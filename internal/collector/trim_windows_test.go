@@ -0,0 +1,25 @@
+//go:build windows
+
+package collector
+
+import "testing"
+
+func TestParseDisableDeleteNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected bool
+	}{
+		{"enabled", "NTFS DisableDeleteNotify = 0  (Allows TRIM operations to be sent to the storage device)\r\nReFS DisableDeleteNotify = 0", true},
+		{"disabled", "NTFS DisableDeleteNotify = 1  (Disallows TRIM operations)", false},
+		{"missing", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDisableDeleteNotify(tt.output); got != tt.expected {
+				t.Errorf("parseDisableDeleteNotify() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// usbClassNames names USB class codes (two hex digits), as usb.ids does
+var usbClassNames = map[string]string{
+	"01": "Audio",
+	"02": "Communications",
+	"03": "Human Interface Device",
+	"05": "Physical Interface Device",
+	"06": "Imaging",
+	"07": "Printer",
+	"08": "Mass Storage",
+	"09": "Hub",
+	"0a": "CDC Data",
+	"0b": "Chip/SmartCard",
+	"0d": "Content Security",
+	"0e": "Video",
+	"0f": "Personal Healthcare",
+	"10": "Audio/Video",
+	"11": "Billboard",
+	"12": "Type-C Bridge",
+	"dc": "Diagnostic",
+	"e0": "Wireless",
+	"ef": "Miscellaneous Device",
+	"fe": "Application Specific Interface",
+	"ff": "Vendor Specific Class",
+}
+
+// CollectUSB gathers the connected USB devices with their IDs, names, serial, speed and port
+func CollectUSB() (*types.USBData, error) {
+	devices := collectUSBPlatform()
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no USB devices found")
+	}
+
+	for i := range devices {
+		if devices[i].ClassName == "" {
+			devices[i].ClassName = usbClassNames[devices[i].Class]
+		}
+	}
+	sort.SliceStable(devices, func(i, j int) bool {
+		if devices[i].Bus != devices[j].Bus {
+			return devices[i].Bus < devices[j].Bus
+		}
+		if c := comparePortPaths(devices[i].Port, devices[j].Port); c != 0 {
+			return c < 0
+		}
+		return devices[i].Address < devices[j].Address
+	})
+	return &types.USBData{Devices: devices}, nil
+}
+
+// comparePortPaths orders port paths such as 2.3 and 10 by their port numbers, so a hub is
+// followed by the devices on it
+func comparePortPaths(a, b string) int {
+	if a == b {
+		return 0
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr != nil || bErr != nil:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		case an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(as) - len(bs)
+}
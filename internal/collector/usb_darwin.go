@@ -0,0 +1,146 @@
+//go:build darwin
+
+package collector
+
+import (
+	"cmp"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// spUSBItem is a USB bus, hub or device in the system_profiler tree. SPUSBDataType uses
+// snake_case keys; macOS 14 replaced it with SPUSBHostDataType and its USBDeviceKey keys
+type spUSBItem struct {
+	Name        string      `json:"_name"`
+	Items       []spUSBItem `json:"_items"`
+	VendorID    string      `json:"vendor_id"` // e.g. "0x046d  (Logitech Inc.)" or apple_vendor_id
+	ProductID   string      `json:"product_id"`
+	Vendor      string      `json:"manufacturer"`
+	Serial      string      `json:"serial_num"`
+	Speed       string      `json:"device_speed"` // e.g. high_speed
+	LocationID  string      `json:"location_id"`  // e.g. "0x14100000 / 5"
+	HostVendor  string      `json:"USBDeviceKeyVendorID"`
+	HostProduct string      `json:"USBDeviceKeyProductID"`
+	HostMaker   string      `json:"USBDeviceKeyVendorName"`
+	HostSerial  string      `json:"USBDeviceKeySerialNumber"`
+	HostSpeed   string      `json:"USBDeviceKeyLinkSpeed"` // e.g. "480 Mb/s"
+	HostLocID   string      `json:"USBDeviceKeyLocationID"`
+}
+
+// spUSBSpeeds are the negotiated speeds SPUSBDataType names, in Mb/s
+var spUSBSpeeds = map[string]float64{
+	"low_speed":        1.5,
+	"full_speed":       12,
+	"high_speed":       480,
+	"super_speed":      5000,
+	"super_speed_plus": 10000,
+}
+
+func collectUSBPlatform() []types.USBDevice {
+	for _, dataType := range []string{"SPUSBHostDataType", "SPUSBDataType"} {
+		out, err := sandbox.Command("system_profiler", dataType, "-json").Output()
+		if err != nil {
+			continue
+		}
+		if devices := parseSPUSB(out, dataType); len(devices) > 0 {
+			return devices
+		}
+	}
+	return nil
+}
+
+// parseSPUSB flattens the buses, hubs and devices of a system_profiler USB report into the
+// devices; buses carry no vendor ID and are left out
+func parseSPUSB(output []byte, dataType string) []types.USBDevice {
+	var report map[string][]spUSBItem
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil
+	}
+	var devices []types.USBDevice
+	var walk func(items []spUSBItem)
+	walk = func(items []spUSBItem) {
+		for _, item := range items {
+			if device, ok := spUSBDevice(item); ok {
+				devices = append(devices, device)
+			}
+			walk(item.Items)
+		}
+	}
+	walk(report[dataType])
+	return devices
+}
+
+func spUSBDevice(item spUSBItem) (types.USBDevice, bool) {
+	device := types.USBDevice{
+		Product: strings.TrimSpace(item.Name),
+		Vendor:  strings.TrimSpace(cmp.Or(item.Vendor, item.HostMaker)),
+		Serial:  strings.TrimSpace(cmp.Or(item.Serial, item.HostSerial)),
+	}
+	vendor := cmp.Or(item.VendorID, item.HostVendor)
+	if vendor == "apple_vendor_id" {
+		vendor = "0x05ac"
+		if device.Vendor == "" {
+			device.Vendor = "Apple Inc."
+		}
+	}
+	vendor, vendorName, _ := strings.Cut(vendor, " ")
+	device.VendorID = normalizeHexID(vendor)
+	if device.VendorID == "" {
+		return types.USBDevice{}, false
+	}
+	if device.Vendor == "" {
+		device.Vendor = strings.Trim(strings.TrimSpace(vendorName), "()")
+	}
+	product, _, _ := strings.Cut(cmp.Or(item.ProductID, item.HostProduct), " ")
+	device.ProductID = normalizeHexID(product)
+
+	if speed, ok := spUSBSpeeds[item.Speed]; ok {
+		device.SpeedMbps = speed
+	} else if item.HostSpeed != "" {
+		device.SpeedMbps = linkSpeedMbps(item.HostSpeed)
+	}
+
+	location, _, _ := strings.Cut(cmp.Or(item.LocationID, item.HostLocID), " ")
+	if id, err := strconv.ParseUint(normalizeHexID(location), 16, 32); err == nil && id != 0 {
+		device.Address = "0x" + normalizeHexID(location)
+		device.Bus, device.Port = usbLocation(uint32(id))
+	}
+	return device, true
+}
+
+// usbLocation decodes an IOKit location ID: the bus number in the top byte, then one port
+// number per nibble, from the root hub down, e.g. 0x14120000 is bus 20, port 1.2
+func usbLocation(id uint32) (bus int, port string) {
+	var ports []string
+	for shift := 20; shift >= 0; shift -= 4 {
+		n := id >> shift & 0xf
+		if n == 0 {
+			break
+		}
+		ports = append(ports, strconv.Itoa(int(n)))
+	}
+	return int(id >> 24), strings.Join(ports, ".")
+}
+
+// linkSpeedMbps parses a link speed such as "480 Mb/s" or "5 Gb/s"
+func linkSpeedMbps(speed string) float64 {
+	fields := strings.Fields(speed)
+	if len(fields) != 2 {
+		return 0
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	switch strings.ToLower(fields[1]) {
+	case "gb/s":
+		return value * 1000
+	case "mb/s":
+		return value
+	}
+	return 0
+}
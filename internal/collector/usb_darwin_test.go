@@ -0,0 +1,50 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+const spUSBOutput = `{"SPUSBDataType": [{
+  "_name": "USB31Bus",
+  "host_controller": "AppleT8103USBXHCI",
+  "_items": [{
+    "_name": "USB2.0 Hub",
+    "location_id": "0x01100000 / 1",
+    "product_id": "0x0610",
+    "vendor_id": "0x05e3  (Genesys Logic, Inc.)",
+    "device_speed": "high_speed",
+    "_items": [{
+      "_name": "USB Receiver",
+      "location_id": "0x01120000 / 3",
+      "manufacturer": "Logitech",
+      "product_id": "0xc52b",
+      "vendor_id": "0x046d  (Logitech Inc.)",
+      "serial_num": "ABC123",
+      "device_speed": "full_speed"
+    }]
+  }]
+}]}`
+
+func TestParseSPUSB(t *testing.T) {
+	devices := parseSPUSB([]byte(spUSBOutput), "SPUSBDataType")
+	if len(devices) != 2 {
+		t.Fatalf("parseSPUSB() found %d devices, expected the hub and receiver: %+v", len(devices), devices)
+	}
+	hub, receiver := devices[0], devices[1]
+	if hub.Vendor != "Genesys Logic, Inc." || hub.VendorID != "05e3" || hub.SpeedMbps != 480 || hub.Port != "1" {
+		t.Errorf("hub = %+v", hub)
+	}
+	if receiver.Vendor != "Logitech" || receiver.Product != "USB Receiver" || receiver.ProductID != "c52b" ||
+		receiver.Serial != "ABC123" || receiver.SpeedMbps != 12 || receiver.Bus != 1 || receiver.Port != "1.2" {
+		t.Errorf("receiver = %+v", receiver)
+	}
+}
+
+func TestUSBLocation(t *testing.T) {
+	if bus, port := usbLocation(0x14120000); bus != 20 || port != "1.2" {
+		t.Errorf("usbLocation(0x14120000) = %d, %q, expected 20, 1.2", bus, port)
+	}
+	if got := linkSpeedMbps("5 Gb/s"); got != 5000 {
+		t.Errorf("linkSpeedMbps(5 Gb/s) = %v, expected 5000", got)
+	}
+}
@@ -0,0 +1,178 @@
+//go:build linux
+
+package collector
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// usbIDsPaths are where distributions install usb.ids, the database lsusb names devices from
+var usbIDsPaths = []string{
+	"/usr/share/hwdata/usb.ids",
+	"/usr/share/misc/usb.ids",
+	"/usr/share/usb.ids",
+	"/var/lib/usbutils/usb.ids",
+}
+
+// collectUSBPlatform reads the connected devices from sysfs, naming those whose descriptors
+// carry no manufacturer or product string from usb.ids
+func collectUSBPlatform() []types.USBDevice {
+	devices := scanUSBDevices(hostPath(usbDevicesPath))
+	for _, path := range usbIDsPaths {
+		f, err := os.Open(hostPath(path))
+		if err != nil {
+			continue
+		}
+		lookupUSBIDs(f, devices)
+		f.Close()
+		break
+	}
+	return devices
+}
+
+// scanUSBDevices reads every USB device below root. Root hubs (usb1, usb2, ...) stand for the
+// host controllers and interface entries (1-2:1.0) for parts of a device, so both are skipped
+func scanUSBDevices(root string) []types.USBDevice {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var devices []types.USBDevice
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "usb") || strings.Contains(name, ":") {
+			continue
+		}
+		dir := filepath.Join(root, name)
+		read := func(file string) string {
+			value, _ := readSysFile(filepath.Join(dir, file))
+			return strings.TrimSpace(value)
+		}
+		vendor := normalizeHexID(read("idVendor"))
+		if vendor == "" {
+			continue
+		}
+		device := types.USBDevice{
+			Address:   name,
+			Port:      read("devpath"),
+			VendorID:  vendor,
+			ProductID: normalizeHexID(read("idProduct")),
+			Vendor:    read("manufacturer"),
+			Product:   read("product"),
+			Serial:    read("serial"),
+			SpeedMbps: usbSpeedMbps(read("speed")),
+			Version:   read("version"),
+			Class:     normalizeHexID(read("bDeviceClass")),
+		}
+		device.Bus, _ = strconv.Atoi(read("busnum"))
+
+		classes, drivers := usbInterfaces(root, name)
+		// Class 00 means each interface declares its own
+		if (device.Class == "" || device.Class == "00") && len(classes) > 0 {
+			device.Class = classes[0]
+		}
+		device.Drivers = drivers
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// usbInterfaces returns the classes of a device's interfaces, in order, and the distinct
+// drivers bound to them
+func usbInterfaces(root, device string) (classes, drivers []string) {
+	interfaces, err := filepath.Glob(filepath.Join(root, device+":*"))
+	if err != nil {
+		return nil, nil
+	}
+	seen := map[string]bool{}
+	for _, iface := range interfaces {
+		if class, err := readSysFile(filepath.Join(iface, "bInterfaceClass")); err == nil {
+			classes = append(classes, normalizeHexID(class))
+		}
+		if driver, ok := boundDriver(iface); ok && !seen[driver] {
+			seen[driver] = true
+			drivers = append(drivers, driver)
+		}
+	}
+	return classes, drivers
+}
+
+// usbSpeedMbps reads the negotiated speed sysfs reports in Mb/s, e.g. 480 or 1.5
+func usbSpeedMbps(speed string) float64 {
+	mbps, err := strconv.ParseFloat(speed, 64)
+	if err != nil || mbps <= 0 {
+		return 0
+	}
+	return mbps
+}
+
+// lookupUSBIDs fills in vendor and product names the devices' descriptors leave out from a
+// usb.ids listing: vendor lines "046d  Logitech, Inc." each followed by tab-indented
+// product lines, before the class and other lists
+func lookupUSBIDs(r io.Reader, devices []types.USBDevice) {
+	wanted := map[string]bool{}
+	for _, d := range devices {
+		if d.Vendor == "" || d.Product == "" {
+			wanted[d.VendorID] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return
+	}
+
+	vendors := map[string]string{}
+	products := map[string]string{} // vendor:product IDs to name
+	var vendor string
+	scanner := bufio.NewScanner(r)
+scan:
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" || line[0] == '#' || strings.HasPrefix(line, "\t\t"):
+			continue
+		case line[0] == '\t':
+			if id, name, ok := usbIDsEntry(line[1:]); ok && vendor != "" {
+				products[vendor+":"+id] = name
+			}
+		default:
+			id, name, ok := usbIDsEntry(line)
+			if !ok {
+				break scan
+			}
+			vendor = ""
+			if wanted[id] {
+				vendor, vendors[id] = id, name
+			}
+		}
+	}
+
+	for i := range devices {
+		d := &devices[i]
+		if d.Vendor == "" {
+			d.Vendor = vendors[d.VendorID]
+		}
+		if d.Product == "" {
+			d.Product = products[d.VendorID+":"+d.ProductID]
+		}
+	}
+}
+
+// usbIDsEntry splits a usb.ids line such as "c52b  Unifying Receiver" into its ID and name
+func usbIDsEntry(line string) (id, name string, ok bool) {
+	id, name, found := strings.Cut(line, "  ")
+	if !found || len(id) != 4 {
+		return "", "", false
+	}
+	if _, err := strconv.ParseUint(id, 16, 16); err != nil {
+		return "", "", false
+	}
+	return normalizeHexID(id), strings.TrimSpace(name), true
+}
@@ -0,0 +1,98 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestScanUSBDevices(t *testing.T) {
+	root := t.TempDir()
+	writeEntry := func(name string, files map[string]string, driver string) {
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, file), []byte(content+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if driver != "" {
+			if err := os.Symlink(filepath.Join("drivers", driver), filepath.Join(dir, "driver")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Root hub, left out
+	writeEntry("usb1", map[string]string{"idVendor": "1d6b", "idProduct": "0002", "busnum": "1", "devpath": "0"}, "usb")
+	writeEntry("1-0:1.0", map[string]string{"bInterfaceClass": "09"}, "hub")
+	// A composite receiver, whose interfaces declare the class
+	writeEntry("1-2", map[string]string{
+		"idVendor": "046d", "idProduct": "c52b", "manufacturer": "Logitech", "product": "USB Receiver",
+		"busnum": "1", "devpath": "2", "speed": "12", "version": " 2.00", "bDeviceClass": "00",
+	}, "usb")
+	writeEntry("1-2:1.0", map[string]string{"bInterfaceClass": "03"}, "usbhid")
+	writeEntry("1-2:1.1", map[string]string{"bInterfaceClass": "03"}, "usbhid")
+	writeEntry("1-2:1.2", map[string]string{"bInterfaceClass": "ff"}, "")
+	// A flash drive without descriptor strings, behind a hub
+	writeEntry("2-1.4", map[string]string{
+		"idVendor": "0781", "idProduct": "5581", "serial": "4C530001131210112375",
+		"busnum": "2", "devpath": "1.4", "speed": "5000", "bDeviceClass": "00",
+	}, "usb")
+	writeEntry("2-1.4:1.0", map[string]string{"bInterfaceClass": "08"}, "usb-storage")
+
+	devices := scanUSBDevices(root)
+	if len(devices) != 2 {
+		t.Fatalf("scanUSBDevices() found %d devices, expected 2: %+v", len(devices), devices)
+	}
+	expected := types.USBDevice{
+		Address:   "1-2",
+		Bus:       1,
+		Port:      "2",
+		VendorID:  "046d",
+		ProductID: "c52b",
+		Vendor:    "Logitech",
+		Product:   "USB Receiver",
+		SpeedMbps: 12,
+		Version:   "2.00",
+		Class:     "03",
+		Drivers:   []string{"usbhid"},
+	}
+	if !reflect.DeepEqual(devices[0], expected) {
+		t.Errorf("receiver = %+v, expected %+v", devices[0], expected)
+	}
+	drive := devices[1]
+	if drive.Bus != 2 || drive.Port != "1.4" || drive.SpeedMbps != 5000 || drive.Serial != "4C530001131210112375" ||
+		drive.Class != "08" || !reflect.DeepEqual(drive.Drivers, []string{"usb-storage"}) {
+		t.Errorf("drive = %+v", drive)
+	}
+
+	// usb.ids names what the descriptors leave out, and keeps their own names
+	lookupUSBIDs(strings.NewReader(usbIDs), devices)
+	if devices[1].Vendor != "SanDisk Corp." || devices[1].Product != "Ultra" {
+		t.Errorf("drive = %s %s, expected the usb.ids names", devices[1].Vendor, devices[1].Product)
+	}
+	if devices[0].Vendor != "Logitech" || devices[0].Product != "USB Receiver" {
+		t.Errorf("receiver = %s %s, expected its descriptor's names", devices[0].Vendor, devices[0].Product)
+	}
+}
+
+const usbIDs = `# List of USB ID's
+#
+046d  Logitech, Inc.
+	c52b  Unifying Receiver
+0781  SanDisk Corp.
+	5567  Cruzer Blade
+	5581  Ultra
+		00  interface line
+# List of known device classes, subclasses and protocols
+C 00  (Defined at Interface level)
+0781  Not a vendor
+`
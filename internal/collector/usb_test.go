@@ -0,0 +1,22 @@
+package collector
+
+import "testing"
+
+func TestComparePortPaths(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"2", "2.1", -1}, // A hub comes before the devices on it
+		{"2.3", "2.10", -1},
+		{"3", "2.10", 1},
+		{"1.4", "1.4", 0},
+	}
+	for _, tt := range tests {
+		got := comparePortPaths(tt.a, tt.b)
+		if got < 0 && tt.want >= 0 || got > 0 && tt.want <= 0 || got == 0 && tt.want != 0 {
+			t.Errorf("comparePortPaths(%q, %q) = %d, expected sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//go:build windows
+
+package collector
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+)
+
+// pnpUSBEntity is a USB device as Win32_PnPEntity reports it
+type pnpUSBEntity struct {
+	Name                   string
+	Manufacturer           string
+	PNPDeviceID            string   // e.g. USB\VID_046D&PID_C52B\5&2F1D8C5B&0&2
+	CompatibleID           []string // Includes the class code, e.g. USB\Class_03&SubClass_01
+	Service                string
+	ConfigManagerErrorCode uint32
+}
+
+var pnpUSBClassRe = regexp.MustCompile(`(?i)^USB\\(?:Dev)?Class_([0-9A-F]{2})`)
+
+// collectUSBPlatform lists the USB PnP devices. The interfaces of composite devices
+// (USB\VID_...&MI_00) are left out, as the composite device stands for them, and so are the
+// root hubs, which carry no vendor ID
+func collectUSBPlatform() []types.USBDevice {
+	var entities []pnpUSBEntity
+	query := "SELECT Name, Manufacturer, PNPDeviceID, CompatibleID, Service, ConfigManagerErrorCode FROM Win32_PnPEntity WHERE PNPDeviceID LIKE 'USB\\\\VID%'"
+	if err := wmi.Query(query, &entities); err != nil {
+		return nil
+	}
+
+	var devices []types.USBDevice
+	for _, entity := range entities {
+		if device, ok := pnpUSBDevice(entity); ok {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// pnpUSBDevice reads the IDs from a PnP device ID and the class from the compatible IDs.
+// The instance ID ending the device ID is the device's serial number when it has one;
+// Windows generates one containing '&' for devices without. The driver is only reported
+// while the device is started
+func pnpUSBDevice(entity pnpUSBEntity) (types.USBDevice, bool) {
+	m := pnpUSBIDRe.FindStringSubmatch(entity.PNPDeviceID)
+	if m == nil {
+		return types.USBDevice{}, false
+	}
+	id := strings.TrimSpace(entity.PNPDeviceID)
+	device := types.USBDevice{
+		Address:   id,
+		VendorID:  normalizeHexID(m[1]),
+		ProductID: normalizeHexID(m[2]),
+		Vendor:    strings.TrimSpace(entity.Manufacturer),
+		Product:   strings.TrimSpace(entity.Name),
+	}
+	if instance := id[strings.LastIndex(id, `\`)+1:]; !strings.Contains(instance, "&") {
+		device.Serial = instance
+	}
+	// Generic names Windows gives vendors it has no INF for
+	if strings.HasPrefix(device.Vendor, "(") {
+		device.Vendor = ""
+	}
+	for _, compatible := range entity.CompatibleID {
+		if c := pnpUSBClassRe.FindStringSubmatch(compatible); c != nil {
+			if class := normalizeHexID(c[1]); class != "00" {
+				device.Class = class
+			}
+			break
+		}
+	}
+	if entity.Service != "" && entity.ConfigManagerErrorCode == 0 {
+		device.Drivers = []string{entity.Service}
+	}
+	return device, true
+}
@@ -0,0 +1,50 @@
+//go:build windows
+
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestPnPUSBDevice(t *testing.T) {
+	device, ok := pnpUSBDevice(pnpUSBEntity{
+		Name:         "USB Mass Storage Device",
+		Manufacturer: "Compatible USB storage device",
+		PNPDeviceID:  `USB\VID_0781&PID_5581\4C530001131210112375`,
+		CompatibleID: []string{`USB\Class_08&SubClass_06&Prot_50`, `USB\Class_08&SubClass_06`, `USB\Class_08`},
+		Service:      "USBSTOR",
+	})
+	expected := types.USBDevice{
+		Address:   `USB\VID_0781&PID_5581\4C530001131210112375`,
+		VendorID:  "0781",
+		ProductID: "5581",
+		Vendor:    "Compatible USB storage device",
+		Product:   "USB Mass Storage Device",
+		Serial:    "4C530001131210112375",
+		Class:     "08",
+		Drivers:   []string{"USBSTOR"},
+	}
+	if !ok || !reflect.DeepEqual(device, expected) {
+		t.Errorf("pnpUSBDevice() = %+v, expected %+v", device, expected)
+	}
+
+	// A composite device without a serial, from a vendor Windows has no INF for
+	device, _ = pnpUSBDevice(pnpUSBEntity{
+		Manufacturer: "(Standard USB Host Controller)",
+		PNPDeviceID:  `USB\VID_046D&PID_C52B\5&2F1D8C5B&0&2`,
+		CompatibleID: []string{`USB\DevClass_00&SubClass_00&Prot_00`, `USB\COMPOSITE`},
+		Service:      "usbccgp",
+	})
+	if device.Serial != "" || device.Vendor != "" || device.Class != "" {
+		t.Errorf("pnpUSBDevice() = %+v, expected no serial, vendor or class", device)
+	}
+
+	for _, id := range []string{`USB\ROOT_HUB30\4&1`, `USB\VID_046D&PID_C52B&MI_00\6&1`} {
+		if _, ok := pnpUSBDevice(pnpUSBEntity{PNPDeviceID: id}); ok {
+			t.Errorf("pnpUSBDevice() accepted %s", id)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CollectUsers gathers the sessions of logged-in users, oldest login first, and counts the
+// local accounts
+func CollectUsers() (*types.UserData, error) {
+	data := collectUsersPlatform()
+	if data == nil {
+		return nil, fmt.Errorf("no user sessions or accounts found")
+	}
+	if data.Sessions == nil {
+		data.Sessions = []types.UserSession{}
+	}
+	sort.SliceStable(data.Sessions, func(i, j int) bool {
+		return data.Sessions[i].LoginTime.Before(data.Sessions[j].LoginTime)
+	})
+	return data, nil
+}
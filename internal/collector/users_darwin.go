@@ -0,0 +1,49 @@
+//go:build darwin
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectUsersPlatform reads the sessions from utmpx and the accounts from the local
+// directory service
+func collectUsersPlatform() *types.UserData {
+	sessions, sessionsErr := utmpSessions()
+	var data *types.UserData
+	if out, err := sandbox.Command("dscl", ".", "-list", "/Users", "UniqueID").Output(); err == nil {
+		data = parseDsclUsers(string(out))
+	} else if sessionsErr != nil {
+		return nil
+	} else {
+		data = &types.UserData{}
+	}
+	data.Sessions = sessions
+	return data
+}
+
+// parseDsclUsers counts the accounts `dscl . -list /Users UniqueID` lists, one per line with
+// its UID. Accounts for people start at UID 501; the system's own have lower UIDs, or names
+// starting with _ and negative UIDs such as nobody's -2
+func parseDsclUsers(output string) *types.UserData {
+	data := &types.UserData{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		uid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		data.LocalAccounts++
+		if uid >= 501 && !strings.HasPrefix(fields[0], "_") {
+			data.UserAccounts++
+		}
+	}
+	return data
+}
@@ -0,0 +1,20 @@
+//go:build darwin
+
+package collector
+
+import "testing"
+
+func TestParseDsclUsers(t *testing.T) {
+	output := `_amavisd                 83
+_analyticsd              263
+daemon                   1
+nobody                   -2
+root                     0
+alice                    501
+bob                      502
+`
+	data := parseDsclUsers(output)
+	if data.LocalAccounts != 7 || data.UserAccounts != 2 {
+		t.Errorf("parseDsclUsers() = %+v, expected 7 accounts with alice and bob for people", data)
+	}
+}
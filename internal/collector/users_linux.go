@@ -0,0 +1,74 @@
+//go:build linux
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const loginDefsPath = "/etc/login.defs"
+
+// collectUsersPlatform reads the sessions from utmp and the accounts from /etc/passwd
+func collectUsersPlatform() *types.UserData {
+	sessions, sessionsErr := utmpSessions()
+	data := collectLocalAccounts(hostfs)
+	if data == nil {
+		if sessionsErr != nil {
+			return nil
+		}
+		data = &types.UserData{}
+	}
+	data.Sessions = sessions
+	return data
+}
+
+// collectLocalAccounts counts the accounts in /etc/passwd, and the ones for people by the
+// UID range useradd gives them in /etc/login.defs; nil when /etc/passwd cannot be read
+func collectLocalAccounts(fsys fsReader) *types.UserData {
+	passwd, err := readString(fsys, "/etc/passwd")
+	if err != nil {
+		return nil
+	}
+	uidMin, uidMax := 1000, 60000
+	if defs, err := readString(fsys, loginDefsPath); err == nil {
+		uidMin, uidMax = parseLoginDefsUIDRange(defs, uidMin, uidMax)
+	}
+
+	data := &types.UserData{}
+	for _, line := range strings.Split(passwd, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 7 || strings.HasPrefix(fields[0], "#") || fields[0] == "" {
+			continue
+		}
+		data.LocalAccounts++
+		if uid, err := strconv.Atoi(fields[2]); err == nil && uid >= uidMin && uid <= uidMax {
+			data.UserAccounts++
+		}
+	}
+	return data
+}
+
+// parseLoginDefsUIDRange reads UID_MIN and UID_MAX from login.defs, keeping the defaults for
+// settings it does not have
+func parseLoginDefsUIDRange(content string, uidMin, uidMax int) (int, int) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "UID_MIN":
+			uidMin = value
+		case "UID_MAX":
+			uidMax = value
+		}
+	}
+	return uidMin, uidMax
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const etcPasswd = `root:x:0:0:root:/root:/bin/bash
+daemon:x:1:1:daemon:/usr/sbin:/usr/sbin/nologin
+alice:x:1000:1000:Alice,,,:/home/alice:/bin/bash
+bob:x:1001:1001::/home/bob:/bin/zsh
+svc-build:x:500:500::/srv/build:/bin/sh
+nobody:x:65534:65534:nobody:/nonexistent:/usr/sbin/nologin
+`
+
+func TestParseLoginDefsUIDRange(t *testing.T) {
+	defs := "# UID_MIN 100\nUID_MIN\t\t\t  500\nUID_MAX  bogus\nGID_MIN 500\n"
+	if uidMin, uidMax := parseLoginDefsUIDRange(defs, 1000, 60000); uidMin != 500 || uidMax != 60000 {
+		t.Errorf("parseLoginDefsUIDRange() = %d, %d, expected 500, 60000", uidMin, uidMax)
+	}
+}
+
+func TestCollectLocalAccounts(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "etc"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "etc", "passwd"), []byte(etcPasswd), 0644); err != nil {
+		t.Fatalf("Failed to write passwd: %v", err)
+	}
+
+	data := collectLocalAccounts(hostReader{root: root})
+	if data == nil || data.LocalAccounts != 6 || data.UserAccounts != 2 {
+		t.Fatalf("collectLocalAccounts() = %+v, expected 6 accounts with alice and bob for people", data)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "etc", "login.defs"), []byte("UID_MIN 500\n"), 0644); err != nil {
+		t.Fatalf("Failed to write login.defs: %v", err)
+	}
+	if data := collectLocalAccounts(hostReader{root: root}); data.UserAccounts != 3 {
+		t.Errorf("UserAccounts = %d, expected 3 from UID 500", data.UserAccounts)
+	}
+
+	if data := collectLocalAccounts(hostReader{root: filepath.Join(root, "missing")}); data != nil {
+		t.Errorf("collectLocalAccounts() without /etc/passwd = %+v, expected nil", data)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package collector
+
+import (
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// utmpSessions lists the login sessions recorded in utmp (utmpx on macOS). Graphical logins
+// only appear there when the display manager records them
+func utmpSessions() ([]types.UserSession, error) {
+	users, err := host.Users()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []types.UserSession
+	for _, u := range users {
+		if u.User == "" {
+			continue
+		}
+		sessions = append(sessions, types.UserSession{
+			User:       u.User,
+			Terminal:   u.Terminal,
+			RemoteHost: u.Host,
+			LoginTime:  time.Unix(int64(u.Started), 0),
+		})
+	}
+	return sessions, nil
+}
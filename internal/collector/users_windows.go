@@ -0,0 +1,148 @@
+//go:build windows
+
+package collector
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows"
+)
+
+var procWTSQuerySessionInformation = windows.NewLazySystemDLL("wtsapi32.dll").NewProc("WTSQuerySessionInformationW")
+
+// WTS_INFO_CLASS values queried
+const (
+	wtsClientName  = 10
+	wtsSessionInfo = 24
+)
+
+// WTS_CONNECTSTATE_CLASS values of sessions with a user
+const (
+	wtsActive       = 0
+	wtsDisconnected = 4
+)
+
+// wtsInfo is the WTSINFOW structure describing a session
+type wtsInfo struct {
+	State                   uint32
+	SessionID               uint32
+	IncomingBytes           uint32
+	OutgoingBytes           uint32
+	IncomingFrames          uint32
+	OutgoingFrames          uint32
+	IncomingCompressedBytes uint32
+	OutgoingCompressedBytes uint32
+	WinStationName          [32]uint16
+	Domain                  [17]uint16
+	UserName                [21]uint16
+	_                       uint32 // Aligns the times to 8 bytes as in C, also on 386
+	ConnectTime             int64
+	DisconnectTime          int64
+	LastInputTime           int64
+	LogonTime               int64
+	CurrentTime             int64
+}
+
+// win32UserAccount is a local account
+type win32UserAccount struct {
+	Name     string
+	Disabled bool
+}
+
+// collectUsersPlatform lists the Remote Desktop Services sessions, which include the console,
+// and counts the local accounts
+func collectUsersPlatform() *types.UserData {
+	sessions, sessionsErr := wtsSessions()
+	var accounts []win32UserAccount
+	if err := wmi.Query("SELECT Name, Disabled FROM Win32_UserAccount WHERE LocalAccount = TRUE", &accounts); err != nil && sessionsErr != nil {
+		return nil
+	}
+	data := &types.UserData{Sessions: sessions, LocalAccounts: len(accounts)}
+	for _, account := range accounts {
+		if !account.Disabled {
+			data.UserAccounts++
+		}
+	}
+	return data
+}
+
+// wtsSessions lists the sessions a user is logged on to, connected or not; session 0, where
+// services run, and listeners waiting for connections have no user
+func wtsSessions() ([]types.UserSession, error) {
+	var infos *windows.WTS_SESSION_INFO
+	var count uint32
+	if err := windows.WTSEnumerateSessions(0, 0, 1, &infos, &count); err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(infos)))
+
+	var sessions []types.UserSession
+	for _, s := range unsafe.Slice(infos, count) {
+		info := querySessionInfo(s.SessionID)
+		if info == nil {
+			continue
+		}
+		user := windows.UTF16ToString(info.UserName[:])
+		if user == "" || (info.State != wtsActive && info.State != wtsDisconnected) {
+			continue
+		}
+		if domain := windows.UTF16ToString(info.Domain[:]); domain != "" {
+			user = domain + `\` + user
+		}
+		session := types.UserSession{
+			User:       user,
+			Terminal:   windows.UTF16ToString(info.WinStationName[:]),
+			RemoteHost: sessionClientName(s.SessionID),
+			State:      "active",
+		}
+		if info.State == wtsDisconnected {
+			session.State = "disconnected"
+		}
+		if info.LogonTime > 0 {
+			session.LoginTime = time.Unix(0, (*windows.Filetime)(unsafe.Pointer(&info.LogonTime)).Nanoseconds())
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// querySessionInfo returns the WTSINFOW of a session, copied out of the buffer the call allocates
+func querySessionInfo(sessionID uint32) *wtsInfo {
+	buffer, size := querySession(sessionID, wtsSessionInfo)
+	if buffer == nil {
+		return nil
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buffer)))
+	if size < uint32(unsafe.Sizeof(wtsInfo{})) {
+		return nil
+	}
+	info := *(*wtsInfo)(unsafe.Pointer(buffer))
+	return &info
+}
+
+// sessionClientName returns the name of the computer a Remote Desktop session is connected
+// from; empty for the console
+func sessionClientName(sessionID uint32) string {
+	buffer, _ := querySession(sessionID, wtsClientName)
+	if buffer == nil {
+		return ""
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buffer)))
+	return windows.UTF16PtrToString((*uint16)(unsafe.Pointer(buffer)))
+}
+
+// querySession calls WTSQuerySessionInformationW on the local server, returning the buffer
+// to free with WTSFreeMemory, or nil when the call failed
+func querySession(sessionID uint32, infoClass uint32) (*byte, uint32) {
+	var buffer *byte
+	var size uint32
+	ok, _, _ := procWTSQuerySessionInformation.Call(0, uintptr(sessionID), uintptr(infoClass),
+		uintptr(unsafe.Pointer(&buffer)), uintptr(unsafe.Pointer(&size)))
+	if ok == 0 {
+		return nil, 0
+	}
+	return buffer, size
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestWTSInfoLayout(t *testing.T) {
+	// WTSINFOW: 8 DWORDs, 70 WCHARs of names, then 5 LARGE_INTEGERs aligned to 8 bytes
+	if offset := unsafe.Offsetof(wtsInfo{}.ConnectTime); offset != 176 {
+		t.Errorf("ConnectTime offset = %d, expected 176", offset)
+	}
+	if size := unsafe.Sizeof(wtsInfo{}); size != 216 {
+		t.Errorf("wtsInfo size = %d, expected 216", size)
+	}
+}
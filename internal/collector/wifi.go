@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// attachWiFi sets the association of the wireless interfaces among interfaces
+func attachWiFi(interfaces []types.NetworkInterface) {
+	links := collectWiFiPlatform(interfaces)
+	for i := range interfaces {
+		if link, ok := links[interfaces[i].Name]; ok {
+			interfaces[i].WiFi = &link
+		}
+	}
+}
+
+// completeWiFiLink derives the channel, band and frequency from whichever of them the
+// platform reported
+func completeWiFiLink(link *types.WiFiLink) {
+	if link.FrequencyMHz == 0 && link.Channel > 0 {
+		link.FrequencyMHz = wifiFrequency(link.Channel, link.Band)
+	}
+	if link.FrequencyMHz > 0 {
+		if link.Channel == 0 {
+			link.Channel = wifiChannel(link.FrequencyMHz)
+		}
+		if band := wifiBand(link.FrequencyMHz); band != "" {
+			link.Band = band
+		}
+	}
+}
+
+// wifiChannel returns the IEEE channel number of a center frequency
+func wifiChannel(freqMHz int) int {
+	switch {
+	case freqMHz == 2484:
+		return 14
+	case freqMHz >= 2412 && freqMHz < 2484:
+		return (freqMHz - 2407) / 5
+	case freqMHz >= 5955 && freqMHz <= 7115:
+		return (freqMHz - 5950) / 5
+	case freqMHz >= 5160 && freqMHz <= 5895:
+		return (freqMHz - 5000) / 5
+	case freqMHz >= 4910 && freqMHz <= 4980:
+		return (freqMHz - 4000) / 5 // Japan's 4.9 GHz channels 182-196
+	default:
+		return 0
+	}
+}
+
+// wifiFrequency returns the center frequency of a channel; without a band, channels up to
+// 14 are taken as 2.4 GHz and the others as 5 GHz, since 6 GHz reuses the 5 GHz numbers
+func wifiFrequency(channel int, band string) int {
+	switch {
+	case strings.HasPrefix(band, "6"):
+		return 5950 + 5*channel
+	case channel == 14:
+		return 2484
+	case channel >= 1 && channel <= 13 && !strings.HasPrefix(band, "5"):
+		return 2407 + 5*channel
+	case channel > 0:
+		return 5000 + 5*channel
+	default:
+		return 0
+	}
+}
+
+// wifiBand names the band a frequency is in
+func wifiBand(freqMHz int) string {
+	switch {
+	case freqMHz >= 2400 && freqMHz < 2500:
+		return "2.4 GHz"
+	case freqMHz >= 4900 && freqMHz < 5925:
+		return "5 GHz"
+	case freqMHz >= 5925 && freqMHz < 7125:
+		return "6 GHz"
+	default:
+		return ""
+	}
+}
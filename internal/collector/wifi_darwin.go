@@ -0,0 +1,133 @@
+//go:build darwin
+
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// airportPath is the private framework tool reporting the current association; macOS 14.4
+// removed it
+const airportPath = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
+
+// spAirPortInterface is a Wi-Fi interface in the SPAirPortDataType report
+type spAirPortInterface struct {
+	Name    string `json:"_name"` // BSD name, e.g. en0
+	Status  string `json:"spairport_status_information"`
+	Current *struct {
+		SSID    string `json:"_name"`                     // <redacted> without Location Services permission
+		Channel any    `json:"spairport_network_channel"` // e.g. "36 (5GHz, 80MHz)", a number before macOS 13
+		PHYMode string `json:"spairport_network_phymode"` // e.g. 802.11ax
+		Rate    any    `json:"spairport_network_rate"`    // Mbps
+		Signal  string `json:"spairport_signal_noise"`    // e.g. "-52 dBm / -90 dBm"
+	} `json:"spairport_current_network_information"`
+}
+
+// collectWiFiPlatform lists the Wi-Fi interfaces from system_profiler, then fills in the
+// BSSID and transmit rate from airport where it is still installed
+func collectWiFiPlatform(interfaces []types.NetworkInterface) map[string]types.WiFiLink {
+	out, err := sandbox.Command("system_profiler", "SPAirPortDataType", "-json").Output()
+	if err != nil {
+		return nil
+	}
+	links := parseSPAirPort(out)
+
+	// airport does not name the interface, so it is only matched to a single one
+	if len(links) == 1 {
+		if out, err := sandbox.Command(airportPath, "-I").Output(); err == nil {
+			for name, link := range links {
+				mergeAirportInfo(&link, string(out))
+				links[name] = link
+			}
+		}
+	}
+	return links
+}
+
+// parseSPAirPort reads the association of each interface of a system_profiler
+// SPAirPortDataType report
+func parseSPAirPort(output []byte) map[string]types.WiFiLink {
+	var report struct {
+		Items []struct {
+			Interfaces []spAirPortInterface `json:"spairport_airport_interfaces"`
+		} `json:"SPAirPortDataType"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil
+	}
+
+	links := make(map[string]types.WiFiLink)
+	for _, item := range report.Items {
+		for _, iface := range item.Interfaces {
+			// AWDL and other pseudo-interfaces are listed with no status
+			if iface.Status == "" {
+				continue
+			}
+			link := types.WiFiLink{Connected: iface.Status == "spairport_status_connected"}
+			if current := iface.Current; current != nil && link.Connected {
+				if current.SSID != "<redacted>" {
+					link.SSID = current.SSID
+				}
+				link.Channel, link.Band = parseSPAirPortChannel(fmt.Sprint(current.Channel))
+				link.Standard = current.PHYMode
+				link.TxRateMbps, _ = strconv.ParseFloat(fmt.Sprint(current.Rate), 64)
+				signal, _, _ := strings.Cut(current.Signal, " ")
+				link.SignalDBm, _ = strconv.Atoi(signal)
+			}
+			completeWiFiLink(&link)
+			links[iface.Name] = link
+		}
+	}
+	return links
+}
+
+// parseSPAirPortChannel reads a channel such as "36 (5GHz, 80MHz)" or "6"
+func parseSPAirPortChannel(value string) (int, string) {
+	number, rest, _ := strings.Cut(value, " ")
+	channel, _ := strconv.Atoi(number)
+	band := ""
+	if _, details, found := strings.Cut(rest, "("); found {
+		band, _, _ = strings.Cut(details, ",")
+		band = strings.Replace(strings.TrimSpace(band), "GHz", " GHz", 1)
+	}
+	return channel, band
+}
+
+// mergeAirportInfo adds what `airport -I` reports beyond system_profiler:
+//
+//	agrCtlRSSI: -52
+//	lastTxRate: 867
+//	     BSSID: 3c:37:86:aa:bb:cc
+//	      SSID: HomeNet
+//	   channel: 36,80
+func mergeAirportInfo(link *types.WiFiLink, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "agrCtlRSSI":
+			if rssi, err := strconv.Atoi(value); err == nil && rssi != 0 {
+				link.SignalDBm = rssi
+			}
+		case "lastTxRate":
+			if rate, err := strconv.ParseFloat(value, 64); err == nil && rate > 0 {
+				link.TxRateMbps = rate
+			}
+		case "BSSID":
+			link.BSSID = strings.ToLower(value)
+		case "SSID":
+			if link.SSID == "" {
+				link.SSID = value
+			}
+		}
+	}
+}
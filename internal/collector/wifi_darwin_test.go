@@ -0,0 +1,79 @@
+//go:build darwin
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestParseSPAirPort(t *testing.T) {
+	output := []byte(`{"SPAirPortDataType": [{
+		"spairport_airport_interfaces": [
+			{
+				"_name": "en0",
+				"spairport_status_information": "spairport_status_connected",
+				"spairport_current_network_information": {
+					"_name": "HomeNet",
+					"spairport_network_channel": "36 (5GHz, 80MHz)",
+					"spairport_network_phymode": "802.11ax",
+					"spairport_network_rate": 1200,
+					"spairport_signal_noise": "-52 dBm / -90 dBm"
+				}
+			},
+			{"_name": "awdl0"},
+			{"_name": "en1", "spairport_status_information": "spairport_status_off"}
+		]
+	}]}`)
+	links := parseSPAirPort(output)
+	expected := types.WiFiLink{
+		Connected:    true,
+		SSID:         "HomeNet",
+		FrequencyMHz: 5180,
+		Channel:      36,
+		Band:         "5 GHz",
+		Standard:     "802.11ax",
+		TxRateMbps:   1200,
+		SignalDBm:    -52,
+	}
+	if len(links) != 2 || links["en0"] != expected {
+		t.Errorf("parseSPAirPort() = %+v, expected en0 = %+v", links, expected)
+	}
+	if link := links["en1"]; link.Connected {
+		t.Errorf("en1 = %+v, expected disconnected", link)
+	}
+}
+
+func TestParseSPAirPortChannel(t *testing.T) {
+	tests := []struct {
+		value   string
+		channel int
+		band    string
+	}{
+		{"36 (5GHz, 80MHz)", 36, "5 GHz"},
+		{"6 (2GHz, 20MHz)", 6, "2 GHz"},
+		{"11", 11, ""},
+	}
+	for _, tt := range tests {
+		if channel, band := parseSPAirPortChannel(tt.value); channel != tt.channel || band != tt.band {
+			t.Errorf("parseSPAirPortChannel(%q) = %d, %q, expected %d, %q", tt.value, channel, band, tt.channel, tt.band)
+		}
+	}
+}
+
+func TestMergeAirportInfo(t *testing.T) {
+	output := `     agrCtlRSSI: -48
+     agrExtRSSI: 0
+     lastTxRate: 867
+        maxRate: 867
+          BSSID: 3C:37:86:AA:BB:CC
+           SSID: HomeNet
+        channel: 36,80
+`
+	link := types.WiFiLink{Connected: true, SignalDBm: -52}
+	mergeAirportInfo(&link, output)
+	if link.SignalDBm != -48 || link.TxRateMbps != 867 || link.BSSID != "3c:37:86:aa:bb:cc" || link.SSID != "HomeNet" {
+		t.Errorf("mergeAirportInfo() = %+v", link)
+	}
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package collector
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectWiFiPlatform asks iw for the link of each cfg80211 interface, or NetworkManager
+// when iw is not installed. Both query the network namespace sysinfo runs in, so nothing
+// is reported for another system's files
+func collectWiFiPlatform(interfaces []types.NetworkInterface) map[string]types.WiFiLink {
+	if readingHost() {
+		return nil
+	}
+	links := make(map[string]types.WiFiLink)
+	for _, iface := range interfaces {
+		if !isWirelessInterface(iface.Name) {
+			continue
+		}
+		if out, err := sandbox.Command("iw", "dev", iface.Name, "link").Output(); err == nil {
+			links[iface.Name] = parseIwLink(string(out))
+			continue
+		}
+		// Cached scan results only: a rescan takes seconds and disturbs the link
+		if out, err := sandbox.Command("nmcli", "-t", "-f", "IN-USE,SSID,BSSID,CHAN,FREQ,RATE,SIGNAL",
+			"device", "wifi", "list", "ifname", iface.Name, "--rescan", "no").Output(); err == nil {
+			links[iface.Name] = parseNmcliWiFi(string(out))
+		}
+	}
+	return links
+}
+
+// isWirelessInterface reports whether a network interface is driven by cfg80211
+func isWirelessInterface(name string) bool {
+	dir := hostPath(filepath.Join(netClassPath, name))
+	for _, entry := range []string{"phy80211", "wireless"} {
+		if _, err := os.Stat(filepath.Join(dir, entry)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIwLink parses `iw dev <interface> link`:
+//
+//	Connected to 3c:37:86:aa:bb:cc (on wlan0)
+//		SSID: HomeNet
+//		freq: 5180
+//		signal: -52 dBm
+//		rx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+//		tx bitrate: 780.0 MBit/s VHT-MCS 8 80MHz short GI VHT-NSS 2
+func parseIwLink(output string) types.WiFiLink {
+	var link types.WiFiLink
+	var rxStandard string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if rest, found := strings.CutPrefix(line, "Connected to "); found {
+			link.Connected = true
+			link.BSSID, _, _ = strings.Cut(rest, " ")
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "SSID":
+			link.SSID = value
+		case "freq":
+			// Recent versions print fractional frequencies, e.g. 5180.0
+			if freq, err := strconv.ParseFloat(value, 64); err == nil {
+				link.FrequencyMHz = int(freq)
+			}
+		case "signal":
+			field, _, _ := strings.Cut(value, " ")
+			link.SignalDBm, _ = strconv.Atoi(field)
+		case "rx bitrate":
+			link.RxRateMbps, rxStandard = iwBitrate(value)
+		case "tx bitrate":
+			link.TxRateMbps, link.Standard = iwBitrate(value)
+		}
+	}
+	// Legacy rates name no MCS in one direction while the other uses HT or later
+	link.Standard = cmp.Or(link.Standard, rxStandard)
+	completeWiFiLink(&link)
+	return link
+}
+
+// iwBitrate reads the rate of a bitrate line, e.g. "866.7 MBit/s VHT-MCS 9 80MHz", and the
+// standard its MCS names: EHT is 802.11be, HE 802.11ax, VHT 802.11ac and HT 802.11n
+func iwBitrate(value string) (float64, string) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, ""
+	}
+	rate, _ := strconv.ParseFloat(fields[0], 64)
+	standard := ""
+	for _, field := range fields[1:] {
+		switch field {
+		case "EHT-MCS":
+			standard = "802.11be"
+		case "HE-MCS":
+			standard = "802.11ax"
+		case "VHT-MCS":
+			standard = "802.11ac"
+		case "MCS":
+			standard = "802.11n"
+		}
+		if standard != "" {
+			break
+		}
+	}
+	return rate, standard
+}
+
+// parseNmcliWiFi reads the network marked in use from terse `nmcli device wifi list`
+// output, e.g. "*:HomeNet:3C\:37\:86\:AA\:BB\:CC:36:5180 MHz:540 Mbit/s:92"
+func parseNmcliWiFi(output string) types.WiFiLink {
+	var link types.WiFiLink
+	for _, line := range strings.Split(output, "\n") {
+		fields := splitNmcliFields(line)
+		if len(fields) < 7 || fields[0] != "*" {
+			continue
+		}
+		link = types.WiFiLink{
+			Connected: true,
+			SSID:      fields[1],
+			BSSID:     strings.ToLower(fields[2]),
+		}
+		link.Channel, _ = strconv.Atoi(fields[3])
+		link.FrequencyMHz, _ = strconv.Atoi(strings.TrimSuffix(fields[4], " MHz"))
+		rate, _, _ := strings.Cut(fields[5], " ")
+		link.TxRateMbps, _ = strconv.ParseFloat(rate, 64)
+		link.SignalPercent, _ = strconv.Atoi(fields[6])
+		break
+	}
+	completeWiFiLink(&link)
+	return link
+}
+
+// splitNmcliFields splits a terse nmcli line on the colons that are not escaped
+func splitNmcliFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
@@ -0,0 +1,86 @@
+//go:build linux
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestParseIwLink(t *testing.T) {
+	output := `Connected to 3c:37:86:aa:bb:cc (on wlan0)
+	SSID: HomeNet
+	freq: 5180.0
+	RX: 183738 bytes (1033 packets)
+	TX: 19922 bytes (143 packets)
+	signal: -52 dBm
+	rx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+	tx bitrate: 24.0 MBit/s
+
+	bss flags:	short-slot-time
+	dtim period:	1
+	beacon int:	100
+`
+	expected := types.WiFiLink{
+		Connected:    true,
+		SSID:         "HomeNet",
+		BSSID:        "3c:37:86:aa:bb:cc",
+		FrequencyMHz: 5180,
+		Channel:      36,
+		Band:         "5 GHz",
+		Standard:     "802.11ac",
+		TxRateMbps:   24,
+		RxRateMbps:   866.7,
+		SignalDBm:    -52,
+	}
+	if link := parseIwLink(output); link != expected {
+		t.Errorf("parseIwLink() = %+v, expected %+v", link, expected)
+	}
+
+	if link := parseIwLink("Not connected.\n"); link != (types.WiFiLink{}) {
+		t.Errorf("parseIwLink(not connected) = %+v, expected a disconnected link", link)
+	}
+}
+
+func TestIwBitrate(t *testing.T) {
+	tests := []struct {
+		value    string
+		rate     float64
+		standard string
+	}{
+		{"2401.9 MBit/s 160MHz HE-MCS 11 HE-NSS 2 HE-GI 0 HE-DCM 0", 2401.9, "802.11ax"},
+		{"5764.7 MBit/s 320MHz EHT-MCS 13 EHT-NSS 2 EHT-GI 0", 5764.7, "802.11be"},
+		{"144.4 MBit/s MCS 15 short GI", 144.4, "802.11n"},
+		{"54.0 MBit/s", 54, ""},
+		{"", 0, ""},
+	}
+	for _, tt := range tests {
+		if rate, standard := iwBitrate(tt.value); rate != tt.rate || standard != tt.standard {
+			t.Errorf("iwBitrate(%q) = %v, %q, expected %v, %q", tt.value, rate, standard, tt.rate, tt.standard)
+		}
+	}
+}
+
+func TestParseNmcliWiFi(t *testing.T) {
+	output := ` :Neighbour:11\:22\:33\:44\:55\:66:1:2412 MHz:130 Mbit/s:40
+*:Home\:Net:3C\:37\:86\:AA\:BB\:CC:36:5180 MHz:540 Mbit/s:92
+`
+	expected := types.WiFiLink{
+		Connected:     true,
+		SSID:          "Home:Net",
+		BSSID:         "3c:37:86:aa:bb:cc",
+		FrequencyMHz:  5180,
+		Channel:       36,
+		Band:          "5 GHz",
+		TxRateMbps:    540,
+		SignalPercent: 92,
+	}
+	if link := parseNmcliWiFi(output); link != expected {
+		t.Errorf("parseNmcliWiFi() = %+v, expected %+v", link, expected)
+	}
+
+	if link := parseNmcliWiFi(" :Neighbour:11\\:22\\:33\\:44\\:55\\:66:1:2412 MHz:130 Mbit/s:40\n"); link.Connected {
+		t.Errorf("parseNmcliWiFi() without a network in use = %+v, expected disconnected", link)
+	}
+}
@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestWiFiChannel(t *testing.T) {
+	tests := []struct {
+		freq    int
+		channel int
+		band    string
+	}{
+		{2412, 1, "2.4 GHz"},
+		{2437, 6, "2.4 GHz"},
+		{2484, 14, "2.4 GHz"},
+		{5180, 36, "5 GHz"},
+		{5825, 165, "5 GHz"},
+		{4920, 184, "5 GHz"},
+		{5955, 1, "6 GHz"},
+		{6135, 37, "6 GHz"},
+		{60480, 0, ""},
+	}
+	for _, tt := range tests {
+		if channel := wifiChannel(tt.freq); channel != tt.channel {
+			t.Errorf("wifiChannel(%d) = %d, expected %d", tt.freq, channel, tt.channel)
+		}
+		if band := wifiBand(tt.freq); band != tt.band {
+			t.Errorf("wifiBand(%d) = %q, expected %q", tt.freq, band, tt.band)
+		}
+	}
+}
+
+func TestWiFiFrequency(t *testing.T) {
+	tests := []struct {
+		channel int
+		band    string
+		freq    int
+	}{
+		{6, "", 2437},
+		{14, "", 2484},
+		{36, "", 5180},
+		{36, "5 GHz", 5180},
+		{37, "6 GHz", 6135},
+		{0, "", 0},
+	}
+	for _, tt := range tests {
+		if freq := wifiFrequency(tt.channel, tt.band); freq != tt.freq {
+			t.Errorf("wifiFrequency(%d, %q) = %d, expected %d", tt.channel, tt.band, freq, tt.freq)
+		}
+	}
+}
+
+func TestCompleteWiFiLink(t *testing.T) {
+	link := types.WiFiLink{Channel: 37, Band: "6 GHz"}
+	completeWiFiLink(&link)
+	if link.FrequencyMHz != 6135 || link.Band != "6 GHz" {
+		t.Errorf("completeWiFiLink(channel 37, 6 GHz) = %+v", link)
+	}
+
+	link = types.WiFiLink{FrequencyMHz: 2462}
+	completeWiFiLink(&link)
+	if link.Channel != 11 || link.Band != "2.4 GHz" {
+		t.Errorf("completeWiFiLink(2462 MHz) = %+v", link)
+	}
+
+	link = types.WiFiLink{}
+	completeWiFiLink(&link)
+	if link != (types.WiFiLink{}) {
+		t.Errorf("completeWiFiLink(empty) = %+v, expected it unchanged", link)
+	}
+}
@@ -0,0 +1,79 @@
+//go:build windows
+
+package collector
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/sandbox"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// collectWiFiPlatform reads the WLAN AutoConfig service's view of each wireless interface
+func collectWiFiPlatform(interfaces []types.NetworkInterface) map[string]types.WiFiLink {
+	out, err := sandbox.Command("netsh", "wlan", "show", "interfaces").Output()
+	if err != nil {
+		return nil
+	}
+	return parseNetshWLAN(string(out))
+}
+
+// parseNetshWLAN parses `netsh wlan show interfaces`, a block of "Key : Value" lines per
+// interface starting with its name. The labels are English; other display languages
+// translate them and are not recognized
+//
+//	Name                   : Wi-Fi
+//	State                  : connected
+//	SSID                   : HomeNet
+//	AP BSSID               : 3c:37:86:aa:bb:cc
+//	Radio type             : 802.11ax
+//	Band                   : 5 GHz
+//	Channel                : 36
+//	Receive rate (Mbps)    : 1201
+//	Transmit rate (Mbps)   : 960
+//	Signal                 : 92%
+func parseNetshWLAN(output string) map[string]types.WiFiLink {
+	links := make(map[string]types.WiFiLink)
+	var name string
+	var link types.WiFiLink
+	flush := func() {
+		if name != "" {
+			completeWiFiLink(&link)
+			links[name] = link
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "Name":
+			flush()
+			name, link = value, types.WiFiLink{}
+		case "State":
+			link.Connected = value == "connected"
+		case "SSID":
+			link.SSID = value
+		case "BSSID", "AP BSSID": // Windows 11 renamed the label
+			link.BSSID = strings.ToLower(value)
+		case "Radio type":
+			link.Standard = value
+		case "Band":
+			link.Band = value
+		case "Channel":
+			link.Channel, _ = strconv.Atoi(value)
+		case "Receive rate (Mbps)":
+			link.RxRateMbps, _ = strconv.ParseFloat(value, 64)
+		case "Transmit rate (Mbps)":
+			link.TxRateMbps, _ = strconv.ParseFloat(value, 64)
+		case "Signal":
+			link.SignalPercent, _ = strconv.Atoi(strings.TrimSuffix(value, "%"))
+		}
+	}
+	flush()
+	return links
+}
@@ -0,0 +1,62 @@
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestParseNetshWLAN(t *testing.T) {
+	output := `
+There are 2 interfaces on the system:
+
+    Name                   : Wi-Fi
+    Description            : Intel(R) Wi-Fi 6E AX211 160MHz
+    GUID                   : 0f3b2a7c-1d7e-4c5b-9a55-2b6f1c9e0a11
+    Physical address       : 8c:17:59:11:22:33
+    Interface type         : Primary
+    State                  : connected
+    SSID                   : HomeNet
+    AP BSSID               : 3C:37:86:AA:BB:CC
+    Band                   : 5 GHz
+    Channel                : 36
+    Network type           : Infrastructure
+    Radio type             : 802.11ax
+    Authentication         : WPA2-Personal
+    Cipher                 : CCMP
+    Connection mode        : Auto Connect
+    Receive rate (Mbps)    : 1201
+    Transmit rate (Mbps)   : 960
+    Signal                 : 92%
+    Profile                : HomeNet
+
+    Name                   : Wi-Fi 2
+    Description            : TP-Link Wireless USB Adapter
+    State                  : disconnected
+    Radio status           : Hardware On
+                             Software On
+
+    Hosted network status  : Not available
+`
+	links := parseNetshWLAN(output)
+	expected := types.WiFiLink{
+		Connected:     true,
+		SSID:          "HomeNet",
+		BSSID:         "3c:37:86:aa:bb:cc",
+		FrequencyMHz:  5180,
+		Channel:       36,
+		Band:          "5 GHz",
+		Standard:      "802.11ax",
+		TxRateMbps:    960,
+		RxRateMbps:    1201,
+		SignalPercent: 92,
+	}
+	if len(links) != 2 || links["Wi-Fi"] != expected {
+		t.Errorf("parseNetshWLAN() = %+v, expected Wi-Fi = %+v", links, expected)
+	}
+	if link, ok := links["Wi-Fi 2"]; !ok || link.Connected {
+		t.Errorf("Wi-Fi 2 = %+v, expected a disconnected interface", link)
+	}
+}
@@ -1,22 +1,89 @@
 package config
 
+import "fmt"
+
 // Config holds the runtime configuration for the application
 type Config struct {
-	// Output format: json, text, pretty
+	// Output format: json, ndjson, text, pretty, html, csv, prometheus, influx, template, xml, msgpack, dot, parquet, sqlite
 	Format string
 
+	// Tabular section emitted by the csv format: disk, process, network, smart, sensors, pci, usb (empty means all)
+	Section string
+
+	// jq-style path whose values are written instead of the report, e.g. .cpu.model_name
+	Query string
+
+	// Dotted JSON field paths the report is pruned to before formatting, e.g. system.hostname
+	Fields []string
+
+	// Minified output for the json format, for piping into other tools and smaller log lines
+	Compact bool
+
+	// Mask serial numbers, MAC and IP addresses, the hostname and UUIDs, for sharing reports publicly
+	Redact bool
+
+	// Measurement name prefix for the influx format (empty means sysinfo_)
+	InfluxPrefix string
+
+	// text/template file rendered by the template format
+	TemplateFile string
+
 	// Output file path (empty means stdout)
 	OutputFile string
 
+	// Output sinks from the config file; when set they replace the single stdout/OutputFile destination
+	Outputs []OutputConfig
+
 	// Verbosity level
 	Verbose bool
 
+	// Deterministic output: sorted lists and a fixed timestamp so runs can be diffed
+	Stable bool
+
+	// Report timestamp override in RFC 3339 (empty means collection time, or the epoch with Stable)
+	Timestamp string
+
+	// Report times in UTC instead of local time
+	UTC bool
+
+	// Size units: binary (KiB, 1024-based) or decimal (KB, 1000-based); empty keeps 1024-based sizes labelled KB
+	ByteUnits string
+
+	// Directory the host's / is read from on Linux, e.g. where a container has it mounted
+	HostRoot string
+
+	// Directory sysfs is read from on Linux instead of /sys, e.g. a host's /sys mounted into a container
+	SysfsRoot string
+
+	// How report timestamps are written: rfc3339, unix or none (empty keeps each format's default)
+	TimestampFormat string
+
+	// Time server queried by the timesync module (empty means pool.ntp.org)
+	NTPServer string
+
+	// Files hashed by the integrity module, with glob patterns (empty means the platform's defaults)
+	IntegrityPaths []string
+
 	// Full dump mode - collect everything and save to JSON file
 	FullDumpToFile bool
 
 	// Module selection flags
 	Modules ModuleConfig
 
+	// Process capture options (off by default, values are redacted)
+	ProcessCmdline bool     // Capture command lines of the top processes
+	ProcessEnv     []string // Environment variable names to capture from the top processes
+
+	// Characters of process names shown in pretty output; 0 keeps the default of 30
+	ProcessNameWidth int
+
+	// Noise estimation from fan speeds (thermal module)
+	FanModels    []FanModel // Fan models to base estimates on, first match wins
+	NoiseHistory bool       // Record the estimate in the history database and report its trend
+
+	// Host health score component weights, by component (unset components keep their defaults)
+	HealthWeights map[string]float64
+
 	// SMART analysis options
 	SMARTAnalyze       bool   // Perform deep SMART analysis
 	SMARTHistory       bool   // Show historical trends
@@ -25,18 +92,107 @@ type Config struct {
 	SMARTAlerts        bool   // Check and send alerts
 }
 
+// OutputConfig describes one output sink
+type OutputConfig struct {
+	Type    string            `yaml:"type"`              // stdout, file, webhook, scrutiny, homeassistant
+	Format  string            `yaml:"format,omitempty"`  // json, ndjson, text, pretty, html, csv, prometheus, influx, template, xml, msgpack, dot, parquet, sqlite (default: the global format)
+	Path    string            `yaml:"path,omitempty"`    // Destination for file sinks
+	URL     string            `yaml:"url,omitempty"`     // Endpoint for webhook sinks, server base URL for scrutiny sinks, MQTT broker for homeassistant sinks
+	Headers map[string]string `yaml:"headers,omitempty"` // Extra HTTP headers for webhook and scrutiny sinks
+	Timeout int               `yaml:"timeout,omitempty"` // Webhook timeout in seconds (default: 30)
+	HostID  string            `yaml:"host_id,omitempty"` // Host label for scrutiny and homeassistant sinks
+
+	// Home Assistant MQTT discovery prefix for homeassistant sinks (default: homeassistant)
+	DiscoveryPrefix string `yaml:"discovery_prefix,omitempty"`
+
+	// Delta sends a JSON merge patch against the last acknowledged report instead of the full report
+	Delta     bool   `yaml:"delta,omitempty"`
+	StatePath string `yaml:"state_path,omitempty"` // Where the acknowledged baseline is kept (default: user cache dir)
+}
+
+// FanModel rates a fan's noise at full speed; the estimate scales it by the fan laws
+type FanModel struct {
+	Match  string  `yaml:"match"`   // Substring of the fan name (case-insensitive)
+	Name   string  `yaml:"name"`    // Shown in reports, e.g. "Noctua NF-A12x25"
+	MaxRPM float64 `yaml:"max_rpm"` // Rated maximum speed
+	MaxDBA float64 `yaml:"max_dba"` // Rated noise at MaxRPM in dB(A)
+}
+
+// AgentToken grants one API consumer access to a set of modules in agent mode
+type AgentToken struct {
+	Name    string   `yaml:"name"`              // Shown in logs
+	Token   string   `yaml:"token"`             // Bearer token presented by the consumer
+	Modules []string `yaml:"modules"`           // Module names, or "all"
+	Serials bool     `yaml:"serials,omitempty"` // Include serial numbers and UUIDs
+}
+
+// CommandPolicy restricts how external tools such as smartctl and nvidia-smi are run
+type CommandPolicy struct {
+	Allow      []string `yaml:"allow,omitempty"`      // Tools that may run, by name or absolute path; empty allows all
+	Restricted bool     `yaml:"restricted,omitempty"` // Resolve tools from trusted paths only and clear their environment
+	Paths      []string `yaml:"paths,omitempty"`      // Trusted directories for restricted mode (default: system directories)
+	Landlock   bool     `yaml:"landlock,omitempty"`   // Linux: tools get a read-only filesystem apart from /dev
+	AuditLog   string   `yaml:"audit_log,omitempty"`  // JSON lines file recording every command run; empty disables auditing
+}
+
+// ScheduledTask runs one agent task periodically
+type ScheduledTask struct {
+	Task      string `yaml:"task"`                // collect, smart_analyze, disk_usage, gpu_memory, prune or push
+	Cron      string `yaml:"cron"`                // Five-field cron expression, @daily-style descriptor or "@every 10m"
+	Retention string `yaml:"retention,omitempty"` // prune: history older than this is deleted (default: 90d)
+}
+
+// DiskGrowthRule is a rate-of-change alert on partition usage, checked by the agent's
+// disk_usage task against the usage it recorded in the history database
+type DiskGrowthRule struct {
+	Mount    string  `yaml:"mount,omitempty"` // Mount point or glob, e.g. /var/*; empty for every partition
+	Increase float64 `yaml:"increase"`        // Rise in used percentage points that fires the rule, e.g. 5
+	Window   string  `yaml:"window"`          // Period the rise is measured over, e.g. 1h
+	Level    string  `yaml:"level,omitempty"` // WARNING (default) or CRITICAL
+}
+
+// VRAMLeakCheck tunes the GPU memory leak detection of the agent's gpu_memory task
+type VRAMLeakCheck struct {
+	Window        string `yaml:"window,omitempty"`          // Period the growth is looked for in (default: 1h)
+	Samples       int    `yaml:"samples,omitempty"`         // Readings the growth must span (default: 6)
+	MinIncreaseMB int    `yaml:"min_increase_mb,omitempty"` // Growth in MiB below which it is not reported (default: 512)
+}
+
+// AgentBuffers caps what the agent holds in memory; zero keeps the default
+type AgentBuffers struct {
+	HistoryRecords int `yaml:"history_records,omitempty"` // Most SMART history rows or alerts one dashboard request loads (default: 1000)
+	EventBytes     int `yaml:"event_bytes,omitempty"`     // Encoding buffer an event stream keeps between samples (default: 65536)
+}
+
 // ModuleConfig controls which information modules to collect
 type ModuleConfig struct {
-	All     bool
-	System  bool
-	CPU     bool
-	Memory  bool
-	Disk    bool
-	Network bool
-	Process bool
-	SMART   bool
-	GPU     bool
-	Battery bool
+	All         bool
+	System      bool
+	CPU         bool
+	Memory      bool
+	Disk        bool
+	Network     bool
+	Process     bool
+	SMART       bool
+	GPU         bool
+	Battery     bool
+	Security    bool
+	Accelerator bool
+	Thermal     bool
+	Sensors     bool
+	Baseboard   bool
+	PCI         bool
+	USB         bool
+	Displays    bool
+	Audio       bool
+	Bluetooth   bool
+	Drivers     bool
+	Services    bool
+	Firewall    bool
+	Users       bool
+	Packages    bool // Opt-in: not part of All because the inventory runs to thousands of entries
+	Integrity   bool // Opt-in: not part of All because it reads every configured file in full
+	TimeSync    bool // Opt-in: not part of All because it queries a network time server
 }
 
 // NewConfig creates a default configuration
@@ -51,32 +207,137 @@ func NewConfig() *Config {
 	}
 }
 
+// ModuleNames lists every selectable module
+var ModuleNames = []string{"system", "cpu", "memory", "disk", "network", "process", "smart", "gpu", "battery", "security", "accelerator", "thermal", "sensors", "baseboard", "pci", "usb", "displays", "audio", "bluetooth", "drivers", "services", "firewall", "users", "packages", "integrity", "timesync"}
+
 // ShouldCollect determines if a module should be collected
 func (c *Config) ShouldCollect(module string) bool {
-	if c.Modules.All {
+	return c.Modules.Includes(module)
+}
+
+// Includes reports whether a module is selected
+func (m ModuleConfig) Includes(module string) bool {
+	if m.All && module != "timesync" && module != "integrity" && module != "packages" {
 		return true
 	}
 
 	switch module {
 	case "system":
-		return c.Modules.System
+		return m.System
 	case "cpu":
-		return c.Modules.CPU
+		return m.CPU
 	case "memory":
-		return c.Modules.Memory
+		return m.Memory
 	case "disk":
-		return c.Modules.Disk
+		return m.Disk
 	case "network":
-		return c.Modules.Network
+		return m.Network
 	case "process":
-		return c.Modules.Process
+		return m.Process
 	case "smart":
-		return c.Modules.SMART
+		return m.SMART
 	case "gpu":
-		return c.Modules.GPU
+		return m.GPU
 	case "battery":
-		return c.Modules.Battery
+		return m.Battery
+	case "security":
+		return m.Security
+	case "accelerator":
+		return m.Accelerator
+	case "thermal":
+		return m.Thermal
+	case "sensors":
+		return m.Sensors
+	case "baseboard":
+		return m.Baseboard
+	case "pci":
+		return m.PCI
+	case "usb":
+		return m.USB
+	case "displays":
+		return m.Displays
+	case "audio":
+		return m.Audio
+	case "bluetooth":
+		return m.Bluetooth
+	case "drivers":
+		return m.Drivers
+	case "services":
+		return m.Services
+	case "firewall":
+		return m.Firewall
+	case "users":
+		return m.Users
+	case "packages":
+		return m.Packages
+	case "integrity":
+		return m.Integrity
+	case "timesync":
+		return m.TimeSync
 	default:
 		return false
 	}
 }
+
+// Enable turns on a module by name, "all" turns on every module
+func (m *ModuleConfig) Enable(module string) error {
+	switch module {
+	case "all":
+		m.All = true
+	case "system":
+		m.System = true
+	case "cpu":
+		m.CPU = true
+	case "memory":
+		m.Memory = true
+	case "disk":
+		m.Disk = true
+	case "network":
+		m.Network = true
+	case "process":
+		m.Process = true
+	case "smart":
+		m.SMART = true
+	case "gpu":
+		m.GPU = true
+	case "battery":
+		m.Battery = true
+	case "security":
+		m.Security = true
+	case "accelerator":
+		m.Accelerator = true
+	case "thermal":
+		m.Thermal = true
+	case "sensors":
+		m.Sensors = true
+	case "baseboard":
+		m.Baseboard = true
+	case "pci":
+		m.PCI = true
+	case "usb":
+		m.USB = true
+	case "displays":
+		m.Displays = true
+	case "audio":
+		m.Audio = true
+	case "bluetooth":
+		m.Bluetooth = true
+	case "drivers":
+		m.Drivers = true
+	case "services":
+		m.Services = true
+	case "firewall":
+		m.Firewall = true
+	case "users":
+		m.Users = true
+	case "packages":
+		m.Packages = true
+	case "integrity":
+		m.Integrity = true
+	case "timesync":
+		m.TimeSync = true
+	default:
+		return fmt.Errorf("unknown module: %s", module)
+	}
+	return nil
+}
@@ -51,6 +51,14 @@ func TestShouldCollect(t *testing.T) {
 			module:   "cpu",
 			expected: true,
 		},
+		{
+			name: "all modules enabled - opt-in packages",
+			config: &Config{
+				Modules: ModuleConfig{All: true},
+			},
+			module:   "packages",
+			expected: false,
+		},
 		{
 			name: "only system module",
 			config: &Config{
@@ -140,6 +148,17 @@ func TestShouldCollect(t *testing.T) {
 			module:   "smart",
 			expected: true,
 		},
+		{
+			name: "security module enabled",
+			config: &Config{
+				Modules: ModuleConfig{
+					All:      false,
+					Security: true,
+				},
+			},
+			module:   "security",
+			expected: true,
+		},
 		{
 			name: "unknown module",
 			config: &Config{
@@ -228,3 +247,23 @@ func TestConfigFields(t *testing.T) {
 		t.Error("ShouldCollect(memory) = true; want false")
 	}
 }
+
+func TestModuleConfigEnable(t *testing.T) {
+	for _, module := range ModuleNames {
+		var modules ModuleConfig
+		if err := modules.Enable(module); err != nil {
+			t.Errorf("Enable(%q) error = %v", module, err)
+		}
+		if !modules.Includes(module) {
+			t.Errorf("Enable(%q) did not select the module", module)
+		}
+	}
+
+	var modules ModuleConfig
+	if err := modules.Enable("all"); err != nil || !modules.All {
+		t.Errorf("Enable(all) = %v, All = %v", err, modules.All)
+	}
+	if err := modules.Enable("bogus"); err == nil {
+		t.Error("Enable(bogus) expected error")
+	}
+}
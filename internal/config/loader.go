@@ -16,20 +16,70 @@ type FileConfig struct {
 	// Default output file
 	OutputFile string `yaml:"output_file,omitempty"`
 
+	// InfluxDB line protocol options
+	Influx struct {
+		Prefix string `yaml:"prefix,omitempty"` // Measurement name prefix (default: sysinfo_)
+	} `yaml:"influx,omitempty"`
+
+	// text/template custom output format
+	Template struct {
+		File string `yaml:"file,omitempty"` // Template rendered by the template format
+	} `yaml:"template,omitempty"`
+
+	// Output sinks used together in one run (replaces output_file)
+	Outputs []OutputConfig `yaml:"outputs,omitempty"`
+
 	// Verbosity
 	Verbose bool `yaml:"verbose,omitempty"`
 
+	// Deterministic output for diffing and checksumming reports
+	Stable bool `yaml:"stable,omitempty"`
+
+	// Minified JSON for the json format
+	Compact bool `yaml:"compact,omitempty"`
+
+	// Mask serial numbers, MAC and IP addresses, the hostname and UUIDs in every report
+	Redact bool `yaml:"redact,omitempty"`
+
+	// Size units in formatted fields and text output: binary or decimal
+	Units string `yaml:"units,omitempty"`
+
+	// Where the host's / and /sys are read from on Linux, e.g. when mounted into a container
+	HostRoot  string `yaml:"host_root,omitempty"`
+	SysfsRoot string `yaml:"sysfs_root,omitempty"`
+
+	// Timestamp handling: report times in UTC, written as rfc3339, unix or none
+	UTC             bool   `yaml:"utc,omitempty"`
+	TimestampFormat string `yaml:"timestamp_format,omitempty"`
+
 	// Default modules to collect
 	Modules struct {
-		System  bool `yaml:"system,omitempty"`
-		CPU     bool `yaml:"cpu,omitempty"`
-		Memory  bool `yaml:"memory,omitempty"`
-		Disk    bool `yaml:"disk,omitempty"`
-		Network bool `yaml:"network,omitempty"`
-		Process bool `yaml:"process,omitempty"`
-		SMART   bool `yaml:"smart,omitempty"`
-		GPU     bool `yaml:"gpu,omitempty"`
-		Battery bool `yaml:"battery,omitempty"`
+		System      bool `yaml:"system,omitempty"`
+		CPU         bool `yaml:"cpu,omitempty"`
+		Memory      bool `yaml:"memory,omitempty"`
+		Disk        bool `yaml:"disk,omitempty"`
+		Network     bool `yaml:"network,omitempty"`
+		Process     bool `yaml:"process,omitempty"`
+		SMART       bool `yaml:"smart,omitempty"`
+		GPU         bool `yaml:"gpu,omitempty"`
+		Battery     bool `yaml:"battery,omitempty"`
+		Security    bool `yaml:"security,omitempty"`
+		Accelerator bool `yaml:"accelerator,omitempty"`
+		Thermal     bool `yaml:"thermal,omitempty"`
+		Sensors     bool `yaml:"sensors,omitempty"`
+		Baseboard   bool `yaml:"baseboard,omitempty"`
+		PCI         bool `yaml:"pci,omitempty"`
+		USB         bool `yaml:"usb,omitempty"`
+		Displays    bool `yaml:"displays,omitempty"`
+		Audio       bool `yaml:"audio,omitempty"`
+		Bluetooth   bool `yaml:"bluetooth,omitempty"`
+		Drivers     bool `yaml:"drivers,omitempty"`
+		Services    bool `yaml:"services,omitempty"`
+		Firewall    bool `yaml:"firewall,omitempty"`
+		Users       bool `yaml:"users,omitempty"`
+		Packages    bool `yaml:"packages,omitempty"`
+		Integrity   bool `yaml:"integrity,omitempty"`
+		TimeSync    bool `yaml:"timesync,omitempty"`
 	} `yaml:"modules,omitempty"`
 
 	// SMART monitoring configuration
@@ -42,17 +92,96 @@ type FileConfig struct {
 		} `yaml:"alert_thresholds,omitempty"`
 		WebhookURL string `yaml:"webhook_url,omitempty"`
 		DBPath     string `yaml:"db_path,omitempty"` // Custom history database path
+
+		// Leave drives in standby asleep when polling (smart analyze and agent schedule); Linux/macOS
+		SkipStandby bool `yaml:"skip_standby,omitempty"`
+		Endurance   []struct {
+			Model string  `yaml:"model"` // Substring of the drive model
+			TBW   float64 `yaml:"tbw"`   // Rated terabytes written
+		} `yaml:"endurance,omitempty"` // Drive TBW warranty ratings
 	} `yaml:"smart,omitempty"`
 
 	// Process monitoring configuration
 	Process struct {
-		TopCount int `yaml:"top_count,omitempty"` // Number of top processes to show
+		TopCount       int      `yaml:"top_count,omitempty"`       // Number of top processes to show
+		CaptureCmdline bool     `yaml:"capture_cmdline,omitempty"` // Capture command lines of top processes
+		EnvAllowlist   []string `yaml:"env_allowlist,omitempty"`   // Environment variables to capture from top processes
+		NameWidth      int      `yaml:"name_width,omitempty"`      // Characters of process names shown in pretty output
 	} `yaml:"process,omitempty"`
 
+	// Clock offset measurement (timesync module)
+	TimeSync struct {
+		Server         string `yaml:"server,omitempty"`          // NTP server (default: pool.ntp.org)
+		CorrectHistory bool   `yaml:"correct_history,omitempty"` // Store SMART history times corrected by the measured offset
+	} `yaml:"timesync,omitempty"`
+
+	// File checksums (integrity module)
+	Integrity struct {
+		Paths []string `yaml:"paths,omitempty"` // Files and glob patterns to hash (default: critical system binaries and configs)
+	} `yaml:"integrity,omitempty"`
+
+	// Noise estimation from fan speeds (thermal module)
+	Noise struct {
+		Fans    []FanModel `yaml:"fans,omitempty"`    // Fan models, matched against fan names in order
+		History bool       `yaml:"history,omitempty"` // Track the estimate in the history database
+	} `yaml:"noise,omitempty"`
+
+	// Composite host health score
+	Health struct {
+		Weights map[string]float64 `yaml:"weights,omitempty"` // smart, disk, memory, temperature, services; 0 leaves one out
+	} `yaml:"health,omitempty"`
+
+	// External command policy
+	Commands CommandPolicy `yaml:"commands,omitempty"`
+
 	// Display preferences
 	Display struct {
 		UseASCII bool `yaml:"use_ascii,omitempty"` // Force ASCII output instead of Unicode
 	} `yaml:"display,omitempty"`
+
+	// Agent mode configuration
+	Agent struct {
+		Tokens   []AgentToken    `yaml:"tokens,omitempty"`   // API tokens; when empty the agent is open to anyone who can reach it
+		Schedule []ScheduledTask `yaml:"schedule,omitempty"` // Periodic tasks run by the agent
+
+		// Privilege dropping (Linux): when started as root, serve as this user after binding
+		User         string   `yaml:"user,omitempty"`
+		Group        string   `yaml:"group,omitempty"`        // Default: the user's primary group
+		Capabilities []string `yaml:"capabilities,omitempty"` // Kept after dropping (default: CAP_SYS_RAWIO)
+
+		// Disk and USB hot-swap events, recorded in the history database
+		Hotplug struct {
+			Enabled bool `yaml:"enabled,omitempty"`
+			Alerts  bool `yaml:"alerts,omitempty"` // Send removals to smart.webhook_url
+		} `yaml:"hotplug,omitempty"`
+		Network struct {
+			Enabled    bool   `yaml:"enabled,omitempty"`
+			Alerts     bool   `yaml:"alerts,omitempty"`      // Send flapping interfaces to smart.webhook_url
+			FlapCount  int    `yaml:"flap_count,omitempty"`  // Link downs that count as flapping (default 3)
+			FlapWindow string `yaml:"flap_window,omitempty"` // Window the downs must fall in (default 10m)
+		} `yaml:"network,omitempty"`
+
+		// Rate-of-change alerts on partition usage, checked by the disk_usage task
+		DiskGrowth []DiskGrowthRule `yaml:"disk_growth,omitempty"`
+
+		// GPU memory leak detection, checked by the gpu_memory task
+		VRAMLeak VRAMLeakCheck `yaml:"vram_leak,omitempty"`
+
+		// Caps on the dashboard's history reads and the event streams' buffers
+		Buffers AgentBuffers `yaml:"buffers,omitempty"`
+	} `yaml:"agent,omitempty"`
+
+	// Fleet alert aggregation (sysinfo alerts server)
+	Alerts struct {
+		Server struct {
+			Listen     string `yaml:"listen,omitempty"`      // Default: :9102
+			DBPath     string `yaml:"db_path,omitempty"`     // Default: same as the smart commands
+			ForwardURL string `yaml:"forward_url,omitempty"` // Downstream webhook new alerts are sent to
+			MinLevel   string `yaml:"min_level,omitempty"`   // Lowest level forwarded: INFO, WARNING or CRITICAL (default WARNING)
+			Dedupe     string `yaml:"dedupe,omitempty"`      // Window repeats from a host and device are folded over (default 1h)
+			Token      string `yaml:"token,omitempty"`       // Shared secret senders present; when empty the server is open
+		} `yaml:"server,omitempty"`
+	} `yaml:"alerts,omitempty"`
 }
 
 // LoadConfigFile attempts to load configuration from file
@@ -108,14 +237,91 @@ func (c *Config) MergeWithFileConfig(fileConfig *FileConfig) {
 		c.Format = fileConfig.Format
 	}
 
-	if c.OutputFile == "" && fileConfig.OutputFile != "" {
+	// An explicit --output replaces the configured sink list
+	if c.OutputFile == "" && len(fileConfig.Outputs) > 0 {
+		c.Outputs = fileConfig.Outputs
+	}
+
+	if c.OutputFile == "" && len(c.Outputs) == 0 && fileConfig.OutputFile != "" {
 		c.OutputFile = fileConfig.OutputFile
 	}
 
+	if c.InfluxPrefix == "" && fileConfig.Influx.Prefix != "" {
+		c.InfluxPrefix = fileConfig.Influx.Prefix
+	}
+
+	if c.TemplateFile == "" && fileConfig.Template.File != "" {
+		c.TemplateFile = fileConfig.Template.File
+	}
+
 	if !c.Verbose && fileConfig.Verbose {
 		c.Verbose = fileConfig.Verbose
 	}
 
+	if !c.Stable && fileConfig.Stable {
+		c.Stable = true
+	}
+
+	if !c.Compact && fileConfig.Compact {
+		c.Compact = true
+	}
+
+	if !c.Redact && fileConfig.Redact {
+		c.Redact = true
+	}
+
+	if !c.UTC && fileConfig.UTC {
+		c.UTC = true
+	}
+
+	if c.ByteUnits == "" && fileConfig.Units != "" {
+		c.ByteUnits = fileConfig.Units
+	}
+
+	if c.HostRoot == "" && fileConfig.HostRoot != "" {
+		c.HostRoot = fileConfig.HostRoot
+	}
+
+	if c.SysfsRoot == "" && fileConfig.SysfsRoot != "" {
+		c.SysfsRoot = fileConfig.SysfsRoot
+	}
+
+	if c.TimestampFormat == "" && fileConfig.TimestampFormat != "" {
+		c.TimestampFormat = fileConfig.TimestampFormat
+	}
+
+	if c.NTPServer == "" && fileConfig.TimeSync.Server != "" {
+		c.NTPServer = fileConfig.TimeSync.Server
+	}
+
+	if len(c.IntegrityPaths) == 0 && len(fileConfig.Integrity.Paths) > 0 {
+		c.IntegrityPaths = fileConfig.Integrity.Paths
+	}
+
+	if !c.ProcessCmdline && fileConfig.Process.CaptureCmdline {
+		c.ProcessCmdline = true
+	}
+
+	if len(c.ProcessEnv) == 0 && len(fileConfig.Process.EnvAllowlist) > 0 {
+		c.ProcessEnv = fileConfig.Process.EnvAllowlist
+	}
+
+	if c.ProcessNameWidth == 0 && fileConfig.Process.NameWidth > 0 {
+		c.ProcessNameWidth = fileConfig.Process.NameWidth
+	}
+
+	if len(c.FanModels) == 0 && len(fileConfig.Noise.Fans) > 0 {
+		c.FanModels = fileConfig.Noise.Fans
+	}
+
+	if !c.NoiseHistory && fileConfig.Noise.History {
+		c.NoiseHistory = true
+	}
+
+	if len(c.HealthWeights) == 0 && len(fileConfig.Health.Weights) > 0 {
+		c.HealthWeights = fileConfig.Health.Weights
+	}
+
 	// Merge module settings if --all wasn't specified
 	if !c.Modules.All {
 		if fileConfig.Modules.System {
@@ -145,6 +351,57 @@ func (c *Config) MergeWithFileConfig(fileConfig *FileConfig) {
 		if fileConfig.Modules.Battery {
 			c.Modules.Battery = true
 		}
+		if fileConfig.Modules.Security {
+			c.Modules.Security = true
+		}
+		if fileConfig.Modules.Accelerator {
+			c.Modules.Accelerator = true
+		}
+		if fileConfig.Modules.Thermal {
+			c.Modules.Thermal = true
+		}
+		if fileConfig.Modules.Sensors {
+			c.Modules.Sensors = true
+		}
+		if fileConfig.Modules.Baseboard {
+			c.Modules.Baseboard = true
+		}
+		if fileConfig.Modules.PCI {
+			c.Modules.PCI = true
+		}
+		if fileConfig.Modules.USB {
+			c.Modules.USB = true
+		}
+		if fileConfig.Modules.Displays {
+			c.Modules.Displays = true
+		}
+		if fileConfig.Modules.Audio {
+			c.Modules.Audio = true
+		}
+		if fileConfig.Modules.Bluetooth {
+			c.Modules.Bluetooth = true
+		}
+		if fileConfig.Modules.Drivers {
+			c.Modules.Drivers = true
+		}
+		if fileConfig.Modules.Services {
+			c.Modules.Services = true
+		}
+		if fileConfig.Modules.Firewall {
+			c.Modules.Firewall = true
+		}
+		if fileConfig.Modules.Users {
+			c.Modules.Users = true
+		}
+		if fileConfig.Modules.Packages {
+			c.Modules.Packages = true
+		}
+		if fileConfig.Modules.Integrity {
+			c.Modules.Integrity = true
+		}
+		if fileConfig.Modules.TimeSync {
+			c.Modules.TimeSync = true
+		}
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfigFile(t *testing.T) {
@@ -196,6 +198,203 @@ func TestMergeWithFileConfigModules(t *testing.T) {
 	}
 }
 
+func TestMergeWithFileConfigOutputs(t *testing.T) {
+	file := &FileConfig{
+		OutputFile: "legacy.txt",
+		Outputs: []OutputConfig{
+			{Type: "stdout", Format: "pretty"},
+			{Type: "webhook", URL: "https://example.com/ingest"},
+		},
+	}
+
+	// File outputs replace the legacy output_file
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if len(runtime.Outputs) != 2 {
+		t.Fatalf("Outputs = %d entries; want 2", len(runtime.Outputs))
+	}
+	if runtime.OutputFile != "" {
+		t.Errorf("OutputFile = %q; want empty when outputs are configured", runtime.OutputFile)
+	}
+
+	// An explicit --output flag takes precedence over the file's outputs
+	runtime2 := &Config{OutputFile: "cli.json"}
+	runtime2.MergeWithFileConfig(file)
+	if len(runtime2.Outputs) != 0 {
+		t.Errorf("Outputs = %d entries; want 0 when --output is set", len(runtime2.Outputs))
+	}
+	if runtime2.OutputFile != "cli.json" {
+		t.Errorf("OutputFile = %q; want %q", runtime2.OutputFile, "cli.json")
+	}
+}
+
+func TestMergeWithFileConfigProcessCapture(t *testing.T) {
+	file := &FileConfig{}
+	file.Process.CaptureCmdline = true
+	file.Process.EnvAllowlist = []string{"PATH", "JAVA_OPTS"}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if !runtime.ProcessCmdline {
+		t.Error("ProcessCmdline should be set from file config")
+	}
+	if len(runtime.ProcessEnv) != 2 {
+		t.Errorf("ProcessEnv = %v; want file allowlist", runtime.ProcessEnv)
+	}
+
+	// --process-env replaces the file allowlist
+	runtime2 := &Config{ProcessEnv: []string{"LANG"}}
+	runtime2.MergeWithFileConfig(file)
+	if len(runtime2.ProcessEnv) != 1 || runtime2.ProcessEnv[0] != "LANG" {
+		t.Errorf("ProcessEnv = %v; want [LANG]", runtime2.ProcessEnv)
+	}
+
+	// --process-name-width wins over the file's name_width
+	file.Process.NameWidth = 48
+	runtime.MergeWithFileConfig(file)
+	if runtime.ProcessNameWidth != 48 {
+		t.Errorf("ProcessNameWidth = %d; want 48 from file config", runtime.ProcessNameWidth)
+	}
+	runtime3 := &Config{ProcessNameWidth: 20}
+	runtime3.MergeWithFileConfig(file)
+	if runtime3.ProcessNameWidth != 20 {
+		t.Errorf("ProcessNameWidth = %d; want 20 from the flag", runtime3.ProcessNameWidth)
+	}
+}
+
+func TestMergeWithFileConfigUnits(t *testing.T) {
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(&FileConfig{Units: "decimal"})
+	if runtime.ByteUnits != "decimal" {
+		t.Errorf("ByteUnits = %q; want decimal from file config", runtime.ByteUnits)
+	}
+
+	runtime = &Config{ByteUnits: "binary"}
+	runtime.MergeWithFileConfig(&FileConfig{Units: "decimal"})
+	if runtime.ByteUnits != "binary" {
+		t.Errorf("ByteUnits = %q; want the flag's binary", runtime.ByteUnits)
+	}
+}
+
+func TestMergeWithFileConfigHostPaths(t *testing.T) {
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(&FileConfig{HostRoot: "/host", SysfsRoot: "/host/sys"})
+	if runtime.HostRoot != "/host" || runtime.SysfsRoot != "/host/sys" {
+		t.Errorf("HostRoot, SysfsRoot = %q, %q; want /host and /host/sys from file config", runtime.HostRoot, runtime.SysfsRoot)
+	}
+
+	runtime = &Config{SysfsRoot: "/mnt/sys"}
+	runtime.MergeWithFileConfig(&FileConfig{SysfsRoot: "/host/sys"})
+	if runtime.SysfsRoot != "/mnt/sys" {
+		t.Errorf("SysfsRoot = %q; want the flag's /mnt/sys", runtime.SysfsRoot)
+	}
+}
+
+func TestMergeWithFileConfigCompact(t *testing.T) {
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(&FileConfig{Compact: true})
+	if !runtime.Compact {
+		t.Error("Compact = false; want it set from file config")
+	}
+}
+
+func TestMergeWithFileConfigRedact(t *testing.T) {
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(&FileConfig{Redact: true})
+	if !runtime.Redact {
+		t.Error("Redact = false; want it set from file config")
+	}
+}
+
+func TestMergeWithFileConfigTimestamps(t *testing.T) {
+	file := &FileConfig{Stable: true, UTC: true, TimestampFormat: "unix"}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if !runtime.Stable || !runtime.UTC {
+		t.Errorf("Stable = %v, UTC = %v; want both set from file config", runtime.Stable, runtime.UTC)
+	}
+	if runtime.TimestampFormat != "unix" {
+		t.Errorf("TimestampFormat = %q; want unix", runtime.TimestampFormat)
+	}
+
+	// --timestamp-format takes precedence
+	runtime2 := &Config{TimestampFormat: "none"}
+	runtime2.MergeWithFileConfig(file)
+	if runtime2.TimestampFormat != "none" {
+		t.Errorf("TimestampFormat = %q; want none", runtime2.TimestampFormat)
+	}
+}
+
+func TestMergeWithFileConfigNoise(t *testing.T) {
+	file := &FileConfig{}
+	if err := yaml.Unmarshal([]byte("noise:\n  history: true\n  fans:\n    - match: cpu\n      name: Noctua NF-A12x25\n      max_rpm: 2000\n      max_dba: 22.6\n"), file); err != nil {
+		t.Fatalf("Failed to parse noise config: %v", err)
+	}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if !runtime.NoiseHistory {
+		t.Error("NoiseHistory should be set from file config")
+	}
+	if len(runtime.FanModels) != 1 || runtime.FanModels[0].MaxRPM != 2000 || runtime.FanModels[0].MaxDBA != 22.6 {
+		t.Errorf("FanModels = %+v; want the configured Noctua model", runtime.FanModels)
+	}
+}
+
+func TestMergeWithFileConfigHealth(t *testing.T) {
+	file := &FileConfig{}
+	if err := yaml.Unmarshal([]byte("health:\n  weights:\n    memory: 0\n    smart: 5\n"), file); err != nil {
+		t.Fatalf("Failed to parse health config: %v", err)
+	}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if weight, ok := runtime.HealthWeights["memory"]; !ok || weight != 0 || runtime.HealthWeights["smart"] != 5 {
+		t.Errorf("HealthWeights = %v; want memory 0 and smart 5", runtime.HealthWeights)
+	}
+}
+
+func TestMergeWithFileConfigInflux(t *testing.T) {
+	file := &FileConfig{}
+	if err := yaml.Unmarshal([]byte("influx:\n  prefix: host_\n"), file); err != nil {
+		t.Fatalf("Failed to parse influx config: %v", err)
+	}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if runtime.InfluxPrefix != "host_" {
+		t.Errorf("InfluxPrefix = %q; want host_", runtime.InfluxPrefix)
+	}
+
+	// --influx-prefix takes precedence
+	runtime2 := &Config{InfluxPrefix: "lab_"}
+	runtime2.MergeWithFileConfig(file)
+	if runtime2.InfluxPrefix != "lab_" {
+		t.Errorf("InfluxPrefix = %q; want lab_", runtime2.InfluxPrefix)
+	}
+}
+
+func TestMergeWithFileConfigTemplate(t *testing.T) {
+	file := &FileConfig{}
+	if err := yaml.Unmarshal([]byte("template:\n  file: /etc/sysinfo/report.tmpl\n"), file); err != nil {
+		t.Fatalf("Failed to parse template config: %v", err)
+	}
+
+	runtime := &Config{}
+	runtime.MergeWithFileConfig(file)
+	if runtime.TemplateFile != "/etc/sysinfo/report.tmpl" {
+		t.Errorf("TemplateFile = %q; want /etc/sysinfo/report.tmpl", runtime.TemplateFile)
+	}
+
+	// --template-file takes precedence
+	runtime2 := &Config{TemplateFile: "mine.tmpl"}
+	runtime2.MergeWithFileConfig(file)
+	if runtime2.TemplateFile != "mine.tmpl" {
+		t.Errorf("TemplateFile = %q; want mine.tmpl", runtime2.TemplateFile)
+	}
+}
+
 func TestSaveConfigFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config", "sysinfo.yaml")
@@ -0,0 +1,55 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// audioDeviceString names a sound device with its maker where the name does not already
+// start with it, e.g. "Realtek High Definition Audio"
+func audioDeviceString(d types.AudioDevice) string {
+	name := d.Name
+	if d.Manufacturer != "" && !strings.HasPrefix(strings.ToLower(d.Name), strings.ToLower(d.Manufacturer)) {
+		name = strings.TrimSpace(d.Manufacturer + " " + d.Name)
+	}
+	if name == "" {
+		name = "Unknown audio device"
+	}
+	return name
+}
+
+// audioEndpointsString lists a device's outputs and inputs, e.g.
+// "out: Speakers, HDMI 0; in: Microphone Array"
+func audioEndpointsString(d types.AudioDevice) string {
+	var parts []string
+	if len(d.Playback) > 0 {
+		parts = append(parts, "out: "+strings.Join(d.Playback, ", "))
+	}
+	if len(d.Capture) > 0 {
+		parts = append(parts, "in: "+strings.Join(d.Capture, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// audioDetailString lists a device's codecs, driver, bus and state, e.g.
+// "codec Realtek ALC257, driver snd_hda_intel, pci, default"
+func audioDetailString(d types.AudioDevice) string {
+	var details []string
+	for _, codec := range d.Codecs {
+		details = append(details, "codec "+codec)
+	}
+	if d.Driver != "" {
+		details = append(details, "driver "+d.Driver)
+	}
+	if d.Bus != "" {
+		details = append(details, d.Bus)
+	}
+	if d.Default {
+		details = append(details, "default")
+	}
+	if d.Status != "" {
+		details = append(details, "status "+d.Status)
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,53 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// bluetoothAdapterDetailString lists an adapter's maker, version, firmware, driver and state,
+// e.g. "Intel Corp., Bluetooth 5.2, firmware 0x1e0b, driver btusb, usb, powered"
+func bluetoothAdapterDetailString(a types.BluetoothAdapter) string {
+	var details []string
+	if a.Manufacturer != "" {
+		details = append(details, a.Manufacturer)
+	}
+	if a.Version != "" {
+		details = append(details, "Bluetooth "+a.Version)
+	}
+	if a.Firmware != "" {
+		details = append(details, "firmware "+a.Firmware)
+	}
+	if a.Driver != "" {
+		details = append(details, "driver "+a.Driver)
+	}
+	if a.Bus != "" {
+		details = append(details, a.Bus)
+	}
+	if a.Powered {
+		details = append(details, "powered")
+	} else {
+		details = append(details, "off")
+	}
+	return strings.Join(details, ", ")
+}
+
+// bluetoothDeviceDetailString lists a paired device's type, state and battery, e.g.
+// "audio-headset, connected, battery 70%"
+func bluetoothDeviceDetailString(d types.BluetoothDevice) string {
+	var details []string
+	if d.Type != "" {
+		details = append(details, d.Type)
+	}
+	if d.Connected {
+		details = append(details, "connected")
+	} else {
+		details = append(details, "not connected")
+	}
+	if d.BatteryPercent > 0 {
+		details = append(details, fmt.Sprintf("battery %d%%", d.BatteryPercent))
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,45 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// boardItem is a single line of the baseboard section
+type boardItem struct {
+	Label string
+	Value string
+}
+
+// boardItems flattens the system, motherboard, firmware and chassis identity into labelled
+// lines shared by the text, pretty and HTML formatters, leaving out what was not reported
+func boardItems(board *types.BoardData) []boardItem {
+	var items []boardItem
+	add := func(label string, parts ...string) {
+		var kept []string
+		for _, p := range parts {
+			if p != "" {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) > 0 {
+			items = append(items, boardItem{label, strings.Join(kept, " ")})
+		}
+	}
+
+	add("System", board.SystemVendor, board.SystemProduct)
+	add("System Version", board.SystemVersion)
+	add("System Serial", board.SystemSerial)
+	add("UUID", board.UUID)
+	add("Motherboard", board.Vendor, board.Product)
+	add("Board Version", board.Version)
+	add("Board Serial", board.Serial)
+	bios := ""
+	if board.BIOSDate != "" {
+		bios = "(" + board.BIOSDate + ")"
+	}
+	add("BIOS", board.BIOSVendor, board.BIOSVersion, bios)
+	add("Chassis", board.ChassisType)
+	return items
+}
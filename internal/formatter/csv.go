@@ -0,0 +1,409 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// CSVSections lists the sections the csv format can emit, in output order
+var CSVSections = []string{"disk", "process", "network", "smart", "sensors", "pci", "usb", "displays", "audio", "drivers", "users", "packages", "integrity"}
+
+// csvTable is one section rendered as a header and rows
+type csvTable struct {
+	header []string
+	rows   [][]string
+}
+
+// FormatCSV formats one tabular section as CSV. With no section every
+// collected section is emitted, each preceded by a "# <section>" line
+func FormatCSV(info *types.SystemInfo, section string) (string, error) {
+	if section != "" {
+		table, err := csvSection(info, section)
+		if err != nil {
+			return "", err
+		}
+		return writeCSV(table)
+	}
+
+	var parts []string
+	for _, name := range CSVSections {
+		table, _ := csvSection(info, name)
+		if len(table.rows) == 0 {
+			continue
+		}
+		out, err := writeCSV(table)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, "# "+name+"\n"+out)
+	}
+	return strings.Join(parts, "\n"), nil
+}
+
+// ValidateCSVSection reports whether section names a csv section
+func ValidateCSVSection(section string) error {
+	for _, name := range CSVSections {
+		if section == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown section %q: expected one of %s", section, strings.Join(CSVSections, ", "))
+}
+
+// csvSection builds the table for a section; missing data gives a header with no rows
+func csvSection(info *types.SystemInfo, section string) (csvTable, error) {
+	switch section {
+	case "disk":
+		return partitionsCSV(info.Disk), nil
+	case "process":
+		return processesCSV(info.Processes), nil
+	case "network":
+		return interfacesCSV(info.Network), nil
+	case "smart":
+		return smartAttributesCSV(info.Disk), nil
+	case "sensors":
+		return sensorsCSV(info.Sensors), nil
+	case "pci":
+		return pciCSV(info.PCI), nil
+	case "usb":
+		return usbCSV(info.USB), nil
+	case "displays":
+		return displaysCSV(info.Displays), nil
+	case "audio":
+		return audioCSV(info.Audio), nil
+	case "drivers":
+		return driversCSV(info.Drivers), nil
+	case "users":
+		return usersCSV(info.Users), nil
+	case "packages":
+		return packagesCSV(info.Packages), nil
+	case "integrity":
+		return integrityCSV(info.Integrity), nil
+	default:
+		return csvTable{}, ValidateCSVSection(section)
+	}
+}
+
+func partitionsCSV(disk *types.DiskData) csvTable {
+	table := csvTable{header: []string{
+		"device", "mount_point", "fs_type", "total_bytes", "used_bytes", "free_bytes", "used_percent",
+		"inodes_total", "inodes_used", "inodes_free",
+	}}
+	if disk == nil {
+		return table
+	}
+	for _, p := range disk.Partitions {
+		table.rows = append(table.rows, []string{
+			p.Device, p.MountPoint, p.FSType,
+			csvUint(p.Total), csvUint(p.Used), csvUint(p.Free), csvFloat(p.UsedPercent),
+			csvUint(p.InodesTotal), csvUint(p.InodesUsed), csvUint(p.InodesFree),
+		})
+	}
+	return table
+}
+
+// processesCSV emits every top list; a process in several lists appears once per list
+func processesCSV(proc *types.ProcessData) csvTable {
+	table := csvTable{header: []string{
+		"list", "pid", "name", "username", "cpu_percent", "memory_percent", "memory_mb", "status",
+		"disk_read_bytes", "disk_write_bytes", "gpu_percent", "exe",
+	}}
+	if proc == nil {
+		return table
+	}
+	lists := []struct {
+		name      string
+		processes []types.ProcessInfo
+	}{
+		{"cpu", proc.TopByCPU},
+		{"memory", proc.TopByMemory},
+		{"disk_io", proc.TopByDiskIO},
+		{"gpu", proc.TopByGPU},
+	}
+	for _, list := range lists {
+		for _, p := range list.processes {
+			table.rows = append(table.rows, []string{
+				list.name, strconv.FormatInt(int64(p.PID), 10), p.Name, p.Username,
+				csvFloat(p.CPUPercent), strconv.FormatFloat(float64(p.MemoryPercent), 'f', -1, 32), csvUint(p.MemoryMB), p.Status,
+				csvUint(p.DiskReadBytes), csvUint(p.DiskWriteBytes), csvFloat(p.GPUPercent), p.Exe,
+			})
+		}
+	}
+	return table
+}
+
+func interfacesCSV(network *types.NetworkData) csvTable {
+	table := csvTable{header: []string{
+		"name", "hardware_addr", "addresses", "mtu", "bytes_sent", "bytes_recv", "packets_sent", "packets_recv",
+		"errors_in", "errors_out", "drops_in", "drops_out",
+		"wifi_ssid", "wifi_bssid", "wifi_channel", "wifi_signal_dbm", "wifi_tx_rate_mbps",
+	}}
+	if network == nil {
+		return table
+	}
+	for _, iface := range network.Interfaces {
+		row := []string{
+			iface.Name, iface.HardwareAddr, strings.Join(iface.Addresses, " "), strconv.Itoa(iface.MTU),
+			csvUint(iface.BytesSent), csvUint(iface.BytesRecv), csvUint(iface.PacketsSent), csvUint(iface.PacketsRecv),
+			csvUint(iface.ErrorsIn), csvUint(iface.ErrorsOut), csvUint(iface.DropsIn), csvUint(iface.DropsOut),
+		}
+		if link := iface.WiFi; link != nil && link.Connected {
+			row = append(row, link.SSID, link.BSSID, strconv.Itoa(link.Channel), strconv.Itoa(link.SignalDBm), csvFloat(link.TxRateMbps))
+		} else {
+			row = append(row, "", "", "", "", "")
+		}
+		table.rows = append(table.rows, row)
+	}
+	return table
+}
+
+func sensorsCSV(sensors *types.SensorsData) csvTable {
+	table := csvTable{header: []string{
+		"chip", "label", "source", "temperature_celsius", "min_celsius", "max_celsius", "critical_celsius",
+	}}
+	if sensors == nil {
+		return table
+	}
+	for _, s := range sensors.Temperatures {
+		table.rows = append(table.rows, []string{
+			s.Chip, s.Label, s.Source, csvFloat(s.Temperature), csvFloat(s.Min), csvFloat(s.Max), csvFloat(s.Critical),
+		})
+	}
+	return table
+}
+
+func pciCSV(pci *types.PCIData) csvTable {
+	table := csvTable{header: []string{
+		"address", "vendor_id", "device_id", "subsystem_vendor_id", "subsystem_id", "revision", "class", "class_name",
+		"vendor", "name", "driver",
+	}}
+	if pci == nil {
+		return table
+	}
+	for _, d := range pci.Devices {
+		table.rows = append(table.rows, []string{
+			d.Address, d.VendorID, d.DeviceID, d.SubsystemVendorID, d.SubsystemID, d.Revision, d.Class, d.ClassName,
+			d.Vendor, d.Name, d.Driver,
+		})
+	}
+	return table
+}
+
+func usbCSV(usb *types.USBData) csvTable {
+	table := csvTable{header: []string{
+		"address", "bus", "port", "vendor_id", "product_id", "vendor", "product", "serial", "speed_mbps", "usb_version",
+		"class", "class_name", "drivers",
+	}}
+	if usb == nil {
+		return table
+	}
+	for _, d := range usb.Devices {
+		// Left empty where the platform does not report them
+		bus, speed := "", ""
+		if d.Bus > 0 {
+			bus = strconv.Itoa(d.Bus)
+		}
+		if d.SpeedMbps > 0 {
+			speed = csvFloat(d.SpeedMbps)
+		}
+		table.rows = append(table.rows, []string{
+			d.Address, bus, d.Port, d.VendorID, d.ProductID, d.Vendor, d.Product, d.Serial, speed, d.Version,
+			d.Class, d.ClassName, strings.Join(d.Drivers, " "),
+		})
+	}
+	return table
+}
+
+func displaysCSV(displays *types.DisplayData) csvTable {
+	table := csvTable{header: []string{
+		"name", "manufacturer", "manufacturer_id", "model", "product_code", "serial", "manufacture_year", "width", "height",
+		"refresh_hz", "width_mm", "height_mm", "diagonal_inches", "primary", "builtin",
+	}}
+	if displays == nil {
+		return table
+	}
+	// Left empty where unknown
+	number := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	decimal := func(f float64) string {
+		if f == 0 {
+			return ""
+		}
+		return csvFloat(f)
+	}
+	for _, d := range displays.Displays {
+		table.rows = append(table.rows, []string{
+			d.Name, d.Manufacturer, d.ManufacturerID, d.Model, d.ProductCode, d.Serial, number(d.Year), number(d.Width), number(d.Height),
+			decimal(d.RefreshHz), number(d.WidthMM), number(d.HeightMM), decimal(d.DiagonalInches),
+			strconv.FormatBool(d.Primary), strconv.FormatBool(d.Builtin),
+		})
+	}
+	return table
+}
+
+func audioCSV(audio *types.AudioData) csvTable {
+	table := csvTable{header: []string{
+		"name", "id", "manufacturer", "codecs", "driver", "bus", "playback", "capture", "default", "status",
+	}}
+	if audio == nil {
+		return table
+	}
+	for _, d := range audio.Devices {
+		// Lists are joined with ";" as endpoint names contain spaces
+		table.rows = append(table.rows, []string{
+			d.Name, d.ID, d.Manufacturer, strings.Join(d.Codecs, ";"), d.Driver, d.Bus,
+			strings.Join(d.Playback, ";"), strings.Join(d.Capture, ";"), strconv.FormatBool(d.Default), d.Status,
+		})
+	}
+	return table
+}
+
+func driversCSV(drivers *types.DriverData) csvTable {
+	table := csvTable{header: []string{
+		"name", "description", "version", "size_bytes", "ref_count", "used_by", "state", "taint", "path",
+	}}
+	if drivers == nil {
+		return table
+	}
+	for _, d := range drivers.Drivers {
+		table.rows = append(table.rows, []string{
+			d.Name, d.Description, d.Version, csvUint(d.SizeBytes), strconv.Itoa(d.RefCount),
+			strings.Join(d.UsedBy, ";"), d.State, d.Taint, d.Path,
+		})
+	}
+	return table
+}
+
+func usersCSV(users *types.UserData) csvTable {
+	table := csvTable{header: []string{"user", "terminal", "remote_host", "login_time", "state"}}
+	if users == nil {
+		return table
+	}
+	for _, s := range users.Sessions {
+		login := ""
+		if !s.LoginTime.IsZero() {
+			login = s.LoginTime.Format(time.RFC3339)
+		}
+		table.rows = append(table.rows, []string{s.User, s.Terminal, s.RemoteHost, login, s.State})
+	}
+	return table
+}
+
+func packagesCSV(packages *types.PackageData) csvTable {
+	table := csvTable{header: []string{"name", "version", "arch", "manager", "publisher"}}
+	if packages == nil {
+		return table
+	}
+	for _, p := range packages.Packages {
+		table.rows = append(table.rows, []string{p.Name, p.Version, p.Arch, p.Manager, p.Publisher})
+	}
+	return table
+}
+
+func integrityCSV(integrity *types.IntegrityData) csvTable {
+	table := csvTable{header: []string{"path", "sha256", "size_bytes", "mode", "error"}}
+	if integrity == nil {
+		return table
+	}
+	for _, f := range integrity.Files {
+		// Left empty for files that were not hashed
+		size := ""
+		if f.SHA256 != "" {
+			size = strconv.FormatInt(f.Size, 10)
+		}
+		table.rows = append(table.rows, []string{f.Path, f.SHA256, size, f.Mode, f.Error})
+	}
+	return table
+}
+
+// smartAttributesCSV emits one row per attribute per drive. Drives without an
+// ATA attribute table (NVMe, Windows) fall back to their key/value attributes
+func smartAttributesCSV(disk *types.DiskData) csvTable {
+	table := csvTable{header: []string{
+		"device", "model", "serial", "id", "name", "value", "worst", "threshold", "raw_value", "raw_string",
+		"type", "updated", "when_failed",
+	}}
+	if disk == nil {
+		return table
+	}
+	for _, smart := range disk.SMARTData {
+		if len(smart.DetailedAttribs) > 0 {
+			for _, a := range smart.DetailedAttribs {
+				table.rows = append(table.rows, []string{
+					smart.Device, smart.DeviceModel, smart.Serial,
+					strconv.Itoa(int(a.ID)), a.Name, strconv.Itoa(int(a.Value)), strconv.Itoa(int(a.Worst)),
+					strconv.Itoa(int(a.Threshold)), csvUint(a.RawValue), a.RawString,
+					a.Type, a.Updated, a.WhenFailed,
+				})
+			}
+			continue
+		}
+
+		names := make([]string, 0, len(smart.Attributes))
+		for name := range smart.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			table.rows = append(table.rows, []string{
+				smart.Device, smart.DeviceModel, smart.Serial,
+				"", name, "", "", "", "", smart.Attributes[name], "", "", "",
+			})
+		}
+	}
+	return table
+}
+
+// FormatSMARTHistoryCSV formats SMART history records as CSV, one row per record with its
+// time in RFC 3339 in loc, for analysis in spreadsheets
+func FormatSMARTHistoryCSV(records []analyzer.SMARTHistoryRecord, loc *time.Location) (string, error) {
+	table := csvTable{header: []string{
+		"host", "device", "timestamp", "temperature_celsius", "health_status", "failure_probability",
+		"remaining_life_percent", "percent_used", "power_on_hours", "issue_count", "critical_issues",
+		"warning_issues", "clock_offset_ms",
+	}}
+	for _, r := range records {
+		clockOffset := ""
+		if r.ClockOffsetMS != nil {
+			clockOffset = csvFloat(*r.ClockOffsetMS)
+		}
+		table.rows = append(table.rows, []string{
+			r.Host, r.Device, r.Timestamp.In(loc).Format(time.RFC3339), strconv.Itoa(r.Temperature),
+			string(r.HealthStatus), csvFloat(r.FailureProbability), csvFloat(r.RemainingLife),
+			csvFloat(r.PercentUsed), strconv.FormatInt(r.PowerOnHours, 10), strconv.Itoa(r.IssueCount),
+			strconv.Itoa(r.CriticalIssues), strconv.Itoa(r.WarningIssues), clockOffset,
+		})
+	}
+	return writeCSV(table)
+}
+
+func writeCSV(table csvTable) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.Write(table.header); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	if err := w.WriteAll(table.rows); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func csvUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func csvFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,288 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatCSVSection(t *testing.T) {
+	info := createTestSystemInfo()
+	out, err := Format(info, &config.Config{Format: "csv", Section: "disk"})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records; want header and one partition", len(records))
+	}
+	if records[0][0] != "device" || records[0][6] != "used_percent" {
+		t.Errorf("header = %v", records[0])
+	}
+	if records[1][0] != "/dev/sda1" || records[1][3] != "536870912000" || records[1][6] != "60" {
+		t.Errorf("row = %v", records[1])
+	}
+}
+
+func TestFormatCSVProcesses(t *testing.T) {
+	out, err := FormatCSV(createTestSystemInfo(), "process")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines; want header and two processes:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[1], "cpu,5678,node,") || !strings.HasPrefix(lines[2], "memory,1234,chrome,,15.5,25.3,4096,") {
+		t.Errorf("unexpected rows:\n%s", out)
+	}
+}
+
+func TestFormatCSVSMARTAttributes(t *testing.T) {
+	info := &types.SystemInfo{Disk: &types.DiskData{SMARTData: []types.SMARTInfo{
+		{
+			Device: "/dev/sda",
+			DetailedAttribs: []types.SMARTAttribute{
+				{ID: 5, Name: "Reallocated_Sector_Ct", Value: 100, Worst: 100, Threshold: 10, RawValue: 0, RawString: "0"},
+			},
+		},
+		{Device: "/dev/nvme0", Attributes: map[string]string{"b": "2", "a": "1, with comma"}},
+	}}}
+
+	out, err := FormatCSV(info, "smart")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d records; want 4", len(records))
+	}
+	if records[1][3] != "5" || records[1][4] != "Reallocated_Sector_Ct" || records[1][7] != "10" {
+		t.Errorf("attribute row = %v", records[1])
+	}
+	if records[2][4] != "a" || records[2][9] != "1, with comma" || records[3][4] != "b" {
+		t.Errorf("fallback rows = %v, %v", records[2], records[3])
+	}
+}
+
+func TestFormatCSVAllSections(t *testing.T) {
+	out, err := FormatCSV(createTestSystemInfo(), "")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	for _, want := range []string{"# disk\n", "# process\n", "# network\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	// The test drive has no attributes, so the smart section is left out
+	if strings.Contains(out, "# smart") {
+		t.Error("empty smart section should be omitted")
+	}
+}
+
+func TestFormatCSVUnknownSection(t *testing.T) {
+	if _, err := FormatCSV(createTestSystemInfo(), "gpu"); err == nil {
+		t.Error("expected error for unknown section")
+	}
+}
+
+func TestFormatSMARTHistoryCSV(t *testing.T) {
+	offset := 1250.5
+	records := []analyzer.SMARTHistoryRecord{
+		{Host: "nas", Device: "/dev/sda", Timestamp: time.Date(2025, 11, 7, 14, 30, 0, 0, time.UTC), Temperature: 42,
+			PowerOnHours: 12000, HealthStatus: analyzer.HealthGood, RemainingLife: 97.5, PercentUsed: 2.5},
+		{Host: "nas", Device: "/dev/sda", Timestamp: time.Date(2025, 11, 8, 9, 0, 0, 0, time.UTC), Temperature: 44,
+			PowerOnHours: 12019, HealthStatus: analyzer.HealthWarning, FailureProbability: 12, IssueCount: 1,
+			WarningIssues: 1, ClockOffsetMS: &offset},
+	}
+	out, err := FormatSMARTHistoryCSV(records, time.UTC)
+	if err != nil {
+		t.Fatalf("FormatSMARTHistoryCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows; want header and two records:\n%s", len(rows), out)
+	}
+	if rows[0][2] != "timestamp" || rows[0][3] != "temperature_celsius" || rows[0][12] != "clock_offset_ms" {
+		t.Errorf("header = %v", rows[0])
+	}
+	want := []string{"nas", "/dev/sda", "2025-11-07T14:30:00Z", "42", string(analyzer.HealthGood), "0", "97.5", "2.5", "12000", "0", "0", "0", ""}
+	if strings.Join(rows[1], ",") != strings.Join(want, ",") {
+		t.Errorf("row = %v; want %v", rows[1], want)
+	}
+	if rows[2][4] != string(analyzer.HealthWarning) || rows[2][12] != "1250.5" {
+		t.Errorf("row = %v", rows[2])
+	}
+}
+
+func TestFormatCSVSensors(t *testing.T) {
+	info := &types.SystemInfo{Sensors: &types.SensorsData{Temperatures: []types.TemperatureSensor{
+		{Chip: "coretemp", Label: "Package id 0", Source: "hwmon", Temperature: 52, Max: 100, Critical: 100},
+	}}}
+	out, err := FormatCSV(info, "sensors")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "chip,label,source,temperature_celsius,min_celsius,max_celsius,critical_celsius\ncoretemp,Package id 0,hwmon,52,0,100,100\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVUSB(t *testing.T) {
+	info := &types.SystemInfo{USB: &types.USBData{Devices: []types.USBDevice{
+		{Address: "2-1.4", Bus: 2, Port: "1.4", VendorID: "0781", ProductID: "5581", Vendor: "SanDisk Corp.", Product: "Ultra",
+			Serial: "4C530001", SpeedMbps: 5000, Version: "3.20", Class: "08", ClassName: "Mass Storage", Drivers: []string{"usb-storage", "uas"}},
+		{Address: `USB\VID_046D&PID_C52B\5&2F1D8C5B&0&2`, VendorID: "046d", ProductID: "c52b"},
+	}}}
+	out, err := FormatCSV(info, "usb")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "address,bus,port,vendor_id,product_id,vendor,product,serial,speed_mbps,usb_version,class,class_name,drivers\n" +
+		"2-1.4,2,1.4,0781,5581,SanDisk Corp.,Ultra,4C530001,5000,3.20,08,Mass Storage,usb-storage uas\n" +
+		`USB\VID_046D&PID_C52B\5&2F1D8C5B&0&2,,,046d,c52b,,,,,,,,` + "\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVDisplays(t *testing.T) {
+	info := &types.SystemInfo{Displays: &types.DisplayData{Displays: []types.DisplayInfo{
+		{Name: "DP-1", Manufacturer: "Dell", ManufacturerID: "DEL", Model: "DELL U2720Q", ProductCode: "a0c4", Serial: "ABC1234", Year: 2020,
+			Width: 2560, Height: 1440, RefreshHz: 59.95, WidthMM: 597, HeightMM: 336, DiagonalInches: 27, Primary: true},
+		{Name: "HDMI-2"},
+	}}}
+	out, err := FormatCSV(info, "displays")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "name,manufacturer,manufacturer_id,model,product_code,serial,manufacture_year,width,height,refresh_hz,width_mm,height_mm,diagonal_inches,primary,builtin\n" +
+		"DP-1,Dell,DEL,DELL U2720Q,a0c4,ABC1234,2020,2560,1440,59.95,597,336,27,true,false\n" +
+		"HDMI-2,,,,,,,,,,,,,false,false\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVAudio(t *testing.T) {
+	info := &types.SystemInfo{Audio: &types.AudioData{Devices: []types.AudioDevice{
+		{Name: "HDA Intel PCH", ID: "PCH", Codecs: []string{"Realtek ALC257"}, Driver: "snd_hda_intel", Bus: "pci",
+			Playback: []string{"ALC257 Analog", "HDMI 0"}, Capture: []string{"ALC257 Analog"}},
+		{Name: "USB Audio Device", Status: "Error"},
+	}}}
+	out, err := FormatCSV(info, "audio")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "name,id,manufacturer,codecs,driver,bus,playback,capture,default,status\n" +
+		"HDA Intel PCH,PCH,,Realtek ALC257,snd_hda_intel,pci,ALC257 Analog;HDMI 0,ALC257 Analog,false,\n" +
+		"USB Audio Device,,,,,,,,false,Error\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVDrivers(t *testing.T) {
+	info := &types.SystemInfo{Drivers: &types.DriverData{Drivers: []types.KernelDriver{
+		{Name: "nvidia", Version: "550.54.14", SizeBytes: 54808576, RefCount: 2, UsedBy: []string{"nvidia_modeset", "nvidia_uvm"}, State: "Live", Taint: "POE"},
+		{Name: "ACPI", Description: "Microsoft ACPI Driver", Version: "10.0.22621.2506", State: "Running", Path: `C:\Windows\system32\drivers\ACPI.sys`},
+	}}}
+	out, err := FormatCSV(info, "drivers")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "name,description,version,size_bytes,ref_count,used_by,state,taint,path\n" +
+		"nvidia,,550.54.14,54808576,2,nvidia_modeset;nvidia_uvm,Live,POE,\n" +
+		"ACPI,Microsoft ACPI Driver,10.0.22621.2506,0,0,,Running,,C:\\Windows\\system32\\drivers\\ACPI.sys\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVUsers(t *testing.T) {
+	info := &types.SystemInfo{Users: &types.UserData{Sessions: []types.UserSession{
+		{User: "alice", Terminal: "pts/0", RemoteHost: "10.0.0.5", LoginTime: time.Date(2026, 6, 1, 9, 12, 0, 0, time.UTC)},
+		{User: "bob", Terminal: "Console", State: "disconnected"},
+	}}}
+	out, err := FormatCSV(info, "users")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "user,terminal,remote_host,login_time,state\n" +
+		"alice,pts/0,10.0.0.5,2026-06-01T09:12:00Z,\n" +
+		"bob,Console,,,disconnected\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVPackages(t *testing.T) {
+	info := &types.SystemInfo{Packages: &types.PackageData{
+		Managers: []string{"dpkg", "programs"},
+		Packages: []types.InstalledPackage{
+			{Name: "bash", Version: "5.2.15-2+b7", Arch: "amd64", Manager: "dpkg"},
+			{Name: "7-Zip 23.01 (x64)", Version: "23.01", Manager: "programs", Publisher: "Igor Pavlov"},
+		},
+	}}
+	out, err := FormatCSV(info, "packages")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "name,version,arch,manager,publisher\n" +
+		"bash,5.2.15-2+b7,amd64,dpkg,\n" +
+		"7-Zip 23.01 (x64),23.01,,programs,Igor Pavlov\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVIntegrity(t *testing.T) {
+	info := &types.SystemInfo{Integrity: &types.IntegrityData{Files: []types.FileChecksum{
+		{Path: "/etc/hosts", SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Mode: "-rw-r--r--"},
+		{Path: "/etc/shadow", Error: "permission denied"},
+	}}}
+	out, err := FormatCSV(info, "integrity")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "path,sha256,size_bytes,mode,error\n" +
+		"/etc/hosts,e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855,0,-rw-r--r--,\n" +
+		"/etc/shadow,,,,permission denied\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestFormatCSVPCI(t *testing.T) {
+	info := &types.SystemInfo{PCI: &types.PCIData{Devices: []types.PCIDevice{
+		{Address: "0000:00:1f.3", VendorID: "8086", DeviceID: "a348", SubsystemVendorID: "17aa", SubsystemID: "2292", Revision: "10",
+			Class: "040380", ClassName: "Audio device", Vendor: "Intel Corporation", Name: "Cannon Lake PCH cAVS", Driver: "snd_hda_intel"},
+	}}}
+	out, err := FormatCSV(info, "pci")
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+	want := "address,vendor_id,device_id,subsystem_vendor_id,subsystem_id,revision,class,class_name,vendor,name,driver\n" +
+		"0000:00:1f.3,8086,a348,17aa,2292,10,040380,Audio device,Intel Corporation,Cannon Lake PCH cAVS,snd_hda_intel\n"
+	if out != want {
+		t.Errorf("FormatCSV() =\n%s\nwant\n%s", out, want)
+	}
+}
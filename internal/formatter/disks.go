@@ -0,0 +1,180 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// FormatDisks formats a storage-only inventory for the disks command
+func FormatDisks(disk *types.DiskData, format string) (string, error) {
+	if disk == nil {
+		disk = &types.DiskData{}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(disk, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(data), nil
+	case "text":
+		return formatDisksText(disk), nil
+	case "pretty":
+		return formatDisksPretty(disk), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// formatDisksText renders the storage inventory as plain text
+func formatDisksText(disk *types.DiskData) string {
+	var sb strings.Builder
+
+	sb.WriteString("PHYSICAL DISKS\n")
+	if len(disk.PhysicalDisks) == 0 {
+		sb.WriteString("  No physical disks detected\n")
+	}
+	for _, d := range disk.PhysicalDisks {
+		sb.WriteString(fmt.Sprintf("  %-16s %-8s %-10s %s\n", d.Name, diskTypeLabel(d), d.SizeFormatted, d.Model))
+		if d.Slot != nil {
+			sb.WriteString(fmt.Sprintf("  %-16s %s\n", "", d.Slot))
+		}
+	}
+	sb.WriteString("\n")
+
+	partitions := significantPartitions(disk.Partitions)
+	if len(partitions) > 0 {
+		sb.WriteString("PARTITIONS\n")
+		for _, part := range partitions {
+			sb.WriteString(fmt.Sprintf("  %-16s %-20s %-6s %10s %6.1f%% used\n",
+				part.Device, part.MountPoint, part.FSType, part.TotalFormatted, part.UsedPercent))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(disk.SMARTData) > 0 {
+		sb.WriteString("SMART SUMMARY\n")
+		for _, smart := range disk.SMARTData {
+			sb.WriteString(fmt.Sprintf("  %-16s %-8s %s\n", smart.Device, smartHealthLabel(smart), smartSummary(smart)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(disk.IOStats) > 0 {
+		sb.WriteString("IO STATISTICS\n")
+		for _, io := range disk.IOStats {
+			sb.WriteString(fmt.Sprintf("  %-16s read %s (%d ops)  write %s (%d ops)\n",
+				io.Name, formatBytes(io.ReadBytes), io.ReadCount, formatBytes(io.WriteBytes), io.WriteCount))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatDisksPretty renders the storage inventory with colors and box drawing
+func formatDisksPretty(disk *types.DiskData) string {
+	var sb strings.Builder
+
+	headerColor := color.New(color.FgCyan, color.Bold)
+	labelColor := color.New(color.FgGreen)
+	valueColor := color.New(color.FgWhite)
+
+	sb.WriteString(headerColor.Sprintf("┌─ PHYSICAL DISKS ─────────────────────────────────────────────┐\n"))
+	if len(disk.PhysicalDisks) == 0 {
+		sb.WriteString("│ No physical disks detected\n")
+	}
+	for _, d := range disk.PhysicalDisks {
+		sb.WriteString(fmt.Sprintf("│ %s [%s] %s\n", valueColor.Sprint(d.Name), valueColor.Sprint(diskTypeLabel(d)), labelColor.Sprint(d.SizeFormatted)))
+		if d.Model != "" {
+			sb.WriteString(fmt.Sprintf("│   %s\n", d.Model))
+		}
+		if d.Slot != nil {
+			sb.WriteString(fmt.Sprintf("│   %s %s\n", labelColor.Sprint("Slot:"), d.Slot))
+		}
+	}
+	sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
+
+	partitions := significantPartitions(disk.Partitions)
+	if len(partitions) > 0 {
+		sb.WriteString(headerColor.Sprintf("┌─ PARTITIONS ─────────────────────────────────────────────────┐\n"))
+		for _, part := range partitions {
+			sb.WriteString(fmt.Sprintf("│ %s → %s (%s)\n", valueColor.Sprint(part.Device), valueColor.Sprint(part.MountPoint), part.FSType))
+			sb.WriteString(fmt.Sprintf("│   %s %5.1f%% of %s\n", createProgressBar(part.UsedPercent, 30), part.UsedPercent, part.TotalFormatted))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
+	}
+
+	if len(disk.SMARTData) > 0 {
+		sb.WriteString(headerColor.Sprintf("┌─ SMART SUMMARY ──────────────────────────────────────────────┐\n"))
+		for _, smart := range disk.SMARTData {
+			healthColor := color.New(color.FgGreen, color.Bold)
+			if !smart.Healthy {
+				healthColor = color.New(color.FgRed, color.Bold)
+			}
+			sb.WriteString(fmt.Sprintf("│ %s [%s] %s\n", valueColor.Sprint(smart.Device), healthColor.Sprint(smartHealthLabel(smart)), smartSummary(smart)))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
+	}
+
+	if len(disk.IOStats) > 0 {
+		sb.WriteString(headerColor.Sprintf("┌─ IO STATISTICS ──────────────────────────────────────────────┐\n"))
+		for _, io := range disk.IOStats {
+			sb.WriteString(fmt.Sprintf("│ %-16s %s %s (%d ops)  %s %s (%d ops)\n",
+				valueColor.Sprint(io.Name),
+				labelColor.Sprint("Read:"), formatBytes(io.ReadBytes), io.ReadCount,
+				labelColor.Sprint("Write:"), formatBytes(io.WriteBytes), io.WriteCount))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	return sb.String()
+}
+
+// diskTypeLabel returns a disk's media type, falling back to Unknown
+func diskTypeLabel(d types.PhysicalDisk) string {
+	if d.Type == "" {
+		return "Unknown"
+	}
+	return d.Type
+}
+
+// significantPartitions filters out loop devices and squashfs snap mounts
+func significantPartitions(partitions []types.PartitionInfo) []types.PartitionInfo {
+	var result []types.PartitionInfo
+	for _, part := range partitions {
+		if strings.HasPrefix(part.Device, "/dev/loop") || part.FSType == "squashfs" {
+			continue
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+// smartHealthLabel returns HEALTHY or WARNING for a drive's overall SMART status
+func smartHealthLabel(smart types.SMARTInfo) string {
+	if smart.Healthy {
+		return "HEALTHY"
+	}
+	return "WARNING"
+}
+
+// smartSummary returns a one-line model/temperature/power-on summary for a drive
+func smartSummary(smart types.SMARTInfo) string {
+	var parts []string
+	if smart.DeviceModel != "" {
+		parts = append(parts, smart.DeviceModel)
+	}
+	if smart.Temperature > 0 {
+		parts = append(parts, fmt.Sprintf("%d°C", smart.Temperature))
+	}
+	if smart.PowerOnHours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh powered on", smart.PowerOnHours))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,117 @@
+package formatter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func createTestDiskData() *types.DiskData {
+	return &types.DiskData{
+		PhysicalDisks: []types.PhysicalDisk{
+			{Name: "/dev/nvme0n1", Model: "Samsung SSD 980 PRO 1TB", Type: "NVMe", SizeFormatted: "931.51 GB"},
+		},
+		Partitions: []types.PartitionInfo{
+			{Device: "/dev/nvme0n1p2", MountPoint: "/", FSType: "ext4", TotalFormatted: "900.00 GB", UsedPercent: 42.5},
+			{Device: "/dev/loop3", MountPoint: "/snap/core/1", FSType: "squashfs"},
+		},
+		SMARTData: []types.SMARTInfo{
+			{Device: "/dev/nvme0n1", DeviceModel: "Samsung SSD 980 PRO 1TB", Healthy: true, Temperature: 38, PowerOnHours: 1200},
+		},
+		IOStats: []types.DiskIOStat{
+			{Name: "nvme0n1", ReadCount: 1000, WriteCount: 500, ReadBytes: 1024 * 1024, WriteBytes: 2048},
+		},
+	}
+}
+
+func TestFormatDisks(t *testing.T) {
+	disk := createTestDiskData()
+
+	tests := []struct {
+		format   string
+		contains []string
+		excludes []string
+	}{
+		{
+			format:   "text",
+			contains: []string{"PHYSICAL DISKS", "/dev/nvme0n1", "NVMe", "PARTITIONS", "42.5% used", "SMART SUMMARY", "HEALTHY", "38°C", "IO STATISTICS", "1.00 MB"},
+			excludes: []string{"/dev/loop3", "SYSTEM INFORMATION"},
+		},
+		{
+			format:   "pretty",
+			contains: []string{"PHYSICAL DISKS", "Samsung SSD 980 PRO 1TB", "PARTITIONS", "SMART SUMMARY", "IO STATISTICS"},
+			excludes: []string{"/dev/loop3", "SYSTEM INFORMATION REPORT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			output, err := FormatDisks(disk, tt.format)
+			if err != nil {
+				t.Fatalf("FormatDisks(%q) error = %v", tt.format, err)
+			}
+			output = stripAnsiCodes(output)
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("FormatDisks(%q) missing %q", tt.format, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("FormatDisks(%q) should not contain %q", tt.format, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatDisksJSON(t *testing.T) {
+	output, err := FormatDisks(createTestDiskData(), "json")
+	if err != nil {
+		t.Fatalf("FormatDisks(json) error = %v", err)
+	}
+
+	var decoded types.DiskData
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("FormatDisks(json) produced invalid JSON: %v", err)
+	}
+	if len(decoded.PhysicalDisks) != 1 {
+		t.Errorf("PhysicalDisks = %d, expected 1", len(decoded.PhysicalDisks))
+	}
+	if len(decoded.IOStats) != 1 {
+		t.Errorf("IOStats = %d, expected 1", len(decoded.IOStats))
+	}
+}
+
+func TestFormatDisksErrors(t *testing.T) {
+	if _, err := FormatDisks(createTestDiskData(), "invalid"); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+
+	output, err := FormatDisks(nil, "text")
+	if err != nil {
+		t.Fatalf("FormatDisks(nil) error = %v", err)
+	}
+	if !strings.Contains(output, "No physical disks detected") {
+		t.Error("FormatDisks(nil) should report no physical disks")
+	}
+}
+
+func TestFormatDisksSlot(t *testing.T) {
+	disk := &types.DiskData{PhysicalDisks: []types.PhysicalDisk{
+		{Name: "/dev/sdd", Model: "ST8000NM000A", Type: "HDD", SizeFormatted: "7.28 TB",
+			Slot: &types.EnclosureSlot{Device: "/dev/sdd", Enclosure: "0:0:8:0", Slot: 4, Label: "Slot 04"}},
+	}}
+
+	for _, format := range []string{"text", "pretty"} {
+		output, err := FormatDisks(disk, format)
+		if err != nil {
+			t.Fatalf("FormatDisks(%q) error = %v", format, err)
+		}
+		if !strings.Contains(stripAnsiCodes(output), "enclosure 0:0:8:0, slot 4 (Slot 04)") {
+			t.Errorf("FormatDisks(%q) missing slot:\n%s", format, output)
+		}
+	}
+}
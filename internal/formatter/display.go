@@ -0,0 +1,59 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// displayString describes a monitor by maker and model with its PNP and product IDs, e.g.
+// "Dell U2720Q [DEL:a0c4]"; the maker is left out where the model already starts with it
+func displayString(d types.DisplayInfo) string {
+	name := d.Model
+	if d.Manufacturer != "" && !strings.HasPrefix(strings.ToLower(d.Model), strings.ToLower(d.Manufacturer)) {
+		name = strings.TrimSpace(d.Manufacturer + " " + d.Model)
+	}
+	if name == "" {
+		name = "Unknown display"
+	}
+	if d.ManufacturerID != "" {
+		name += fmt.Sprintf(" [%s:%s]", d.ManufacturerID, d.ProductCode)
+	}
+	return name
+}
+
+// displayModeString writes a monitor's resolution and refresh rate, e.g. "2560x1440 @ 59.95 Hz"
+func displayModeString(d types.DisplayInfo) string {
+	if d.Width == 0 {
+		return ""
+	}
+	mode := fmt.Sprintf("%dx%d", d.Width, d.Height)
+	if d.RefreshHz > 0 {
+		mode += " @ " + strconv.FormatFloat(d.RefreshHz, 'f', -1, 64) + " Hz"
+	}
+	return mode
+}
+
+// displayDetailString lists a monitor's size, year, serial and role, e.g.
+// `27" 597x336 mm, 2020, serial ABC1234, primary`
+func displayDetailString(d types.DisplayInfo) string {
+	var details []string
+	if d.DiagonalInches > 0 {
+		details = append(details, fmt.Sprintf(`%s" %dx%d mm`, strconv.FormatFloat(d.DiagonalInches, 'f', -1, 64), d.WidthMM, d.HeightMM))
+	}
+	if d.Year > 0 {
+		details = append(details, strconv.Itoa(d.Year))
+	}
+	if d.Serial != "" {
+		details = append(details, "serial "+d.Serial)
+	}
+	if d.Builtin {
+		details = append(details, "built-in")
+	}
+	if d.Primary {
+		details = append(details, "primary")
+	}
+	return strings.Join(details, ", ")
+}
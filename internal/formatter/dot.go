@@ -0,0 +1,193 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// FormatDOT formats the hardware topology as a Graphviz DOT graph, for rendering system
+// diagrams with e.g. `dot -Tsvg`: the host links to the CPU and its cores, disks and the
+// partitions and mount points on them, GPUs and network interfaces. The report has no
+// socket breakdown, so all cores hang off a single CPU node
+func FormatDOT(info *types.SystemInfo) string {
+	g := &dotGraph{}
+	g.begin("digraph sysinfo")
+	g.line("rankdir=LR;")
+	g.line("node [shape=box, style=rounded, fontname=\"Helvetica\"];")
+
+	host := "host"
+	hostLabel := "host"
+	if info.System != nil {
+		hostLabel = info.System.Hostname
+		if info.System.Platform != "" {
+			hostLabel += "\n" + strings.TrimSpace(info.System.Platform+" "+info.System.PlatformVersion)
+		}
+	}
+	g.node(host, hostLabel, "shape=box3d")
+
+	if cpu := info.CPU; cpu != nil {
+		g.begin("subgraph cluster_cpu")
+		g.line("label=\"CPU\";")
+		label := cpu.ModelName
+		if label == "" {
+			label = "CPU"
+		}
+		label += fmt.Sprintf("\n%d cores, %d threads", cpu.Cores, cpu.LogicalCPUs)
+		g.node("cpu", label, "")
+		threads := ""
+		if cpu.Cores > 0 && cpu.LogicalCPUs > cpu.Cores {
+			threads = fmt.Sprintf("\n%d threads", cpu.LogicalCPUs/cpu.Cores)
+		}
+		for i := 0; i < int(cpu.Cores); i++ {
+			core := fmt.Sprintf("cpu/core%d", i)
+			g.node(core, fmt.Sprintf("Core %d%s", i, threads), "")
+			g.edge("cpu", core)
+		}
+		g.end()
+		g.edge(host, "cpu")
+	}
+
+	if disk := info.Disk; disk != nil && (len(disk.PhysicalDisks) > 0 || len(disk.Partitions) > 0) {
+		g.begin("subgraph cluster_storage")
+		g.line("label=\"Storage\";")
+		for _, d := range disk.PhysicalDisks {
+			label := d.Name
+			if d.Model != "" {
+				label += "\n" + d.Model
+			}
+			label += "\n" + strings.TrimSpace(d.SizeFormatted+" "+d.Type)
+			g.node("disk/"+d.Name, label, "shape=cylinder")
+		}
+		seen, linked := map[string]bool{}, map[string]bool{}
+		for _, p := range disk.Partitions {
+			partition := "partition/" + p.Device
+			if !seen[partition] {
+				seen[partition] = true
+				g.node(partition, strings.TrimSpace(p.Device+"\n"+p.FSType), "")
+				if parent := partitionDisk(p.Device, disk.PhysicalDisks); parent != "" {
+					g.edge("disk/"+parent, partition)
+				}
+			}
+			if p.MountPoint != "" {
+				mount := "mount/" + p.MountPoint
+				g.node(mount, fmt.Sprintf("%s\n%s of %s used", p.MountPoint, p.UsedFormatted, p.TotalFormatted), "shape=folder")
+				g.edge(partition, mount)
+			}
+		}
+		g.end()
+		for _, d := range disk.PhysicalDisks {
+			g.edge(host, "disk/"+d.Name)
+		}
+		// Partitions with no disk of their own (device mapper, network and Windows volumes)
+		// link straight to the host so none float free
+		for _, p := range disk.Partitions {
+			if partitionDisk(p.Device, disk.PhysicalDisks) == "" && !linked[p.Device] {
+				linked[p.Device] = true
+				g.edge(host, "partition/"+p.Device)
+			}
+		}
+	}
+
+	if gpu := info.GPU; gpu != nil && len(gpu.GPUs) > 0 {
+		g.begin("subgraph cluster_gpu")
+		g.line("label=\"GPU\";")
+		for _, card := range gpu.GPUs {
+			label := fmt.Sprintf("GPU %d: %s", card.Index, card.Name)
+			if card.MemoryTotal > 0 {
+				label += "\n" + utils.FormatBytes(card.MemoryTotal)
+			}
+			if card.Driver != "" {
+				label += "\n" + strings.TrimSpace(card.Driver+" "+card.DriverVersion)
+			}
+			g.node(fmt.Sprintf("gpu/%d", card.Index), label, "")
+		}
+		g.end()
+		for _, card := range gpu.GPUs {
+			g.edge(host, fmt.Sprintf("gpu/%d", card.Index))
+		}
+	}
+
+	if network := info.Network; network != nil && len(network.Interfaces) > 0 {
+		g.begin("subgraph cluster_network")
+		g.line("label=\"Network\";")
+		for _, iface := range network.Interfaces {
+			lines := []string{iface.Name}
+			if iface.HardwareAddr != "" {
+				lines = append(lines, iface.HardwareAddr)
+			}
+			lines = append(lines, iface.Addresses...)
+			g.node("nic/"+iface.Name, strings.Join(lines, "\n"), "shape=component")
+		}
+		g.end()
+		for _, iface := range network.Interfaces {
+			g.edge(host, "nic/"+iface.Name)
+		}
+	}
+
+	g.end()
+	return g.String()
+}
+
+// partitionDisk returns the name of the physical disk a partition device is on, matching
+// the kernel's naming (sda1 on sda, nvme0n1p2 on nvme0n1, disk0s1 on disk0, or sdb itself
+// for a filesystem on the whole disk), or ""
+func partitionDisk(device string, disks []types.PhysicalDisk) string {
+	base := device[strings.LastIndex(device, "/")+1:]
+	best, disk := "", ""
+	for _, d := range disks {
+		// Disks are named by path on some platforms (/dev/sda) and bare on others (sda)
+		name := d.Name[strings.LastIndex(d.Name, "/")+1:]
+		rest, ok := strings.CutPrefix(base, name)
+		if !ok || name == "" || len(name) <= len(best) {
+			continue
+		}
+		if number := strings.TrimLeft(rest, "ps"); rest == "" || (number != "" && strings.Trim(number, "0123456789") == "") {
+			best, disk = name, d.Name
+		}
+	}
+	return disk
+}
+
+// dotGraph accumulates DOT statements, indented by graph nesting
+type dotGraph struct {
+	strings.Builder
+	depth int
+}
+
+func (g *dotGraph) line(s string) {
+	g.WriteString(strings.Repeat("  ", g.depth))
+	g.WriteString(s)
+	g.WriteString("\n")
+}
+
+func (g *dotGraph) begin(graph string) {
+	g.line(graph + " {")
+	g.depth++
+}
+
+func (g *dotGraph) end() {
+	g.depth--
+	g.line("}")
+}
+
+func (g *dotGraph) node(id, label, attrs string) {
+	if attrs != "" {
+		attrs = ", " + attrs
+	}
+	g.line(fmt.Sprintf("%s [label=%s%s];", dotQuote(id), dotQuote(label), attrs))
+}
+
+func (g *dotGraph) edge(from, to string) {
+	g.line(fmt.Sprintf("%s -> %s;", dotQuote(from), dotQuote(to)))
+}
+
+// dotQuote writes s as a DOT quoted string, with line breaks as \n escapes
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
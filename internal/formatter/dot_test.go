@@ -0,0 +1,63 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatDOT(t *testing.T) {
+	info := createTestSystemInfo()
+	info.CPU.Cores = 2
+	info.CPU.LogicalCPUs = 4
+	info.Disk.PhysicalDisks = []types.PhysicalDisk{{Name: "sda", Model: "Test \"Fast\" Disk", SizeFormatted: "500 GB", Type: "SSD"}}
+	info.Disk.Partitions = append(info.Disk.Partitions, types.PartitionInfo{Device: "/dev/mapper/vg-data", MountPoint: "/data", FSType: "xfs"})
+
+	output, err := Format(info, &config.Config{Format: "dot"})
+	if err != nil {
+		t.Fatalf("Format dot failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "digraph sysinfo {\n") || !strings.HasSuffix(output, "}\n") {
+		t.Errorf("output is not a digraph:\n%s", output)
+	}
+
+	for _, expected := range []string{
+		`"host" -> "cpu";`,
+		`"cpu" -> "cpu/core1";`,
+		`"cpu/core0" [label="Core 0\n2 threads"];`,
+		`"disk/sda" -> "partition//dev/sda1";`,
+		`"partition//dev/sda1" -> "mount//";`,
+		`"host" -> "partition//dev/mapper/vg-data";`,
+		`Test \"Fast\" Disk`,
+		`"host" -> "nic/eth0";`,
+		`"host" -> "gpu/0";`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("output does not contain %s:\n%s", expected, output)
+		}
+	}
+	if strings.Count(output, "{") != strings.Count(output, "}") {
+		t.Errorf("unbalanced braces:\n%s", output)
+	}
+}
+
+func TestPartitionDisk(t *testing.T) {
+	disks := []types.PhysicalDisk{{Name: "sda"}, {Name: "nvme0n1"}, {Name: "nvme0n10"}, {Name: "disk0"}, {Name: "/dev/sdb"}}
+	tests := map[string]string{
+		"/dev/sda1":           "sda",
+		"/dev/nvme0n1p2":      "nvme0n1",
+		"/dev/nvme0n10p1":     "nvme0n10",
+		"/dev/disk0s1":        "disk0",
+		"/dev/sdb":            "/dev/sdb",
+		"/dev/sdab1":          "",
+		"/dev/mapper/vg-root": "",
+		"C:":                  "",
+	}
+	for device, expected := range tests {
+		if got := partitionDisk(device, disks); got != expected {
+			t.Errorf("partitionDisk(%q) = %q, expected %q", device, got, expected)
+		}
+	}
+}
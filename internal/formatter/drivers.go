@@ -0,0 +1,32 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// driverDetailString lists a driver's description, size, users, state and taint, e.g.
+// "1.5 MB, used by snd_hda_intel, snd_hda_codec_hdmi, taint POE"
+func driverDetailString(d types.KernelDriver) string {
+	var details []string
+	if d.Description != "" {
+		details = append(details, d.Description)
+	}
+	if d.SizeBytes > 0 {
+		details = append(details, formatBytes(d.SizeBytes))
+	}
+	if len(d.UsedBy) > 0 {
+		details = append(details, "used by "+strings.Join(d.UsedBy, ", "))
+	} else if d.RefCount > 0 {
+		details = append(details, plural(d.RefCount, "reference"))
+	}
+	// Loaded modules are Live and listed drivers Running; anything else is worth noting
+	if d.State != "" && d.State != "Live" && d.State != "Running" {
+		details = append(details, strings.ToLower(d.State))
+	}
+	if d.Taint != "" {
+		details = append(details, "taint "+d.Taint)
+	}
+	return strings.Join(details, ", ")
+}
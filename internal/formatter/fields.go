@@ -0,0 +1,80 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// selectedField is one value of a report pruned with --fields, by its dotted JSON path
+type selectedField struct {
+	Path  string
+	Value string
+}
+
+// selectedFields lists the values a --fields report keeps, in the order the json format writes
+// them. The text formats print these rather than their layout, which would show every field
+// that was not selected as empty or zero
+func selectedFields(info *types.SystemInfo) ([]selectedField, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var fields []selectedField
+	if err := walkSelectedFields(decoder, "", &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	// The timestamp is kept for the formats' own header
+	kept := fields[:0]
+	for _, field := range fields {
+		if field.Path != "timestamp" {
+			kept = append(kept, field)
+		}
+	}
+	return kept, nil
+}
+
+// walkSelectedFields appends the scalar values of the next JSON value under path, naming list
+// elements by index, e.g. disk.partitions[0].mount_point
+func walkSelectedFields(decoder *json.Decoder, path string, fields *[]selectedField) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			name := fmt.Sprint(key)
+			if path != "" {
+				name = path + "." + name
+			}
+			if err := walkSelectedFields(decoder, name, fields); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := walkSelectedFields(decoder, fmt.Sprintf("%s[%d]", path, i), fields); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	case nil:
+		return nil
+	}
+	*fields = append(*fields, selectedField{Path: path, Value: fmt.Sprint(token)})
+	return nil
+}
@@ -0,0 +1,54 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// firewallStatusString is "active" when any firewall filters traffic, else "inactive"
+func firewallStatusString(f *types.FirewallData) string {
+	if f.Active {
+		return "active"
+	}
+	return "inactive"
+}
+
+// firewallDetailString summarizes a firewall, e.g. "active, 4 rules, incoming deny"
+func firewallDetailString(f types.Firewall) string {
+	details := []string{"inactive"}
+	if f.Active {
+		details[0] = "active"
+	}
+	if f.Rules != nil {
+		details = append(details, ruleCountString(*f.Rules))
+	}
+	if f.DefaultIncoming != "" {
+		details = append(details, "incoming "+f.DefaultIncoming)
+	}
+	return strings.Join(details, ", ")
+}
+
+// firewallZoneString summarizes a zone or profile, e.g. "eth0 wlan0, 5 rules, incoming reject"
+func firewallZoneString(z types.FirewallZone) string {
+	var details []string
+	if !z.Active {
+		details = append(details, "off")
+	}
+	if len(z.Interfaces) > 0 {
+		details = append(details, strings.Join(z.Interfaces, " "))
+	}
+	details = append(details, ruleCountString(z.Rules))
+	if z.DefaultIncoming != "" {
+		details = append(details, "incoming "+z.DefaultIncoming)
+	}
+	return strings.Join(details, ", ")
+}
+
+func ruleCountString(n int) string {
+	if n == 1 {
+		return "1 rule"
+	}
+	return fmt.Sprintf("%d rules", n)
+}
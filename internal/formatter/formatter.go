@@ -3,6 +3,7 @@ package formatter
 import (
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/mayvqt/sysinfo/internal/config"
 	"github.com/mayvqt/sysinfo/internal/types"
@@ -10,13 +11,43 @@ import (
 
 // Format formats the system information according to the specified format
 func Format(info *types.SystemInfo, cfg *config.Config) (string, error) {
+	// A query replaces the report with the values it selects
+	if cfg.Query != "" {
+		return FormatQuery(info, cfg.Query)
+	}
+
 	switch cfg.Format {
 	case "json":
+		if cfg.Compact {
+			return FormatCompactJSON(info)
+		}
 		return FormatJSON(info)
+	case "ndjson":
+		return FormatNDJSON(info)
 	case "text":
 		return FormatText(info), nil
 	case "pretty":
 		return FormatPretty(info), nil
+	case "html":
+		return FormatHTML(info)
+	case "csv":
+		return FormatCSV(info, cfg.Section)
+	case "prometheus":
+		return FormatPrometheus(info), nil
+	case "influx":
+		return FormatInflux(info, cfg.InfluxPrefix), nil
+	case "template":
+		return FormatTemplate(info, cfg.TemplateFile)
+	case "xml":
+		return FormatXML(info)
+	case "msgpack":
+		return FormatMsgpack(info)
+	case "dot":
+		return FormatDOT(info), nil
+	case "parquet":
+		return FormatParquet(info)
+	case "sqlite":
+		return "", fmt.Errorf("the sqlite format writes a database file, use it with --output or a file output")
 	default:
 		return "", fmt.Errorf("unknown format: %s", cfg.Format)
 	}
@@ -30,3 +61,52 @@ func FormatJSON(info *types.SystemInfo) (string, error) {
 	}
 	return string(data), nil
 }
+
+// FormatCompactJSON formats the information as minified JSON, for piping into other tools
+func FormatCompactJSON(info *types.SystemInfo) (string, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatNDJSON formats the information as one compact JSON line (newline-delimited JSON),
+// so repeated reports appended to one stream are read by log shippers as one event each
+func FormatNDJSON(info *types.SystemInfo) (string, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// DefaultProcessNameWidth is how many characters of a process name the pretty format shows
+const DefaultProcessNameWidth = 30
+
+// minProcessNameWidth leaves room for a few characters before the ellipsis
+const minProcessNameWidth = 8
+
+// nameWidth is the process name column width; zero keeps DefaultProcessNameWidth
+var nameWidth atomic.Int32
+
+// ValidateProcessNameWidth rejects --process-name-width values too narrow to read; 0 keeps the default
+func ValidateProcessNameWidth(width int) error {
+	if width != 0 && width < minProcessNameWidth {
+		return fmt.Errorf("invalid process name width: %d (expected at least %d)", width, minProcessNameWidth)
+	}
+	return nil
+}
+
+// SetProcessNameWidth sets how many characters of process and container names the pretty
+// format shows from now on; longer names are cut with an ellipsis
+func SetProcessNameWidth(width int) {
+	nameWidth.Store(int32(width))
+}
+
+func processNameWidth() int {
+	if width := nameWidth.Load(); width > 0 {
+		return int(width)
+	}
+	return DefaultProcessNameWidth
+}
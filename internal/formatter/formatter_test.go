@@ -1,6 +1,7 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -257,6 +258,47 @@ func TestFormatJSON(t *testing.T) {
 	}
 }
 
+func TestFormatNDJSON(t *testing.T) {
+	output, err := Format(createTestSystemInfo(), &config.Config{Format: "ndjson"})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	// One line, terminated by a newline
+	if !strings.HasSuffix(output, "\n") || strings.Count(output, "\n") != 1 {
+		t.Errorf("NDJSON output is not a single line: %q", output)
+	}
+
+	var decoded types.SystemInfo
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if decoded.System == nil || decoded.System.Hostname != "test-host" {
+		t.Errorf("decoded system = %+v", decoded.System)
+	}
+}
+
+func TestFormatCompactJSON(t *testing.T) {
+	info := createTestSystemInfo()
+	output, err := Format(info, &config.Config{Format: "json", Compact: true})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if strings.Contains(output, "\n") {
+		t.Errorf("compact JSON is not a single line: %q", output)
+	}
+
+	// The same report as the indented JSON
+	indented, _ := FormatJSON(info)
+	var compactBuf bytes.Buffer
+	if err := json.Compact(&compactBuf, []byte(indented)); err != nil {
+		t.Fatal(err)
+	}
+	if output != compactBuf.String() {
+		t.Errorf("compact JSON differs from the indented report:\n%s\n%s", output, compactBuf.String())
+	}
+}
+
 func TestFormatJSONWithNilFields(t *testing.T) {
 	info := &types.SystemInfo{
 		Timestamp: time.Now(),
@@ -340,6 +382,1264 @@ func TestFormatTextWithNilFields(t *testing.T) {
 	}
 }
 
+func TestLicenseFormatting(t *testing.T) {
+	installDate := time.Date(2023, 3, 14, 9, 0, 0, 0, time.UTC)
+	info := createTestSystemInfo()
+	info.System.License = &types.OSLicense{
+		Edition:     "Microsoft Windows 11 Pro",
+		Status:      "Licensed",
+		Channel:     "RETAIL",
+		InstallDate: &installDate,
+	}
+
+	expected := []string{"Microsoft Windows 11 Pro", "Licensed", "(RETAIL)", "2023-03-14"}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing license value: %s", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing license value: %s", value)
+		}
+	}
+
+	// No license section on other platforms
+	info.System.License = nil
+	if strings.Contains(FormatText(info), "Edition:") {
+		t.Error("Text output should not contain Edition when License is nil")
+	}
+}
+
+func TestSkippedModuleFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.System.InstallationType = "Server Core"
+	info.Errors = []types.CollectionError{{Module: "battery", Error: "Server Core has no battery class driver or its WMI classes"}}
+
+	expected := []string{"Installation:", "Server Core", "ERRORS", "battery:", "no battery class driver"}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing %q", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing %q", value)
+		}
+	}
+
+	info.Errors = nil
+	if strings.Contains(FormatText(info), "ERRORS") {
+		t.Error("Text output should not contain ERRORS when nothing was skipped")
+	}
+}
+
+func TestCPUTopologyFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.CPU.Sockets, info.CPU.CoresPerSocket, info.CPU.ThreadsPerCore = 2, 20, 2
+	info.CPU.Packages = []types.CPUPackage{
+		{Socket: 0, ModelName: "Intel Xeon Gold 6230", Stepping: 6, Cores: 20, Threads: 40},
+		{Socket: 1, ModelName: "Intel Xeon Gold 6230", Stepping: 7, Cores: 20, Threads: 40},
+	}
+
+	expected := []string{"2 sockets × 20 cores × 2 threads", "Socket 1:", "Intel Xeon Gold 6230 (20 cores, 40 threads, stepping 7)"}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing %q", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing %q", value)
+		}
+	}
+
+	// A single socket is described by the topology line alone
+	info.CPU.Packages = info.CPU.Packages[:1]
+	if strings.Contains(FormatText(info), "Socket 0:") {
+		t.Error("Text output should not list sockets for a single package")
+	}
+}
+
+func TestCoreClassFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.CPU.Usage = []float64{90, 80, 5, 3}
+	info.CPU.CoreClasses = []types.CPUCoreClass{
+		{Class: "performance", CPUs: []int{0, 1}, Usage: 85},
+		{Class: "efficiency", CPUs: []int{2, 3}, Usage: 4},
+	}
+
+	textOutput := FormatText(info)
+	for _, value := range []string{"Performance Cores Usage: 85.00% across CPUs 0-1", "Efficiency Cores Usage: 4.00% across CPUs 2-3", "Core 0 (P): 90.00%", "Core 3 (E): 3.00%"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing %q", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range []string{"Performance Cores:", "85.0% (CPUs 0-1)", "Core 2  E:"} {
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing %q", value)
+		}
+	}
+	if summary := FormatSummary(info, false); !strings.Contains(summary, "(P 85%, E 4%)") {
+		t.Errorf("Summary missing per-class usage:\n%s", summary)
+	}
+	if metrics := FormatPrometheus(info); !strings.Contains(metrics, `sysinfo_cpu_class_usage_percent{class="efficiency"} 4`) {
+		t.Error("Prometheus output missing per-class usage")
+	}
+
+	// Without classes the per-core lines are untagged
+	info.CPU.CoreClasses = nil
+	if textOutput := FormatText(info); !strings.Contains(textOutput, "Core 0: 90.00%") || strings.Contains(textOutput, "Cores Usage") {
+		t.Error("Text output should not show core classes for CPUs with one kind of core")
+	}
+}
+
+func TestCPUListString(t *testing.T) {
+	tests := map[string][]int{
+		"":            nil,
+		"3":           {3},
+		"0-7,16":      {0, 1, 2, 3, 4, 5, 6, 7, 16},
+		"0,2,4-5":     {0, 2, 4, 5},
+		"16-23,30-31": {16, 17, 18, 19, 20, 21, 22, 23, 30, 31},
+	}
+	for want, cpus := range tests {
+		if got := cpuListString(cpus); got != want {
+			t.Errorf("cpuListString(%v) = %q, expected %q", cpus, got, want)
+		}
+	}
+}
+
+func TestSecurityFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Security = &types.SecurityData{
+		SIP:        "enabled",
+		Gatekeeper: "disabled",
+		FileVault:  "on",
+		MDM:        &types.MDMEnrollment{Enrolled: true, DEP: true, UserApproved: true},
+	}
+
+	expected := []string{"SECURITY", "System Integrity Protection: enabled", "Gatekeeper: disabled", "FileVault: on", "MDM: enrolled (DEP)"}
+
+	textOutput := FormatText(info)
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing security value: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "SECURITY") || !strings.Contains(prettyOutput, "Gatekeeper:") {
+		t.Error("Pretty output missing security section")
+	}
+
+	// Linux enforcement status
+	info.Security = &types.SecurityData{
+		SELinux:  &types.SELinuxStatus{Mode: "permissive", ConfigMode: "enforcing", Policy: "targeted"},
+		AppArmor: &types.AppArmorStatus{Enabled: true, Profiles: 40, Enforce: 38, Complain: 2},
+	}
+	textOutput = FormatText(info)
+	for _, value := range []string{"SELinux: permissive (policy: targeted), enforcing at boot", "AppArmor: enabled (40 profiles: 38 enforce, 2 complain)"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing security value: %s", value)
+		}
+	}
+
+	// CA trust store with a locally added root
+	info.Security = &types.SecurityData{
+		TrustStore: &types.TrustStore{
+			Source:  "/etc/ssl/certs/ca-certificates.crt",
+			Total:   146,
+			Expired: 1,
+			ExpiringSoon: []types.CACertificate{
+				{Subject: "Old Root CA", NotAfter: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			Local: []types.CACertificate{
+				{Subject: "Corp Inspection CA", NotAfter: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Source: "/usr/local/share/ca-certificates/corp.crt"},
+			},
+		},
+	}
+	textOutput = FormatText(info)
+	for _, value := range []string{
+		"CA trust store: 146 CAs, 1 expired, 1 expiring within 90 days, 1 locally added (/etc/ssl/certs/ca-certificates.crt)",
+		"Locally added CA: Corp Inspection CA (expires 2030-01-01, /usr/local/share/ca-certificates/corp.crt)",
+		"Expiring CA: Old Root CA (expires 2026-08-01)",
+	} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing trust store value: %s", value)
+		}
+	}
+	if !strings.Contains(stripAnsiCodes(FormatPretty(info)), "Locally added CA:") {
+		t.Error("Pretty output missing locally added CA")
+	}
+
+	// Nothing to report means no section
+	info.Security = &types.SecurityData{}
+	if strings.Contains(FormatText(info), "SECURITY") {
+		t.Error("Text output should not contain empty security section")
+	}
+}
+
+func TestBaseboardFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Baseboard = &types.BoardData{
+		SystemVendor:  "Dell Inc.",
+		SystemProduct: "PowerEdge R650",
+		SystemSerial:  "7XK2Q93",
+		Vendor:        "Dell Inc.",
+		Product:       "0Y2K8N",
+		Version:       "A03",
+		BIOSVendor:    "Dell Inc.",
+		BIOSVersion:   "1.9.2",
+		BIOSDate:      "2023-01-12",
+		ChassisType:   "Rack Mount Chassis",
+	}
+
+	expected := []string{"BASEBOARD", "System: Dell Inc. PowerEdge R650", "System Serial: 7XK2Q93", "Motherboard: Dell Inc. 0Y2K8N", "Board Version: A03", "BIOS: Dell Inc. 1.9.2 (2023-01-12)", "Chassis: Rack Mount Chassis"}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing baseboard value: %s", value)
+		}
+		label, _, _ := strings.Cut(value, ": ")
+		if !strings.Contains(prettyOutput, label) {
+			t.Errorf("Pretty output missing baseboard label: %s", label)
+		}
+	}
+	if strings.Contains(textOutput, "UUID:") {
+		t.Error("Text output should leave out the unreported UUID")
+	}
+
+	html, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "<h2>Baseboard</h2>") || !strings.Contains(html, "PowerEdge R650") {
+		t.Error("HTML output missing baseboard section")
+	}
+
+	// Nothing to report means no section
+	info.Baseboard = &types.BoardData{}
+	if strings.Contains(FormatText(info), "BASEBOARD") {
+		t.Error("Text output should not contain empty baseboard section")
+	}
+}
+
+func TestAcceleratorFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Accelerators = &types.AcceleratorData{Accelerators: []types.AcceleratorInfo{
+		{Type: "NPU", Name: "Intel Meteor Lake NPU", Vendor: "Intel", Bus: "pci", Address: "0000:00:0b.0", VendorID: "8086", DeviceID: "7d1d", Driver: "intel_vpu", DriverLoaded: true},
+		{Type: "FPGA", Name: "Xilinx FPGA", Vendor: "Xilinx", Bus: "pci", Address: "0000:01:00.0", VendorID: "10ee", DeviceID: "903f"},
+	}}
+
+	expected := []string{"ACCELERATORS", "Intel Meteor Lake NPU", "PCI 0000:00:0b.0 [8086:7d1d]", "intel_vpu", "Xilinx FPGA", "not loaded"}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing accelerator value: %s", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing accelerator value: %s", value)
+		}
+	}
+
+	info.Accelerators = nil
+	if strings.Contains(FormatText(info), "ACCELERATORS") {
+		t.Error("Text output should not contain accelerator section when Accelerators is nil")
+	}
+}
+
+func TestPCIFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.PCI = &types.PCIData{Devices: []types.PCIDevice{
+		{Address: "0000:00:1f.3", VendorID: "8086", DeviceID: "a348", ClassName: "Audio device", Vendor: "Intel Corporation", Name: "Cannon Lake PCH cAVS", Driver: "snd_hda_intel"},
+		{Address: "0000:3d:00.0", VendorID: "1e0f", DeviceID: "0001", ClassName: "Non-Volatile memory controller"},
+	}}
+
+	expected := []string{
+		"0000:00:1f.3 Audio device: Intel Corporation Cannon Lake PCH cAVS [8086:a348] (snd_hda_intel)",
+		"0000:3d:00.0 Non-Volatile memory controller: Unknown device [1e0f:0001] (no driver)",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "PCI DEVICES") {
+		t.Error("Text output missing PCI section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing PCI device: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "PCI DEVICES") || !strings.Contains(prettyOutput, "Cannon Lake PCH cAVS [8086:a348] (snd_hda_intel)") {
+		t.Error("Pretty output missing PCI devices")
+	}
+
+	info.PCI = nil
+	if strings.Contains(FormatText(info), "PCI DEVICES") {
+		t.Error("Text output should not contain PCI section when PCI is nil")
+	}
+}
+
+func TestUSBFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.USB = &types.USBData{Devices: []types.USBDevice{
+		{Address: "1-2", Bus: 1, Port: "2", VendorID: "046d", ProductID: "c52b", Vendor: "Logitech", Product: "USB Receiver",
+			SpeedMbps: 12, ClassName: "Human Interface Device", Drivers: []string{"usbhid"}},
+		{Address: "2-1.4", Bus: 2, Port: "1.4", VendorID: "0781", ProductID: "5581", Serial: "4C530001", SpeedMbps: 10000},
+	}}
+
+	expected := []string{
+		"bus 1 port 2 Human Interface Device: Logitech USB Receiver [046d:c52b] (12 Mbps, usbhid)",
+		"bus 2 port 1.4 Unknown device [0781:5581] (10 Gbps, serial 4C530001)",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "USB DEVICES") {
+		t.Error("Text output missing USB section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing USB device: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "USB DEVICES") || !strings.Contains(prettyOutput, "Logitech USB Receiver [046d:c52b] (12 Mbps, usbhid)") {
+		t.Error("Pretty output missing USB devices")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>bus 2 port 1.4</td>") || !strings.Contains(htmlOutput, "<td>10 Gbps</td>") {
+		t.Error("HTML output missing USB devices")
+	}
+
+	info.USB = nil
+	if strings.Contains(FormatText(info), "USB DEVICES") {
+		t.Error("Text output should not contain USB section when USB is nil")
+	}
+}
+
+func TestDisplayFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Displays = &types.DisplayData{Displays: []types.DisplayInfo{
+		{Name: "eDP-1", Manufacturer: "BOE", ManufacturerID: "BOE", Model: "NE135FBM-N41", ProductCode: "0bca", Width: 2256, Height: 1504,
+			RefreshHz: 60, WidthMM: 285, HeightMM: 190, DiagonalInches: 13.5, Builtin: true},
+		{Name: "DP-1", Manufacturer: "Dell", ManufacturerID: "DEL", Model: "DELL U2720Q", ProductCode: "a0c4", Serial: "ABC1234", Year: 2020,
+			Width: 2560, Height: 1440, RefreshHz: 59.95, WidthMM: 597, HeightMM: 336, DiagonalInches: 27, Primary: true},
+		{Name: "HDMI-2"},
+	}}
+
+	expected := []string{
+		`eDP-1 BOE NE135FBM-N41 [BOE:0bca] 2256x1504 @ 60 Hz (13.5" 285x190 mm, built-in)`,
+		`DP-1 DELL U2720Q [DEL:a0c4] 2560x1440 @ 59.95 Hz (27" 597x336 mm, 2020, serial ABC1234, primary)`,
+		"HDMI-2 Unknown display\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "DISPLAYS") {
+		t.Error("Text output missing displays section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing display: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "DISPLAYS") || !strings.Contains(prettyOutput, "DELL U2720Q [DEL:a0c4] 2560x1440 @ 59.95 Hz") {
+		t.Error("Pretty output missing displays")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>DP-1 (primary)</td>") || !strings.Contains(htmlOutput, "<td>2560x1440 @ 59.95 Hz</td>") {
+		t.Error("HTML output missing displays")
+	}
+
+	info.Displays = nil
+	if strings.Contains(FormatText(info), "DISPLAYS") {
+		t.Error("Text output should not contain displays section when Displays is nil")
+	}
+}
+
+func TestAudioFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Audio = &types.AudioData{Devices: []types.AudioDevice{
+		{Name: "HDA Intel PCH", ID: "PCH", Codecs: []string{"Realtek ALC257", "Intel Kabylake HDMI"}, Driver: "snd_hda_intel", Bus: "pci",
+			Playback: []string{"ALC257 Analog", "HDMI 0"}, Capture: []string{"ALC257 Analog"}},
+		{Name: "Scarlett 2i2 USB", Manufacturer: "Focusrite", Bus: "usb", Playback: []string{"Scarlett 2i2 USB"}, Default: true},
+		{Name: "USB Audio Device", Driver: "usbaudio", Status: "Error"},
+	}}
+
+	expected := []string{
+		"HDA Intel PCH [out: ALC257 Analog, HDMI 0; in: ALC257 Analog] (codec Realtek ALC257, codec Intel Kabylake HDMI, driver snd_hda_intel, pci)",
+		"Focusrite Scarlett 2i2 USB [out: Scarlett 2i2 USB] (usb, default)",
+		"USB Audio Device (driver usbaudio, status Error)\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "AUDIO DEVICES") {
+		t.Error("Text output missing audio section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing audio device: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "AUDIO DEVICES") || !strings.Contains(prettyOutput, "out: ALC257 Analog, HDMI 0; in: ALC257 Analog") {
+		t.Error("Pretty output missing audio devices")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>Focusrite Scarlett 2i2 USB (default)</td>") || !strings.Contains(htmlOutput, "<td>Realtek ALC257, Intel Kabylake HDMI</td>") {
+		t.Error("HTML output missing audio devices")
+	}
+
+	info.Audio = nil
+	if strings.Contains(FormatText(info), "AUDIO DEVICES") {
+		t.Error("Text output should not contain audio section when Audio is nil")
+	}
+}
+
+func TestDriversFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Drivers = &types.DriverData{
+		Drivers: []types.KernelDriver{
+			{Name: "nvidia", Version: "550.54.14", SizeBytes: 54808576, RefCount: 2, UsedBy: []string{"nvidia_modeset", "nvidia_uvm"}, State: "Live", Taint: "POE"},
+			{Name: "e1000e", SizeBytes: 323584, State: "Live"},
+			{Name: "usbhid", RefCount: 1, State: "Unloading"},
+		},
+		Tainted: []string{"proprietary module", "externally-built (out-of-tree) module loaded"},
+	}
+
+	expected := []string{
+		"Kernel tainted: proprietary module, externally-built (out-of-tree) module loaded\n",
+		"nvidia 550.54.14 (52.27 MB, used by nvidia_modeset, nvidia_uvm, taint POE)\n",
+		"e1000e (316.00 KB)\n",
+		"usbhid (1 reference, unloading)\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "KERNEL DRIVERS") {
+		t.Error("Text output missing drivers section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing driver line: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "KERNEL DRIVERS") || !strings.Contains(prettyOutput, "used by nvidia_modeset, nvidia_uvm") {
+		t.Error("Pretty output missing drivers")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>nvidia</td><td>550.54.14</td>") || !strings.Contains(htmlOutput, "Kernel tainted: proprietary module") {
+		t.Error("HTML output missing drivers")
+	}
+
+	info.Drivers = nil
+	if strings.Contains(FormatText(info), "KERNEL DRIVERS") {
+		t.Error("Text output should not contain drivers section when Drivers is nil")
+	}
+}
+
+func TestServicesFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Services = &types.ServiceData{
+		Manager: "systemd",
+		Total:   212,
+		Running: 180,
+		Failed:  2,
+		FailedServices: []types.ServiceStatus{
+			{Name: "backup.service", Description: "Nightly backup", State: "failed", Reason: "exit-code 2"},
+			{Name: "worker.service", Description: "worker.service", State: "failed"},
+		},
+	}
+
+	expected := []string{
+		"Manager: systemd\n",
+		"Services: 212 total, 180 running, 2 failed\n",
+		"Failed: backup.service (Nightly backup, exit-code 2)\n",
+		"Failed: worker.service\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "SERVICES") {
+		t.Error("Text output missing services section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing service line: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "SERVICES") || !strings.Contains(prettyOutput, "(Nightly backup, exit-code 2)") {
+		t.Error("Pretty output missing services")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "systemd: 212 total, 180 running, 2 failed") || !strings.Contains(htmlOutput, "<td>backup.service</td><td>Nightly backup</td>") {
+		t.Error("HTML output missing services")
+	}
+
+	info.Services = nil
+	if strings.Contains(FormatText(info), "SERVICES") {
+		t.Error("Text output should not contain services section when Services is nil")
+	}
+}
+
+func TestPackagesFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Packages = &types.PackageData{
+		Managers: []string{"dpkg", "rpm"},
+		Packages: []types.InstalledPackage{
+			{Name: "bash", Version: "5.2.15-2+b7", Arch: "amd64", Manager: "dpkg"},
+			{Name: "openssl", Version: "1:3.0.7-27.el9", Arch: "x86_64", Manager: "rpm"},
+			{Name: "tzdata", Version: "2024a-0+deb12u1", Arch: "all", Manager: "dpkg"},
+		},
+	}
+
+	expected := []string{
+		"Packages: 3 (2 dpkg, 1 rpm)\n",
+		"bash 5.2.15-2+b7 (amd64, dpkg)\n",
+		"openssl 1:3.0.7-27.el9 (x86_64, rpm)\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "INSTALLED PACKAGES") {
+		t.Error("Text output missing packages section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing package line: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "INSTALLED PACKAGES") || !strings.Contains(prettyOutput, "2 dpkg, 1 rpm") || strings.Contains(prettyOutput, "tzdata") {
+		t.Error("Pretty output should summarize the packages without listing them")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>openssl</td><td>1:3.0.7-27.el9</td><td>x86_64</td><td>rpm</td>") {
+		t.Error("HTML output missing packages")
+	}
+
+	info.Packages = nil
+	if strings.Contains(FormatText(info), "INSTALLED PACKAGES") {
+		t.Error("Text output should not contain packages section when Packages is nil")
+	}
+}
+
+func TestFirewallFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	rules := 7
+	info.Firewall = &types.FirewallData{
+		Active: true,
+		Firewalls: []types.Firewall{
+			{
+				Name:   "firewalld",
+				Active: true,
+				Rules:  &rules,
+				Zones: []types.FirewallZone{
+					{Name: "public", Active: true, DefaultIncoming: "reject", Interfaces: []string{"eth0"}, Rules: 6},
+					{Name: "trusted", Active: true, DefaultIncoming: "allow", Interfaces: []string{"docker0"}, Rules: 1},
+				},
+			},
+			{Name: "ufw", DefaultIncoming: "deny"},
+		},
+	}
+
+	expected := []string{
+		"Status: active\n",
+		"firewalld: active, 7 rules\n",
+		"  public: eth0, 6 rules, incoming reject\n",
+		"  trusted: docker0, 1 rule, incoming allow\n",
+		"ufw: inactive, incoming deny\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "FIREWALL\n") {
+		t.Error("Text output missing firewall section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing firewall line: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "FIREWALL") || !strings.Contains(prettyOutput, "eth0, 6 rules, incoming reject") {
+		t.Error("Pretty output missing firewall section")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td></td><td>public</td><td>active</td><td>eth0</td><td>6</td><td>reject</td>") {
+		t.Error("HTML output missing firewall zones")
+	}
+
+	info.Firewall = nil
+	if strings.Contains(FormatText(info), "FIREWALL\n") {
+		t.Error("Text output should not contain firewall section when Firewall is nil")
+	}
+}
+
+func TestUsersFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	login := time.Date(2026, 6, 1, 9, 12, 0, 0, time.UTC)
+	info.Users = &types.UserData{
+		Sessions: []types.UserSession{
+			{User: "alice", Terminal: "pts/0", RemoteHost: "10.0.0.5", LoginTime: login},
+			{User: "bob", Terminal: "RDP-Tcp#3", LoginTime: login.Add(time.Hour), State: "disconnected"},
+		},
+		LocalAccounts: 42,
+		UserAccounts:  3,
+	}
+
+	expected := []string{
+		"Local accounts: 42 (3 for people)\n",
+		"Sessions: 2\n",
+		"alice (pts/0 from 10.0.0.5, since 2026-06-01 09:12)\n",
+		"bob (RDP-Tcp#3, since 2026-06-01 10:12, disconnected)\n",
+	}
+	textOutput := FormatText(info)
+	if !strings.Contains(textOutput, "USERS\n") {
+		t.Error("Text output missing users section")
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing users line: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "USERS") || !strings.Contains(prettyOutput, "(pts/0 from 10.0.0.5, since 2026-06-01 09:12)") {
+		t.Error("Pretty output missing users section")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>alice</td><td>pts/0</td><td>10.0.0.5</td><td>2026-06-01 09:12</td>") {
+		t.Error("HTML output missing sessions")
+	}
+
+	info.Users = nil
+	if strings.Contains(FormatText(info), "USERS\n") {
+		t.Error("Text output should not contain users section when Users is nil")
+	}
+}
+
+func TestRecommendationsFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Processes = &types.ProcessData{
+		TotalCount:    120,
+		Zombies:       14,
+		ZombieParents: []types.ZombieParent{{PID: 2301, Name: "php-fpm", Zombies: 12}, {PID: 900, Zombies: 2}},
+		FileHandles:   &types.FileHandleUsage{Open: 95000, Max: 100000},
+		TopByOpenFiles: []types.ProcessInfo{
+			{PID: 4242, Name: "nginx", OpenFiles: 1020, OpenFilesLimit: 1024, Service: "nginx.service"},
+		},
+	}
+	info.Recommendations = []types.Recommendation{
+		{Severity: "CRITICAL", Component: "file_handles", Subject: "nginx.service", Action: "nginx.service (nginx, PID 4242) has 1020 of its 1024 file descriptors open"},
+		{Severity: "WARNING", Component: "zombies", Subject: "php-fpm", Action: "Restart php-fpm (PID 2301)"},
+	}
+
+	textOutput := FormatText(info)
+	expected := []string{
+		"RECOMMENDATIONS\n",
+		"CRITICAL nginx.service (nginx, PID 4242) has 1020 of its 1024 file descriptors open\n",
+		"WARNING  Restart php-fpm (PID 2301)\n",
+		"Zombies: 14 (12 from php-fpm, 2 from PID 900)\n",
+		"Open Files: 95000 of 100000\n",
+		"  nginx (PID 4242): 1020 of 1024 open files\n    Service: nginx.service\n",
+	}
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range []string{"RECOMMENDATIONS", "CRITICAL nginx.service", "Top by Open Files:", "12 from php-fpm"} {
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, `<tr><td><span class="fail">CRITICAL</span></td><td>nginx.service (nginx, PID 4242)`) {
+		t.Error("HTML output missing recommendations")
+	}
+
+	info.Recommendations = nil
+	if strings.Contains(FormatText(info), "RECOMMENDATIONS") {
+		t.Error("Text output should not contain recommendations section without recommendations")
+	}
+}
+
+func TestBluetoothFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Bluetooth = &types.BluetoothData{
+		Adapters: []types.BluetoothAdapter{
+			{Name: "hci0", Address: "00:1A:7D:DA:71:13", Manufacturer: "Intel Corp.", Version: "5.2", Firmware: "0x1e0b", Driver: "btusb", Bus: "usb", Powered: true},
+		},
+		Devices: []types.BluetoothDevice{
+			{Name: "Headphones", Address: "38:18:4C:1A:2B:3C", Type: "audio-headset", Connected: true, BatteryPercent: 70},
+			{Name: "MX Master 3", Address: "C8:2A:DD:00:11:22"},
+		},
+	}
+
+	expected := []string{
+		"BLUETOOTH\n",
+		"hci0 00:1A:7D:DA:71:13 (Intel Corp., Bluetooth 5.2, firmware 0x1e0b, driver btusb, usb, powered)\n",
+		"  Headphones 38:18:4C:1A:2B:3C (audio-headset, connected, battery 70%)\n",
+		"  MX Master 3 C8:2A:DD:00:11:22 (not connected)\n",
+	}
+	textOutput := FormatText(info)
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing %q", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "BLUETOOTH") || !strings.Contains(prettyOutput, "38:18:4C:1A:2B:3C (audio-headset, connected, battery 70%)") {
+		t.Error("Pretty output missing Bluetooth devices")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td>0x1e0b</td>") || !strings.Contains(htmlOutput, "<td>70%</td>") {
+		t.Error("HTML output missing Bluetooth adapters and devices")
+	}
+
+	info.Bluetooth = nil
+	if strings.Contains(FormatText(info), "BLUETOOTH") {
+		t.Error("Text output should not contain Bluetooth section when Bluetooth is nil")
+	}
+}
+
+func TestIntegrityFormatting(t *testing.T) {
+	const sudo = "2b4a8f1e6c3d9a7b5e0f1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f"
+	info := createTestSystemInfo()
+	info.Integrity = &types.IntegrityData{Files: []types.FileChecksum{
+		{Path: "/usr/bin/sudo", SHA256: sudo, Size: 278528, Mode: "urwxr-xr-x"},
+		{Path: "/etc/ld.so.preload", Error: "not found"},
+	}}
+
+	textOutput := FormatText(info)
+	for _, value := range []string{"FILE INTEGRITY\n", sudo + "  /usr/bin/sudo\n", "/etc/ld.so.preload: not found\n"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing %q", value)
+		}
+	}
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "FILE INTEGRITY") || !strings.Contains(prettyOutput, "2b4a8f1e6c3d9a7b     /usr/bin/sudo (urwxr-xr-x, 272.00 KB)") {
+		t.Error("Pretty output missing file checksums")
+	}
+	htmlOutput, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(htmlOutput, "<td><code>"+sudo+"</code></td>") || !strings.Contains(htmlOutput, "<td>not found</td>") {
+		t.Error("HTML output missing file checksums")
+	}
+
+	info.Integrity = nil
+	if strings.Contains(FormatText(info), "FILE INTEGRITY") {
+		t.Error("Text output should not contain integrity section when Integrity is nil")
+	}
+}
+
+func TestThermalFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Thermal = &types.ThermalData{
+		CoolingPolicyAC: "active",
+		CoolingPolicyDC: "passive",
+		Sensors: []types.ThermalSensor{
+			{Name: "thermal_zone1", Component: "CPU", Source: "sysfs", Type: "x86_pkg_temp", Temperature: 52, Policy: "step_wise",
+				TripPoints: []types.TripPoint{{Type: "passive", Temperature: 100}, {Type: "critical", Temperature: 105}}},
+			{Name: "/dev/sda", Component: "Disk", Source: "smart", Temperature: 38,
+				TripPoints: []types.TripPoint{{Type: "high", Temperature: 60}, {Type: "critical", Temperature: 70}}},
+		},
+	}
+
+	expected := []string{
+		"THERMAL",
+		"Cooling Policy: active (AC), passive (battery)",
+		"thermal_zone1 [CPU, x86_pkg_temp]: 52.0°C (passive 100°C, critical 105°C), governor step_wise",
+		"/dev/sda [Disk]: 38.0°C (high 60°C, critical 70°C)",
+	}
+	textOutput := FormatText(info)
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing thermal value: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "THERMAL") || !strings.Contains(prettyOutput, "52.0°C (passive 100°C, critical 105°C)") {
+		t.Error("Pretty output missing thermal section")
+	}
+
+	// Fans and the noise estimate with its trend
+	info.Thermal.Fans = []types.FanInfo{{Name: "CPU Fan", Chip: "nct6798", RPM: 1200}}
+	info.Thermal.Noise = &types.NoiseEstimate{
+		TotalDBA:      20.2,
+		Fans:          []types.FanNoise{{Name: "CPU Fan", RPM: 1200, DBA: 20.2, Model: "generic 120 mm fan"}},
+		Trend:         "increasing",
+		TrendDBPerDay: 0.08,
+	}
+	textOutput = FormatText(info)
+	for _, value := range []string{"COOLING", "Fan CPU Fan: 1200 RPM, ~20.2 dB(A) (generic 120 mm fan)", "Estimated Noise: ~20.2 dB(A), increasing (+0.08 dB/day)"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing noise value: %s", value)
+		}
+	}
+
+	// Fan limits, duty cycle and alarm
+	info.Thermal.Noise = nil
+	info.Thermal.Fans = []types.FanInfo{
+		{Name: "CPU Fan", Chip: "nct6798", RPM: 1180, MinRPM: 300, MaxRPM: 2000, PWM: 45},
+		{Name: "nct6798/fan2", Chip: "nct6798", RPM: 0, MinRPM: 200, Alarm: true},
+	}
+	textOutput = FormatText(info)
+	for _, value := range []string{"Fan CPU Fan: 1180 RPM at 45% (min 300, max 2000 RPM)", "Fan nct6798/fan2: 0 RPM (min 200 RPM), ALARM"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing fan value: %s", value)
+		}
+	}
+	if prettyOutput := stripAnsiCodes(FormatPretty(info)); !strings.Contains(prettyOutput, "COOLING") || !strings.Contains(prettyOutput, "0 RPM (min 200 RPM), ALARM") {
+		t.Error("Pretty output missing cooling section")
+	}
+
+	// Only fans: no empty thermal section
+	info.Thermal.Sensors = nil
+	if textOutput = FormatText(info); strings.Contains(textOutput, "THERMAL") || !strings.Contains(textOutput, "COOLING") {
+		t.Error("Text output should show the cooling section alone when there are no thermal zones")
+	}
+}
+
+func TestFanStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		fan  types.FanInfo
+		want int
+	}{
+		{"within limits", types.FanInfo{RPM: 1180, MinRPM: 300, MaxRPM: 2000}, fanNormal},
+		{"no limits", types.FanInfo{RPM: 5000}, fanNormal},
+		{"stopped at idle", types.FanInfo{RPM: 0, MinRPM: 1200, MaxRPM: 4900}, fanNormal},
+		{"alarm", types.FanInfo{RPM: 0, MinRPM: 200, Alarm: true}, fanFault},
+		{"below minimum", types.FanInfo{RPM: 150, MinRPM: 300}, fanFault},
+		{"near maximum", types.FanInfo{RPM: 1850, MinRPM: 300, MaxRPM: 2000}, fanHigh},
+	}
+	for _, tt := range tests {
+		if got := fanStatus(tt.fan); got != tt.want {
+			t.Errorf("%s: fanStatus(%+v) = %d, expected %d", tt.name, tt.fan, got, tt.want)
+		}
+	}
+}
+
+func TestSensorsFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Sensors = &types.SensorsData{Temperatures: []types.TemperatureSensor{
+		{Chip: "coretemp", Label: "Package id 0", Source: "hwmon", Temperature: 52, Max: 100, Critical: 100},
+		{Chip: "nvme", Label: "Composite", Source: "hwmon", Temperature: 38.85, Min: -273.15, Max: 81.85, Critical: 84.85},
+		{Chip: "Intel Core i7-12700K", Label: "CPU Package", Source: "librehardwaremonitor", Temperature: 45.1, Min: 38, Max: 71.5},
+	}}
+
+	expected := []string{
+		"SENSORS",
+		"coretemp Package id 0: 52.0°C (max 100°C, crit 100°C)",
+		"nvme Composite: 38.9°C (max 82°C, crit 85°C)",
+		"Intel Core i7-12700K CPU Package: 45.1°C (seen 38.0–71.5°C)",
+	}
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing sensor value: %s", value)
+		}
+	}
+	if !strings.Contains(prettyOutput, "SENSORS") || !strings.Contains(prettyOutput, "52.0°C (max 100°C, crit 100°C)") {
+		t.Error("Pretty output missing sensors section")
+	}
+
+	html, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "<h2>Sensors</h2>") || !strings.Contains(html, "<td>Package id 0</td><td>52.0°C</td>") {
+		t.Error("HTML output missing sensors table")
+	}
+
+	prom := FormatPrometheus(info)
+	if !strings.Contains(prom, `sysinfo_sensor_temperature_celsius{chip="coretemp",label="Package id 0"} 52`) {
+		t.Errorf("Prometheus output missing sensor temperature:\n%s", prom)
+	}
+	influx := FormatInflux(info, "")
+	if !strings.Contains(influx, `sysinfo_sensor,host=test-host,chip=nvme,label=Composite temperature_celsius=38.85,max_celsius=81.85,critical_celsius=84.85`) {
+		t.Errorf("Influx output missing sensor point:\n%s", influx)
+	}
+}
+
+func TestThermalHeadroom(t *testing.T) {
+	sensor := types.ThermalSensor{Temperature: 88, TripPoints: []types.TripPoint{{Type: "critical", Temperature: 105}, {Type: "passive", Temperature: 95}}}
+	if headroom, ok := thermalHeadroom(sensor); !ok || headroom != 7 {
+		t.Errorf("thermalHeadroom() = %v, %v, expected 7, true", headroom, ok)
+	}
+	if _, ok := thermalHeadroom(types.ThermalSensor{Temperature: 40}); ok {
+		t.Error("thermalHeadroom() without trip points should report false")
+	}
+}
+
+func TestHTMLFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	start := time.Date(2025, 10, 5, 12, 0, 0, 0, time.UTC)
+	info.Disk.SMARTData[0].History = &types.SMARTHistory{
+		Since: start,
+		Points: []types.SMARTHistoryPoint{
+			{Timestamp: start, Temperature: 33, PercentUsed: 3},
+			{Timestamp: start.Add(24 * time.Hour), Temperature: 41, PercentUsed: 3},
+			{Timestamp: start.Add(48 * time.Hour), Temperature: 35, PercentUsed: 4},
+		},
+	}
+	info.System.Hostname = "<lab-01>"
+
+	output, err := Format(info, &config.Config{Format: "html"})
+	if err != nil {
+		t.Fatalf("Format(html) failed: %v", err)
+	}
+	for _, value := range []string{"<!DOCTYPE html>", "SMART trends", "<svg", "<polyline points=\"0.0,", "33-41°C, now 35°C", "3% to 4% used", "&lt;lab-01&gt;"} {
+		if !strings.Contains(output, value) {
+			t.Errorf("HTML output missing %q", value)
+		}
+	}
+	if strings.Contains(output, "<lab-01>") {
+		t.Error("HTML output should escape report text")
+	}
+
+	// History is embedded in JSON as-is
+	jsonOutput, err := FormatJSON(info)
+	if err != nil {
+		t.Fatalf("FormatJSON failed: %v", err)
+	}
+	if !strings.Contains(jsonOutput, `"history"`) || !strings.Contains(jsonOutput, `"temperature_celsius": 41`) {
+		t.Error("JSON output missing embedded SMART history")
+	}
+
+	// Tables with usage bars, and the text report tucked away
+	for _, value := range []string{"<h2>Storage</h2>", `<span class="bar"><span style="width: 60.0%"></span></span>60.0%`,
+		`<span class="bar warn"><span style="width: 75.0%"></span></span>`, "<summary>Show everything collected as text</summary>"} {
+		if !strings.Contains(output, value) {
+			t.Errorf("HTML output missing %q", value)
+		}
+	}
+
+	// SMART attributes are collapsed under each drive
+	info.Disk.SMARTData[0].DetailedAttribs = []types.SMARTAttribute{
+		{ID: 5, Name: "Reallocated_Sector_Ct", Value: 100, Worst: 100, Threshold: 10, RawValue: 0, WhenFailed: "-"},
+		{ID: 197, Name: "Current_Pending_Sector", Value: 1, Worst: 1, Threshold: 0, RawString: "8", WhenFailed: "FAILING_NOW"},
+	}
+	output, _ = FormatHTML(info)
+	for _, value := range []string{"<summary>/dev/sda SMART attributes (2)</summary>", "<td>Current_Pending_Sector</td>", "<td>FAILING_NOW</td>"} {
+		if !strings.Contains(output, value) {
+			t.Errorf("HTML output missing %q", value)
+		}
+	}
+
+	// No history, no trend charts
+	info.Disk.SMARTData[0].History = nil
+	output, _ = FormatHTML(info)
+	if strings.Contains(output, "SMART trends") {
+		t.Error("HTML output should not contain trends without history")
+	}
+}
+
+func TestSparklineSVG(t *testing.T) {
+	svg := string(sparklineSVG([]float64{10, 20}))
+	if !strings.Contains(svg, `points="0.0,39.0 240.0,1.0"`) {
+		t.Errorf("sparklineSVG() = %s, expected a rising line across the full width", svg)
+	}
+	// A flat series is drawn at the top instead of dividing by zero
+	if svg := string(sparklineSVG([]float64{5})); !strings.Contains(svg, `points="0.0,1.0"`) {
+		t.Errorf("sparklineSVG() single value = %s", svg)
+	}
+}
+
+func TestProcessDetailsFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Processes.TopByMemory[0].Cmdline = "chrome --type=renderer --token ***"
+	info.Processes.TopByMemory[0].Env = map[string]string{"PATH": "/usr/bin", "LANG": "C"}
+
+	textOutput := FormatText(info)
+	for _, value := range []string{"Command: chrome --type=renderer --token ***", "Env: LANG=C, PATH=/usr/bin"} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing process detail: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "chrome --type=renderer") {
+		t.Error("Pretty output missing process command line")
+	}
+
+	// Without capture nothing extra is printed
+	if strings.Contains(FormatText(createTestSystemInfo()), "Command:") {
+		t.Error("Text output should not contain a command line when none was captured")
+	}
+}
+
+func TestContainerFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	ref := types.ContainerRef{
+		ID:      "3f4e1c0d2b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0",
+		Runtime: "containerd",
+		PodUID:  "1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d",
+	}
+	info.Processes.TopByMemory[0].Container = &ref
+	info.Processes.Containers = []types.ContainerUsage{{ContainerRef: ref, Processes: 3, CPUPercent: 12.5, MemoryMB: 256}}
+
+	textOutput := FormatText(info)
+	for _, value := range []string{
+		"Container: containerd 3f4e1c0d2b5a (pod 1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d)",
+		"Top Containers by CPU:",
+		"12.50% CPU, 256.00 MB, 3 processes",
+	} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing container detail: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(prettyOutput, "Top Containers:") {
+		t.Error("Pretty output missing container summary")
+	}
+}
+
+func TestProcessIOAndGPUFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Processes.TopByDiskIO = []types.ProcessInfo{{PID: 42, Name: "sqlservr", DiskReadBytes: 2048, DiskWriteBytes: 3 * 1024 * 1024}}
+	info.Processes.TopByGPU = []types.ProcessInfo{{PID: 77, Name: "game.exe", GPUPercent: 87}}
+
+	textOutput := FormatText(info)
+	for _, value := range []string{
+		"Top Processes by Disk I/O:",
+		"sqlservr (PID 42): read 2.00 KB, write 3.00 MB",
+		"Top Processes by GPU:",
+		"game.exe (PID 77): 87%",
+	} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range []string{"Top by Disk I/O:", "Top by GPU:"} {
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+}
+
+func TestTimestampFormatOutput(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Timestamp = time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	info.TimestampFormat = "unix"
+	if output := FormatText(info); !strings.Contains(output, "Timestamp: 1709649000") {
+		t.Error("Text output should use the unix timestamp")
+	}
+
+	html, err := FormatHTML(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "Collected 1709649000") {
+		t.Error("HTML output should use the unix timestamp")
+	}
+
+	info.TimestampFormat = "none"
+	if output := FormatText(info); strings.Contains(output, "Timestamp:") {
+		t.Error("Text output should omit the timestamp with format none")
+	}
+	if output := stripAnsiCodes(FormatPretty(info)); strings.Contains(output, "Timestamp:") {
+		t.Error("Pretty output should omit the timestamp with format none")
+	}
+	if html, err = FormatHTML(info); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(html, "Collected") {
+		t.Error("HTML output should omit the timestamp with format none")
+	}
+}
+
+func TestSelectedFieldsOutput(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Fields = types.FieldSet{"system": {"hostname": nil}, "memory": {"used_percent": nil}, "disk": {"partitions": {"mount_point": nil}}}
+
+	output := FormatText(info)
+	for _, expected := range []string{"system.hostname: test-host\n", "memory.used_percent: 50\n", "disk.partitions[0].mount_point: /\n"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Text output missing %q:\n%s", expected, output)
+		}
+	}
+	// Fields that were not selected are left out rather than printed empty
+	for _, unexpected := range []string{"SYSTEM INFORMATION", "Processes:", "Kernel:", "memory.total", "timestamp"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("Text output contains %q:\n%s", unexpected, output)
+		}
+	}
+
+	if pretty := stripAnsiCodes(FormatPretty(info)); !strings.Contains(pretty, "system.hostname: test-host") || strings.Contains(pretty, "Kernel:") {
+		t.Errorf("Pretty output should list only the selected fields:\n%s", pretty)
+	}
+
+	html, err := FormatHTML(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "<th>system.hostname</th><td>test-host</td>") || strings.Contains(html, "<th>Kernel</th>") {
+		t.Error("HTML output should list only the selected fields")
+	}
+}
+
+func TestClockOffsetFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Meta = &types.ReportMeta{ClockOffset: &types.ClockOffset{Server: "pool.ntp.org", OffsetMS: -1520.25, RoundTripMS: 31.5, Stratum: 2}}
+
+	expected := "Clock Offset: -1520.2 ms vs pool.ntp.org (round trip 31.5 ms)"
+	if output := FormatText(info); !strings.Contains(output, expected) {
+		t.Errorf("Text output missing %q", expected)
+	}
+	if output := stripAnsiCodes(FormatPretty(info)); !strings.Contains(output, expected) {
+		t.Errorf("Pretty output missing %q", expected)
+	}
+}
+
+func TestMemoryAvailableFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	// Page cache makes "used" look high while most of it is reclaimable
+	info.Memory.Used = 14 * 1024 * 1024 * 1024
+	info.Memory.UsedPercent = 87.5
+	info.Memory.Free = 1 * 1024 * 1024 * 1024
+	info.Memory.Available = 12 * 1024 * 1024 * 1024
+	info.Memory.Cached = 10 * 1024 * 1024 * 1024
+	info.Memory.Buffers = 512 * 1024 * 1024
+
+	textOutput := FormatText(info)
+	for _, value := range []string{
+		"Available: 12.00 GB",
+		"Cache/Buffers: 10.50 GB (reclaimable)",
+		"Effectively Used: 4.00 GB (25.00%)",
+	} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+	}
+
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range []string{"Available:", "12.00 GB", "Effectively Used:", "4.00 GB (25.0%)", "reclaimed on demand"} {
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+
+	// Without an Available figure there is nothing to derive
+	info.Memory.Available = 0
+	if strings.Contains(FormatText(info), "Effectively Used:") {
+		t.Error("Text output should omit Effectively Used when Available is unknown")
+	}
+}
+
+func TestBatteryFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Battery = &types.BatteryData{
+		Present:   true,
+		OnBattery: true,
+		Batteries: []types.BatteryInfo{{
+			Name:          "BAT0",
+			Model:         "5B10W13930",
+			State:         "Discharging",
+			ChargeLevel:   64.5,
+			Health:        87.2,
+			CycleCount:    311,
+			TimeRemaining: 135,
+			IsDischarging: true,
+		}},
+		UPSDevices: []types.UPSInfo{{
+			Name:         "ups0",
+			Manufacturer: "APC",
+			Model:        "Back-UPS 900",
+			Status:       "Online",
+			ChargeLevel:  100,
+			Load:         23,
+			Runtime:      42,
+		}},
+	}
+
+	expected := []string{
+		"Power Source:",
+		"BAT0 (5B10W13930)",
+		"Charge Level:",
+		"64.5%",
+		"87.2%",
+		"Cycle Count:",
+		"311",
+		"Time Remaining:",
+		"2 hr 15 min",
+		"UPS: ups0 (APC Back-UPS 900)",
+		"Load:",
+		"23.0%",
+		"Runtime:",
+		"42 min",
+	}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range append([]string{"BATTERY INFORMATION"}, expected...) {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+	}
+	for _, value := range append([]string{"BATTERY"}, expected...) {
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+	if !strings.Contains(prettyOutput, "█") {
+		t.Error("Pretty output should draw charge bars")
+	}
+
+	// A desktop with only a UPS still gets the section, without a power source line
+	info.Battery = &types.BatteryData{UPSDevices: info.Battery.UPSDevices}
+	textOutput = FormatText(info)
+	if !strings.Contains(textOutput, "UPS: ups0") {
+		t.Error("Text output should show a UPS without batteries")
+	}
+	if strings.Contains(textOutput, "Power Source:") {
+		t.Error("Text output should not show a power source without batteries")
+	}
+
+	info.Battery = &types.BatteryData{}
+	if strings.Contains(FormatText(info), "BATTERY INFORMATION") {
+		t.Error("Text output should omit the battery section when nothing is present")
+	}
+}
+
 func TestFormatPretty(t *testing.T) {
 	info := createTestSystemInfo()
 
@@ -421,6 +1721,7 @@ func TestTruncate(t *testing.T) {
 		{"exact length", "hello", 5, "hello"},
 		{"needs truncation", "hello world", 8, "hello..."},
 		{"very long", "this is a very long string", 10, "this is..."},
+		{"multibyte", "データベース同期サービス", 8, "データベー..."},
 	}
 
 	for _, tt := range tests {
@@ -551,6 +1852,78 @@ func TestGPUFormatting(t *testing.T) {
 	}
 }
 
+func TestGPUPartitionFormatting(t *testing.T) {
+	gi, ci := 2, 0
+	info := createTestSystemInfo()
+	info.GPU = &types.GPUData{GPUs: []types.GPUInfo{{
+		Index:   0,
+		Name:    "NVIDIA A100-SXM4-80GB",
+		MIGMode: "Enabled",
+		Partitions: []types.GPUPartition{
+			{Kind: "mig", Index: 0, Profile: "3g.40gb", GPUInstanceID: &gi, ComputeInstanceID: &ci,
+				Multiprocessors: 42, MemoryTotal: 40 * 1024 * 1024 * 1024, MemoryUsed: 1024 * 1024 * 1024},
+			{Kind: "vgpu", Index: 0, Profile: "NVIDIA A40-12Q", VMName: "render-01", MemoryUsed: 2 * 1024 * 1024 * 1024, Utilization: 35},
+		},
+	}}}
+
+	expected := []string{
+		"MIG Mode:",
+		"Partitions:",
+		"MIG 0: 3g.40gb (42 SMs, 1.00 GB / 40.00 GB, GI 2, CI 0)",
+		"vGPU 0: NVIDIA A40-12Q on render-01 (2.00 GB used, 35% util)",
+	}
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range expected {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+}
+
+func TestGPUDriverIssueFormatting(t *testing.T) {
+	info := createTestSystemInfo()
+	info.GPU = &types.GPUData{
+		GPUs: []types.GPUInfo{{
+			Index:               0,
+			Name:                "NVIDIA GeForce RTX 3090",
+			Vendor:              "NVIDIA",
+			KernelModuleVersion: "535.104.05",
+			DriverError:         "Failed to initialize NVML: Driver/library version mismatch",
+		}},
+		DriverIssues: []types.GPUDriverIssue{{
+			GPUIndex:       0,
+			Severity:       "CRITICAL",
+			Code:           "DRIVER_LIBRARY_MISMATCH",
+			Description:    "Userspace driver libraries do not match the loaded kernel module",
+			Recommendation: "Reboot to load the kernel module that matches the installed driver",
+		}},
+	}
+
+	textOutput := FormatText(info)
+	prettyOutput := stripAnsiCodes(FormatPretty(info))
+	for _, value := range []string{
+		"Kernel Module:",
+		"535.104.05",
+		"Driver Issues:",
+		"[CRITICAL] GPU 0: Userspace driver libraries do not match the loaded kernel module",
+		"Reboot to load the kernel module that matches the installed driver",
+	} {
+		if !strings.Contains(textOutput, value) {
+			t.Errorf("Text output missing: %s", value)
+		}
+		if !strings.Contains(prettyOutput, value) {
+			t.Errorf("Pretty output missing: %s", value)
+		}
+	}
+	if !strings.Contains(textOutput, "Driver Error: Failed to initialize NVML: Driver/library version mismatch") {
+		t.Error("Text output missing the driver error")
+	}
+}
+
 func TestGPUFormattingMultipleGPUs(t *testing.T) {
 	info := createTestSystemInfo()
 
@@ -680,3 +2053,35 @@ func stripAnsiCodes(s string) string {
 	}
 	return result
 }
+
+func TestProcessNameWidth(t *testing.T) {
+	defer SetProcessNameWidth(0)
+	info := createTestSystemInfo()
+	info.Processes = &types.ProcessData{TopByCPU: []types.ProcessInfo{
+		{PID: 42, Name: "kube-controller-manager-with-a-long-suffix", Exe: "/usr/local/bin/kube-controller-manager-with-a-long-suffix", CPUPercent: 12.5},
+		{PID: 43, Name: "データベース同期サービスのワーカープロセス名前", CPUPercent: 3},
+	}}
+
+	output := stripAnsiCodes(FormatPretty(info))
+	if !strings.Contains(output, "kube-controller-manager-wit... ") || !strings.Contains(output, "データベース同期サービスのワーカープロセス名前") {
+		t.Errorf("Pretty output should cut names at 30 characters:\n%s", output)
+	}
+
+	SetProcessNameWidth(48)
+	if output := stripAnsiCodes(FormatPretty(info)); !strings.Contains(output, "kube-controller-manager-with-a-long-suffix ") {
+		t.Errorf("Pretty output should show whole names up to 48 characters:\n%s", output)
+	}
+
+	if text := FormatText(info); !strings.Contains(text, "Path: /usr/local/bin/kube-controller-manager-with-a-long-suffix") {
+		t.Error("Text output missing the executable path")
+	}
+
+	if err := ValidateProcessNameWidth(4); err == nil {
+		t.Error("ValidateProcessNameWidth(4) should fail")
+	}
+	for _, width := range []int{0, 8, 120} {
+		if err := ValidateProcessNameWidth(width); err != nil {
+			t.Errorf("ValidateProcessNameWidth(%d) = %v", width, err)
+		}
+	}
+}
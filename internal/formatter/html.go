@@ -0,0 +1,445 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// Sparkline dimensions in pixels
+const (
+	sparklineWidth  = 240
+	sparklineHeight = 40
+)
+
+// htmlTemplate is a self-contained page meant for sharing: styled tables with usage
+// bars, SMART trend charts, collapsible SMART attributes and the full text report
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"bar":          progressBarHTML,
+	"bytes":        formatBytes,
+	"join":         strings.Join,
+	"topology":     cpuTopologyString,
+	"sensorLimits": sensorLimitsString,
+	"package":      cpuPackageString,
+	"coreClass":    coreClassName,
+	"cpuList":      cpuListString,
+	"boardItems":   boardItems,
+	"usbLocation":  usbLocationString,
+	"usbSpeed":     usbSpeedString,
+	"displayName":  displayString,
+	"displayMode":  displayModeString,
+	"audioName":    audioDeviceString,
+	"fileDetail":   checksumDetailString,
+	"wifi":         wifiString,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>SysInfo report{{if .Host}} - {{.Host}}{{end}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 72em; padding: 0 1em; color: #222; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.2em; margin-top: 1.6em; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; }
+table { border-collapse: collapse; margin-bottom: 0.8em; }
+th, td { text-align: left; padding: 0.3em 1em 0.3em 0; vertical-align: middle; }
+th { color: #57606a; font-weight: 600; }
+tr + tr td { border-top: 1px solid #eaeef2; }
+.bar { display: inline-block; width: 10em; height: 0.8em; background: #eaeef2; border-radius: 0.4em; overflow: hidden; vertical-align: middle; margin-right: 0.5em; }
+.bar span { display: block; height: 100%; background: #2da44e; }
+.bar.warn span { background: #d4a72c; }
+.bar.crit span { background: #cf222e; }
+.ok { color: #1a7f37; font-weight: 600; }
+.fail { color: #cf222e; font-weight: 600; }
+details { margin: 0.3em 0 0.8em; }
+summary { cursor: pointer; color: #0969da; }
+svg polyline { fill: none; stroke: #0969da; stroke-width: 1.5; }
+.muted { color: #666; }
+</style>
+</head>
+<body>
+<h1>SysInfo report{{if .Host}} - {{.Host}}{{end}}</h1>
+{{with .Timestamp}}<p class="muted">Collected {{.}}</p>{{end}}
+{{if .Fields}}
+<h2>Selected fields</h2>
+<table>
+{{range .Fields}}<tr><th>{{.Path}}</th><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{else}}
+{{with .Info.Recommendations}}
+<h2>Recommendations</h2>
+<table>
+{{range .}}<tr><td>{{if eq .Severity "CRITICAL"}}<span class="fail">{{.Severity}}</span>{{else}}{{.Severity}}{{end}}</td><td>{{.Action}}</td></tr>
+{{end}}</table>
+{{end}}
+{{with .Info.System}}
+<h2>System</h2>
+<table>
+<tr><th>Hostname</th><td>{{.Hostname}}</td></tr>
+<tr><th>Operating system</th><td>{{.Platform}} {{.PlatformVersion}} ({{.OS}})</td></tr>
+<tr><th>Kernel</th><td>{{.KernelVersion}} {{.KernelArch}}</td></tr>
+<tr><th>Uptime</th><td>{{.UptimeFormatted}}</td></tr>
+</table>
+{{end}}
+{{with .Info.Baseboard}}{{with boardItems .}}
+<h2>Baseboard</h2>
+<table>
+{{range .}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.CPU}}
+<h2>CPU</h2>
+<table>
+<tr><th>Model</th><td>{{.ModelName}}</td></tr>
+<tr><th>Cores</th><td>{{.Cores}} physical, {{.LogicalCPUs}} logical</td></tr>
+{{if .Sockets}}<tr><th>Topology</th><td>{{topology .}}</td></tr>{{end}}
+{{if gt (len .Packages) 1}}{{range .Packages}}<tr><th>Socket {{.Socket}}</th><td>{{package .}}</td></tr>{{end}}{{end}}
+{{if $.CPUUsage}}<tr><th>Usage</th><td>{{bar $.CPUUsage}}</td></tr>{{end}}
+{{range .CoreClasses}}<tr><th>{{coreClass .Class}}</th><td>{{bar .Usage}} CPUs {{cpuList .CPUs}}</td></tr>{{end}}
+{{with .LoadAvg}}<tr><th>Load average</th><td>{{printf "%.2f" .Load1}} / {{printf "%.2f" .Load5}} / {{printf "%.2f" .Load15}}</td></tr>{{end}}
+</table>
+{{end}}
+{{with .Info.Memory}}
+<h2>Memory</h2>
+<table>
+<tr><th>Memory</th><td>{{bar .UsedPercent}}</td><td>{{bytes .Used}} of {{bytes .Total}}</td></tr>
+{{if .SwapTotal}}<tr><th>Swap</th><td>{{bar .SwapPercent}}</td><td>{{bytes .SwapUsed}} of {{bytes .SwapTotal}}</td></tr>{{end}}
+</table>
+{{end}}
+{{with .Info.Disk}}
+<h2>Storage</h2>
+{{if .Partitions}}<table>
+<tr><th>Mount point</th><th>Device</th><th>Type</th><th>Used</th><th>Size</th></tr>
+{{range .Partitions}}<tr><td>{{.MountPoint}}</td><td>{{.Device}}</td><td>{{.FSType}}</td><td>{{bar .UsedPercent}}</td><td>{{bytes .Used}} of {{bytes .Total}}</td></tr>
+{{end}}</table>{{end}}
+{{if .PhysicalDisks}}<table>
+<tr><th>Disk</th><th>Model</th><th>Type</th><th>Size</th><th>Serial</th><th>Slot</th></tr>
+{{range .PhysicalDisks}}<tr><td>{{.Name}}</td><td>{{.Model}}</td><td>{{.Type}}{{if .Interface}} ({{.Interface}}){{end}}</td><td>{{bytes .Size}}</td><td>{{.SerialNumber}}</td><td>{{with .Slot}}{{.String}}{{end}}</td></tr>
+{{end}}</table>{{end}}
+{{if .SMARTData}}
+<h2>SMART health</h2>
+<table>
+<tr><th>Device</th><th>Model</th><th>Serial</th><th>Health</th><th>Temperature</th><th>Power-on hours</th></tr>
+{{range .SMARTData}}<tr><td>{{.Device}}</td><td>{{.DeviceModel}}</td><td>{{.Serial}}</td><td>{{if .Healthy}}<span class="ok">Healthy</span>{{else}}<span class="fail">Failing</span>{{end}}</td><td>{{if .Temperature}}{{.Temperature}}°C{{end}}</td><td>{{if .PowerOnHours}}{{.PowerOnHours}}{{end}}</td></tr>
+{{end}}</table>
+{{range .SMARTData}}{{if .DetailedAttribs}}
+<details>
+<summary>{{.Device}} SMART attributes ({{len .DetailedAttribs}})</summary>
+<table>
+<tr><th>ID</th><th>Attribute</th><th>Value</th><th>Worst</th><th>Threshold</th><th>Raw</th><th>Failed</th></tr>
+{{range .DetailedAttribs}}<tr><td>{{.ID}}</td><td>{{.Name}}</td><td>{{.Value}}</td><td>{{.Worst}}</td><td>{{.Threshold}}</td><td>{{if .RawString}}{{.RawString}}{{else}}{{.RawValue}}{{end}}</td><td>{{if ne .WhenFailed "-"}}{{.WhenFailed}}{{end}}</td></tr>
+{{end}}</table>
+</details>
+{{else if .Attributes}}
+<details>
+<summary>{{.Device}} SMART attributes ({{len .Attributes}})</summary>
+<table>
+<tr><th>Attribute</th><th>Value</th></tr>
+{{range $name, $value := .Attributes}}<tr><td>{{$name}}</td><td>{{$value}}</td></tr>
+{{end}}</table>
+</details>
+{{end}}{{end}}
+{{end}}
+{{end}}
+{{if .Trends}}
+<h2>SMART trends</h2>
+<table>
+<tr><th>Device</th><th>Temperature</th><th>Wear</th></tr>
+{{range .Trends}}<tr>
+<td>{{.Device}}<br><span class="muted">{{.Records}} readings since {{.Since}}</span></td>
+<td>{{if .Temperature}}{{.Temperature}}<br><span class="muted">{{.TemperatureRange}}</span>{{end}}</td>
+<td>{{if .Wear}}{{.Wear}}<br><span class="muted">{{.WearRange}}</span>{{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+{{with .Info.Network}}{{if .Interfaces}}
+<h2>Network</h2>
+<table>
+<tr><th>Interface</th><th>Addresses</th><th>MAC</th><th>Sent</th><th>Received</th><th>Errors</th></tr>
+{{range .Interfaces}}<tr><td>{{.Name}}{{with .WiFi}}<br><span class="muted">{{wifi .}}</span>{{end}}</td><td>{{join .Addresses ", "}}</td><td>{{.HardwareAddr}}</td><td>{{bytes .BytesSent}}</td><td>{{bytes .BytesRecv}}</td><td>{{.ErrorsIn}} in, {{.ErrorsOut}} out</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.GPU}}{{if .GPUs}}
+<h2>GPU</h2>
+<table>
+<tr><th>GPU</th><th>Utilization</th><th>Memory</th><th>Temperature</th><th>Driver</th></tr>
+{{range .GPUs}}<tr><td>{{.Name}}</td><td>{{bar .Utilization}}</td><td>{{if .MemoryTotal}}{{bytes .MemoryUsed}} of {{bytes .MemoryTotal}}{{end}}</td><td>{{if .Temperature}}{{.Temperature}}°C{{end}}</td><td>{{.DriverVersion}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.PCI}}{{if .Devices}}
+<h2>PCI devices</h2>
+<table>
+<tr><th>Address</th><th>Class</th><th>Device</th><th>IDs</th><th>Driver</th></tr>
+{{range .Devices}}<tr><td>{{.Address}}</td><td>{{.ClassName}}</td><td>{{.Vendor}} {{.Name}}</td><td>{{.VendorID}}:{{.DeviceID}}</td><td>{{.Driver}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.USB}}{{if .Devices}}
+<h2>USB devices</h2>
+<table>
+<tr><th>Location</th><th>Class</th><th>Device</th><th>IDs</th><th>Speed</th><th>Serial</th><th>Drivers</th></tr>
+{{range .Devices}}<tr><td>{{usbLocation .}}</td><td>{{.ClassName}}</td><td>{{.Vendor}} {{.Product}}</td><td>{{.VendorID}}:{{.ProductID}}</td><td>{{if .SpeedMbps}}{{usbSpeed .SpeedMbps}}{{end}}</td><td>{{.Serial}}</td><td>{{join .Drivers ", "}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Displays}}{{if .Displays}}
+<h2>Displays</h2>
+<table>
+<tr><th>Connector</th><th>Display</th><th>Mode</th><th>Size</th><th>Year</th><th>Serial</th></tr>
+{{range .Displays}}<tr><td>{{.Name}}{{if .Primary}} (primary){{end}}</td><td>{{displayName .}}</td><td>{{displayMode .}}</td><td>{{if .DiagonalInches}}{{.DiagonalInches}}"{{end}}</td><td>{{if .Year}}{{.Year}}{{end}}</td><td>{{.Serial}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Audio}}{{if .Devices}}
+<h2>Audio devices</h2>
+<table>
+<tr><th>Device</th><th>Codecs</th><th>Driver</th><th>Bus</th><th>Outputs</th><th>Inputs</th></tr>
+{{range .Devices}}<tr><td>{{audioName .}}{{if .Default}} (default){{end}}</td><td>{{join .Codecs ", "}}</td><td>{{.Driver}}</td><td>{{.Bus}}</td><td>{{join .Playback ", "}}</td><td>{{join .Capture ", "}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Bluetooth}}{{if .Adapters}}
+<h2>Bluetooth</h2>
+<table>
+<tr><th>Adapter</th><th>Address</th><th>Manufacturer</th><th>Version</th><th>Firmware</th><th>Driver</th><th>Powered</th></tr>
+{{range .Adapters}}<tr><td>{{.Name}}</td><td>{{.Address}}</td><td>{{.Manufacturer}}</td><td>{{.Version}}</td><td>{{.Firmware}}</td><td>{{.Driver}}</td><td>{{if .Powered}}yes{{else}}no{{end}}</td></tr>
+{{end}}</table>
+{{if .Devices}}<table>
+<tr><th>Paired device</th><th>Address</th><th>Type</th><th>Connected</th><th>Battery</th></tr>
+{{range .Devices}}<tr><td>{{.Name}}</td><td>{{.Address}}</td><td>{{.Type}}</td><td>{{if .Connected}}yes{{else}}no{{end}}</td><td>{{if .BatteryPercent}}{{.BatteryPercent}}%{{end}}</td></tr>
+{{end}}</table>{{end}}
+{{end}}{{end}}
+{{with .Info.Drivers}}{{if .Drivers}}
+<h2>Kernel drivers</h2>
+{{if .Tainted}}<p>Kernel tainted: {{join .Tainted ", "}}</p>
+{{end}}<table>
+<tr><th>Name</th><th>Version</th><th>Description</th><th>Size</th><th>Used by</th><th>Taint</th><th>Path</th></tr>
+{{range .Drivers}}<tr><td>{{.Name}}</td><td>{{.Version}}</td><td>{{.Description}}</td><td>{{if .SizeBytes}}{{bytes .SizeBytes}}{{end}}</td><td>{{join .UsedBy ", "}}</td><td>{{.Taint}}</td><td>{{.Path}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Services}}
+<h2>Services</h2>
+<p>{{.Manager}}: {{.Total}} total, {{.Running}} running, {{.Failed}} failed</p>
+{{if .FailedServices}}<table>
+<tr><th>Failed service</th><th>Description</th><th>State</th><th>Reason</th></tr>
+{{range .FailedServices}}<tr><td>{{.Name}}</td><td>{{.Description}}</td><td>{{.State}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Firewall}}
+<h2>Firewall</h2>
+<p>{{if .Active}}<span class="ok">Active</span>{{else}}<span class="fail">Inactive</span>{{end}}</p>
+{{if .Firewalls}}<table>
+<tr><th>Firewall</th><th>Zone</th><th>State</th><th>Interfaces</th><th>Rules</th><th>Incoming</th></tr>
+{{range .Firewalls}}<tr><td>{{.Name}}</td><td></td><td>{{if .Active}}active{{else}}inactive{{end}}</td><td></td><td>{{with .Rules}}{{.}}{{end}}</td><td>{{.DefaultIncoming}}</td></tr>
+{{range .Zones}}<tr><td></td><td>{{.Name}}</td><td>{{if .Active}}active{{else}}off{{end}}</td><td>{{join .Interfaces " "}}</td><td>{{.Rules}}</td><td>{{.DefaultIncoming}}</td></tr>
+{{end}}{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Users}}
+<h2>Users</h2>
+<p>Local accounts: {{.LocalAccounts}} ({{.UserAccounts}} for people)</p>
+{{if .Sessions}}<table>
+<tr><th>User</th><th>Terminal</th><th>From</th><th>Login time</th><th>State</th></tr>
+{{range .Sessions}}<tr><td>{{.User}}</td><td>{{.Terminal}}</td><td>{{.RemoteHost}}</td><td>{{if not .LoginTime.IsZero}}{{.LoginTime.Format "2006-01-02 15:04"}}{{end}}</td><td>{{.State}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Packages}}{{if .Packages}}
+<h2>Installed packages</h2>
+<table>
+<tr><th>Name</th><th>Version</th><th>Arch</th><th>Manager</th><th>Publisher</th></tr>
+{{range .Packages}}<tr><td>{{.Name}}</td><td>{{.Version}}</td><td>{{.Arch}}</td><td>{{.Manager}}</td><td>{{.Publisher}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Integrity}}{{if .Files}}
+<h2>File integrity</h2>
+<table>
+<tr><th>File</th><th>SHA-256</th><th>Permissions and size</th></tr>
+{{range .Files}}<tr><td>{{.Path}}</td><td>{{if .SHA256}}<code>{{.SHA256}}</code>{{else}}{{.Error}}{{end}}</td><td>{{fileDetail .}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Sensors}}{{if .Temperatures}}
+<h2>Sensors</h2>
+<table>
+<tr><th>Chip</th><th>Sensor</th><th>Temperature</th><th>Limits</th></tr>
+{{range .Temperatures}}<tr><td>{{.Chip}}</td><td>{{.Label}}</td><td>{{printf "%.1f" .Temperature}}°C</td><td>{{sensorLimits .}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Battery}}{{if .Batteries}}
+<h2>Battery</h2>
+<table>
+<tr><th>Battery</th><th>Charge</th><th>State</th><th>Health</th></tr>
+{{range .Batteries}}<tr><td>{{.Name}}</td><td>{{bar .ChargeLevel}}</td><td>{{.State}}</td><td>{{if .Health}}{{printf "%.0f" .Health}}%{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+{{with .Info.Processes}}{{if .TopByCPU}}
+<h2>Top processes</h2>
+<table>
+<tr><th>PID</th><th>Name</th><th>User</th><th>CPU</th><th>Memory</th></tr>
+{{range .TopByCPU}}<tr><td>{{.PID}}</td><td{{with .Exe}} title="{{.}}"{{end}}>{{.Name}}</td><td>{{.Username}}</td><td>{{printf "%.1f" .CPUPercent}}%</td><td>{{.MemoryMB}} MB</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+<h2>Full report</h2>
+<details>
+<summary>Show everything collected as text</summary>
+<pre>{{.Text}}</pre>
+</details>
+{{end}}
+</body>
+</html>
+`))
+
+// htmlTrend is one device's row of trend charts
+type htmlTrend struct {
+	Device           string
+	Since            string
+	Records          int
+	Temperature      template.HTML
+	TemperatureRange string
+	Wear             template.HTML
+	WearRange        string
+}
+
+// FormatHTML formats the information as a standalone HTML page
+func FormatHTML(info *types.SystemInfo) (string, error) {
+	data := struct {
+		Info      *types.SystemInfo
+		Host      string
+		Timestamp string
+		CPUUsage  float64
+		Trends    []htmlTrend
+		Fields    []selectedField
+		Text      string
+	}{
+		Info:      info,
+		Timestamp: utils.FormatTimestamp(info.Timestamp, info.TimestampFormat, "2006-01-02 15:04:05 MST"),
+		Text:      FormatText(info),
+	}
+	if info.System != nil {
+		data.Host = info.System.Hostname
+	}
+	if len(info.Fields) > 0 {
+		// The sections would show every field that was not selected as empty
+		fields, err := selectedFields(info)
+		if err != nil {
+			return "", err
+		}
+		data.Fields = fields
+	}
+	if info.CPU != nil && len(info.CPU.Usage) > 0 {
+		for _, percent := range info.CPU.Usage {
+			data.CPUUsage += percent
+		}
+		data.CPUUsage /= float64(len(info.CPU.Usage))
+	}
+	if info.Disk != nil {
+		for _, smart := range info.Disk.SMARTData {
+			if trend, ok := smartTrend(smart); ok {
+				data.Trends = append(data.Trends, trend)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// progressBarHTML draws a percentage as a bar, amber above 70% and red above 90% like the pretty format
+// Accepts the integer percentages GPUs report as well as floats
+func progressBarHTML(value interface{}) template.HTML {
+	var percent float64
+	switch v := value.(type) {
+	case float64:
+		percent = v
+	case int:
+		percent = float64(v)
+	}
+
+	class := "bar"
+	if percent > 90 {
+		class += " crit"
+	} else if percent > 70 {
+		class += " warn"
+	}
+	width := percent
+	if width < 0 {
+		width = 0
+	} else if width > 100 {
+		width = 100
+	}
+
+	// Only numbers are interpolated, so the markup is safe to mark as HTML
+	return template.HTML(fmt.Sprintf(`<span class="%s"><span style="width: %.1f%%"></span></span>%.1f%%`, class, width, percent))
+}
+
+// smartTrend charts a device's embedded history, skipping series that were never recorded
+func smartTrend(smart types.SMARTInfo) (htmlTrend, bool) {
+	if smart.History == nil || len(smart.History.Points) == 0 {
+		return htmlTrend{}, false
+	}
+
+	var temps, wear []float64
+	for _, point := range smart.History.Points {
+		if point.Temperature > 0 {
+			temps = append(temps, float64(point.Temperature))
+		}
+		if point.PercentUsed > 0 {
+			wear = append(wear, point.PercentUsed)
+		}
+	}
+
+	trend := htmlTrend{
+		Device:  smart.Device,
+		Since:   smart.History.Since.Format("2006-01-02"),
+		Records: len(smart.History.Points),
+	}
+	if len(temps) > 0 {
+		low, high := seriesRange(temps)
+		trend.Temperature = sparklineSVG(temps)
+		trend.TemperatureRange = fmt.Sprintf("%.0f-%.0f°C, now %.0f°C", low, high, temps[len(temps)-1])
+	}
+	if len(wear) > 0 {
+		trend.Wear = sparklineSVG(wear)
+		trend.WearRange = fmt.Sprintf("%.0f%% to %.0f%% used", wear[0], wear[len(wear)-1])
+	}
+	return trend, trend.Temperature != "" || trend.Wear != ""
+}
+
+// sparklineSVG draws values as an inline SVG polyline scaled to the value range
+func sparklineSVG(values []float64) template.HTML {
+	low, high := seriesRange(values)
+	span := high - low
+	if span == 0 {
+		span = 1
+	}
+
+	points := make([]string, 0, len(values))
+	for i, value := range values {
+		x := 0.0
+		if len(values) > 1 {
+			x = float64(i) * sparklineWidth / float64(len(values)-1)
+		}
+		// SVG y grows downwards; keep a pixel of margin for the stroke
+		y := 1 + (high-value)/span*(sparklineHeight-2)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+
+	// Only numbers are interpolated, so the markup is safe to mark as HTML
+	return template.HTML(fmt.Sprintf(`<svg width="%d" height="%d" viewBox="0 0 %d %d" role="img"><polyline points="%s"/></svg>`,
+		sparklineWidth, sparklineHeight, sparklineWidth, sparklineHeight, strings.Join(points, " ")))
+}
+
+// seriesRange returns the lowest and highest value
+func seriesRange(values []float64) (float64, float64) {
+	low, high := values[0], values[0]
+	for _, value := range values[1:] {
+		if value < low {
+			low = value
+		}
+		if value > high {
+			high = value
+		}
+	}
+	return low, high
+}
@@ -0,0 +1,192 @@
+package formatter
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// DefaultInfluxPrefix keeps sysinfo measurements apart from Telegraf's own cpu, mem and disk
+const DefaultInfluxPrefix = "sysinfo_"
+
+// reportHostname names the host a report's points are tagged with: the collected hostname, or
+// this machine's when the system module was not collected. A redacted report must not carry
+// the real hostname, so it gets none
+func reportHostname(info *types.SystemInfo) string {
+	if info.System != nil && info.System.Hostname != "" {
+		return info.System.Hostname
+	}
+	if info.Redacted {
+		return ""
+	}
+	host, _ := os.Hostname()
+	return host
+}
+
+// influxPoint is one line of line protocol
+type influxPoint struct {
+	measurement string
+	tags        []string // Alternating keys and values
+	fields      []influxField
+}
+
+type influxField struct {
+	key   string
+	value string // Already encoded: 12i, 1.5, true or "quoted"
+}
+
+// FormatInflux formats the information as InfluxDB line protocol. Every point is
+// tagged with the host and stamped with the report time in nanoseconds
+func FormatInflux(info *types.SystemInfo, prefix string) string {
+	if prefix == "" {
+		prefix = DefaultInfluxPrefix
+	}
+	host := reportHostname(info)
+
+	var points []influxPoint
+	add := func(measurement string, tags []string, fields ...influxField) {
+		if len(fields) > 0 {
+			points = append(points, influxPoint{measurement: prefix + measurement, tags: tags, fields: fields})
+		}
+	}
+
+	if cpu := info.CPU; cpu != nil {
+		for i, percent := range cpu.Usage {
+			add("cpu", []string{"cpu", strconv.Itoa(i)}, influxFloat("usage_percent", percent))
+		}
+		for _, class := range cpu.CoreClasses {
+			add("cpu_class", []string{"class", class.Class}, influxFloat("usage_percent", class.Usage))
+		}
+		if cpu.LoadAvg != nil {
+			add("load", nil,
+				influxFloat("load1", cpu.LoadAvg.Load1),
+				influxFloat("load5", cpu.LoadAvg.Load5),
+				influxFloat("load15", cpu.LoadAvg.Load15))
+		}
+	}
+
+	if mem := info.Memory; mem != nil {
+		add("mem", nil,
+			influxUint("total_bytes", mem.Total),
+			influxUint("used_bytes", mem.Used),
+			influxUint("available_bytes", mem.Available),
+			influxFloat("used_percent", mem.UsedPercent),
+			influxUint("swap_total_bytes", mem.SwapTotal),
+			influxUint("swap_used_bytes", mem.SwapUsed))
+	}
+
+	if disk := info.Disk; disk != nil {
+		for _, p := range disk.Partitions {
+			add("disk", []string{"device", p.Device, "path", p.MountPoint, "fstype", p.FSType},
+				influxUint("total_bytes", p.Total),
+				influxUint("used_bytes", p.Used),
+				influxUint("free_bytes", p.Free),
+				influxFloat("used_percent", p.UsedPercent))
+		}
+		for _, smart := range disk.SMARTData {
+			fields := []influxField{{key: "healthy", value: strconv.FormatBool(smart.Healthy)}}
+			if smart.Temperature != 0 {
+				fields = append(fields, influxInt("temperature_celsius", int64(smart.Temperature)))
+			}
+			if smart.PowerOnHours != 0 {
+				fields = append(fields, influxUint("power_on_hours", smart.PowerOnHours))
+			}
+			add("smart", []string{"device", smart.Device, "model", smart.DeviceModel, "serial", smart.Serial}, fields...)
+		}
+	}
+
+	if network := info.Network; network != nil {
+		for _, iface := range network.Interfaces {
+			add("net", []string{"interface", iface.Name},
+				influxUint("bytes_sent", iface.BytesSent),
+				influxUint("bytes_recv", iface.BytesRecv),
+				influxUint("packets_sent", iface.PacketsSent),
+				influxUint("packets_recv", iface.PacketsRecv),
+				influxUint("errors_in", iface.ErrorsIn),
+				influxUint("errors_out", iface.ErrorsOut),
+				influxUint("drops_in", iface.DropsIn),
+				influxUint("drops_out", iface.DropsOut))
+		}
+	}
+
+	if gpu := info.GPU; gpu != nil {
+		for _, g := range gpu.GPUs {
+			fields := []influxField{influxInt("utilization_percent", int64(g.Utilization))}
+			if g.MemoryTotal != 0 {
+				fields = append(fields, influxUint("memory_total_bytes", g.MemoryTotal), influxUint("memory_used_bytes", g.MemoryUsed))
+			}
+			if g.Temperature != 0 {
+				fields = append(fields, influxInt("temperature_celsius", int64(g.Temperature)))
+			}
+			if g.PowerDraw != 0 {
+				fields = append(fields, influxFloat("power_draw_watts", g.PowerDraw))
+			}
+			add("gpu", []string{"gpu", strconv.Itoa(g.Index), "name", g.Name}, fields...)
+		}
+	}
+
+	if sensors := info.Sensors; sensors != nil {
+		for _, s := range sensors.Temperatures {
+			fields := []influxField{influxFloat("temperature_celsius", s.Temperature)}
+			if s.Max != 0 {
+				fields = append(fields, influxFloat("max_celsius", s.Max))
+			}
+			if s.Critical != 0 {
+				fields = append(fields, influxFloat("critical_celsius", s.Critical))
+			}
+			add("sensor", []string{"chip", s.Chip, "label", s.Label}, fields...)
+		}
+	}
+
+	timestamp := ""
+	if !info.Timestamp.IsZero() {
+		timestamp = " " + strconv.FormatInt(info.Timestamp.UnixNano(), 10)
+	}
+
+	var sb strings.Builder
+	for _, point := range points {
+		sb.WriteString(influxMeasurementEscaper.Replace(point.measurement))
+		writeInfluxTag(&sb, "host", host)
+		for i := 0; i+1 < len(point.tags); i += 2 {
+			writeInfluxTag(&sb, point.tags[i], point.tags[i+1])
+		}
+		for i, field := range point.fields {
+			if i == 0 {
+				sb.WriteString(" ")
+			} else {
+				sb.WriteString(",")
+			}
+			sb.WriteString(influxTagEscaper.Replace(field.key) + "=" + field.value)
+		}
+		sb.WriteString(timestamp + "\n")
+	}
+	return sb.String()
+}
+
+// writeInfluxTag appends ,key=value; line protocol has no empty tag values, so those are left out
+func writeInfluxTag(sb *strings.Builder, key, value string) {
+	if value == "" {
+		return
+	}
+	sb.WriteString("," + influxTagEscaper.Replace(key) + "=" + influxTagEscaper.Replace(value))
+}
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	influxTagEscaper         = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+)
+
+func influxFloat(key string, v float64) influxField {
+	return influxField{key: key, value: strconv.FormatFloat(v, 'f', -1, 64)}
+}
+
+func influxInt(key string, v int64) influxField {
+	return influxField{key: key, value: strconv.FormatInt(v, 10) + "i"}
+}
+
+// influxUint writes unsigned values as integers, which every InfluxDB version accepts
+func influxUint(key string, v uint64) influxField {
+	return influxField{key: key, value: strconv.FormatUint(v, 10) + "i"}
+}
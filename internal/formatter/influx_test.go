@@ -0,0 +1,46 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatInflux(t *testing.T) {
+	out, err := Format(createTestSystemInfo(), &config.Config{Format: "influx"})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	const ts = " 1762257600000000000\n"
+	for _, want := range []string{
+		"sysinfo_cpu,host=test-host,cpu=1 usage_percent=20.3" + ts,
+		"sysinfo_load,host=test-host load1=1.5,load5=1.2,load15=0.9" + ts,
+		"sysinfo_mem,host=test-host total_bytes=17179869184i,used_bytes=8589934592i,",
+		"sysinfo_disk,host=test-host,device=/dev/sda1,path=/,fstype=ext4 total_bytes=536870912000i,",
+		"sysinfo_smart,host=test-host,device=/dev/sda,model=Samsung\\ SSD\\ 970\\ EVO healthy=true,temperature_celsius=35i,power_on_hours=1000i" + ts,
+		"sysinfo_net,host=test-host,interface=eth0 bytes_sent=104857600i,",
+		"sysinfo_gpu,host=test-host,gpu=0,name=NVIDIA\\ GeForce\\ RTX\\ 4070 utilization_percent=75i,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatInfluxPrefixAndEscaping(t *testing.T) {
+	info := &types.SystemInfo{
+		System: &types.SystemData{Hostname: "web 01"},
+		Disk: &types.DiskData{Partitions: []types.PartitionInfo{
+			{Device: "map,auto", MountPoint: "/mnt/a=b", Total: 10},
+		}},
+	}
+
+	out := FormatInflux(info, "lab_")
+	want := `lab_disk,host=web\ 01,device=map\,auto,path=/mnt/a\=b total_bytes=10i,used_bytes=0i,free_bytes=0i,used_percent=0` + "\n"
+	if out != want {
+		t.Errorf("FormatInflux() = %q\nwant %q", out, want)
+	}
+}
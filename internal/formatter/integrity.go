@@ -0,0 +1,30 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// checksumLine writes a file the way sha256sum does, "<sha256>  <path>", or with the reason
+// it was not hashed, e.g. "/etc/ld.so.preload: not found"
+func checksumLine(f types.FileChecksum) string {
+	if f.SHA256 == "" {
+		return f.Path + ": " + f.Error
+	}
+	return f.SHA256 + "  " + f.Path
+}
+
+// checksumDetailString lists a file's permissions and size, e.g. "urwxr-xr-x, 272.00 KB"
+func checksumDetailString(f types.FileChecksum) string {
+	if f.SHA256 == "" {
+		return ""
+	}
+	var details []string
+	if f.Mode != "" {
+		details = append(details, f.Mode)
+	}
+	details = append(details, utils.FormatBytes(uint64(f.Size)))
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,146 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// FormatMsgpack formats the information as MessagePack, a compact binary encoding of the
+// JSON report for high-frequency collection: the same keys and values, without the text
+// overhead. Each report is one self-delimiting map, so reports can be concatenated into a stream
+func FormatMsgpack(info *types.SystemInfo) (string, error) {
+	// As for XML, the JSON encoding is the marshaling layer and its token stream keeps field order
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	out, err := appendMsgpackValue(nil, decoder)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal MessagePack: %w", err)
+	}
+	return string(out), nil
+}
+
+// appendMsgpackValue appends the next JSON value from decoder in MessagePack encoding
+func appendMsgpackValue(b []byte, decoder *json.Decoder) ([]byte, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		// Container headers carry the element count, so the elements are encoded first
+		var elements []byte
+		count := 0
+		for decoder.More() {
+			if value == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				elements = appendMsgpackString(elements, key.(string))
+			}
+			if elements, err = appendMsgpackValue(elements, decoder); err != nil {
+				return nil, err
+			}
+			count++
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		if value == '{' {
+			b = appendMsgpackHeader(b, count, 0x80, 0xde)
+		} else {
+			b = appendMsgpackHeader(b, count, 0x90, 0xdc)
+		}
+		return append(b, elements...), nil
+	case string:
+		return appendMsgpackString(b, value), nil
+	case json.Number:
+		return appendMsgpackNumber(b, value), nil
+	case bool:
+		if value {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	default:
+		return append(b, 0xc0), nil // nil
+	}
+}
+
+// appendMsgpackHeader appends a map or array header: the fix form for up to 15 elements,
+// else the 16 or 32 bit form (whose type byte follows the 16 bit one)
+func appendMsgpackHeader(b []byte, count int, fix, type16 byte) []byte {
+	switch {
+	case count < 16:
+		return append(b, fix|byte(count))
+	case count <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, type16), uint16(count))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, type16+1), uint32(count))
+	}
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// appendMsgpackNumber encodes integers in the smallest form that holds them, other numbers as float64
+func appendMsgpackNumber(b []byte, number json.Number) []byte {
+	if i, err := strconv.ParseInt(string(number), 10, 64); err == nil {
+		switch {
+		case i >= 0 && i < 128:
+			return append(b, byte(i))
+		case i >= -32 && i < 0:
+			return append(b, byte(int8(i)))
+		case i >= 0:
+			return appendMsgpackUint(b, uint64(i))
+		case i >= math.MinInt8:
+			return append(b, 0xd0, byte(int8(i)))
+		case i >= math.MinInt16:
+			return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(int16(i)))
+		case i >= math.MinInt32:
+			return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(int32(i)))
+		default:
+			return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+		}
+	}
+	if u, err := strconv.ParseUint(string(number), 10, 64); err == nil {
+		return appendMsgpackUint(b, u)
+	}
+	f, _ := strconv.ParseFloat(string(number), 64)
+	return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f))
+}
+
+func appendMsgpackUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= math.MaxUint8:
+		return append(b, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), u)
+	}
+}
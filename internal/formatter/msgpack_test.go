@@ -0,0 +1,164 @@
+package formatter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+// decodeMsgpack is a minimal decoder for the subset FormatMsgpack writes, with numbers as float64 like encoding/json
+func decodeMsgpack(b []byte) (interface{}, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, fmt.Errorf("unexpected end of data")
+	}
+	kind, b := b[0], b[1:]
+	length := func(size int) (int, []byte) {
+		switch size {
+		case 1:
+			return int(b[0]), b[1:]
+		case 2:
+			return int(binary.BigEndian.Uint16(b)), b[2:]
+		default:
+			return int(binary.BigEndian.Uint32(b)), b[4:]
+		}
+	}
+
+	var n int
+	switch {
+	case kind < 0x80:
+		return float64(kind), b, nil
+	case kind >= 0xe0:
+		return float64(int8(kind)), b, nil
+	case kind&0xf0 == 0x80:
+		return decodeMsgpackMap(int(kind&0x0f), b)
+	case kind == 0xde:
+		n, b = length(2)
+		return decodeMsgpackMap(n, b)
+	case kind == 0xdf:
+		n, b = length(4)
+		return decodeMsgpackMap(n, b)
+	case kind&0xf0 == 0x90:
+		return decodeMsgpackArray(int(kind&0x0f), b)
+	case kind == 0xdc:
+		n, b = length(2)
+		return decodeMsgpackArray(n, b)
+	case kind == 0xdd:
+		n, b = length(4)
+		return decodeMsgpackArray(n, b)
+	case kind&0xe0 == 0xa0:
+		n = int(kind & 0x1f)
+	case kind == 0xd9 || kind == 0xda || kind == 0xdb:
+		n, b = length(1 << (kind - 0xd9))
+	case kind == 0xc0:
+		return nil, b, nil
+	case kind == 0xc2 || kind == 0xc3:
+		return kind == 0xc3, b, nil
+	case kind == 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), b[8:], nil
+	case kind >= 0xcc && kind <= 0xcf:
+		size := 1 << (kind - 0xcc)
+		var u uint64
+		for _, c := range b[:size] {
+			u = u<<8 | uint64(c)
+		}
+		return float64(u), b[size:], nil
+	case kind >= 0xd0 && kind <= 0xd3:
+		size := 1 << (kind - 0xd0)
+		var u uint64
+		for _, c := range b[:size] {
+			u = u<<8 | uint64(c)
+		}
+		shift := 64 - 8*size
+		return float64(int64(u<<shift) >> shift), b[size:], nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected type byte 0x%02x", kind)
+	}
+	return string(b[:n]), b[n:], nil
+}
+
+func decodeMsgpackMap(n int, b []byte) (interface{}, []byte, error) {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, rest, err := decodeMsgpack(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		if m[key.(string)], b, err = decodeMsgpack(rest); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m, b, nil
+}
+
+func decodeMsgpackArray(n int, b []byte) (interface{}, []byte, error) {
+	a := make([]interface{}, n)
+	var err error
+	for i := range a {
+		if a[i], b, err = decodeMsgpack(b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return a, b, nil
+}
+
+func TestFormatMsgpack(t *testing.T) {
+	info := createTestSystemInfo()
+	info.System.Hostname = strings.Repeat("h", 40) // str8
+	info.CPU.Usage = make([]float64, 20)           // array16
+	info.CPU.Usage[0] = -3.5
+
+	output, err := Format(info, &config.Config{Format: "msgpack"})
+	if err != nil {
+		t.Fatalf("Format msgpack failed: %v", err)
+	}
+
+	decoded, rest, err := decodeMsgpack([]byte(output))
+	if err != nil {
+		t.Fatalf("output is not valid MessagePack: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("%d trailing bytes after the report", len(rest))
+	}
+
+	// The report carries exactly what the JSON report does
+	jsonOutput, _ := FormatJSON(info)
+	var expected interface{}
+	if err := json.Unmarshal([]byte(jsonOutput), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("decoded MessagePack differs from the JSON report:\n%v\n%v", decoded, expected)
+	}
+	if len(output) >= len(jsonOutput) {
+		t.Errorf("MessagePack is %d bytes, expected it smaller than the %d byte JSON", len(output), len(jsonOutput))
+	}
+}
+
+func TestAppendMsgpackNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		want   []byte
+	}{
+		{"0", []byte{0x00}},
+		{"127", []byte{0x7f}},
+		{"-1", []byte{0xff}},
+		{"-32", []byte{0xe0}},
+		{"-33", []byte{0xd0, 0xdf}},
+		{"200", []byte{0xcc, 0xc8}},
+		{"65536", []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		{"-40000", []byte{0xd2, 0xff, 0xff, 0x63, 0xc0}},
+		{"18446744073709551615", []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"1.5", []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		if got := appendMsgpackNumber(nil, json.Number(tt.number)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("appendMsgpackNumber(%s) = % x, expected % x", tt.number, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package formatter
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// FormatNetwork formats a network-only report for the net command
+func FormatNetwork(network *types.NetworkData, format string) (string, error) {
+	if network == nil {
+		network = &types.NetworkData{}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(network, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(data), nil
+	case "ndjson":
+		data, err := json.Marshal(network)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(data) + "\n", nil
+	case "text":
+		return formatNetworkText(network), nil
+	case "pretty":
+		return formatNetworkPretty(network), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// formatNetworkText renders interfaces, totals and live rates as plain text
+func formatNetworkText(network *types.NetworkData) string {
+	var sb strings.Builder
+
+	sb.WriteString("NETWORK INTERFACES\n")
+	for _, iface := range network.Interfaces {
+		sb.WriteString(iface.Name)
+		if len(iface.Flags) > 0 {
+			sb.WriteString(fmt.Sprintf(" [%s]", strings.Join(iface.Flags, ",")))
+		}
+		sb.WriteString("\n")
+		if len(iface.Addresses) > 0 {
+			sb.WriteString(fmt.Sprintf("  Addresses: %s\n", strings.Join(iface.Addresses, ", ")))
+		}
+		if iface.WiFi != nil {
+			sb.WriteString(fmt.Sprintf("  Wi-Fi: %s\n", wifiString(iface.WiFi)))
+		}
+		sb.WriteString(fmt.Sprintf("  Total: sent %s, received %s\n", formatBytes(iface.BytesSent), formatBytes(iface.BytesRecv)))
+		if hasNetworkRates(iface) {
+			sb.WriteString(fmt.Sprintf("  Rate:  sent %s, received %s\n", formatRate(iface.SentBytesPerSec), formatRate(iface.RecvBytesPerSec)))
+		}
+		if iface.ErrorsIn+iface.ErrorsOut+iface.DropsIn+iface.DropsOut > 0 {
+			sb.WriteString(fmt.Sprintf("  Errors: %d in, %d out; Drops: %d in, %d out\n",
+				iface.ErrorsIn, iface.ErrorsOut, iface.DropsIn, iface.DropsOut))
+		}
+	}
+
+	if network.Connections > 0 {
+		sb.WriteString(fmt.Sprintf("\nConnections: %d\n", network.Connections))
+	}
+
+	return sb.String()
+}
+
+// formatNetworkPretty renders interfaces, totals and live rates with colors
+func formatNetworkPretty(network *types.NetworkData) string {
+	var sb strings.Builder
+
+	headerColor := color.New(color.FgCyan, color.Bold)
+	labelColor := color.New(color.FgGreen)
+	valueColor := color.New(color.FgWhite)
+	rateColor := color.New(color.FgYellow, color.Bold)
+
+	sb.WriteString(headerColor.Sprintf("┌─ NETWORK ────────────────────────────────────────────────────┐\n"))
+	for _, iface := range network.Interfaces {
+		sb.WriteString(fmt.Sprintf("│ %s", valueColor.Sprint(iface.Name)))
+		if len(iface.Flags) > 0 {
+			sb.WriteString(fmt.Sprintf(" %s", color.New(color.FgCyan).Sprint(strings.Join(iface.Flags, ","))))
+		}
+		sb.WriteString("\n")
+		for i, addr := range iface.Addresses {
+			label := ""
+			if i == 0 {
+				label = "IP:"
+			}
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint(label), valueColor.Sprint(addr)))
+		}
+		if iface.WiFi != nil {
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Wi-Fi:"), valueColor.Sprint(wifiString(iface.WiFi))))
+		}
+		sb.WriteString(fmt.Sprintf("│   %-18s %s / %s\n", labelColor.Sprint("Sent/Received:"),
+			valueColor.Sprint(formatBytes(iface.BytesSent)), valueColor.Sprint(formatBytes(iface.BytesRecv))))
+		if hasNetworkRates(iface) {
+			sb.WriteString(fmt.Sprintf("│   %-18s ↑ %s  ↓ %s\n", labelColor.Sprint("Throughput:"),
+				rateColor.Sprint(formatRate(iface.SentBytesPerSec)), rateColor.Sprint(formatRate(iface.RecvBytesPerSec))))
+		}
+		if iface.ErrorsIn+iface.ErrorsOut+iface.DropsIn+iface.DropsOut > 0 {
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Errors/Drops:"),
+				color.New(color.FgRed).Sprintf("%d/%d in, %d/%d out", iface.ErrorsIn, iface.DropsIn, iface.ErrorsOut, iface.DropsOut)))
+		}
+		sb.WriteString("│\n")
+	}
+	if network.Connections > 0 {
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Connections:"), valueColor.Sprintf("%d", network.Connections)))
+	}
+	sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+
+	return sb.String()
+}
+
+// hasNetworkRates reports whether live throughput was measured for an interface
+func hasNetworkRates(iface types.NetworkInterface) bool {
+	return iface.SentBytesPerSec > 0 || iface.RecvBytesPerSec > 0
+}
+
+// formatRate formats a byte rate as a human-readable per-second value
+func formatRate(bytesPerSec float64) string {
+	return formatBytes(uint64(bytesPerSec)) + "/s"
+}
+
+// wifiString describes a wireless association, e.g.
+// "HomeNet (3c:37:86:aa:bb:cc), 5 GHz channel 36, 802.11ax, -52 dBm, 960 Mbit/s"
+func wifiString(link *types.WiFiLink) string {
+	if !link.Connected {
+		return "not connected"
+	}
+	var parts []string
+	network := cmp.Or(link.SSID, "connected")
+	if link.BSSID != "" {
+		network += fmt.Sprintf(" (%s)", link.BSSID)
+	}
+	parts = append(parts, network)
+	if link.Channel > 0 {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%s channel %d", link.Band, link.Channel)))
+	}
+	if link.Standard != "" {
+		parts = append(parts, link.Standard)
+	}
+	switch {
+	case link.SignalDBm != 0:
+		parts = append(parts, fmt.Sprintf("%d dBm", link.SignalDBm))
+	case link.SignalPercent > 0:
+		parts = append(parts, fmt.Sprintf("signal %d%%", link.SignalPercent))
+	}
+	if link.TxRateMbps > 0 {
+		parts = append(parts, fmt.Sprintf("%s Mbit/s", strconv.FormatFloat(link.TxRateMbps, 'f', -1, 64)))
+	}
+	return strings.Join(parts, ", ")
+}
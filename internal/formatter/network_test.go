@@ -0,0 +1,113 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatNetwork(t *testing.T) {
+	network := &types.NetworkData{
+		Interfaces: []types.NetworkInterface{
+			{
+				Name:            "eth0",
+				Addresses:       []string{"192.168.1.10/24"},
+				Flags:           []string{"UP", "BROADCAST"},
+				BytesSent:       2048,
+				BytesRecv:       4096,
+				SentBytesPerSec: 1024,
+				RecvBytesPerSec: 2 * 1024 * 1024,
+				ErrorsIn:        3,
+			},
+			{Name: "lo", Flags: []string{"UP", "LOOPBACK"}},
+		},
+		Connections: 42,
+	}
+
+	tests := []struct {
+		format   string
+		contains []string
+	}{
+		{"text", []string{"NETWORK INTERFACES", "eth0 [UP,BROADCAST]", "192.168.1.10/24", "1.00 KB/s", "2.00 MB/s", "Errors: 3 in", "Connections: 42"}},
+		{"pretty", []string{"NETWORK", "eth0", "Throughput:", "1.00 KB/s", "Errors/Drops:"}},
+		{"json", []string{"\"recv_bytes_per_sec\": 2097152", "\"connection_count\": 42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			output, err := FormatNetwork(network, tt.format)
+			if err != nil {
+				t.Fatalf("FormatNetwork(%q) error = %v", tt.format, err)
+			}
+			output = stripAnsiCodes(output)
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("FormatNetwork(%q) missing %q", tt.format, want)
+				}
+			}
+		})
+	}
+
+	// Rates are only shown once measured
+	output, _ := FormatNetwork(&types.NetworkData{Interfaces: []types.NetworkInterface{{Name: "lo"}}}, "text")
+	if strings.Contains(output, "Rate:") {
+		t.Error("FormatNetwork should not show rates for a single sample")
+	}
+
+	if _, err := FormatNetwork(network, "invalid"); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+}
+
+func TestWiFiFormatting(t *testing.T) {
+	link := &types.WiFiLink{
+		Connected:    true,
+		SSID:         "HomeNet",
+		BSSID:        "3c:37:86:aa:bb:cc",
+		FrequencyMHz: 5180,
+		Channel:      36,
+		Band:         "5 GHz",
+		Standard:     "802.11ax",
+		TxRateMbps:   960,
+		SignalDBm:    -52,
+	}
+	expected := "HomeNet (3c:37:86:aa:bb:cc), 5 GHz channel 36, 802.11ax, -52 dBm, 960 Mbit/s"
+	if got := wifiString(link); got != expected {
+		t.Errorf("wifiString() = %q, expected %q", got, expected)
+	}
+	if got := wifiString(&types.WiFiLink{Connected: true, SignalPercent: 80}); got != "connected, signal 80%" {
+		t.Errorf("wifiString(percent) = %q", got)
+	}
+	if got := wifiString(&types.WiFiLink{}); got != "not connected" {
+		t.Errorf("wifiString(disconnected) = %q", got)
+	}
+
+	info := createTestSystemInfo()
+	info.Network = &types.NetworkData{Interfaces: []types.NetworkInterface{{Name: "wlan0", WiFi: link}}}
+	for name, output := range map[string]string{
+		"text":   FormatText(info),
+		"pretty": stripAnsiCodes(FormatPretty(info)),
+		"net":    formatNetworkText(info.Network),
+	} {
+		if !strings.Contains(output, "Wi-Fi:") || !strings.Contains(output, expected) {
+			t.Errorf("%s output missing Wi-Fi link", name)
+		}
+	}
+
+	html, err := FormatHTML(info)
+	if err != nil {
+		t.Fatalf("FormatHTML failed: %v", err)
+	}
+	if !strings.Contains(html, "HomeNet (3c:37:86:aa:bb:cc)") {
+		t.Error("HTML output missing Wi-Fi link")
+	}
+
+	csv, err := FormatCSV(info, "network")
+	if err != nil {
+		t.Fatalf("FormatCSV failed: %v", err)
+	}
+	if !strings.Contains(csv, "wifi_ssid") || !strings.Contains(csv, "HomeNet,3c:37:86:aa:bb:cc,36,-52,960") {
+		t.Errorf("CSV output missing Wi-Fi columns:\n%s", csv)
+	}
+}
@@ -0,0 +1,35 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// packageCountString counts the packages of each manager, e.g. "1843 dpkg, 12 homebrew"
+func packageCountString(p *types.PackageData) string {
+	counts := make(map[string]int)
+	for _, pkg := range p.Packages {
+		counts[pkg.Manager]++
+	}
+	var parts []string
+	for _, manager := range p.Managers {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[manager], manager))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// packageDetailString lists a package's architecture, manager and publisher, e.g.
+// "amd64, dpkg"
+func packageDetailString(p types.InstalledPackage) string {
+	var details []string
+	if p.Arch != "" {
+		details = append(details, p.Arch)
+	}
+	details = append(details, p.Manager)
+	if p.Publisher != "" {
+		details = append(details, p.Publisher)
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,432 @@
+package formatter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// parquetKind is a column's type: its physical type with the logical type annotating it
+type parquetKind int
+
+const (
+	parquetString    parquetKind = iota // BYTE_ARRAY, UTF-8
+	parquetInt64                        // INT64
+	parquetDouble                       // DOUBLE
+	parquetTimestamp                    // INT64, milliseconds since the epoch in UTC
+)
+
+type parquetColumn struct {
+	name     string
+	kind     parquetKind
+	optional bool // Rows may leave it null
+}
+
+// parquetTable is one flat table: its columns and rows of values, each a string, int64,
+// float64 or time.Time as the column's kind requires, or nil for null
+type parquetTable struct {
+	columns []parquetColumn
+	rows    [][]any
+}
+
+// Values of the Parquet format's enums, from parquet.thrift
+const (
+	parquetTypeInt64     = 2
+	parquetTypeDouble    = 5
+	parquetTypeByteArray = 6
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMillis = 9
+
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+
+	parquetCodecUncompressed = 0
+	parquetPageData          = 0
+)
+
+// FormatParquet formats the report's metrics as an Apache Parquet file for data lakes
+// (S3 with Athena, DuckDB, Spark), one row per sample of the metrics the prometheus format
+// exposes: timestamp, host, metric, labels as a JSON object and value. Reports from many
+// hosts can be queried together without a conversion step
+func FormatParquet(info *types.SystemInfo) (string, error) {
+	table := parquetTable{columns: []parquetColumn{
+		{name: "timestamp", kind: parquetTimestamp, optional: true},
+		{name: "host", kind: parquetString, optional: true},
+		{name: "metric", kind: parquetString},
+		{name: "labels", kind: parquetString, optional: true},
+		{name: "value", kind: parquetDouble},
+	}}
+	var timestamp, host any
+	if !info.Timestamp.IsZero() {
+		timestamp = info.Timestamp
+	}
+	if name := reportHostname(info); name != "" {
+		host = name
+	}
+
+	for _, family := range promFamilies(info) {
+		for _, sample := range family.samples {
+			var labels any
+			if encoded := parquetLabels(sample.labels); encoded != "" {
+				labels = encoded
+			}
+			table.rows = append(table.rows, []any{timestamp, host, family.name, labels, sample.value})
+		}
+	}
+	return writeParquet(table)
+}
+
+// FormatSMARTHistoryParquet formats SMART history records as a Parquet file, with the
+// columns of FormatSMARTHistoryCSV and the time as a UTC timestamp
+func FormatSMARTHistoryParquet(records []analyzer.SMARTHistoryRecord) (string, error) {
+	table := parquetTable{columns: []parquetColumn{
+		{name: "host", kind: parquetString},
+		{name: "device", kind: parquetString},
+		{name: "timestamp", kind: parquetTimestamp},
+		{name: "temperature_celsius", kind: parquetInt64},
+		{name: "health_status", kind: parquetString},
+		{name: "failure_probability", kind: parquetDouble},
+		{name: "remaining_life_percent", kind: parquetDouble},
+		{name: "percent_used", kind: parquetDouble},
+		{name: "power_on_hours", kind: parquetInt64},
+		{name: "issue_count", kind: parquetInt64},
+		{name: "critical_issues", kind: parquetInt64},
+		{name: "warning_issues", kind: parquetInt64},
+		{name: "clock_offset_ms", kind: parquetDouble, optional: true},
+	}}
+	for _, r := range records {
+		var clockOffset any
+		if r.ClockOffsetMS != nil {
+			clockOffset = *r.ClockOffsetMS
+		}
+		table.rows = append(table.rows, []any{
+			r.Host, r.Device, r.Timestamp, int64(r.Temperature), string(r.HealthStatus), r.FailureProbability,
+			r.RemainingLife, r.PercentUsed, r.PowerOnHours, int64(r.IssueCount), int64(r.CriticalIssues),
+			int64(r.WarningIssues), clockOffset,
+		})
+	}
+	return writeParquet(table)
+}
+
+// parquetLabels encodes alternating label names and values as a JSON object, leaving out
+// empty values as the Prometheus format does; "" without any
+func parquetLabels(labels []string) string {
+	object := map[string]string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		if labels[i+1] != "" {
+			object[labels[i]] = labels[i+1]
+		}
+	}
+	if len(object) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(object)
+	return string(data)
+}
+
+// writeParquet encodes a table as a Parquet file with a single row group, each column one
+// uncompressed data page of plain-encoded values. Readers need nothing more, and reports are
+// small enough that dictionaries and compression would gain little
+func writeParquet(table parquetTable) (string, error) {
+	out := []byte("PAR1")
+	var chunks []parquetChunk
+	if len(table.rows) > 0 {
+		for i, column := range table.columns {
+			page, err := parquetPage(column, table.rows, i)
+			if err != nil {
+				return "", err
+			}
+			chunks = append(chunks, parquetChunk{offset: int64(len(out)), size: int64(len(page))})
+			out = append(out, page...)
+		}
+	}
+
+	footer := parquetFooter(table, chunks)
+	out = append(out, footer...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(footer)))
+	return string(append(out, "PAR1"...)), nil
+}
+
+// parquetChunk locates a column's data page in the file
+type parquetChunk struct {
+	offset, size int64
+}
+
+// parquetPage encodes one column of the rows as a data page with its header. An optional
+// column's page starts with the definition levels telling values from nulls
+func parquetPage(column parquetColumn, rows [][]any, index int) ([]byte, error) {
+	var data, values []byte
+	defined := make([]bool, len(rows))
+	for r, row := range rows {
+		value := row[index]
+		if value == nil {
+			if !column.optional {
+				return nil, fmt.Errorf("parquet column %s: row %d is null", column.name, r)
+			}
+			continue
+		}
+		defined[r] = true
+
+		var ok bool
+		switch column.kind {
+		case parquetString:
+			var s string
+			if s, ok = value.(string); ok {
+				values = binary.LittleEndian.AppendUint32(values, uint32(len(s)))
+				values = append(values, s...)
+			}
+		case parquetInt64:
+			var n int64
+			if n, ok = value.(int64); ok {
+				values = binary.LittleEndian.AppendUint64(values, uint64(n))
+			}
+		case parquetDouble:
+			var f float64
+			if f, ok = value.(float64); ok {
+				values = binary.LittleEndian.AppendUint64(values, math.Float64bits(f))
+			}
+		case parquetTimestamp:
+			var t time.Time
+			if t, ok = value.(time.Time); ok {
+				values = binary.LittleEndian.AppendUint64(values, uint64(t.UnixMilli()))
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("parquet column %s: row %d has a %T value", column.name, r, value)
+		}
+	}
+	if column.optional {
+		data = appendDefinitionLevels(data, defined)
+	}
+	data = append(data, values...)
+
+	header := newThriftWriter()
+	header.i32(1, parquetPageData)
+	header.i32(2, int32(len(data))) // Uncompressed size
+	header.i32(3, int32(len(data))) // Compressed size
+	header.beginStruct(5)           // DataPageHeader
+	header.i32(1, int32(len(rows)))
+	header.i32(2, parquetEncodingPlain)
+	header.i32(3, parquetEncodingRLE) // Definition levels
+	header.i32(4, parquetEncodingRLE) // Repetition levels, absent in a flat schema
+	header.endStruct()
+	return append(header.finish(), data...), nil
+}
+
+// appendDefinitionLevels writes whether each row has a value as runs of the RLE hybrid
+// encoding, 1 for a value and 0 for null, after their length
+func appendDefinitionLevels(b []byte, defined []bool) []byte {
+	var runs []byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		runs = binary.AppendUvarint(runs, uint64(j-i)<<1)
+		if defined[i] {
+			runs = append(runs, 1)
+		} else {
+			runs = append(runs, 0)
+		}
+		i = j
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(runs)))
+	return append(b, runs...)
+}
+
+// parquetFooter encodes the FileMetaData: the schema, a root group holding the columns, and
+// the row group with each column chunk's location
+func parquetFooter(table parquetTable, chunks []parquetChunk) []byte {
+	w := newThriftWriter()
+	w.i32(1, 1) // Format version
+	w.listBegin(2, thriftStruct, len(table.columns)+1)
+	w.elementBegin()
+	w.str(4, "schema")
+	w.i32(5, int32(len(table.columns)))
+	w.endStruct()
+	for _, column := range table.columns {
+		w.elementBegin()
+		w.i32(1, column.physicalType())
+		if column.optional {
+			w.i32(3, parquetOptional)
+		} else {
+			w.i32(3, parquetRequired)
+		}
+		w.str(4, column.name)
+		switch column.kind {
+		case parquetString:
+			w.i32(6, parquetConvertedUTF8)
+			w.beginStruct(10) // LogicalType
+			w.beginStruct(1)  // STRING
+			w.endStruct()
+			w.endStruct()
+		case parquetTimestamp:
+			w.i32(6, parquetConvertedTimestampMillis)
+			w.beginStruct(10) // LogicalType
+			w.beginStruct(8)  // TIMESTAMP
+			w.boolean(1, true)
+			w.beginStruct(2) // Unit
+			w.beginStruct(1) // MILLIS
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+		}
+		w.endStruct()
+	}
+	w.i64(3, int64(len(table.rows)))
+
+	if len(chunks) == 0 {
+		w.listBegin(4, thriftStruct, 0)
+	} else {
+		w.listBegin(4, thriftStruct, 1)
+		w.elementBegin()
+		var total int64
+		w.listBegin(1, thriftStruct, len(chunks))
+		for i, chunk := range chunks {
+			column := table.columns[i]
+			total += chunk.size
+			w.elementBegin()
+			w.i64(2, chunk.offset)
+			w.beginStruct(3) // ColumnMetaData
+			w.i32(1, column.physicalType())
+			w.listBegin(2, thriftI32, 2)
+			w.element32(parquetEncodingPlain)
+			w.element32(parquetEncodingRLE)
+			w.listBegin(3, thriftBinary, 1)
+			w.elementString(column.name)
+			w.i32(4, parquetCodecUncompressed)
+			w.i64(5, int64(len(table.rows)))
+			w.i64(6, chunk.size)
+			w.i64(7, chunk.size)
+			w.i64(9, chunk.offset)
+			w.endStruct()
+			w.endStruct()
+		}
+		w.i64(2, total)
+		w.i64(3, int64(len(table.rows)))
+		w.endStruct()
+	}
+	w.str(6, "sysinfo")
+	return w.finish()
+}
+
+func (c parquetColumn) physicalType() int32 {
+	switch c.kind {
+	case parquetString:
+		return parquetTypeByteArray
+	case parquetDouble:
+		return parquetTypeDouble
+	default:
+		return parquetTypeInt64
+	}
+}
+
+// Type codes of the Thrift compact protocol
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes a struct in the Thrift compact protocol Parquet's metadata uses.
+// Field headers hold the difference to the previous field's ID in the same struct, so the
+// last ID is kept for each struct being written
+type thriftWriter struct {
+	buf    []byte
+	lastID []int16
+}
+
+// newThriftWriter starts writing the outermost struct, ended by finish
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastID: []int16{0}}
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.lastID[len(w.lastID)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.buf = binary.AppendVarint(w.buf, int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *thriftWriter) str(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.elementString(s)
+}
+
+// boolean writes a bool field, whose value is its type code
+func (w *thriftWriter) boolean(id int16, v bool) {
+	if v {
+		w.field(id, thriftTrue)
+	} else {
+		w.field(id, thriftFalse)
+	}
+}
+
+// beginStruct starts a struct field, ended by endStruct
+func (w *thriftWriter) beginStruct(id int16) {
+	w.field(id, thriftStruct)
+	w.lastID = append(w.lastID, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, 0)
+	w.lastID = w.lastID[:len(w.lastID)-1]
+}
+
+// listBegin starts a list field of n elements of one type, which follow it
+func (w *thriftWriter) listBegin(id int16, elemType byte, n int) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.buf = append(w.buf, byte(n)<<4|elemType)
+		return
+	}
+	w.buf = append(w.buf, 0xf0|elemType)
+	w.buf = binary.AppendUvarint(w.buf, uint64(n))
+}
+
+// elementBegin starts a struct list element, ended by endStruct
+func (w *thriftWriter) elementBegin() {
+	w.lastID = append(w.lastID, 0)
+}
+
+func (w *thriftWriter) element32(v int32) {
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) elementString(s string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// finish ends the outermost struct and returns its encoding
+func (w *thriftWriter) finish() []byte {
+	return append(w.buf, 0)
+}
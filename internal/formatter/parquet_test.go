@@ -0,0 +1,281 @@
+package formatter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+// thriftReader is a minimal compact protocol decoder, with structs as maps by field ID,
+// lists as slices and integers as int64
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) varint() int64 {
+	v, n := binary.Varint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) readStruct() map[int16]any {
+	fields := map[int16]any{}
+	var id int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(r.varint())
+		}
+		fields[id] = r.readValue(header & 0x0f)
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) any {
+	switch typ {
+	case thriftTrue:
+		return true
+	case thriftFalse:
+		return false
+	case thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		header := r.byte()
+		n := int(header >> 4)
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.readValue(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+	panic(fmt.Sprintf("unexpected thrift type %d", typ))
+}
+
+// readParquet reads back the flat files writeParquet produces: column names and rows, with
+// timestamps as UTC times and nulls as nil
+func readParquet(t *testing.T, file string) ([]string, [][]any) {
+	t.Helper()
+	data := []byte(file)
+	if len(data) < 12 || string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		t.Fatalf("not a Parquet file: % x", data)
+	}
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := &thriftReader{data: data[:len(data)-8], pos: len(data) - 8 - footerSize}
+	meta := footer.readStruct()
+	if footer.pos != len(data)-8 {
+		t.Fatalf("footer ends at %d, want %d", footer.pos, len(data)-8)
+	}
+
+	schema := meta[2].([]any)
+	if root := schema[0].(map[int16]any); root[5].(int64) != int64(len(schema)-1) {
+		t.Fatalf("root schema element = %v", root)
+	}
+	var names []string
+	var elements []map[int16]any
+	for _, e := range schema[1:] {
+		element := e.(map[int16]any)
+		names = append(names, element[4].(string))
+		elements = append(elements, element)
+	}
+
+	numRows := int(meta[3].(int64))
+	rows := make([][]any, numRows)
+	for i := range rows {
+		rows[i] = make([]any, len(names))
+	}
+	for _, rg := range meta[4].([]any) {
+		rowGroup := rg.(map[int16]any)
+		if rowGroup[3].(int64) != int64(numRows) {
+			t.Fatalf("row group has %d rows, file %d", rowGroup[3], numRows)
+		}
+		for c, chunk := range rowGroup[1].([]any) {
+			columnMeta := chunk.(map[int16]any)[3].(map[int16]any)
+			if columnMeta[3].([]any)[0] != names[c] {
+				t.Fatalf("column chunk %d is for %v, want %s", c, columnMeta[3], names[c])
+			}
+			page := &thriftReader{data: data, pos: int(columnMeta[9].(int64))}
+			header := page.readStruct()
+			if header[1].(int64) != parquetPageData || header[5].(map[int16]any)[1].(int64) != int64(numRows) {
+				t.Fatalf("page header = %v", header)
+			}
+			pageStart := page.pos
+			if int64(page.pos)+header[3].(int64)-columnMeta[9].(int64) != columnMeta[7].(int64) {
+				t.Fatalf("column %s: chunk size %d does not match its page", names[c], columnMeta[7])
+			}
+
+			defined := make([]bool, numRows)
+			if elements[c][3].(int64) == parquetOptional {
+				levels := &thriftReader{data: data, pos: page.pos + 4}
+				end := levels.pos + int(binary.LittleEndian.Uint32(data[page.pos:]))
+				for row := 0; levels.pos < end; {
+					run := int(levels.uvarint() >> 1)
+					value := levels.byte() == 1
+					for i := 0; i < run; i++ {
+						defined[row] = value
+						row++
+					}
+				}
+				page.pos = end
+			} else {
+				for i := range defined {
+					defined[i] = true
+				}
+			}
+
+			for row := range rows {
+				if !defined[row] {
+					continue
+				}
+				switch elements[c][1].(int64) {
+				case parquetTypeByteArray:
+					n := int(binary.LittleEndian.Uint32(data[page.pos:]))
+					rows[row][c] = string(data[page.pos+4 : page.pos+4+n])
+					page.pos += 4 + n
+				case parquetTypeDouble:
+					rows[row][c] = math.Float64frombits(binary.LittleEndian.Uint64(data[page.pos:]))
+					page.pos += 8
+				case parquetTypeInt64:
+					n := int64(binary.LittleEndian.Uint64(data[page.pos:]))
+					if converted, ok := elements[c][6]; ok && converted.(int64) == parquetConvertedTimestampMillis {
+						rows[row][c] = time.UnixMilli(n).UTC()
+					} else {
+						rows[row][c] = n
+					}
+					page.pos += 8
+				}
+			}
+			if page.pos-pageStart != int(header[2].(int64)) {
+				t.Fatalf("column %s: read %d bytes of a %d byte page", names[c], page.pos-pageStart, header[2])
+			}
+		}
+	}
+	return names, rows
+}
+
+func TestFormatParquet(t *testing.T) {
+	info := createTestSystemInfo()
+	output, err := Format(info, &config.Config{Format: "parquet"})
+	if err != nil {
+		t.Fatalf("Format parquet failed: %v", err)
+	}
+
+	names, rows := readParquet(t, output)
+	if fmt.Sprint(names) != "[timestamp host metric labels value]" {
+		t.Fatalf("columns = %v", names)
+	}
+	samples := 0
+	for _, family := range promFamilies(info) {
+		samples += len(family.samples)
+	}
+	if len(rows) != samples {
+		t.Fatalf("got %d rows, want one per sample: %d", len(rows), samples)
+	}
+
+	byMetric := map[string][]any{}
+	for _, row := range rows {
+		if row[0] != info.Timestamp || row[1] != "test-host" {
+			t.Fatalf("row %v lacks the report's timestamp and host", row)
+		}
+		byMetric[fmt.Sprintf("%v %v", row[2], row[3])] = row
+	}
+	if row := byMetric["sysinfo_memory_total_bytes <nil>"]; row == nil || row[4] != float64(info.Memory.Total) {
+		t.Errorf("memory total row = %v", row)
+	}
+	if row := byMetric[`sysinfo_load_average {"period":"5m"}`]; row == nil || row[4] != 1.2 {
+		t.Errorf("load average row = %v", row)
+	}
+}
+
+func TestFormatSMARTHistoryParquet(t *testing.T) {
+	offset := 1250.5
+	records := []analyzer.SMARTHistoryRecord{
+		{Host: "nas", Device: "/dev/sda", Timestamp: time.Date(2025, 11, 7, 14, 30, 0, 0, time.UTC), Temperature: 42,
+			PowerOnHours: 12000, HealthStatus: analyzer.HealthGood, RemainingLife: 97.5, PercentUsed: 2.5},
+		{Host: "nas", Device: "/dev/sda", Timestamp: time.Date(2025, 11, 8, 9, 0, 0, 0, time.UTC), Temperature: 44,
+			PowerOnHours: 12019, HealthStatus: analyzer.HealthWarning, FailureProbability: 12, IssueCount: 1,
+			WarningIssues: 1, ClockOffsetMS: &offset},
+	}
+	output, err := FormatSMARTHistoryParquet(records)
+	if err != nil {
+		t.Fatalf("FormatSMARTHistoryParquet() error = %v", err)
+	}
+
+	names, rows := readParquet(t, output)
+	if len(names) != 13 || names[2] != "timestamp" || names[12] != "clock_offset_ms" {
+		t.Fatalf("columns = %v", names)
+	}
+	want := []any{"nas", "/dev/sda", records[0].Timestamp, int64(42), string(analyzer.HealthGood), 0.0, 97.5, 2.5,
+		int64(12000), int64(0), int64(0), int64(0), nil}
+	if len(rows) != 2 || fmt.Sprint(rows[0]) != fmt.Sprint(want) {
+		t.Fatalf("rows = %v; want first %v", rows, want)
+	}
+	if rows[1][4] != string(analyzer.HealthWarning) || rows[1][12] != offset {
+		t.Errorf("row = %v", rows[1])
+	}
+
+	// An empty period is still a valid file, with the schema and no rows
+	output, err = FormatSMARTHistoryParquet(nil)
+	if err != nil {
+		t.Fatalf("FormatSMARTHistoryParquet(nil) error = %v", err)
+	}
+	if names, rows := readParquet(t, output); len(names) != 13 || len(rows) != 0 {
+		t.Errorf("empty export = %v columns, %d rows", names, len(rows))
+	}
+}
+
+func TestWriteParquetRejectsMismatchedValues(t *testing.T) {
+	tests := map[string]parquetTable{
+		"null in required column": {columns: []parquetColumn{{name: "a", kind: parquetString}}, rows: [][]any{{nil}}},
+		"wrong type":              {columns: []parquetColumn{{name: "a", kind: parquetInt64}}, rows: [][]any{{1.5}}},
+	}
+	for name, table := range tests {
+		if _, err := writeParquet(table); err == nil {
+			t.Errorf("%s: writeParquet() should fail", name)
+		}
+	}
+}
+
+func TestThriftWriterLongFieldIDs(t *testing.T) {
+	// IDs more than 15 apart, or going backwards, take the long form
+	w := newThriftWriter()
+	w.i32(1, -3)
+	w.i64(20, 1<<40)
+	w.str(2, "x")
+	r := &thriftReader{data: w.finish()}
+	fields := r.readStruct()
+	if fields[1] != int64(-3) || fields[20] != int64(1<<40) || fields[2] != "x" {
+		t.Errorf("fields = %v", fields)
+	}
+}
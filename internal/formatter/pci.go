@@ -0,0 +1,30 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// pciDeviceString describes a PCI function by class, vendor and name with its IDs, e.g.
+// "Audio device: Intel Corporation Cannon Lake PCH cAVS [8086:a348]"
+func pciDeviceString(d types.PCIDevice) string {
+	name := strings.TrimSpace(d.Vendor + " " + d.Name)
+	if name == "" {
+		name = "Unknown device"
+	}
+	text := fmt.Sprintf("%s [%s:%s]", name, d.VendorID, d.DeviceID)
+	if d.ClassName != "" {
+		text = d.ClassName + ": " + text
+	}
+	return text
+}
+
+// pciDriverString names the bound driver, or says there is none
+func pciDriverString(d types.PCIDevice) string {
+	if d.Driver == "" {
+		return "no driver"
+	}
+	return d.Driver
+}
@@ -2,10 +2,12 @@ package formatter
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 )
 
 // FormatPretty formats the information with colors and tables
@@ -21,7 +23,32 @@ func FormatPretty(info *types.SystemInfo) string {
 	sb.WriteString(headerColor.Sprintf("═══════════════════════════════════════════════════════════════\n"))
 	sb.WriteString(headerColor.Sprintf("  SYSTEM INFORMATION REPORT\n"))
 	sb.WriteString(headerColor.Sprintf("═══════════════════════════════════════════════════════════════\n"))
-	sb.WriteString(fmt.Sprintf("%s %s\n\n", labelColor.Sprint("Timestamp:"), valueColor.Sprint(info.Timestamp.Format("2006-01-02 15:04:05"))))
+	if timestamp := utils.FormatTimestamp(info.Timestamp, info.TimestampFormat, "2006-01-02 15:04:05"); timestamp != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n\n", labelColor.Sprint("Timestamp:"), valueColor.Sprint(timestamp)))
+	} else {
+		sb.WriteString("\n")
+	}
+
+	// A --fields report lists only what was selected
+	if len(info.Fields) > 0 {
+		fields, err := selectedFields(info)
+		if err != nil {
+			sb.WriteString(color.New(color.FgRed).Sprintf("Error: %v\n", err))
+		}
+		for _, field := range fields {
+			sb.WriteString(fmt.Sprintf("%s %s\n", labelColor.Sprint(field.Path+":"), valueColor.Sprint(field.Value)))
+		}
+		return sb.String()
+	}
+
+	if info.Meta != nil && info.Meta.ClockOffset != nil {
+		offsetColor := valueColor
+		// Offsets beyond a second skew cross-host correlation noticeably
+		if math.Abs(info.Meta.ClockOffset.OffsetMS) >= 1000 {
+			offsetColor = color.New(color.FgYellow)
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n\n", labelColor.Sprint("Clock Offset:"), offsetColor.Sprint(clockOffsetString(info.Meta.ClockOffset))))
+	}
 
 	// System information
 	if info.System != nil {
@@ -32,9 +59,38 @@ func FormatPretty(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Kernel:"), valueColor.Sprintf("%s (%s)", info.System.KernelVersion, info.System.KernelArch)))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Uptime:"), valueColor.Sprint(info.System.UptimeFormatted)))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Processes:"), valueColor.Sprintf("%d", info.System.Procs)))
+		if info.System.InstallationType != "" {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Installation:"), valueColor.Sprint(info.System.InstallationType)))
+		}
+		if lic := info.System.License; lic != nil {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Edition:"), valueColor.Sprint(lic.Edition)))
+			licenseColor := color.New(color.FgGreen)
+			if lic.Status != "Licensed" {
+				licenseColor = color.New(color.FgYellow)
+			}
+			licenseStatus := licenseColor.Sprint(lic.Status)
+			if lic.Channel != "" {
+				licenseStatus += valueColor.Sprintf(" (%s)", lic.Channel)
+			}
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("License:"), licenseStatus))
+			if lic.InstallDate != nil {
+				sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Install Date:"), valueColor.Sprint(lic.InstallDate.Format("2006-01-02"))))
+			}
+		}
 		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
 	}
 
+	// Motherboard, firmware and chassis
+	if info.Baseboard != nil {
+		if items := boardItems(info.Baseboard); len(items) > 0 {
+			sb.WriteString(headerColor.Sprintf("┌─ BASEBOARD ──────────────────────────────────────────────────┐\n"))
+			for _, item := range items {
+				sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint(item.Label+":"), valueColor.Sprint(item.Value)))
+			}
+			sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
+		}
+	}
+
 	// CPU information
 	if info.CPU != nil {
 		sb.WriteString(headerColor.Sprintf("┌─ CPU ────────────────────────────────────────────────────────┐\n"))
@@ -42,10 +98,18 @@ func FormatPretty(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Vendor:"), valueColor.Sprint(info.CPU.Vendor)))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Physical Cores:"), valueColor.Sprintf("%d", info.CPU.Cores)))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Logical CPUs:"), valueColor.Sprintf("%d", info.CPU.LogicalCPUs)))
+		if info.CPU.Sockets > 0 {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Topology:"), valueColor.Sprint(cpuTopologyString(info.CPU))))
+		}
+		if len(info.CPU.Packages) > 1 {
+			for _, p := range info.CPU.Packages {
+				sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprintf("  Socket %d:", p.Socket), valueColor.Sprint(cpuPackageString(p))))
+			}
+		}
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Frequency:"), valueColor.Sprintf("%.2f MHz", info.CPU.MHz)))
 
 		if info.CPU.CacheSize > 0 {
-			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Cache Size:"), valueColor.Sprintf("%d KB", info.CPU.CacheSize)))
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Cache Size:"), valueColor.Sprint(formatBytes(uint64(info.CPU.CacheSize)*1024))))
 		}
 
 		if info.CPU.Microcode != "" {
@@ -57,11 +121,21 @@ func FormatPretty(info *types.SystemInfo) string {
 				valueColor.Sprintf("%.2f, %.2f, %.2f", info.CPU.LoadAvg.Load1, info.CPU.LoadAvg.Load5, info.CPU.LoadAvg.Load15)))
 		}
 
+		for _, class := range info.CPU.CoreClasses {
+			bar := createProgressBar(class.Usage, 20)
+			sb.WriteString(fmt.Sprintf("│ %-20s %s %s\n", labelColor.Sprint(coreClassName(class.Class)+":"), bar,
+				valueColor.Sprintf("%.1f%% (CPUs %s)", class.Usage, cpuListString(class.CPUs))))
+		}
+
 		if len(info.CPU.Usage) > 0 {
 			sb.WriteString(fmt.Sprintf("│ %-20s\n", labelColor.Sprint("Core Usage:")))
 			for i, usage := range info.CPU.Usage {
 				bar := createProgressBar(usage, 20)
-				sb.WriteString(fmt.Sprintf("│   Core %-2d: %s %s\n", i, bar, valueColor.Sprintf("%.1f%%", usage)))
+				label := fmt.Sprintf("Core %-2d", i)
+				if tag := coreClassTag(info.CPU, i); tag != "" {
+					label += " " + tag
+				}
+				sb.WriteString(fmt.Sprintf("│   %s: %s %s\n", label, bar, valueColor.Sprintf("%.1f%%", usage)))
 			}
 		}
 		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
@@ -76,6 +150,9 @@ func FormatPretty(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("│ %-20s %s %s\n", labelColor.Sprint("Used:"),
 			memBar, valueColor.Sprintf("%s (%.1f%%)", info.Memory.UsedFormatted, info.Memory.UsedPercent)))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Free:"), valueColor.Sprint(info.Memory.FreeFormatted)))
+		if info.Memory.Available > 0 {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Available:"), valueColor.Sprint(formatBytes(info.Memory.Available))))
+		}
 
 		if info.Memory.Cached > 0 {
 			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Cached:"), valueColor.Sprint(formatBytes(info.Memory.Cached))))
@@ -83,6 +160,14 @@ func FormatPretty(info *types.SystemInfo) string {
 		if info.Memory.Buffers > 0 {
 			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Buffers:"), valueColor.Sprint(formatBytes(info.Memory.Buffers))))
 		}
+		if info.Memory.Cached+info.Memory.Buffers > 0 {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", "", color.New(color.Faint).Sprint("cache and buffers are reclaimed on demand")))
+		}
+		if used, percent, ok := effectiveMemoryUsed(info.Memory); ok {
+			effectiveBar := createProgressBar(percent, 30)
+			sb.WriteString(fmt.Sprintf("│ %-20s %s %s\n", labelColor.Sprint("Effectively Used:"),
+				effectiveBar, valueColor.Sprintf("%s (%.1f%%)", formatBytes(used), percent)))
+		}
 
 		if info.Memory.SwapTotal > 0 {
 			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Swap Total:"), valueColor.Sprint(formatBytes(info.Memory.SwapTotal))))
@@ -192,6 +277,20 @@ func FormatPretty(info *types.SystemInfo) string {
 			}
 		}
 
+		// TRIM status for SSDs
+		if info.Disk.Trim != nil {
+			trimColor := color.New(color.FgGreen)
+			if info.Disk.Trim.Status != "OK" {
+				trimColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│ %-20s %s %s\n", labelColor.Sprint("TRIM:"),
+				trimColor.Sprint(info.Disk.Trim.Status), valueColor.Sprint(info.Disk.Trim.Message)))
+			if info.Disk.Trim.LastTrim != nil {
+				sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Last Trim:"),
+					valueColor.Sprintf("%s (%d days ago)", info.Disk.Trim.LastTrim.Format("2006-01-02"), info.Disk.Trim.DaysSinceTrim)))
+			}
+		}
+
 		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n\n"))
 	}
 
@@ -282,6 +381,9 @@ func FormatPretty(info *types.SystemInfo) string {
 					}
 				}
 			}
+			if iface.WiFi != nil {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Wi-Fi:"), valueColor.Sprint(wifiString(iface.WiFi))))
+			}
 			if iface.BytesSent > 0 || iface.BytesRecv > 0 {
 				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Sent:"), valueColor.Sprint(formatBytes(iface.BytesSent))))
 				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Received:"), valueColor.Sprint(formatBytes(iface.BytesRecv))))
@@ -293,12 +395,19 @@ func FormatPretty(info *types.SystemInfo) string {
 
 	// Process information
 	if info.Processes != nil {
+		width := processNameWidth()
 		sb.WriteString(headerColor.Sprintf("┌─ PROCESSES ──────────────────────────────────────────────────┐\n"))
 		sb.WriteString(fmt.Sprintf("│ %-20s %s (Running: %s, Sleeping: %s)\n",
 			labelColor.Sprint("Total:"),
 			valueColor.Sprintf("%d", info.Processes.TotalCount),
 			valueColor.Sprintf("%d", info.Processes.Running),
 			valueColor.Sprintf("%d", info.Processes.Sleeping)))
+		if info.Processes.Zombies > 0 {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Zombies:"), color.New(color.FgYellow).Sprint(zombieString(info.Processes))))
+		}
+		if handles := info.Processes.FileHandles; handles != nil {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Open Files:"), valueColor.Sprintf("%d of %d", handles.Open, handles.Max)))
+		}
 
 		if len(info.Processes.TopByMemory) > 0 {
 			sb.WriteString(fmt.Sprintf("│\n│ %s\n", labelColor.Sprint("Top by Memory:")))
@@ -306,8 +415,9 @@ func FormatPretty(info *types.SystemInfo) string {
 				if i >= 5 {
 					break
 				}
-				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-30s %6d MB  %.1f%%",
-					truncate(proc.Name, 30), proc.MemoryMB, proc.MemoryPercent)))
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s %10s  %.1f%%",
+					width, truncate(proc.Name, width), formatBytes(proc.MemoryMB<<20), proc.MemoryPercent)))
+				writePrettyProcessDetails(&sb, proc)
 			}
 		}
 
@@ -317,8 +427,56 @@ func FormatPretty(info *types.SystemInfo) string {
 				if i >= 5 {
 					break
 				}
-				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-30s %6.1f%%",
-					truncate(proc.Name, 30), proc.CPUPercent)))
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s %6.1f%%",
+					width, truncate(proc.Name, width), proc.CPUPercent)))
+				writePrettyProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByDiskIO) > 0 {
+			sb.WriteString(fmt.Sprintf("│\n│ %s\n", labelColor.Sprint("Top by Disk I/O:")))
+			for i, proc := range info.Processes.TopByDiskIO {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s R %10s  W %10s",
+					width, truncate(proc.Name, width), formatBytes(proc.DiskReadBytes), formatBytes(proc.DiskWriteBytes))))
+				writePrettyProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByGPU) > 0 {
+			sb.WriteString(fmt.Sprintf("│\n│ %s\n", labelColor.Sprint("Top by GPU:")))
+			for i, proc := range info.Processes.TopByGPU {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s %6.0f%%",
+					width, truncate(proc.Name, width), proc.GPUPercent)))
+				writePrettyProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByOpenFiles) > 0 {
+			sb.WriteString(fmt.Sprintf("│\n│ %s\n", labelColor.Sprint("Top by Open Files:")))
+			for i, proc := range info.Processes.TopByOpenFiles {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s %s",
+					width, truncate(proc.Name, width), openFilesString(proc))))
+				writePrettyProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.Containers) > 0 {
+			sb.WriteString(fmt.Sprintf("│\n│ %s\n", labelColor.Sprint("Top Containers:")))
+			for i, c := range info.Processes.Containers {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("│   %s\n", valueColor.Sprintf("%-*s %6.1f%%  %10s  %d procs",
+					width, truncate(containerLabel(c.ContainerRef), width), c.CPUPercent, formatBytes(c.MemoryMB<<20), c.Processes)))
 			}
 		}
 
@@ -326,27 +484,26 @@ func FormatPretty(info *types.SystemInfo) string {
 	}
 
 	// Battery information
-	if info.Battery != nil && info.Battery.Present && len(info.Battery.Batteries) > 0 {
+	if hasBatteryData(info.Battery) {
 		sb.WriteString("\n")
 		sb.WriteString(headerColor.Sprintf("┌─ BATTERY ────────────────────────────────────────────────────┐\n"))
 
 		// Power source status
-		powerSource := "AC Power"
-		if info.Battery.OnBattery {
-			powerSource = color.New(color.FgYellow).Sprint("Battery Power")
+		if len(info.Battery.Batteries) > 0 {
+			powerSource := "AC Power"
+			if info.Battery.OnBattery {
+				powerSource = color.New(color.FgYellow).Sprint("Battery Power")
+			}
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Power Source:"), powerSource))
 		}
-		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Power Source:"), powerSource))
 
 		if len(info.Battery.Batteries) > 1 {
 			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Total Capacity:"),
 				valueColor.Sprint(formatMilliwattHours(info.Battery.TotalCapacity))))
 		}
-		sb.WriteString("│\n")
 
-		for i, battery := range info.Battery.Batteries {
-			if i > 0 {
-				sb.WriteString("│\n")
-			}
+		for _, battery := range info.Battery.Batteries {
+			sb.WriteString("│\n")
 
 			batteryLabel := battery.Name
 			if battery.Model != "" {
@@ -467,6 +624,59 @@ func FormatPretty(info *types.SystemInfo) string {
 			}
 		}
 
+		for _, ups := range info.Battery.UPSDevices {
+			sb.WriteString("│\n")
+			sb.WriteString(fmt.Sprintf("│ %s\n", valueColor.Sprintf("UPS: %s", upsLabel(ups))))
+
+			statusColor := color.New(color.FgGreen)
+			if strings.Contains(strings.ToLower(ups.Status), "low") {
+				statusColor = color.New(color.FgRed)
+			} else if !strings.EqualFold(ups.Status, "Online") {
+				statusColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Status:"), statusColor.Sprint(ups.Status)))
+
+			if ups.ChargeLevel > 0 {
+				chargeColor := valueColor
+				if ups.ChargeLevel < 20 {
+					chargeColor = color.New(color.FgRed)
+				} else if ups.ChargeLevel < 50 {
+					chargeColor = color.New(color.FgYellow)
+				}
+				sb.WriteString(fmt.Sprintf("│   %-18s %s %s\n", labelColor.Sprint("Charge Level:"),
+					createProgressBar(ups.ChargeLevel, 28), chargeColor.Sprintf("%.1f%%", ups.ChargeLevel)))
+			}
+
+			if ups.Load > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s %s\n", labelColor.Sprint("Load:"),
+					createProgressBar(ups.Load, 28), valueColor.Sprintf("%.1f%%", ups.Load)))
+			}
+
+			if ups.Runtime > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Runtime:"), valueColor.Sprint(formatTime(ups.Runtime))))
+			}
+
+			if ups.Voltage > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Voltage:"), valueColor.Sprintf("%.1f V", ups.Voltage)))
+			}
+
+			if ups.BatteryVoltage > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Battery Voltage:"), valueColor.Sprintf("%.2f V", ups.BatteryVoltage)))
+			}
+
+			if ups.Power > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Power Rating:"), valueColor.Sprintf("%d W", ups.Power)))
+			}
+
+			if ups.Temperature > 0 {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Temperature:"), valueColor.Sprintf("%.1f°C", ups.Temperature)))
+			}
+
+			if ups.SerialNumber != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Serial Number:"), valueColor.Sprint(ups.SerialNumber)))
+			}
+		}
+
 		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
 	}
 
@@ -545,8 +755,348 @@ func FormatPretty(info *types.SystemInfo) string {
 				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("PCI Bus:"), valueColor.Sprint(gpu.PCIBus)))
 			}
 
+			if gpu.KernelModuleVersion != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Kernel Module:"), valueColor.Sprint(gpu.KernelModuleVersion)))
+			}
+
+			if gpu.CUDAVersion != "" || gpu.CUDARuntimeVersion != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("CUDA:"), valueColor.Sprint(cudaString(gpu))))
+			}
+
+			if gpu.DriverError != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Driver Error:"), color.New(color.FgRed).Sprint(truncate(gpu.DriverError, 40))))
+			}
+
+			if gpu.MIGMode != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("MIG Mode:"), valueColor.Sprint(gpu.MIGMode)))
+			}
+
+			if gpu.VirtualizationMode != "" && gpu.VirtualizationMode != "None" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Virtualization:"), valueColor.Sprint(gpu.VirtualizationMode)))
+			}
+
+			if len(gpu.Partitions) > 0 {
+				sb.WriteString(fmt.Sprintf("│   %s\n", labelColor.Sprint("Partitions:")))
+				for _, partition := range gpu.Partitions {
+					sb.WriteString(fmt.Sprintf("│     %s\n", valueColor.Sprint(gpuPartitionString(partition))))
+				}
+			}
+
 			sb.WriteString("│\n")
 		}
+
+		if len(info.GPU.DriverIssues) > 0 {
+			sb.WriteString(fmt.Sprintf("│ %s\n", labelColor.Sprint("Driver Issues:")))
+			for _, issue := range info.GPU.DriverIssues {
+				severityColor := color.New(color.FgYellow)
+				if issue.Severity == "CRITICAL" {
+					severityColor = color.New(color.FgRed)
+				}
+				sb.WriteString(fmt.Sprintf("│   %s GPU %d: %s\n", severityColor.Sprintf("[%s]", issue.Severity), issue.GPUIndex, issue.Description))
+				sb.WriteString(fmt.Sprintf("│     → %s\n", issue.Recommendation))
+			}
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Non-GPU accelerators
+	if info.Accelerators != nil && len(info.Accelerators.Accelerators) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ ACCELERATORS ───────────────────────────────────────────────┐\n"))
+		for i, acc := range info.Accelerators.Accelerators {
+			sb.WriteString(fmt.Sprintf("│ %s\n", valueColor.Sprintf("%s %d: %s", acc.Type, i, acc.Name)))
+			if acc.Vendor != "" {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Vendor:"), valueColor.Sprint(acc.Vendor)))
+			}
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Bus:"), valueColor.Sprint(acceleratorBusString(acc))))
+			driverColor := color.New(color.FgGreen)
+			if !acc.DriverLoaded {
+				driverColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint("Driver:"), driverColor.Sprint(acceleratorDriverString(acc))))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// PCI devices
+	if info.PCI != nil && len(info.PCI.Devices) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ PCI DEVICES ────────────────────────────────────────────────┐\n"))
+		for _, d := range info.PCI.Devices {
+			driverColor := color.New(color.FgGreen)
+			if d.Driver == "" {
+				driverColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│ %-20s %s %s\n", labelColor.Sprint(d.Address), valueColor.Sprint(pciDeviceString(d)), driverColor.Sprintf("(%s)", pciDriverString(d))))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// USB devices
+	if info.USB != nil && len(info.USB.Devices) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ USB DEVICES ────────────────────────────────────────────────┐\n"))
+		for _, d := range info.USB.Devices {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(usbLocationString(d)), valueColor.Sprint(usbDeviceString(d)))
+			if detail := usbDetailString(d); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Connected displays
+	if info.Displays != nil && len(info.Displays.Displays) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ DISPLAYS ───────────────────────────────────────────────────┐\n"))
+		for _, d := range info.Displays.Displays {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(d.Name), valueColor.Sprint(displayString(d)))
+			if mode := displayModeString(d); mode != "" {
+				line += " " + mode
+			}
+			if detail := displayDetailString(d); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Sound cards and their endpoints
+	if info.Audio != nil && len(info.Audio.Devices) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ AUDIO DEVICES ──────────────────────────────────────────────┐\n"))
+		for _, d := range info.Audio.Devices {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(audioDeviceString(d)), valueColor.Sprint(audioEndpointsString(d)))
+			if detail := audioDetailString(d); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Bluetooth adapters and paired devices
+	if info.Bluetooth != nil && len(info.Bluetooth.Adapters) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ BLUETOOTH ──────────────────────────────────────────────────┐\n"))
+		for _, a := range info.Bluetooth.Adapters {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(a.Name), valueColor.Sprint(a.Address))
+			line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", bluetoothAdapterDetailString(a))
+			sb.WriteString(line + "\n")
+		}
+		for _, d := range info.Bluetooth.Devices {
+			stateColor := color.New(color.FgHiBlack)
+			if d.Connected {
+				stateColor = color.New(color.FgGreen)
+			}
+			line := fmt.Sprintf("│   %-18s %s", labelColor.Sprint(d.Name), valueColor.Sprint(d.Address))
+			line += " " + stateColor.Sprintf("(%s)", bluetoothDeviceDetailString(d))
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Loaded kernel modules and drivers
+	if info.Drivers != nil && len(info.Drivers.Drivers) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ KERNEL DRIVERS ─────────────────────────────────────────────┐\n"))
+		if len(info.Drivers.Tainted) > 0 {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Kernel tainted:"), color.New(color.FgYellow).Sprint(strings.Join(info.Drivers.Tainted, ", "))))
+		}
+		for _, d := range info.Drivers.Drivers {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(d.Name), valueColor.Sprint(d.Version))
+			if detail := driverDetailString(d); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Service states and the failed services
+	if info.Services != nil {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ SERVICES ───────────────────────────────────────────────────┐\n"))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Manager:"), valueColor.Sprint(info.Services.Manager)))
+		countColor := valueColor
+		if info.Services.Failed > 0 {
+			countColor = color.New(color.FgRed)
+		}
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Services:"), countColor.Sprint(serviceCountString(info.Services))))
+		for _, s := range info.Services.FailedServices {
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint("Failed:"), color.New(color.FgRed).Sprint(s.Name))
+			if detail := serviceDetailString(s); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Firewall state and rule summary
+	if info.Firewall != nil {
+		statusColor := color.New(color.FgGreen)
+		if !info.Firewall.Active {
+			statusColor = color.New(color.FgYellow)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ FIREWALL ───────────────────────────────────────────────────┐\n"))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Status:"), statusColor.Sprint(firewallStatusString(info.Firewall))))
+		for _, f := range info.Firewall.Firewalls {
+			sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint(f.Name+":"), valueColor.Sprint(firewallDetailString(f))))
+			for _, z := range f.Zones {
+				sb.WriteString(fmt.Sprintf("│   %-18s %s\n", labelColor.Sprint(z.Name+":"), firewallZoneString(z)))
+			}
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Logged-in users and local accounts
+	if info.Users != nil {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ USERS ──────────────────────────────────────────────────────┐\n"))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Local accounts:"), valueColor.Sprint(accountCountString(info.Users))))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Sessions:"), valueColor.Sprint(len(info.Users.Sessions))))
+		for _, s := range info.Users.Sessions {
+			line := fmt.Sprintf("│ %-20s", labelColor.Sprint(s.User))
+			if detail := sessionDetailString(s); detail != "" {
+				line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", detail)
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Installed software inventory; the packages themselves are listed by the text, html,
+	// csv and json formats, as thousands of rows would swamp the terminal
+	if info.Packages != nil && len(info.Packages.Packages) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ INSTALLED PACKAGES ─────────────────────────────────────────┐\n"))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Packages:"), valueColor.Sprint(len(info.Packages.Packages))))
+		sb.WriteString(fmt.Sprintf("│ %-20s %s\n", labelColor.Sprint("Managers:"), valueColor.Sprint(packageCountString(info.Packages))))
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Checksums of critical files
+	if info.Integrity != nil && len(info.Integrity.Files) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ FILE INTEGRITY ─────────────────────────────────────────────┐\n"))
+		for _, f := range info.Integrity.Files {
+			if f.SHA256 == "" {
+				sb.WriteString(fmt.Sprintf("│ %-20s %s\n", color.New(color.FgYellow).Sprint(f.Error), valueColor.Sprint(f.Path)))
+				continue
+			}
+			// The first 16 hex digits tell copies apart at a glance; json has the whole hash
+			line := fmt.Sprintf("│ %-20s %s", labelColor.Sprint(f.SHA256[:min(16, len(f.SHA256))]), valueColor.Sprint(f.Path))
+			line += " " + color.New(color.FgHiBlack).Sprintf("(%s)", checksumDetailString(f))
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Thermal zones and trip points
+	if info.Thermal != nil && len(info.Thermal.Sensors) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ THERMAL ────────────────────────────────────────────────────┐\n"))
+		for _, sensor := range info.Thermal.Sensors {
+			tempColor := color.New(color.FgGreen)
+			if headroom, ok := thermalHeadroom(sensor); ok {
+				if headroom <= 0 {
+					tempColor = color.New(color.FgRed)
+				} else if headroom <= thermalMargin {
+					tempColor = color.New(color.FgYellow)
+				}
+			}
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint(thermalSensorLabel(sensor)+":"), tempColor.Sprint(thermalSensorValue(sensor))))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Cooling policy, fans and their noise
+	if info.Thermal != nil && hasCooling(info.Thermal) {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ COOLING ────────────────────────────────────────────────────┐\n"))
+		if info.Thermal.CoolingPolicyAC != "" || info.Thermal.CoolingPolicyDC != "" {
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint("Cooling Policy:"), valueColor.Sprint(coolingPolicyString(info.Thermal))))
+		}
+		for _, fan := range info.Thermal.Fans {
+			fanColor := color.New(color.FgGreen)
+			switch fanStatus(fan) {
+			case fanFault:
+				fanColor = color.New(color.FgRed)
+			case fanHigh:
+				fanColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint("Fan "+fan.Name+":"), fanColor.Sprint(fanString(fan, info.Thermal.Noise))))
+		}
+		if noise := info.Thermal.Noise; noise != nil {
+			noiseColor := valueColor
+			if noise.Trend == "increasing" {
+				noiseColor = color.New(color.FgYellow)
+			}
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint("Estimated Noise:"), noiseColor.Sprint(noiseString(noise))))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Hardware monitoring sensors
+	if info.Sensors != nil && len(info.Sensors.Temperatures) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ SENSORS ────────────────────────────────────────────────────┐\n"))
+		for _, sensor := range info.Sensors.Temperatures {
+			tempColor := color.New(color.FgGreen)
+			if headroom, ok := sensorHeadroom(sensor); ok {
+				if headroom <= 0 {
+					tempColor = color.New(color.FgRed)
+				} else if headroom <= thermalMargin {
+					tempColor = color.New(color.FgYellow)
+				}
+			}
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint(sensor.Chip+" "+sensor.Label+":"), tempColor.Sprint(sensorValue(sensor))))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Security posture
+	if info.Security != nil {
+		if items := securityItems(info.Security); len(items) > 0 {
+			sb.WriteString("\n")
+			sb.WriteString(headerColor.Sprintf("┌─ SECURITY ───────────────────────────────────────────────────┐\n"))
+			for _, item := range items {
+				statusColor := color.New(color.FgGreen)
+				if !item.OK {
+					statusColor = color.New(color.FgYellow)
+				}
+				sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint(item.Label+":"), statusColor.Sprint(item.Value)))
+			}
+			sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+		}
+	}
+
+	// What to do about the problems found
+	if len(info.Recommendations) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ RECOMMENDATIONS ────────────────────────────────────────────┐\n"))
+		for _, r := range info.Recommendations {
+			severityColor := color.New(color.FgYellow)
+			if r.Severity == "CRITICAL" {
+				severityColor = color.New(color.FgRed, color.Bold)
+			}
+			sb.WriteString(fmt.Sprintf("│ %s %s\n", severityColor.Sprintf("%-8s", r.Severity), r.Action))
+		}
+		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
+	}
+
+	// Modules left out of the report
+	if len(info.Errors) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(headerColor.Sprintf("┌─ ERRORS ─────────────────────────────────────────────────────┐\n"))
+		skipColor := color.New(color.FgYellow)
+		for _, e := range info.Errors {
+			sb.WriteString(fmt.Sprintf("│ %-38s %s\n", labelColor.Sprint(e.Module+":"), skipColor.Sprint(e.Error)))
+		}
 		sb.WriteString(headerColor.Sprintf("└──────────────────────────────────────────────────────────────┘\n"))
 	}
 
@@ -574,12 +1124,25 @@ func createProgressBar(percent float64, width int) string {
 	return color.New(color.FgGreen).Sprint(bar)
 }
 
-// truncate truncates a string to the specified length
-func truncate(s string, length int) string {
-	if len(s) <= length {
-		return s
+// writePrettyProcessDetails writes the optional command line and environment of a process
+func writePrettyProcessDetails(sb *strings.Builder, proc types.ProcessInfo) {
+	if proc.Service != "" {
+		sb.WriteString(fmt.Sprintf("│     %s\n", color.New(color.Faint).Sprint(truncate(proc.Service, 56))))
+	}
+	if proc.Container != nil {
+		sb.WriteString(fmt.Sprintf("│     %s\n", color.New(color.Faint).Sprint(truncate(containerLabel(*proc.Container), 56))))
+	}
+	if proc.Cmdline != "" {
+		sb.WriteString(fmt.Sprintf("│     %s\n", color.New(color.Faint).Sprint(truncate(proc.Cmdline, 56))))
+	}
+	if len(proc.Env) > 0 {
+		sb.WriteString(fmt.Sprintf("│     %s\n", color.New(color.Faint).Sprint(truncate(processEnvString(proc.Env), 56))))
 	}
-	return s[:length-3] + "..."
+}
+
+// truncate truncates a string to the specified number of characters
+func truncate(s string, length int) string {
+	return utils.Truncate(s, length)
 }
 
 // formatTime formats minutes into a human-readable time string
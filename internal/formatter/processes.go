@@ -0,0 +1,36 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// zombieString counts the zombies and names the parents leaving the most, e.g. "12 (8 from php-fpm)"
+func zombieString(processes *types.ProcessData) string {
+	s := fmt.Sprintf("%d", processes.Zombies)
+	var parents []string
+	for i, parent := range processes.ZombieParents {
+		if i >= 3 {
+			break
+		}
+		name := parent.Name
+		if name == "" {
+			name = fmt.Sprintf("PID %d", parent.PID)
+		}
+		parents = append(parents, fmt.Sprintf("%d from %s", parent.Zombies, name))
+	}
+	if len(parents) > 0 {
+		s += " (" + strings.Join(parents, ", ") + ")"
+	}
+	return s
+}
+
+// openFilesString is a process's open file descriptors, with its limit where known
+func openFilesString(proc types.ProcessInfo) string {
+	if proc.OpenFilesLimit > 0 {
+		return fmt.Sprintf("%d of %d open files", proc.OpenFiles, proc.OpenFilesLimit)
+	}
+	return fmt.Sprintf("%d open files", proc.OpenFiles)
+}
@@ -0,0 +1,191 @@
+package formatter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// promSample is one labelled value of a metric
+type promSample struct {
+	labels []string // Alternating names and values
+	value  float64
+}
+
+// promFamily is a gauge and all of its samples; the exposition format requires them to be contiguous
+type promFamily struct {
+	name    string
+	help    string
+	samples []promSample
+}
+
+// FormatPrometheus formats the information in the Prometheus text exposition format,
+// e.g. for the node_exporter textfile collector. Every metric is a gauge prefixed sysinfo_
+func FormatPrometheus(info *types.SystemInfo) string {
+	var sb strings.Builder
+	for _, family := range promFamilies(info) {
+		if len(family.samples) == 0 {
+			continue
+		}
+		sb.WriteString("# HELP " + family.name + " " + family.help + "\n")
+		sb.WriteString("# TYPE " + family.name + " gauge\n")
+		for _, sample := range family.samples {
+			sb.WriteString(family.name)
+			writePromLabels(&sb, sample.labels)
+			sb.WriteString(" " + strconv.FormatFloat(sample.value, 'f', -1, 64) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// promFamilies flattens the report into its metrics, shared by the Prometheus and Parquet formats
+func promFamilies(info *types.SystemInfo) []promFamily {
+	var families []promFamily
+	add := func(name, help string, samples ...promSample) {
+		families = append(families, promFamily{name: "sysinfo_" + name, help: help, samples: samples})
+	}
+
+	if !info.Timestamp.IsZero() {
+		add("collection_timestamp_seconds", "Time the report was collected, in seconds since the epoch",
+			promSample{value: float64(info.Timestamp.Unix())})
+	}
+
+	if cpu := info.CPU; cpu != nil {
+		usage := make([]promSample, 0, len(cpu.Usage))
+		for i, percent := range cpu.Usage {
+			usage = append(usage, promSample{labels: []string{"cpu", strconv.Itoa(i)}, value: percent})
+		}
+		add("cpu_usage_percent", "CPU utilization in percent", usage...)
+		classes := make([]promSample, 0, len(cpu.CoreClasses))
+		for _, class := range cpu.CoreClasses {
+			classes = append(classes, promSample{labels: []string{"class", class.Class}, value: class.Usage})
+		}
+		add("cpu_class_usage_percent", "Mean utilization of a hybrid CPU's performance or efficiency cores in percent", classes...)
+		if cpu.LoadAvg != nil {
+			add("load_average", "System load average",
+				promSample{labels: []string{"period", "1m"}, value: cpu.LoadAvg.Load1},
+				promSample{labels: []string{"period", "5m"}, value: cpu.LoadAvg.Load5},
+				promSample{labels: []string{"period", "15m"}, value: cpu.LoadAvg.Load15})
+		}
+	}
+
+	if mem := info.Memory; mem != nil {
+		add("memory_total_bytes", "Total physical memory", promSample{value: float64(mem.Total)})
+		add("memory_used_bytes", "Used physical memory", promSample{value: float64(mem.Used)})
+		add("memory_available_bytes", "Memory available to new processes", promSample{value: float64(mem.Available)})
+		add("memory_used_percent", "Used physical memory in percent", promSample{value: mem.UsedPercent})
+		add("swap_total_bytes", "Total swap space", promSample{value: float64(mem.SwapTotal)})
+		add("swap_used_bytes", "Used swap space", promSample{value: float64(mem.SwapUsed)})
+	}
+
+	if disk := info.Disk; disk != nil {
+		var size, used, free, percent []promSample
+		for _, p := range disk.Partitions {
+			labels := []string{"device", p.Device, "mountpoint", p.MountPoint, "fstype", p.FSType}
+			size = append(size, promSample{labels: labels, value: float64(p.Total)})
+			used = append(used, promSample{labels: labels, value: float64(p.Used)})
+			free = append(free, promSample{labels: labels, value: float64(p.Free)})
+			percent = append(percent, promSample{labels: labels, value: p.UsedPercent})
+		}
+		add("filesystem_size_bytes", "Filesystem size", size...)
+		add("filesystem_used_bytes", "Used filesystem space", used...)
+		add("filesystem_free_bytes", "Free filesystem space", free...)
+		add("filesystem_used_percent", "Used filesystem space in percent", percent...)
+
+		var healthy, temperature, hours []promSample
+		for _, smart := range disk.SMARTData {
+			labels := []string{"device", smart.Device, "model", smart.DeviceModel, "serial", smart.Serial}
+			healthy = append(healthy, promSample{labels: labels, value: promBool(smart.Healthy)})
+			if smart.Temperature != 0 {
+				temperature = append(temperature, promSample{labels: labels, value: float64(smart.Temperature)})
+			}
+			if smart.PowerOnHours != 0 {
+				hours = append(hours, promSample{labels: labels, value: float64(smart.PowerOnHours)})
+			}
+		}
+		add("smart_healthy", "1 if the drive passes its SMART health assessment", healthy...)
+		add("smart_temperature_celsius", "Drive temperature reported by SMART", temperature...)
+		add("smart_power_on_hours", "Drive power-on hours reported by SMART", hours...)
+	}
+
+	if gpu := info.GPU; gpu != nil {
+		var utilization, memUsed, memTotal, temperature, power []promSample
+		for _, g := range gpu.GPUs {
+			labels := []string{"gpu", strconv.Itoa(g.Index), "name", g.Name}
+			utilization = append(utilization, promSample{labels: labels, value: float64(g.Utilization)})
+			if g.MemoryTotal != 0 {
+				memTotal = append(memTotal, promSample{labels: labels, value: float64(g.MemoryTotal)})
+				memUsed = append(memUsed, promSample{labels: labels, value: float64(g.MemoryUsed)})
+			}
+			if g.Temperature != 0 {
+				temperature = append(temperature, promSample{labels: labels, value: float64(g.Temperature)})
+			}
+			if g.PowerDraw != 0 {
+				power = append(power, promSample{labels: labels, value: g.PowerDraw})
+			}
+		}
+		add("gpu_utilization_percent", "GPU utilization in percent", utilization...)
+		add("gpu_memory_total_bytes", "GPU memory size", memTotal...)
+		add("gpu_memory_used_bytes", "Used GPU memory", memUsed...)
+		add("gpu_temperature_celsius", "GPU temperature", temperature...)
+		add("gpu_power_draw_watts", "GPU power draw", power...)
+	}
+
+	if sensors := info.Sensors; sensors != nil {
+		var temperature, max, critical []promSample
+		for _, s := range sensors.Temperatures {
+			labels := []string{"chip", s.Chip, "label", s.Label}
+			temperature = append(temperature, promSample{labels: labels, value: s.Temperature})
+			if s.Max != 0 {
+				max = append(max, promSample{labels: labels, value: s.Max})
+			}
+			if s.Critical != 0 {
+				critical = append(critical, promSample{labels: labels, value: s.Critical})
+			}
+		}
+		add("sensor_temperature_celsius", "Temperature of a hardware monitoring sensor", temperature...)
+		add("sensor_temperature_max_celsius", "High limit of a hardware monitoring sensor", max...)
+		add("sensor_temperature_critical_celsius", "Critical limit of a hardware monitoring sensor", critical...)
+	}
+
+	if health := info.Health; health != nil {
+		add("host_health_score", "Composite host health score from 0 (failing) to 100 (healthy)",
+			promSample{value: health.Score})
+		components := make([]promSample, 0, len(health.Components))
+		for _, c := range health.Components {
+			components = append(components, promSample{labels: []string{"component", c.Name}, value: c.Score})
+		}
+		add("host_health_component_score", "Host health score of one component from 0 to 100", components...)
+	}
+	return families
+}
+
+// writePromLabels writes {name="value",...}, leaving out empty values
+func writePromLabels(sb *strings.Builder, labels []string) {
+	first := true
+	for i := 0; i+1 < len(labels); i += 2 {
+		if labels[i+1] == "" {
+			continue
+		}
+		if first {
+			sb.WriteString("{")
+			first = false
+		} else {
+			sb.WriteString(",")
+		}
+		sb.WriteString(labels[i] + `="` + promLabelEscaper.Replace(labels[i+1]) + `"`)
+	}
+	if !first {
+		sb.WriteString("}")
+	}
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promBool(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
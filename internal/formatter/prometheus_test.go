@@ -0,0 +1,86 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatPrometheus(t *testing.T) {
+	out, err := Format(createTestSystemInfo(), &config.Config{Format: "prometheus"})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# TYPE sysinfo_cpu_usage_percent gauge\n",
+		"sysinfo_cpu_usage_percent{cpu=\"1\"} 20.3\n",
+		"sysinfo_load_average{period=\"15m\"} 0.9\n",
+		"sysinfo_memory_used_percent 50\n",
+		"sysinfo_filesystem_size_bytes{device=\"/dev/sda1\",mountpoint=\"/\",fstype=\"ext4\"} 536870912000\n",
+		"sysinfo_smart_temperature_celsius{device=\"/dev/sda\",model=\"Samsung SSD 970 EVO\"} 35\n",
+		"sysinfo_smart_healthy{device=\"/dev/sda\",model=\"Samsung SSD 970 EVO\"} 1\n",
+		"sysinfo_gpu_utilization_percent{gpu=\"0\",name=\"NVIDIA GeForce RTX 4070\"} 75\n",
+		"sysinfo_collection_timestamp_seconds 1762257600\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+
+	// Each family's samples follow its TYPE line without interruption
+	seen := map[string]bool{}
+	current := ""
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			current = strings.Fields(line)[2]
+			if seen[current] {
+				t.Errorf("family %s declared twice", current)
+			}
+			seen[current] = true
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := strings.FieldsFunc(line, func(r rune) bool { return r == '{' || r == ' ' })[0]
+		if name != current {
+			t.Errorf("sample %q outside its family %s", line, current)
+		}
+	}
+}
+
+func TestFormatPrometheusHealth(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Health = &types.HostHealth{Score: 87.5, Components: []types.HealthComponent{
+		{Name: "disk", Score: 62.5, Weight: 2},
+		{Name: "memory", Score: 100, Weight: 1},
+	}}
+	out := FormatPrometheus(info)
+	for _, want := range []string{
+		"# TYPE sysinfo_host_health_score gauge\n",
+		"sysinfo_host_health_score 87.5\n",
+		"sysinfo_host_health_component_score{component=\"disk\"} 62.5\n",
+		"sysinfo_host_health_component_score{component=\"memory\"} 100\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestFormatPrometheusEscapesLabels(t *testing.T) {
+	info := &types.SystemInfo{Disk: &types.DiskData{Partitions: []types.PartitionInfo{
+		{Device: `C:\`, MountPoint: `C:\`, FSType: "NTFS", Total: 100},
+	}}}
+
+	out := FormatPrometheus(info)
+	if !strings.Contains(out, `sysinfo_filesystem_size_bytes{device="C:\\",mountpoint="C:\\",fstype="NTFS"} 100`) {
+		t.Errorf("labels not escaped:\n%s", out)
+	}
+	if strings.Contains(out, "sysinfo_smart_") || strings.Contains(out, "sysinfo_collection_timestamp_seconds") {
+		t.Errorf("families without samples should be omitted:\n%s", out)
+	}
+}
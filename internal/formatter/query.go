@@ -0,0 +1,226 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// querySegment is one step of a query path: a field, an array index or iteration
+type querySegment struct {
+	field   string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+// Query is a parsed jq-style path such as .disk.smart_data[].temperature_celsius
+type Query []querySegment
+
+// ParseQuery parses a jq-style path: "." is the whole report, .name or ."name" or ["name"]
+// selects a field, [N] an array element (negative counts from the end) and [] every element
+func ParseQuery(expr string) (Query, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("invalid query %q: must start with '.'", expr)
+	}
+
+	var query Query
+	rest := expr
+	if rest == "." {
+		return query, nil
+	}
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".["):
+			rest = rest[1:]
+		case strings.HasPrefix(rest, `."`):
+			field, n, err := unquoteQueryString(rest[1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+			}
+			query = append(query, querySegment{field: field})
+			rest = rest[1+n:]
+		case rest[0] == '.':
+			n := 1
+			for n < len(rest) && isQueryIdentChar(rest[n]) {
+				n++
+			}
+			if n == 1 {
+				return nil, fmt.Errorf("invalid query %q: expected a field name after '.'", expr)
+			}
+			query = append(query, querySegment{field: rest[1:n]})
+			rest = rest[n:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if strings.HasPrefix(rest, `["`) {
+				field, n, err := unquoteQueryString(rest[1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+				}
+				if !strings.HasPrefix(rest[1+n:], "]") {
+					return nil, fmt.Errorf("invalid query %q: expected ']'", expr)
+				}
+				query = append(query, querySegment{field: field})
+				rest = rest[2+n:]
+				continue
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing ']'", expr)
+			}
+			inside := strings.TrimSpace(rest[1:end])
+			if inside == "" {
+				query = append(query, querySegment{iterate: true})
+			} else {
+				index, err := strconv.Atoi(inside)
+				if err != nil {
+					return nil, fmt.Errorf("invalid query %q: bad index %q", expr, inside)
+				}
+				query = append(query, querySegment{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid query %q: unexpected %q", expr, rest)
+		}
+	}
+	return query, nil
+}
+
+func isQueryIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// unquoteQueryString reads the JSON string literal at the start of s, returning it and its length
+func unquoteQueryString(s string) (string, int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			var field string
+			if err := json.Unmarshal([]byte(s[:i+1]), &field); err != nil {
+				return "", 0, fmt.Errorf("bad string %s", s[:i+1])
+			}
+			return field, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// Evaluate applies the query to a decoded JSON value. As in jq, a field of null is null,
+// while iterating null yields nothing, so a query over a module that was not collected is empty
+func (q Query) Evaluate(value interface{}) ([]interface{}, error) {
+	values := []interface{}{value}
+	for _, segment := range q {
+		var next []interface{}
+		for _, v := range values {
+			switch {
+			case segment.iterate:
+				switch v := v.(type) {
+				case nil:
+				case []interface{}:
+					next = append(next, v...)
+				case map[string]interface{}:
+					// Object values in key order, so results are stable between runs
+					keys := make([]string, 0, len(v))
+					for key := range v {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, v[key])
+					}
+				default:
+					return nil, fmt.Errorf("cannot iterate over %s", queryType(v))
+				}
+			case segment.isIndex:
+				switch v := v.(type) {
+				case nil:
+					next = append(next, nil)
+				case []interface{}:
+					i := segment.index
+					if i < 0 {
+						i += len(v)
+					}
+					if i >= 0 && i < len(v) {
+						next = append(next, v[i])
+					} else {
+						next = append(next, nil)
+					}
+				default:
+					return nil, fmt.Errorf("cannot index %s with a number", queryType(v))
+				}
+			default:
+				switch v := v.(type) {
+				case nil:
+					next = append(next, nil)
+				case map[string]interface{}:
+					next = append(next, v[segment.field])
+				default:
+					return nil, fmt.Errorf("cannot index %s with %q", queryType(v), segment.field)
+				}
+			}
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func queryType(v interface{}) string {
+	switch v.(type) {
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "number"
+	}
+}
+
+// FormatQuery evaluates a query against the JSON report, one result per line: strings are
+// written raw, like jq -r, so scripts can use them directly, and anything else as compact JSON
+func FormatQuery(info *types.SystemInfo, expr string) (string, error) {
+	query, err := ParseQuery(expr)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var report interface{}
+	if err := decoder.Decode(&report); err != nil {
+		return "", fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	results, err := query.Evaluate(report)
+	if err != nil {
+		return "", fmt.Errorf("query %s: %w", expr, err)
+	}
+	var buf strings.Builder
+	for _, result := range results {
+		if s, ok := result.(string); ok {
+			buf.WriteString(s)
+		} else {
+			line, err := json.Marshal(result)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			buf.Write(line)
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String(), nil
+}
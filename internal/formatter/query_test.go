@@ -0,0 +1,58 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatQuery(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Disk.SMARTData = []types.SMARTInfo{
+		{Device: "/dev/sda", Temperature: 35, Attributes: map[string]string{"Power On Hours": "1200"}},
+		{Device: "/dev/sdb", Temperature: 41},
+	}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{".system.hostname", "test-host\n"},
+		{".disk.smart_data[].temperature_celsius", "35\n41\n"},
+		{".disk.smart_data[-1].device", "/dev/sdb\n"},
+		{`.disk.smart_data[0].attributes["Power On Hours"]`, "1200\n"},
+		{`.disk.smart_data[0].attributes."Power On Hours"`, "1200\n"},
+		{".disk.smart_data[5]", "null\n"},
+		{".disk.smart_data[0].attributes", "{\"Power On Hours\":\"1200\"}\n"},
+		{".battery.batteries[]", ""}, // Not collected
+		{".battery.batteries[0]", "null\n"},
+	}
+	for _, tt := range tests {
+		output, err := Format(info, &config.Config{Format: "pretty", Query: tt.query})
+		if err != nil {
+			t.Errorf("query %s failed: %v", tt.query, err)
+			continue
+		}
+		if output != tt.expected {
+			t.Errorf("query %s = %q, expected %q", tt.query, output, tt.expected)
+		}
+	}
+
+	if _, err := FormatQuery(info, ".system.hostname[]"); err == nil {
+		t.Error("expected iterating over a string to fail")
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	for _, valid := range []string{".", ".cpu", ".cpu.usage_percent[]", ".[\"cpu\"]", ".disk.partitions[0].mount_point", " .cpu "} {
+		if _, err := ParseQuery(valid); err != nil {
+			t.Errorf("ParseQuery(%q) failed: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "cpu", ".cpu.", "..cpu", ".cpu[", ".cpu[x]", `.cpu["model`, ".cpu | length"} {
+		if _, err := ParseQuery(invalid); err == nil {
+			t.Errorf("ParseQuery(%q) succeeded, expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// schemaDialect is the JSON Schema draft the exported schema follows
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FormatSchema describes the JSON report as a JSON Schema, generated from the types package
+// so it cannot drift from what the json format writes. Fields without omitempty are required;
+// those that encode nil as null (pointers, slices and maps) also allow null
+func FormatSchema() (string, error) {
+	g := &schemaGenerator{defs: map[string]map[string]any{}}
+	root := g.structSchema(reflect.TypeOf(types.SystemInfo{}))
+
+	// SystemInfo.MarshalJSON writes the timestamp per --timestamp-format: RFC 3339 by
+	// default, Unix seconds with unix, or not at all with none
+	properties := root["properties"].(map[string]any)
+	properties["timestamp"] = map[string]any{
+		"anyOf": []any{
+			map[string]any{"type": "string", "format": "date-time"},
+			map[string]any{"type": "integer"},
+		},
+	}
+	names, _ := root["required"].([]string)
+	var required []string
+	for _, name := range names {
+		if name != "timestamp" {
+			required = append(required, name)
+		}
+	}
+	if len(required) > 0 {
+		root["required"] = required
+	} else {
+		delete(root, "required")
+	}
+
+	root["$schema"] = schemaDialect
+	root["title"] = "SysInfo report"
+	root["description"] = "A report written by sysinfo --format json"
+	root["$defs"] = g.defs
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// schemaGenerator collects a definition per named struct type, so shared types such as
+// SMARTAttribute are described once and referenced
+type schemaGenerator struct {
+	defs map[string]map[string]any
+}
+
+// typeSchema describes how encoding/json writes a value of type t
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserve the name so recursive types terminate
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{} // Interfaces hold any value
+	}
+}
+
+// structSchema describes a struct's JSON object, with the fields of embedded structs
+// promoted as encoding/json does
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, field := range jsonFields(t) {
+		schema := g.typeSchema(field.typ)
+		if !field.omitEmpty {
+			required = append(required, field.name)
+			switch field.typ.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map:
+				schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+			}
+		}
+		properties[field.name] = schema
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonField is a struct field as encoding/json writes it
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields lists the fields encoding/json writes for a struct; a field declared directly
+// shadows one of the same name promoted from an embedded struct
+func jsonFields(t reflect.Type) []jsonField {
+	var fields, promoted []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		embedded := f.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			promoted = append(promoted, jsonFields(embedded)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		omitEmpty := false
+		for _, option := range strings.Split(options, ",") {
+			omitEmpty = omitEmpty || option == "omitempty" || option == "omitzero"
+		}
+		fields = append(fields, jsonField{name: name, typ: f.Type, omitEmpty: omitEmpty})
+	}
+
+	for _, p := range promoted {
+		shadowed := false
+		for _, f := range fields {
+			shadowed = shadowed || f.name == p.name
+		}
+		if !shadowed {
+			fields = append(fields, p)
+		}
+	}
+	return fields
+}
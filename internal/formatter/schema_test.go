@@ -0,0 +1,136 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// validateSchema checks a decoded JSON value against the subset of JSON Schema FormatSchema emits
+func validateSchema(root, schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")]
+		return validateSchema(root, def.(map[string]any), value, path)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, option := range anyOf {
+			if validateSchema(root, option.(map[string]any), value, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %v matches no alternative", path, value)
+	}
+
+	switch schema["type"] {
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s: expected null", path)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected a string, got %v", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean, got %v", path, value)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema["type"] == "integer" && n != math.Trunc(n)) {
+			return fmt.Errorf("%s: expected an %s, got %v", path, schema["type"], value)
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && n < minimum {
+			return fmt.Errorf("%s: %v is below %v", path, n, minimum)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %v", path, value)
+		}
+		for i, item := range items {
+			if err := validateSchema(root, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %v", path, value)
+		}
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required %s", path, name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for key, v := range object {
+			propertySchema, ok := properties[key].(map[string]any)
+			if !ok {
+				additional, ok := schema["additionalProperties"].(map[string]any)
+				if !ok {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+				propertySchema = additional
+			}
+			if err := validateSchema(root, propertySchema, v, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestFormatSchema(t *testing.T) {
+	output, err := FormatSchema()
+	if err != nil {
+		t.Fatalf("FormatSchema failed: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(output), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema["$schema"] != schemaDialect {
+		t.Errorf("$schema = %v", schema["$schema"])
+	}
+
+	defs := schema["$defs"].(map[string]any)
+	system := defs["SystemData"].(map[string]any)
+	if required := fmt.Sprint(system["required"]); !strings.Contains(required, "hostname") {
+		t.Errorf("SystemData required = %s, expected hostname", required)
+	}
+	// Promoted from the embedded ContainerRef
+	usage := defs["ContainerUsage"].(map[string]any)["properties"].(map[string]any)
+	if _, ok := usage["runtime"]; !ok {
+		t.Errorf("ContainerUsage properties = %v, expected the embedded runtime", usage)
+	}
+
+	info := createTestSystemInfo()
+	info.Disk.SMARTData[0].Attributes = map[string]string{"Critical Warning": "0x00"}
+	for _, timestampFormat := range []string{"", utils.TimestampUnix, utils.TimestampNone} {
+		info.TimestampFormat = timestampFormat
+		report, err := json.Marshal(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value any
+		json.Unmarshal(report, &value)
+		if err := validateSchema(schema, schema, value, "$"); err != nil {
+			t.Errorf("report with timestamp format %q does not validate: %v", timestampFormat, err)
+		}
+	}
+
+	// A field the types package does not know is rejected
+	var value map[string]any
+	report, _ := json.Marshal(&types.SystemInfo{System: &types.SystemData{Hostname: "h"}})
+	json.Unmarshal(report, &value)
+	value["system"].(map[string]any)["hostnme"] = "typo"
+	if err := validateSchema(schema, schema, value, "$"); err == nil {
+		t.Error("expected an unknown property to fail validation")
+	}
+}
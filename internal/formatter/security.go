@@ -0,0 +1,103 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// securityItem is a single line of the security section
+type securityItem struct {
+	Label string
+	Value string
+	OK    bool // Value is the compliant/expected state
+}
+
+// securityItems flattens security posture into labelled lines shared by the text and pretty formatters
+func securityItems(sec *types.SecurityData) []securityItem {
+	var items []securityItem
+
+	if sec.SIP != "" {
+		items = append(items, securityItem{"System Integrity Protection", sec.SIP, sec.SIP == "enabled"})
+	}
+	if sec.Gatekeeper != "" {
+		items = append(items, securityItem{"Gatekeeper", sec.Gatekeeper, sec.Gatekeeper == "enabled"})
+	}
+	if sec.FileVault != "" {
+		items = append(items, securityItem{"FileVault", sec.FileVault, sec.FileVault == "on"})
+	}
+	if sec.MDM != nil {
+		value := "not enrolled"
+		if sec.MDM.Enrolled {
+			value = "enrolled"
+			if sec.MDM.DEP {
+				value += " (DEP)"
+			} else if sec.MDM.UserApproved {
+				value += " (user approved)"
+			}
+		}
+		items = append(items, securityItem{"MDM", value, sec.MDM.Enrolled})
+	}
+	if sec.SELinux != nil {
+		value := sec.SELinux.Mode
+		if sec.SELinux.Policy != "" {
+			value += fmt.Sprintf(" (policy: %s)", sec.SELinux.Policy)
+		}
+		if sec.SELinux.ConfigMode != "" && sec.SELinux.ConfigMode != sec.SELinux.Mode {
+			value += fmt.Sprintf(", %s at boot", sec.SELinux.ConfigMode)
+		}
+		items = append(items, securityItem{"SELinux", value, sec.SELinux.Mode == "enforcing"})
+	}
+	if sec.AppArmor != nil {
+		value := "disabled"
+		if sec.AppArmor.Enabled {
+			value = "enabled"
+			if sec.AppArmor.Profiles > 0 {
+				value += fmt.Sprintf(" (%d profiles: %d enforce, %d complain)", sec.AppArmor.Profiles, sec.AppArmor.Enforce, sec.AppArmor.Complain)
+			}
+		}
+		items = append(items, securityItem{"AppArmor", value, sec.AppArmor.Enabled && sec.AppArmor.Complain == 0})
+	}
+	if store := sec.TrustStore; store != nil {
+		items = append(items, securityItem{"CA trust store", trustStoreString(store), len(store.Local) == 0})
+		for _, ca := range store.Local {
+			items = append(items, securityItem{"Locally added CA", caString(ca, true), false})
+		}
+		for _, ca := range store.ExpiringSoon {
+			items = append(items, securityItem{"Expiring CA", caString(ca, false), false})
+		}
+	}
+
+	return items
+}
+
+// trustStoreString summarizes a trust store, e.g. "146 CAs, 2 expiring within 90 days, 1 locally added"
+func trustStoreString(store *types.TrustStore) string {
+	value := fmt.Sprintf("%d CAs", store.Total)
+	if store.Expired > 0 {
+		value += fmt.Sprintf(", %d expired", store.Expired)
+	}
+	if len(store.ExpiringSoon) > 0 {
+		value += fmt.Sprintf(", %d expiring within 90 days", len(store.ExpiringSoon))
+	}
+	if len(store.Local) > 0 {
+		value += fmt.Sprintf(", %d locally added", len(store.Local))
+	}
+	if store.Source != "" {
+		value += fmt.Sprintf(" (%s)", store.Source)
+	}
+	return value
+}
+
+// caString describes a trusted CA by subject and expiry, with where it was added when withSource is set
+func caString(ca types.CACertificate, withSource bool) string {
+	details := []string{"expires " + ca.NotAfter.Format("2006-01-02")}
+	if ca.Issuer != "" {
+		details = append([]string{"issued by " + ca.Issuer}, details...)
+	}
+	if withSource && ca.Source != "" {
+		details = append(details, ca.Source)
+	}
+	return fmt.Sprintf("%s (%s)", ca.Subject, strings.Join(details, ", "))
+}
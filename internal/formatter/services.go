@@ -0,0 +1,26 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// serviceCountString summarizes the service counts, e.g. "212 total, 180 running, 1 failed"
+func serviceCountString(s *types.ServiceData) string {
+	return fmt.Sprintf("%d total, %d running, %d failed", s.Total, s.Running, s.Failed)
+}
+
+// serviceDetailString lists a failed service's description and why it failed, e.g.
+// "Nightly backup, exit-code 2"
+func serviceDetailString(s types.ServiceStatus) string {
+	var details []string
+	if s.Description != "" && s.Description != s.Name {
+		details = append(details, s.Description)
+	}
+	if s.Reason != "" {
+		details = append(details, s.Reason)
+	}
+	return strings.Join(details, ", ")
+}
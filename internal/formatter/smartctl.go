@@ -0,0 +1,429 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smartctlVersion is the smartctl release whose JSON output the smartctl format follows
+var smartctlVersion = []int{7, 4}
+
+// smartctl exit status bits that tools such as Scrutiny read to judge a drive
+const (
+	smartctlExitFailing     = 1 << 3 // SMART status check returned "DISK FAILING"
+	smartctlExitPrefailNow  = 1 << 4 // Attributes at or below threshold
+	smartctlExitFailedPast  = 1 << 5 // Attributes were at or below threshold in the past
+	smartctlExitErrorLog    = 1 << 6 // The error log contains errors
+	smartctlExitSelfTestLog = 1 << 7 // The self-test log contains errors
+)
+
+// smartctlDocument is one device in the layout of `smartctl -a -j`
+type smartctlDocument struct {
+	JSONFormatVersion []int          `json:"json_format_version"`
+	Smartctl          smartctlTool   `json:"smartctl"`
+	Device            smartctlDevice `json:"device"`
+
+	ModelFamily     string            `json:"model_family,omitempty"`
+	ModelName       string            `json:"model_name,omitempty"`
+	SerialNumber    string            `json:"serial_number,omitempty"`
+	FirmwareVersion string            `json:"firmware_version,omitempty"`
+	UserCapacity    *smartctlCapacity `json:"user_capacity,omitempty"`
+	RotationRate    *uint32           `json:"rotation_rate,omitempty"`
+	FormFactor      *smartctlName     `json:"form_factor,omitempty"`
+	SmartStatus     smartctlStatus    `json:"smart_status"`
+
+	AtaSmartAttributes  *smartctlAttributes   `json:"ata_smart_attributes,omitempty"`
+	NvmeHealthLog       *smartctlNvmeLog      `json:"nvme_smart_health_information_log,omitempty"`
+	PowerOnTime         *smartctlPowerOnTime  `json:"power_on_time,omitempty"`
+	PowerCycleCount     uint64                `json:"power_cycle_count,omitempty"`
+	Temperature         *smartctlTemperature  `json:"temperature,omitempty"`
+	AtaSmartErrorLog    *smartctlErrorLog     `json:"ata_smart_error_log,omitempty"`
+	AtaSmartSelfTestLog *smartctlSelfTestLogs `json:"ata_smart_self_test_log,omitempty"`
+}
+
+type smartctlTool struct {
+	Version      []int    `json:"version"`
+	PlatformInfo string   `json:"platform_info"`
+	BuildInfo    string   `json:"build_info"`
+	Argv         []string `json:"argv"`
+	ExitStatus   int      `json:"exit_status"`
+}
+
+type smartctlDevice struct {
+	Name     string `json:"name"`
+	InfoName string `json:"info_name"`
+	Type     string `json:"type"`
+	Protocol string `json:"protocol"`
+}
+
+type smartctlCapacity struct {
+	Blocks uint64 `json:"blocks"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+type smartctlName struct {
+	Name string `json:"name"`
+}
+
+type smartctlStatus struct {
+	Passed bool `json:"passed"`
+}
+
+type smartctlAttributes struct {
+	Revision int                 `json:"revision"`
+	Table    []smartctlAttribute `json:"table"`
+}
+
+type smartctlAttribute struct {
+	ID         uint8         `json:"id"`
+	Name       string        `json:"name"`
+	Value      uint8         `json:"value"`
+	Worst      uint8         `json:"worst"`
+	Thresh     uint8         `json:"thresh"`
+	WhenFailed string        `json:"when_failed"` // "", "now" or "past"
+	Flags      smartctlFlags `json:"flags"`
+	Raw        smartctlRaw   `json:"raw"`
+}
+
+type smartctlFlags struct {
+	Value         uint16 `json:"value"`
+	String        string `json:"string"`
+	Prefailure    bool   `json:"prefailure"`
+	UpdatedOnline bool   `json:"updated_online"`
+	Performance   bool   `json:"performance"`
+	ErrorRate     bool   `json:"error_rate"`
+	EventCount    bool   `json:"event_count"`
+	AutoKeep      bool   `json:"auto_keep"`
+}
+
+type smartctlRaw struct {
+	Value  uint64 `json:"value"`
+	String string `json:"string"`
+}
+
+type smartctlNvmeLog struct {
+	CriticalWarning  uint64 `json:"critical_warning"`
+	Temperature      int    `json:"temperature"`
+	AvailableSpare   uint64 `json:"available_spare"`
+	PercentageUsed   uint64 `json:"percentage_used"`
+	DataUnitsRead    uint64 `json:"data_units_read"`
+	DataUnitsWritten uint64 `json:"data_units_written"`
+	PowerCycles      uint64 `json:"power_cycles"`
+	PowerOnHours     uint64 `json:"power_on_hours"`
+	MediaErrors      uint64 `json:"media_errors"`
+}
+
+type smartctlPowerOnTime struct {
+	Hours uint64 `json:"hours"`
+}
+
+type smartctlTemperature struct {
+	Current int `json:"current"`
+}
+
+type smartctlErrorLog struct {
+	Summary struct {
+		Revision int    `json:"revision"`
+		Count    uint64 `json:"count"`
+	} `json:"summary"`
+}
+
+type smartctlSelfTestLogs struct {
+	Standard smartctlSelfTestLog `json:"standard"`
+}
+
+type smartctlSelfTestLog struct {
+	Revision int                `json:"revision"`
+	Table    []smartctlSelfTest `json:"table"`
+	Count    int                `json:"count"`
+}
+
+type smartctlSelfTest struct {
+	Type          smartctlName           `json:"type"`
+	Status        smartctlSelfTestStatus `json:"status"`
+	LifetimeHours uint64                 `json:"lifetime_hours"`
+	LBA           uint64                 `json:"lba,omitempty"`
+}
+
+type smartctlSelfTestStatus struct {
+	String string `json:"string"`
+	Passed bool   `json:"passed"`
+}
+
+// smartctlScan is the layout of `smartctl --scan -j`
+type smartctlScan struct {
+	JSONFormatVersion []int            `json:"json_format_version"`
+	Smartctl          smartctlTool     `json:"smartctl"`
+	Devices           []smartctlDevice `json:"devices"`
+}
+
+// FormatSmartctl formats one drive as `smartctl -a -j` would print it, so tools built
+// around smartctl can read data sysinfo gathered from other sources such as WMI
+func FormatSmartctl(smart types.SMARTInfo) (string, error) {
+	return marshalSmartctl(smartctlDocumentFor(smart))
+}
+
+// FormatSmartctlList formats every drive as a JSON array of `smartctl -a -j` documents
+func FormatSmartctlList(drives []types.SMARTInfo) (string, error) {
+	documents := make([]smartctlDocument, 0, len(drives))
+	for _, smart := range drives {
+		documents = append(documents, smartctlDocumentFor(smart))
+	}
+	return marshalSmartctl(documents)
+}
+
+// FormatSmartctlScan lists the drives as `smartctl --scan -j` does
+func FormatSmartctlScan(drives []types.SMARTInfo) (string, error) {
+	scan := smartctlScan{
+		JSONFormatVersion: []int{1, 0},
+		Smartctl:          smartctlToolFor([]string{"smartctl", "--scan", "-j"}, 0),
+		Devices:           make([]smartctlDevice, 0, len(drives)),
+	}
+	for _, smart := range drives {
+		scan.Devices = append(scan.Devices, smartctlDeviceFor(smart))
+	}
+	return marshalSmartctl(scan)
+}
+
+func marshalSmartctl(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal smartctl JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+func smartctlToolFor(argv []string, exitStatus int) smartctlTool {
+	return smartctlTool{
+		Version:      smartctlVersion,
+		PlatformInfo: runtime.GOOS + "-" + runtime.GOARCH,
+		BuildInfo:    "(sysinfo)",
+		Argv:         argv,
+		ExitStatus:   exitStatus,
+	}
+}
+
+// smartctlNVMe reports whether a drive is NVMe; Windows names drives by number, so
+// its NVMe drives are recognised by their health log counters instead
+func smartctlNVMe(smart types.SMARTInfo) bool {
+	if strings.Contains(strings.ToLower(smart.Device), "nvme") {
+		return true
+	}
+	_, hasDataUnits := smart.Attributes["Data_Units_Written"]
+	return hasDataUnits && len(smart.DetailedAttribs) == 0
+}
+
+// SmartctlDeviceType returns the smartctl device type (-d) and protocol a drive is exported with
+func SmartctlDeviceType(smart types.SMARTInfo) (deviceType, protocol string) {
+	if smartctlNVMe(smart) {
+		return "nvme", "NVMe"
+	}
+	return "sat", "ATA"
+}
+
+func smartctlDeviceFor(smart types.SMARTInfo) smartctlDevice {
+	deviceType, protocol := SmartctlDeviceType(smart)
+	infoName := smart.Device
+	if deviceType == "sat" {
+		infoName += " [SAT]"
+	}
+	return smartctlDevice{Name: smart.Device, InfoName: infoName, Type: deviceType, Protocol: protocol}
+}
+
+func smartctlDocumentFor(smart types.SMARTInfo) smartctlDocument {
+	doc := smartctlDocument{
+		JSONFormatVersion: []int{1, 0},
+		Device:            smartctlDeviceFor(smart),
+		ModelFamily:       smart.ModelFamily,
+		ModelName:         smart.DeviceModel,
+		SerialNumber:      smart.Serial,
+		FirmwareVersion:   smart.FirmwareVersion,
+		SmartStatus:       smartctlStatus{Passed: smart.Healthy},
+		PowerCycleCount:   smart.PowerCycleCount,
+	}
+	if smart.HealthAssessment != nil {
+		doc.SmartStatus.Passed = smart.HealthAssessment.Passed
+	}
+	if smart.Capacity > 0 {
+		doc.UserCapacity = &smartctlCapacity{Blocks: smart.Capacity / 512, Bytes: smart.Capacity}
+	}
+	if smart.FormFactor != "" {
+		doc.FormFactor = &smartctlName{Name: smart.FormFactor}
+	}
+	if smart.PowerOnHours > 0 {
+		doc.PowerOnTime = &smartctlPowerOnTime{Hours: smart.PowerOnHours}
+	}
+	if smart.Temperature > 0 {
+		doc.Temperature = &smartctlTemperature{Current: smart.Temperature}
+	}
+
+	exitStatus := 0
+	if !doc.SmartStatus.Passed {
+		exitStatus |= smartctlExitFailing
+	}
+
+	if doc.Device.Protocol == "NVMe" {
+		doc.NvmeHealthLog = smartctlNvmeLogFor(smart)
+	} else {
+		rotationRate := smart.RotationRate
+		doc.RotationRate = &rotationRate
+
+		if len(smart.DetailedAttribs) > 0 {
+			doc.AtaSmartAttributes = &smartctlAttributes{Revision: 16, Table: make([]smartctlAttribute, 0, len(smart.DetailedAttribs))}
+			for _, attr := range smart.DetailedAttribs {
+				converted := smartctlAttributeFor(attr)
+				switch converted.WhenFailed {
+				case "now":
+					exitStatus |= smartctlExitPrefailNow
+				case "past":
+					exitStatus |= smartctlExitFailedPast
+				}
+				doc.AtaSmartAttributes.Table = append(doc.AtaSmartAttributes.Table, converted)
+			}
+		}
+		if smart.ErrorLog != nil {
+			doc.AtaSmartErrorLog = &smartctlErrorLog{}
+			doc.AtaSmartErrorLog.Summary.Revision = 1
+			doc.AtaSmartErrorLog.Summary.Count = smart.ErrorLog.ErrorCount
+			if smart.ErrorLog.ErrorCount > 0 {
+				exitStatus |= smartctlExitErrorLog
+			}
+		}
+		if smart.SelfTestLog != nil {
+			doc.AtaSmartSelfTestLog = smartctlSelfTestLogFor(smart.SelfTestLog)
+			for _, test := range doc.AtaSmartSelfTestLog.Standard.Table {
+				if !test.Status.Passed {
+					exitStatus |= smartctlExitSelfTestLog
+				}
+			}
+		}
+	}
+
+	doc.Smartctl = smartctlToolFor([]string{"smartctl", "-a", "-j", smart.Device}, exitStatus)
+	return doc
+}
+
+// smartctlAttributeFor converts an attribute, deriving the flags from the type and
+// update columns when the source (WMI) did not report the flag word
+func smartctlAttributeFor(attr types.SMARTAttribute) smartctlAttribute {
+	flags := attr.Flag
+	if flags == 0 {
+		if attr.Type == "Pre-fail" {
+			flags |= 0x01
+		}
+		if attr.Updated == "Always" {
+			flags |= 0x02
+		}
+	}
+
+	raw := attr.RawString
+	if raw == "" {
+		raw = strconv.FormatUint(attr.RawValue, 10)
+	}
+
+	whenFailed := ""
+	switch attr.WhenFailed {
+	case "FAILING_NOW":
+		whenFailed = "now"
+	case "In_the_past":
+		whenFailed = "past"
+	}
+
+	return smartctlAttribute{
+		ID:         attr.ID,
+		Name:       attr.Name,
+		Value:      attr.Value,
+		Worst:      attr.Worst,
+		Thresh:     attr.Threshold,
+		WhenFailed: whenFailed,
+		Flags:      smartctlFlagsFor(flags),
+		Raw:        smartctlRaw{Value: attr.RawValue, String: raw},
+	}
+}
+
+// smartctlFlagsFor decodes the attribute flag word, e.g. 0x0033 is "PO--CK "
+func smartctlFlagsFor(value uint16) smartctlFlags {
+	letters := []byte("POSRCK")
+	for bit := range letters {
+		if value&(1<<bit) == 0 {
+			letters[bit] = '-'
+		}
+	}
+	return smartctlFlags{
+		Value:         value,
+		String:        string(letters) + " ",
+		Prefailure:    value&0x01 != 0,
+		UpdatedOnline: value&0x02 != 0,
+		Performance:   value&0x04 != 0,
+		ErrorRate:     value&0x08 != 0,
+		EventCount:    value&0x10 != 0,
+		AutoKeep:      value&0x20 != 0,
+	}
+}
+
+// smartctlNvmeLogFor rebuilds the NVMe health log from the counters kept as attributes
+func smartctlNvmeLogFor(smart types.SMARTInfo) *smartctlNvmeLog {
+	counter := func(name string) uint64 {
+		value, _ := strconv.ParseUint(smart.Attributes[name], 10, 64)
+		return value
+	}
+
+	log := &smartctlNvmeLog{
+		Temperature:      smart.Temperature,
+		DataUnitsRead:    counter("Data_Units_Read"),
+		DataUnitsWritten: counter("Data_Units_Written"),
+		PowerCycles:      smart.PowerCycleCount,
+		PowerOnHours:     smart.PowerOnHours,
+		MediaErrors:      counter("Media_Errors"),
+	}
+	if health := smart.HealthAssessment; health != nil {
+		log.AvailableSpare = uint64(health.AvailableSpare)
+		log.PercentageUsed = uint64(health.PercentUsed)
+		if health.CriticalWarning != "" {
+			warning, err := strconv.ParseUint(strings.TrimPrefix(health.CriticalWarning, "0x"), 16, 64)
+			if err != nil {
+				// Windows describes the warning in words; report it as degraded reliability
+				warning = 0x04
+			}
+			log.CriticalWarning = warning
+		}
+	}
+	return log
+}
+
+func smartctlSelfTestLogFor(selfTests *types.SMARTSelfTestLog) *smartctlSelfTestLogs {
+	log := &smartctlSelfTestLogs{Standard: smartctlSelfTestLog{
+		Revision: 1,
+		Table:    make([]smartctlSelfTest, 0, len(selfTests.Tests)),
+		Count:    int(selfTests.TestCount),
+	}}
+	for _, test := range selfTests.Tests {
+		log.Standard.Table = append(log.Standard.Table, smartctlSelfTest{
+			Type:          smartctlName{Name: test.TestDescription},
+			Status:        smartctlSelfTestStatus{String: test.Status, Passed: selfTestPassed(test.Status)},
+			LifetimeHours: test.LifetimeHours,
+			LBA:           test.LBA,
+		})
+	}
+	if log.Standard.Count < len(log.Standard.Table) {
+		log.Standard.Count = len(log.Standard.Table)
+	}
+	return log
+}
+
+// selfTestPassed treats completed, aborted and in-progress tests as passed, as smartctl does;
+// only a test that found a problem fails
+func selfTestPassed(status string) bool {
+	status = strings.ToLower(status)
+	for _, failure := range []string{"fail", "fatal", "error", "damage"} {
+		if strings.Contains(status, failure) && !strings.Contains(status, "without error") {
+			return false
+		}
+	}
+	return true
+}
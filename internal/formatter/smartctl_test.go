@@ -0,0 +1,188 @@
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatSmartctlATA(t *testing.T) {
+	smart := types.SMARTInfo{
+		Device:       `\\.\PHYSICALDRIVE0`,
+		DeviceModel:  "WDC WD40EFRX",
+		Serial:       "WD-123",
+		Capacity:     4000787030016,
+		Healthy:      true,
+		Temperature:  38,
+		PowerOnHours: 20000,
+		DetailedAttribs: []types.SMARTAttribute{
+			{ID: 5, Name: "Reallocated_Sector_Ct", Flag: 0x33, Value: 100, Worst: 100, Threshold: 10, RawValue: 0},
+			// WMI does not report the flag word
+			{ID: 9, Name: "Power_On_Hours", Value: 73, Worst: 73, RawValue: 20000, Type: "Pre-fail", Updated: "Always"},
+			{ID: 197, Name: "Current_Pending_Sector", Value: 1, Threshold: 5, RawValue: 8, WhenFailed: "FAILING_NOW", RawString: "8"},
+		},
+		SelfTestLog: &types.SMARTSelfTestLog{TestCount: 1, Tests: []types.SMARTSelfTest{
+			{TestDescription: "Short offline", Status: "Completed: read failure", LifetimeHours: 19990, LBA: 1234},
+		}},
+	}
+
+	out, err := FormatSmartctl(smart)
+	if err != nil {
+		t.Fatalf("FormatSmartctl() error = %v", err)
+	}
+
+	var doc struct {
+		Smartctl struct {
+			ExitStatus int `json:"exit_status"`
+		} `json:"smartctl"`
+		Device struct {
+			Name     string `json:"name"`
+			Protocol string `json:"protocol"`
+		} `json:"device"`
+		ModelName    string `json:"model_name"`
+		UserCapacity struct {
+			Bytes uint64 `json:"bytes"`
+		} `json:"user_capacity"`
+		RotationRate *uint32 `json:"rotation_rate"`
+		SmartStatus  struct {
+			Passed bool `json:"passed"`
+		} `json:"smart_status"`
+		AtaSmartAttributes struct {
+			Table []struct {
+				ID         int    `json:"id"`
+				WhenFailed string `json:"when_failed"`
+				Flags      struct {
+					Value      int    `json:"value"`
+					String     string `json:"string"`
+					Prefailure bool   `json:"prefailure"`
+				} `json:"flags"`
+				Raw struct {
+					Value  uint64 `json:"value"`
+					String string `json:"string"`
+				} `json:"raw"`
+			} `json:"table"`
+		} `json:"ata_smart_attributes"`
+		Temperature struct {
+			Current int `json:"current"`
+		} `json:"temperature"`
+		NvmeLog *json.RawMessage `json:"nvme_smart_health_information_log"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+
+	if doc.Device.Name != `\\.\PHYSICALDRIVE0` || doc.Device.Protocol != "ATA" {
+		t.Errorf("device = %+v, expected ATA %s", doc.Device, smart.Device)
+	}
+	if doc.ModelName != "WDC WD40EFRX" || doc.UserCapacity.Bytes != 4000787030016 || doc.Temperature.Current != 38 {
+		t.Errorf("identity not carried over: %s", out)
+	}
+	if doc.RotationRate == nil || !doc.SmartStatus.Passed || doc.NvmeLog != nil {
+		t.Errorf("unexpected ATA layout: %s", out)
+	}
+	if want := smartctlExitPrefailNow | smartctlExitSelfTestLog; doc.Smartctl.ExitStatus != want {
+		t.Errorf("exit_status = %d, expected %d", doc.Smartctl.ExitStatus, want)
+	}
+
+	table := doc.AtaSmartAttributes.Table
+	if len(table) != 3 {
+		t.Fatalf("got %d attributes, expected 3", len(table))
+	}
+	if table[0].Flags.Value != 0x33 || table[0].Flags.String != "PO--CK " || table[0].WhenFailed != "" {
+		t.Errorf("attribute 5 = %+v", table[0])
+	}
+	if table[1].Flags.Value != 0x03 || !table[1].Flags.Prefailure || table[1].Raw.String != "20000" {
+		t.Errorf("attribute 9 = %+v, expected flags derived from type and update", table[1])
+	}
+	if table[2].WhenFailed != "now" || table[2].Raw.Value != 8 {
+		t.Errorf("attribute 197 = %+v", table[2])
+	}
+}
+
+func TestFormatSmartctlNVMe(t *testing.T) {
+	// An NVMe drive read through Windows has no nvme in its name
+	smart := types.SMARTInfo{
+		Device:          `\\.\PHYSICALDRIVE1`,
+		Temperature:     45,
+		PowerOnHours:    500,
+		PowerCycleCount: 30,
+		Attributes: map[string]string{
+			"Data_Units_Read":    "1000",
+			"Data_Units_Written": "2000",
+			"Media_Errors":       "0",
+		},
+		HealthAssessment: &types.SMARTHealthStatus{Passed: false, PercentUsed: 95, AvailableSpare: 5, CriticalWarning: "SSD wear level critical"},
+	}
+
+	out, err := FormatSmartctl(smart)
+	if err != nil {
+		t.Fatalf("FormatSmartctl() error = %v", err)
+	}
+
+	var doc struct {
+		Smartctl struct {
+			ExitStatus int `json:"exit_status"`
+		} `json:"smartctl"`
+		Device struct {
+			Type     string `json:"type"`
+			Protocol string `json:"protocol"`
+		} `json:"device"`
+		RotationRate *uint32 `json:"rotation_rate"`
+		SmartStatus  struct {
+			Passed bool `json:"passed"`
+		} `json:"smart_status"`
+		NvmeLog struct {
+			CriticalWarning  int    `json:"critical_warning"`
+			PercentageUsed   int    `json:"percentage_used"`
+			AvailableSpare   int    `json:"available_spare"`
+			DataUnitsWritten uint64 `json:"data_units_written"`
+			PowerOnHours     uint64 `json:"power_on_hours"`
+		} `json:"nvme_smart_health_information_log"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+
+	if doc.Device.Protocol != "NVMe" || doc.Device.Type != "nvme" || doc.RotationRate != nil {
+		t.Errorf("expected an NVMe document: %s", out)
+	}
+	if doc.SmartStatus.Passed || doc.Smartctl.ExitStatus != smartctlExitFailing {
+		t.Errorf("expected a failing drive: %s", out)
+	}
+	if doc.NvmeLog.CriticalWarning != 0x04 || doc.NvmeLog.PercentageUsed != 95 || doc.NvmeLog.AvailableSpare != 5 ||
+		doc.NvmeLog.DataUnitsWritten != 2000 || doc.NvmeLog.PowerOnHours != 500 {
+		t.Errorf("health log = %+v", doc.NvmeLog)
+	}
+}
+
+func TestFormatSmartctlScan(t *testing.T) {
+	out, err := FormatSmartctlScan([]types.SMARTInfo{{Device: "/dev/sda"}, {Device: "/dev/nvme0"}})
+	if err != nil {
+		t.Fatalf("FormatSmartctlScan() error = %v", err)
+	}
+
+	var scan struct {
+		Devices []smartctlDevice `json:"devices"`
+	}
+	if err := json.Unmarshal([]byte(out), &scan); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	want := []smartctlDevice{
+		{Name: "/dev/sda", InfoName: "/dev/sda [SAT]", Type: "sat", Protocol: "ATA"},
+		{Name: "/dev/nvme0", InfoName: "/dev/nvme0", Type: "nvme", Protocol: "NVMe"},
+	}
+	if len(scan.Devices) != len(want) {
+		t.Fatalf("got %d devices, expected %d", len(scan.Devices), len(want))
+	}
+	for i := range want {
+		if scan.Devices[i] != want[i] {
+			t.Errorf("device %d = %+v, expected %+v", i, scan.Devices[i], want[i])
+		}
+	}
+
+	list, err := FormatSmartctlList(nil)
+	if err != nil || list != "[]\n" {
+		t.Errorf("FormatSmartctlList(nil) = %q, %v; expected an empty array", list, err)
+	}
+}
@@ -0,0 +1,244 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// smartSeverity orders SMART assessments from best to worst
+var smartSeverity = map[string]int{
+	"PASS": 0,
+	"WARN": 1,
+	"FAIL": 2,
+}
+
+// FormatSummary renders a compact single-screen overview suitable for MOTD/login banners
+func FormatSummary(info *types.SystemInfo, ansi bool) string {
+	var sb strings.Builder
+
+	paint := func(c *color.Color, s string) string {
+		if ansi {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+		return c.Sprint(s)
+	}
+	label := func(s string) string {
+		return paint(color.New(color.FgCyan, color.Bold), fmt.Sprintf("%-6s", s))
+	}
+
+	if info.System != nil {
+		sb.WriteString(fmt.Sprintf("%s %s", label("Host"), paint(color.New(color.Bold), info.System.Hostname)))
+		if info.System.Platform != "" {
+			sb.WriteString(fmt.Sprintf(" (%s %s)", info.System.Platform, info.System.PlatformVersion))
+		}
+		sb.WriteString(fmt.Sprintf(", up %s\n", info.System.UptimeFormatted))
+	}
+
+	if info.Health != nil {
+		sb.WriteString(fmt.Sprintf("%s %s", label("Health"),
+			paint(healthColor(info.Health.Score), fmt.Sprintf("%.0f/100", info.Health.Score))))
+		if worst, ok := worstHealthComponent(info.Health.Components); ok && worst.Score < 100 {
+			sb.WriteString(fmt.Sprintf(" (%s: %s)", worst.Name, worst.Detail))
+		}
+		sb.WriteString("\n")
+	}
+
+	// The most severe recommendation, as there is no room for all of them
+	if len(info.Recommendations) > 0 {
+		first := info.Recommendations[0]
+		severityColor := color.New(color.FgYellow, color.Bold)
+		if first.Severity == "CRITICAL" {
+			severityColor = color.New(color.FgRed, color.Bold)
+		}
+		sb.WriteString(fmt.Sprintf("%s %s", label("Action"), paint(severityColor, first.Action)))
+		if more := len(info.Recommendations) - 1; more > 0 {
+			sb.WriteString(fmt.Sprintf(" (+%d more)", more))
+		}
+		sb.WriteString("\n")
+	}
+
+	if info.CPU != nil {
+		sb.WriteString(label("CPU"))
+		if info.CPU.LoadAvg != nil {
+			sb.WriteString(fmt.Sprintf(" load %.2f %.2f %.2f", info.CPU.LoadAvg.Load1, info.CPU.LoadAvg.Load5, info.CPU.LoadAvg.Load15))
+		}
+		if len(info.CPU.Usage) > 0 {
+			usage := averageUsage(info.CPU.Usage)
+			sb.WriteString(fmt.Sprintf(" usage %s", paint(percentColor(usage), fmt.Sprintf("%.0f%%", usage))))
+			// The average hides a saturated cluster on hybrid CPUs
+			if classes := info.CPU.CoreClasses; len(classes) > 0 {
+				parts := make([]string, 0, len(classes))
+				for _, class := range classes {
+					parts = append(parts, fmt.Sprintf("%s %s", strings.ToUpper(class.Class[:1]),
+						paint(percentColor(class.Usage), fmt.Sprintf("%.0f%%", class.Usage))))
+				}
+				sb.WriteString(" (" + strings.Join(parts, ", ") + ")")
+			}
+		}
+		if info.CPU.LogicalCPUs > 0 {
+			sb.WriteString(fmt.Sprintf(" (%d CPUs)", info.CPU.LogicalCPUs))
+		}
+		sb.WriteString("\n")
+	}
+
+	if info.Memory != nil {
+		sb.WriteString(fmt.Sprintf("%s %s of %s\n", label("Memory"),
+			paint(percentColor(info.Memory.UsedPercent), fmt.Sprintf("%.0f%%", info.Memory.UsedPercent)),
+			info.Memory.TotalFormatted))
+	}
+
+	if info.Disk != nil {
+		if part, ok := fullestPartition(info.Disk.Partitions); ok {
+			sb.WriteString(fmt.Sprintf("%s %s on %s (fullest)\n", label("Disk"),
+				paint(percentColor(part.UsedPercent), fmt.Sprintf("%.0f%%", part.UsedPercent)), part.MountPoint))
+		}
+		if len(info.Disk.SMARTData) > 0 {
+			status, device := worstSMARTStatus(info.Disk.SMARTData)
+			statusColor := color.New(color.FgGreen)
+			switch status {
+			case "WARN":
+				statusColor = color.New(color.FgYellow, color.Bold)
+			case "FAIL":
+				statusColor = color.New(color.FgRed, color.Bold)
+			}
+			sb.WriteString(fmt.Sprintf("%s %s", label("SMART"), paint(statusColor, status)))
+			if device != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", device))
+			} else {
+				sb.WriteString(fmt.Sprintf(" (%d drives)", len(info.Disk.SMARTData)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if info.GPU != nil {
+		if gpu, ok := hottestGPU(info.GPU.GPUs); ok {
+			sb.WriteString(fmt.Sprintf("%s %s %s\n", label("GPU"),
+				paint(temperatureColor(gpu.Temperature), fmt.Sprintf("%d°C", gpu.Temperature)), gpu.Name))
+		}
+	}
+
+	if info.Battery != nil && info.Battery.Present && len(info.Battery.Batteries) > 0 {
+		bat := info.Battery.Batteries[0]
+		batColor := color.New(color.FgGreen)
+		if bat.ChargeLevel <= 20 {
+			batColor = color.New(color.FgRed, color.Bold)
+		} else if bat.ChargeLevel <= 50 {
+			batColor = color.New(color.FgYellow)
+		}
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", label("Power"),
+			paint(batColor, fmt.Sprintf("%.0f%%", bat.ChargeLevel)), strings.ToLower(bat.State)))
+	}
+
+	return sb.String()
+}
+
+// averageUsage returns the mean of per-core usage percentages
+func averageUsage(usage []float64) float64 {
+	var total float64
+	for _, u := range usage {
+		total += u
+	}
+	return total / float64(len(usage))
+}
+
+// fullestPartition returns the significant partition with the highest usage
+func fullestPartition(partitions []types.PartitionInfo) (types.PartitionInfo, bool) {
+	var fullest types.PartitionInfo
+	found := false
+	for _, part := range significantPartitions(partitions) {
+		if part.Total == 0 {
+			continue
+		}
+		if !found || part.UsedPercent > fullest.UsedPercent {
+			fullest = part
+			found = true
+		}
+	}
+	return fullest, found
+}
+
+// worstSMARTStatus returns PASS, WARN or FAIL and the device responsible for anything worse than PASS
+func worstSMARTStatus(drives []types.SMARTInfo) (string, string) {
+	worst, device := "PASS", ""
+	for _, smart := range drives {
+		status := "PASS"
+		if smart.HealthAssessment != nil && smart.HealthAssessment.OverallAssessment != "" {
+			status = smart.HealthAssessment.OverallAssessment
+		} else if !smart.Healthy {
+			status = "WARN"
+		}
+		if smartSeverity[status] > smartSeverity[worst] {
+			worst, device = status, smart.Device
+		}
+	}
+	return worst, device
+}
+
+// hottestGPU returns the GPU with the highest reported temperature
+func hottestGPU(gpus []types.GPUInfo) (types.GPUInfo, bool) {
+	var hottest types.GPUInfo
+	found := false
+	for _, gpu := range gpus {
+		if gpu.Temperature <= 0 {
+			continue
+		}
+		if !found || gpu.Temperature > hottest.Temperature {
+			hottest = gpu
+			found = true
+		}
+	}
+	return hottest, found
+}
+
+// worstHealthComponent returns the lowest scoring health component, the first of equals
+func worstHealthComponent(components []types.HealthComponent) (types.HealthComponent, bool) {
+	var worst types.HealthComponent
+	for i, c := range components {
+		if i == 0 || c.Score < worst.Score {
+			worst = c
+		}
+	}
+	return worst, len(components) > 0
+}
+
+// healthColor picks green/yellow/red for a host health score
+func healthColor(score float64) *color.Color {
+	switch {
+	case score < 50:
+		return color.New(color.FgRed, color.Bold)
+	case score < 80:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgGreen)
+	}
+}
+
+// percentColor picks green/yellow/red for a utilization percentage
+func percentColor(percent float64) *color.Color {
+	switch {
+	case percent >= 90:
+		return color.New(color.FgRed, color.Bold)
+	case percent >= 75:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgGreen)
+	}
+}
+
+// temperatureColor picks green/yellow/red for a component temperature
+func temperatureColor(celsius int) *color.Color {
+	switch {
+	case celsius >= 85:
+		return color.New(color.FgRed, color.Bold)
+	case celsius >= 70:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgGreen)
+	}
+}
@@ -0,0 +1,90 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestFormatSummary(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Disk.SMARTData = []types.SMARTInfo{
+		{Device: "/dev/sda", Healthy: true},
+		{Device: "/dev/sdb", Healthy: false},
+	}
+	info.GPU = &types.GPUData{GPUs: []types.GPUInfo{
+		{Name: "GPU A", Temperature: 45},
+		{Name: "GPU B", Temperature: 72},
+	}}
+	info.Battery = &types.BatteryData{Present: true, Batteries: []types.BatteryInfo{
+		{Name: "BAT0", State: "Discharging", ChargeLevel: 64},
+	}}
+	info.Health = &types.HostHealth{Score: 50, Components: []types.HealthComponent{
+		{Name: "memory", Score: 100, Weight: 1, Detail: "50% used"},
+		{Name: "smart", Score: 0, Weight: 3, Detail: "/dev/sdb failed self-assessment"},
+	}}
+	info.Recommendations = []types.Recommendation{
+		{Severity: "CRITICAL", Component: "smart", Subject: "/dev/sdb", Action: "Drive fails its own SMART self-assessment - back up its data and replace it"},
+		{Severity: "WARNING", Component: "services", Subject: "nginx.service", Action: "Check why nginx.service failed, then restart it"},
+	}
+
+	output := FormatSummary(info, false)
+
+	expected := []string{
+		"Host   test-host (ubuntu 22.04), up 1h 0m 0s",
+		"Health 50/100 (smart: /dev/sdb failed self-assessment)",
+		"Action Drive fails its own SMART self-assessment - back up its data and replace it (+1 more)",
+		"load 1.50 1.20 0.90",
+		"usage 15%",
+		"Memory 50% of 16.00 GB",
+		"on / (fullest)",
+		"SMART  WARN (/dev/sdb)",
+		"GPU    72°C GPU B",
+		"Power  64% discharging",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatSummary() missing %q in:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "\x1b[") {
+		t.Error("FormatSummary(plain) should not contain ANSI escape codes")
+	}
+	if !strings.Contains(FormatSummary(info, true), "\x1b[") {
+		t.Error("FormatSummary(ansi) should contain ANSI escape codes")
+	}
+}
+
+func TestFormatSummaryWithNilFields(t *testing.T) {
+	output := FormatSummary(&types.SystemInfo{}, false)
+	if output != "" {
+		t.Errorf("FormatSummary(empty) = %q, expected empty output", output)
+	}
+}
+
+func TestWorstSMARTStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		drives         []types.SMARTInfo
+		expectedStatus string
+		expectedDevice string
+	}{
+		{"all healthy", []types.SMARTInfo{{Device: "/dev/sda", Healthy: true}}, "PASS", ""},
+		{"unhealthy flag", []types.SMARTInfo{{Device: "/dev/sda", Healthy: true}, {Device: "/dev/sdb"}}, "WARN", "/dev/sdb"},
+		{"assessment wins", []types.SMARTInfo{
+			{Device: "/dev/sda", HealthAssessment: &types.SMARTHealthStatus{OverallAssessment: "WARN"}},
+			{Device: "/dev/sdb", HealthAssessment: &types.SMARTHealthStatus{OverallAssessment: "FAIL"}},
+		}, "FAIL", "/dev/sdb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, device := worstSMARTStatus(tt.drives)
+			if status != tt.expectedStatus || device != tt.expectedDevice {
+				t.Errorf("worstSMARTStatus() = %v, %v, expected %v, %v", status, device, tt.expectedStatus, tt.expectedDevice)
+			}
+		})
+	}
+}
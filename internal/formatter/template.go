@@ -0,0 +1,112 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// templateFuncs are the helpers available to user templates on top of text/template's builtins
+var templateFuncs = template.FuncMap{
+	"formatBytes": func(value interface{}) string { return formatBytes(templateUint(value)) },
+	"bar":         templateBar,
+	"percent":     func(value interface{}) string { return fmt.Sprintf("%.1f%%", templateFloat(value)) },
+	"join":        strings.Join,
+	"upper":       strings.ToUpper,
+	"lower":       strings.ToLower,
+	"repeat":      strings.Repeat,
+}
+
+// ParseTemplate reads and parses a template file for the template format
+func ParseTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, errors.New("the template format requires --template-file")
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// FormatTemplate renders the information through a user's text/template file
+// The template receives the types.SystemInfo, e.g. {{.System.Hostname}} or {{range .Disk.Partitions}}
+func FormatTemplate(info *types.SystemInfo, path string) (string, error) {
+	tmpl, err := ParseTemplate(path)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, info); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// templateBar draws a percentage as a plain text bar, 20 characters wide unless a width is given
+func templateBar(value interface{}, width ...int) string {
+	size := 20
+	if len(width) > 0 && width[0] > 0 {
+		size = width[0]
+	}
+
+	filled := int(templateFloat(value) / 100.0 * float64(size))
+	if filled > size {
+		filled = size
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", size-filled)
+}
+
+// templateFloat accepts the numeric field types found in SystemInfo, so templates need no conversions
+func templateFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return 0
+}
+
+func templateUint(value interface{}) uint64 {
+	switch v := value.(type) {
+	case uint64:
+		return v
+	case uint32:
+		return uint64(v)
+	case int:
+		if v > 0 {
+			return uint64(v)
+		}
+	case int64:
+		if v > 0 {
+			return uint64(v)
+		}
+	case float64:
+		if v > 0 {
+			return uint64(v)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+func writeTemplate(t *testing.T, text string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestFormatTemplate(t *testing.T) {
+	path := writeTemplate(t, `{{.System.Hostname | upper}}
+mem {{formatBytes .Memory.Total}} {{percent .Memory.UsedPercent}}
+{{range .Disk.Partitions}}{{.MountPoint}} [{{bar .UsedPercent 10}}]
+{{end}}`)
+
+	out, err := Format(createTestSystemInfo(), &config.Config{Format: "template", TemplateFile: path})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"TEST-HOST\n",
+		"mem 16.00 GB 50.0%\n",
+		"/ [██████░░░░]\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatTemplateErrors(t *testing.T) {
+	info := createTestSystemInfo()
+
+	if _, err := FormatTemplate(info, ""); err == nil || !strings.Contains(err.Error(), "--template-file") {
+		t.Errorf("FormatTemplate() without a file error = %v", err)
+	}
+	if _, err := FormatTemplate(info, filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("expected error for a missing template file")
+	}
+	if _, err := FormatTemplate(info, writeTemplate(t, "{{.System.Hostname")); err == nil {
+		t.Error("expected error for an unparsable template")
+	}
+	if _, err := FormatTemplate(info, writeTemplate(t, "{{.NoSuchField}}")); err == nil || !strings.Contains(err.Error(), "failed to render") {
+		t.Errorf("FormatTemplate() with an unknown field error = %v", err)
+	}
+}
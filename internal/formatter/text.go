@@ -2,16 +2,37 @@ package formatter
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
 )
 
 // FormatText formats the information as plain text
 func FormatText(info *types.SystemInfo) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("Timestamp: %s\n\n", info.Timestamp.Format("2006-01-02 15:04:05")))
+	if timestamp := utils.FormatTimestamp(info.Timestamp, info.TimestampFormat, "2006-01-02 15:04:05"); timestamp != "" {
+		sb.WriteString(fmt.Sprintf("Timestamp: %s\n\n", timestamp))
+	}
+
+	// A --fields report lists only what was selected
+	if len(info.Fields) > 0 {
+		fields, err := selectedFields(info)
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("Error: %v\n", err))
+		}
+		for _, field := range fields {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", field.Path, field.Value))
+		}
+		return sb.String()
+	}
+
+	if info.Meta != nil && info.Meta.ClockOffset != nil {
+		sb.WriteString(fmt.Sprintf("Clock Offset: %s\n\n", clockOffsetString(info.Meta.ClockOffset)))
+	}
 
 	// System information
 	if info.System != nil {
@@ -22,7 +43,33 @@ func FormatText(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("Platform Family: %s\n", info.System.PlatformFamily))
 		sb.WriteString(fmt.Sprintf("Kernel: %s (%s)\n", info.System.KernelVersion, info.System.KernelArch))
 		sb.WriteString(fmt.Sprintf("Uptime: %s\n", info.System.UptimeFormatted))
-		sb.WriteString(fmt.Sprintf("Processes: %d\n\n", info.System.Procs))
+		sb.WriteString(fmt.Sprintf("Processes: %d\n", info.System.Procs))
+		if info.System.InstallationType != "" {
+			sb.WriteString(fmt.Sprintf("Installation: %s\n", info.System.InstallationType))
+		}
+		if lic := info.System.License; lic != nil {
+			sb.WriteString(fmt.Sprintf("Edition: %s\n", lic.Edition))
+			sb.WriteString(fmt.Sprintf("License: %s", lic.Status))
+			if lic.Channel != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", lic.Channel))
+			}
+			sb.WriteString("\n")
+			if lic.InstallDate != nil {
+				sb.WriteString(fmt.Sprintf("Install Date: %s\n", lic.InstallDate.Format("2006-01-02")))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// Motherboard, firmware and chassis
+	if info.Baseboard != nil {
+		if items := boardItems(info.Baseboard); len(items) > 0 {
+			sb.WriteString("BASEBOARD\n")
+			for _, item := range items {
+				sb.WriteString(fmt.Sprintf("%s: %s\n", item.Label, item.Value))
+			}
+			sb.WriteString("\n")
+		}
 	}
 
 	// CPU information
@@ -32,15 +79,30 @@ func FormatText(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("Vendor: %s\n", info.CPU.Vendor))
 		sb.WriteString(fmt.Sprintf("Physical Cores: %d\n", info.CPU.Cores))
 		sb.WriteString(fmt.Sprintf("Logical CPUs: %d\n", info.CPU.LogicalCPUs))
+		if info.CPU.Sockets > 0 {
+			sb.WriteString(fmt.Sprintf("Topology: %s\n", cpuTopologyString(info.CPU)))
+		}
+		if len(info.CPU.Packages) > 1 {
+			for _, p := range info.CPU.Packages {
+				sb.WriteString(fmt.Sprintf("  Socket %d: %s\n", p.Socket, cpuPackageString(p)))
+			}
+		}
 		sb.WriteString(fmt.Sprintf("Frequency: %.2f MHz\n", info.CPU.MHz))
 		if info.CPU.LoadAvg != nil {
 			sb.WriteString(fmt.Sprintf("Load Average: %.2f, %.2f, %.2f\n",
 				info.CPU.LoadAvg.Load1, info.CPU.LoadAvg.Load5, info.CPU.LoadAvg.Load15))
 		}
+		for _, class := range info.CPU.CoreClasses {
+			sb.WriteString(fmt.Sprintf("%s Usage: %s\n", coreClassName(class.Class), coreClassString(class)))
+		}
 		if len(info.CPU.Usage) > 0 {
 			sb.WriteString("CPU Usage Per Core:\n")
 			for i, usage := range info.CPU.Usage {
-				sb.WriteString(fmt.Sprintf("  Core %d: %.2f%%\n", i, usage))
+				if tag := coreClassTag(info.CPU, i); tag != "" {
+					sb.WriteString(fmt.Sprintf("  Core %d (%s): %.2f%%\n", i, tag, usage))
+				} else {
+					sb.WriteString(fmt.Sprintf("  Core %d: %.2f%%\n", i, usage))
+				}
 			}
 		}
 		sb.WriteString("\n")
@@ -52,6 +114,15 @@ func FormatText(info *types.SystemInfo) string {
 		sb.WriteString(fmt.Sprintf("Total: %s\n", info.Memory.TotalFormatted))
 		sb.WriteString(fmt.Sprintf("Used: %s (%.2f%%)\n", info.Memory.UsedFormatted, info.Memory.UsedPercent))
 		sb.WriteString(fmt.Sprintf("Free: %s\n", info.Memory.FreeFormatted))
+		if info.Memory.Available > 0 {
+			sb.WriteString(fmt.Sprintf("Available: %s\n", formatBytes(info.Memory.Available)))
+		}
+		if reclaimable := info.Memory.Cached + info.Memory.Buffers; reclaimable > 0 {
+			sb.WriteString(fmt.Sprintf("Cache/Buffers: %s (reclaimable)\n", formatBytes(reclaimable)))
+		}
+		if used, percent, ok := effectiveMemoryUsed(info.Memory); ok {
+			sb.WriteString(fmt.Sprintf("Effectively Used: %s (%.2f%%)\n", formatBytes(used), percent))
+		}
 		if info.Memory.SwapTotal > 0 {
 			sb.WriteString(fmt.Sprintf("Swap Total: %s\n", formatBytes(info.Memory.SwapTotal)))
 			sb.WriteString(fmt.Sprintf("Swap Used: %s (%.2f%%)\n", formatBytes(info.Memory.SwapUsed), info.Memory.SwapPercent))
@@ -98,17 +169,10 @@ func FormatText(info *types.SystemInfo) string {
 
 		// Mounted partitions (filter loop devices)
 		if len(info.Disk.Partitions) > 0 {
-			var significantPartitions []types.PartitionInfo
-			for _, part := range info.Disk.Partitions {
-				if strings.HasPrefix(part.Device, "/dev/loop") || part.FSType == "squashfs" {
-					continue
-				}
-				significantPartitions = append(significantPartitions, part)
-			}
-
-			if len(significantPartitions) > 0 {
+			partitions := significantPartitions(info.Disk.Partitions)
+			if len(partitions) > 0 {
 				sb.WriteString("Mounted Partitions:\n")
-				for _, part := range significantPartitions {
+				for _, part := range partitions {
 					sb.WriteString(fmt.Sprintf("  %s", part.Device))
 					if part.MountPoint != "" {
 						sb.WriteString(fmt.Sprintf(" → %s", part.MountPoint))
@@ -121,6 +185,15 @@ func FormatText(info *types.SystemInfo) string {
 				}
 			}
 		}
+
+		// TRIM status for SSDs
+		if info.Disk.Trim != nil {
+			sb.WriteString(fmt.Sprintf("\nTRIM: %s - %s\n", info.Disk.Trim.Status, info.Disk.Trim.Message))
+			if info.Disk.Trim.LastTrim != nil {
+				sb.WriteString(fmt.Sprintf("  Last Trim: %s (%d days ago)\n",
+					info.Disk.Trim.LastTrim.Format("2006-01-02"), info.Disk.Trim.DaysSinceTrim))
+			}
+		}
 		sb.WriteString("\n")
 	}
 
@@ -197,6 +270,9 @@ func FormatText(info *types.SystemInfo) string {
 			if len(iface.Flags) > 0 {
 				sb.WriteString(fmt.Sprintf("  Flags: %s\n", strings.Join(iface.Flags, ", ")))
 			}
+			if iface.WiFi != nil {
+				sb.WriteString(fmt.Sprintf("  Wi-Fi: %s\n", wifiString(iface.WiFi)))
+			}
 			sb.WriteString(fmt.Sprintf("  MTU: %d\n", iface.MTU))
 			if iface.BytesSent > 0 || iface.BytesRecv > 0 {
 				sb.WriteString(fmt.Sprintf("  Bytes Sent: %s\n", formatBytes(iface.BytesSent)))
@@ -211,6 +287,12 @@ func FormatText(info *types.SystemInfo) string {
 		sb.WriteString("PROCESS INFORMATION\n")
 		sb.WriteString(fmt.Sprintf("Total: %d (Running: %d, Sleeping: %d)\n",
 			info.Processes.TotalCount, info.Processes.Running, info.Processes.Sleeping))
+		if info.Processes.Zombies > 0 {
+			sb.WriteString(fmt.Sprintf("Zombies: %s\n", zombieString(info.Processes)))
+		}
+		if handles := info.Processes.FileHandles; handles != nil {
+			sb.WriteString(fmt.Sprintf("Open Files: %d of %d\n", handles.Open, handles.Max))
+		}
 
 		if len(info.Processes.TopByMemory) > 0 {
 			sb.WriteString("\nTop Processes by Memory:\n")
@@ -218,8 +300,9 @@ func FormatText(info *types.SystemInfo) string {
 				if i >= 5 {
 					break
 				}
-				sb.WriteString(fmt.Sprintf("  %s (PID %d): %d MB (%.2f%%)\n",
-					proc.Name, proc.PID, proc.MemoryMB, proc.MemoryPercent))
+				sb.WriteString(fmt.Sprintf("  %s (PID %d): %s (%.2f%%)\n",
+					proc.Name, proc.PID, formatBytes(proc.MemoryMB<<20), proc.MemoryPercent))
+				writeProcessDetails(&sb, proc)
 			}
 		}
 
@@ -231,20 +314,70 @@ func FormatText(info *types.SystemInfo) string {
 				}
 				sb.WriteString(fmt.Sprintf("  %s (PID %d): %.2f%%\n",
 					proc.Name, proc.PID, proc.CPUPercent))
+				writeProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByDiskIO) > 0 {
+			sb.WriteString("\nTop Processes by Disk I/O:\n")
+			for i, proc := range info.Processes.TopByDiskIO {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("  %s (PID %d): read %s, write %s\n",
+					proc.Name, proc.PID, formatBytes(proc.DiskReadBytes), formatBytes(proc.DiskWriteBytes)))
+				writeProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByGPU) > 0 {
+			sb.WriteString("\nTop Processes by GPU:\n")
+			for i, proc := range info.Processes.TopByGPU {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("  %s (PID %d): %.0f%%\n",
+					proc.Name, proc.PID, proc.GPUPercent))
+				writeProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.TopByOpenFiles) > 0 {
+			sb.WriteString("\nTop Processes by Open Files:\n")
+			for i, proc := range info.Processes.TopByOpenFiles {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("  %s (PID %d): %s\n",
+					proc.Name, proc.PID, openFilesString(proc)))
+				writeProcessDetails(&sb, proc)
+			}
+		}
+
+		if len(info.Processes.Containers) > 0 {
+			sb.WriteString("\nTop Containers by CPU:\n")
+			for i, c := range info.Processes.Containers {
+				if i >= 5 {
+					break
+				}
+				sb.WriteString(fmt.Sprintf("  %s: %.2f%% CPU, %s, %d processes\n",
+					containerLabel(c.ContainerRef), c.CPUPercent, formatBytes(c.MemoryMB<<20), c.Processes))
 			}
 		}
 		sb.WriteString("\n")
 	}
 
 	// Battery information
-	if info.Battery != nil && info.Battery.Present && len(info.Battery.Batteries) > 0 {
+	if hasBatteryData(info.Battery) {
 		sb.WriteString("BATTERY INFORMATION\n")
 
-		powerSource := "AC Power"
-		if info.Battery.OnBattery {
-			powerSource = "Battery Power"
+		if len(info.Battery.Batteries) > 0 {
+			powerSource := "AC Power"
+			if info.Battery.OnBattery {
+				powerSource = "Battery Power"
+			}
+			sb.WriteString(fmt.Sprintf("Power Source: %s\n", powerSource))
 		}
-		sb.WriteString(fmt.Sprintf("Power Source: %s\n", powerSource))
 
 		if len(info.Battery.Batteries) > 1 {
 			sb.WriteString(fmt.Sprintf("Total Capacity: %s\n", formatMilliwattHours(info.Battery.TotalCapacity)))
@@ -318,6 +451,43 @@ func FormatText(info *types.SystemInfo) string {
 				sb.WriteString(fmt.Sprintf("  Serial Number: %s\n", battery.SerialNumber))
 			}
 		}
+
+		for _, ups := range info.Battery.UPSDevices {
+			sb.WriteString(fmt.Sprintf("\nUPS: %s\n", upsLabel(ups)))
+			sb.WriteString(fmt.Sprintf("  Status: %s\n", ups.Status))
+
+			if ups.ChargeLevel > 0 {
+				sb.WriteString(fmt.Sprintf("  Charge Level: %.1f%%\n", ups.ChargeLevel))
+			}
+
+			if ups.Load > 0 {
+				sb.WriteString(fmt.Sprintf("  Load: %.1f%%\n", ups.Load))
+			}
+
+			if ups.Runtime > 0 {
+				sb.WriteString(fmt.Sprintf("  Runtime: %s\n", formatTime(ups.Runtime)))
+			}
+
+			if ups.Voltage > 0 {
+				sb.WriteString(fmt.Sprintf("  Voltage: %.1f V\n", ups.Voltage))
+			}
+
+			if ups.BatteryVoltage > 0 {
+				sb.WriteString(fmt.Sprintf("  Battery Voltage: %.2f V\n", ups.BatteryVoltage))
+			}
+
+			if ups.Power > 0 {
+				sb.WriteString(fmt.Sprintf("  Power Rating: %d W\n", ups.Power))
+			}
+
+			if ups.Temperature > 0 {
+				sb.WriteString(fmt.Sprintf("  Temperature: %.1f°C\n", ups.Temperature))
+			}
+
+			if ups.SerialNumber != "" {
+				sb.WriteString(fmt.Sprintf("  Serial Number: %s\n", ups.SerialNumber))
+			}
+		}
 		sb.WriteString("\n")
 	}
 
@@ -369,6 +539,257 @@ func FormatText(info *types.SystemInfo) string {
 			if gpu.PCIBus != "" {
 				sb.WriteString(fmt.Sprintf("  PCI Bus: %s\n", gpu.PCIBus))
 			}
+			if gpu.KernelModuleVersion != "" {
+				sb.WriteString(fmt.Sprintf("  Kernel Module: %s\n", gpu.KernelModuleVersion))
+			}
+			if gpu.CUDAVersion != "" || gpu.CUDARuntimeVersion != "" {
+				sb.WriteString(fmt.Sprintf("  CUDA: %s\n", cudaString(gpu)))
+			}
+			if gpu.DriverError != "" {
+				sb.WriteString(fmt.Sprintf("  Driver Error: %s\n", gpu.DriverError))
+			}
+			if gpu.MIGMode != "" {
+				sb.WriteString(fmt.Sprintf("  MIG Mode: %s\n", gpu.MIGMode))
+			}
+			if gpu.VirtualizationMode != "" && gpu.VirtualizationMode != "None" {
+				sb.WriteString(fmt.Sprintf("  Virtualization: %s\n", gpu.VirtualizationMode))
+			}
+			if len(gpu.Partitions) > 0 {
+				sb.WriteString("  Partitions:\n")
+				for _, partition := range gpu.Partitions {
+					sb.WriteString(fmt.Sprintf("    %s\n", gpuPartitionString(partition)))
+				}
+			}
+		}
+		if len(info.GPU.DriverIssues) > 0 {
+			sb.WriteString("\nDriver Issues:\n")
+			for _, issue := range info.GPU.DriverIssues {
+				sb.WriteString(fmt.Sprintf("  [%s] GPU %d: %s\n", issue.Severity, issue.GPUIndex, issue.Description))
+				sb.WriteString(fmt.Sprintf("    Recommendation: %s\n", issue.Recommendation))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// Non-GPU accelerators
+	if info.Accelerators != nil && len(info.Accelerators.Accelerators) > 0 {
+		sb.WriteString("ACCELERATORS\n")
+		for i, acc := range info.Accelerators.Accelerators {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("%s %d: %s\n", acc.Type, i, acc.Name))
+			if acc.Vendor != "" {
+				sb.WriteString(fmt.Sprintf("  Vendor: %s\n", acc.Vendor))
+			}
+			sb.WriteString(fmt.Sprintf("  Bus: %s\n", acceleratorBusString(acc)))
+			sb.WriteString(fmt.Sprintf("  Driver: %s\n", acceleratorDriverString(acc)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// PCI devices
+	if info.PCI != nil && len(info.PCI.Devices) > 0 {
+		sb.WriteString("PCI DEVICES\n")
+		for _, d := range info.PCI.Devices {
+			sb.WriteString(fmt.Sprintf("%s %s (%s)\n", d.Address, pciDeviceString(d), pciDriverString(d)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// USB devices
+	if info.USB != nil && len(info.USB.Devices) > 0 {
+		sb.WriteString("USB DEVICES\n")
+		for _, d := range info.USB.Devices {
+			line := usbLocationString(d) + " " + usbDeviceString(d)
+			if detail := usbDetailString(d); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Connected displays
+	if info.Displays != nil && len(info.Displays.Displays) > 0 {
+		sb.WriteString("DISPLAYS\n")
+		for _, d := range info.Displays.Displays {
+			line := d.Name + " " + displayString(d)
+			if mode := displayModeString(d); mode != "" {
+				line += " " + mode
+			}
+			if detail := displayDetailString(d); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Sound cards and their endpoints
+	if info.Audio != nil && len(info.Audio.Devices) > 0 {
+		sb.WriteString("AUDIO DEVICES\n")
+		for _, d := range info.Audio.Devices {
+			line := audioDeviceString(d)
+			if endpoints := audioEndpointsString(d); endpoints != "" {
+				line += " [" + endpoints + "]"
+			}
+			if detail := audioDetailString(d); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Bluetooth adapters and paired devices
+	if info.Bluetooth != nil && len(info.Bluetooth.Adapters) > 0 {
+		sb.WriteString("BLUETOOTH\n")
+		for _, a := range info.Bluetooth.Adapters {
+			sb.WriteString(strings.TrimSpace(a.Name+" "+a.Address) + " (" + bluetoothAdapterDetailString(a) + ")\n")
+		}
+		for _, d := range info.Bluetooth.Devices {
+			sb.WriteString("  " + strings.TrimSpace(d.Name+" "+d.Address) + " (" + bluetoothDeviceDetailString(d) + ")\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Loaded kernel modules and drivers
+	if info.Drivers != nil && len(info.Drivers.Drivers) > 0 {
+		sb.WriteString("KERNEL DRIVERS\n")
+		if len(info.Drivers.Tainted) > 0 {
+			sb.WriteString("Kernel tainted: " + strings.Join(info.Drivers.Tainted, ", ") + "\n")
+		}
+		for _, d := range info.Drivers.Drivers {
+			line := strings.TrimSpace(d.Name + " " + d.Version)
+			if detail := driverDetailString(d); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Service states and the failed services
+	if info.Services != nil {
+		sb.WriteString("SERVICES\n")
+		sb.WriteString(fmt.Sprintf("Manager: %s\n", info.Services.Manager))
+		sb.WriteString(fmt.Sprintf("Services: %s\n", serviceCountString(info.Services)))
+		for _, s := range info.Services.FailedServices {
+			line := "Failed: " + s.Name
+			if detail := serviceDetailString(s); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Firewall state and rule summary
+	if info.Firewall != nil {
+		sb.WriteString("FIREWALL\n")
+		sb.WriteString(fmt.Sprintf("Status: %s\n", firewallStatusString(info.Firewall)))
+		for _, f := range info.Firewall.Firewalls {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", f.Name, firewallDetailString(f)))
+			for _, z := range f.Zones {
+				sb.WriteString(fmt.Sprintf("  %s: %s\n", z.Name, firewallZoneString(z)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// Logged-in users and local accounts
+	if info.Users != nil {
+		sb.WriteString("USERS\n")
+		sb.WriteString(fmt.Sprintf("Local accounts: %s\n", accountCountString(info.Users)))
+		sb.WriteString(fmt.Sprintf("Sessions: %d\n", len(info.Users.Sessions)))
+		for _, s := range info.Users.Sessions {
+			line := s.User
+			if detail := sessionDetailString(s); detail != "" {
+				line += " (" + detail + ")"
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Installed software inventory
+	if info.Packages != nil && len(info.Packages.Packages) > 0 {
+		sb.WriteString("INSTALLED PACKAGES\n")
+		sb.WriteString(fmt.Sprintf("Packages: %d (%s)\n", len(info.Packages.Packages), packageCountString(info.Packages)))
+		for _, p := range info.Packages.Packages {
+			sb.WriteString(strings.TrimSpace(p.Name+" "+p.Version) + " (" + packageDetailString(p) + ")\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Checksums of critical files
+	if info.Integrity != nil && len(info.Integrity.Files) > 0 {
+		sb.WriteString("FILE INTEGRITY\n")
+		for _, f := range info.Integrity.Files {
+			sb.WriteString(checksumLine(f) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Thermal zones and trip points
+	if info.Thermal != nil && len(info.Thermal.Sensors) > 0 {
+		sb.WriteString("THERMAL\n")
+		for _, sensor := range info.Thermal.Sensors {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", thermalSensorLabel(sensor), thermalSensorValue(sensor)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Cooling policy, fans and their noise
+	if info.Thermal != nil && hasCooling(info.Thermal) {
+		sb.WriteString("COOLING\n")
+		if info.Thermal.CoolingPolicyAC != "" || info.Thermal.CoolingPolicyDC != "" {
+			sb.WriteString(fmt.Sprintf("Cooling Policy: %s\n", coolingPolicyString(info.Thermal)))
+		}
+		for _, fan := range info.Thermal.Fans {
+			sb.WriteString(fmt.Sprintf("Fan %s: %s\n", fan.Name, fanString(fan, info.Thermal.Noise)))
+		}
+		if info.Thermal.Noise != nil {
+			sb.WriteString(fmt.Sprintf("Estimated Noise: %s\n", noiseString(info.Thermal.Noise)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Hardware monitoring sensors
+	if info.Sensors != nil && len(info.Sensors.Temperatures) > 0 {
+		sb.WriteString("SENSORS\n")
+		for _, sensor := range info.Sensors.Temperatures {
+			sb.WriteString(fmt.Sprintf("%s %s: %s\n", sensor.Chip, sensor.Label, sensorValue(sensor)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Security posture
+	if info.Security != nil {
+		if items := securityItems(info.Security); len(items) > 0 {
+			sb.WriteString("SECURITY\n")
+			for _, item := range items {
+				sb.WriteString(fmt.Sprintf("%s: %s\n", item.Label, item.Value))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// What to do about the problems found
+	if len(info.Recommendations) > 0 {
+		sb.WriteString("RECOMMENDATIONS\n")
+		for _, r := range info.Recommendations {
+			sb.WriteString(fmt.Sprintf("%-8s %s\n", r.Severity, r.Action))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Modules left out of the report
+	if len(info.Errors) > 0 {
+		sb.WriteString("ERRORS\n")
+		for _, e := range info.Errors {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", e.Module, e.Error))
 		}
 		sb.WriteString("\n")
 	}
@@ -376,18 +797,225 @@ func FormatText(info *types.SystemInfo) string {
 	return sb.String()
 }
 
+// cpuTopologyString describes sockets, cores and threads, e.g. "2 sockets × 16 cores × 2 threads"
+func cpuTopologyString(cpu *types.CPUData) string {
+	return fmt.Sprintf("%s × %s × %s", plural(int(cpu.Sockets), "socket"), plural(int(cpu.CoresPerSocket), "core"), plural(int(cpu.ThreadsPerCore), "thread"))
+}
+
+// cpuPackageString describes one socket's CPU, e.g. "Intel Xeon Gold 6230 (20 cores, 40 threads, stepping 7)"
+func cpuPackageString(p types.CPUPackage) string {
+	details := []string{plural(int(p.Cores), "core"), plural(int(p.Threads), "thread")}
+	if p.Stepping > 0 {
+		details = append(details, fmt.Sprintf("stepping %d", p.Stepping))
+	}
+	if p.Microcode != "" {
+		details = append(details, "microcode "+p.Microcode)
+	}
+	return fmt.Sprintf("%s (%s)", p.ModelName, strings.Join(details, ", "))
+}
+
+// coreClassString describes a hybrid CPU's core class, e.g. "35.20% across CPUs 0-15"
+func coreClassString(class types.CPUCoreClass) string {
+	return fmt.Sprintf("%.2f%% across CPUs %s", class.Usage, cpuListString(class.CPUs))
+}
+
+// coreClassName names a core class for display, e.g. "Performance Cores"
+func coreClassName(class string) string {
+	if class == "" {
+		return "Cores"
+	}
+	return strings.ToUpper(class[:1]) + class[1:] + " Cores"
+}
+
+// coreClassTag returns P or E for a logical CPU on a hybrid CPU, or "" on other CPUs
+func coreClassTag(cpu *types.CPUData, index int) string {
+	for _, class := range cpu.CoreClasses {
+		for _, n := range class.CPUs {
+			if n == index {
+				return strings.ToUpper(class.Class[:1])
+			}
+		}
+	}
+	return ""
+}
+
+// cpuListString writes CPU numbers in the kernel's list form, e.g. "0-7,16"
+func cpuListString(cpus []int) string {
+	var parts []string
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(cpus[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// plural writes a count with its noun, e.g. "1 socket", "2 sockets"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// formatBytes writes a size in the configured units
 func formatBytes(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+	return utils.FormatBytes(bytes)
+}
+
+// writeProcessDetails writes the executable path and the optional command line and environment of a process
+func writeProcessDetails(sb *strings.Builder, proc types.ProcessInfo) {
+	if proc.Exe != "" {
+		sb.WriteString(fmt.Sprintf("    Path: %s\n", proc.Exe))
 	}
+	if proc.Service != "" {
+		sb.WriteString(fmt.Sprintf("    Service: %s\n", proc.Service))
+	}
+	if proc.Container != nil {
+		sb.WriteString(fmt.Sprintf("    Container: %s\n", containerLabel(*proc.Container)))
+	}
+	if proc.Cmdline != "" {
+		sb.WriteString(fmt.Sprintf("    Command: %s\n", proc.Cmdline))
+	}
+	if len(proc.Env) > 0 {
+		sb.WriteString(fmt.Sprintf("    Env: %s\n", processEnvString(proc.Env)))
+	}
+}
+
+// processEnvString formats captured environment variables as sorted KEY=value pairs
+func processEnvString(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-	div, exp := uint64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + env[key]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// cudaString shows the CUDA version the driver supports and the installed runtime
+func cudaString(gpu types.GPUInfo) string {
+	switch {
+	case gpu.CUDAVersion != "" && gpu.CUDARuntimeVersion != "":
+		return fmt.Sprintf("%s (driver), %s (runtime)", gpu.CUDAVersion, gpu.CUDARuntimeVersion)
+	case gpu.CUDAVersion != "":
+		return fmt.Sprintf("%s (driver)", gpu.CUDAVersion)
+	default:
+		return fmt.Sprintf("%s (runtime)", gpu.CUDARuntimeVersion)
+	}
+}
+
+// gpuPartitionString describes a MIG instance or vGPU on one line
+func gpuPartitionString(p types.GPUPartition) string {
+	var sb strings.Builder
+	if p.Kind == "vgpu" {
+		sb.WriteString(fmt.Sprintf("vGPU %d", p.Index))
+	} else {
+		sb.WriteString(fmt.Sprintf("MIG %d", p.Index))
+	}
+	if p.Profile != "" {
+		sb.WriteString(": " + p.Profile)
 	}
+	if p.VMName != "" {
+		sb.WriteString(" on " + p.VMName)
+	}
+
+	details := make([]string, 0, 4)
+	if p.Multiprocessors > 0 {
+		details = append(details, fmt.Sprintf("%d SMs", p.Multiprocessors))
+	}
+	if p.MemoryTotal > 0 {
+		details = append(details, fmt.Sprintf("%s / %s", formatBytes(p.MemoryUsed), formatBytes(p.MemoryTotal)))
+	} else if p.MemoryUsed > 0 {
+		details = append(details, fmt.Sprintf("%s used", formatBytes(p.MemoryUsed)))
+	}
+	if p.Utilization > 0 {
+		details = append(details, fmt.Sprintf("%d%% util", p.Utilization))
+	}
+	if p.GPUInstanceID != nil && p.ComputeInstanceID != nil {
+		details = append(details, fmt.Sprintf("GI %d, CI %d", *p.GPUInstanceID, *p.ComputeInstanceID))
+	}
+	if len(details) > 0 {
+		sb.WriteString(" (" + strings.Join(details, ", ") + ")")
+	}
+	return sb.String()
+}
+
+// acceleratorBusString shows the bus, address and vendor:device IDs of an accelerator
+func acceleratorBusString(acc types.AcceleratorInfo) string {
+	s := strings.ToUpper(acc.Bus)
+	if acc.Address != "" {
+		s += " " + acc.Address
+	}
+	if acc.VendorID != "" {
+		s += fmt.Sprintf(" [%s:%s]", acc.VendorID, acc.DeviceID)
+	}
+	return s
+}
+
+// acceleratorDriverString shows the bound driver or flags a device without one
+func acceleratorDriverString(acc types.AcceleratorInfo) string {
+	if !acc.DriverLoaded {
+		return "not loaded"
+	}
+	if acc.Driver == "" {
+		return "loaded"
+	}
+	return acc.Driver
+}
+
+// effectiveMemoryUsed returns the memory that cannot be reclaimed on demand
+// (Total - Available); the "used" figure can include page cache on some platforms
+func effectiveMemoryUsed(mem *types.MemoryData) (uint64, float64, bool) {
+	if mem.Total == 0 || mem.Available == 0 || mem.Available > mem.Total {
+		return 0, 0, false
+	}
+	used := mem.Total - mem.Available
+	return used, float64(used) / float64(mem.Total) * 100, true
+}
+
+// hasBatteryData reports whether there is a battery or UPS worth rendering
+func hasBatteryData(battery *types.BatteryData) bool {
+	if battery == nil {
+		return false
+	}
+	return (battery.Present && len(battery.Batteries) > 0) || len(battery.UPSDevices) > 0
+}
+
+// upsLabel names a UPS by device name plus manufacturer and model when known
+func upsLabel(ups types.UPSInfo) string {
+	model := strings.TrimSpace(ups.Manufacturer + " " + ups.Model)
+	if model == "" || model == ups.Name {
+		return ups.Name
+	}
+	return fmt.Sprintf("%s (%s)", ups.Name, model)
+}
+
+// containerLabel formats a container as "<runtime> <short id>", with its pod when known
+func containerLabel(ref types.ContainerRef) string {
+	id := ref.ID
+	if ref.Runtime != "lxc" && len(id) > 12 {
+		id = id[:12]
+	}
+	label := ref.Runtime + " " + id
+	if ref.PodUID != "" {
+		label += " (pod " + ref.PodUID + ")"
+	}
+	return label
+}
 
-	units := []string{"KB", "MB", "GB", "TB", "PB", "EB"}
-	return fmt.Sprintf("%.2f %s", float64(bytes)/float64(div), units[exp])
+// clockOffsetString describes a measured clock offset, e.g. "+12.3 ms vs pool.ntp.org (round trip 24.0 ms)"
+func clockOffsetString(offset *types.ClockOffset) string {
+	return fmt.Sprintf("%+.1f ms vs %s (round trip %.1f ms)", offset.OffsetMS, offset.Server, offset.RoundTripMS)
 }
@@ -0,0 +1,180 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// thermalMargin is how close to its lowest trip point a sensor is flagged as hot
+const thermalMargin = 10.0
+
+// coolingPolicyString shows the Windows system cooling policy on AC and battery
+func coolingPolicyString(thermal *types.ThermalData) string {
+	switch {
+	case thermal.CoolingPolicyAC != "" && thermal.CoolingPolicyDC != "":
+		return fmt.Sprintf("%s (AC), %s (battery)", thermal.CoolingPolicyAC, thermal.CoolingPolicyDC)
+	case thermal.CoolingPolicyAC != "":
+		return thermal.CoolingPolicyAC + " (AC)"
+	default:
+		return thermal.CoolingPolicyDC + " (battery)"
+	}
+}
+
+// thermalSensorLabel names a sensor with the component and zone type it maps to
+func thermalSensorLabel(sensor types.ThermalSensor) string {
+	details := []string{sensor.Component}
+	if sensor.Type != "" {
+		details = append(details, sensor.Type)
+	}
+	return fmt.Sprintf("%s [%s]", sensor.Name, strings.Join(details, ", "))
+}
+
+// tripPointsString lists trip thresholds, e.g. "passive 95°C, critical 105°C"
+func tripPointsString(trips []types.TripPoint) string {
+	parts := make([]string, 0, len(trips))
+	for _, trip := range trips {
+		parts = append(parts, fmt.Sprintf("%s %.0f°C", trip.Type, trip.Temperature))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// thermalSensorValue shows the reading followed by its thresholds and governor
+func thermalSensorValue(sensor types.ThermalSensor) string {
+	value := fmt.Sprintf("%.1f°C", sensor.Temperature)
+	if len(sensor.TripPoints) > 0 {
+		value += " (" + tripPointsString(sensor.TripPoints) + ")"
+	}
+	if sensor.Policy != "" {
+		value += ", governor " + sensor.Policy
+	}
+	return value
+}
+
+// thermalHeadroom returns the distance to the lowest trip point, or false when none is configured
+func thermalHeadroom(sensor types.ThermalSensor) (float64, bool) {
+	if len(sensor.TripPoints) == 0 {
+		return 0, false
+	}
+	lowest := sensor.TripPoints[0].Temperature
+	for _, trip := range sensor.TripPoints[1:] {
+		if trip.Temperature < lowest {
+			lowest = trip.Temperature
+		}
+	}
+	return lowest - sensor.Temperature, true
+}
+
+// sensorLimitsString lists a sensor's limits, e.g. "max 100°C, crit 105°C"; OpenHardwareMonitor's
+// min and max are the readings seen, so they are shown as a range instead
+func sensorLimitsString(sensor types.TemperatureSensor) string {
+	if strings.HasSuffix(sensor.Source, "hardwaremonitor") {
+		if sensor.Min == 0 && sensor.Max == 0 {
+			return ""
+		}
+		return fmt.Sprintf("seen %.1f–%.1f°C", sensor.Min, sensor.Max)
+	}
+	var parts []string
+	for _, limit := range []struct {
+		name  string
+		value float64
+	}{{"min", sensor.Min}, {"max", sensor.Max}, {"crit", sensor.Critical}} {
+		// Drivers without a limit often report 0 or absolute zero
+		if limit.value != 0 && limit.value > -273 {
+			parts = append(parts, fmt.Sprintf("%s %.0f°C", limit.name, limit.value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sensorValue shows a sensor's reading followed by its limits
+func sensorValue(sensor types.TemperatureSensor) string {
+	value := fmt.Sprintf("%.1f°C", sensor.Temperature)
+	if limits := sensorLimitsString(sensor); limits != "" {
+		value += " (" + limits + ")"
+	}
+	return value
+}
+
+// sensorHeadroom returns the distance to the lowest high limit, or false when none is set
+func sensorHeadroom(sensor types.TemperatureSensor) (float64, bool) {
+	if strings.HasSuffix(sensor.Source, "hardwaremonitor") {
+		return 0, false // Max is the highest reading seen, not a limit
+	}
+	limit := sensor.Max
+	if limit <= 0 || (sensor.Critical > 0 && sensor.Critical < limit) {
+		limit = sensor.Critical
+	}
+	if limit <= 0 {
+		return 0, false
+	}
+	return limit - sensor.Temperature, true
+}
+
+// fanHighShare is the share of its maximum speed above which a fan is flagged as running high
+const fanHighShare = 0.9
+
+// Fan states the pretty output colours
+const (
+	fanNormal = iota
+	fanHigh   // Near the highest speed the controller drives it at
+	fanFault  // Alarm raised, or spinning below its minimum
+)
+
+// hasCooling reports whether there is anything to show in the cooling section
+func hasCooling(thermal *types.ThermalData) bool {
+	return thermal.CoolingPolicyAC != "" || thermal.CoolingPolicyDC != "" || len(thermal.Fans) > 0 || thermal.Noise != nil
+}
+
+// fanStatus compares a fan's speed to its limits. A stopped fan only counts as failing when
+// the chip raises the alarm, as many boards and Macs stop fans at idle on purpose
+func fanStatus(fan types.FanInfo) int {
+	switch {
+	case fan.Alarm || (fan.RPM > 0 && fan.RPM < fan.MinRPM):
+		return fanFault
+	case fan.MaxRPM > 0 && float64(fan.RPM) >= fanHighShare*float64(fan.MaxRPM):
+		return fanHigh
+	}
+	return fanNormal
+}
+
+// fanString shows a fan's speed, duty cycle, limits, alarm and, when estimated, its noise
+// contribution, e.g. "1180 RPM at 45% (min 300, max 2000 RPM), ~20.2 dB(A) (generic 120 mm fan)"
+func fanString(fan types.FanInfo, noise *types.NoiseEstimate) string {
+	value := fmt.Sprintf("%d RPM", fan.RPM)
+	if fan.PWM > 0 {
+		value += fmt.Sprintf(" at %d%%", fan.PWM)
+	}
+	var limits []string
+	if fan.MinRPM > 0 {
+		limits = append(limits, fmt.Sprintf("min %d", fan.MinRPM))
+	}
+	if fan.MaxRPM > 0 {
+		limits = append(limits, fmt.Sprintf("max %d", fan.MaxRPM))
+	}
+	if len(limits) > 0 {
+		value += " (" + strings.Join(limits, ", ") + " RPM)"
+	}
+	if fan.Alarm {
+		value += ", ALARM"
+	}
+	if noise == nil {
+		return value
+	}
+	for _, fn := range noise.Fans {
+		if fn.Name == fan.Name && fn.RPM == fan.RPM {
+			return fmt.Sprintf("%s, ~%.1f dB(A) (%s)", value, fn.DBA, fn.Model)
+		}
+	}
+	return value
+}
+
+// noiseString shows the combined estimate and, with history, how it is trending
+func noiseString(noise *types.NoiseEstimate) string {
+	value := fmt.Sprintf("~%.1f dB(A)", noise.TotalDBA)
+	if noise.Trend != "" {
+		value += fmt.Sprintf(", %s (%+.2f dB/day)", noise.Trend, noise.TrendDBPerDay)
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// usbDeviceString describes a USB device by class, vendor and product with its IDs, e.g.
+// "Mass Storage: SanDisk Corp. Ultra [0781:5581]"
+func usbDeviceString(d types.USBDevice) string {
+	name := strings.TrimSpace(d.Vendor + " " + d.Product)
+	if name == "" {
+		name = "Unknown device"
+	}
+	text := fmt.Sprintf("%s [%s:%s]", name, d.VendorID, d.ProductID)
+	if d.ClassName != "" {
+		text = d.ClassName + ": " + text
+	}
+	return text
+}
+
+// usbDetailString lists a USB device's speed, serial and drivers, e.g.
+// "5 Gbps, serial 4C53000113, usb-storage"
+func usbDetailString(d types.USBDevice) string {
+	var details []string
+	if d.SpeedMbps > 0 {
+		details = append(details, usbSpeedString(d.SpeedMbps))
+	}
+	if d.Serial != "" {
+		details = append(details, "serial "+d.Serial)
+	}
+	if len(d.Drivers) > 0 {
+		details = append(details, strings.Join(d.Drivers, ", "))
+	}
+	return strings.Join(details, ", ")
+}
+
+// usbSpeedString writes a speed in Mb/s as USB marketing does, e.g. 480 Mbps or 10 Gbps
+func usbSpeedString(mbps float64) string {
+	if mbps >= 1000 {
+		return strconv.FormatFloat(mbps/1000, 'f', -1, 64) + " Gbps"
+	}
+	return strconv.FormatFloat(mbps, 'f', -1, 64) + " Mbps"
+}
+
+// usbLocationString names where a device is plugged in, e.g. "bus 2 port 1.4", falling back
+// to its address where bus and port are unknown
+func usbLocationString(d types.USBDevice) string {
+	if d.Bus > 0 && d.Port != "" {
+		return fmt.Sprintf("bus %d port %s", d.Bus, d.Port)
+	}
+	return d.Address
+}
@@ -0,0 +1,33 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// accountCountString counts the local accounts, e.g. "42 (3 for people)"
+func accountCountString(u *types.UserData) string {
+	return fmt.Sprintf("%d (%d for people)", u.LocalAccounts, u.UserAccounts)
+}
+
+// sessionDetailString lists where and since when a user is logged in, e.g.
+// "pts/0 from 10.0.0.5, since 2026-06-01 09:12, disconnected"
+func sessionDetailString(s types.UserSession) string {
+	var details []string
+	where := s.Terminal
+	if s.RemoteHost != "" {
+		where = strings.TrimSpace(where + " from " + s.RemoteHost)
+	}
+	if where != "" {
+		details = append(details, where)
+	}
+	if !s.LoginTime.IsZero() {
+		details = append(details, "since "+s.LoginTime.Format("2006-01-02 15:04"))
+	}
+	if s.State != "" && s.State != "active" {
+		details = append(details, s.State)
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// FormatXML formats the information as an XML inventory document, for CMDB tools that only
+// ingest XML. The document mirrors the JSON report under a <sysinfo> root: objects become
+// elements named by their JSON keys, array entries become <item> elements, and map keys
+// that are not valid XML names, such as "/dev/sda", become <entry key="...">
+func FormatXML(info *types.SystemInfo) (string, error) {
+	// The JSON encoding is the marshaling layer: it already handles the maps and
+	// omitempty fields that encoding/xml cannot, and its token stream keeps field order
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := writeXMLValue(encoder, decoder, xml.StartElement{Name: xml.Name{Local: "sysinfo"}}); err != nil {
+		return "", fmt.Errorf("failed to marshal XML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal XML: %w", err)
+	}
+	buf.WriteString("\n")
+	return buf.String(), nil
+}
+
+// writeXMLValue writes the next JSON value from decoder as the element start
+func writeXMLValue(encoder *xml.Encoder, decoder *json.Decoder, start xml.StartElement) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		for decoder.More() {
+			child := xml.StartElement{Name: xml.Name{Local: "item"}}
+			if value == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				child = xmlElement(key.(string))
+			}
+			if err := writeXMLValue(encoder, decoder, child); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	case string:
+		err = encoder.EncodeToken(xml.CharData(value))
+	case json.Number:
+		err = encoder.EncodeToken(xml.CharData(value.String()))
+	case bool:
+		err = encoder.EncodeToken(xml.CharData(fmt.Sprint(value)))
+	}
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// xmlElement names an element after a JSON key, or keeps the key in an attribute when it
+// is not a valid XML name
+func xmlElement(key string) xml.StartElement {
+	if validXMLName(key) {
+		return xml.StartElement{Name: xml.Name{Local: key}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "entry"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+	}
+}
+
+// validXMLName reports whether name can be used as an element name as is
+// Names starting with "xml" are reserved, and colons would declare a namespace
+func validXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+)
+
+func TestFormatXML(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Disk.SMARTData[0].Attributes = map[string]string{
+		"Critical Warning":   "0x00",
+		"Data_Units_Written": "1,234",
+	}
+	info.System.Hostname = "test-host & <lab>"
+
+	output, err := Format(info, &config.Config{Format: "xml"})
+	if err != nil {
+		t.Fatalf("Format xml failed: %v", err)
+	}
+
+	// The document must parse
+	decoder := xml.NewDecoder(strings.NewReader(output))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("output is not well-formed XML: %v\n%s", err, output)
+		}
+	}
+
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		"<sysinfo>",
+		"<hostname>test-host &amp; &lt;lab&gt;</hostname>",
+		"<partitions>\n      <item>\n        <device>/dev/sda1</device>",
+		"<healthy>true</healthy>",
+		"<load1>1.5</load1>",
+		`<entry key="Critical Warning">0x00</entry>`,
+		"<Data_Units_Written>1,234</Data_Units_Written>",
+		"</sysinfo>\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("XML output missing %q\n%s", want, output)
+		}
+	}
+
+	// Elements keep the report's field order
+	if strings.Index(output, "<system>") > strings.Index(output, "<cpu>") {
+		t.Error("expected <system> before <cpu>")
+	}
+}
+
+func TestValidXMLName(t *testing.T) {
+	tests := map[string]bool{
+		"hostname":       true,
+		"_private":       true,
+		"used-percent.1": true,
+		"":               false,
+		"1st":            false,
+		"/dev/sda":       false,
+		"xmlns":          false,
+		"a:b":            false,
+		"two words":      false,
+	}
+	for name, want := range tests {
+		if got := validXMLName(name); got != want {
+			t.Errorf("validXMLName(%q) = %v, expected %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package hotplug reports disks and USB devices being attached or detached
+package hotplug
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Event actions
+const (
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+)
+
+// Device kinds
+const (
+	KindDisk = "disk"
+	KindUSB  = "usb"
+)
+
+// Event is one device appearing or disappearing
+type Event struct {
+	Time        time.Time
+	Action      string // ActionAdd or ActionRemove
+	Kind        string // KindDisk or KindUSB
+	Device      string // /dev/sdX for disks, the bus path (e.g. 1-2) for USB on Linux; the device ID on Windows
+	Description string // Model or product name, when known
+	Serial      string
+}
+
+// Watch sends an event on events for every disk or USB device attached or detached
+// until ctx is cancelled. Devices present when it starts are not reported.
+// It returns an error when the platform cannot be watched
+func Watch(ctx context.Context, events chan<- Event) error {
+	return watch(ctx, events)
+}
+
+// diff compares two snapshots keyed by device ID, returning removals then additions
+// Platforms without change notifications poll and diff
+func diff(previous, current map[string]Event, now time.Time) []Event {
+	var removed, added []Event
+	for key, event := range previous {
+		if _, ok := current[key]; !ok {
+			event.Action = ActionRemove
+			event.Time = now
+			removed = append(removed, event)
+		}
+	}
+	for key, event := range current {
+		if _, ok := previous[key]; !ok {
+			event.Action = ActionAdd
+			event.Time = now
+			added = append(added, event)
+		}
+	}
+
+	byDevice := func(events []Event) {
+		sort.Slice(events, func(i, j int) bool { return events[i].Device < events[j].Device })
+	}
+	byDevice(removed)
+	byDevice(added)
+	return append(removed, added...)
+}
@@ -0,0 +1,12 @@
+//go:build darwin
+
+package hotplug
+
+import (
+	"context"
+	"errors"
+)
+
+func watch(context.Context, chan<- Event) error {
+	return errors.New("device hot-swap events are not supported on macOS")
+}
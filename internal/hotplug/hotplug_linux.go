@@ -0,0 +1,178 @@
+//go:build linux
+
+package hotplug
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const sysfsRoot = "/sys"
+
+// watch listens for kernel uevents on a netlink socket, the same feed udev reads
+func watch(ctx context.Context, events chan<- Event) error {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return fmt.Errorf("failed to open uevent socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	// Group 1 carries the kernel's own events; udev rebroadcasts on group 2
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: 1}); err != nil {
+		return fmt.Errorf("failed to bind uevent socket: %w", err)
+	}
+	// Wake up regularly so cancellation is noticed
+	timeout := unix.NsecToTimeval(time.Second.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		return fmt.Errorf("failed to configure uevent socket: %w", err)
+	}
+	_ = unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUF, 1<<20)
+
+	// Describe the devices already present so their removal names the model
+	known := scanDevices(sysfsRoot)
+
+	buf := make([]byte, 16<<10)
+	for ctx.Err() == nil {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			// Dropped events on a full buffer are not fatal
+			if errors.Is(err, unix.ENOBUFS) {
+				continue
+			}
+			return fmt.Errorf("failed to read uevent: %w", err)
+		}
+
+		event, ok := ueventToEvent(parseUevent(buf[:n]), sysfsRoot, known)
+		if !ok {
+			continue
+		}
+		event.Time = time.Now()
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+	return nil
+}
+
+// parseUevent splits a kernel uevent ("add@/devices/...\0ACTION=add\0...") into its variables
+func parseUevent(msg []byte) map[string]string {
+	env := make(map[string]string)
+	for _, field := range bytes.Split(msg, []byte{0}) {
+		key, value, ok := strings.Cut(string(field), "=")
+		if ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// ueventToEvent turns the uevent of a whole disk or USB device into an Event
+// known maps DEVPATH to the device's description, learned on add and forgotten on remove
+func ueventToEvent(env map[string]string, root string, known map[string]Event) (Event, bool) {
+	action := env["ACTION"]
+	if action != ActionAdd && action != ActionRemove {
+		return Event{}, false
+	}
+	devpath := env["DEVPATH"]
+
+	var event Event
+	switch {
+	case env["SUBSYSTEM"] == "block" && env["DEVTYPE"] == "disk":
+		if virtualDisk(env["DEVNAME"]) {
+			return Event{}, false
+		}
+		event = Event{Kind: KindDisk, Device: "/dev/" + env["DEVNAME"]}
+	case env["SUBSYSTEM"] == "usb" && env["DEVTYPE"] == "usb_device":
+		event = Event{Kind: KindUSB, Device: filepath.Base(devpath)}
+	default:
+		return Event{}, false
+	}
+	event.Action = action
+
+	if action == ActionAdd {
+		describe(&event, filepath.Join(root, devpath))
+		known[devpath] = event
+	} else {
+		if previous, ok := known[devpath]; ok {
+			event.Description = previous.Description
+			event.Serial = previous.Serial
+		}
+		delete(known, devpath)
+	}
+	return event, true
+}
+
+// virtualDisk reports block devices that are not hardware
+func virtualDisk(name string) bool {
+	for _, prefix := range []string{"loop", "ram", "zram", "dm-", "md", "nbd"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return name == ""
+}
+
+// describe fills in the model and serial from sysfs while the device is still there
+func describe(event *Event, dir string) {
+	read := func(path string) string {
+		data, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	if event.Kind == KindDisk {
+		event.Description = read("device/model")
+		event.Serial = read("device/serial")
+		return
+	}
+	event.Description = strings.TrimSpace(read("manufacturer") + " " + read("product"))
+	event.Serial = read("serial")
+}
+
+// scanDevices describes the disks and USB devices present now, keyed by DEVPATH
+func scanDevices(root string) map[string]Event {
+	known := make(map[string]Event)
+	add := func(dir string, event Event) {
+		target, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return
+		}
+		rel, err := filepath.Rel(root, target)
+		if err != nil {
+			return
+		}
+		describe(&event, target)
+		known["/"+rel] = event
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(root, "block")); err == nil {
+		for _, entry := range entries {
+			if !virtualDisk(entry.Name()) {
+				add(filepath.Join(root, "block", entry.Name()), Event{Kind: KindDisk, Device: "/dev/" + entry.Name()})
+			}
+		}
+	}
+	if entries, err := os.ReadDir(filepath.Join(root, "bus", "usb", "devices")); err == nil {
+		for _, entry := range entries {
+			// Interfaces (1-2:1.0) are not devices
+			if !strings.Contains(entry.Name(), ":") {
+				add(filepath.Join(root, "bus", "usb", "devices", entry.Name()), Event{Kind: KindUSB, Device: entry.Name()})
+			}
+		}
+	}
+	return known
+}
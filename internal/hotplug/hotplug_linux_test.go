@@ -0,0 +1,103 @@
+//go:build linux
+
+package hotplug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func uevent(fields ...string) []byte {
+	return []byte(strings.Join(fields, "\x00") + "\x00")
+}
+
+func TestParseUevent(t *testing.T) {
+	env := parseUevent(uevent("remove@/devices/pci0000:00/0000:00:17.0/ata3/host2/target2:0:0/2:0:0:0/block/sdc",
+		"ACTION=remove", "DEVPATH=/devices/pci0000:00/0000:00:17.0/ata3/host2/target2:0:0/2:0:0:0/block/sdc",
+		"SUBSYSTEM=block", "DEVNAME=sdc", "DEVTYPE=disk", "SEQNUM=4821"))
+
+	if env["ACTION"] != "remove" || env["DEVNAME"] != "sdc" || env["SEQNUM"] != "4821" {
+		t.Errorf("parseUevent() = %v", env)
+	}
+}
+
+func TestUeventToEvent(t *testing.T) {
+	root := t.TempDir()
+	diskPath := "/devices/pci0000:00/ata3/host2/target2:0:0/2:0:0:0/block/sdc"
+	usbPath := "/devices/pci0000:00/0000:00:14.0/usb1/1-2"
+	write := func(dir, file, content string) {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, file), []byte(content+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	write(diskPath+"/device", "model", "ST8000NM000A    ")
+	write(usbPath, "manufacturer", "SanDisk")
+	write(usbPath, "product", "Ultra")
+	write(usbPath, "serial", "4C530001")
+
+	known := map[string]Event{}
+	added, ok := ueventToEvent(map[string]string{
+		"ACTION": "add", "DEVPATH": diskPath, "SUBSYSTEM": "block", "DEVTYPE": "disk", "DEVNAME": "sdc",
+	}, root, known)
+	if !ok || added.Kind != KindDisk || added.Device != "/dev/sdc" || added.Description != "ST8000NM000A" {
+		t.Errorf("disk add = %+v, %v", added, ok)
+	}
+
+	// Once removed sysfs is gone, so the description comes from the add
+	if err := os.RemoveAll(filepath.Join(root, diskPath)); err != nil {
+		t.Fatal(err)
+	}
+	removed, ok := ueventToEvent(map[string]string{
+		"ACTION": "remove", "DEVPATH": diskPath, "SUBSYSTEM": "block", "DEVTYPE": "disk", "DEVNAME": "sdc",
+	}, root, known)
+	if !ok || removed.Action != ActionRemove || removed.Description != "ST8000NM000A" {
+		t.Errorf("disk remove = %+v, %v", removed, ok)
+	}
+
+	usb, ok := ueventToEvent(map[string]string{
+		"ACTION": "add", "DEVPATH": usbPath, "SUBSYSTEM": "usb", "DEVTYPE": "usb_device",
+	}, root, known)
+	if !ok || usb.Kind != KindUSB || usb.Device != "1-2" || usb.Description != "SanDisk Ultra" || usb.Serial != "4C530001" {
+		t.Errorf("usb add = %+v, %v", usb, ok)
+	}
+
+	ignored := []map[string]string{
+		{"ACTION": "add", "SUBSYSTEM": "block", "DEVTYPE": "partition", "DEVNAME": "sdc1"},
+		{"ACTION": "add", "SUBSYSTEM": "block", "DEVTYPE": "disk", "DEVNAME": "loop7"},
+		{"ACTION": "change", "SUBSYSTEM": "block", "DEVTYPE": "disk", "DEVNAME": "sdc"},
+		{"ACTION": "add", "SUBSYSTEM": "usb", "DEVTYPE": "usb_interface"},
+	}
+	for _, env := range ignored {
+		if event, ok := ueventToEvent(env, root, known); ok {
+			t.Errorf("ueventToEvent(%v) = %+v, expected it to be ignored", env, event)
+		}
+	}
+}
+
+func TestScanDevices(t *testing.T) {
+	root := t.TempDir()
+	devDir := filepath.Join(root, "devices", "pci0000:00", "block", "sda")
+	if err := os.MkdirAll(filepath.Join(devDir, "device"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(devDir, "device", "model"), []byte("Samsung SSD 870\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "block"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(devDir, filepath.Join(root, "block", "sda")); err != nil {
+		t.Fatal(err)
+	}
+
+	known := scanDevices(root)
+	event, ok := known["/devices/pci0000:00/block/sda"]
+	if !ok || event.Description != "Samsung SSD 870" || event.Device != "/dev/sda" {
+		t.Errorf("scanDevices() = %+v", known)
+	}
+}
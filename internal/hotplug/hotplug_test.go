@@ -0,0 +1,33 @@
+package hotplug
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	previous := map[string]Event{
+		"disk0": {Kind: KindDisk, Device: "disk0", Description: "ST8000NM000A"},
+		"disk1": {Kind: KindDisk, Device: "disk1"},
+	}
+	current := map[string]Event{
+		"disk1": {Kind: KindDisk, Device: "disk1"},
+		"usb0":  {Kind: KindUSB, Device: "usb0", Description: "SanDisk Ultra"},
+	}
+	now := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	events := diff(previous, current, now)
+	if len(events) != 2 {
+		t.Fatalf("diff() = %+v, expected one removal and one addition", events)
+	}
+	if events[0].Action != ActionRemove || events[0].Device != "disk0" || events[0].Description != "ST8000NM000A" || !events[0].Time.Equal(now) {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Action != ActionAdd || events[1].Device != "usb0" || events[1].Kind != KindUSB {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+
+	if events := diff(current, current, now); len(events) != 0 {
+		t.Errorf("diff() of identical snapshots = %+v", events)
+	}
+}
@@ -0,0 +1,84 @@
+//go:build windows
+
+package hotplug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// pollInterval is how often WMI is asked for the attached devices
+const pollInterval = 5 * time.Second
+
+type win32DiskDrive struct {
+	DeviceID     string
+	Model        string
+	SerialNumber string
+}
+
+type win32PnPEntity struct {
+	DeviceID string
+	Name     string
+}
+
+// watch polls WMI and reports the difference between snapshots; the WMI client
+// used here has no event subscriptions, and a few seconds of latency is fine for hot-swap
+func watch(ctx context.Context, events chan<- Event) error {
+	previous, err := snapshot()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := snapshot()
+			if err != nil {
+				// A transient WMI failure must not read as every device being removed
+				continue
+			}
+			for _, event := range diff(previous, current, now) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			previous = current
+		}
+	}
+}
+
+// snapshot lists the attached disks and USB devices keyed by device ID
+func snapshot() (map[string]Event, error) {
+	var disks []win32DiskDrive
+	if err := wmi.Query("SELECT DeviceID, Model, SerialNumber FROM Win32_DiskDrive", &disks); err != nil {
+		return nil, fmt.Errorf("failed to query disks: %w", err)
+	}
+	var usb []win32PnPEntity
+	if err := wmi.Query(`SELECT DeviceID, Name FROM Win32_PnPEntity WHERE DeviceID LIKE 'USB\\VID%'`, &usb); err != nil {
+		return nil, fmt.Errorf("failed to query USB devices: %w", err)
+	}
+
+	devices := make(map[string]Event, len(disks)+len(usb))
+	for _, d := range disks {
+		devices[d.DeviceID] = Event{
+			Kind:        KindDisk,
+			Device:      d.DeviceID,
+			Description: strings.TrimSpace(d.Model),
+			Serial:      strings.TrimSpace(d.SerialNumber),
+		}
+	}
+	for _, u := range usb {
+		devices[u.DeviceID] = Event{Kind: KindUSB, Device: u.DeviceID, Description: strings.TrimSpace(u.Name)}
+	}
+	return devices, nil
+}
@@ -0,0 +1,306 @@
+// Package mqtt implements the publishing half of MQTT 3.1.1 (QoS 0, retained messages and a
+// last will), which is all sysinfo needs to feed a broker without a client library
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Control packet types
+const (
+	packetConnect    = 1
+	packetConnack    = 2
+	packetPublish    = 3
+	packetPingreq    = 12
+	packetDisconnect = 14
+)
+
+const (
+	dialTimeout  = 10 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+// ErrClosed is returned when publishing on a client whose connection is gone
+var ErrClosed = errors.New("mqtt connection closed")
+
+// connackReasons are the CONNACK return codes of MQTT 3.1.1
+var connackReasons = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Message is a published message
+type Message struct {
+	Topic   string
+	Payload []byte
+	Retain  bool
+}
+
+// Options configure a connection
+type Options struct {
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration // Ping interval the broker enforces; 0 disables keep-alive
+	Will      *Message      // Published by the broker when the connection drops without a disconnect
+}
+
+// Client is a connection to a broker
+type Client struct {
+	conn net.Conn
+
+	mu  sync.Mutex // Serializes writes and guards err
+	err error
+
+	done chan struct{}
+}
+
+// Dial connects to a broker given as mqtt://host[:1883] or mqtts://host[:8883]; tcp://, ssl://
+// and tls:// are accepted too. Credentials in the URL are used unless set in opts
+func Dial(broker string, opts Options) (*Client, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker URL: %w", err)
+	}
+	if u.User != nil && opts.Username == "" {
+		opts.Username = u.User.Username()
+		opts.Password, _ = u.User.Password()
+	}
+
+	var secure bool
+	switch u.Scheme {
+	case "mqtt", "tcp":
+	case "mqtts", "ssl", "tls":
+		secure = true
+	default:
+		return nil, fmt.Errorf("unsupported broker scheme %q (expected mqtt or mqtts)", u.Scheme)
+	}
+	address := u.Host
+	if u.Port() == "" {
+		port := "1883"
+		if secure {
+			port = "8883"
+		}
+		address = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	if secure {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to broker: %w", err)
+	}
+
+	client, err := handshake(conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// handshake sends CONNECT over an established connection and waits for the broker's CONNACK
+func handshake(conn net.Conn, opts Options) (*Client, error) {
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+	if _, err := conn.Write(connectPacket(opts)); err != nil {
+		return nil, fmt.Errorf("failed to send connect: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	kind, body, err := readPacket(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read connack: %w", err)
+	}
+	if kind != packetConnack || len(body) != 2 {
+		return nil, fmt.Errorf("unexpected packet type %d instead of connack", kind)
+	}
+	if code := body[1]; code != 0 {
+		reason, ok := connackReasons[code]
+		if !ok {
+			reason = fmt.Sprintf("return code %d", code)
+		}
+		return nil, fmt.Errorf("broker refused connection: %s", reason)
+	}
+	conn.SetDeadline(time.Time{})
+
+	c := &Client{conn: conn, done: make(chan struct{})}
+	go c.readLoop(reader)
+	if opts.KeepAlive > 0 {
+		go c.pingLoop(opts.KeepAlive)
+	}
+	return c, nil
+}
+
+// Publish sends a message at QoS 0
+func (c *Client) Publish(msg Message) error {
+	header := byte(packetPublish << 4)
+	if msg.Retain {
+		header |= 0x01
+	}
+	body := appendString(nil, msg.Topic)
+	body = append(body, msg.Payload...)
+	return c.write(packet(header, body))
+}
+
+// Close disconnects cleanly, so the broker does not publish the last will
+func (c *Client) Close() error {
+	err := c.write([]byte{packetDisconnect << 4, 0})
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = ErrClosed
+	}
+	c.mu.Unlock()
+	if closeErr := c.conn.Close(); err == nil && !errors.Is(closeErr, net.ErrClosed) {
+		err = closeErr
+	}
+	return err
+}
+
+func (c *Client) write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := c.conn.Write(data); err != nil {
+		c.err = fmt.Errorf("failed to write to broker: %w", err)
+		c.conn.Close()
+		return c.err
+	}
+	return nil
+}
+
+// readLoop discards what the broker sends (ping responses) and notices when it hangs up
+func (c *Client) readLoop(reader *bufio.Reader) {
+	defer close(c.done)
+	for {
+		if _, _, err := readPacket(reader); err != nil {
+			c.mu.Lock()
+			if c.err == nil {
+				c.err = ErrClosed
+			}
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+// pingLoop keeps an idle connection alive, pinging at half the keep-alive interval
+func (c *Client) pingLoop(keepAlive time.Duration) {
+	ticker := time.NewTicker(keepAlive / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.write([]byte{packetPingreq << 4, 0}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func connectPacket(opts Options) []byte {
+	var flags byte = 0x02 // Clean session
+	if opts.Will != nil {
+		flags |= 0x04
+		if opts.Will.Retain {
+			flags |= 0x20
+		}
+	}
+	if opts.Username != "" {
+		flags |= 0x80
+		if opts.Password != "" {
+			flags |= 0x40
+		}
+	}
+
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags) // Protocol level 4 is MQTT 3.1.1
+	body = binary.BigEndian.AppendUint16(body, uint16(opts.KeepAlive/time.Second))
+	body = appendString(body, opts.ClientID)
+	if opts.Will != nil {
+		body = appendString(body, opts.Will.Topic)
+		body = binary.BigEndian.AppendUint16(body, uint16(len(opts.Will.Payload)))
+		body = append(body, opts.Will.Payload...)
+	}
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+		if opts.Password != "" {
+			body = appendString(body, opts.Password)
+		}
+	}
+	return packet(packetConnect<<4, body)
+}
+
+// packet prefixes a body with the fixed header: type and flags, then the remaining length
+func packet(header byte, body []byte) []byte {
+	out := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		out = append(out, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(out, body...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// readPacket reads one control packet and returns its type and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		multiplier *= 128
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header >> 4, body, nil
+}
@@ -0,0 +1,117 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// broker accepts one connection, answers CONNECT with the given return code and
+// reports the packets it receives
+func broker(t *testing.T, code byte) (string, <-chan [2][]byte) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	packets := make(chan [2][]byte, 16)
+	go func() {
+		defer close(packets)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			kind, body, err := readPacket(reader)
+			if err != nil {
+				return
+			}
+			packets <- [2][]byte{{kind}, body}
+			if kind == packetConnect {
+				conn.Write([]byte{packetConnack << 4, 2, 0, code})
+			}
+		}
+	}()
+	return "mqtt://user:secret@" + listener.Addr().String(), packets
+}
+
+func next(t *testing.T, packets <-chan [2][]byte) (byte, []byte) {
+	t.Helper()
+	select {
+	case p, ok := <-packets:
+		if !ok {
+			t.Fatal("connection closed before the expected packet")
+		}
+		return p[0][0], p[1]
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a packet")
+	}
+	return 0, nil
+}
+
+func TestPublish(t *testing.T) {
+	url, packets := broker(t, 0)
+
+	client, err := Dial(url, Options{
+		ClientID: "sysinfo-test",
+		Will:     &Message{Topic: "sysinfo/host/availability", Payload: []byte("offline"), Retain: true},
+	})
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+
+	kind, body := next(t, packets)
+	if kind != packetConnect {
+		t.Fatalf("first packet type = %d, expected connect", kind)
+	}
+	// Protocol name, level 4, flags: user name, password, will retain, will, clean session
+	if !bytes.HasPrefix(body, []byte("\x00\x04MQTT\x04\xe6")) {
+		t.Errorf("connect header = %q", body[:8])
+	}
+	for _, field := range []string{"sysinfo-test", "sysinfo/host/availability", "offline", "user", "secret"} {
+		if !bytes.Contains(body, []byte(field)) {
+			t.Errorf("connect packet missing %q", field)
+		}
+	}
+
+	// Long enough to need two remaining length bytes
+	payload := []byte(strings.Repeat("x", 300))
+	if err := client.Publish(Message{Topic: "sysinfo/host/state", Payload: payload, Retain: true}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	kind, body = next(t, packets)
+	if kind != packetPublish {
+		t.Fatalf("packet type = %d, expected publish", kind)
+	}
+	if want := append([]byte("\x00\x12sysinfo/host/state"), payload...); !bytes.Equal(body, want) {
+		t.Errorf("publish body = %q", body)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if kind, _ = next(t, packets); kind != packetDisconnect {
+		t.Errorf("packet type = %d, expected disconnect", kind)
+	}
+	if err := client.Publish(Message{Topic: "t"}); err == nil {
+		t.Error("expected an error publishing after Close")
+	}
+}
+
+func TestDialRefused(t *testing.T) {
+	url, _ := broker(t, 4)
+	if _, err := Dial(url, Options{ClientID: "sysinfo-test"}); err == nil || !strings.Contains(err.Error(), "bad user name or password") {
+		t.Errorf("Dial() error = %v, expected the refusal reason", err)
+	}
+
+	if _, err := Dial("http://broker", Options{}); err == nil || !strings.Contains(err.Error(), "unsupported broker scheme") {
+		t.Errorf("Dial() error = %v, expected an unsupported scheme", err)
+	}
+}
@@ -0,0 +1,64 @@
+package netwatch
+
+import "time"
+
+// FlapDetector recognises an interface whose link keeps going down
+type FlapDetector struct {
+	count   int
+	window  time.Duration
+	downs   map[string][]time.Time
+	alerted map[string]time.Time
+}
+
+// NewFlapDetector reports an interface as flapping when its link goes down count times within window
+func NewFlapDetector(count int, window time.Duration) *FlapDetector {
+	return &FlapDetector{
+		count:   count,
+		window:  window,
+		downs:   make(map[string][]time.Time),
+		alerted: make(map[string]time.Time),
+	}
+}
+
+// Observe records an event and reports whether it makes the interface flapping, with the
+// number of link downs in the window. It reports an interface at most once per window
+func (d *FlapDetector) Observe(event Event) (int, bool) {
+	if event.Action != LinkDown {
+		return 0, false
+	}
+
+	cutoff := event.Time.Add(-d.window)
+	d.forget(cutoff)
+	downs := d.downs[event.Interface][:0]
+	for _, at := range d.downs[event.Interface] {
+		if at.After(cutoff) {
+			downs = append(downs, at)
+		}
+	}
+	downs = append(downs, event.Time)
+	d.downs[event.Interface] = downs
+
+	if len(downs) < d.count {
+		return len(downs), false
+	}
+	if last, ok := d.alerted[event.Interface]; ok && last.After(cutoff) {
+		return len(downs), false
+	}
+	d.alerted[event.Interface] = event.Time
+	return len(downs), true
+}
+
+// forget drops interfaces with nothing in the window, so container hosts creating and removing
+// veth interfaces do not grow the detector for the life of the agent
+func (d *FlapDetector) forget(cutoff time.Time) {
+	for name, downs := range d.downs {
+		if len(downs) == 0 || !downs[len(downs)-1].After(cutoff) {
+			delete(d.downs, name)
+		}
+	}
+	for name, at := range d.alerted {
+		if !at.After(cutoff) {
+			delete(d.alerted, name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package netwatch reports network links going up or down and addresses being
+// added or removed, as the operating system announces them
+package netwatch
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+)
+
+// Event actions
+const (
+	LinkUp     = "link_up"
+	LinkDown   = "link_down"
+	AddrAdd    = "addr_add"
+	AddrRemove = "addr_remove"
+)
+
+// Kind is the device kind network events are recorded under alongside hot-swap events
+const Kind = "network"
+
+// rescanInterval bounds how long a change can go unnoticed if a notification is lost
+const rescanInterval = 30 * time.Second
+
+// settleDelay lets a burst of notifications (a link coming up with several addresses) finish before rescanning
+const settleDelay = 200 * time.Millisecond
+
+// Event is one change to a network interface
+type Event struct {
+	Time      time.Time
+	Interface string
+	Action    string // LinkUp, LinkDown, AddrAdd or AddrRemove
+	Address   string // CIDR, for address events
+}
+
+// ifaceState is what is compared between scans
+type ifaceState struct {
+	up    bool // Administratively up with carrier
+	addrs map[string]bool
+}
+
+// Watch sends an event on events for every link and address change until ctx is cancelled.
+// The state when it starts is not reported. It returns an error when change
+// notifications cannot be subscribed to
+func Watch(ctx context.Context, events chan<- Event) error {
+	changes, err := subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := snapshot()
+	rescan := time.NewTicker(rescanInterval)
+	defer rescan.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changes:
+			time.Sleep(settleDelay)
+			drain(changes)
+		case <-rescan.C:
+		}
+
+		current := snapshot()
+		for _, event := range diff(previous, current, time.Now()) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		previous = current
+	}
+}
+
+// drain discards notifications that arrived while settling
+func drain(changes <-chan struct{}) {
+	for {
+		select {
+		case <-changes:
+		default:
+			return
+		}
+	}
+}
+
+// notify signals a change without blocking; one pending signal covers any number of changes
+func notify(changes chan<- struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
+
+// snapshot reads the link state and addresses of every interface
+func snapshot() map[string]ifaceState {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	states := make(map[string]ifaceState, len(interfaces))
+	for _, iface := range interfaces {
+		state := ifaceState{
+			up:    iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagRunning != 0,
+			addrs: make(map[string]bool),
+		}
+		if addrs, err := iface.Addrs(); err == nil {
+			for _, addr := range addrs {
+				state.addrs[addr.String()] = true
+			}
+		}
+		states[iface.Name] = state
+	}
+	return states
+}
+
+// diff compares two scans. An interface that disappears goes down and loses its
+// addresses; a new one comes up with its addresses
+func diff(previous, current map[string]ifaceState, now time.Time) []Event {
+	// A failed scan says nothing about the interfaces
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	var events []Event
+	names := make(map[string]bool, len(previous)+len(current))
+	for name := range previous {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		before, after := previous[name], current[name]
+
+		for _, addr := range sortedKeys(before.addrs) {
+			if !after.addrs[addr] {
+				events = append(events, Event{Time: now, Interface: name, Action: AddrRemove, Address: addr})
+			}
+		}
+		switch {
+		case before.up && !after.up:
+			events = append(events, Event{Time: now, Interface: name, Action: LinkDown})
+		case !before.up && after.up:
+			events = append(events, Event{Time: now, Interface: name, Action: LinkUp})
+		}
+		for _, addr := range sortedKeys(after.addrs) {
+			if !before.addrs[addr] {
+				events = append(events, Event{Time: now, Interface: name, Action: AddrAdd, Address: addr})
+			}
+		}
+	}
+	return events
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
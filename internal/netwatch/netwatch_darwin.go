@@ -0,0 +1,51 @@
+//go:build darwin
+
+package netwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// subscribe listens on a routing socket, which announces interface and address
+// changes (RTM_IFINFO, RTM_NEWADDR, RTM_DELADDR) alongside route updates
+func subscribe(ctx context.Context) (<-chan struct{}, error) {
+	fd, err := unix.Socket(unix.AF_ROUTE, unix.SOCK_RAW, unix.AF_UNSPEC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open routing socket: %w", err)
+	}
+	unix.CloseOnExec(fd)
+	// Wake up regularly so cancellation is noticed
+	timeout := unix.NsecToTimeval(time.Second.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to configure routing socket: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer unix.Close(fd)
+		buf := make([]byte, 16<<10)
+		for ctx.Err() == nil {
+			n, err := unix.Read(fd, buf)
+			switch {
+			case err == nil:
+				// Byte 3 is the message type
+				if n > 3 && (buf[3] == unix.RTM_IFINFO || buf[3] == unix.RTM_NEWADDR || buf[3] == unix.RTM_DELADDR) {
+					notify(changes)
+				}
+			case errors.Is(err, unix.ENOBUFS):
+				notify(changes)
+			case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+			default:
+				// The periodic rescan still catches changes
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
@@ -0,0 +1,53 @@
+//go:build linux
+
+package netwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// subscribe listens on a rtnetlink socket for link and address messages. The
+// messages themselves are not decoded; any of them triggers a rescan
+func subscribe(ctx context.Context) (<-chan struct{}, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink socket: %w", err)
+	}
+	groups := uint32(unix.RTMGRP_LINK | unix.RTMGRP_IPV4_IFADDR | unix.RTMGRP_IPV6_IFADDR)
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: groups}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind netlink socket: %w", err)
+	}
+	// Wake up regularly so cancellation is noticed
+	timeout := unix.NsecToTimeval(time.Second.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to configure netlink socket: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer unix.Close(fd)
+		buf := make([]byte, 16<<10)
+		for ctx.Err() == nil {
+			_, _, err := unix.Recvfrom(fd, buf, 0)
+			switch {
+			case err == nil:
+				notify(changes)
+			case errors.Is(err, unix.ENOBUFS):
+				// Messages were dropped, so something changed
+				notify(changes)
+			case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+			default:
+				// The periodic rescan still catches changes
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
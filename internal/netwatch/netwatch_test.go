@@ -0,0 +1,74 @@
+package netwatch
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	now := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	previous := map[string]ifaceState{
+		"eth0":  {up: true, addrs: map[string]bool{"192.0.2.2/24": true, "fe80::1/64": true}},
+		"wlan0": {up: false, addrs: map[string]bool{}},
+		"tun0":  {up: true, addrs: map[string]bool{"10.8.0.2/24": true}},
+	}
+	current := map[string]ifaceState{
+		"eth0":  {up: false, addrs: map[string]bool{"fe80::1/64": true}},
+		"wlan0": {up: true, addrs: map[string]bool{"198.51.100.7/24": true}},
+		"wg0":   {up: true, addrs: map[string]bool{"10.9.0.1/24": true}},
+	}
+
+	want := []Event{
+		{Time: now, Interface: "eth0", Action: AddrRemove, Address: "192.0.2.2/24"},
+		{Time: now, Interface: "eth0", Action: LinkDown},
+		{Time: now, Interface: "tun0", Action: AddrRemove, Address: "10.8.0.2/24"},
+		{Time: now, Interface: "tun0", Action: LinkDown},
+		{Time: now, Interface: "wg0", Action: LinkUp},
+		{Time: now, Interface: "wg0", Action: AddrAdd, Address: "10.9.0.1/24"},
+		{Time: now, Interface: "wlan0", Action: LinkUp},
+		{Time: now, Interface: "wlan0", Action: AddrAdd, Address: "198.51.100.7/24"},
+	}
+	if got := diff(previous, current, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("diff() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := diff(previous, nil, now); got != nil {
+		t.Errorf("diff() against a failed scan = %+v, expected nothing", got)
+	}
+}
+
+func TestFlapDetector(t *testing.T) {
+	detector := NewFlapDetector(3, 10*time.Minute)
+	start := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	down := func(minutes int) (int, bool) {
+		return detector.Observe(Event{Time: start.Add(time.Duration(minutes) * time.Minute), Interface: "eth0", Action: LinkDown})
+	}
+
+	if _, flapping := detector.Observe(Event{Time: start, Interface: "eth0", Action: LinkUp}); flapping {
+		t.Error("link up should not count")
+	}
+	down(0)
+	down(4)
+	if n, flapping := down(8); !flapping || n != 3 {
+		t.Errorf("third down in the window = %d, %v; expected flapping", n, flapping)
+	}
+	// Already reported within the window
+	if _, flapping := down(9); flapping {
+		t.Error("flapping should be reported once per window")
+	}
+	// The downs at 0 and 4 have aged out by minute 25, leaving too few
+	if n, flapping := down(25); flapping || n != 1 {
+		t.Errorf("down after a quiet period = %d, %v", n, flapping)
+	}
+
+	// Interfaces that came and went are forgotten once their downs age out
+	for i := 0; i < 1000; i++ {
+		detector.Observe(Event{Time: start.Add(30 * time.Minute), Interface: fmt.Sprintf("veth%d", i), Action: LinkDown})
+	}
+	down(60)
+	if len(detector.downs) != 1 || len(detector.alerted) != 0 {
+		t.Errorf("detector holds %d interfaces and %d alerts, expected only eth0", len(detector.downs), len(detector.alerted))
+	}
+}
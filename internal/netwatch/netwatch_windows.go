@@ -0,0 +1,46 @@
+//go:build windows
+
+package netwatch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	// Callbacks cannot be released, so they are created once and share one channel
+	callbackOnce sync.Once
+	callback     uintptr
+	changes      = make(chan struct{}, 1)
+)
+
+// subscribe registers for IP interface and unicast address change notifications
+func subscribe(ctx context.Context) (<-chan struct{}, error) {
+	callbackOnce.Do(func() {
+		callback = syscall.NewCallback(func(callerContext, row unsafe.Pointer, notificationType uint32) uintptr {
+			notify(changes)
+			return 0
+		})
+	})
+
+	var iface, addr windows.Handle
+	if err := windows.NotifyIpInterfaceChange(windows.AF_UNSPEC, callback, nil, false, &iface); err != nil {
+		return nil, fmt.Errorf("failed to subscribe to interface changes: %w", err)
+	}
+	if err := windows.NotifyUnicastIpAddressChange(windows.AF_UNSPEC, callback, nil, false, &addr); err != nil {
+		_ = windows.CancelMibChangeNotify2(iface)
+		return nil, fmt.Errorf("failed to subscribe to address changes: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = windows.CancelMibChangeNotify2(iface)
+		_ = windows.CancelMibChangeNotify2(addr)
+	}()
+	return changes, nil
+}
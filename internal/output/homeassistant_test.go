@@ -0,0 +1,176 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/mqtt"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func homeAssistantTestInfo() *types.SystemInfo {
+	return &types.SystemInfo{
+		System: &types.SystemData{Hostname: "Media-Server", Platform: "ubuntu", PlatformVersion: "24.04", KernelVersion: "6.8.0", BootTime: 1700000000, Procs: 312},
+		CPU:    &types.CPUData{Usage: []float64{10, 20.55}},
+		Memory: &types.MemoryData{Used: 8 << 30, UsedPercent: 50},
+		Disk: &types.DiskData{
+			Partitions: []types.PartitionInfo{{MountPoint: "/", UsedPercent: 71.26}, {MountPoint: "/mnt/media", UsedPercent: 90}},
+			SMARTData:  []types.SMARTInfo{{Device: "/dev/sda", Healthy: false, Temperature: 41, PowerOnHours: 12000}},
+		},
+	}
+}
+
+func TestHomeAssistantMessages(t *testing.T) {
+	sink := NewHomeAssistantSink(config.OutputConfig{URL: "mqtt://broker"})
+	messages, err := sink.messages(homeAssistantTestInfo())
+	if err != nil {
+		t.Fatalf("messages() error = %v", err)
+	}
+
+	topics := make(map[string]mqtt.Message)
+	for _, msg := range messages {
+		if !msg.Retain {
+			t.Errorf("%s is not retained", msg.Topic)
+		}
+		topics[msg.Topic] = msg
+	}
+
+	var temp haDiscovery
+	if err := json.Unmarshal(topics["homeassistant/sensor/media_server/smart_dev_sda_temperature/config"].Payload, &temp); err != nil {
+		t.Fatalf("temperature discovery config missing or invalid: %v", err)
+	}
+	if temp.DeviceClass != "temperature" || temp.UnitOfMeasurement != "°C" || temp.StateClass != "measurement" {
+		t.Errorf("temperature sensor = %+v", temp)
+	}
+	if temp.UniqueID != "media_server_smart_dev_sda_temperature" || temp.StateTopic != "sysinfo/media_server/state" ||
+		temp.AvailabilityTopic != "sysinfo/media_server/availability" || temp.ValueTemplate != "{{ value_json.smart_dev_sda_temperature }}" {
+		t.Errorf("temperature sensor topics = %+v", temp)
+	}
+	if temp.Device.Name != "Media-Server" || temp.Device.Identifiers[0] != "sysinfo_media_server" || temp.Device.Model != "ubuntu 24.04" {
+		t.Errorf("device = %+v", temp.Device)
+	}
+
+	var problem haDiscovery
+	if err := json.Unmarshal(topics["homeassistant/binary_sensor/media_server/smart_dev_sda_problem/config"].Payload, &problem); err != nil {
+		t.Fatalf("drive problem discovery config missing or invalid: %v", err)
+	}
+	if problem.DeviceClass != "problem" || problem.PayloadOn != "ON" {
+		t.Errorf("problem sensor = %+v", problem)
+	}
+
+	if got := string(topics["sysinfo/media_server/availability"].Payload); got != "online" {
+		t.Errorf("availability = %q, expected online", got)
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(topics["sysinfo/media_server/state"].Payload, &state); err != nil {
+		t.Fatalf("state missing or invalid: %v", err)
+	}
+	for key, want := range map[string]interface{}{
+		"cpu_usage":                 15.3,
+		"disk_root":                 71.3,
+		"disk_mnt_media":            90.0,
+		"smart_dev_sda_problem":     "ON",
+		"smart_dev_sda_temperature": 41.0,
+		"last_boot":                 "2023-11-14T22:13:20Z",
+		"processes":                 312.0,
+	} {
+		if state[key] != want {
+			t.Errorf("state[%s] = %v, expected %v", key, state[key], want)
+		}
+	}
+
+	// Every entity reads a field the state holds
+	for topic := range topics {
+		if strings.HasSuffix(topic, "/config") {
+			key := strings.Split(topic, "/")[3]
+			if _, ok := state[key]; !ok {
+				t.Errorf("%s has no state field", topic)
+			}
+		}
+	}
+}
+
+func TestHomeAssistantSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	topics := make(chan string, 64)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			header, err := reader.ReadByte()
+			if err != nil {
+				close(topics)
+				return
+			}
+			length, multiplier := 0, 1
+			for {
+				digit, _ := reader.ReadByte()
+				length += int(digit&0x7f) * multiplier
+				multiplier *= 128
+				if digit&0x80 == 0 {
+					break
+				}
+			}
+			body := make([]byte, length)
+			io.ReadFull(reader, body)
+
+			switch header >> 4 {
+			case 1: // CONNECT
+				conn.Write([]byte{0x20, 2, 0, 0})
+			case 3: // PUBLISH
+				topicLen := int(body[0])<<8 | int(body[1])
+				topics <- string(body[2 : 2+topicLen])
+			}
+		}
+	}()
+
+	sink := NewHomeAssistantSink(config.OutputConfig{URL: "mqtt://user:secret@" + listener.Addr().String(), HostID: "nas", DiscoveryPrefix: "ha"})
+	if strings.Contains(sink.Name(), "secret") {
+		t.Errorf("Name() = %q exposes the password", sink.Name())
+	}
+	if err := sink.Write(&types.SystemInfo{Memory: &types.MemoryData{UsedPercent: 40}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var got []string
+	timeout := time.After(2 * time.Second)
+	for done := false; !done; {
+		select {
+		case topic, ok := <-topics:
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, topic)
+		case <-timeout:
+			t.Fatal("timed out waiting for the broker to see the disconnect")
+		}
+	}
+	want := []string{
+		"ha/sensor/nas/memory_usage/config",
+		"ha/sensor/nas/memory_used/config",
+		"sysinfo/nas/availability",
+		"sysinfo/nas/state",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("published %v, expected %v", got, want)
+	}
+}
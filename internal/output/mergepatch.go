@@ -0,0 +1,65 @@
+package output
+
+import "reflect"
+
+// MergePatch computes an RFC 7396 JSON merge patch that turns prev into curr
+// Both values are decoded JSON (map[string]any, []any, string, float64, bool, nil)
+// Arrays are replaced wholesale, as merge patch cannot address individual elements
+func MergePatch(prev, curr map[string]any) map[string]any {
+	patch := make(map[string]any)
+
+	for key := range prev {
+		if _, ok := curr[key]; !ok {
+			// null removes the member on the receiving side
+			patch[key] = nil
+		}
+	}
+
+	for key, currValue := range curr {
+		prevValue, ok := prev[key]
+		if !ok {
+			patch[key] = currValue
+			continue
+		}
+
+		prevMap, prevIsMap := prevValue.(map[string]any)
+		currMap, currIsMap := currValue.(map[string]any)
+		if prevIsMap && currIsMap {
+			if nested := MergePatch(prevMap, currMap); len(nested) > 0 {
+				patch[key] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(prevValue, currValue) {
+			patch[key] = currValue
+		}
+	}
+
+	return patch
+}
+
+// ApplyMergePatch applies an RFC 7396 merge patch to target and returns the result
+// target is modified in place
+func ApplyMergePatch(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any)
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		if patchMap, ok := value.(map[string]any); ok {
+			targetMap, _ := target[key].(map[string]any)
+			target[key] = ApplyMergePatch(targetMap, patchMap)
+			continue
+		}
+
+		target[key] = value
+	}
+
+	return target
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var v map[string]any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON %q: %v", s, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		curr string
+		want string
+	}{
+		{
+			name: "Unchanged",
+			prev: `{"a":1,"b":{"c":"x"}}`,
+			curr: `{"a":1,"b":{"c":"x"}}`,
+			want: `{}`,
+		},
+		{
+			name: "Changed scalar",
+			prev: `{"a":1,"b":2}`,
+			curr: `{"a":1,"b":3}`,
+			want: `{"b":3}`,
+		},
+		{
+			name: "Nested change",
+			prev: `{"memory":{"total":100,"used":40}}`,
+			curr: `{"memory":{"total":100,"used":55}}`,
+			want: `{"memory":{"used":55}}`,
+		},
+		{
+			name: "Removed member",
+			prev: `{"a":1,"gpu":{"name":"x"}}`,
+			curr: `{"a":1}`,
+			want: `{"gpu":null}`,
+		},
+		{
+			name: "Added member",
+			prev: `{"a":1}`,
+			curr: `{"a":1,"battery":{"percent":80}}`,
+			want: `{"battery":{"percent":80}}`,
+		},
+		{
+			name: "Array replaced wholesale",
+			prev: `{"disks":[1,2]}`,
+			curr: `{"disks":[1,2,3]}`,
+			want: `{"disks":[1,2,3]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, curr := decode(t, tt.prev), decode(t, tt.curr)
+			got := MergePatch(prev, curr)
+			if !reflect.DeepEqual(got, decode(t, tt.want)) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("MergePatch() = %s, expected %s", gotJSON, tt.want)
+			}
+
+			// Applying the patch to the previous document must reproduce the current one
+			applied := ApplyMergePatch(decode(t, tt.prev), got)
+			if !reflect.DeepEqual(applied, curr) {
+				appliedJSON, _ := json.Marshal(applied)
+				t.Errorf("ApplyMergePatch() = %s, expected %s", appliedJSON, tt.curr)
+			}
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package output
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Sink delivers a report to one destination
+type Sink interface {
+	// Name describes the destination for progress and error messages
+	Name() string
+	// Write formats and delivers the report
+	Write(info *types.SystemInfo) error
+}
+
+// Build creates the sinks for a run
+// Without configured outputs this is a single stdout or --output file sink, as before
+func Build(cfg *config.Config) ([]Sink, error) {
+	if len(cfg.Outputs) == 0 {
+		if cfg.OutputFile != "" {
+			return []Sink{fileSink(cfg.OutputFile, cfg)}, nil
+		}
+		if cfg.Format == "sqlite" {
+			return nil, errors.New("the sqlite format writes a database file, set one with --output")
+		}
+		return []Sink{&StdoutSink{Writer: os.Stdout, cfg: cfg}}, nil
+	}
+
+	sinks := make([]Sink, 0, len(cfg.Outputs))
+	for i, out := range cfg.Outputs {
+		// Each sink formats with its own format but shares every other option
+		sinkCfg := *cfg
+		if out.Format != "" {
+			sinkCfg.Format = out.Format
+		}
+
+		switch out.Type {
+		case "stdout":
+			if sinkCfg.Format == "sqlite" {
+				return nil, fmt.Errorf("output %d: the sqlite format needs a file sink", i+1)
+			}
+			sinks = append(sinks, &StdoutSink{Writer: os.Stdout, cfg: &sinkCfg})
+		case "file":
+			if out.Path == "" {
+				return nil, fmt.Errorf("output %d: file sink requires a path", i+1)
+			}
+			sinks = append(sinks, fileSink(out.Path, &sinkCfg))
+		case "webhook":
+			if out.URL == "" {
+				return nil, fmt.Errorf("output %d: webhook sink requires a url", i+1)
+			}
+			if out.Format == "" {
+				sinkCfg.Format = "json"
+			}
+			if out.Delta && sinkCfg.Format != "json" {
+				return nil, fmt.Errorf("output %d: delta webhooks require json format", i+1)
+			}
+			sinks = append(sinks, NewWebhookSink(out, &sinkCfg))
+		case "scrutiny":
+			if out.URL == "" {
+				return nil, fmt.Errorf("output %d: scrutiny sink requires a url", i+1)
+			}
+			sinks = append(sinks, NewScrutinySink(out))
+		case "homeassistant":
+			if out.URL == "" {
+				return nil, fmt.Errorf("output %d: homeassistant sink requires a broker url", i+1)
+			}
+			sinks = append(sinks, NewHomeAssistantSink(out))
+		default:
+			return nil, fmt.Errorf("output %d: unknown sink type: %s", i+1, out.Type)
+		}
+	}
+
+	return sinks, nil
+}
+
+// fileSink writes to path in the configured format, or as a SQLite database when the format
+// is sqlite or left at its default and the path ends in .db, .sqlite or .sqlite3. A path
+// ending in .parquet likewise picks the parquet format
+func fileSink(path string, cfg *config.Config) Sink {
+	if cfg.Query == "" && (cfg.Format == "sqlite" || cfg.Format == "pretty" && IsSQLitePath(path)) {
+		return &SQLiteSink{Path: path}
+	}
+	if cfg.Query == "" && cfg.Format == "pretty" && strings.EqualFold(filepath.Ext(path), ".parquet") {
+		parquetCfg := *cfg
+		parquetCfg.Format = "parquet"
+		cfg = &parquetCfg
+	}
+	return &FileSink{Path: path, cfg: cfg}
+}
+
+// IsPush reports whether a sink sends reports to a remote server rather than writing them locally
+func IsPush(sink Sink) bool {
+	switch sink.(type) {
+	case *WebhookSink, *ScrutinySink, *HomeAssistantSink:
+		return true
+	}
+	return false
+}
+
+// Close releases sinks that keep a connection open between reports
+func Close(sinks []Sink) {
+	for _, sink := range sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
+
+// WriteAll delivers the report to every sink, continuing past failures
+func WriteAll(sinks []Sink, info *types.SystemInfo, verbose bool) error {
+	var failed []error
+	for _, sink := range sinks {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Writing to %s\n", sink.Name())
+		}
+		if err := sink.Write(info); err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		return failed[0]
+	default:
+		return fmt.Errorf("%d outputs failed: %w", len(failed), errors.Join(failed...))
+	}
+}
+
+// StdoutSink prints the report
+type StdoutSink struct {
+	Writer io.Writer
+	cfg    *config.Config
+}
+
+func (s *StdoutSink) Name() string {
+	return "stdout"
+}
+
+func (s *StdoutSink) Write(info *types.SystemInfo) error {
+	output, err := formatter.Format(info, s.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	_, err = fmt.Fprint(s.Writer, output)
+	return err
+}
+
+// FileSink writes the report to a file
+// NDJSON and MessagePack reports are appended, so repeated runs build up a stream of reports
+type FileSink struct {
+	Path string
+	cfg  *config.Config
+}
+
+func (s *FileSink) Name() string {
+	return "file " + s.Path
+}
+
+func (s *FileSink) Write(info *types.SystemInfo) error {
+	output, err := formatter.Format(info, s.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	if s.cfg.Format == "ndjson" || s.cfg.Format == "msgpack" {
+		if err := appendFile(s.Path, []byte(output)); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(s.Path, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Output written to: %s\n", s.Path)
+	return nil
+}
+
+func appendFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WebhookSink POSTs the report to an HTTP endpoint
+type WebhookSink struct {
+	URL     string
+	Headers map[string]string
+	cfg     *config.Config
+	client  *http.Client
+
+	// Delta mode sends a merge patch against the baseline stored at StatePath
+	Delta     bool
+	StatePath string
+}
+
+// NewWebhookSink creates a webhook sink from its output configuration
+func NewWebhookSink(out config.OutputConfig, cfg *config.Config) *WebhookSink {
+	timeout := out.Timeout
+	if timeout == 0 {
+		timeout = 30
+	}
+	sink := &WebhookSink{
+		URL:       out.URL,
+		Headers:   out.Headers,
+		cfg:       cfg,
+		client:    &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		Delta:     out.Delta,
+		StatePath: out.StatePath,
+	}
+	if sink.Delta && sink.StatePath == "" {
+		sink.StatePath = defaultDeltaStatePath(out.URL)
+	}
+	return sink
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook " + s.URL
+}
+
+func (s *WebhookSink) Write(info *types.SystemInfo) error {
+	output, err := formatter.Format(info, s.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if s.Delta {
+		return s.writeDelta(output)
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	switch s.cfg.Format {
+	case "json":
+		contentType = "application/json"
+	case "ndjson":
+		contentType = "application/x-ndjson"
+	case "html":
+		contentType = "text/html; charset=utf-8"
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	case "xml":
+		contentType = "application/xml"
+	case "msgpack":
+		contentType = "application/x-msgpack"
+	case "parquet":
+		contentType = "application/vnd.apache.parquet"
+	case "dot":
+		contentType = "text/vnd.graphviz"
+	case "prometheus":
+		contentType = "text/plain; version=0.0.4; charset=utf-8"
+	}
+	status, err := s.post([]byte(output), contentType, nil)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("webhook returned status %d", status)
+	}
+	return nil
+}
+
+// writeDelta sends only what changed since the last acknowledged report
+// Every push carries a new X-SysInfo-Report-ID, and a delta names the report it patches in
+// X-SysInfo-Baseline. When the receiver rejects the delta with a 4xx, e.g. 409 or 412 because
+// it no longer holds that report, the full report is resent
+func (s *WebhookSink) writeDelta(output string) error {
+	var current map[string]any
+	if err := json.Unmarshal([]byte(output), &current); err != nil {
+		return fmt.Errorf("failed to decode report for delta: %w", err)
+	}
+	id, err := newReportID()
+	if err != nil {
+		return err
+	}
+
+	if baseline, ok := s.loadBaseline(); ok {
+		patch, err := json.Marshal(MergePatch(baseline.Report, current))
+		if err != nil {
+			return fmt.Errorf("failed to encode delta: %w", err)
+		}
+		headers := map[string]string{
+			"X-SysInfo-Report":    "delta",
+			"X-SysInfo-Report-ID": id,
+			"X-SysInfo-Baseline":  baseline.ID,
+		}
+		status, err := s.post(patch, "application/merge-patch+json", headers)
+		if err != nil {
+			return err
+		}
+		switch {
+		case status >= 200 && status < 300:
+			return s.saveBaseline(deltaBaseline{ID: id, Report: current})
+		case status < 400 || status >= 500:
+			return fmt.Errorf("webhook returned status %d", status)
+		}
+	}
+
+	full, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	status, err := s.post(full, "application/json", map[string]string{
+		"X-SysInfo-Report":    "full",
+		"X-SysInfo-Report-ID": id,
+	})
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("webhook returned status %d", status)
+	}
+	return s.saveBaseline(deltaBaseline{ID: id, Report: current})
+}
+
+// post sends body to the webhook and returns the response status
+func (s *WebhookSink) post(body []byte, contentType string, extra map[string]string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "SysInfo-Output/1.0")
+	for key, value := range extra {
+		req.Header.Set(key, value)
+	}
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// deltaBaseline is the last acknowledged report and the ID it was pushed with
+type deltaBaseline struct {
+	ID     string         `json:"id"`
+	Report map[string]any `json:"report"`
+}
+
+// loadBaseline reads the last acknowledged report; baselines saved without an ID are not used
+func (s *WebhookSink) loadBaseline() (deltaBaseline, bool) {
+	var baseline deltaBaseline
+	data, err := os.ReadFile(s.StatePath)
+	if err != nil {
+		return baseline, false
+	}
+	if err := json.Unmarshal(data, &baseline); err != nil || baseline.ID == "" || baseline.Report == nil {
+		return baseline, false
+	}
+	return baseline, true
+}
+
+// saveBaseline records an acknowledged report as the base for the next delta
+func (s *WebhookSink) saveBaseline(baseline deltaBaseline) error {
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.StatePath), 0755); err != nil {
+		return fmt.Errorf("failed to create delta state directory: %w", err)
+	}
+	if err := os.WriteFile(s.StatePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to save delta baseline: %w", err)
+	}
+	return nil
+}
+
+// newReportID returns an opaque ID for a push, which the receiver keeps with the report so the
+// next delta can name it as its baseline
+func newReportID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate report ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// defaultDeltaStatePath keeps one baseline per endpoint in the user cache directory
+func defaultDeltaStatePath(url string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, "sysinfo", "push", hex.EncodeToString(sum[:8])+".json")
+}
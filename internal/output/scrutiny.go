@@ -0,0 +1,153 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/formatter"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// ScrutinySink submits SMART data to a Scrutiny server through its collector API, taking
+// the place of scrutiny-collector-metrics: drives are registered, then each drive's data
+// is sent as smartctl JSON
+type ScrutinySink struct {
+	URL     string // Base URL of the Scrutiny web server, e.g. http://scrutiny:8080
+	HostID  string // Host label shown on the Scrutiny dashboard
+	Headers map[string]string
+	client  *http.Client
+}
+
+// scrutinyDevice is a drive as registered with Scrutiny's /api/devices/register
+type scrutinyDevice struct {
+	WWN            string `json:"wwn"`
+	HostID         string `json:"host_id,omitempty"`
+	DeviceName     string `json:"device_name"`
+	ModelName      string `json:"model_name,omitempty"`
+	SerialNumber   string `json:"serial_number,omitempty"`
+	Firmware       string `json:"firmware,omitempty"`
+	RotationSpeed  int    `json:"rotational_speed"`
+	Capacity       int64  `json:"capacity"`
+	FormFactor     string `json:"form_factor,omitempty"`
+	SmartSupport   bool   `json:"smart_support"`
+	DeviceProtocol string `json:"device_protocol"`
+	DeviceType     string `json:"device_type"`
+}
+
+// NewScrutinySink creates a Scrutiny sink from its output configuration
+func NewScrutinySink(out config.OutputConfig) *ScrutinySink {
+	timeout := out.Timeout
+	if timeout == 0 {
+		timeout = 30
+	}
+	return &ScrutinySink{
+		URL:     strings.TrimSuffix(out.URL, "/"),
+		HostID:  out.HostID,
+		Headers: out.Headers,
+		client:  &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+}
+
+func (s *ScrutinySink) Name() string {
+	return "scrutiny " + s.URL
+}
+
+func (s *ScrutinySink) Write(info *types.SystemInfo) error {
+	if info.Disk == nil || len(info.Disk.SMARTData) == 0 {
+		return errors.New("no SMART data collected (SMART needs elevated privileges)")
+	}
+
+	// Scrutiny keys drives by WWN; sysinfo does not read one, so the serial stands in,
+	// as Scrutiny's own collector does for drives without a WWN
+	var devices []scrutinyDevice
+	var drives []types.SMARTInfo
+	for _, smart := range info.Disk.SMARTData {
+		if smart.Serial == "" {
+			continue
+		}
+		devices = append(devices, scrutinyDeviceFor(smart, s.HostID))
+		drives = append(drives, smart)
+	}
+	if len(devices) == 0 {
+		return errors.New("no drive reported a serial number to identify it by")
+	}
+
+	body, err := json.Marshal(map[string][]scrutinyDevice{"data": devices})
+	if err != nil {
+		return fmt.Errorf("failed to encode devices: %w", err)
+	}
+	if err := s.post("/api/devices/register", body); err != nil {
+		return fmt.Errorf("failed to register devices: %w", err)
+	}
+
+	var failed []error
+	for i, smart := range drives {
+		report, err := formatter.FormatSmartctl(smart)
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", smart.Device, err))
+			continue
+		}
+		if err := s.post("/api/device/"+url.PathEscape(devices[i].WWN)+"/smart", []byte(report)); err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", smart.Device, err))
+		}
+	}
+	return errors.Join(failed...)
+}
+
+// post sends a JSON body to a collector API endpoint
+func (s *ScrutinySink) post(path string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SysInfo-Output/1.0")
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("scrutiny returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func scrutinyDeviceFor(smart types.SMARTInfo, hostID string) scrutinyDevice {
+	deviceType, protocol := formatter.SmartctlDeviceType(smart)
+	return scrutinyDevice{
+		WWN:            strings.ToLower(smart.Serial),
+		HostID:         hostID,
+		DeviceName:     scrutinyDeviceName(smart.Device),
+		ModelName:      smart.DeviceModel,
+		SerialNumber:   smart.Serial,
+		Firmware:       smart.FirmwareVersion,
+		RotationSpeed:  int(smart.RotationRate),
+		Capacity:       int64(smart.Capacity),
+		FormFactor:     smart.FormFactor,
+		SmartSupport:   true,
+		DeviceProtocol: protocol,
+		DeviceType:     deviceType,
+	}
+}
+
+// scrutinyDeviceName strips the device directory, as Scrutiny shows "sda" for /dev/sda;
+// Windows names such as \\.\PHYSICALDRIVE0 become PHYSICALDRIVE0
+func scrutinyDeviceName(device string) string {
+	if i := strings.LastIndexAny(device, `/\`); i >= 0 {
+		return device[i+1:]
+	}
+	return device
+}
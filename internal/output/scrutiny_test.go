@@ -0,0 +1,101 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestScrutinySink(t *testing.T) {
+	var mu sync.Mutex
+	bodies := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	sink := NewScrutinySink(config.OutputConfig{
+		URL:     server.URL + "/",
+		HostID:  "nas",
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	})
+	info := &types.SystemInfo{Disk: &types.DiskData{SMARTData: []types.SMARTInfo{
+		{Device: `\\.\PHYSICALDRIVE0`, Serial: "WD-ABC123", DeviceModel: "WDC WD40EFRX", Capacity: 4000787030016, RotationRate: 5400, Healthy: true},
+		{Device: "/dev/nvme0", Serial: "S5GXNX0R", Healthy: true},
+		{Device: "/dev/sdz", Healthy: true}, // No serial to key it by
+	}}}
+	if err := sink.Write(info); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var registered struct {
+		Data []scrutinyDevice `json:"data"`
+	}
+	if err := json.Unmarshal(bodies["/api/devices/register"], &registered); err != nil {
+		t.Fatalf("register body is not JSON: %v", err)
+	}
+	if len(registered.Data) != 2 {
+		t.Fatalf("registered %d devices, expected 2", len(registered.Data))
+	}
+	want := scrutinyDevice{
+		WWN: "wd-abc123", HostID: "nas", DeviceName: "PHYSICALDRIVE0", ModelName: "WDC WD40EFRX", SerialNumber: "WD-ABC123",
+		RotationSpeed: 5400, Capacity: 4000787030016, SmartSupport: true, DeviceProtocol: "ATA", DeviceType: "sat",
+	}
+	if registered.Data[0] != want {
+		t.Errorf("device = %+v, expected %+v", registered.Data[0], want)
+	}
+	if registered.Data[1].DeviceName != "nvme0" || registered.Data[1].DeviceProtocol != "NVMe" {
+		t.Errorf("NVMe device = %+v", registered.Data[1])
+	}
+
+	var report struct {
+		Device struct {
+			Name string `json:"name"`
+		} `json:"device"`
+		SerialNumber string `json:"serial_number"`
+	}
+	if err := json.Unmarshal(bodies["/api/device/wd-abc123/smart"], &report); err != nil {
+		t.Fatalf("smart body is not JSON: %v", err)
+	}
+	if report.Device.Name != `\\.\PHYSICALDRIVE0` || report.SerialNumber != "WD-ABC123" {
+		t.Errorf("smartctl report = %+v", report)
+	}
+	if _, ok := bodies["/api/device/s5gxnx0r/smart"]; !ok {
+		t.Error("NVMe drive data was not submitted")
+	}
+	if len(bodies) != 3 {
+		t.Errorf("got %d requests, expected register and two drives", len(bodies))
+	}
+}
+
+func TestScrutinySinkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	sink := NewScrutinySink(config.OutputConfig{URL: server.URL})
+
+	if err := sink.Write(&types.SystemInfo{}); err == nil || !strings.Contains(err.Error(), "no SMART data") {
+		t.Errorf("Write() without SMART data error = %v", err)
+	}
+
+	info := &types.SystemInfo{Disk: &types.DiskData{SMARTData: []types.SMARTInfo{{Device: "/dev/sda", Serial: "X"}}}}
+	if err := sink.Write(info); err == nil || !strings.Contains(err.Error(), "status 500") {
+		t.Errorf("Write() error = %v, expected status 500", err)
+	}
+}
@@ -0,0 +1,450 @@
+package output
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchemaVersion is stored as the database's user_version; later versions only add
+// tables and columns, which existing databases gain when the next report is written
+const sqliteSchemaVersion = 1
+
+// sqliteExtensions are output file extensions written as a SQLite database without --format sqlite
+var sqliteExtensions = []string{".db", ".sqlite", ".sqlite3"}
+
+// IsSQLitePath reports whether an output path names a SQLite database by its extension
+func IsSQLitePath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range sqliteExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// SQLiteSink appends reports to a SQLite database, so they can be queried with plain SQL.
+// Each report is a row in the reports table, each module a table named after its JSON key
+// with one row per report, and each list of records, such as disk partitions or SMART
+// attributes, a child table named after its path, e.g. disk_smart_data_detailed_attributes. Lists of
+// plain values and maps are stored as JSON text, to be read with json_each
+type SQLiteSink struct {
+	Path string
+}
+
+func (s *SQLiteSink) Name() string {
+	return "sqlite " + s.Path
+}
+
+func (s *SQLiteSink) Write(info *types.SystemInfo) error {
+	db, err := sql.Open("sqlite", s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	// Pragmas are per connection
+	db.SetMaxOpenConns(1)
+	for _, pragma := range []string{"PRAGMA foreign_keys = ON", "PRAGMA busy_timeout = 5000"} {
+		if _, err := db.Exec(pragma); err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to write database: %w", err)
+	}
+	defer tx.Rollback()
+
+	schema := reportSchema()
+	if err := schema.create(tx); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+	if err := schema.insert(tx, info); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write database: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Output written to: %s\n", s.Path)
+	return nil
+}
+
+// sqliteSchema maps the report to tables
+type sqliteSchema struct {
+	modules []sqliteChild // Module tables, reached from SystemInfo
+	errors  *sqliteTable
+}
+
+// sqliteTable holds rows of one struct type: its fields, flattened, as columns, and its
+// lists of records as child tables
+type sqliteTable struct {
+	name     string
+	parent   *sqliteTable
+	columns  []sqliteColumn
+	children []sqliteChild
+}
+
+type sqliteColumn struct {
+	name  string
+	typ   string // SQLite type affinity
+	index []int  // Field index path from the row's struct
+}
+
+type sqliteChild struct {
+	index []int // Field index path of the record or list in the parent's struct
+	table *sqliteTable
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// reportSchema derives the tables from the report types, so every table exists with all its
+// columns whatever was collected
+func reportSchema() *sqliteSchema {
+	schema := &sqliteSchema{}
+	t := reflect.TypeOf(types.SystemInfo{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, ok := sqliteField(field)
+		if !ok {
+			continue
+		}
+		switch {
+		case field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct:
+			schema.modules = append(schema.modules, sqliteChild{index: []int{i}, table: newSQLiteTable(name, nil, field.Type.Elem())})
+		case field.Name == "Errors":
+			schema.errors = newSQLiteTable(name, nil, field.Type.Elem())
+		}
+	}
+	return schema
+}
+
+func newSQLiteTable(name string, parent *sqliteTable, t reflect.Type) *sqliteTable {
+	table := &sqliteTable{name: name, parent: parent}
+	table.addFields(t, nil, "")
+	return table
+}
+
+// addFields adds the fields of t as columns, prefixing nested records with their name
+func (t *sqliteTable) addFields(typ reflect.Type, index []int, prefix string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, embedded, ok := sqliteField(field)
+		if !ok {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		base := field.Type
+		if base.Kind() == reflect.Pointer {
+			base = base.Elem()
+		}
+
+		switch {
+		case embedded:
+			t.addFields(base, fieldIndex, prefix)
+		case base == timeType:
+			t.columns = append(t.columns, sqliteColumn{name: prefix + name, typ: "TEXT", index: fieldIndex})
+		case base.Kind() == reflect.Struct:
+			t.addFields(base, fieldIndex, prefix+name+"_")
+		case base.Kind() == reflect.Slice && isRecord(base.Elem()):
+			elem := base.Elem()
+			if elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			child := newSQLiteTable(t.name+"_"+prefix+name, t, elem)
+			t.children = append(t.children, sqliteChild{index: fieldIndex, table: child})
+		default:
+			t.columns = append(t.columns, sqliteColumn{name: prefix + name, typ: sqliteType(base), index: fieldIndex})
+		}
+	}
+}
+
+// isRecord reports whether list elements of type t get a child table
+func isRecord(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// sqliteField returns the column name of a struct field, from its JSON key, and whether it
+// is embedded, like encoding/json; ok is false for fields left out of the report
+func sqliteField(field reflect.StructField) (name string, embedded, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if field.Anonymous && name == "" {
+		return "", true, true
+	}
+	if !field.IsExported() {
+		return "", false, false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false, true
+}
+
+func sqliteType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// create creates the tables missing from the database and adds columns missing from tables
+// an older version created
+func (s *sqliteSchema) create(tx *sql.Tx) error {
+	if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS reports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp TEXT NOT NULL,
+		hostname TEXT,
+		redacted INTEGER NOT NULL
+	)`); err != nil {
+		return err
+	}
+	if err := s.errors.create(tx); err != nil {
+		return err
+	}
+	for _, module := range s.modules {
+		if err := module.table.create(tx); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", sqliteSchemaVersion))
+	return err
+}
+
+func (t *sqliteTable) create(tx *sql.Tx) error {
+	keys := []string{
+		"row_id INTEGER PRIMARY KEY AUTOINCREMENT",
+		"report_id INTEGER NOT NULL REFERENCES reports(id) ON DELETE CASCADE",
+	}
+	if t.parent != nil {
+		keys = append(keys,
+			fmt.Sprintf("parent_row_id INTEGER NOT NULL REFERENCES %s(row_id) ON DELETE CASCADE", quoteIdent(t.parent.name)),
+			"position INTEGER NOT NULL")
+	}
+	columns := keys
+	for _, c := range t.columns {
+		columns = append(columns, quoteIdent(c.name)+" "+c.typ)
+	}
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", quoteIdent(t.name), strings.Join(columns, ",\n\t"))
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+	index := "report_id"
+	if t.parent != nil {
+		index = "parent_row_id"
+	}
+	query = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", quoteIdent(t.name+"_"+index), quoteIdent(t.name), index)
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+
+	existing, err := tableColumns(tx, t.name)
+	if err != nil {
+		return err
+	}
+	for _, c := range t.columns {
+		if existing[c.name] {
+			continue
+		}
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", quoteIdent(t.name), quoteIdent(c.name), c.typ)
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range t.children {
+		if err := child.table.create(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tableColumns(tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+// insert adds the report and the modules it holds
+func (s *sqliteSchema) insert(tx *sql.Tx, info *types.SystemInfo) error {
+	var hostname any
+	if info.System != nil && info.System.Hostname != "" {
+		hostname = info.System.Hostname
+	}
+	result, err := tx.Exec("INSERT INTO reports (timestamp, hostname, redacted) VALUES (?, ?, ?)",
+		info.Timestamp.Format(time.RFC3339Nano), hostname, info.Redacted)
+	if err != nil {
+		return err
+	}
+	reportID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for i, e := range info.Errors {
+		if err := s.errors.insert(tx, reportID, 0, i, reflect.ValueOf(e)); err != nil {
+			return err
+		}
+	}
+	report := reflect.ValueOf(info).Elem()
+	for _, module := range s.modules {
+		if row, ok := fieldByIndex(report, module.index); ok {
+			if err := module.table.insert(tx, reportID, 0, 0, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insert adds one row, then the rows of its child tables
+func (t *sqliteTable) insert(tx *sql.Tx, reportID, parentID int64, position int, row reflect.Value) error {
+	names := []string{"report_id"}
+	values := []any{reportID}
+	if t.parent != nil {
+		names = append(names, "parent_row_id", "position")
+		values = append(values, parentID, position)
+	}
+	for _, c := range t.columns {
+		value, err := sqliteValue(row, c.index)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.name, c.name, err)
+		}
+		names = append(names, quoteIdent(c.name))
+		values = append(values, value)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quoteIdent(t.name), strings.Join(names, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+	result, err := tx.Exec(query, values...)
+	if err != nil {
+		return err
+	}
+	if len(t.children) == 0 {
+		return nil
+	}
+	rowID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, child := range t.children {
+		list, ok := fieldByIndex(row, child.index)
+		if !ok {
+			continue
+		}
+		for i := 0; i < list.Len(); i++ {
+			elem, ok := deref(list.Index(i))
+			if !ok {
+				continue
+			}
+			if err := child.table.insert(tx, reportID, rowID, i, elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldByIndex follows a field index path through pointers; ok is false when one is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		var ok bool
+		if v, ok = deref(v); !ok {
+			return reflect.Value{}, false
+		}
+		v = v.Field(i)
+	}
+	return deref(v)
+}
+
+func deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// sqliteValue returns a column's value in the row, nil for unset pointers, times and lists
+func sqliteValue(row reflect.Value, index []int) (any, error) {
+	v, ok := fieldByIndex(row, index)
+	if !ok {
+		return nil, nil
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t.Format(time.RFC3339Nano), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return float64(v.Uint()), nil
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32:
+		// As JSON writes it, without float32 noise such as 45.099998
+		return strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
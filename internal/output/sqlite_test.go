@@ -0,0 +1,159 @@
+package output
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+func TestIsSQLitePath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"report.db":               true,
+		"/var/lib/reports.SQLite": true,
+		"fleet.sqlite3":           true,
+		"report.json":             false,
+		"db":                      false,
+	} {
+		if got := IsSQLitePath(path); got != want {
+			t.Errorf("IsSQLitePath(%q) = %v, expected %v", path, got, want)
+		}
+	}
+}
+
+func TestBuildSQLite(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		wantErr bool
+		sqlite  bool
+	}{
+		{name: "Default format to .db", cfg: &config.Config{Format: "pretty", OutputFile: "report.db"}, sqlite: true},
+		{name: "Explicit format", cfg: &config.Config{Format: "sqlite", OutputFile: "report.out"}, sqlite: true},
+		{name: "Explicit other format to .db", cfg: &config.Config{Format: "json", OutputFile: "report.db"}},
+		{name: "File output", cfg: &config.Config{Format: "text", Outputs: []config.OutputConfig{{Type: "file", Path: "r.db", Format: "sqlite"}}}, sqlite: true},
+		{name: "Stdout", cfg: &config.Config{Format: "sqlite"}, wantErr: true},
+		{name: "Stdout output", cfg: &config.Config{Outputs: []config.OutputConfig{{Type: "stdout", Format: "sqlite"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks, err := Build(tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Build() should fail without a file to write the database to")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if _, ok := sinks[0].(*SQLiteSink); ok != tt.sqlite {
+				t.Errorf("Build() = %T, expected a SQLite sink: %v", sinks[0], tt.sqlite)
+			}
+		})
+	}
+}
+
+func TestReportSchema(t *testing.T) {
+	schema := reportSchema()
+	tables := map[string]*sqliteTable{}
+	var walk func(table *sqliteTable)
+	walk = func(table *sqliteTable) {
+		tables[table.name] = table
+		for _, child := range table.children {
+			walk(child.table)
+		}
+	}
+	walk(schema.errors)
+	for _, module := range schema.modules {
+		walk(module.table)
+	}
+
+	for _, name := range []string{"system", "cpu", "memory", "disk", "disk_partitions", "disk_smart_data", "disk_smart_data_detailed_attributes", "processes_top_by_cpu", "errors", "meta", "pci_devices"} {
+		if tables[name] == nil {
+			t.Errorf("schema has no %s table", name)
+		}
+	}
+	columns := map[string]string{}
+	for _, c := range tables["meta"].columns {
+		columns[c.name] = c.typ
+	}
+	// Nested records are flattened into their parent's table
+	if columns["clock_offset_offset_ms"] != "REAL" {
+		t.Errorf("meta columns = %v, expected clock_offset_offset_ms REAL", columns)
+	}
+	if tables["disk_smart_data_detailed_attributes"].parent != tables["disk_smart_data"] {
+		t.Error("SMART attributes should be children of their drive")
+	}
+}
+
+func TestSQLiteSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports.db")
+	sink := &SQLiteSink{Path: path}
+	info := &types.SystemInfo{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		System:    &types.SystemData{Hostname: "web-01"},
+		Memory:    &types.MemoryData{Total: 1 << 34, UsedPercent: 42.5},
+		Disk: &types.DiskData{
+			Partitions: []types.PartitionInfo{{MountPoint: "/"}, {MountPoint: "/home"}},
+			SMARTData: []types.SMARTInfo{{
+				Device:          "/dev/sda",
+				Attributes:      map[string]string{"Reallocated_Sector_Ct": "8"},
+				DetailedAttribs: []types.SMARTAttribute{{ID: 5, Name: "Reallocated_Sector_Ct", RawValue: 8}},
+			}},
+		},
+		Errors: []types.CollectionError{{Module: "gpu", Error: "no GPU found"}},
+	}
+	if err := sink.Write(info); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	info.System.Hostname = "web-02"
+	if err := sink.Write(info); err != nil {
+		t.Fatalf("second Write() error = %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var reports int
+	if err := db.QueryRow("SELECT count(*) FROM reports").Scan(&reports); err != nil || reports != 2 {
+		t.Fatalf("reports = %d (%v), expected each write appended", reports, err)
+	}
+	var hostname string
+	var used float64
+	err = db.QueryRow(`SELECT r.hostname, m.used_percent FROM reports r JOIN memory m ON m.report_id = r.id
+		ORDER BY r.id DESC LIMIT 1`).Scan(&hostname, &used)
+	if err != nil || hostname != "web-02" || used != 42.5 {
+		t.Errorf("latest memory = %s %v (%v), expected web-02 42.5", hostname, used, err)
+	}
+	var mounts int
+	if err := db.QueryRow("SELECT count(*) FROM disk_partitions WHERE report_id = 1").Scan(&mounts); err != nil || mounts != 2 {
+		t.Errorf("partitions = %d (%v), expected 2", mounts, err)
+	}
+	var device, attributes string
+	err = db.QueryRow(`SELECT device, attributes FROM disk_smart_data s JOIN disk d ON s.parent_row_id = d.row_id
+		WHERE d.report_id = 2`).Scan(&device, &attributes)
+	if err != nil || device != "/dev/sda" || attributes == "" {
+		t.Errorf("SMART drive = %s %s (%v), expected /dev/sda with its attributes as JSON", device, attributes, err)
+	}
+	var raw int64
+	err = db.QueryRow(`SELECT a.raw_value FROM disk_smart_data_detailed_attributes a
+		JOIN disk_smart_data s ON a.parent_row_id = s.row_id WHERE s.device = '/dev/sda' AND a.id = 5 AND a.report_id = 1`).Scan(&raw)
+	if err != nil || raw != 8 {
+		t.Errorf("reallocated sectors = %d (%v), expected 8", raw, err)
+	}
+	var module string
+	if err := db.QueryRow("SELECT module FROM errors WHERE report_id = 1").Scan(&module); err != nil || module != "gpu" {
+		t.Errorf("error module = %q (%v), expected gpu", module, err)
+	}
+	var cpuRows int
+	if err := db.QueryRow("SELECT count(*) FROM cpu").Scan(&cpuRows); err != nil || cpuRows != 0 {
+		t.Errorf("cpu rows = %d (%v), expected an empty table for a module not collected", cpuRows, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package privdrop runs the long-lived serving phase of a command as an unprivileged user.
+// A process started as root does its privileged setup (binding ports, opening devices), then
+// re-executes itself under the target user with only the listed capabilities, and waits
+package privdrop
+
+import (
+	"os"
+	"strings"
+)
+
+// childEnv marks the re-executed process so it does not drop privileges again
+const childEnv = "SYSINFO_PRIVDROP_CHILD"
+
+// DefaultCapabilities are retained when none are configured: raw disk access for SMART queries
+var DefaultCapabilities = []string{"CAP_SYS_RAWIO"}
+
+// Options selects the identity the serving phase runs as
+type Options struct {
+	User         string   // User name or numeric UID
+	Group        string   // Group name or numeric GID (default: the user's primary group)
+	Capabilities []string // Capabilities kept after the switch, e.g. CAP_SYS_RAWIO (Linux only)
+}
+
+// IsChild reports whether this process is the unprivileged re-executed child
+func IsChild() bool {
+	return os.Getenv(childEnv) == "1"
+}
+
+// InheritedFile returns the i-th file passed to the child through Run's files (fd 3 onwards)
+func InheritedFile(i int, name string) *os.File {
+	return os.NewFile(uintptr(3+i), name)
+}
+
+// normalizeCapability accepts "sys_rawio", "SYS_RAWIO" or "CAP_SYS_RAWIO"
+func normalizeCapability(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "CAP_") {
+		name = "CAP_" + name
+	}
+	return name
+}
+
+// childEnvironment returns env for the child: marked as the child and with WATCHDOG_PID
+// removed, since systemd set it to the parent's PID and the child sends the pings
+func childEnvironment(env []string) []string {
+	result := make([]string, 0, len(env)+1)
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "WATCHDOG_PID=") || strings.HasPrefix(kv, childEnv+"=") {
+			continue
+		}
+		result = append(result, kv)
+	}
+	return append(result, childEnv+"=1")
+}
@@ -0,0 +1,146 @@
+//go:build linux
+
+package privdrop
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// capabilities maps the capability names accepted in configuration to their numbers
+var capabilities = map[string]uintptr{
+	"CAP_CHOWN":            unix.CAP_CHOWN,
+	"CAP_DAC_OVERRIDE":     unix.CAP_DAC_OVERRIDE,
+	"CAP_DAC_READ_SEARCH":  unix.CAP_DAC_READ_SEARCH,
+	"CAP_FOWNER":           unix.CAP_FOWNER,
+	"CAP_NET_ADMIN":        unix.CAP_NET_ADMIN,
+	"CAP_NET_BIND_SERVICE": unix.CAP_NET_BIND_SERVICE,
+	"CAP_NET_RAW":          unix.CAP_NET_RAW,
+	"CAP_SYS_ADMIN":        unix.CAP_SYS_ADMIN,
+	"CAP_SYS_PTRACE":       unix.CAP_SYS_PTRACE,
+	"CAP_SYS_RAWIO":        unix.CAP_SYS_RAWIO,
+	"CAP_SYSLOG":           unix.CAP_SYSLOG,
+}
+
+// parseCapabilities converts capability names to numbers
+func parseCapabilities(names []string) ([]uintptr, error) {
+	caps := make([]uintptr, 0, len(names))
+	for _, name := range names {
+		c, ok := capabilities[normalizeCapability(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown capability: %s", name)
+		}
+		caps = append(caps, c)
+	}
+	return caps, nil
+}
+
+// lookupCredential resolves the user and group to IDs, including the user's supplementary
+// groups so membership in e.g. "disk" keeps granting access to device nodes
+func lookupCredential(opts Options) (*syscall.Credential, error) {
+	u, err := user.Lookup(opts.User)
+	if err != nil {
+		if u, err = user.LookupId(opts.User); err != nil {
+			return nil, fmt.Errorf("unknown user: %s", opts.User)
+		}
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid for %s: %s", opts.User, u.Uid)
+	}
+
+	gidString := u.Gid
+	if opts.Group != "" {
+		g, err := user.LookupGroup(opts.Group)
+		if err != nil {
+			if g, err = user.LookupGroupId(opts.Group); err != nil {
+				return nil, fmt.Errorf("unknown group: %s", opts.Group)
+			}
+		}
+		gidString = g.Gid
+	}
+	gid, err := strconv.ParseUint(gidString, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gid: %s", gidString)
+	}
+
+	var groups []uint32
+	if ids, err := u.GroupIds(); err == nil {
+		for _, id := range ids {
+			if g, err := strconv.ParseUint(id, 10, 32); err == nil {
+				groups = append(groups, uint32(g))
+			}
+		}
+	}
+
+	return &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), Groups: groups}, nil
+}
+
+// Run re-executes the current command as opts.User with only opts.Capabilities and
+// returns when the child exits. files are inherited by the child (see InheritedFile).
+// SIGINT and SIGTERM are forwarded so the child can shut down cleanly
+func Run(opts Options, files ...*os.File) error {
+	if os.Geteuid() != 0 {
+		return errors.New("dropping privileges requires starting as root")
+	}
+
+	cred, err := lookupCredential(opts)
+	if err != nil {
+		return err
+	}
+	names := opts.Capabilities
+	if names == nil {
+		names = DefaultCapabilities
+	}
+	caps, err := parseCapabilities(names)
+	if err != nil {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	cmd := exec.Command(self, os.Args[1:]...)
+	cmd.Env = childEnvironment(os.Environ())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = files
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential:  cred,
+		AmbientCaps: caps,
+		// Never leave the child running as an orphan if the parent is killed
+		Pdeathsig: syscall.SIGTERM,
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start unprivileged process: %w", err)
+	}
+	// The parent's copies are no longer needed once the child holds them
+	for _, f := range files {
+		f.Close()
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	for {
+		select {
+		case sig := <-signals:
+			_ = cmd.Process.Signal(sig)
+		case err := <-done:
+			return err
+		}
+	}
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+package privdrop
+
+import (
+	"os"
+	"os/user"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	caps, err := parseCapabilities([]string{"sys_rawio", "CAP_DAC_READ_SEARCH"})
+	if err != nil {
+		t.Fatalf("parseCapabilities returned error: %v", err)
+	}
+	if len(caps) != 2 || caps[0] != unix.CAP_SYS_RAWIO || caps[1] != unix.CAP_DAC_READ_SEARCH {
+		t.Errorf("caps = %v, expected [%d %d]", caps, unix.CAP_SYS_RAWIO, unix.CAP_DAC_READ_SEARCH)
+	}
+
+	if _, err := parseCapabilities([]string{"CAP_FLY"}); err == nil {
+		t.Error("expected error for unknown capability")
+	}
+}
+
+func TestLookupCredential(t *testing.T) {
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("current user unavailable: %v", err)
+	}
+
+	// Both names and numeric IDs are accepted
+	for _, name := range []string{current.Username, current.Uid} {
+		cred, err := lookupCredential(Options{User: name})
+		if err != nil {
+			t.Fatalf("lookupCredential(%q) returned error: %v", name, err)
+		}
+		if int(cred.Uid) != os.Getuid() {
+			t.Errorf("Uid = %d, expected %d", cred.Uid, os.Getuid())
+		}
+	}
+
+	if _, err := lookupCredential(Options{User: "no-such-sysinfo-user"}); err == nil {
+		t.Error("expected error for unknown user")
+	}
+	if _, err := lookupCredential(Options{User: current.Username, Group: "no-such-sysinfo-group"}); err == nil {
+		t.Error("expected error for unknown group")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package privdrop
+
+import (
+	"errors"
+	"os"
+)
+
+// Run is only supported on Linux, where ambient capabilities let the child keep raw disk access
+func Run(opts Options, files ...*os.File) error {
+	return errors.New("dropping privileges is only supported on Linux")
+}
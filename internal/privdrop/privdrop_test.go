@@ -0,0 +1,54 @@
+package privdrop
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeCapability(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"CAP_SYS_RAWIO", "CAP_SYS_RAWIO"},
+		{"sys_rawio", "CAP_SYS_RAWIO"},
+		{" cap_dac_read_search ", "CAP_DAC_READ_SEARCH"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeCapability(tt.input); got != tt.expected {
+			t.Errorf("normalizeCapability(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestChildEnvironment(t *testing.T) {
+	env := childEnvironment([]string{
+		"PATH=/usr/bin",
+		"NOTIFY_SOCKET=/run/systemd/notify",
+		"WATCHDOG_USEC=30000000",
+		"WATCHDOG_PID=1234",
+		childEnv + "=0",
+	})
+
+	expected := []string{
+		"PATH=/usr/bin",
+		"NOTIFY_SOCKET=/run/systemd/notify",
+		"WATCHDOG_USEC=30000000",
+		childEnv + "=1",
+	}
+	if !slices.Equal(env, expected) {
+		t.Errorf("childEnvironment = %v, expected %v", env, expected)
+	}
+}
+
+func TestIsChild(t *testing.T) {
+	t.Setenv(childEnv, "")
+	if IsChild() {
+		t.Error("IsChild = true, expected false without the marker")
+	}
+	t.Setenv(childEnv, "1")
+	if !IsChild() {
+		t.Error("IsChild = false, expected true with the marker")
+	}
+}
@@ -0,0 +1,430 @@
+package requirements
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/analyzer"
+	"github.com/mayvqt/sysinfo/internal/types"
+	"github.com/mayvqt/sysinfo/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// memoryTolerance accounts for firmware/kernel reserved memory, so a 16GB machine
+// (which typically reports ~15.5GB usable) still satisfies min_memory: 16GB
+const memoryTolerance = 0.95
+
+// Manifest is a declarative list of hardware/OS requirements
+type Manifest struct {
+	Name         string   `yaml:"name,omitempty"`
+	MinMemory    string   `yaml:"min_memory,omitempty"`     // e.g. "16GB"
+	MinCores     int32    `yaml:"min_cores,omitempty"`      // Physical cores
+	MinThreads   int32    `yaml:"min_threads,omitempty"`    // Logical CPUs
+	MinGPUVRAM   string   `yaml:"min_gpu_vram,omitempty"`   // Largest GPU must have at least this much memory
+	MinFreeDisk  string   `yaml:"min_free_disk,omitempty"`  // Free space on DiskPath
+	DiskPath     string   `yaml:"disk_path,omitempty"`      // Mount point to check (default: largest free partition)
+	OS           []string `yaml:"os,omitempty"`             // Allowed OS names (linux, windows, darwin)
+	Platforms    []string `yaml:"platforms,omitempty"`      // Allowed platforms (ubuntu, debian, Microsoft Windows 11 Pro, ...)
+	MinOSVersion string   `yaml:"min_os_version,omitempty"` // Inclusive lower bound on the platform version
+	MaxOSVersion string   `yaml:"max_os_version,omitempty"` // Exclusive upper bound on the platform version
+
+	// Provisioning checks, for validating freshly built machines and images
+	Memory          string       `yaml:"memory,omitempty"`            // Exact installed memory, e.g. "64GB"
+	Disks           []DiskLayout `yaml:"disks,omitempty"`             // Mount points the disk layout must have
+	Drivers         []string     `yaml:"drivers,omitempty"`           // Kernel drivers that must be bound to a GPU or accelerator
+	NoSMARTWarnings bool         `yaml:"no_smart_warnings,omitempty"` // Every drive's SMART analysis must be GOOD
+}
+
+// DiskLayout is one mount point expected in the disk layout
+type DiskLayout struct {
+	Mount   string `yaml:"mount"`
+	FSType  string `yaml:"fs_type,omitempty"`  // e.g. ext4, xfs, NTFS
+	MinSize string `yaml:"min_size,omitempty"` // Filesystem size, e.g. "40GB"
+}
+
+// Result is the outcome of a single requirement check
+type Result struct {
+	Requirement string `json:"requirement"`
+	Expected    string `json:"expected"`
+	Actual      string `json:"actual"`
+	Passed      bool   `json:"passed"`
+}
+
+// Report contains all check results for a manifest
+type Report struct {
+	Name      string    `json:"name,omitempty"`
+	Host      string    `json:"host,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+	Passed    bool      `json:"passed"`
+	Results   []Result  `json:"results"`
+}
+
+// LoadManifest reads a requirements manifest from a YAML file
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read requirements file: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse requirements file: %w", err)
+	}
+
+	// Validate sizes up front so typos are reported instead of silently failing checks
+	sizes := map[string]string{
+		"min_memory":    manifest.MinMemory,
+		"min_gpu_vram":  manifest.MinGPUVRAM,
+		"min_free_disk": manifest.MinFreeDisk,
+		"memory":        manifest.Memory,
+	}
+	for i, disk := range manifest.Disks {
+		if disk.Mount == "" {
+			return nil, fmt.Errorf("invalid disks[%d]: mount is required", i)
+		}
+		sizes[fmt.Sprintf("disks[%d].min_size", i)] = disk.MinSize
+	}
+	for field, value := range sizes {
+		if value == "" {
+			continue
+		}
+		if _, err := ParseSize(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Check evaluates the manifest against collected system information
+func Check(manifest *Manifest, info *types.SystemInfo) *Report {
+	report := &Report{Name: manifest.Name, CheckedAt: info.Timestamp, Passed: true}
+	if info.System != nil {
+		report.Host = info.System.Hostname
+	}
+	add := func(result Result) {
+		report.Results = append(report.Results, result)
+		if !result.Passed {
+			report.Passed = false
+		}
+	}
+
+	if manifest.MinMemory != "" {
+		required, _ := ParseSize(manifest.MinMemory)
+		result := Result{Requirement: "Memory", Expected: ">= " + manifest.MinMemory, Actual: "unknown"}
+		if info.Memory != nil {
+			result.Actual = utils.FormatBytes(info.Memory.Total)
+			result.Passed = float64(info.Memory.Total) >= float64(required)*memoryTolerance
+		}
+		add(result)
+	}
+
+	if manifest.MinCores > 0 {
+		result := Result{Requirement: "CPU cores", Expected: fmt.Sprintf(">= %d", manifest.MinCores), Actual: "unknown"}
+		if info.CPU != nil {
+			result.Actual = strconv.Itoa(int(info.CPU.Cores))
+			result.Passed = info.CPU.Cores >= manifest.MinCores
+		}
+		add(result)
+	}
+
+	if manifest.MinThreads > 0 {
+		result := Result{Requirement: "CPU threads", Expected: fmt.Sprintf(">= %d", manifest.MinThreads), Actual: "unknown"}
+		if info.CPU != nil {
+			result.Actual = strconv.Itoa(int(info.CPU.LogicalCPUs))
+			result.Passed = info.CPU.LogicalCPUs >= manifest.MinThreads
+		}
+		add(result)
+	}
+
+	if manifest.MinGPUVRAM != "" {
+		required, _ := ParseSize(manifest.MinGPUVRAM)
+		result := Result{Requirement: "GPU VRAM", Expected: ">= " + manifest.MinGPUVRAM, Actual: "no GPU"}
+		if info.GPU != nil {
+			var largest uint64
+			for _, gpu := range info.GPU.GPUs {
+				if gpu.MemoryTotal > largest {
+					largest = gpu.MemoryTotal
+				}
+			}
+			if largest > 0 {
+				result.Actual = utils.FormatBytes(largest)
+				result.Passed = largest >= required
+			}
+		}
+		add(result)
+	}
+
+	if manifest.MinFreeDisk != "" {
+		required, _ := ParseSize(manifest.MinFreeDisk)
+		add(checkFreeDisk(manifest, info, required))
+	}
+
+	if len(manifest.OS) > 0 {
+		result := Result{Requirement: "OS", Expected: strings.Join(manifest.OS, " | "), Actual: "unknown"}
+		if info.System != nil {
+			result.Actual = info.System.OS
+			result.Passed = containsFold(manifest.OS, info.System.OS)
+		}
+		add(result)
+	}
+
+	if len(manifest.Platforms) > 0 {
+		result := Result{Requirement: "Platform", Expected: strings.Join(manifest.Platforms, " | "), Actual: "unknown"}
+		if info.System != nil {
+			result.Actual = info.System.Platform
+			result.Passed = containsFold(manifest.Platforms, info.System.Platform)
+		}
+		add(result)
+	}
+
+	if manifest.MinOSVersion != "" || manifest.MaxOSVersion != "" {
+		result := Result{Requirement: "OS version", Expected: versionRange(manifest.MinOSVersion, manifest.MaxOSVersion), Actual: "unknown"}
+		if info.System != nil && info.System.PlatformVersion != "" {
+			version := info.System.PlatformVersion
+			result.Actual = version
+			result.Passed = (manifest.MinOSVersion == "" || CompareVersions(version, manifest.MinOSVersion) >= 0) &&
+				(manifest.MaxOSVersion == "" || CompareVersions(version, manifest.MaxOSVersion) < 0)
+		}
+		add(result)
+	}
+
+	if manifest.Memory != "" {
+		installed, _ := ParseSize(manifest.Memory)
+		result := Result{Requirement: "Memory size", Expected: "= " + manifest.Memory, Actual: "unknown"}
+		if info.Memory != nil {
+			// Usable memory is never more than what is installed, and firmware reserves a little of it
+			result.Actual = utils.FormatBytes(info.Memory.Total)
+			result.Passed = float64(info.Memory.Total) >= float64(installed)*memoryTolerance && info.Memory.Total <= installed
+		}
+		add(result)
+	}
+
+	for _, disk := range manifest.Disks {
+		add(checkDiskLayout(disk, info))
+	}
+
+	for _, driver := range manifest.Drivers {
+		add(checkDriver(driver, info))
+	}
+
+	if manifest.NoSMARTWarnings {
+		add(checkSMART(info))
+	}
+
+	return report
+}
+
+// checkDiskLayout checks that a mount point exists with the expected filesystem and size
+func checkDiskLayout(disk DiskLayout, info *types.SystemInfo) Result {
+	var expected []string
+	if disk.FSType != "" {
+		expected = append(expected, disk.FSType)
+	}
+	if disk.MinSize != "" {
+		expected = append(expected, ">= "+disk.MinSize)
+	}
+	if len(expected) == 0 {
+		expected = append(expected, "mounted")
+	}
+	result := Result{Requirement: "Mount " + disk.Mount, Expected: strings.Join(expected, ", "), Actual: "not mounted"}
+	if info.Disk == nil {
+		result.Actual = "unknown"
+		return result
+	}
+
+	for _, part := range info.Disk.Partitions {
+		if part.MountPoint != disk.Mount {
+			continue
+		}
+		result.Actual = fmt.Sprintf("%s, %s", part.FSType, utils.FormatBytes(part.Total))
+		result.Passed = disk.FSType == "" || strings.EqualFold(part.FSType, disk.FSType)
+		if disk.MinSize != "" {
+			required, _ := ParseSize(disk.MinSize)
+			result.Passed = result.Passed && part.Total >= required
+		}
+		break
+	}
+	return result
+}
+
+// checkDriver checks that a kernel driver is bound to one of the GPUs or accelerators
+func checkDriver(driver string, info *types.SystemInfo) Result {
+	result := Result{Requirement: "Driver " + driver, Expected: "bound", Actual: "not bound"}
+	if info.GPU != nil {
+		for _, gpu := range info.GPU.GPUs {
+			if strings.EqualFold(gpu.Driver, driver) {
+				result.Actual = "bound to " + gpu.Name
+				result.Passed = true
+				return result
+			}
+		}
+	}
+	if info.Accelerators != nil {
+		for _, acc := range info.Accelerators.Accelerators {
+			if acc.DriverLoaded && strings.EqualFold(acc.Driver, driver) {
+				result.Actual = "bound to " + acc.Name
+				result.Passed = true
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// checkSMART fails on any drive that failed its own self-assessment or whose SMART analysis is not GOOD
+// Virtual machines have no SMART-capable drives, which passes
+func checkSMART(info *types.SystemInfo) Result {
+	result := Result{Requirement: "SMART health", Expected: "no warnings", Actual: "no SMART data", Passed: true}
+	if info.Disk == nil || len(info.Disk.SMARTData) == 0 {
+		return result
+	}
+
+	smartAnalyzer := analyzer.NewSMARTAnalyzer()
+	var unhealthy []string
+	for i := range info.Disk.SMARTData {
+		smart := &info.Disk.SMARTData[i]
+		if !smart.Healthy {
+			unhealthy = append(unhealthy, smart.Device+" self-assessment FAILED")
+		} else if analysis := smartAnalyzer.Analyze(smart); analysis.OverallHealth != analyzer.HealthGood {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s %s", smart.Device, analysis.OverallHealth))
+		}
+	}
+	if len(unhealthy) > 0 {
+		result.Actual = strings.Join(unhealthy, ", ")
+		result.Passed = false
+		return result
+	}
+	result.Actual = fmt.Sprintf("%d drive(s) GOOD", len(info.Disk.SMARTData))
+	return result
+}
+
+// checkFreeDisk checks free space on the configured mount point, or the partition with the most free space
+func checkFreeDisk(manifest *Manifest, info *types.SystemInfo, required uint64) Result {
+	result := Result{Requirement: "Free disk", Expected: ">= " + manifest.MinFreeDisk, Actual: "unknown"}
+	if manifest.DiskPath != "" {
+		result.Requirement = "Free disk (" + manifest.DiskPath + ")"
+	}
+	if info.Disk == nil {
+		return result
+	}
+
+	var best *types.PartitionInfo
+	for i := range info.Disk.Partitions {
+		part := &info.Disk.Partitions[i]
+		if manifest.DiskPath != "" {
+			if part.MountPoint == manifest.DiskPath {
+				best = part
+				break
+			}
+			continue
+		}
+		if best == nil || part.Free > best.Free {
+			best = part
+		}
+	}
+
+	if best == nil {
+		if manifest.DiskPath != "" {
+			result.Actual = "not mounted"
+		}
+		return result
+	}
+
+	result.Actual = fmt.Sprintf("%s on %s", utils.FormatBytes(best.Free), best.MountPoint)
+	result.Passed = best.Free >= required
+	return result
+}
+
+// ParseSize parses a human-readable size such as "512MB", "16 GB" or "1TiB" into bytes
+// Decimal and binary suffixes are both treated as powers of 1024 to match how sizes are displayed
+func ParseSize(s string) (uint64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multipliers := []struct {
+		suffix string
+		factor uint64
+	}{
+		{"TIB", 1 << 40}, {"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+		{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
+	}
+
+	factor := uint64(1)
+	for _, m := range multipliers {
+		if strings.HasSuffix(value, m.suffix) {
+			factor = m.factor
+			value = strings.TrimSpace(strings.TrimSuffix(value, m.suffix))
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return uint64(number * float64(factor)), nil
+}
+
+// CompareVersions compares dotted numeric versions, returning -1, 0 or 1
+// Non-numeric suffixes within a component (e.g. "22.04-LTS") are ignored
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA = leadingNumber(partsA[i])
+		}
+		if i < len(partsB) {
+			numB = leadingNumber(partsB[i])
+		}
+		if numA < numB {
+			return -1
+		}
+		if numA > numB {
+			return 1
+		}
+	}
+	return 0
+}
+
+// leadingNumber returns the integer prefix of a version component
+func leadingNumber(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
+
+// containsFold reports whether value matches any candidate, ignoring case
+func containsFold(candidates []string, value string) bool {
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// versionRange describes a version bound for display
+func versionRange(min, max string) string {
+	switch {
+	case min != "" && max != "":
+		return fmt.Sprintf(">= %s, < %s", min, max)
+	case min != "":
+		return ">= " + min
+	default:
+		return "< " + max
+	}
+}
@@ -0,0 +1,199 @@
+package requirements
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+const gib = 1024 * 1024 * 1024
+
+func createTestSystemInfo() *types.SystemInfo {
+	return &types.SystemInfo{
+		System: &types.SystemData{OS: "linux", Platform: "ubuntu", PlatformVersion: "22.04"},
+		CPU:    &types.CPUData{Cores: 8, LogicalCPUs: 16},
+		Memory: &types.MemoryData{Total: 15*gib + 600*1024*1024},
+		Disk: &types.DiskData{Partitions: []types.PartitionInfo{
+			{MountPoint: "/", FSType: "ext4", Total: 60 * gib, Free: 40 * gib},
+			{MountPoint: "/data", FSType: "xfs", Total: 500 * gib, Free: 400 * gib},
+		}},
+		GPU: &types.GPUData{GPUs: []types.GPUInfo{
+			{Name: "iGPU", MemoryTotal: 1 * gib, Driver: "i915"},
+			{Name: "dGPU", MemoryTotal: 12 * gib, Driver: "nvidia"},
+		}},
+		Accelerators: &types.AcceleratorData{Accelerators: []types.AcceleratorInfo{
+			{Name: "Meteor Lake NPU", Driver: "intel_vpu", DriverLoaded: true},
+			{Name: "Coral Edge TPU", Driver: "apex"},
+		}},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		passed   bool
+	}{
+		{"memory within tolerance", Manifest{MinMemory: "16GB"}, true},
+		{"memory too low", Manifest{MinMemory: "32GB"}, false},
+		{"cores", Manifest{MinCores: 8, MinThreads: 16}, true},
+		{"too few cores", Manifest{MinCores: 12}, false},
+		{"largest gpu vram", Manifest{MinGPUVRAM: "8GB"}, true},
+		{"insufficient vram", Manifest{MinGPUVRAM: "16GB"}, false},
+		{"free disk any partition", Manifest{MinFreeDisk: "100GB"}, true},
+		{"free disk on root", Manifest{MinFreeDisk: "100GB", DiskPath: "/"}, false},
+		{"free disk unmounted path", Manifest{MinFreeDisk: "1GB", DiskPath: "/missing"}, false},
+		{"os allowed", Manifest{OS: []string{"Linux", "darwin"}}, true},
+		{"os not allowed", Manifest{OS: []string{"windows"}}, false},
+		{"platform allowed", Manifest{Platforms: []string{"debian", "ubuntu"}}, true},
+		{"version in range", Manifest{MinOSVersion: "20.04", MaxOSVersion: "24.04"}, true},
+		{"version below minimum", Manifest{MinOSVersion: "24.04"}, false},
+		{"version at exclusive maximum", Manifest{MaxOSVersion: "22.04"}, false},
+		{"exact memory", Manifest{Memory: "16GB"}, true},
+		{"memory larger than expected", Manifest{Memory: "8GB"}, false},
+		{"disk layout", Manifest{Disks: []DiskLayout{{Mount: "/", FSType: "EXT4", MinSize: "50GB"}, {Mount: "/data"}}}, true},
+		{"wrong filesystem", Manifest{Disks: []DiskLayout{{Mount: "/data", FSType: "ext4"}}}, false},
+		{"filesystem too small", Manifest{Disks: []DiskLayout{{Mount: "/", MinSize: "100GB"}}}, false},
+		{"missing mount", Manifest{Disks: []DiskLayout{{Mount: "/var/lib/docker"}}}, false},
+		{"drivers bound", Manifest{Drivers: []string{"nvidia", "intel_vpu"}}, true},
+		{"driver not loaded", Manifest{Drivers: []string{"apex"}}, false},
+		{"no smart data", Manifest{NoSMARTWarnings: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Check(&tt.manifest, createTestSystemInfo())
+			if report.Passed != tt.passed {
+				t.Errorf("Check().Passed = %v, expected %v (results: %+v)", report.Passed, tt.passed, report.Results)
+			}
+			if len(report.Results) == 0 {
+				t.Error("Check() returned no results")
+			}
+		})
+	}
+}
+
+func TestCheckMissingData(t *testing.T) {
+	manifest := &Manifest{MinMemory: "1GB", MinCores: 1, MinGPUVRAM: "1GB", MinFreeDisk: "1GB", OS: []string{"linux"}, MinOSVersion: "1",
+		Memory: "1GB", Disks: []DiskLayout{{Mount: "/"}}, Drivers: []string{"nvidia"}}
+	report := Check(manifest, &types.SystemInfo{})
+
+	if report.Passed {
+		t.Error("Check() should fail when data is missing")
+	}
+	for _, result := range report.Results {
+		if result.Passed {
+			t.Errorf("Result %q passed without data", result.Requirement)
+		}
+	}
+}
+
+func TestCheckSMART(t *testing.T) {
+	info := createTestSystemInfo()
+	info.Disk.SMARTData = []types.SMARTInfo{
+		{Device: "/dev/sda", Healthy: true, Temperature: 35, PowerOnHours: 1000},
+		{Device: "/dev/sdb", Healthy: false, Temperature: 35, PowerOnHours: 1000},
+	}
+
+	result := checkSMART(info)
+	if result.Passed || !strings.Contains(result.Actual, "/dev/sdb") || strings.Contains(result.Actual, "/dev/sda") {
+		t.Errorf("checkSMART() = %+v, expected only /dev/sdb to fail", result)
+	}
+
+	info.Disk.SMARTData = info.Disk.SMARTData[:1]
+	if result := checkSMART(info); !result.Passed {
+		t.Errorf("checkSMART() = %+v, expected a healthy drive to pass", result)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected uint64
+		wantErr  bool
+	}{
+		{"512", 512, false},
+		{"1KB", 1024, false},
+		{"16GB", 16 * gib, false},
+		{"16 gb", 16 * gib, false},
+		{"1.5G", 1.5 * gib, false},
+		{"2TiB", 2 * 1024 * gib, false},
+		{"", 0, true},
+		{"lots", 0, true},
+		{"-1GB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseSize(%q) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"22.04", "22.04", 0},
+		{"22.04", "20.04", 1},
+		{"10.0.19045", "10.0.22000", -1},
+		{"12", "12.0.0", 0},
+		{"22.04-LTS", "22.4", 0},
+		{"14.1.2", "14.2", -1},
+	}
+
+	for _, tt := range tests {
+		if result := CompareVersions(tt.a, tt.b); result != tt.expected {
+			t.Errorf("CompareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "reqs.yaml")
+	content := "name: Render Node\nmin_memory: 32GB\nmin_cores: 8\nos: [linux]\nmin_os_version: \"20.04\"\n"
+	if err := os.WriteFile(valid, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	manifest, err := LoadManifest(valid)
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if manifest.Name != "Render Node" || manifest.MinCores != 8 || manifest.MinOSVersion != "20.04" {
+		t.Errorf("LoadManifest() = %+v", manifest)
+	}
+
+	invalid := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(invalid, []byte("min_memory: lots\n"), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	if _, err := LoadManifest(invalid); err == nil {
+		t.Error("Expected error for invalid size, got nil")
+	}
+
+	for _, bad := range []string{"disks:\n  - fs_type: ext4\n", "disks:\n  - mount: /\n    min_size: big\n"} {
+		if err := os.WriteFile(invalid, []byte(bad), 0644); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		if _, err := LoadManifest(invalid); err == nil {
+			t.Errorf("Expected error for disks entry %q, got nil", bad)
+		}
+	}
+
+	if _, err := LoadManifest(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
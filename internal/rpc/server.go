@@ -0,0 +1,360 @@
+// Package rpc serves collection over JSON-RPC 2.0 on a stream such as stdio, so
+// orchestration tools and MCP clients can query modules from a long-lived process
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision answered to clients that do not ask for one
+const mcpProtocolVersion = "2024-11-05"
+
+// maxMessageSize bounds a single request line
+const maxMessageSize = 1 << 20
+
+// request is a JSON-RPC request, or a notification when ID is absent
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response carrying either a result or an error
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// CollectParams are the parameters of the collect method and the collect tool
+type CollectParams struct {
+	Modules []string `json:"modules,omitempty"` // Modules to collect, "all" for every module (default: the configured modules)
+	MaxAge  string   `json:"max_age,omitempty"` // Reuse a report of the same modules collected within this duration, e.g. "30s"
+	Redact  bool     `json:"redact,omitempty"`  // Mask serial numbers, addresses and the hostname
+}
+
+// cachedReport is a collected report with when it was collected
+type cachedReport struct {
+	info *types.SystemInfo
+	at   time.Time
+}
+
+// Server answers JSON-RPC requests, one JSON message per line, with the collection
+// functions as methods. Requests are handled in order, one at a time
+type Server struct {
+	cfg *config.Config
+
+	// collect gathers a report; replaced in tests
+	collect func(*config.Config) (*types.SystemInfo, error)
+	now     func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedReport
+}
+
+// New creates a server collecting with cfg, whose modules are collected when a request names none
+func New(cfg *config.Config) *Server {
+	return &Server{
+		cfg:     cfg,
+		collect: collector.Collect,
+		now:     time.Now,
+		cache:   make(map[string]cachedReport),
+	}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted or ctx is cancelled
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxMessageSize)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if reply := s.handleMessage(line); reply != nil {
+			if err := encoder.Encode(reply); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMessage answers a single request or a batch; nil means nothing is written back
+func (s *Server) handleMessage(message []byte) any {
+	if message[0] != '[' {
+		var req request
+		if err := json.Unmarshal(message, &req); err != nil {
+			return errorResponse(nil, codeParseError, "parse error: "+err.Error())
+		}
+		if reply := s.handle(req); reply != nil {
+			return reply
+		}
+		return nil
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(message, &batch); err != nil {
+		return errorResponse(nil, codeParseError, "parse error: "+err.Error())
+	}
+	if len(batch) == 0 {
+		return errorResponse(nil, codeInvalidRequest, "empty batch")
+	}
+	var replies []*response
+	for _, raw := range batch {
+		var req request
+		if err := json.Unmarshal(raw, &req); err != nil {
+			replies = append(replies, errorResponse(nil, codeInvalidRequest, "invalid request"))
+			continue
+		}
+		if reply := s.handle(req); reply != nil {
+			replies = append(replies, reply)
+		}
+	}
+	// A batch of notifications gets no reply at all
+	if len(replies) == 0 {
+		return nil
+	}
+	return replies
+}
+
+// handle dispatches one request; notifications are run but not answered
+func (s *Server) handle(req request) *response {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+
+	result, err := s.call(req.Method, req.Params)
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		rpcErr, ok := err.(*Error)
+		if !ok {
+			rpcErr = &Error{Code: codeInternalError, Message: err.Error()}
+		}
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// call runs a method: the plain collect and modules methods, and the MCP methods
+// exposing collect as a tool
+func (s *Server) call(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "collect":
+		var p CollectParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.collectReport(p)
+	case "modules":
+		return config.ModuleNames, nil
+	case "ping":
+		return struct{}{}, nil
+	case "initialize":
+		return s.initialize(params)
+	case "tools/list":
+		return map[string]any{"tools": []any{collectTool()}}, nil
+	case "tools/call":
+		return s.callTool(params)
+	default:
+		// Notifications such as notifications/initialized need no handling
+		if strings.HasPrefix(method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &Error{Code: codeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// initialize answers the MCP handshake, agreeing to the client's protocol version
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	version := "devel"
+	if build, ok := debug.ReadBuildInfo(); ok && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		version = build.Main.Version
+	}
+	return map[string]any{
+		"protocolVersion": cmp.Or(p.ProtocolVersion, mcpProtocolVersion),
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "sysinfo", "version": version},
+	}, nil
+}
+
+// collectTool describes the collect method as an MCP tool
+func collectTool() map[string]any {
+	modules := append([]string{"all"}, config.ModuleNames...)
+	return map[string]any{
+		"name":        "collect",
+		"description": "Collect system information for the given modules and return the report as JSON",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"modules": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string", "enum": modules},
+					"description": "Modules to collect; all for every module. Defaults to the configured modules",
+				},
+				"max_age": map[string]any{
+					"type":        "string",
+					"description": "Reuse a report of the same modules collected within this duration, e.g. 30s",
+				},
+				"redact": map[string]any{
+					"type":        "boolean",
+					"description": "Mask serial numbers, MAC and IP addresses, the hostname and UUIDs",
+				},
+			},
+		},
+	}
+}
+
+// callTool runs an MCP tool call. Collection failures are reported in the result, as MCP
+// expects, so the calling model sees them
+func (s *Server) callTool(params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Name != "collect" {
+		return nil, &Error{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+	var args CollectParams
+	if err := decodeParams(p.Arguments, &args); err != nil {
+		return nil, err
+	}
+
+	text, isError := "", false
+	if info, err := s.collectReport(args); err != nil {
+		text, isError = err.Error(), true
+	} else if data, err := json.Marshal(info); err != nil {
+		text, isError = err.Error(), true
+	} else {
+		text = string(data)
+	}
+	return map[string]any{
+		"content": []any{map[string]any{"type": "text", "text": text}},
+		"isError": isError,
+	}, nil
+}
+
+// collectReport collects the requested modules, or returns the cached report of the same
+// modules when it is younger than MaxAge
+func (s *Server) collectReport(p CollectParams) (*types.SystemInfo, error) {
+	var maxAge time.Duration
+	if p.MaxAge != "" {
+		var err error
+		if maxAge, err = time.ParseDuration(p.MaxAge); err != nil || maxAge < 0 {
+			return nil, &Error{Code: codeInvalidParams, Message: fmt.Sprintf("invalid max_age: %q", p.MaxAge)}
+		}
+	}
+
+	reportCfg := *s.cfg
+	if len(p.Modules) > 0 {
+		reportCfg.Modules = config.ModuleConfig{}
+		for _, module := range p.Modules {
+			if err := reportCfg.Modules.Enable(strings.ToLower(strings.TrimSpace(module))); err != nil {
+				return nil, &Error{Code: codeInvalidParams, Message: err.Error()}
+			}
+		}
+	}
+	reportCfg.Redact = reportCfg.Redact || p.Redact
+
+	key := cacheKey(reportCfg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached, ok := s.cache[key]; ok && maxAge > 0 && s.now().Sub(cached.at) <= maxAge {
+		return cached.info, nil
+	}
+
+	info, err := s.collect(&reportCfg)
+	if err != nil {
+		return nil, err
+	}
+	if reportCfg.UTC {
+		collector.UseUTC(info)
+	}
+	info.TimestampFormat = reportCfg.TimestampFormat
+	if reportCfg.Redact {
+		collector.Redact(info)
+	}
+	s.cache[key] = cachedReport{info: info, at: s.now()}
+	return info, nil
+}
+
+// cacheKey identifies a report by its modules and whether it was redacted
+func cacheKey(cfg config.Config) string {
+	var modules []string
+	for _, module := range config.ModuleNames {
+		if cfg.ShouldCollect(module) {
+			modules = append(modules, module)
+		}
+	}
+	return fmt.Sprintf("%s redact=%t", strings.Join(modules, ","), cfg.Redact)
+}
+
+// decodeParams decodes by-name parameters; absent parameters leave v unchanged
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: codeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// errorResponse builds an error reply; a nil ID is written as null
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &Error{Code: code, Message: message}}
+}
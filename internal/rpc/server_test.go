@@ -0,0 +1,219 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// newTestServer returns a server whose collections are recorded instead of run
+func newTestServer(collected *[]config.ModuleConfig) *Server {
+	cfg := config.NewConfig()
+	cfg.Modules = config.ModuleConfig{System: true, CPU: true}
+	s := New(cfg)
+	s.collect = func(c *config.Config) (*types.SystemInfo, error) {
+		*collected = append(*collected, c.Modules)
+		info := &types.SystemInfo{Timestamp: time.Now()}
+		if c.ShouldCollect("system") {
+			info.System = &types.SystemData{Hostname: "build-01"}
+		}
+		if c.ShouldCollect("memory") {
+			info.Memory = &types.MemoryData{Total: 1 << 30}
+		}
+		return info, nil
+	}
+	return s
+}
+
+// serve runs the server over the given request lines and decodes each response line
+func serve(t *testing.T, s *Server, lines ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var replies []map[string]any
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var reply map[string]any
+		if err := decoder.Decode(&reply); err != nil {
+			t.Fatalf("Invalid response: %v", err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// errorCode returns the code of an error response, or 0
+func errorCode(reply map[string]any) int {
+	if e, ok := reply["error"].(map[string]any); ok {
+		return int(e["code"].(float64))
+	}
+	return 0
+}
+
+func TestServeCollect(t *testing.T) {
+	var collected []config.ModuleConfig
+	s := newTestServer(&collected)
+
+	replies := serve(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"collect"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"collect","params":{"modules":["memory"]}}`,
+	)
+	if len(replies) != 2 {
+		t.Fatalf("Expected 2 responses, got %d", len(replies))
+	}
+
+	// Without modules the configured ones are collected
+	result := replies[0]["result"].(map[string]any)
+	if replies[0]["id"] != float64(1) || result["system"] == nil || result["memory"] != nil {
+		t.Errorf("collect without modules = %v", replies[0])
+	}
+	result = replies[1]["result"].(map[string]any)
+	if replies[1]["id"] != "two" || result["memory"] == nil || result["system"] != nil {
+		t.Errorf("collect memory = %v", replies[1])
+	}
+	if len(collected) != 2 || !collected[1].Memory || collected[1].System {
+		t.Errorf("Collected modules = %+v", collected)
+	}
+}
+
+func TestServeCollectCache(t *testing.T) {
+	var collected []config.ModuleConfig
+	s := newTestServer(&collected)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["cpu","system"],"max_age":"30s"}}`
+	serve(t, s, request, request)
+	if len(collected) != 1 {
+		t.Errorf("Expected the second request to reuse the report, collected %d times", len(collected))
+	}
+
+	// Module order does not matter, but other modules or redaction are a new report
+	serve(t, s, `{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["system","cpu"],"max_age":"30s"}}`)
+	serve(t, s, `{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["system","cpu"],"max_age":"30s","redact":true}}`)
+	if len(collected) != 2 {
+		t.Errorf("Expected only the redacted request to collect, collected %d times", len(collected))
+	}
+
+	now = now.Add(time.Minute)
+	serve(t, s, request)
+	if len(collected) != 3 {
+		t.Errorf("Expected an expired report to be collected again, collected %d times", len(collected))
+	}
+
+	// Without max_age every request collects
+	serve(t, s, `{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["cpu","system"]}}`)
+	if len(collected) != 4 {
+		t.Errorf("Expected a request without max_age to collect, collected %d times", len(collected))
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	var collected []config.ModuleConfig
+	s := newTestServer(&collected)
+
+	tests := []struct {
+		line string
+		code int
+	}{
+		{`not json`, codeParseError},
+		{`{"id":1,"method":"collect"}`, codeInvalidRequest},
+		{`{"jsonrpc":"2.0","id":1,"method":"reboot"}`, codeMethodNotFound},
+		{`{"jsonrpc":"2.0","id":1,"method":"collect","params":{"modules":["floppy"]}}`, codeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"collect","params":{"max_age":"soon"}}`, codeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"collect","params":[1]}`, codeInvalidParams},
+		{`[]`, codeInvalidRequest},
+	}
+	for _, tt := range tests {
+		replies := serve(t, s, tt.line)
+		if len(replies) != 1 || errorCode(replies[0]) != tt.code {
+			t.Errorf("%s: got %v, expected error %d", tt.line, replies, tt.code)
+		}
+	}
+
+	s.collect = func(*config.Config) (*types.SystemInfo, error) {
+		return nil, errors.New("collection failed")
+	}
+	replies := serve(t, s, `{"jsonrpc":"2.0","id":1,"method":"collect"}`)
+	if len(replies) != 1 || errorCode(replies[0]) != codeInternalError {
+		t.Errorf("Failed collection = %v, expected an internal error", replies)
+	}
+}
+
+func TestServeNotificationsAndBatch(t *testing.T) {
+	var collected []config.ModuleConfig
+	s := newTestServer(&collected)
+
+	// Notifications are not answered, even unknown ones
+	if replies := serve(t, s, `{"jsonrpc":"2.0","method":"notifications/initialized"}`, `{"jsonrpc":"2.0","method":"reboot"}`); len(replies) != 0 {
+		t.Errorf("Notifications were answered: %v", replies)
+	}
+
+	var out bytes.Buffer
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":2,"method":"modules"}]`
+	if err := s.Serve(context.Background(), strings.NewReader(batch), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var replies []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &replies); err != nil {
+		t.Fatalf("Batch response is not an array: %v", err)
+	}
+	if len(replies) != 2 || replies[1]["id"] != float64(2) {
+		t.Fatalf("Batch responses = %v", replies)
+	}
+	modules, _ := replies[1]["result"].([]any)
+	if len(modules) != len(config.ModuleNames) {
+		t.Errorf("modules returned %d names, expected %d", len(modules), len(config.ModuleNames))
+	}
+}
+
+func TestServeMCP(t *testing.T) {
+	var collected []config.ModuleConfig
+	s := newTestServer(&collected)
+
+	replies := serve(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"collect","arguments":{"modules":["memory"]}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"collect","arguments":{"modules":["floppy"]}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"reboot"}}`,
+	)
+	if len(replies) != 5 {
+		t.Fatalf("Expected 5 responses, got %d", len(replies))
+	}
+
+	initialized := replies[0]["result"].(map[string]any)
+	if initialized["protocolVersion"] != "2025-03-26" || initialized["serverInfo"].(map[string]any)["name"] != "sysinfo" {
+		t.Errorf("initialize = %v", initialized)
+	}
+
+	tools := replies[1]["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["name"] != "collect" {
+		t.Errorf("tools/list = %v", tools)
+	}
+
+	call := replies[2]["result"].(map[string]any)
+	content := call["content"].([]any)[0].(map[string]any)
+	var report map[string]any
+	if err := json.Unmarshal([]byte(content["text"].(string)), &report); err != nil || report["memory"] == nil || call["isError"] != false {
+		t.Errorf("tools/call collect = %v", call)
+	}
+
+	// Tool failures are results the model can read, unknown tools are protocol errors
+	if failed := replies[3]["result"].(map[string]any); failed["isError"] != true {
+		t.Errorf("tools/call with an unknown module = %v, expected isError", replies[3])
+	}
+	if errorCode(replies[4]) != codeInvalidParams {
+		t.Errorf("tools/call reboot = %v, expected invalid params", replies[4])
+	}
+}
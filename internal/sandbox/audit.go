@@ -0,0 +1,88 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// auditRecord is one line of the audit log
+type auditRecord struct {
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	DurationMS float64   `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`       // -1 when the command did not start or was killed by a signal
+	Error      string    `json:"error,omitempty"` // Why the command failed, including policy rejections
+	PID        int       `json:"pid"`             // The sysinfo process that ran the command
+	UID        int       `json:"uid"`             // Its user ID (-1 on Windows)
+}
+
+// auditMu serializes appends so concurrent collectors never interleave lines
+var auditMu sync.Mutex
+
+// checkAuditLog verifies the audit log can be appended to
+func checkAuditLog(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open command audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// audit appends a record for a finished run
+func (c *Cmd) audit(start time.Time, err error) {
+	if c.auditLog == "" {
+		return
+	}
+
+	record := auditRecord{
+		Time:       start,
+		Command:    c.path,
+		Args:       c.args,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		ExitCode:   -1,
+		PID:        os.Getpid(),
+		UID:        os.Getuid(),
+	}
+	if record.Args == nil {
+		record.Args = []string{}
+	}
+	if c.ProcessState != nil {
+		record.ExitCode = c.ProcessState.ExitCode()
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		record.Error = err.Error()
+	}
+
+	if err := appendAuditRecord(c.auditLog, record); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write command audit log: %v\n", err)
+	}
+}
+
+// appendAuditRecord writes one JSON line; the file is reopened per record so log rotation works
+func appendAuditRecord(path string, record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
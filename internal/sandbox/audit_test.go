@@ -0,0 +1,102 @@
+package sandbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// readAuditLog parses every record in the audit log
+func readAuditLog(t *testing.T, path string) []auditRecord {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer f.Close()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAuditLog(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	defer Configure(Policy{})
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := Configure(Policy{Allow: []string{"sh"}, AuditLog: logPath}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	if _, err := Command("sh", "-c", "exit 0").Output(); err != nil {
+		t.Skipf("sh unavailable: %v", err)
+	}
+	_ = Command("sh", "-c", "exit 3").Run()
+	_, _ = Command("lspci", "-mm").CombinedOutput()
+
+	records := readAuditLog(t, logPath)
+	if len(records) != 3 {
+		t.Fatalf("got %d records, expected 3", len(records))
+	}
+
+	tests := []struct {
+		exitCode int
+		args     []string
+		errorMsg string
+	}{
+		{0, []string{"-c", "exit 0"}, ""},
+		{3, []string{"-c", "exit 3"}, ""},
+		{-1, []string{"-mm"}, "not in the command allowlist"},
+	}
+	for i, tt := range tests {
+		record := records[i]
+		if record.ExitCode != tt.exitCode {
+			t.Errorf("record %d: exit_code = %d, expected %d", i, record.ExitCode, tt.exitCode)
+		}
+		if strings.Join(record.Args, " ") != strings.Join(tt.args, " ") {
+			t.Errorf("record %d: args = %v, expected %v", i, record.Args, tt.args)
+		}
+		if !strings.Contains(record.Error, tt.errorMsg) || (tt.errorMsg == "" && record.Error != "") {
+			t.Errorf("record %d: error = %q, expected %q", i, record.Error, tt.errorMsg)
+		}
+		if record.PID != os.Getpid() || record.Time.IsZero() || record.DurationMS < 0 {
+			t.Errorf("record %d: incomplete record %+v", i, record)
+		}
+	}
+	if !filepath.IsAbs(records[0].Command) {
+		t.Errorf("command = %q, expected the resolved absolute path", records[0].Command)
+	}
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	defer Configure(Policy{})
+	if err := Configure(Policy{}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	c := Command("sysinfo-no-such-tool")
+	if c.auditLog != "" {
+		t.Errorf("auditLog = %q, expected auditing to be off by default", c.auditLog)
+	}
+}
+
+func TestConfigureRejectsUnwritableAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.jsonl")
+	if err := Configure(Policy{AuditLog: path}); err == nil {
+		t.Error("expected error for an audit log in a missing directory")
+	}
+}
@@ -0,0 +1,234 @@
+// Package sandbox runs the external tools collectors depend on (smartctl, nvidia-smi, lspci, ...)
+// under a configurable policy: a command allowlist, resolution from trusted directories instead
+// of PATH, a cleared environment, and on Linux an optional Landlock profile
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
+
+// ExecArg is the hidden first argument that makes the binary act as the Landlock exec wrapper
+const ExecArg = "__sandbox-exec"
+
+// Policy controls how external commands are run
+type Policy struct {
+	// Allow lists the tools that may run, by name (smartctl) or absolute path (/opt/bin/smartctl).
+	// Empty allows every tool
+	Allow []string
+
+	// Restricted resolves tools only from Paths, never from PATH or the working directory,
+	// and runs them with a minimal environment
+	Restricted bool
+
+	// Paths are the trusted directories searched in restricted mode (default: system directories)
+	Paths []string
+
+	// Landlock makes tools' filesystem read-only apart from /dev (Linux 5.13+)
+	Landlock bool
+
+	// AuditLog is a JSON lines file recording every command run; empty disables auditing
+	AuditLog string
+}
+
+var (
+	policyMu sync.RWMutex
+	policy   Policy
+)
+
+// Configure replaces the policy for subsequent commands
+func Configure(p Policy) error {
+	for _, dir := range p.Paths {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("trusted command path must be absolute: %s", dir)
+		}
+	}
+	if p.Landlock {
+		if err := landlockAvailable(); err != nil {
+			return err
+		}
+	}
+	if p.AuditLog != "" {
+		if err := checkAuditLog(p.AuditLog); err != nil {
+			return err
+		}
+	}
+
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	policy = p
+	return nil
+}
+
+// current returns the active policy
+func current() Policy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return policy
+}
+
+// Cmd is an external command run under the active policy
+// Run, Output and CombinedOutput are recorded in the audit log when one is configured
+type Cmd struct {
+	*exec.Cmd
+
+	path     string   // Resolved tool path, or the requested name when it was not resolved
+	args     []string // Arguments as given, without the wrapper
+	auditLog string
+}
+
+// Command returns a Cmd for the named tool under the active policy
+// A tool that is not allowed or not found gets a Cmd whose Run/Output return the error
+func Command(name string, args ...string) *Cmd {
+	p := current()
+	c := &Cmd{path: name, args: args, auditLog: p.AuditLog}
+
+	path, err := p.resolve(name)
+	if err != nil {
+		c.Cmd = &exec.Cmd{Path: name, Args: append([]string{name}, args...), Err: err}
+		return c
+	}
+	c.path = path
+
+	if p.Landlock {
+		self, err := os.Executable()
+		if err != nil {
+			c.Cmd = &exec.Cmd{Path: name, Args: append([]string{name}, args...), Err: fmt.Errorf("sandbox: %w", err)}
+			return c
+		}
+		c.Cmd = exec.Command(self, append([]string{ExecArg, path}, args...)...)
+	} else {
+		c.Cmd = exec.Command(path, args...)
+	}
+	if p.Restricted {
+		c.Env = p.environment()
+	}
+	return c
+}
+
+// Run starts the command and waits for it to complete
+func (c *Cmd) Run() error {
+	start := time.Now()
+	err := c.Cmd.Run()
+	c.audit(start, err)
+	return err
+}
+
+// Output runs the command and returns its standard output
+func (c *Cmd) Output() ([]byte, error) {
+	start := time.Now()
+	out, err := c.Cmd.Output()
+	c.audit(start, err)
+	return out, err
+}
+
+// CombinedOutput runs the command and returns its combined standard output and standard error
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	start := time.Now()
+	out, err := c.Cmd.CombinedOutput()
+	c.audit(start, err)
+	return out, err
+}
+
+// LookPath reports where the named tool would be run from under the active policy
+func LookPath(name string) (string, error) {
+	return current().resolve(name)
+}
+
+// resolve checks the allowlist and finds the tool's executable
+func (p Policy) resolve(name string) (string, error) {
+	allowedPath, err := p.allowed(name)
+	if err != nil {
+		return "", err
+	}
+	if allowedPath != "" {
+		return allowedPath, nil
+	}
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	if !p.Restricted {
+		return utils.LookPath(name)
+	}
+
+	paths := p.trustedPaths()
+	for _, dir := range paths {
+		for _, candidate := range executableNames(name) {
+			path := filepath.Join(dir, candidate)
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() && isExecutable(info) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s not found in trusted paths (%s)", name, strings.Join(paths, ", "))
+}
+
+// allowed checks name against the allowlist; an allowlist entry with a path pins the tool to it
+func (p Policy) allowed(name string) (string, error) {
+	if len(p.Allow) == 0 {
+		return "", nil
+	}
+
+	tool := toolName(name)
+	for _, entry := range p.Allow {
+		if toolName(entry) != tool {
+			continue
+		}
+		if !filepath.IsAbs(entry) {
+			return "", nil
+		}
+		// A pinned tool requested by absolute path must be the pinned one
+		if filepath.IsAbs(name) && filepath.Clean(name) != filepath.Clean(entry) {
+			continue
+		}
+		return entry, nil
+	}
+	return "", fmt.Errorf("%s is not in the command allowlist", tool)
+}
+
+// trustedPaths returns the configured trusted directories or the platform defaults
+func (p Policy) trustedPaths() []string {
+	if len(p.Paths) > 0 {
+		return p.Paths
+	}
+	return defaultPaths()
+}
+
+// environment is the minimal environment tools run with in restricted mode
+// The C locale keeps tool output in the form the collectors parse
+func (p Policy) environment() []string {
+	env := []string{
+		"PATH=" + strings.Join(p.trustedPaths(), string(os.PathListSeparator)),
+		"LC_ALL=C",
+	}
+	if runtime.GOOS == "windows" {
+		// Many Windows tools fail to start without these
+		env = append(env, "SystemRoot="+os.Getenv("SystemRoot"), "windir="+os.Getenv("windir"))
+	}
+	return env
+}
+
+// toolName reduces a command or allowlist entry to the bare tool name, e.g. nvidia-smi
+func toolName(name string) string {
+	base := filepath.Base(name)
+	if runtime.GOOS == "windows" {
+		base = strings.TrimSuffix(strings.ToLower(base), ".exe")
+	}
+	return base
+}
+
+// executableNames lists the file names a tool may have on disk
+func executableNames(name string) []string {
+	if runtime.GOOS == "windows" && !strings.HasSuffix(strings.ToLower(name), ".exe") {
+		return []string{name + ".exe"}
+	}
+	return []string{name}
+}
@@ -0,0 +1,19 @@
+//go:build darwin
+
+package sandbox
+
+import "errors"
+
+// defaultPaths are the system directories plus Homebrew, where smartctl is usually installed
+func defaultPaths() []string {
+	return []string{"/usr/sbin", "/usr/bin", "/sbin", "/bin", "/usr/local/sbin", "/usr/local/bin", "/opt/homebrew/sbin", "/opt/homebrew/bin"}
+}
+
+func landlockAvailable() error {
+	return errors.New("landlock is only available on Linux")
+}
+
+// RunExec is only used with Landlock, which macOS does not have
+func RunExec(args []string) error {
+	return errors.New("landlock is only available on Linux")
+}
@@ -0,0 +1,92 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// defaultPaths are the system binary directories, system ones first
+func defaultPaths() []string {
+	return []string{"/usr/sbin", "/usr/bin", "/sbin", "/bin", "/usr/local/sbin", "/usr/local/bin"}
+}
+
+// landlockWriteAccess are the Landlock ABI 1 rights that modify the filesystem
+const landlockWriteAccess = unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+	unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+	unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+	unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+	unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+	unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+	unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+	unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+	unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+	unix.LANDLOCK_ACCESS_FS_MAKE_SYM
+
+// landlockWritable are the only places tools may write: device nodes, for ioctls that need O_RDWR
+var landlockWritable = []string{"/dev"}
+
+// landlockAvailable checks the kernel supports Landlock
+func landlockAvailable() error {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 || int(abi) < 1 {
+		return fmt.Errorf("landlock is not supported by this kernel (requires Linux 5.13+ with landlock enabled): %v", errno)
+	}
+	return nil
+}
+
+// RunExec is the body of the exec wrapper: args are the tool's absolute path and its arguments.
+// It restricts this thread with Landlock and replaces the process with the tool, which inherits
+// the restriction. It only returns on failure
+func RunExec(args []string) error {
+	if len(args) == 0 {
+		return errors.New("sandbox: missing command")
+	}
+
+	// Landlock applies to the calling thread, so the exec must happen on the same one
+	runtime.LockOSThread()
+	if err := restrictSelf(); err != nil {
+		return err
+	}
+	return syscall.Exec(args[0], args, os.Environ())
+}
+
+// restrictSelf applies a read-only filesystem profile to the calling thread
+func restrictSelf() error {
+	attr := unix.LandlockRulesetAttr{Access_fs: landlockWriteAccess}
+	// Only pass the ABI 1 field so older kernels accept the struct
+	rulesetFd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr.Access_fs), 0)
+	if errno != 0 {
+		return fmt.Errorf("sandbox: failed to create landlock ruleset: %w", errno)
+	}
+	defer unix.Close(int(rulesetFd))
+
+	for _, dir := range landlockWritable {
+		fd, err := unix.Open(dir, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			continue
+		}
+		rule := unix.LandlockPathBeneathAttr{Allowed_access: unix.LANDLOCK_ACCESS_FS_WRITE_FILE, Parent_fd: int32(fd)}
+		_, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, rulesetFd, unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&rule)), 0, 0, 0)
+		unix.Close(fd)
+		if errno != 0 {
+			return fmt.Errorf("sandbox: failed to add landlock rule for %s: %w", dir, errno)
+		}
+	}
+
+	// Required for unprivileged callers; also stops the tool gaining privileges through setuid binaries
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("sandbox: failed to set no_new_privs: %w", err)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, rulesetFd, 0, 0); errno != 0 {
+		return fmt.Errorf("sandbox: failed to apply landlock ruleset: %w", errno)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLandlockReadOnly(t *testing.T) {
+	if err := landlockAvailable(); err != nil {
+		t.Skip(err)
+	}
+	defer Configure(Policy{})
+	if err := Configure(Policy{Landlock: true}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	target := filepath.Join(t.TempDir(), "written")
+	out, err := Command("sh", "-c", "cat /proc/self/status >/dev/null && echo read-ok; echo x > "+target).CombinedOutput()
+	if !strings.Contains(string(out), "read-ok") {
+		t.Fatalf("output = %q (%v), expected reads to be allowed", out, err)
+	}
+	if err == nil {
+		t.Error("expected the write outside /dev to fail")
+	}
+	if _, statErr := os.Stat(target); statErr == nil {
+		t.Error("sandboxed tool created a file outside /dev")
+	}
+
+	// Device nodes stay writable
+	if out, err := Command("sh", "-c", "echo x > /dev/null").CombinedOutput(); err != nil {
+		t.Errorf("write to /dev/null failed: %v: %s", err, out)
+	}
+}
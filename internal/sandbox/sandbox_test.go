@@ -0,0 +1,128 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestMain lets the test binary act as the exec wrapper, as the sysinfo binary does
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == ExecArg {
+		if err := RunExec(os.Args[2:]); err != nil {
+			os.Stderr.WriteString(err.Error() + "\n")
+			os.Exit(126)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+// writeTool creates an executable file in dir
+func writeTool(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, executableNames(name)[0])
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPolicyResolve(t *testing.T) {
+	trusted := t.TempDir()
+	smartctl := writeTool(t, trusted, "smartctl")
+	pinned := writeTool(t, t.TempDir(), "nvidia-smi")
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		tool      string
+		expected  string
+		expectErr string
+	}{
+		{"restricted finds trusted tool", Policy{Restricted: true, Paths: []string{trusted}}, "smartctl", smartctl, ""},
+		{"restricted ignores PATH", Policy{Restricted: true, Paths: []string{trusted}}, "lspci", "", "not found in trusted paths"},
+		{"allowlisted", Policy{Allow: []string{"smartctl"}, Restricted: true, Paths: []string{trusted}}, "smartctl", smartctl, ""},
+		{"not allowlisted", Policy{Allow: []string{"smartctl"}}, "lspci", "", "lspci is not in the command allowlist"},
+		{"pinned path", Policy{Allow: []string{pinned}}, "nvidia-smi", pinned, ""},
+		{"pinned path rejects other location", Policy{Allow: []string{pinned}}, filepath.Join(trusted, "nvidia-smi"), "", "not in the command allowlist"},
+		{"absolute path", Policy{Restricted: true, Paths: []string{t.TempDir()}}, smartctl, smartctl, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := tt.policy.resolve(tt.tool)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Errorf("error = %v, expected it to contain %q", err, tt.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve returned error: %v", err)
+			}
+			if path != tt.expected {
+				t.Errorf("path = %q, expected %q", path, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommandNotAllowed(t *testing.T) {
+	defer Configure(Policy{})
+	if err := Configure(Policy{Allow: []string{"smartctl"}}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	_, err := Command("lspci").Output()
+	if err == nil || !strings.Contains(err.Error(), "not in the command allowlist") {
+		t.Errorf("error = %v, expected the allowlist to reject lspci", err)
+	}
+}
+
+func TestCommandRestrictedEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	defer Configure(Policy{})
+	t.Setenv("SYSINFO_SECRET", "leaked")
+	if err := Configure(Policy{Restricted: true}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	out, err := Command("sh", "-c", "env").Output()
+	if err != nil {
+		t.Skipf("sh unavailable in trusted paths: %v", err)
+	}
+	env := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if slices.Contains(env, "SYSINFO_SECRET=leaked") {
+		t.Error("restricted command inherited the caller's environment")
+	}
+	if !slices.Contains(env, "LC_ALL=C") {
+		t.Errorf("env = %v, expected LC_ALL=C", env)
+	}
+}
+
+func TestConfigureRejectsRelativePaths(t *testing.T) {
+	if err := Configure(Policy{Restricted: true, Paths: []string{"bin"}}); err == nil {
+		t.Error("expected error for a relative trusted path")
+	}
+}
+
+func TestToolName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"smartctl", "smartctl"},
+		{"/usr/sbin/smartctl", "smartctl"},
+	}
+
+	for _, tt := range tests {
+		if got := toolName(tt.input); got != tt.expected {
+			t.Errorf("toolName(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
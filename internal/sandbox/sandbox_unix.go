@@ -0,0 +1,10 @@
+//go:build !windows
+
+package sandbox
+
+import "io/fs"
+
+// isExecutable reports whether any execute bit is set
+func isExecutable(info fs.FileInfo) bool {
+	return info.Mode().Perm()&0o111 != 0
+}
@@ -0,0 +1,38 @@
+//go:build windows
+
+package sandbox
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// defaultPaths are System32 and the install locations of the tools the collectors use
+func defaultPaths() []string {
+	systemRoot := os.Getenv("SystemRoot")
+	if systemRoot == "" {
+		systemRoot = `C:\Windows`
+	}
+	return []string{
+		filepath.Join(systemRoot, "System32"),
+		filepath.Join(systemRoot, "System32", "WindowsPowerShell", "v1.0"),
+		`C:\Program Files\NVIDIA Corporation\NVSMI`,
+		`C:\Program Files\smartmontools\bin`,
+	}
+}
+
+// isExecutable accepts any regular file; executability is decided by the extension on Windows
+func isExecutable(info fs.FileInfo) bool {
+	return true
+}
+
+func landlockAvailable() error {
+	return errors.New("landlock is only available on Linux")
+}
+
+// RunExec is only used with Landlock, which Windows does not have
+func RunExec(args []string) error {
+	return errors.New("landlock is only available on Linux")
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2025, 1, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2025, 1, 15, 10, 8, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2025, 1, 15, 10, 15, 0, 0, time.UTC)},
+		{"fixed time later today", "30 14 * * *", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)},
+		{"fixed time tomorrow", "0 3 * * *", time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC)},
+		{"list", "5,40 * * * *", time.Date(2025, 1, 15, 10, 40, 0, 0, time.UTC)},
+		{"range with step", "0 8-18/4 * * *", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{"weekday names", "0 9 * * sat,sun", time.Date(2025, 1, 18, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"month name", "0 0 1 mar *", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"day of month or weekday", "0 0 1 * mon", time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"weekly", "@weekly", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"every", "@every 90s", base.Add(90 * time.Second)},
+		{"never", "0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+			}
+			if got := schedule.Next(base); !got.Equal(tt.expected) {
+				t.Errorf("Next = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@every soon",
+		"@every 10ms",
+		"@fortnightly",
+	}
+
+	for _, expr := range tests {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", expr)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package scheduler runs periodic tasks on cron schedules inside long-lived modes such as the agent
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Task is one periodic job
+type Task struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs each task whenever its schedule comes due
+// A task never overlaps with itself: a run that overruns its next slot makes it skip that slot
+type Scheduler struct {
+	tasks []Task
+
+	// Log receives one line per failed run; defaults to stderr
+	Log io.Writer
+
+	now   func() time.Time
+	after func(d time.Duration) <-chan time.Time
+}
+
+// New creates a scheduler for the given tasks
+func New(tasks ...Task) *Scheduler {
+	return &Scheduler{
+		tasks: tasks,
+		Log:   os.Stderr,
+		now:   time.Now,
+		after: time.After,
+	}
+}
+
+// Run blocks running tasks until ctx is cancelled and in-flight runs have returned
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, task)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop waits for each due time of one task and runs it
+func (s *Scheduler) loop(ctx context.Context, task Task) {
+	for {
+		next := task.Schedule.Next(s.now())
+		if next.IsZero() {
+			fmt.Fprintf(s.Log, "Scheduler: %s has no upcoming run, disabling it\n", task.Name)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.after(next.Sub(s.now())):
+		}
+
+		if err := task.Run(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(s.Log, "Scheduler: %s failed: %v\n", task.Name, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerRunsTasks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int32
+	task := Task{
+		Name:     "tick",
+		Schedule: EverySchedule{Interval: time.Millisecond},
+		Run: func(context.Context) error {
+			if runs.Add(1) == 3 {
+				cancel()
+			}
+			return nil
+		},
+	}
+
+	s := New(task)
+	s.Log = io.Discard
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+	if got := runs.Load(); got != 3 {
+		t.Errorf("runs = %d, expected 3", got)
+	}
+}
+
+func TestSchedulerLogsFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int32
+	task := Task{
+		Name:     "prune",
+		Schedule: EverySchedule{Interval: time.Millisecond},
+		Run: func(context.Context) error {
+			// A failed run is logged and the task keeps its schedule
+			if runs.Add(1) == 2 {
+				cancel()
+				return nil
+			}
+			return errors.New("database locked")
+		},
+	}
+
+	var log strings.Builder
+	s := New(task)
+	s.Log = &log
+	s.Run(ctx)
+
+	if got := runs.Load(); got != 2 {
+		t.Errorf("runs = %d, expected 2", got)
+	}
+	if !strings.Contains(log.String(), "prune failed: database locked") {
+		t.Errorf("log = %q, expected the failure to be reported", log.String())
+	}
+}
+
+func TestSchedulerDisablesExhaustedSchedule(t *testing.T) {
+	schedule, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	var log strings.Builder
+	s := New(Task{Name: "never", Schedule: schedule, Run: func(context.Context) error { return nil }})
+	s.Log = &log
+	s.Run(context.Background())
+
+	if !strings.Contains(log.String(), "never has no upcoming run") {
+		t.Errorf("log = %q, expected the task to be disabled", log.String())
+	}
+}
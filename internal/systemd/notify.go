@@ -0,0 +1,82 @@
+// Package systemd implements the sd_notify protocol so services can report readiness,
+// status and watchdog keep-alives to systemd without linking libsystemd
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notify sends a state string such as "READY=1" to the service manager
+// It reports false without error when not running under systemd (NOTIFY_SOCKET unset)
+func Notify(state string) (bool, error) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return false, nil
+	}
+	// A leading @ names a socket in the abstract namespace
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("failed to notify service manager: %w", err)
+	}
+	return true, nil
+}
+
+// Status formats a STATUS= line; newlines would end the field early so they are flattened
+func Status(status string) string {
+	return "STATUS=" + strings.ReplaceAll(status, "\n", " ")
+}
+
+// WatchdogInterval returns the watchdog timeout systemd expects pings within (WatchdogSec=)
+// Zero means the watchdog is disabled or meant for another process
+func WatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// Watchdog pings the service manager at half the watchdog timeout until ctx is cancelled,
+// attaching the current status to each ping. It returns immediately when the watchdog is disabled
+func Watchdog(ctx context.Context, status func() string) error {
+	timeout := WatchdogInterval()
+	if timeout == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(timeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			state := "WATCHDOG=1"
+			if status != nil {
+				state += "\n" + Status(status())
+			}
+			if _, err := Notify(state); err != nil {
+				return err
+			}
+		}
+	}
+}
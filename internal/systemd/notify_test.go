@@ -0,0 +1,115 @@
+//go:build !windows
+
+package systemd
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenNotify creates a notify socket and points NOTIFY_SOCKET at it
+func listenNotify(t *testing.T) *net.UnixConn {
+	t.Helper()
+	// Socket paths are limited to ~100 bytes, so avoid the long t.TempDir path
+	dir, err := os.MkdirTemp("", "sd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", path)
+	return conn
+}
+
+func readState(t *testing.T, conn *net.UnixConn) string {
+	t.Helper()
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("failed to read notification: %v", err)
+	}
+	return string(buf[:n])
+}
+
+func TestNotify(t *testing.T) {
+	conn := listenNotify(t)
+
+	sent, err := Notify("READY=1\n" + Status("Serving\non :8090"))
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if !sent {
+		t.Error("sent = false, expected true")
+	}
+	if got, expected := readState(t, conn), "READY=1\nSTATUS=Serving on :8090"; got != expected {
+		t.Errorf("state = %q, expected %q", got, expected)
+	}
+}
+
+func TestNotifyWithoutSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	sent, err := Notify("READY=1")
+	if err != nil || sent {
+		t.Errorf("Notify = %v, %v, expected false, nil", sent, err)
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		usec     string
+		pid      string
+		expected time.Duration
+	}{
+		{"disabled", "", "", 0},
+		{"invalid", "soon", "", 0},
+		{"enabled", "30000000", "", 30 * time.Second},
+		{"this process", "2000000", strconv.Itoa(os.Getpid()), 2 * time.Second},
+		{"other process", "2000000", "1", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			t.Setenv("WATCHDOG_PID", tt.pid)
+			if got := WatchdogInterval(); got != tt.expected {
+				t.Errorf("WatchdogInterval = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWatchdogPings(t *testing.T) {
+	conn := listenNotify(t)
+	t.Setenv("WATCHDOG_USEC", "20000")
+	t.Setenv("WATCHDOG_PID", "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Watchdog(ctx, func() string { return "last collection 12:00" })
+	}()
+
+	state := readState(t, conn)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watchdog returned error: %v", err)
+	}
+	if !strings.HasPrefix(state, "WATCHDOG=1\n") || !strings.Contains(state, "STATUS=last collection 12:00") {
+		t.Errorf("state = %q, expected a watchdog ping with status", state)
+	}
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// FieldSet selects JSON fields by name at each level of a report; a field with no children
+// is kept whole. Lists are selected through, so a FieldSet applies to each of their elements
+type FieldSet map[string]FieldSet
+
+// filterJSON removes the fields not in the set from encoded JSON, keeping the encoder's field order
+func (f FieldSet) filterJSON(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(f) == 0 || len(data) == 0 {
+		return data, nil
+	}
+
+	switch data[0] {
+	case '{':
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			name, _ := token.(string)
+			sub, ok := f[name]
+			if !ok {
+				continue
+			}
+			if value, err = sub.filterJSON(value); err != nil {
+				return nil, err
+			}
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(name)
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return nil, err
+		}
+		for i := range elements {
+			filtered, err := f.filterJSON(elements[i])
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = filtered
+		}
+		return json.Marshal(elements)
+	default:
+		return data, nil
+	}
+}
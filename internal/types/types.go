@@ -1,18 +1,145 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mayvqt/sysinfo/internal/utils"
+)
 
 // SystemInfo holds all collected system information
 type SystemInfo struct {
-	Timestamp time.Time    `json:"timestamp"`
-	System    *SystemData  `json:"system,omitempty"`
-	CPU       *CPUData     `json:"cpu,omitempty"`
-	Memory    *MemoryData  `json:"memory,omitempty"`
-	Disk      *DiskData    `json:"disk,omitempty"`
-	Network   *NetworkData `json:"network,omitempty"`
-	Processes *ProcessData `json:"processes,omitempty"`
-	GPU       *GPUData     `json:"gpu,omitempty"`
-	Battery   *BatteryData `json:"battery,omitempty"`
+	Timestamp    time.Time        `json:"timestamp"`
+	System       *SystemData      `json:"system,omitempty"`
+	Baseboard    *BoardData       `json:"baseboard,omitempty"`
+	CPU          *CPUData         `json:"cpu,omitempty"`
+	Memory       *MemoryData      `json:"memory,omitempty"`
+	Disk         *DiskData        `json:"disk,omitempty"`
+	Network      *NetworkData     `json:"network,omitempty"`
+	Processes    *ProcessData     `json:"processes,omitempty"`
+	GPU          *GPUData         `json:"gpu,omitempty"`
+	Battery      *BatteryData     `json:"battery,omitempty"`
+	Security     *SecurityData    `json:"security,omitempty"`
+	Accelerators *AcceleratorData `json:"accelerators,omitempty"`
+	PCI          *PCIData         `json:"pci,omitempty"`
+	USB          *USBData         `json:"usb,omitempty"`
+	Displays     *DisplayData     `json:"displays,omitempty"`
+	Audio        *AudioData       `json:"audio,omitempty"`
+	Bluetooth    *BluetoothData   `json:"bluetooth,omitempty"`
+	Drivers      *DriverData      `json:"drivers,omitempty"`
+	Services     *ServiceData     `json:"services,omitempty"`
+	Firewall     *FirewallData    `json:"firewall,omitempty"`
+	Packages     *PackageData     `json:"packages,omitempty"`
+	Users        *UserData        `json:"users,omitempty"`
+	Thermal      *ThermalData     `json:"thermal,omitempty"`
+	Sensors      *SensorsData     `json:"sensors,omitempty"`
+	Integrity    *IntegrityData   `json:"integrity,omitempty"`
+	Health       *HostHealth      `json:"health,omitempty"` // Composite score of what was collected
+
+	// What to do about the problems found, most severe first
+	Recommendations []Recommendation `json:"recommendations,omitempty"`
+
+	// Information about the collection itself
+	Meta *ReportMeta `json:"meta,omitempty"`
+
+	// Modules that were skipped, with the reason
+	Errors []CollectionError `json:"errors,omitempty"`
+
+	// Identifiers were masked for sharing (--redact)
+	Redacted bool `json:"redacted,omitempty"`
+
+	// How Timestamp is written: rfc3339, unix, none, or empty for RFC 3339 with nanoseconds
+	TimestampFormat string `json:"-"`
+
+	// Fields written to JSON, with the timestamp; nil writes every field
+	Fields FieldSet `json:"-"`
+}
+
+// MarshalJSON writes Timestamp according to TimestampFormat and only the selected Fields
+func (s SystemInfo) MarshalJSON() ([]byte, error) {
+	// The alias drops this method; the outer Timestamp field shadows the embedded one
+	type alias SystemInfo
+	var timestamp any
+	var data []byte
+	var err error
+	switch s.TimestampFormat {
+	case utils.TimestampRFC3339:
+		timestamp = s.Timestamp.Format(time.RFC3339)
+	case utils.TimestampUnix:
+		timestamp = s.Timestamp.Unix()
+	case utils.TimestampNone:
+		timestamp = nil
+	default:
+		data, err = json.Marshal(alias(s))
+	}
+	if data == nil && err == nil {
+		data, err = json.Marshal(struct {
+			Timestamp any `json:"timestamp,omitempty"`
+			alias
+		}{timestamp, alias(s)})
+	}
+	if err != nil || len(s.Fields) == 0 {
+		return data, err
+	}
+
+	fields := FieldSet{"timestamp": nil, "redacted": nil}
+	for name, sub := range s.Fields {
+		fields[name] = sub
+	}
+	return fields.filterJSON(data)
+}
+
+// UnmarshalJSON reads reports written with any TimestampFormat: an RFC 3339 string, seconds
+// since the epoch, or no timestamp at all
+func (s *SystemInfo) UnmarshalJSON(data []byte) error {
+	// The alias drops this method; the outer Timestamp field shadows the embedded one
+	type alias SystemInfo
+	decoded := struct {
+		Timestamp json.RawMessage `json:"timestamp"`
+		*alias
+	}{alias: (*alias)(s)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	s.Timestamp = time.Time{}
+	switch raw := string(decoded.Timestamp); {
+	case raw == "" || raw == "null":
+	case raw[0] == '"':
+		if err := json.Unmarshal(decoded.Timestamp, &s.Timestamp); err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+	default:
+		var seconds int64
+		if err := json.Unmarshal(decoded.Timestamp, &seconds); err != nil {
+			return fmt.Errorf("invalid timestamp %s", raw)
+		}
+		s.Timestamp = time.Unix(seconds, 0)
+	}
+	return nil
+}
+
+// CollectionError is a module left out of the report and why
+type CollectionError struct {
+	Module string `json:"module"`
+	Error  string `json:"error"`
+}
+
+// ReportMeta describes how and when a report was collected
+type ReportMeta struct {
+	// Local clock offset measured against a time server (timesync module)
+	ClockOffset *ClockOffset `json:"clock_offset,omitempty"`
+}
+
+// ClockOffset is the local clock's offset from an NTP server
+// A positive offset means the local clock is behind; add it to local times to correct them
+type ClockOffset struct {
+	Server      string    `json:"server"`
+	OffsetMS    float64   `json:"offset_ms"`
+	RoundTripMS float64   `json:"round_trip_ms"`
+	Stratum     int       `json:"stratum"`
+	MeasuredAt  time.Time `json:"measured_at"`
 }
 
 // SystemData contains general system information
@@ -28,6 +155,71 @@ type SystemData struct {
 	UptimeFormatted string `json:"uptime_formatted"`
 	BootTime        uint64 `json:"boot_time"`
 	Procs           uint64 `json:"processes"`
+
+	// Windows installation type: Client, Server, Server Core or Nano Server (Windows only)
+	InstallationType string `json:"installation_type,omitempty"`
+
+	// Windows edition and activation details (Windows only)
+	License *OSLicense `json:"license,omitempty"`
+
+	// Services that failed: failed systemd units on Linux, automatic services stopped with an
+	// error on Windows
+	FailedServices []string `json:"failed_services,omitempty"`
+}
+
+// BoardData identifies the machine from its DMI/SMBIOS tables: the system as sold, the
+// motherboard in it, its firmware and its enclosure. Serials and the UUID usually need
+// root or administrator rights
+type BoardData struct {
+	SystemVendor  string `json:"system_vendor,omitempty"`  // e.g. Dell Inc., LENOVO
+	SystemProduct string `json:"system_product,omitempty"` // e.g. PowerEdge R740, 20XW0026US
+	SystemVersion string `json:"system_version,omitempty"` // e.g. ThinkPad X1 Carbon Gen 9
+	SystemSerial  string `json:"system_serial,omitempty"`  // Service tag or serial number
+	UUID          string `json:"uuid,omitempty"`           // SMBIOS system UUID
+
+	Vendor  string `json:"vendor,omitempty"` // Motherboard manufacturer
+	Product string `json:"product,omitempty"`
+	Version string `json:"version,omitempty"`
+	Serial  string `json:"serial,omitempty"`
+
+	BIOSVendor  string `json:"bios_vendor,omitempty"`
+	BIOSVersion string `json:"bios_version,omitempty"`
+	BIOSDate    string `json:"bios_date,omitempty"` // Release date, YYYY-MM-DD
+
+	ChassisType string `json:"chassis_type,omitempty"` // SMBIOS chassis type, e.g. Desktop, Notebook, Rack Mount Chassis
+}
+
+// HostHealth is a 0-100 composite of the host's health, 100 being healthy, so a fleet can be
+// ranked at a glance
+type HostHealth struct {
+	Score      float64           `json:"score"`
+	Components []HealthComponent `json:"components"`
+}
+
+// HealthComponent is one scored aspect of host health
+type HealthComponent struct {
+	Name   string  `json:"name"`             // smart, disk, memory, temperature, services
+	Score  float64 `json:"score"`            // 0-100
+	Weight float64 `json:"weight"`           // Share of the composite
+	Detail string  `json:"detail,omitempty"` // What the score comes from
+}
+
+// Recommendation is an action to take about a problem found in the report
+type Recommendation struct {
+	Severity  string `json:"severity"`          // CRITICAL, WARNING
+	Component string `json:"component"`         // memory, file_handles, zombies, services, smart, gpu
+	Subject   string `json:"subject,omitempty"` // Process, service or device to act on
+	Action    string `json:"action"`
+}
+
+// OSLicense contains operating system edition and activation status
+type OSLicense struct {
+	Edition              string     `json:"edition"`                                  // e.g. "Microsoft Windows 11 Pro"
+	Status               string     `json:"status"`                                   // Licensed, Unlicensed, OOBGrace, Notification, ...
+	Channel              string     `json:"channel,omitempty"`                        // RETAIL, OEM_DM, VOLUME_KMSCLIENT, ...
+	PartialProductKey    string     `json:"partial_product_key,omitempty"`            // Last five characters of the product key
+	GracePeriodRemaining int        `json:"grace_period_remaining_minutes,omitempty"` // Minutes until grace expires
+	InstallDate          *time.Time `json:"install_date,omitempty"`
 }
 
 // CPUData contains CPU information
@@ -47,6 +239,34 @@ type CPUData struct {
 	LoadAvg     *LoadAverage `json:"load_average,omitempty"`
 	Flags       []string     `json:"flags,omitempty"`
 	Microcode   string       `json:"microcode,omitempty"`
+
+	// Topology: sockets, and cores and threads within them. Packages lists each socket's
+	// CPU, one entry per model where a socket mixes core models (big.LITTLE)
+	Sockets        int32        `json:"sockets,omitempty"`
+	CoresPerSocket int32        `json:"cores_per_socket,omitempty"`
+	ThreadsPerCore int32        `json:"threads_per_core,omitempty"`
+	Packages       []CPUPackage `json:"packages,omitempty"`
+
+	// Hybrid CPUs (Intel P/E cores, ARM big.LITTLE, Apple Silicon): utilization per core
+	// class, as an average across unlike cores is misleading
+	CoreClasses []CPUCoreClass `json:"core_classes,omitempty"`
+}
+
+// CPUCoreClass groups the logical CPUs of one kind of core on a hybrid CPU
+type CPUCoreClass struct {
+	Class string  `json:"class"`         // performance, efficiency
+	CPUs  []int   `json:"cpus"`          // Logical CPU numbers, indexes into usage_percent
+	Usage float64 `json:"usage_percent"` // Mean utilization of the class's logical CPUs
+}
+
+// CPUPackage is the CPU in one socket, or the cores of one model within it
+type CPUPackage struct {
+	Socket    int32  `json:"socket"`
+	ModelName string `json:"model_name"`
+	Stepping  int32  `json:"stepping,omitempty"`
+	Microcode string `json:"microcode,omitempty"`
+	Cores     int32  `json:"cores"`
+	Threads   int32  `json:"threads"`
 }
 
 // LoadAverage contains system load averages
@@ -96,6 +316,7 @@ type DiskData struct {
 	PhysicalDisks []PhysicalDisk  `json:"physical_disks,omitempty"`
 	IOStats       []DiskIOStat    `json:"io_stats,omitempty"`
 	SMARTData     []SMARTInfo     `json:"smart_data,omitempty"`
+	Trim          *TrimStatus     `json:"trim,omitempty"`
 }
 
 // PhysicalDisk contains information about physical disks
@@ -109,6 +330,26 @@ type PhysicalDisk struct {
 	Interface     string `json:"interface,omitempty"` // SATA, NVMe, USB, etc.
 	RPM           uint32 `json:"rpm,omitempty"`       // For HDDs
 	Removable     bool   `json:"removable"`
+
+	Slot *EnclosureSlot `json:"slot,omitempty"` // Backplane slot, when an enclosure reports one
+}
+
+// EnclosureSlot is the backplane slot a drive sits in, as reported by SCSI enclosure services
+type EnclosureSlot struct {
+	Device    string `json:"device"`           // Drive in the slot
+	Serial    string `json:"serial,omitempty"` // Drive serial, where the enclosure reports it
+	Enclosure string `json:"enclosure"`        // Enclosure ID (SCSI address on Linux, enclosure number on Windows)
+	Slot      int    `json:"slot"`             // Slot number printed on the backplane
+	Label     string `json:"label,omitempty"`  // Enclosure's name for the slot, e.g. "Slot 04"
+	Locate    bool   `json:"locate"`           // Identification LED is on
+}
+
+// String describes the slot for people looking at the chassis
+func (s EnclosureSlot) String() string {
+	if s.Label != "" {
+		return fmt.Sprintf("enclosure %s, slot %d (%s)", s.Enclosure, s.Slot, s.Label)
+	}
+	return fmt.Sprintf("enclosure %s, slot %d", s.Enclosure, s.Slot)
 }
 
 // PartitionInfo contains information about a disk partition
@@ -140,6 +381,25 @@ type DiskIOStat struct {
 	IoTime     uint64 `json:"io_time_ms"`
 }
 
+// TrimStatus contains TRIM/discard configuration for solid-state storage
+type TrimStatus struct {
+	Enabled       bool             `json:"enabled"`                   // Continuous discard on every SSD filesystem, or OS-level TRIM, is enabled
+	TimerActive   bool             `json:"timer_active"`              // Periodic trim is scheduled (fstrim.timer, Optimize Drives)
+	LastTrim      *time.Time       `json:"last_trim,omitempty"`       // Last successful periodic trim
+	DaysSinceTrim int              `json:"days_since_trim,omitempty"` // Days since LastTrim
+	Filesystems   []TrimFilesystem `json:"filesystems,omitempty"`     // Mounted filesystems backed by SSDs
+	Status        string           `json:"status"`                    // OK, WARN, or UNKNOWN when the settings could not be read
+	Message       string           `json:"message,omitempty"`
+}
+
+// TrimFilesystem contains discard settings for a mounted SSD filesystem
+type TrimFilesystem struct {
+	Device     string `json:"device"`
+	MountPoint string `json:"mount_point"`
+	FSType     string `json:"fs_type"`
+	Discard    bool   `json:"discard"` // Mounted with continuous discard
+}
+
 // SMARTInfo contains SMART data for a drive
 type SMARTInfo struct {
 	Device           string             `json:"device"`
@@ -162,6 +422,21 @@ type SMARTInfo struct {
 	ErrorLog         *SMARTErrorLog     `json:"error_log,omitempty"`
 	SelfTestLog      *SMARTSelfTestLog  `json:"self_test_log,omitempty"`
 	HealthAssessment *SMARTHealthStatus `json:"health_assessment,omitempty"`
+	History          *SMARTHistory      `json:"history,omitempty"` // Recent readings from the history database
+	Slot             *EnclosureSlot     `json:"slot,omitempty"`    // Backplane slot, when an enclosure reports one
+}
+
+// SMARTHistory is a device's recent trend, embedded so a report tells the story without the database
+type SMARTHistory struct {
+	Since  time.Time           `json:"since"`
+	Points []SMARTHistoryPoint `json:"points"` // Oldest first
+}
+
+// SMARTHistoryPoint is one recorded reading
+type SMARTHistoryPoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Temperature int       `json:"temperature_celsius,omitempty"`
+	PercentUsed float64   `json:"percent_used,omitempty"` // SSD wear
 }
 
 // SMARTAttribute contains detailed information about a SMART attribute
@@ -222,6 +497,22 @@ type SMARTHealthStatus struct {
 	TemperatureStatus string   `json:"temperature_status,omitempty"`
 }
 
+// SMARTCapabilities describes which SMART features a drive supports
+type SMARTCapabilities struct {
+	Device             string `json:"device"`
+	Protocol           string `json:"protocol,omitempty"` // ATA, NVMe, SCSI
+	SMARTSupported     bool   `json:"smart_supported"`
+	SMARTEnabled       bool   `json:"smart_enabled"`
+	SelfTest           bool   `json:"self_test"`
+	ConveyanceSelfTest bool   `json:"conveyance_self_test"`
+	SelectiveSelfTest  bool   `json:"selective_self_test"`
+	ErrorLogging       bool   `json:"error_logging"`
+	SCT                bool   `json:"sct"`                        // SMART Command Transport
+	SCTErrorRecovery   bool   `json:"sct_error_recovery_control"` // SCT ERC (TLER)
+	Sanitize           bool   `json:"sanitize"`
+	TRIM               bool   `json:"trim"`
+}
+
 // NetworkData contains network information
 type NetworkData struct {
 	Interfaces  []NetworkInterface `json:"interfaces"`
@@ -243,6 +534,28 @@ type NetworkInterface struct {
 	ErrorsOut    uint64   `json:"errors_out"`
 	DropsIn      uint64   `json:"drops_in"`
 	DropsOut     uint64   `json:"drops_out"`
+
+	// Association of a wireless interface, omitted for wired ones
+	WiFi *WiFiLink `json:"wifi,omitempty"`
+
+	// Live throughput, only populated in watch mode
+	SentBytesPerSec float64 `json:"sent_bytes_per_sec,omitempty"`
+	RecvBytesPerSec float64 `json:"recv_bytes_per_sec,omitempty"`
+}
+
+// WiFiLink describes the access point a wireless interface is associated with
+type WiFiLink struct {
+	Connected     bool    `json:"connected"`
+	SSID          string  `json:"ssid,omitempty"`
+	BSSID         string  `json:"bssid,omitempty"`          // Access point radio's MAC address
+	FrequencyMHz  int     `json:"frequency_mhz,omitempty"`  // Center frequency of the primary channel
+	Channel       int     `json:"channel,omitempty"`        // Primary channel number
+	Band          string  `json:"band,omitempty"`           // 2.4 GHz, 5 GHz, 6 GHz
+	Standard      string  `json:"standard,omitempty"`       // 802.11n, 802.11ac, 802.11ax, 802.11be
+	TxRateMbps    float64 `json:"tx_rate_mbps,omitempty"`   // Link speed of the last transmitted frames
+	RxRateMbps    float64 `json:"rx_rate_mbps,omitempty"`   // Link speed of the last received frames
+	SignalDBm     int     `json:"signal_dbm,omitempty"`     // Received signal strength (Linux, macOS)
+	SignalPercent int     `json:"signal_percent,omitempty"` // Signal quality (Windows, NetworkManager)
 }
 
 // ProcessData contains process information
@@ -252,18 +565,82 @@ type ProcessData struct {
 	Sleeping    int           `json:"sleeping"`
 	TopByMemory []ProcessInfo `json:"top_by_memory,omitempty"`
 	TopByCPU    []ProcessInfo `json:"top_by_cpu,omitempty"`
+	TopByDiskIO []ProcessInfo `json:"top_by_disk_io,omitempty"`
+	TopByGPU    []ProcessInfo `json:"top_by_gpu,omitempty"`
+
+	// Processes holding the most file descriptors (Linux only)
+	TopByOpenFiles []ProcessInfo `json:"top_by_open_files,omitempty"`
+
+	// Resource usage summed per container, busiest first (container hosts only)
+	Containers []ContainerUsage `json:"containers,omitempty"`
+
+	// Exited processes not yet reaped, and the parents not reaping them, most zombies first
+	Zombies       int            `json:"zombies,omitempty"`
+	ZombieParents []ZombieParent `json:"zombie_parents,omitempty"`
+
+	// Open file handles across the system and their limit (Linux, macOS)
+	FileHandles *FileHandleUsage `json:"file_handles,omitempty"`
+}
+
+// ZombieParent is a process with exited children it has not reaped
+type ZombieParent struct {
+	PID     int32  `json:"pid"`
+	Name    string `json:"name,omitempty"`
+	Zombies int    `json:"zombies"`
+}
+
+// FileHandleUsage is how many file handles are open system-wide, out of the kernel's maximum
+type FileHandleUsage struct {
+	Open uint64 `json:"open"`
+	Max  uint64 `json:"max"`
 }
 
 // ProcessInfo contains information about a single process
 type ProcessInfo struct {
 	PID           int32   `json:"pid"`
 	Name          string  `json:"name"`
+	Exe           string  `json:"exe,omitempty"` // Full path of the executable (top processes only)
 	Username      string  `json:"username,omitempty"`
 	CPUPercent    float64 `json:"cpu_percent"`
 	MemoryPercent float32 `json:"memory_percent"`
 	MemoryMB      uint64  `json:"memory_mb"`
 	Status        string  `json:"status"`
 	CreateTime    int64   `json:"create_time,omitempty"`
+
+	// Cumulative I/O since process start; includes non-disk I/O on Windows
+	DiskReadBytes  uint64 `json:"disk_read_bytes,omitempty"`
+	DiskWriteBytes uint64 `json:"disk_write_bytes,omitempty"`
+	// Busiest GPU engine utilization (Windows only)
+	GPUPercent float64 `json:"gpu_percent,omitempty"`
+	// Open file descriptors (Linux only) and the soft limit on them (top processes only)
+	OpenFiles      int32  `json:"open_files,omitempty"`
+	OpenFilesLimit uint64 `json:"open_files_limit,omitempty"`
+
+	// systemd service the process runs in, from its cgroup (Linux only, top processes only)
+	Service string `json:"service,omitempty"`
+
+	// Only captured when requested, with secrets redacted
+	Cmdline string            `json:"cmdline,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+
+	// Container the process runs in, derived from its cgroup path (Linux only)
+	Container *ContainerRef `json:"container,omitempty"`
+}
+
+// ContainerRef identifies the container and Kubernetes pod a process belongs to
+type ContainerRef struct {
+	ID      string `json:"id"`                // Full container ID
+	Runtime string `json:"runtime"`           // docker, containerd, crio, podman or lxc
+	PodUID  string `json:"pod_uid,omitempty"` // Kubernetes pod UID, when managed by the kubelet
+}
+
+// ContainerUsage is the combined resource usage of all processes in one container
+type ContainerUsage struct {
+	ContainerRef
+	Processes     int     `json:"processes"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float32 `json:"memory_percent"`
+	MemoryMB      uint64  `json:"memory_mb"`
 }
 
 // BatteryData contains battery information for laptops and UPS devices
@@ -322,7 +699,17 @@ type UPSInfo struct {
 
 // GPUData contains GPU information
 type GPUData struct {
-	GPUs []GPUInfo `json:"gpus"`
+	GPUs         []GPUInfo        `json:"gpus"`
+	DriverIssues []GPUDriverIssue `json:"driver_issues,omitempty"`
+}
+
+// GPUDriverIssue is an inconsistency in a GPU's driver stack
+type GPUDriverIssue struct {
+	GPUIndex       int    `json:"gpu_index"`
+	Severity       string `json:"severity"` // WARNING, CRITICAL
+	Code           string `json:"code"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
 }
 
 // GPUInfo contains information about a single GPU
@@ -346,4 +733,407 @@ type GPUInfo struct {
 	ClockSpeedMemory  int     `json:"clock_speed_memory_mhz,omitempty"`
 	PCIBus            string  `json:"pci_bus,omitempty"`
 	UUID              string  `json:"uuid,omitempty"`
+
+	// Thermal thresholds reported by the driver (NVIDIA)
+	TemperatureSlowdown int `json:"temperature_slowdown_celsius,omitempty"` // Clocks are throttled above this
+	TemperatureShutdown int `json:"temperature_shutdown_celsius,omitempty"` // GPU shuts down above this
+
+	// Driver stack (NVIDIA on Linux)
+	KernelModuleVersion string `json:"kernel_module_version,omitempty"` // Version of the loaded kernel module
+	CUDAVersion         string `json:"cuda_version,omitempty"`          // Highest CUDA version the driver supports
+	CUDARuntimeVersion  string `json:"cuda_runtime_version,omitempty"`  // Installed CUDA toolkit runtime
+	DriverError         string `json:"driver_error,omitempty"`          // Why the vendor tool could not query the GPU
+
+	// Partitioning (NVIDIA data center GPUs)
+	MIGMode            string         `json:"mig_mode,omitempty"`            // Enabled, Disabled
+	VirtualizationMode string         `json:"virtualization_mode,omitempty"` // None, Pass-Through, Host VGPU, VGPU
+	Partitions         []GPUPartition `json:"partitions,omitempty"`          // MIG instances or vGPUs carved out of this GPU
+
+	// Processes using the GPU's memory (NVIDIA on Linux)
+	Processes []GPUProcess `json:"processes,omitempty"`
+}
+
+// GPUProcess is a process holding GPU memory
+type GPUProcess struct {
+	PID        int    `json:"pid"`
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"` // C (compute), G (graphics) or C+G
+	MemoryUsed uint64 `json:"memory_used_bytes"`
+}
+
+// GPUPartition is a slice of a physical GPU: a MIG instance or a vGPU assigned to a VM
+type GPUPartition struct {
+	Kind              string `json:"kind"`                          // mig, vgpu
+	Index             int    `json:"index"`                         // MIG device index or vGPU instance number
+	Profile           string `json:"profile,omitempty"`             // e.g. 3g.40gb, GRID A100-4C
+	UUID              string `json:"uuid,omitempty"`                // MIG-... or vGPU UUID
+	GPUInstanceID     *int   `json:"gpu_instance_id,omitempty"`     // MIG only
+	ComputeInstanceID *int   `json:"compute_instance_id,omitempty"` // MIG only
+	Multiprocessors   int    `json:"multiprocessors,omitempty"`     // Streaming multiprocessors in the slice (MIG)
+	MemoryTotal       uint64 `json:"memory_total_bytes,omitempty"`
+	MemoryUsed        uint64 `json:"memory_used_bytes,omitempty"`
+	Utilization       int    `json:"utilization_percent,omitempty"` // vGPU only
+	VMName            string `json:"vm_name,omitempty"`             // VM the vGPU is assigned to
+}
+
+// AcceleratorData contains non-GPU compute accelerators
+type AcceleratorData struct {
+	Accelerators []AcceleratorInfo `json:"accelerators"`
+}
+
+// AcceleratorInfo describes one NPU, TPU, AI accelerator or FPGA
+type AcceleratorInfo struct {
+	Type         string `json:"type"`                // NPU, TPU, AI Accelerator, FPGA
+	Name         string `json:"name"`                // e.g. Intel Meteor Lake NPU, Coral Edge TPU
+	Vendor       string `json:"vendor,omitempty"`    // Intel, AMD, Google, Habana, Xilinx, ...
+	Bus          string `json:"bus"`                 // pci, usb
+	Address      string `json:"address,omitempty"`   // PCI address, USB port or PnP device ID
+	VendorID     string `json:"vendor_id,omitempty"` // PCI/USB vendor ID (hex)
+	DeviceID     string `json:"device_id,omitempty"` // PCI/USB device ID (hex)
+	Driver       string `json:"driver,omitempty"`    // Bound kernel driver
+	DriverLoaded bool   `json:"driver_loaded"`       // Whether a driver is bound to the device
+}
+
+// PCIData lists every PCI function on the machine's PCI buses
+type PCIData struct {
+	Devices []PCIDevice `json:"devices"`
+}
+
+// PCIDevice is one PCI function, identified by its IDs and class code
+type PCIDevice struct {
+	Address           string `json:"address"`                       // domain:bus:device.function, e.g. 0000:00:1f.3; the PnP device ID on Windows
+	VendorID          string `json:"vendor_id"`                     // Hex, e.g. 8086
+	DeviceID          string `json:"device_id"`                     // Hex
+	SubsystemVendorID string `json:"subsystem_vendor_id,omitempty"` // Card or board maker, hex
+	SubsystemID       string `json:"subsystem_id,omitempty"`        // Hex
+	Revision          string `json:"revision,omitempty"`            // Hex
+	Class             string `json:"class,omitempty"`               // Base class, subclass and interface, hex, e.g. 010802
+	ClassName         string `json:"class_name,omitempty"`          // e.g. Non-Volatile memory controller
+	Vendor            string `json:"vendor,omitempty"`              // Vendor name, when known
+	Name              string `json:"name,omitempty"`                // Device name, when known
+	Driver            string `json:"driver,omitempty"`              // Bound kernel driver or Windows service
+}
+
+// USBData lists the devices connected to the machine's USB buses
+type USBData struct {
+	Devices []USBDevice `json:"devices"`
+}
+
+// USBDevice is one connected USB device. Hubs are listed, the controllers' root hubs are not
+type USBDevice struct {
+	Address   string   `json:"address"`               // sysfs name, e.g. 1-2.3 for bus 1, port 2.3; the PnP device ID on Windows; the location ID on macOS
+	Bus       int      `json:"bus,omitempty"`         // Bus number
+	Port      string   `json:"port,omitempty"`        // Port path from the root hub, e.g. 2.3 for port 3 of the hub on port 2
+	VendorID  string   `json:"vendor_id"`             // Hex, e.g. 046d
+	ProductID string   `json:"product_id"`            // Hex
+	Vendor    string   `json:"vendor,omitempty"`      // Manufacturer name, when known
+	Product   string   `json:"product,omitempty"`     // Product name, when known
+	Serial    string   `json:"serial,omitempty"`      // Serial number string, for devices that report one
+	SpeedMbps float64  `json:"speed_mbps,omitempty"`  // Negotiated speed: 1.5, 12, 480, 5000, 10000 or 20000
+	Version   string   `json:"usb_version,omitempty"` // USB version the device declares, e.g. 2.00
+	Class     string   `json:"class,omitempty"`       // Device class, or the first interface's for per-interface devices, hex, e.g. 03
+	ClassName string   `json:"class_name,omitempty"`  // e.g. Human Interface Device
+	Drivers   []string `json:"drivers,omitempty"`     // Drivers bound to its interfaces, or Windows service
+}
+
+// DisplayData lists the connected monitors
+type DisplayData struct {
+	Displays []DisplayInfo `json:"displays"`
+}
+
+// DisplayInfo is one connected monitor, identified by its EDID
+type DisplayInfo struct {
+	Name           string  `json:"name"`                      // Connector, e.g. HDMI-A-1 or eDP-1; the monitor instance on Windows; the display ID on macOS
+	Manufacturer   string  `json:"manufacturer,omitempty"`    // e.g. Dell
+	ManufacturerID string  `json:"manufacturer_id,omitempty"` // Three-letter PNP ID, e.g. DEL
+	Model          string  `json:"model,omitempty"`           // Monitor name, e.g. DELL U2720Q
+	ProductCode    string  `json:"product_code,omitempty"`    // Hex
+	Serial         string  `json:"serial,omitempty"`
+	Year           int     `json:"manufacture_year,omitempty"`
+	Width          int     `json:"width,omitempty"`      // Current resolution in pixels, or the preferred mode's where the current one is unknown
+	Height         int     `json:"height,omitempty"`     // Pixels
+	RefreshHz      float64 `json:"refresh_hz,omitempty"` // Refresh rate of that mode
+	WidthMM        int     `json:"width_mm,omitempty"`   // Physical image size
+	HeightMM       int     `json:"height_mm,omitempty"`
+	DiagonalInches float64 `json:"diagonal_inches,omitempty"`
+	Primary        bool    `json:"primary,omitempty"` // Primary or main display
+	Builtin        bool    `json:"builtin,omitempty"` // Laptop panel
+}
+
+// AudioData lists the sound cards and audio devices
+type AudioData struct {
+	Devices []AudioDevice `json:"devices"`
+}
+
+// AudioDevice is a sound card, or on macOS an audio device, with the outputs and inputs it provides
+type AudioDevice struct {
+	Name         string   `json:"name"`                   // e.g. HDA Intel PCH, Realtek(R) Audio or MacBook Pro Speakers
+	ID           string   `json:"id,omitempty"`           // ALSA card ID, e.g. PCH; the PnP device ID on Windows
+	Manufacturer string   `json:"manufacturer,omitempty"` // Windows and macOS
+	Codecs       []string `json:"codecs,omitempty"`       // HD Audio codecs, e.g. Realtek ALC257 (Linux)
+	Driver       string   `json:"driver,omitempty"`       // Kernel driver, e.g. snd_hda_intel; the driver service on Windows
+	Bus          string   `json:"bus,omitempty"`          // pci, usb, hdaudio, bluetooth, builtin, hdmi...
+	Playback     []string `json:"playback,omitempty"`     // Output devices, e.g. ALC257 Analog or Speakers
+	Capture      []string `json:"capture,omitempty"`      // Input devices
+	Default      bool     `json:"default,omitempty"`      // The default output or input (macOS)
+	Status       string   `json:"status,omitempty"`       // Device status where it is not working (Windows), e.g. Error
+}
+
+// BluetoothData lists the Bluetooth adapters and the devices paired with this host
+type BluetoothData struct {
+	Adapters []BluetoothAdapter `json:"adapters"`
+	Devices  []BluetoothDevice  `json:"devices,omitempty"`
+}
+
+// BluetoothAdapter is a Bluetooth controller
+type BluetoothAdapter struct {
+	Name         string `json:"name"`                   // e.g. hci0, the device name on Windows or the chipset on macOS
+	Address      string `json:"address,omitempty"`      // e.g. 00:1A:7D:DA:71:13
+	Manufacturer string `json:"manufacturer,omitempty"` // e.g. Intel Corp.
+	Version      string `json:"version,omitempty"`      // Bluetooth core version, e.g. 5.2 (Linux)
+	Firmware     string `json:"firmware,omitempty"`     // Firmware version (macOS), or the LMP subversion identifying it (Linux)
+	Driver       string `json:"driver,omitempty"`       // Kernel driver, e.g. btusb; the driver service on Windows
+	Bus          string `json:"bus,omitempty"`          // usb, pci, uart, pcie...
+	Powered      bool   `json:"powered"`                // The radio is on; on Windows, the adapter is enabled
+}
+
+// BluetoothDevice is a device paired with this host
+type BluetoothDevice struct {
+	Name           string `json:"name"`
+	Address        string `json:"address,omitempty"`
+	Type           string `json:"type,omitempty"` // e.g. audio-headset (Linux) or Keyboard (macOS)
+	Connected      bool   `json:"connected"`
+	BatteryPercent int    `json:"battery_percent,omitempty"` // Where the device reports it; the lowest part's for earbuds
+}
+
+// DriverData lists the loaded kernel modules (Linux), kernel extensions (macOS) or
+// kernel drivers (Windows)
+type DriverData struct {
+	Drivers []KernelDriver `json:"drivers"`
+	Tainted []string       `json:"tainted,omitempty"` // Why the Linux kernel is tainted, e.g. "proprietary module"
+}
+
+// KernelDriver is a loaded kernel module, kernel extension or driver
+type KernelDriver struct {
+	Name        string   `json:"name"`                  // Module name, kext bundle ID or driver service name
+	Description string   `json:"description,omitempty"` // Display name (Windows)
+	Version     string   `json:"version,omitempty"`     // Where the module declares one; the file version on Windows
+	SizeBytes   uint64   `json:"size_bytes,omitempty"`  // Memory taken by the module's code and data (Linux, macOS)
+	RefCount    int      `json:"ref_count,omitempty"`   // Users holding a reference (Linux, macOS)
+	UsedBy      []string `json:"used_by,omitempty"`     // Modules depending on this one (Linux)
+	State       string   `json:"state,omitempty"`       // Live, Loading, Unloading (Linux)
+	Taint       string   `json:"taint,omitempty"`       // Taint flags, e.g. POE for an out-of-tree proprietary module (Linux)
+	Path        string   `json:"path,omitempty"`        // Driver file (Windows)
+}
+
+// ServiceData summarizes the services of the service manager: systemd units on Linux, the
+// service control manager on Windows and launchd jobs on macOS
+type ServiceData struct {
+	Manager        string          `json:"manager"` // systemd, scm or launchd
+	Total          int             `json:"total"`
+	Running        int             `json:"running"`
+	Failed         int             `json:"failed"`
+	FailedServices []ServiceStatus `json:"failed_services,omitempty"`
+}
+
+// ServiceStatus is a service and the state it is in
+type ServiceStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state"`            // e.g. failed (systemd), Stopped (Windows)
+	Reason      string `json:"reason,omitempty"` // e.g. "exit-code 1", "exit code 1067", "signal 9"
+}
+
+// FirewallData is whether the host filters incoming traffic, and the firewalls it has
+type FirewallData struct {
+	Active    bool       `json:"active"` // Whether any firewall is filtering
+	Firewalls []Firewall `json:"firewalls"`
+}
+
+// Firewall is one firewall or firewall front end and a summary of its rules
+type Firewall struct {
+	Name            string         `json:"name"` // ufw, firewalld, nftables, iptables, pf, Application Firewall, Windows Firewall
+	Active          bool           `json:"active"`
+	DefaultIncoming string         `json:"default_incoming,omitempty"` // allow, deny or reject
+	Rules           *int           `json:"rules,omitempty"`            // Nil where the rules are not readable, usually without root
+	Zones           []FirewallZone `json:"zones,omitempty"`            // firewalld zones, Windows Firewall profiles
+}
+
+// FirewallZone is a firewalld zone or Windows Firewall profile
+type FirewallZone struct {
+	Name            string   `json:"name"`
+	Active          bool     `json:"active"` // A zone bound to interfaces or sources, a profile switched on
+	DefaultIncoming string   `json:"default_incoming,omitempty"`
+	Interfaces      []string `json:"interfaces,omitempty"`
+	Rules           int      `json:"rules"`
+}
+
+// PackageData is the inventory of installed software
+type PackageData struct {
+	Managers []string           `json:"managers"` // Package databases the inventory was read from
+	Packages []InstalledPackage `json:"packages"`
+}
+
+// InstalledPackage is a package or program installed on the system
+type InstalledPackage struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Arch      string `json:"arch,omitempty"`
+	Manager   string `json:"manager"`             // dpkg, rpm, pacman, homebrew, homebrew-cask, msi or programs
+	Publisher string `json:"publisher,omitempty"` // Windows
+}
+
+// UserData lists the sessions of logged-in users and counts the local accounts
+type UserData struct {
+	Sessions      []UserSession `json:"sessions"`
+	LocalAccounts int           `json:"local_accounts"`
+	UserAccounts  int           `json:"user_accounts"` // Accounts for people rather than services: UIDs in the login.defs range (Linux), UID 501 and up without a leading _ (macOS), enabled accounts (Windows)
+}
+
+// UserSession is a logged-in user's session
+type UserSession struct {
+	User       string    `json:"user"`
+	Terminal   string    `json:"terminal,omitempty"`    // e.g. pts/0, console, RDP-Tcp#0
+	RemoteHost string    `json:"remote_host,omitempty"` // Host the user logged in from; empty for local logins
+	LoginTime  time.Time `json:"login_time"`
+	State      string    `json:"state,omitempty"` // active or disconnected (Windows)
+}
+
+// IntegrityData holds the checksums of critical binaries and configuration files, for
+// spotting drift and tampering by comparing reports across hosts or over time
+type IntegrityData struct {
+	Files []FileChecksum `json:"files"`
+}
+
+// FileChecksum is a file's SHA-256 and permissions, or why it could not be read
+type FileChecksum struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	Mode   string `json:"mode,omitempty"`  // e.g. -rwxr-xr-x, with a leading u for setuid
+	Error  string `json:"error,omitempty"` // e.g. not found or permission denied
+}
+
+// ThermalData ties platform thermal zones and device temperatures to their trip thresholds
+type ThermalData struct {
+	CoolingPolicyAC string          `json:"cooling_policy_ac,omitempty"` // active, passive (Windows power plan)
+	CoolingPolicyDC string          `json:"cooling_policy_dc,omitempty"` // Policy on battery
+	Sensors         []ThermalSensor `json:"sensors"`
+	Fans            []FanInfo       `json:"fans,omitempty"`
+	Noise           *NoiseEstimate  `json:"noise,omitempty"` // Estimated from fan speeds
+}
+
+// FanInfo is one fan tachometer reading and the limits its controller sets
+type FanInfo struct {
+	Name   string `json:"name"`             // Label, or chip/fanN when the fan is unlabelled
+	Chip   string `json:"chip,omitempty"`   // hwmon chip (nct6798, thinkpad), SMC, or the monitored component
+	Source string `json:"source,omitempty"` // hwmon, smc, librehardwaremonitor, openhardwaremonitor
+	RPM    int    `json:"rpm"`
+	MinRPM int    `json:"min_rpm,omitempty"`     // hwmon: alarm threshold; SMC: lowest target speed
+	MaxRPM int    `json:"max_rpm,omitempty"`     // Highest speed the controller drives the fan at
+	PWM    int    `json:"pwm_percent,omitempty"` // Duty cycle the fan is driven at
+	Alarm  bool   `json:"alarm,omitempty"`       // The chip flags the fan as stalled or too slow
+}
+
+// NoiseEstimate is an approximate sound pressure level derived from fan speeds
+type NoiseEstimate struct {
+	TotalDBA      float64    `json:"total_dba"` // All fans combined
+	Fans          []FanNoise `json:"fans"`
+	Trend         string     `json:"trend,omitempty"`            // increasing, stable, decreasing (from history)
+	TrendDBPerDay float64    `json:"trend_db_per_day,omitempty"` // Slope of the total over the history window
+}
+
+// FanNoise is the estimated contribution of one fan
+type FanNoise struct {
+	Name  string  `json:"name"`
+	RPM   int     `json:"rpm"`
+	DBA   float64 `json:"dba"`
+	Model string  `json:"model"` // Fan model the estimate is based on
+}
+
+// ThermalSensor is one temperature reading and the thresholds configured for it
+type ThermalSensor struct {
+	Name        string      `json:"name"`                // thermal_zone0, \_TZ.CPUZ, GPU 0, /dev/sda
+	Component   string      `json:"component"`           // CPU, GPU, Disk, Chipset, Wireless, Battery, ACPI, Other
+	Source      string      `json:"source"`              // sysfs, acpi, nvidia-smi, smart
+	Type        string      `json:"type,omitempty"`      // Zone type, e.g. x86_pkg_temp, acpitz
+	Temperature float64     `json:"temperature_celsius"` // Current reading
+	Policy      string      `json:"policy,omitempty"`    // Linux thermal governor, e.g. step_wise
+	TripPoints  []TripPoint `json:"trip_points,omitempty"`
+}
+
+// SensorsData holds the temperature inputs of hardware monitoring chips: hwmon on Linux, the
+// SMC on macOS, OpenHardwareMonitor (or ACPI thermal zones without it) on Windows
+type SensorsData struct {
+	Temperatures []TemperatureSensor `json:"temperatures"`
+}
+
+// TemperatureSensor is one temperature input of a monitoring chip
+type TemperatureSensor struct {
+	Chip        string  `json:"chip"`                       // coretemp, k10temp, nct6798, nvme, SMC, CPU name on Windows
+	Label       string  `json:"label"`                      // Package id 0, Tctl, Core 3, temp1
+	Source      string  `json:"source"`                     // hwmon, smc, openhardwaremonitor, librehardwaremonitor, acpi
+	Temperature float64 `json:"temperature_celsius"`        // Current reading
+	Min         float64 `json:"min_celsius,omitempty"`      // Low alarm limit; lowest reading seen with OpenHardwareMonitor
+	Max         float64 `json:"max_celsius,omitempty"`      // High alarm limit; highest reading seen with OpenHardwareMonitor
+	Critical    float64 `json:"critical_celsius,omitempty"` // Critical limit
+}
+
+// TripPoint is a temperature at which the platform or device takes action
+type TripPoint struct {
+	Type        string  `json:"type"` // active, passive, hot, critical, slowdown, shutdown, high
+	Temperature float64 `json:"temperature_celsius"`
+}
+
+// SecurityData contains operating system security and compliance posture
+type SecurityData struct {
+	SIP        string          `json:"sip,omitempty"`         // System Integrity Protection: enabled, disabled, custom (macOS)
+	Gatekeeper string          `json:"gatekeeper,omitempty"`  // enabled, disabled (macOS)
+	FileVault  string          `json:"filevault,omitempty"`   // on, off, encrypting, decrypting (macOS)
+	MDM        *MDMEnrollment  `json:"mdm,omitempty"`         // Device management enrollment (macOS)
+	SELinux    *SELinuxStatus  `json:"selinux,omitempty"`     // Linux
+	AppArmor   *AppArmorStatus `json:"apparmor,omitempty"`    // Linux
+	TrustStore *TrustStore     `json:"trust_store,omitempty"` // System CA certificates
+}
+
+// TrustStore summarizes the certificate authorities the system trusts
+type TrustStore struct {
+	Source       string          `json:"source"`                  // Bundle, keychain or registry store the roots were read from
+	Total        int             `json:"total"`                   // Trusted CA certificates, including locally added ones
+	Expired      int             `json:"expired"`                 // Trusted CAs past their expiry date
+	ExpiringSoon []CACertificate `json:"expiring_soon,omitempty"` // Trusted CAs expiring within 90 days
+	Local        []CACertificate `json:"local,omitempty"`         // CAs added by an administrator or user rather than shipped with the OS
+}
+
+// CACertificate identifies a trusted certificate authority
+type CACertificate struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer,omitempty"` // Omitted for self-signed roots
+	NotAfter time.Time `json:"not_after"`
+	SHA256   string    `json:"sha256"`           // Fingerprint of the DER certificate
+	Source   string    `json:"source,omitempty"` // File, keychain or store the certificate was added to
+}
+
+// MDMEnrollment contains mobile device management enrollment status
+type MDMEnrollment struct {
+	Enrolled     bool `json:"enrolled"`
+	UserApproved bool `json:"user_approved"` // Enrollment approved by the user or via DEP
+	DEP          bool `json:"dep"`           // Enrolled via Automated Device Enrollment (DEP/ABM)
+}
+
+// SELinuxStatus contains SELinux enforcement status
+type SELinuxStatus struct {
+	Mode          string `json:"mode"`                     // enforcing, permissive, disabled
+	ConfigMode    string `json:"config_mode,omitempty"`    // Mode configured in /etc/selinux/config (applies at boot)
+	Policy        string `json:"policy,omitempty"`         // targeted, mls, minimum, ...
+	PolicyVersion int    `json:"policy_version,omitempty"` // Loaded kernel policy version
+}
+
+// AppArmorStatus contains AppArmor enforcement status
+type AppArmorStatus struct {
+	Enabled  bool `json:"enabled"`
+	Profiles int  `json:"profiles"`        // Loaded profiles (requires root to read)
+	Enforce  int  `json:"enforce"`         // Profiles in enforce mode
+	Complain int  `json:"complain"`        // Profiles in complain (log-only) mode
+	Other    int  `json:"other,omitempty"` // Profiles in kill/unconfined/prompt modes
 }
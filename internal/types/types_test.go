@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -49,6 +50,64 @@ func TestSystemInfoMarshaling(t *testing.T) {
 	}
 }
 
+func TestSystemInfoTimestampFormat(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 14, 30, 0, 123, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"", `"timestamp":"2024-03-05T14:30:00.000000123Z"`},
+		{"rfc3339", `"timestamp":"2024-03-05T14:30:00Z"`},
+		{"unix", `"timestamp":1709649000`},
+	}
+
+	for _, tt := range tests {
+		info := SystemInfo{Timestamp: ts, TimestampFormat: tt.format, System: &SystemData{Hostname: "host"}}
+		data, err := json.Marshal(info)
+		if err != nil {
+			t.Fatalf("Marshal with format %q failed: %v", tt.format, err)
+		}
+		if !strings.Contains(string(data), tt.expected) {
+			t.Errorf("format %q: %s does not contain %s", tt.format, data, tt.expected)
+		}
+		if !strings.Contains(string(data), `"hostname":"host"`) {
+			t.Errorf("format %q: other fields missing from %s", tt.format, data)
+		}
+	}
+
+	data, err := json.Marshal(&SystemInfo{Timestamp: ts, TimestampFormat: "none"})
+	if err != nil {
+		t.Fatalf("Marshal with format none failed: %v", err)
+	}
+	if strings.Contains(string(data), "timestamp") {
+		t.Errorf("format none should omit the timestamp, got %s", data)
+	}
+}
+
+func TestSystemInfoFields(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	info := SystemInfo{
+		Timestamp:       ts,
+		TimestampFormat: "unix",
+		System:          &SystemData{Hostname: "host", OS: "linux"},
+		Disk: &DiskData{Partitions: []PartitionInfo{
+			{Device: "/dev/sda1", MountPoint: "/"},
+			{Device: "/dev/sda2", MountPoint: "/home"},
+		}},
+		Fields: FieldSet{"system": {"hostname": nil}, "disk": {"partitions": {"mount_point": nil}}},
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("Marshal with fields failed: %v", err)
+	}
+	expected := `{"timestamp":1709649000,"system":{"hostname":"host"},"disk":{"partitions":[{"mount_point":"/"},{"mount_point":"/home"}]}}`
+	if string(data) != expected {
+		t.Errorf("Marshal with fields = %s, expected %s", data, expected)
+	}
+}
+
 func TestCPUDataMarshaling(t *testing.T) {
 	cpu := &CPUData{
 		ModelName:   "Intel Core i7",
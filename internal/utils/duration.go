@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseDuration parses history periods such as 24h, 7d, 2w or 1m (30 days)
+// Anything without a period suffix falls back to time.ParseDuration
+func ParseDuration(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid duration format")
+	}
+
+	unit := s[len(s)-1]
+	value := s[:len(s)-1]
+
+	var multiplier time.Duration
+	switch unit {
+	case 'h':
+		multiplier = time.Hour
+	case 'd':
+		multiplier = 24 * time.Hour
+	case 'w':
+		multiplier = 7 * 24 * time.Hour
+	case 'm':
+		multiplier = 30 * 24 * time.Hour
+	default:
+		return time.ParseDuration(s)
+	}
+
+	var num int
+	if _, err := fmt.Sscanf(value, "%d", &num); err != nil {
+		return 0, fmt.Errorf("invalid number in duration: %w", err)
+	}
+
+	return time.Duration(num) * multiplier, nil
+}
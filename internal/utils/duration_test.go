@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"1h", "1h0m0s", false},
+		{"24h", "24h0m0s", false},
+		{"1d", "24h0m0s", false},
+		{"7d", "168h0m0s", false},
+		{"30d", "720h0m0s", false},
+		{"1w", "168h0m0s", false},
+		{"1m", "720h0m0s", false},
+		{"invalid", "", true},
+		{"", "", true},
+		{"x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			duration, err := ParseDuration(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for input %q, got nil", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error for input %q: %v", tt.input, err)
+				return
+			}
+
+			if duration.String() != tt.expected {
+				t.Errorf("For input %q: expected %s, got %s", tt.input, tt.expected, duration.String())
+			}
+		})
+	}
+}
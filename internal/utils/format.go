@@ -1,20 +1,68 @@
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Byte unit systems accepted by --units
+const (
+	ByteUnitsBinary  = "binary"  // 1024-based, labelled KiB, MiB, GiB
+	ByteUnitsDecimal = "decimal" // 1000-based, labelled KB, MB, GB
+)
+
+// byteUnits is the unit system FormatBytes uses; empty keeps the original 1024-based
+// sizes labelled KB, MB, GB
+var byteUnits atomic.Value
+
+// ValidateByteUnits rejects unknown --units values; empty keeps the default
+func ValidateByteUnits(units string) error {
+	switch units {
+	case "", ByteUnitsBinary, ByteUnitsDecimal:
+		return nil
+	default:
+		return fmt.Errorf("invalid byte units: %s (expected binary or decimal)", units)
+	}
+}
+
+// SetByteUnits selects the unit system of every size FormatBytes writes from now on, in
+// reports' formatted fields as well as text output
+func SetByteUnits(units string) {
+	byteUnits.Store(units)
+}
 
 // FormatBytes converts bytes to human-readable format
 func FormatBytes(bytes uint64) string {
-	const unit = 1024
+	unit, names := uint64(1024), []string{"KB", "MB", "GB", "TB", "PB", "EB"}
+	switch system, _ := byteUnits.Load().(string); system {
+	case ByteUnitsBinary:
+		names = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	case ByteUnitsDecimal:
+		unit = 1000
+	}
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
 	}
 
-	div, exp := uint64(unit), 0
+	div, exp := unit, 0
 	for n := bytes / unit; n >= unit; n /= unit {
 		div *= unit
 		exp++
 	}
 
-	units := []string{"KB", "MB", "GB", "TB", "PB", "EB"}
-	return fmt.Sprintf("%.2f %s", float64(bytes)/float64(div), units[exp])
+	return fmt.Sprintf("%.2f %s", float64(bytes)/float64(div), names[exp])
+}
+
+// Truncate shortens s to at most width characters, ending in "..." when cut. It counts and
+// cuts whole runes, so multibyte names are never split mid-character
+func Truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 3 {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-3]) + "..."
 }
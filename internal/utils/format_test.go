@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"unicode/utf8"
 )
 
 func TestFormatBytes(t *testing.T) {
@@ -82,6 +83,35 @@ func TestFormatBytes(t *testing.T) {
 	}
 }
 
+func TestFormatBytesUnits(t *testing.T) {
+	defer SetByteUnits("")
+
+	tests := []struct {
+		units    string
+		bytes    uint64
+		expected string
+	}{
+		{ByteUnitsBinary, 1023, "1023 B"},
+		{ByteUnitsBinary, 1536, "1.50 KiB"},
+		{ByteUnitsBinary, 1024 * 1024 * 1024 * 5, "5.00 GiB"},
+		{ByteUnitsDecimal, 999, "999 B"},
+		{ByteUnitsDecimal, 1500, "1.50 KB"},
+		{ByteUnitsDecimal, 500_000_000_000, "500.00 GB"},
+		{ByteUnitsDecimal, 1024 * 1024 * 1024, "1.07 GB"},
+		{"", 1024 * 1024 * 1024, "1.00 GB"},
+	}
+	for _, tt := range tests {
+		SetByteUnits(tt.units)
+		if result := FormatBytes(tt.bytes); result != tt.expected {
+			t.Errorf("FormatBytes(%d) with %q units = %q; want %q", tt.bytes, tt.units, result, tt.expected)
+		}
+	}
+
+	if err := ValidateByteUnits("si"); err == nil {
+		t.Error("ValidateByteUnits(si) succeeded, expected an error")
+	}
+}
+
 func BenchmarkFormatBytes(b *testing.B) {
 	testValues := []uint64{
 		0,
@@ -99,3 +129,29 @@ func BenchmarkFormatBytes(b *testing.B) {
 		})
 	}
 }
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 8, "hello..."},
+		{"日本語のプロセス名", 9, "日本語のプロセス名"},
+		{"日本語のプロセス名", 6, "日本語..."},
+		{"🚀rocket-launcher", 8, "🚀rock..."},
+		{"hello", 2, "he"},
+		{"hello", 0, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.input, tt.width)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q; want %q", tt.input, tt.width, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) = %q is not valid UTF-8", tt.input, tt.width, got)
+		}
+	}
+}
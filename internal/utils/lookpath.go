@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// lookPathTTL bounds how long a lookup is reused, so tools installed while
+// a long-running agent is up are still picked up
+const lookPathTTL = 10 * time.Minute
+
+type lookPathResult struct {
+	path    string
+	err     error
+	expires time.Time
+}
+
+var (
+	lookPathMu    sync.Mutex
+	lookPathCache = make(map[string]lookPathResult)
+)
+
+// LookPath is exec.LookPath with results cached for lookPathTTL
+// Collectors check for external tools on every collection, which adds up in long-running modes
+func LookPath(name string) (string, error) {
+	now := time.Now()
+
+	lookPathMu.Lock()
+	result, ok := lookPathCache[name]
+	lookPathMu.Unlock()
+	if ok && now.Before(result.expires) {
+		return result.path, result.err
+	}
+
+	path, err := exec.LookPath(name)
+
+	lookPathMu.Lock()
+	lookPathCache[name] = lookPathResult{path: path, err: err, expires: now.Add(lookPathTTL)}
+	lookPathMu.Unlock()
+
+	return path, err
+}
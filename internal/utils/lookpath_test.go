@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookPathCachesResults(t *testing.T) {
+	const missing = "sysinfo-test-command-that-does-not-exist"
+
+	if _, err := LookPath(missing); err == nil {
+		t.Fatalf("LookPath(%s) succeeded, expected an error", missing)
+	}
+
+	lookPathMu.Lock()
+	result, ok := lookPathCache[missing]
+	lookPathMu.Unlock()
+	if !ok {
+		t.Fatal("LookPath did not cache the result")
+	}
+	if result.err == nil {
+		t.Error("cached result should keep the lookup error")
+	}
+
+	// An expired entry is looked up again
+	lookPathMu.Lock()
+	lookPathCache[missing] = lookPathResult{path: "/stale", expires: time.Now().Add(-time.Second)}
+	lookPathMu.Unlock()
+	if path, err := LookPath(missing); err == nil || path == "/stale" {
+		t.Errorf("LookPath returned stale result %q, %v", path, err)
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp formats accepted by --timestamp-format
+const (
+	TimestampRFC3339 = "rfc3339"
+	TimestampUnix    = "unix"
+	TimestampNone    = "none"
+)
+
+// ValidateTimestampFormat rejects unknown --timestamp-format values; empty keeps each output's default
+func ValidateTimestampFormat(format string) error {
+	switch format {
+	case "", TimestampRFC3339, TimestampUnix, TimestampNone:
+		return nil
+	default:
+		return fmt.Errorf("invalid timestamp format: %s (expected rfc3339, unix or none)", format)
+	}
+}
+
+// FormatTimestamp renders t in the given format, using layout when no format is set
+// Returns an empty string for "none"
+func FormatTimestamp(t time.Time, format, layout string) string {
+	switch format {
+	case TimestampRFC3339:
+		return t.Format(time.RFC3339)
+	case TimestampUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimestampNone:
+		return ""
+	default:
+		return t.Format(layout)
+	}
+}
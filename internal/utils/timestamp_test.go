@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"", "2024-03-05 14:30"},
+		{TimestampRFC3339, "2024-03-05T14:30:00Z"},
+		{TimestampUnix, "1709649000"},
+		{TimestampNone, ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTimestamp(ts, tt.format, "2006-01-02 15:04"); got != tt.expected {
+			t.Errorf("FormatTimestamp(%q) = %q, expected %q", tt.format, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateTimestampFormat(t *testing.T) {
+	for _, format := range []string{"", "rfc3339", "unix", "none"} {
+		if err := ValidateTimestampFormat(format); err != nil {
+			t.Errorf("ValidateTimestampFormat(%q) = %v, expected nil", format, err)
+		}
+	}
+	if err := ValidateTimestampFormat("iso"); err == nil {
+		t.Error("ValidateTimestampFormat(iso) = nil, expected an error")
+	}
+}
@@ -1,15 +1,29 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/mayvqt/sysinfo/cmd"
+	"github.com/mayvqt/sysinfo/internal/sandbox"
 )
 
 func main() {
+	// Re-executed as the Landlock wrapper for an external tool: restrict and exec it
+	if len(os.Args) > 1 && os.Args[1] == sandbox.ExecArg {
+		if err := sandbox.RunExec(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(126)
+	}
+
 	if err := cmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
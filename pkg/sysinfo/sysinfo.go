@@ -0,0 +1,73 @@
+// Package sysinfo is the public Go API of the collector, for programs that embed it instead of
+// running the sysinfo command and parsing its output. It also backs the C bindings in
+// bindings/c, which expose the same calls to Python, Node and other languages
+package sysinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mayvqt/sysinfo/internal/collector"
+	"github.com/mayvqt/sysinfo/internal/config"
+	"github.com/mayvqt/sysinfo/internal/types"
+)
+
+// Report is a collected report, the document the json format writes
+type Report = types.SystemInfo
+
+// Options select what is collected and how the report is written
+type Options struct {
+	Modules []string `json:"modules,omitempty"` // Modules to collect, "all" for every module (default: all but the opt-in integrity, packages and timesync)
+	Redact  bool     `json:"redact,omitempty"`  // Mask serial numbers, MAC and IP addresses, the hostname and UUIDs
+	UTC     bool     `json:"utc,omitempty"`     // Report timestamps in UTC instead of local time
+}
+
+// collectMu serializes collections: collectors sample counters and keep state between
+// calls, so concurrent collections would skew each other's readings
+var collectMu sync.Mutex
+
+// Collect gathers a report of the selected modules. Modules that fail are left out of the
+// report rather than failing the collection; an unknown module name is an error
+func Collect(opts Options) (*Report, error) {
+	cfg := config.NewConfig()
+	if len(opts.Modules) > 0 {
+		cfg.Modules = config.ModuleConfig{}
+		for _, module := range opts.Modules {
+			if err := cfg.Modules.Enable(strings.ToLower(strings.TrimSpace(module))); err != nil {
+				return nil, err
+			}
+		}
+	}
+	cfg.Redact = opts.Redact
+	cfg.UTC = opts.UTC
+
+	collectMu.Lock()
+	info, err := collector.Collect(cfg)
+	collectMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect system information: %w", err)
+	}
+	if cfg.UTC {
+		collector.UseUTC(info)
+	}
+	if cfg.Redact {
+		collector.Redact(info)
+	}
+	return info, nil
+}
+
+// CollectJSON gathers a report and encodes it as compact JSON, the document the json format writes
+func CollectJSON(opts Options) ([]byte, error) {
+	info, err := Collect(opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(info)
+}
+
+// Modules lists the module names Options.Modules accepts, besides "all"
+func Modules() []string {
+	return append([]string(nil), config.ModuleNames...)
+}
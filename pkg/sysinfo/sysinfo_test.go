@@ -0,0 +1,47 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	info, err := Collect(Options{Modules: []string{"Memory"}, UTC: true})
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if info.Memory == nil || info.CPU != nil || info.System != nil {
+		t.Errorf("Collect(memory) = %+v, expected only memory", info)
+	}
+	if info.Timestamp.Location().String() != "UTC" {
+		t.Errorf("Timestamp = %s, expected UTC", info.Timestamp)
+	}
+
+	if _, err := Collect(Options{Modules: []string{"floppy"}}); err == nil {
+		t.Error("Collect(floppy) succeeded, expected an unknown module error")
+	}
+}
+
+func TestCollectJSON(t *testing.T) {
+	data, err := CollectJSON(Options{Modules: []string{"memory"}})
+	if err != nil {
+		t.Fatalf("CollectJSON failed: %v", err)
+	}
+	var report map[string]any
+	if err := json.Unmarshal(data, &report); err != nil || report["memory"] == nil || report["timestamp"] == nil {
+		t.Errorf("CollectJSON() = %s, %v; expected a report with memory", data, err)
+	}
+}
+
+func TestModules(t *testing.T) {
+	modules := Modules()
+	if !slices.Contains(modules, "cpu") || slices.Contains(modules, "all") {
+		t.Errorf("Modules() = %v, expected the module names without all", modules)
+	}
+	// Callers get a copy
+	modules[0] = "changed"
+	if Modules()[0] == "changed" {
+		t.Error("Modules() returned the shared slice")
+	}
+}